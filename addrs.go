@@ -0,0 +1,43 @@
+package p2pd
+
+import (
+	ma "github.com/multiformats/go-multiaddr"
+
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// doListAddrs reports the addresses the host advertises at the time of the
+// request, which unlike the ones logged at startup include the external
+// addresses learned since and the circuit addresses of the relays picked by
+// autorelay.
+func (d *Daemon) doListAddrs(req *pb.Request) *pb.Response {
+	listen := make(map[string]struct{})
+	ifaceAddrs, err := d.host.Network().InterfaceListenAddresses()
+	if err != nil {
+		return errorResponse(err)
+	}
+	for _, addr := range ifaceAddrs {
+		listen[string(addr.Bytes())] = struct{}{}
+	}
+
+	addrs := &pb.AddrsResponse{}
+	for _, addr := range d.host.Addrs() {
+		switch {
+		case isRelayAddr(addr):
+			addrs.Relay = append(addrs.Relay, addr.Bytes())
+		case isListenAddr(listen, addr):
+			addrs.Listen = append(addrs.Listen, addr.Bytes())
+		default:
+			addrs.Observed = append(addrs.Observed, addr.Bytes())
+		}
+	}
+
+	res := okResponse()
+	res.Addrs = addrs
+	return res
+}
+
+func isListenAddr(listen map[string]struct{}, addr ma.Multiaddr) bool {
+	_, ok := listen[string(addr.Bytes())]
+	return ok
+}
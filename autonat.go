@@ -0,0 +1,127 @@
+package p2pd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/libp2p/go-libp2p-core/event"
+	"github.com/libp2p/go-libp2p-core/network"
+
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+
+	autonat "github.com/libp2p/go-libp2p-autonat"
+)
+
+// errNoAutoNATServer is returned by a probe when no connected peer runs an
+// AutoNAT server that answers.
+var errNoAutoNATServer = errors.New("no connected peer runs an AutoNAT server")
+
+// trackReachability keeps the reachability last reported by AutoNAT, which the
+// host doesn't expose, until the daemon is closed. The event is stateful, so
+// the subscription starts with the current value.
+func (d *Daemon) trackReachability() error {
+	sub, err := d.host.EventBus().Subscribe(new(event.EvtLocalReachabilityChanged))
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		defer sub.Close()
+
+		for {
+			select {
+			case evt, ok := <-sub.Out():
+				if !ok {
+					return
+				}
+				reachability := evt.(event.EvtLocalReachabilityChanged).Reachability
+
+				// the private reachability is emitted again by
+				// RefreshRelays and KeepStaticRelays
+				d.mx.Lock()
+				changed := d.reachability != reachability
+				d.reachability = reachability
+				d.mx.Unlock()
+				if changed {
+					log.Infow("reachability changed", "reachability", reachability)
+				}
+
+			case <-d.ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Reachability returns the reachability last reported by AutoNAT.
+func (d *Daemon) Reachability() network.Reachability {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	return d.reachability
+}
+
+func (d *Daemon) doAutoNAT(req *pb.Request) *pb.Response {
+	reachability := pb.Event_Reachability(d.Reachability())
+	info := &pb.AutoNATResponse{Reachability: &reachability}
+
+	if req.AutoNAT.GetProbe() {
+		ctx, cancel := d.requestContext(req.AutoNAT.GetTimeout())
+		defer cancel()
+
+		probe, err := d.probeReachability(ctx)
+		if err != nil {
+			return errorResponse(err)
+		}
+		info.Probe = probe
+	}
+
+	res := okResponse()
+	res.AutoNAT = info
+	return res
+}
+
+// probeReachability asks the connected AutoNAT servers in turn to dial the
+// host back, until one of them tells whether it is reachable. The result
+// doesn't change the reachability reported by AutoNAT, which keeps probing on
+// its own schedule.
+func (d *Daemon) probeReachability(ctx context.Context) (*pb.AutoNATProbe, error) {
+	client := autonat.NewAutoNATClient(d.host, nil)
+
+	lastErr := errNoAutoNATServer
+	for _, p := range d.host.Network().Peers() {
+		protos, err := d.host.Peerstore().SupportsProtocols(p, autonat.AutoNATProto)
+		if err != nil || len(protos) == 0 {
+			continue
+		}
+
+		addr, err := client.DialBack(ctx, p)
+		switch {
+		case err == nil:
+			reachability := pb.Event_PUBLIC
+			return &pb.AutoNATProbe{
+				Reachability: &reachability,
+				Peer:         []byte(p),
+				Addr:         addr.Bytes(),
+			}, nil
+
+		case autonat.IsDialError(err):
+			reachability := pb.Event_PRIVATE
+			return &pb.AutoNATProbe{
+				Reachability: &reachability,
+				Peer:         []byte(p),
+			}, nil
+		}
+
+		log.Debugw("error probing reachability", "peer", p, "error", err)
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("probing reachability: %w", ctx.Err())
+		}
+		lastErr = fmt.Errorf("AutoNAT server %s: %w", p.Pretty(), err)
+	}
+
+	return nil, lastErr
+}
@@ -0,0 +1,80 @@
+package p2pd
+
+import (
+	"sort"
+
+	"github.com/libp2p/go-libp2p-core/metrics"
+	"github.com/libp2p/go-libp2p-core/peer"
+
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// SetBandwidthReporter makes BANDWIDTH requests report the stats of bwc,
+// which must be the reporter the host was constructed with, e.g. through
+// libp2p.BandwidthReporter. BANDWIDTH requests fail until it is set.
+func (d *Daemon) SetBandwidthReporter(bwc metrics.Reporter) {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	d.bwc = bwc
+}
+
+func bandwidthStatsToPb(s metrics.Stats) *pb.BandwidthStats {
+	return &pb.BandwidthStats{
+		TotalIn:  &s.TotalIn,
+		TotalOut: &s.TotalOut,
+		RateIn:   &s.RateIn,
+		RateOut:  &s.RateOut,
+	}
+}
+
+func (d *Daemon) doBandwidth(req *pb.Request) *pb.Response {
+	d.mx.Lock()
+	bwc := d.bwc
+	d.mx.Unlock()
+
+	if bwc == nil {
+		return errorResponseString("bandwidth metrics are not enabled")
+	}
+
+	bandwidth := &pb.BandwidthResponse{
+		Total: bandwidthStatsToPb(bwc.GetBandwidthTotals()),
+	}
+
+	breq := req.GetBandwidth()
+	switch {
+	case breq.GetPeer() != nil:
+		p, err := peer.IDFromBytes(breq.GetPeer())
+		if err != nil {
+			return errorResponse(err)
+		}
+		bandwidth.Peers = []*pb.PeerBandwidth{{
+			Peer:  []byte(p),
+			Stats: bandwidthStatsToPb(bwc.GetBandwidthForPeer(p)),
+		}}
+	case breq.GetByPeer():
+		for p, s := range bwc.GetBandwidthByPeer() {
+			bandwidth.Peers = append(bandwidth.Peers, &pb.PeerBandwidth{
+				Peer:  []byte(p),
+				Stats: bandwidthStatsToPb(s),
+			})
+		}
+	}
+
+	if breq.GetByProtocol() {
+		for proto, s := range bwc.GetBandwidthByProtocol() {
+			name := string(proto)
+			bandwidth.Protocols = append(bandwidth.Protocols, &pb.ProtocolBandwidth{
+				Proto: &name,
+				Stats: bandwidthStatsToPb(s),
+			})
+		}
+		sort.Slice(bandwidth.Protocols, func(i, j int) bool {
+			return bandwidth.Protocols[i].GetProto() < bandwidth.Protocols[j].GetProto()
+		})
+	}
+
+	res := okResponse()
+	res.Bandwidth = bandwidth
+	return res
+}
@@ -9,15 +9,44 @@ import (
 	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/libp2p/go-libp2p-core/peerstore"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
 	dht "github.com/libp2p/go-libp2p-kad-dht"
+	ma "github.com/multiformats/go-multiaddr"
 )
 
+// BootstrapPeers is the bootstrap peer set of new daemons; it can be replaced
+// at runtime with SetBootstrapPeers.
 var BootstrapPeers = dht.DefaultBootstrapPeers
 
 const BootstrapConnections = 4
 
-func bootstrapPeerInfo() ([]peer.AddrInfo, error) {
-	return peer.AddrInfosFromP2pAddrs(BootstrapPeers...)
+func (d *Daemon) bootstrapPeerInfo() ([]peer.AddrInfo, error) {
+	d.bootstrapMx.Lock()
+	defer d.bootstrapMx.Unlock()
+
+	return peer.AddrInfosFromP2pAddrs(d.bootstrapPeers...)
+}
+
+// SetBootstrapPeers replaces the bootstrap peer set used by subsequent
+// bootstraps and by the background task that keeps connections to bootstrap
+// peers open. Every address must include the peer ID; the set is left
+// unchanged if any of them doesn't.
+func (d *Daemon) SetBootstrapPeers(addrs []ma.Multiaddr) error {
+	if len(addrs) == 0 {
+		return fmt.Errorf("bootstrap peer set can't be empty")
+	}
+	for _, addr := range addrs {
+		if _, err := peer.AddrInfoFromP2pAddr(addr); err != nil {
+			return fmt.Errorf("invalid bootstrap peer %s: %w", addr, err)
+		}
+	}
+
+	d.bootstrapMx.Lock()
+	d.bootstrapPeers = append([]ma.Multiaddr(nil), addrs...)
+	d.bootstrapMx.Unlock()
+
+	log.Infow("replaced bootstrap peers", "count", len(addrs))
+	return nil
 }
 
 func shufflePeerInfos(peers []peer.AddrInfo) {
@@ -27,8 +56,24 @@ func shufflePeerInfos(peers []peer.AddrInfo) {
 	}
 }
 
+// Bootstrap connects to the bootstrap peers and bootstraps the DHT, if
+// enabled. It fails right away if another bootstrap is in progress.
 func (d *Daemon) Bootstrap() error {
-	pis, err := bootstrapPeerInfo()
+	d.bootstrapMx.Lock()
+	if d.bootstrapping {
+		d.bootstrapMx.Unlock()
+		return fmt.Errorf("bootstrap already in progress")
+	}
+	d.bootstrapping = true
+	d.bootstrapMx.Unlock()
+
+	defer func() {
+		d.bootstrapMx.Lock()
+		d.bootstrapping = false
+		d.bootstrapMx.Unlock()
+	}()
+
+	pis, err := d.bootstrapPeerInfo()
 	if err != nil {
 		return err
 	}
@@ -41,13 +86,70 @@ func (d *Daemon) Bootstrap() error {
 	if count == 0 {
 		return fmt.Errorf("failed to connect to bootstrap peers")
 	}
+	log.Infow("connected to bootstrap peers", "count", count)
 
-	go d.keepBootstrapConnections(pis)
+	d.keepBootstrapOnce.Do(func() { go d.keepBootstrapConnections() })
 
 	if d.dht != nil {
-		return d.dht.Bootstrap(d.ctx)
+		if err := d.dht.Bootstrap(d.ctx); err != nil {
+			return err
+		}
 	}
 
+	d.bootstrapMx.Lock()
+	d.bootstrapped = true
+	d.bootstrapMx.Unlock()
+
+	return nil
+}
+
+// BootstrapRetryPolicy controls how BootstrapWithRetry retries when none of
+// the bootstrap peers can be reached.
+type BootstrapRetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts; zero or less retries
+	// until the daemon is closed.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+	// Multiplier scales the delay after every failed attempt.
+	Multiplier float64
+}
+
+// BootstrapWithRetry bootstraps the daemon in the background, retrying with
+// exponential backoff according to policy. It returns right away, so the
+// daemon can start serving clients before bootstrapping completes; an error
+// is only returned if the bootstrap peers can't be parsed.
+func (d *Daemon) BootstrapWithRetry(policy BootstrapRetryPolicy) error {
+	if _, err := d.bootstrapPeerInfo(); err != nil {
+		return err
+	}
+
+	go func() {
+		backoff := policy.InitialBackoff
+		for attempt := 1; ; attempt++ {
+			err := d.Bootstrap()
+			if err == nil {
+				log.Infow("bootstrap complete", "attempts", attempt)
+				return
+			}
+
+			bootstrapFailures.Inc()
+			log.Warnw("bootstrap attempt failed", "attempt", attempt, "error", err)
+
+			if policy.MaxAttempts > 0 && attempt >= policy.MaxAttempts {
+				log.Errorw("giving up on bootstrap", "attempts", attempt)
+				return
+			}
+
+			select {
+			case <-time.After(backoff):
+			case <-d.ctx.Done():
+				return
+			}
+			backoff = time.Duration(float64(backoff) * policy.Multiplier)
+		}
+	}()
+
 	return nil
 }
 
@@ -80,17 +182,60 @@ func (d *Daemon) connectBootstrapPeers(pis []peer.AddrInfo, toconnect int) int {
 
 }
 
-func (d *Daemon) keepBootstrapConnections(pis []peer.AddrInfo) {
+// keepBootstrapConnections periodically reconnects to the current bootstrap
+// peers when the daemon has too few connections.
+func (d *Daemon) keepBootstrapConnections() {
 	ticker := time.NewTicker(15 * time.Minute)
+	defer ticker.Stop()
 	for {
-		<-ticker.C
+		select {
+		case <-ticker.C:
+		case <-d.ctx.Done():
+			return
+		}
 
 		conns := d.host.Network().Conns()
 		if len(conns) >= BootstrapConnections {
 			continue
 		}
 
+		pis, err := d.bootstrapPeerInfo()
+		if err != nil {
+			log.Debugw("error parsing bootstrap peers", "error", err)
+			continue
+		}
+		for _, pi := range pis {
+			d.host.Peerstore().AddAddrs(pi.ID, pi.Addrs, peerstore.PermanentAddrTTL)
+		}
+
 		toconnect := BootstrapConnections - len(conns)
 		d.connectBootstrapPeers(pis, toconnect)
 	}
 }
+
+func (d *Daemon) doSetBootstrapPeers(req *pb.Request) *pb.Response {
+	if req.SetBootstrapPeers == nil {
+		return errorResponseString("Malformed request; missing parameters")
+	}
+
+	addrs := make([]ma.Multiaddr, len(req.SetBootstrapPeers.GetAddrs()))
+	for i, bs := range req.SetBootstrapPeers.GetAddrs() {
+		addr, err := ma.NewMultiaddrBytes(bs)
+		if err != nil {
+			return errorResponse(err)
+		}
+		addrs[i] = addr
+	}
+
+	if err := d.SetBootstrapPeers(addrs); err != nil {
+		return errorResponse(err)
+	}
+
+	if req.SetBootstrapPeers.GetBootstrap() {
+		if err := d.Bootstrap(); err != nil {
+			return errorResponse(err)
+		}
+	}
+
+	return okResponse()
+}
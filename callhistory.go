@@ -0,0 +1,110 @@
+package p2pd
+
+import (
+	"sync"
+	"time"
+
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// callHistory keeps the last unary calls made by clients in a ring, for
+// post-mortem inspection with CALL_HISTORY.
+type callHistory struct {
+	mx      sync.Mutex
+	records []*pb.CallRecord
+	// next is the index the next record is written at
+	next int
+	full bool
+}
+
+// SetCallHistorySize makes the daemon keep the last size unary calls made by
+// clients, with their peer, protocol, duration and result. A size of zero
+// disables the history, which is the default. Calls already recorded are
+// dropped.
+func (d *Daemon) SetCallHistorySize(size int) {
+	d.calls.mx.Lock()
+	defer d.calls.mx.Unlock()
+
+	d.calls.records = make([]*pb.CallRecord, size)
+	d.calls.next = 0
+	d.calls.full = false
+}
+
+func (h *callHistory) add(record *pb.CallRecord) {
+	h.mx.Lock()
+	defer h.mx.Unlock()
+
+	if len(h.records) == 0 {
+		return
+	}
+
+	h.records[h.next] = record
+	h.next = (h.next + 1) % len(h.records)
+	if h.next == 0 {
+		h.full = true
+	}
+}
+
+// list returns the recorded calls, oldest first, or nil if the history is
+// disabled.
+func (h *callHistory) list() []*pb.CallRecord {
+	h.mx.Lock()
+	defer h.mx.Unlock()
+
+	if len(h.records) == 0 {
+		return nil
+	}
+	if !h.full {
+		return append([]*pb.CallRecord{}, h.records[:h.next]...)
+	}
+	return append(append([]*pb.CallRecord{}, h.records[h.next:]...), h.records[:h.next]...)
+}
+
+func (h *callHistory) enabled() bool {
+	h.mx.Lock()
+	defer h.mx.Unlock()
+
+	return len(h.records) > 0
+}
+
+// recordCall adds a unary call to the history, given the response the client
+// got.
+func (d *Daemon) recordCall(call *pb.CallUnaryRequest, start time.Time, result string, resp *pb.PersistentConnectionResponse) {
+	if !d.calls.enabled() {
+		return
+	}
+
+	startTime, duration := start.UnixNano(), int64(time.Since(start))
+	proto := call.GetProto()
+	record := &pb.CallRecord{
+		Peer:      call.GetPeer(),
+		Proto:     &proto,
+		StartTime: &startTime,
+		Duration:  &duration,
+		Result:    &result,
+	}
+
+	if result != unaryCallSucceeded {
+		var errMsg string
+		if derr := resp.GetDaemonError(); derr != nil {
+			errMsg = derr.GetMessage()
+		} else if remoteErr := resp.GetCallUnaryResponse().GetError(); remoteErr != nil {
+			errMsg = string(remoteErr)
+		}
+		if errMsg != "" {
+			record.Error = &errMsg
+		}
+	}
+
+	d.calls.add(record)
+}
+
+func (d *Daemon) doCallHistory(req *pb.Request) *pb.Response {
+	if !d.calls.enabled() {
+		return errorResponseString("call history is disabled")
+	}
+
+	res := okResponse()
+	res.CallHistory = d.calls.list()
+	return res
+}
@@ -1,11 +1,19 @@
 package config
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"os"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/ipfs/go-cid"
+	logging "github.com/ipfs/go-log/v2"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/pnet"
+	ps "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/multiformats/go-multiaddr"
 )
 
@@ -14,32 +22,96 @@ type JSONMaddr struct {
 }
 
 func (jm *JSONMaddr) UnmarshalJSON(b []byte) error {
-	ma, err := multiaddr.NewMultiaddr(string(b))
-	if err != nil {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
 		return err
 	}
+	ma, err := multiaddr.NewMultiaddr(s)
+	if err != nil {
+		return fmt.Errorf("invalid multiaddr %q: %w", s, err)
+	}
 	*jm = JSONMaddr{ma}
 	return nil
 }
 
 type MaddrArray []multiaddr.Multiaddr
 
+// UnmarshalJSON decodes an array of multiaddr strings, or a single string
+// holding a comma separated list of them.
 func (maa *MaddrArray) UnmarshalJSON(b []byte) error {
-	maStrings := strings.Split(string(b), ",")
-	*maa = make(MaddrArray, len(maStrings))
-	for i, s := range strings.Split(string(b), ",") {
-		ma, err := multiaddr.NewMultiaddr(s)
-		if err != nil {
+	var maStrings []string
+	if err := json.Unmarshal(b, &maStrings); err != nil {
+		var s string
+		if json.Unmarshal(b, &s) != nil {
 			return err
 		}
-		(*maa)[i] = ma
+		if s != "" {
+			maStrings = strings.Split(s, ",")
+		}
 	}
+
+	addrs, err := parseMaddrs(maStrings)
+	if err != nil {
+		return err
+	}
+	*maa = addrs
 	return nil
 }
 
+// MaddrError tells which entry of a list of multiaddrs is invalid.
+type MaddrError struct {
+	// Field names the list, if known.
+	Field string
+	Index int
+	Addr  string
+	Err   error
+}
+
+func (e *MaddrError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("invalid multiaddr %q at index %d: %s", e.Addr, e.Index, e.Err)
+	}
+	return fmt.Sprintf("%s[%d]: invalid multiaddr %q: %s", e.Field, e.Index, e.Addr, e.Err)
+}
+
+func (e *MaddrError) Unwrap() error {
+	return e.Err
+}
+
+func parseMaddrs(maStrings []string) (MaddrArray, error) {
+	addrs := make(MaddrArray, len(maStrings))
+	for i, s := range maStrings {
+		ma, err := multiaddr.NewMultiaddr(strings.TrimSpace(s))
+		if err != nil {
+			return nil, &MaddrError{Index: i, Addr: s, Err: err}
+		}
+		addrs[i] = ma
+	}
+	return addrs, nil
+}
+
+// ParseMaddrs parses a comma separated list of multiaddrs, such as the value
+// of a command line flag. Errors name field and the index of the invalid
+// entry.
+func ParseMaddrs(field, s string) (MaddrArray, error) {
+	addrs, err := parseMaddrs(strings.Split(s, ","))
+	if err != nil {
+		err.(*MaddrError).Field = field
+		return nil, err
+	}
+	return addrs, nil
+}
+
+type BootstrapRetry struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	Multiplier     float64
+}
+
 type Bootstrap struct {
 	Enabled bool
 	Peers   MaddrArray
+	Retry   BootstrapRetry
 }
 
 type ConnectionManager struct {
@@ -49,26 +121,223 @@ type ConnectionManager struct {
 	GracePeriod   time.Duration
 }
 
+// InboundRateLimit limits the rate of inbound connections from each remote IP.
+type InboundRateLimit struct {
+	Enabled bool
+	// Connections is the number of connections allowed per Window.
+	Connections int
+	Window      time.Duration
+	// Burst is the number of connections allowed in a row.
+	Burst int
+}
+
+// KeepAlive pings persistent control connections on which nothing was
+// received for Interval, and closes them after MaxMissed pings in a row go
+// unanswered. It is disabled if Interval is zero.
+type KeepAlive struct {
+	Interval  time.Duration
+	MaxMissed int
+}
+
+// Dial bounds outbound dials. Timeout is how long the daemon's own dials to a
+// peer may take over all of its addresses, and MaxConcurrent the number of
+// dials in progress at once over transports using file descriptors, such as
+// TCP, which p2pd passes on to the swarm through LIBP2P_SWARM_FD_LIMIT.
+// libp2p's defaults are used where zero.
+type Dial struct {
+	Timeout       time.Duration
+	MaxConcurrent int
+	// Preference lists the addresses to dial peers over first when
+	// connecting and calling, most preferred first, as multiaddr protocol
+	// names such as quic or p2p-circuit and IP networks such as 10.0.0.0/8.
+	Preference []string
+	// PreferenceTimeout is how long the dial over each preferred kind of
+	// address may take before the next one is dialed; a kind is only given
+	// up on once its dial fails if zero.
+	PreferenceTimeout time.Duration
+}
+
+// Provide lists content the daemon announces to the DHT every Interval, so
+// that its provider records don't expire.
+type Provide struct {
+	CIDs     []string
+	Interval time.Duration
+}
+
+// ParseCIDs decodes CIDs.
+func (p Provide) ParseCIDs() ([]cid.Cid, error) {
+	cids := make([]cid.Cid, len(p.CIDs))
+	for i, s := range p.CIDs {
+		c, err := cid.Decode(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CID %s: %w", s, err)
+		}
+		cids[i] = c
+	}
+	return cids, nil
+}
+
+// Rendezvous has the daemon advertise itself to the DHT under Namespace every
+// Interval, looking for the other peers advertising under it and connecting to
+// them; it is disabled if Namespace is empty.
+type Rendezvous struct {
+	Namespace string
+	Interval  time.Duration
+}
+
+// Tracing exports OpenTelemetry spans of unary calls over OTLP/gRPC to the
+// collector at Endpoint, a host:port; it is disabled if Endpoint is empty.
+// SampleRatio is the fraction of the traces started by the daemon that are
+// sampled; calls continuing a client's or remote daemon's trace follow its
+// sampling decision.
+type Tracing struct {
+	Endpoint string
+	// Insecure connects to the collector without TLS.
+	Insecure    bool
+	SampleRatio float64
+}
+
 type GossipSubHeartbeat struct {
 	Interval     time.Duration
 	InitialDelay time.Duration
 }
 
+// GossipSub overrides the gossipsub parameters of the same name where
+// non-zero; see ps.GossipSubParams.
+type GossipSub struct {
+	D             int
+	Dlo           int
+	Dhi           int
+	Dout          int
+	Dlazy         int
+	HistoryLength int
+	HistoryGossip int
+}
+
+// PubSubMessageID selects how pubsub messages are identified, to deliver and
+// forward each of them once.
+type PubSubMessageID struct {
+	// Scheme is author-seqno, the pubsub default, content or prefix.
+	Scheme string
+	// PrefixLength is the number of leading data bytes identifying messages
+	// with the prefix scheme.
+	PrefixLength int
+}
+
 type PubSub struct {
 	Enabled            bool
 	Router             string
 	Sign               bool
 	SignStrict         bool
 	GossipSubHeartbeat GossipSubHeartbeat
+	GossipSub          GossipSub
+	MessageID          PubSubMessageID
+}
+
+// GossipSubParams returns the gossipsub defaults with the configured
+// parameters and heartbeat applied.
+func (p *PubSub) GossipSubParams() ps.GossipSubParams {
+	params := ps.DefaultGossipSubParams()
+	override := func(param *int, value int) {
+		if value != 0 {
+			*param = value
+		}
+	}
+	override(&params.D, p.GossipSub.D)
+	override(&params.Dlo, p.GossipSub.Dlo)
+	override(&params.Dhi, p.GossipSub.Dhi)
+	override(&params.Dout, p.GossipSub.Dout)
+	override(&params.Dlazy, p.GossipSub.Dlazy)
+	override(&params.HistoryLength, p.GossipSub.HistoryLength)
+	override(&params.HistoryGossip, p.GossipSub.HistoryGossip)
+	if p.GossipSubHeartbeat.Interval > 0 {
+		params.HeartbeatInterval = p.GossipSubHeartbeat.Interval
+	}
+	if p.GossipSubHeartbeat.InitialDelay > 0 {
+		params.HeartbeatInitialDelay = p.GossipSubHeartbeat.InitialDelay
+	}
+	return params
+}
+
+func (p *PubSub) validateGossipSub() error {
+	g := p.GossipSub
+	for _, v := range []int{g.D, g.Dlo, g.Dhi, g.Dout, g.Dlazy, g.HistoryLength, g.HistoryGossip} {
+		if v < 0 {
+			return fmt.Errorf("gossipsub parameters can't be negative, got %+v", g)
+		}
+	}
+
+	params := p.GossipSubParams()
+	if params.Dlo > params.D || params.D > params.Dhi {
+		return fmt.Errorf("gossipsub degrees must satisfy Dlo <= D <= Dhi, got Dlo=%d D=%d Dhi=%d", params.Dlo, params.D, params.Dhi)
+	}
+	if params.Dout >= params.Dlo || params.Dout > params.D/2 {
+		return fmt.Errorf("gossipsub Dout must be below Dlo and at most D/2, got Dout=%d Dlo=%d D=%d", params.Dout, params.Dlo, params.D)
+	}
+	if params.HistoryGossip > params.HistoryLength {
+		return fmt.Errorf("gossipsub HistoryGossip can't exceed HistoryLength, got %d > %d", params.HistoryGossip, params.HistoryLength)
+	}
+	return nil
+}
+
+func (p *PubSub) validateMessageID() error {
+	id := p.MessageID
+	switch id.Scheme {
+	case "", "author-seqno", "content":
+		if id.PrefixLength != 0 {
+			return fmt.Errorf("pubsub message id prefix length is only used by the prefix scheme")
+		}
+	case "prefix":
+		if id.PrefixLength <= 0 {
+			return fmt.Errorf("pubsub message id prefix length must be positive, got %d", id.PrefixLength)
+		}
+		// validators can only check that the id of a message was chosen by
+		// its author if authors are authenticated
+		if !p.Sign || !p.SignStrict {
+			return fmt.Errorf("the prefix pubsub message id scheme requires Sign and SignStrict")
+		}
+	default:
+		return fmt.Errorf("unknown pubsub message id scheme %s", id.Scheme)
+	}
+	return nil
 }
 
 type Relay struct {
-	Enabled   bool
-	Active    bool
+	// Enabled lets the node dial and accept relayed connections. It acts as
+	// a relay for other peers only if Hop is enabled too.
+	Enabled bool
+	// Active makes the relay service dial the peers it relays to, rather than
+	// only relay to peers it is already connected to.
+	Active bool
+	// Hop enables the relay service.
 	Hop       bool
 	Discovery bool
 	Auto      bool
-	HopLimit  int
+	// HopLimit bounds the relayed streams of the relay service.
+	HopLimit int
+	// StaticRelays are used by autorelay instead of relays discovered through
+	// the DHT.
+	StaticRelays MaddrArray
+	// DesiredRelays is the number of relays autorelay uses at once.
+	DesiredRelays int
+	// RefreshInterval is how often autorelay looks for relays again while it
+	// uses fewer than DesiredRelays, e.g. after losing one; disabled if zero.
+	RefreshInterval time.Duration
+	// Reconnect is how the node retries connecting to StaticRelays with
+	// autorelay, which it keeps doing while disconnected from any of them.
+	Reconnect RelayReconnect
+}
+
+// RelayReconnect is an exponential backoff with jitter.
+type RelayReconnect struct {
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between attempts.
+	MaxBackoff time.Duration
+	// Multiplier scales the delay after every failed attempt.
+	Multiplier float64
+	// Jitter randomizes every delay by up to this fraction of it.
+	Jitter float64
 }
 
 type DHT struct {
@@ -78,11 +347,340 @@ type DHT struct {
 type PProf struct {
 	Enabled bool
 	Port    uint
+	// ServeMetrics also serves metrics at MetricsPath on the pprof port.
+	ServeMetrics bool
+}
+
+type Peerstore struct {
+	Path          string
+	FlushInterval time.Duration
+}
+
+type HTTPControl struct {
+	Address string
+	Token   string
+}
+
+// UnixSocket sets up the unix control sockets. The mode and owner are set
+// before the socket listens, so no client can connect with the default ones.
+type UnixSocket struct {
+	// Mode is the octal file mode, e.g. "0660"; it is left to the umask if
+	// empty.
+	Mode string
+	// Owner and Group own the socket, by name or numeric ID; they are left
+	// to the process if empty.
+	Owner string
+	Group string
+	// Backlog is the listen backlog; it is the system default if zero.
+	Backlog int
+}
+
+// FileMode parses Mode, returning zero if it is empty.
+func (u UnixSocket) FileMode() (os.FileMode, error) {
+	if u.Mode == "" {
+		return 0, nil
+	}
+	mode, err := strconv.ParseUint(u.Mode, 8, 32)
+	if err != nil || mode == 0 || mode > 0777 {
+		return 0, fmt.Errorf("invalid unix socket mode %q: expected octal permission bits, e.g. 0660", u.Mode)
+	}
+	return os.FileMode(mode), nil
+}
+
+// ControlTLS has clients of the TCP control listeners authenticate with a
+// certificate signed by the CA in CAFile; the daemon presents the one in
+// CertFile, with its key in KeyFile. The files are PEM-encoded, and either
+// all or none of them must be set.
+type ControlTLS struct {
+	CAFile   string
+	CertFile string
+	KeyFile  string
+}
+
+// Enabled reports whether control connections are secured.
+func (t ControlTLS) Enabled() bool {
+	return t.CAFile != "" || t.CertFile != "" || t.KeyFile != ""
+}
+
+type Readiness struct {
+	// Address is a dedicated address to bind the readiness probe to; it is
+	// also served on the metrics address, if any.
+	Address string
+	// Criterion is one of ReadyListening, ReadyBootstrapped and ReadyDHT.
+	Criterion string
+	// InfoFile is where the daemon writes a JSON line with its peer ID and
+	// addresses once started: - for stdout, or a file path. Nothing is
+	// written if empty.
+	InfoFile string
+}
+
+const ReadyListening = "listening"
+const ReadyBootstrapped = "bootstrapped"
+const ReadyDHT = "dht"
+
+type Logging struct {
+	// Level is the minimum level of the emitted logs; go-log picks it from
+	// the environment if empty.
+	Level string
+	// Format is either json or text; go-log picks it from the environment
+	// if empty.
+	Format string
+}
+
+const LogFormatJSON = "json"
+const LogFormatText = "text"
+
+// PrivateNetwork restricts connections to peers sharing a 32-byte pre-shared
+// key, read from KeyFile in the swarm.key format or given hex encoded as Key.
+// QUIC doesn't support private networks, so it is disabled when one is set.
+type PrivateNetwork struct {
+	KeyFile string
+	Key     string
+}
+
+// Enabled tells whether a pre-shared key is configured.
+func (p PrivateNetwork) Enabled() bool {
+	return p.KeyFile != "" || p.Key != ""
+}
+
+// LoadKey returns the pre-shared key, or nil if the private network is
+// disabled.
+func (p PrivateNetwork) LoadKey() (pnet.PSK, error) {
+	var psk pnet.PSK
+	switch {
+	case p.Key != "":
+		key, err := hex.DecodeString(p.Key)
+		if err != nil {
+			return nil, fmt.Errorf("malformed private network key: %w", err)
+		}
+		psk = key
+	case p.KeyFile != "":
+		f, err := os.Open(p.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		psk, err = pnet.DecodeV1PSK(f)
+		if err != nil {
+			return nil, fmt.Errorf("malformed private network key file %s: %w", p.KeyFile, err)
+		}
+	default:
+		return nil, nil
+	}
+
+	if len(psk) != 32 {
+		return nil, fmt.Errorf("private network key must be 32 bytes long, got %d", len(psk))
+	}
+	return psk, nil
 }
 
 type Security struct {
 	Noise bool
 	TLS   bool
+	// Preference lists security protocols from most to least preferred.
+	// Enabled protocols that aren't listed come after, in the default order.
+	Preference []string
+}
+
+const SecurityNoise = "noise"
+const SecurityTLS = "tls"
+
+func (s Security) enabled(proto string) bool {
+	switch proto {
+	case SecurityNoise:
+		return s.Noise
+	case SecurityTLS:
+		return s.TLS
+	default:
+		return false
+	}
+}
+
+// Order returns the enabled security protocols, most preferred first.
+func (s Security) Order() []string {
+	order := make([]string, 0, 2)
+	seen := make(map[string]bool)
+	for _, proto := range append(s.Preference, SecurityNoise, SecurityTLS) {
+		if s.enabled(proto) && !seen[proto] {
+			order = append(order, proto)
+			seen[proto] = true
+		}
+	}
+	return order
+}
+
+const TransportTCP = "tcp"
+const TransportQUIC = "quic"
+const TransportWebsocket = "websocket"
+
+// EnabledTransports returns the transports the host is built with: those in
+// Transports, without QUIC if the deprecated QUIC option is disabled or a
+// private network is configured.
+func (c *Config) EnabledTransports() []string {
+	transports := make([]string, 0, len(c.Transports))
+	for _, t := range c.Transports {
+		if t == TransportQUIC && (!c.QUIC || c.PrivateNetwork.Enabled()) {
+			continue
+		}
+		transports = append(transports, t)
+	}
+	return transports
+}
+
+// addrTransport returns the transport needed to listen on addr, or an empty
+// string if it isn't handled by any of the selectable transports.
+func addrTransport(addr multiaddr.Multiaddr) (string, error) {
+	has := func(code int) bool {
+		_, err := addr.ValueForProtocol(code)
+		return err == nil
+	}
+	switch {
+	case has(multiaddr.P_WSS):
+		return "", fmt.Errorf("secure websocket address %s is not supported; terminate TLS in a proxy in front of a /ws address instead", addr)
+	case has(multiaddr.P_WS):
+		return TransportWebsocket, nil
+	case has(multiaddr.P_QUIC):
+		return TransportQUIC, nil
+	case has(multiaddr.P_TCP):
+		return TransportTCP, nil
+	default:
+		return "", nil
+	}
+}
+
+const AddressFilterIP4 = "ip4"
+const AddressFilterIP6 = "ip6"
+const AddressFilterBoth = "both"
+
+// addrFamily returns the address filter addr falls under, or an empty string
+// if it has no address family, e.g. a /dns or /unix address.
+func addrFamily(addr multiaddr.Multiaddr) string {
+	if len(addr.Protocols()) == 0 {
+		return ""
+	}
+	switch addr.Protocols()[0].Code {
+	case multiaddr.P_IP4, multiaddr.P_DNS4:
+		return AddressFilterIP4
+	case multiaddr.P_IP6, multiaddr.P_DNS6:
+		return AddressFilterIP6
+	default:
+		return ""
+	}
+}
+
+// FilterAddrs returns the addrs AddressFilter lets through; addresses
+// without an address family are always kept.
+func (c *Config) FilterAddrs(addrs []multiaddr.Multiaddr) []multiaddr.Multiaddr {
+	if c.AddressFilter == AddressFilterBoth {
+		return addrs
+	}
+	filtered := make([]multiaddr.Multiaddr, 0, len(addrs))
+	for _, addr := range addrs {
+		if f := addrFamily(addr); f == "" || f == c.AddressFilter {
+			filtered = append(filtered, addr)
+		}
+	}
+	return filtered
+}
+
+// PortPlaceholder stands for the port the host listens on in
+// AnnounceTemplates.
+const PortPlaceholder = "{PORT}"
+
+// parseAnnounceTemplate checks template and returns it with the placeholder
+// replaced by port 0, and the protocol the port belongs to.
+func parseAnnounceTemplate(template string) (multiaddr.Multiaddr, int, error) {
+	if strings.Count(template, PortPlaceholder) != 1 {
+		return nil, 0, fmt.Errorf("%s must hold %s once", template, PortPlaceholder)
+	}
+	addr, err := multiaddr.NewMultiaddr(strings.Replace(template, PortPlaceholder, "0", 1))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	prefix := template[:strings.Index(template, PortPlaceholder)]
+	for _, p := range []multiaddr.Protocol{
+		multiaddr.ProtocolWithCode(multiaddr.P_TCP),
+		multiaddr.ProtocolWithCode(multiaddr.P_UDP),
+	} {
+		if strings.HasSuffix(prefix, "/"+p.Name+"/") {
+			return addr, p.Code, nil
+		}
+	}
+	return nil, 0, fmt.Errorf("%s must stand for a tcp or udp port in %s", PortPlaceholder, template)
+}
+
+// transportCodes returns the protocols of addr after its first one, e.g. the
+// tcp or udp and quic of an ip4 address.
+func transportCodes(addr multiaddr.Multiaddr) []int {
+	protos := addr.Protocols()
+	codes := make([]int, 0, len(protos))
+	for _, p := range protos[1:] {
+		codes = append(codes, p.Code)
+	}
+	return codes
+}
+
+func sameCodes(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// AnnounceAddrs returns the addresses to announce for a host listening on
+// listenAddrs: AnnounceAddresses and the expanded AnnounceTemplates, or
+// listenAddrs if neither is set. A template gets the port of a listen address
+// with the same transport, of the same address family if there is one, and
+// is left out if there is none.
+func (c *Config) AnnounceAddrs(listenAddrs []multiaddr.Multiaddr) []multiaddr.Multiaddr {
+	if len(c.AnnounceAddresses) == 0 && len(c.AnnounceTemplates) == 0 {
+		return listenAddrs
+	}
+
+	addrs := make([]multiaddr.Multiaddr, 0, len(c.AnnounceAddresses)+len(c.AnnounceTemplates))
+	addrs = append(addrs, c.AnnounceAddresses...)
+	for _, template := range c.AnnounceTemplates {
+		tAddr, portCode, err := parseAnnounceTemplate(template)
+		if err != nil {
+			// rejected by Validate
+			continue
+		}
+		codes := transportCodes(tAddr)
+
+		port := ""
+		for _, addr := range listenAddrs {
+			if len(addr.Protocols()) == 0 || !sameCodes(transportCodes(addr), codes) {
+				continue
+			}
+			value, err := addr.ValueForProtocol(portCode)
+			if err != nil {
+				continue
+			}
+			if port == "" || addrFamily(addr) == addrFamily(tAddr) {
+				port = value
+			}
+			if addrFamily(addr) == addrFamily(tAddr) {
+				break
+			}
+		}
+		if port == "" {
+			continue
+		}
+
+		addr, err := multiaddr.NewMultiaddr(strings.Replace(template, PortPlaceholder, port, 1))
+		if err != nil {
+			continue
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs
 }
 
 const DHTFullMode = "full"
@@ -96,6 +694,12 @@ type Config struct {
 	Bootstrap         Bootstrap
 	DHT               DHT
 	ConnectionManager ConnectionManager
+	InboundRateLimit  InboundRateLimit
+	// Transports lists the enabled transports; see TransportTCP,
+	// TransportQUIC and TransportWebsocket.
+	Transports []string
+	// Deprecated: use Transports. QUIC is left out of the enabled transports
+	// if this is false.
 	QUIC              bool
 	NatPortMap        bool
 	PubSub            PubSub
@@ -103,10 +707,189 @@ type Config struct {
 	AutoNat           bool
 	HostAddresses     MaddrArray
 	AnnounceAddresses MaddrArray
-	NoListen          bool
-	MetricsAddress    string
-	PProf             PProf
-	Security          Security
+	// AnnounceTemplates are announced with PortPlaceholder replaced by the
+	// port the host listens on with the same transport, e.g.
+	// /ip4/1.2.3.4/tcp/{PORT}, for ports only known once listening.
+	AnnounceTemplates []string
+	// AddressFilter restricts the listen and announced addresses to IPv4
+	// (AddressFilterIP4) or IPv6 (AddressFilterIP6) ones, or lets both
+	// through (AddressFilterBoth).
+	AddressFilter  string
+	NoListen       bool
+	MetricsAddress string
+	MetricsPath    string
+	PProf          PProf
+	Security       Security
+	PrivateNetwork PrivateNetwork
+	HTTPControl    HTTPControl
+	ControlTLS     ControlTLS
+	UnixSocket     UnixSocket
+	Peerstore      Peerstore
+	// MaxUnaryMessageSize bounds the size of messages accepted from remote
+	// peers on unary streams, in bytes.
+	MaxUnaryMessageSize int
+	// UnaryChunkSize is the size in bytes of the chunks unary payloads larger
+	// than it are split into; chunks must fit in the unary messages remote
+	// peers accept. Payloads aren't split if zero, as peers and clients must
+	// support chunking to receive them.
+	UnaryChunkSize int
+	// MaxChunkedPayloadSize bounds the size in bytes of a payload received in
+	// chunks.
+	MaxChunkedPayloadSize int
+	// RetryUnaryDials retries opening the stream of unary calls once, with a
+	// direct dial, if the peer is in dial backoff.
+	RetryUnaryDials bool
+	// NegotiationTimeout bounds how long negotiating the protocol of a unary
+	// call with a connected peer may take, apart from the call's timeout; it
+	// is only bounded by the call's timeout if zero.
+	NegotiationTimeout time.Duration
+	// LoopbackCalls has unary and stream calls to the daemon's own peer id go
+	// through an in-memory stream to its own handler, for benchmarks and
+	// profiling.
+	LoopbackCalls bool
+	// CallHistorySize is the number of recent unary calls kept for
+	// CALL_HISTORY; the history is disabled if zero.
+	CallHistorySize int
+	// MaxPersistentConns caps the persistent connections clients may have
+	// open at once; they are unlimited if zero.
+	MaxPersistentConns int
+	Logging            Logging
+	Readiness          Readiness
+	KeepAlive          KeepAlive
+	Dial               Dial
+	Provide            Provide
+	Rendezvous         Rendezvous
+	Tracing            Tracing
+	// UserAgent is announced to other peers in identify exchanges; it is
+	// derived from the build info of the daemon if empty.
+	UserAgent string
+	// Metadata is announced to other peers over the /p2pd/metadata/1.0.0
+	// protocol, e.g. the role of the node; none is announced if empty.
+	Metadata map[string]string
+	// AllowedPeers restricts connections to these peer IDs, unless empty.
+	AllowedPeers []string
+	// BlockedPeers are peer IDs connections are never allowed with.
+	BlockedPeers []string
+	// ExtraIdentities are more peers run by the daemon process, each with
+	// its own host and control listener, given as the path of the key file
+	// and the control listen address separated by =, e.g.
+	// key2=/unix/tmp/p2pd2.sock.
+	ExtraIdentities []string
+}
+
+// ExtraIdentity is a peer run by the daemon process besides the main one.
+type ExtraIdentity struct {
+	// KeyFile holds the private key of the peer.
+	KeyFile string
+	// ListenAddr is the control listen address of its daemon.
+	ListenAddr multiaddr.Multiaddr
+}
+
+// ParseExtraIdentities decodes ExtraIdentities.
+func (c *Config) ParseExtraIdentities() ([]ExtraIdentity, error) {
+	identities := make([]ExtraIdentity, len(c.ExtraIdentities))
+	for i, s := range c.ExtraIdentities {
+		sep := strings.Index(s, "=")
+		if sep <= 0 {
+			return nil, fmt.Errorf("ExtraIdentities[%d]: expected a key file and a control address separated by =, got %q", i, s)
+		}
+		addr, err := multiaddr.NewMultiaddr(s[sep+1:])
+		if err != nil {
+			return nil, &MaddrError{Field: "ExtraIdentities", Index: i, Addr: s[sep+1:], Err: err}
+		}
+		identities[i] = ExtraIdentity{KeyFile: s[:sep], ListenAddr: addr}
+	}
+	return identities, nil
+}
+
+// PeerLists decodes AllowedPeers and BlockedPeers.
+func (c *Config) PeerLists() (allowed, blocked []peer.ID, err error) {
+	decode := func(ids []string) ([]peer.ID, error) {
+		peers := make([]peer.ID, len(ids))
+		for i, id := range ids {
+			p, err := peer.Decode(id)
+			if err != nil {
+				return nil, fmt.Errorf("invalid peer ID %s: %w", id, err)
+			}
+			peers[i] = p
+		}
+		return peers, nil
+	}
+
+	if allowed, err = decode(c.AllowedPeers); err != nil {
+		return nil, nil, err
+	}
+	if blocked, err = decode(c.BlockedPeers); err != nil {
+		return nil, nil, err
+	}
+	return allowed, blocked, nil
+}
+
+// validateExtraIdentities checks that the extra identities have distinct
+// control addresses, and that nothing pins the hosts of every identity to the
+// same resources.
+func (c *Config) validateExtraIdentities() error {
+	identities, err := c.ParseExtraIdentities()
+	if err != nil {
+		return err
+	}
+
+	listening := make(map[string]bool)
+	if c.ListenAddr.Multiaddr != nil {
+		listening[c.ListenAddr.String()] = true
+	}
+	for i, id := range identities {
+		if id.KeyFile == "-" {
+			return fmt.Errorf("ExtraIdentities[%d]: the key can't be read from stdin", i)
+		}
+		if listening[id.ListenAddr.String()] {
+			return fmt.Errorf("ExtraIdentities[%d]: control address %s is already taken", i, id.ListenAddr)
+		}
+		listening[id.ListenAddr.String()] = true
+	}
+
+	switch {
+	case len(c.HostAddresses) > 0:
+		return fmt.Errorf("extra identities can't be used with HostAddresses, which every host would listen on")
+	case len(c.AnnounceAddresses) > 0:
+		return fmt.Errorf("extra identities can't be used with AnnounceAddresses, which every host would announce")
+	case c.Peerstore.Path != "":
+		return fmt.Errorf("extra identities can't be used with a persistent peerstore")
+	}
+	return nil
+}
+
+// ParseMetadata parses metadata given as comma-separated key=value pairs, as
+// in the -metadata flag.
+func ParseMetadata(s string) (map[string]string, error) {
+	md := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("metadata entry %q must be key=value", pair)
+		}
+		md[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+	}
+	return md, nil
+}
+
+// validateMetadata applies the limits of p2pd.MaxMetadataSize and
+// p2pd.MaxMetadataEntries.
+func validateMetadata(md map[string]string) error {
+	if len(md) > 64 {
+		return fmt.Errorf("metadata can't have more than 64 entries, got %d", len(md))
+	}
+	size := 0
+	for k, v := range md {
+		if k == "" {
+			return fmt.Errorf("metadata keys can't be empty")
+		}
+		size += len(k) + len(v)
+	}
+	if size > 4096 {
+		return fmt.Errorf("metadata keys and values can't exceed 4096 bytes, got %d", size)
+	}
+	return nil
 }
 
 func (c *Config) UnmarshalJSON(b []byte) error {
@@ -131,8 +914,226 @@ func (c *Config) Validate() error {
 	if c.DHT.Mode != DHTClientMode && c.DHT.Mode != DHTFullMode && c.DHT.Mode != DHTServerMode && c.DHT.Mode != "" {
 		return fmt.Errorf("unknown DHT mode %s", c.DHT)
 	}
-	if c.Relay.Auto && (!c.Relay.Enabled || c.DHT.Mode == "") {
-		return fmt.Errorf("can't have autorelay enabled without Relay enabled and DHT enabled")
+	if !c.Relay.Enabled && (c.Relay.Hop || c.Relay.Active || c.Relay.Discovery || c.Relay.HopLimit > 0) {
+		return fmt.Errorf("can't configure the relay service with Relay disabled")
+	}
+	if !c.Relay.Hop && (c.Relay.Active || c.Relay.HopLimit > 0) {
+		return fmt.Errorf("can't have active relay or a hop limit without Hop enabled")
+	}
+	if c.Relay.HopLimit < 0 {
+		return fmt.Errorf("relay hop limit can't be negative, got %d", c.Relay.HopLimit)
+	}
+	if err := c.PubSub.validateGossipSub(); err != nil {
+		return err
+	}
+	if err := c.PubSub.validateMessageID(); err != nil {
+		return err
+	}
+	if c.Relay.Auto && (!c.Relay.Enabled || (c.DHT.Mode == "" && len(c.Relay.StaticRelays) == 0)) {
+		return fmt.Errorf("can't have autorelay enabled without Relay enabled and DHT enabled or static relays")
+	}
+	for i, addr := range c.Relay.StaticRelays {
+		if _, err := peer.AddrInfoFromP2pAddr(addr); err != nil {
+			return fmt.Errorf("Relay.StaticRelays[%d]: %s must include the relay's peer id: %w", i, addr, err)
+		}
+	}
+	if c.Relay.DesiredRelays < 1 {
+		return fmt.Errorf("desired relays must be positive, got %d", c.Relay.DesiredRelays)
+	}
+	if c.Relay.RefreshInterval < 0 {
+		return fmt.Errorf("relay refresh interval can't be negative, got %s", c.Relay.RefreshInterval)
+	}
+	if r := c.Relay.Reconnect; r.InitialBackoff <= 0 || r.MaxBackoff < r.InitialBackoff {
+		return fmt.Errorf("relay reconnect backoff must be positive and at most the max backoff, got %s and %s", r.InitialBackoff, r.MaxBackoff)
+	}
+	if r := c.Relay.Reconnect; r.Multiplier < 1 || r.Jitter < 0 || r.Jitter >= 1 {
+		return fmt.Errorf("relay reconnect multiplier must be at least 1 and jitter within [0, 1), got %v and %v", r.Multiplier, r.Jitter)
+	}
+	for i, addr := range c.Bootstrap.Peers {
+		if _, err := peer.AddrInfoFromP2pAddr(addr); err != nil {
+			return fmt.Errorf("Bootstrap.Peers[%d]: %s must include the peer id: %w", i, addr, err)
+		}
+	}
+	if c.Bootstrap.Retry.InitialBackoff < 0 {
+		return fmt.Errorf("bootstrap retry backoff can't be negative")
+	}
+	if c.Bootstrap.Retry.Multiplier < 1 {
+		return fmt.Errorf("bootstrap retry backoff multiplier must be at least 1, got %v", c.Bootstrap.Retry.Multiplier)
+	}
+	seen := make(map[string]bool)
+	for _, proto := range c.Security.Preference {
+		if proto != SecurityNoise && proto != SecurityTLS {
+			return fmt.Errorf("unknown security protocol %s", proto)
+		}
+		if !c.Security.enabled(proto) {
+			return fmt.Errorf("security protocol %s is preferred but not enabled", proto)
+		}
+		if seen[proto] {
+			return fmt.Errorf("security protocol %s is listed twice", proto)
+		}
+		seen[proto] = true
+	}
+	if c.PrivateNetwork.KeyFile != "" && c.PrivateNetwork.Key != "" {
+		return fmt.Errorf("private network key and key file are mutually exclusive")
+	}
+	if c.PrivateNetwork.Key != "" {
+		if _, err := c.PrivateNetwork.LoadKey(); err != nil {
+			return err
+		}
+	}
+	if c.InboundRateLimit.Enabled {
+		if c.InboundRateLimit.Connections <= 0 || c.InboundRateLimit.Window <= 0 || c.InboundRateLimit.Burst <= 0 {
+			return fmt.Errorf("inbound rate limit connections, window and burst must be positive")
+		}
+	}
+	transports := c.EnabledTransports()
+	if len(transports) == 0 {
+		return fmt.Errorf("at least one transport must be enabled")
+	}
+	seen = make(map[string]bool)
+	for _, t := range transports {
+		if t != TransportTCP && t != TransportQUIC && t != TransportWebsocket {
+			return fmt.Errorf("unknown transport %s", t)
+		}
+		if seen[t] {
+			return fmt.Errorf("transport %s is listed twice", t)
+		}
+		seen[t] = true
+	}
+	if c.AddressFilter != AddressFilterIP4 && c.AddressFilter != AddressFilterIP6 && c.AddressFilter != AddressFilterBoth {
+		return fmt.Errorf("unknown address filter %q", c.AddressFilter)
+	}
+	for i, template := range c.AnnounceTemplates {
+		if _, _, err := parseAnnounceTemplate(template); err != nil {
+			return fmt.Errorf("AnnounceTemplates[%d]: %w", i, err)
+		}
+	}
+	for _, addrs := range []MaddrArray{c.HostAddresses, c.AnnounceAddresses} {
+		for _, addr := range addrs {
+			if len(c.FilterAddrs([]multiaddr.Multiaddr{addr})) == 0 {
+				return fmt.Errorf("address %s is filtered out by the %s address filter", addr, c.AddressFilter)
+			}
+		}
+	}
+	for _, addr := range c.HostAddresses {
+		t, err := addrTransport(addr)
+		if err != nil {
+			return err
+		}
+		if t != "" && !seen[t] {
+			return fmt.Errorf("host address %s needs the %s transport, which is not enabled", addr, t)
+		}
+	}
+	allowed, blocked, err := c.PeerLists()
+	if err != nil {
+		return err
+	}
+	for _, b := range blocked {
+		for _, a := range allowed {
+			if a == b {
+				return fmt.Errorf("peer %s is both allowed and blocked", b)
+			}
+		}
+	}
+	if c.Logging.Level != "" {
+		if _, err := logging.LevelFromString(c.Logging.Level); err != nil {
+			return fmt.Errorf("unknown log level %s", c.Logging.Level)
+		}
+	}
+	if c.Logging.Format != "" && c.Logging.Format != LogFormatJSON && c.Logging.Format != LogFormatText {
+		return fmt.Errorf("unknown log format %s", c.Logging.Format)
+	}
+	switch c.Readiness.Criterion {
+	case ReadyListening:
+	case ReadyBootstrapped:
+		if !c.Bootstrap.Enabled {
+			return fmt.Errorf("readiness criterion %s requires bootstrap to be enabled", c.Readiness.Criterion)
+		}
+	case ReadyDHT:
+		if !c.Bootstrap.Enabled || c.DHT.Mode == "" {
+			return fmt.Errorf("readiness criterion %s requires bootstrap and the DHT to be enabled", c.Readiness.Criterion)
+		}
+	default:
+		return fmt.Errorf("unknown readiness criterion %s", c.Readiness.Criterion)
+	}
+
+	if len(c.ExtraIdentities) > 0 {
+		if err := c.validateExtraIdentities(); err != nil {
+			return err
+		}
+	}
+
+	if c.UserAgent != "" && strings.TrimSpace(c.UserAgent) == "" {
+		return fmt.Errorf("user agent can't be blank, got %q", c.UserAgent)
+	}
+	if err := validateMetadata(c.Metadata); err != nil {
+		return err
+	}
+	if c.MaxUnaryMessageSize <= 0 {
+		return fmt.Errorf("max unary message size must be positive, got %d", c.MaxUnaryMessageSize)
+	}
+	if c.UnaryChunkSize < 0 {
+		return fmt.Errorf("unary chunk size can't be negative, got %d", c.UnaryChunkSize)
+	}
+	if c.MaxChunkedPayloadSize <= 0 {
+		return fmt.Errorf("max chunked payload size must be positive, got %d", c.MaxChunkedPayloadSize)
+	}
+	if c.CallHistorySize < 0 {
+		return fmt.Errorf("call history size can't be negative, got %d", c.CallHistorySize)
+	}
+	if c.NegotiationTimeout < 0 {
+		return fmt.Errorf("negotiation timeout can't be negative, got %s", c.NegotiationTimeout)
+	}
+	if c.MaxPersistentConns < 0 {
+		return fmt.Errorf("max persistent connections can't be negative, got %d", c.MaxPersistentConns)
+	}
+	if _, err := c.UnixSocket.FileMode(); err != nil {
+		return err
+	}
+	if c.UnixSocket.Backlog < 0 {
+		return fmt.Errorf("unix socket backlog can't be negative, got %d", c.UnixSocket.Backlog)
+	}
+	if c.ControlTLS.Enabled() && (c.ControlTLS.CAFile == "" || c.ControlTLS.CertFile == "" || c.ControlTLS.KeyFile == "") {
+		return fmt.Errorf("control TLS requires a CA file, a certificate file and a key file")
+	}
+	if !strings.HasPrefix(c.MetricsPath, "/") {
+		return fmt.Errorf("metrics path must start with /, got %q", c.MetricsPath)
+	}
+	if c.MetricsPath == "/ready" || (c.PProf.ServeMetrics && strings.HasPrefix(c.MetricsPath, "/debug/pprof/")) {
+		return fmt.Errorf("metrics path %s is taken by another handler", c.MetricsPath)
+	}
+	if c.KeepAlive.Interval < 0 {
+		return fmt.Errorf("keepalive interval can't be negative, got %s", c.KeepAlive.Interval)
+	}
+	if c.KeepAlive.Interval > 0 && c.KeepAlive.MaxMissed <= 0 {
+		return fmt.Errorf("keepalive max missed pings must be positive, got %d", c.KeepAlive.MaxMissed)
+	}
+	if c.Dial.Timeout < 0 {
+		return fmt.Errorf("dial timeout can't be negative, got %s", c.Dial.Timeout)
+	}
+	if c.Dial.MaxConcurrent < 0 {
+		return fmt.Errorf("max concurrent dials can't be negative, got %d", c.Dial.MaxConcurrent)
+	}
+	if c.Dial.PreferenceTimeout < 0 {
+		return fmt.Errorf("dial preference timeout can't be negative, got %s", c.Dial.PreferenceTimeout)
+	}
+	if c.Provide.Interval <= 0 {
+		return fmt.Errorf("reprovide interval must be positive, got %s", c.Provide.Interval)
+	}
+	if len(c.Provide.CIDs) > 0 && c.DHT.Mode == "" {
+		return fmt.Errorf("can't provide content without the DHT enabled")
+	}
+	if _, err := c.Provide.ParseCIDs(); err != nil {
+		return err
+	}
+	if c.Rendezvous.Interval <= 0 {
+		return fmt.Errorf("rendezvous interval must be positive, got %s", c.Rendezvous.Interval)
+	}
+	if c.Rendezvous.Namespace != "" && c.DHT.Mode == "" {
+		return fmt.Errorf("can't rendezvous without the DHT enabled")
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		return fmt.Errorf("trace sample ratio must be between 0 and 1, got %v", c.Tracing.SampleRatio)
 	}
 	return nil
 }
@@ -146,6 +1147,11 @@ func NewDefaultConfig() Config {
 		Bootstrap: Bootstrap{
 			Enabled: false,
 			Peers:   make(MaddrArray, 0),
+			Retry: BootstrapRetry{
+				MaxAttempts:    5,
+				InitialBackoff: time.Second,
+				Multiplier:     2,
+			},
 		},
 		DHT: DHT{
 			Mode: "",
@@ -156,6 +1162,13 @@ func NewDefaultConfig() Config {
 			HighWaterMark: 512,
 			GracePeriod:   120 * time.Second,
 		},
+		InboundRateLimit: InboundRateLimit{
+			Enabled:     false,
+			Connections: 16,
+			Window:      time.Minute,
+			Burst:       32,
+		},
+		Transports: []string{TransportTCP, TransportQUIC, TransportWebsocket},
 		QUIC:       true,
 		NatPortMap: false,
 		PubSub: PubSub{
@@ -167,26 +1180,96 @@ func NewDefaultConfig() Config {
 				Interval:     0,
 				InitialDelay: 0,
 			},
+			MessageID: PubSubMessageID{
+				Scheme:       "author-seqno",
+				PrefixLength: 0,
+			},
 		},
 		Relay: Relay{
-			Enabled:   true,
-			Hop:       false,
-			Discovery: false,
-			Auto:      false,
-			HopLimit:  0,
+			Enabled:         true,
+			Hop:             false,
+			Discovery:       false,
+			Auto:            false,
+			HopLimit:        0,
+			StaticRelays:    make(MaddrArray, 0),
+			DesiredRelays:   1,
+			RefreshInterval: 0,
+			Reconnect: RelayReconnect{
+				InitialBackoff: 5 * time.Second,
+				MaxBackoff:     5 * time.Minute,
+				Multiplier:     2,
+				Jitter:         0.2,
+			},
 		},
 		AutoNat:           false,
 		HostAddresses:     make(MaddrArray, 0),
 		AnnounceAddresses: make(MaddrArray, 0),
+		AnnounceTemplates: make([]string, 0),
+		AddressFilter:     AddressFilterBoth,
 		NoListen:          false,
 		MetricsAddress:    "",
+		MetricsPath:       "/metrics",
 		PProf: PProf{
-			Enabled: false,
-			Port:    0,
+			Enabled:      false,
+			Port:         0,
+			ServeMetrics: false,
 		},
 		Security: Security{
 			Noise: true,
 			TLS:   true,
 		},
+		PrivateNetwork: PrivateNetwork{
+			KeyFile: "",
+			Key:     "",
+		},
+		Peerstore: Peerstore{
+			Path:          "",
+			FlushInterval: time.Minute,
+		},
+		MaxUnaryMessageSize:   1 << 22,
+		UnaryChunkSize:        0,
+		MaxChunkedPayloadSize: 1 << 28,
+		RetryUnaryDials:       false,
+		NegotiationTimeout:    0,
+		LoopbackCalls:         false,
+		CallHistorySize:       0,
+		MaxPersistentConns:    0,
+		Logging: Logging{
+			Level:  "",
+			Format: "",
+		},
+		Readiness: Readiness{
+			Address:   "",
+			Criterion: ReadyListening,
+			InfoFile:  "",
+		},
+		KeepAlive: KeepAlive{
+			Interval:  0,
+			MaxMissed: 3,
+		},
+		Dial: Dial{
+			Timeout:           0,
+			MaxConcurrent:     0,
+			Preference:        make([]string, 0),
+			PreferenceTimeout: 0,
+		},
+		Provide: Provide{
+			CIDs:     make([]string, 0),
+			Interval: 12 * time.Hour,
+		},
+		Rendezvous: Rendezvous{
+			Namespace: "",
+			Interval:  10 * time.Minute,
+		},
+		Tracing: Tracing{
+			Endpoint:    "",
+			Insecure:    false,
+			SampleRatio: 1,
+		},
+		UserAgent:       "",
+		Metadata:        make(map[string]string),
+		AllowedPeers:    make([]string, 0),
+		BlockedPeers:    make([]string, 0),
+		ExtraIdentities: make([]string, 0),
 	}
 }
@@ -1,10 +1,17 @@
 package config
 
 import (
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
 	"testing"
+	"time"
 
+	ps "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/multiformats/go-multiaddr"
 )
 
@@ -23,3 +30,926 @@ func TestDefaultConfig(t *testing.T) {
 		t.Fatal(fmt.Sprintf("Expected %s, got %s", defaultListen.String(), c.ListenAddr.String()))
 	}
 }
+
+func TestBootstrapRetryMultiplier(t *testing.T) {
+	const inputJson = `{"Bootstrap": {"Retry": {"Multiplier": 0.5}}}`
+	var c Config
+	if err := json.Unmarshal([]byte(inputJson), &c); err == nil {
+		t.Fatal("expected a backoff multiplier below 1 to be rejected")
+	}
+}
+
+func TestMaxUnaryMessageSize(t *testing.T) {
+	const inputJson = `{"MaxUnaryMessageSize": 0}`
+	var c Config
+	if err := json.Unmarshal([]byte(inputJson), &c); err == nil {
+		t.Fatal("expected a non-positive max unary message size to be rejected")
+	}
+}
+
+func TestUnaryChunkSize(t *testing.T) {
+	c := NewDefaultConfig()
+	if c.UnaryChunkSize != 0 || c.MaxChunkedPayloadSize != 1<<28 {
+		t.Fatalf("unexpected defaults: chunks of %d bytes, payloads of at most %d", c.UnaryChunkSize, c.MaxChunkedPayloadSize)
+	}
+
+	for _, input := range []string{
+		`{"UnaryChunkSize": -1}`,
+		`{"MaxChunkedPayloadSize": -1}`,
+	} {
+		var c Config
+		if err := json.Unmarshal([]byte(input), &c); err == nil {
+			t.Fatalf("expected %s to be rejected", input)
+		}
+	}
+}
+
+func TestSecurityPreference(t *testing.T) {
+	var c Config
+	if err := json.Unmarshal([]byte(`{"Security": {"Noise": true, "TLS": true, "Preference": ["tls"]}}`), &c); err != nil {
+		t.Fatal(err)
+	}
+	if order := c.Security.Order(); len(order) != 2 || order[0] != SecurityTLS || order[1] != SecurityNoise {
+		t.Fatalf("expected tls to be preferred over noise, got %v", order)
+	}
+
+	for _, input := range []string{
+		`{"Security": {"Noise": true, "TLS": false, "Preference": ["tls"]}}`,
+		`{"Security": {"Preference": ["secio"]}}`,
+		`{"Security": {"Preference": ["noise", "noise"]}}`,
+	} {
+		if err := json.Unmarshal([]byte(input), &c); err == nil {
+			t.Fatalf("expected %s to be rejected", input)
+		}
+	}
+}
+
+func TestLogging(t *testing.T) {
+	var c Config
+	if err := json.Unmarshal([]byte(`{"Logging": {"Level": "info", "Format": "json"}}`), &c); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, input := range []string{
+		`{"Logging": {"Level": "verbose"}}`,
+		`{"Logging": {"Format": "xml"}}`,
+	} {
+		if err := json.Unmarshal([]byte(input), &c); err == nil {
+			t.Fatalf("expected %s to be rejected", input)
+		}
+	}
+}
+
+func TestReadiness(t *testing.T) {
+	var c Config
+	if err := json.Unmarshal([]byte(`{"Readiness": {"Address": "127.0.0.1:8080"}}`), &c); err != nil {
+		t.Fatal(err)
+	}
+	if c.Readiness.Criterion != ReadyListening {
+		t.Fatalf("expected the default criterion, got %s", c.Readiness.Criterion)
+	}
+
+	if err := json.Unmarshal([]byte(`{"Bootstrap": {"Enabled": true}, "DHT": {"Mode": "full"}, "Readiness": {"Criterion": "dht"}}`), &c); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, input := range []string{
+		`{"Readiness": {"Criterion": "healthy"}}`,
+		`{"Readiness": {"Criterion": "bootstrapped"}}`,
+		`{"Bootstrap": {"Enabled": true}, "Readiness": {"Criterion": "dht"}}`,
+	} {
+		if err := json.Unmarshal([]byte(input), &c); err == nil {
+			t.Fatalf("expected %s to be rejected", input)
+		}
+	}
+}
+
+func TestKeepAlive(t *testing.T) {
+	var c Config
+	if err := json.Unmarshal([]byte(`{"KeepAlive": {"Interval": 30000000000}}`), &c); err != nil {
+		t.Fatal(err)
+	}
+	if c.KeepAlive.MaxMissed != 3 {
+		t.Fatalf("expected the default max missed pings, got %d", c.KeepAlive.MaxMissed)
+	}
+
+	for _, input := range []string{
+		`{"KeepAlive": {"Interval": -1}}`,
+		`{"KeepAlive": {"Interval": 30000000000, "MaxMissed": 0}}`,
+	} {
+		if err := json.Unmarshal([]byte(input), &c); err == nil {
+			t.Fatalf("expected %s to be rejected", input)
+		}
+	}
+}
+
+func TestMetricsPath(t *testing.T) {
+	var c Config
+	if err := json.Unmarshal([]byte(`{"MetricsAddress": "127.0.0.1:8888"}`), &c); err != nil {
+		t.Fatal(err)
+	}
+	if c.MetricsPath != "/metrics" {
+		t.Fatalf("expected the default metrics path, got %s", c.MetricsPath)
+	}
+
+	if err := json.Unmarshal([]byte(`{"MetricsPath": "/debug/pprof/metrics"}`), &c); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, input := range []string{
+		`{"MetricsPath": "metrics"}`,
+		`{"MetricsPath": "/ready"}`,
+		`{"MetricsPath": "/debug/pprof/metrics", "PProf": {"Enabled": true, "ServeMetrics": true}}`,
+	} {
+		if err := json.Unmarshal([]byte(input), &c); err == nil {
+			t.Fatalf("expected %s to be rejected", input)
+		}
+	}
+}
+
+func TestPrivateNetwork(t *testing.T) {
+	key := strings.Repeat("ab", 32)
+
+	var c Config
+	if err := json.Unmarshal([]byte(fmt.Sprintf(`{"PrivateNetwork": {"Key": "%s"}}`, key)), &c); err != nil {
+		t.Fatal(err)
+	}
+	psk, err := c.PrivateNetwork.LoadKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(psk) != key {
+		t.Fatalf("expected the configured key, got %x", psk)
+	}
+	for _, tpt := range c.EnabledTransports() {
+		if tpt == TransportQUIC {
+			t.Fatal("expected quic to be disabled in a private network")
+		}
+	}
+
+	f, err := ioutil.TempFile("", "swarm.key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	fmt.Fprintf(f, "/key/swarm/psk/1.0.0/\n/base16/\n%s\n", key)
+	f.Close()
+
+	c = NewDefaultConfig()
+	c.PrivateNetwork.KeyFile = f.Name()
+	if psk, err = c.PrivateNetwork.LoadKey(); err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(psk) != key {
+		t.Fatalf("expected the key from the file, got %x", psk)
+	}
+
+	for _, input := range []string{
+		`{"PrivateNetwork": {"Key": "not hex"}}`,
+		`{"PrivateNetwork": {"Key": "abab"}}`,
+		fmt.Sprintf(`{"PrivateNetwork": {"Key": "%s", "KeyFile": "swarm.key"}}`, key),
+	} {
+		if err := json.Unmarshal([]byte(input), &c); err == nil {
+			t.Fatalf("expected %s to be rejected", input)
+		}
+	}
+}
+
+func TestHostAddressTransports(t *testing.T) {
+	validate := func(transports []string, addr string) error {
+		c := NewDefaultConfig()
+		c.Transports = transports
+		c.HostAddresses = MaddrArray{multiaddr.StringCast(addr)}
+		return c.Validate()
+	}
+
+	for _, addr := range []string{
+		"/ip4/0.0.0.0/tcp/4001",
+		"/ip4/0.0.0.0/tcp/4002/ws",
+		"/ip4/0.0.0.0/udp/4001/quic",
+	} {
+		if err := validate([]string{TransportTCP, TransportQUIC, TransportWebsocket}, addr); err != nil {
+			t.Fatalf("expected %s to be accepted: %s", addr, err)
+		}
+	}
+
+	for _, tc := range []struct {
+		transports []string
+		addr       string
+	}{
+		{[]string{TransportTCP, TransportQUIC}, "/ip4/0.0.0.0/tcp/4002/ws"},
+		{[]string{TransportWebsocket}, "/ip4/0.0.0.0/tcp/4001"},
+		{[]string{TransportTCP}, "/ip4/0.0.0.0/udp/4001/quic"},
+		{[]string{TransportTCP, TransportWebsocket}, "/ip4/0.0.0.0/tcp/4002/wss"},
+	} {
+		if err := validate(tc.transports, tc.addr); err == nil {
+			t.Fatalf("expected %s to be rejected with transports %v", tc.addr, tc.transports)
+		}
+	}
+}
+
+func TestTransports(t *testing.T) {
+	var c Config
+	if err := json.Unmarshal([]byte(`{"Transports": ["quic"]}`), &c); err != nil {
+		t.Fatal(err)
+	}
+	if got := c.EnabledTransports(); len(got) != 1 || got[0] != TransportQUIC {
+		t.Fatalf("expected only quic to be enabled, got %v", got)
+	}
+
+	if err := json.Unmarshal([]byte(`{"QUIC": false}`), &c); err != nil {
+		t.Fatal(err)
+	}
+	for _, transport := range c.EnabledTransports() {
+		if transport == TransportQUIC {
+			t.Fatal("expected the QUIC option to disable quic")
+		}
+	}
+
+	for _, input := range []string{
+		`{"Transports": []}`,
+		`{"Transports": ["quic"], "QUIC": false}`,
+		`{"Transports": ["udp"]}`,
+		`{"Transports": ["tcp", "tcp"]}`,
+	} {
+		if err := json.Unmarshal([]byte(input), &c); err == nil {
+			t.Fatalf("expected %s to be rejected", input)
+		}
+	}
+}
+
+func TestInboundRateLimit(t *testing.T) {
+	var c Config
+	if err := json.Unmarshal([]byte(`{"InboundRateLimit": {"Enabled": true, "Burst": 4}}`), &c); err != nil {
+		t.Fatal(err)
+	}
+	if c.InboundRateLimit.Connections != 16 || c.InboundRateLimit.Window != time.Minute {
+		t.Fatalf("expected the default rate, got %+v", c.InboundRateLimit)
+	}
+
+	if err := json.Unmarshal([]byte(`{"InboundRateLimit": {"Enabled": true, "Burst": 0}}`), &c); err == nil {
+		t.Fatal("expected a zero burst to be rejected")
+	}
+}
+
+func TestDial(t *testing.T) {
+	var c Config
+	if err := json.Unmarshal([]byte(`{"Dial": {"Timeout": 5000000000, "MaxConcurrent": 16}}`), &c); err != nil {
+		t.Fatal(err)
+	}
+	if c.Dial.Timeout != 5*time.Second || c.Dial.MaxConcurrent != 16 {
+		t.Fatalf("expected the dial limits to be set, got %+v", c.Dial)
+	}
+
+	c = Config{}
+	if err := json.Unmarshal([]byte(`{"Dial": {"Preference": ["quic", "10.0.0.0/8"], "PreferenceTimeout": 2000000000}}`), &c); err != nil {
+		t.Fatal(err)
+	}
+	if len(c.Dial.Preference) != 2 || c.Dial.Preference[0] != "quic" || c.Dial.PreferenceTimeout != 2*time.Second {
+		t.Fatalf("expected the dial preference to be set, got %+v", c.Dial)
+	}
+
+	for _, input := range []string{
+		`{"Dial": {"Timeout": -1}}`,
+		`{"Dial": {"MaxConcurrent": -1}}`,
+		`{"Dial": {"PreferenceTimeout": -1}}`,
+	} {
+		if err := json.Unmarshal([]byte(input), &c); err == nil {
+			t.Fatalf("expected %s to be rejected", input)
+		}
+	}
+}
+
+func TestPeerLists(t *testing.T) {
+	const id = "QmcgpsyWgH8Y8ajJz1Cu72KnS5uo2Aa2LpzU7kinSupNKC"
+
+	var c Config
+	if err := json.Unmarshal([]byte(`{"BlockedPeers": ["`+id+`"]}`), &c); err != nil {
+		t.Fatal(err)
+	}
+	if _, blocked, err := c.PeerLists(); err != nil || len(blocked) != 1 {
+		t.Fatalf("expected one blocked peer, got %v (%v)", blocked, err)
+	}
+
+	for _, input := range []string{
+		`{"AllowedPeers": ["not a peer"]}`,
+		`{"AllowedPeers": ["` + id + `"], "BlockedPeers": ["` + id + `"]}`,
+	} {
+		if err := json.Unmarshal([]byte(input), &c); err == nil {
+			t.Fatalf("expected %s to be rejected", input)
+		}
+	}
+}
+
+func TestRelayRefresh(t *testing.T) {
+	var c Config
+	if err := json.Unmarshal([]byte(`{"Relay": {"RefreshInterval": 60000000000}}`), &c); err != nil {
+		t.Fatal(err)
+	}
+	if c.Relay.DesiredRelays != 1 {
+		t.Fatalf("expected the default desired relays, got %d", c.Relay.DesiredRelays)
+	}
+
+	for _, input := range []string{
+		`{"Relay": {"DesiredRelays": 0}}`,
+		`{"Relay": {"RefreshInterval": -1}}`,
+	} {
+		if err := json.Unmarshal([]byte(input), &c); err == nil {
+			t.Fatalf("expected %s to be rejected", input)
+		}
+	}
+
+	// static relays stand in for the DHT
+	c = NewDefaultConfig()
+	c.Relay.Auto = true
+	if err := c.Validate(); err == nil {
+		t.Fatal("expected autorelay to be rejected without the DHT or static relays")
+	}
+	c.Relay.StaticRelays = MaddrArray{multiaddr.StringCast("/ip4/147.75.80.110/tcp/4001/p2p/QmbFgm5zan8P6eWWmeyfncR5feYEMPbht5b1FW1C37aQ7y")}
+	if err := c.Validate(); err != nil {
+		t.Fatalf("expected autorelay with static relays to be accepted: %s", err)
+	}
+	c.Relay.StaticRelays = MaddrArray{multiaddr.StringCast("/ip4/147.75.80.110/tcp/4001")}
+	if err := c.Validate(); err == nil {
+		t.Fatal("expected a static relay without a peer id to be rejected")
+	}
+}
+
+func TestRelayService(t *testing.T) {
+	// a relay client only
+	c := NewDefaultConfig()
+	c.Relay.Hop = false
+	if err := c.Validate(); err != nil {
+		t.Fatalf("expected a relay client to be accepted: %s", err)
+	}
+
+	for _, input := range []string{
+		`{"Relay": {"Enabled": false, "Hop": true}}`,
+		`{"Relay": {"Enabled": false, "Discovery": true}}`,
+		`{"Relay": {"Active": true}}`,
+		`{"Relay": {"HopLimit": 10}}`,
+		`{"Relay": {"Hop": true, "HopLimit": -1}}`,
+	} {
+		if err := json.Unmarshal([]byte(input), &c); err == nil {
+			t.Fatalf("expected %s to be rejected", input)
+		}
+	}
+
+	if err := json.Unmarshal([]byte(`{"Relay": {"Hop": true, "Active": true, "HopLimit": 10}}`), &c); err != nil {
+		t.Fatalf("expected a relay service to be accepted: %s", err)
+	}
+}
+
+func TestAddressFilter(t *testing.T) {
+	addrs := []multiaddr.Multiaddr{
+		multiaddr.StringCast("/ip4/0.0.0.0/tcp/0"),
+		multiaddr.StringCast("/ip6/::/tcp/0"),
+		multiaddr.StringCast("/dns4/example.com/tcp/4001"),
+		multiaddr.StringCast("/dns6/example.com/tcp/4001"),
+		multiaddr.StringCast("/dns/example.com/tcp/4001"),
+	}
+
+	c := NewDefaultConfig()
+	if c.AddressFilter != AddressFilterBoth {
+		t.Fatalf("expected both address families by default, got %s", c.AddressFilter)
+	}
+	if filtered := c.FilterAddrs(addrs); len(filtered) != len(addrs) {
+		t.Fatalf("expected no address to be filtered out, got %v", filtered)
+	}
+
+	c.AddressFilter = AddressFilterIP4
+	if filtered := c.FilterAddrs(addrs); len(filtered) != 3 || !filtered[0].Equal(addrs[0]) ||
+		!filtered[1].Equal(addrs[2]) || !filtered[2].Equal(addrs[4]) {
+		t.Fatalf("expected the ip4 and dns addresses to be kept, got %v", filtered)
+	}
+
+	c.AddressFilter = AddressFilterIP6
+	if filtered := c.FilterAddrs(addrs); len(filtered) != 3 || !filtered[0].Equal(addrs[1]) ||
+		!filtered[1].Equal(addrs[3]) || !filtered[2].Equal(addrs[4]) {
+		t.Fatalf("expected the ip6 and dns addresses to be kept, got %v", filtered)
+	}
+
+	c.AnnounceAddresses = MaddrArray{multiaddr.StringCast("/ip4/1.2.3.4/tcp/4001")}
+	if err := c.Validate(); err == nil {
+		t.Fatal("expected an announced address filtered out to be rejected")
+	}
+	c.AnnounceAddresses = nil
+	c.HostAddresses = MaddrArray{multiaddr.StringCast("/ip4/0.0.0.0/tcp/4001")}
+	if err := c.Validate(); err == nil {
+		t.Fatal("expected a host address filtered out to be rejected")
+	}
+
+	if err := json.Unmarshal([]byte(`{"AddressFilter": "ip5"}`), &c); err == nil {
+		t.Fatal("expected an unknown address filter to be rejected")
+	}
+	if err := json.Unmarshal([]byte(`{"AddressFilter": "ip6"}`), &c); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMaddrParsing(t *testing.T) {
+	relay := "/ip4/147.75.80.110/tcp/4001/p2p/QmbFgm5zan8P6eWWmeyfncR5feYEMPbht5b1FW1C37aQ7y"
+
+	var c Config
+	if err := json.Unmarshal([]byte(`{
+		"ListenAddr": "/unix/tmp/test.sock",
+		"Bootstrap": {"Peers": ["`+relay+`"]},
+		"HostAddresses": "/ip4/0.0.0.0/tcp/4001,/ip6/::/tcp/4001"
+	}`), &c); err != nil {
+		t.Fatal(err)
+	}
+	if c.ListenAddr.String() != "/unix/tmp/test.sock" {
+		t.Fatalf("unexpected listen address %s", c.ListenAddr)
+	}
+	if len(c.Bootstrap.Peers) != 1 || c.Bootstrap.Peers[0].String() != relay {
+		t.Fatalf("unexpected bootstrap peers %v", c.Bootstrap.Peers)
+	}
+	if len(c.HostAddresses) != 2 {
+		t.Fatalf("expected the comma separated host addresses to be parsed, got %v", c.HostAddresses)
+	}
+
+	// errors point at the invalid entry
+	err := json.Unmarshal([]byte(`{"AnnounceAddresses": ["/ip4/1.2.3.4/tcp/4001", "/ip4/1.2.3.4/tcpp/4001"]}`), &c)
+	var maddrErr *MaddrError
+	if !errors.As(err, &maddrErr) || maddrErr.Index != 1 || maddrErr.Addr != "/ip4/1.2.3.4/tcpp/4001" {
+		t.Fatalf("expected an error about the second address, got %v", err)
+	}
+
+	_, err = ParseMaddrs("-hostAddrs", "/ip4/0.0.0.0/tcp/4001,/ip4/0.0.0.0/tcp,/ip6/::/tcp/4001")
+	if err == nil || !strings.HasPrefix(err.Error(), "-hostAddrs[1]: ") {
+		t.Fatalf("expected an error naming the flag and index, got %v", err)
+	}
+
+	err = json.Unmarshal([]byte(`{"Bootstrap": {"Peers": ["`+relay+`", "/ip4/147.75.80.110/tcp/4001"]}}`), &c)
+	if err == nil || !strings.HasPrefix(err.Error(), "Bootstrap.Peers[1]: ") {
+		t.Fatalf("expected an error naming the bootstrap peer without a peer id, got %v", err)
+	}
+}
+
+func TestAnnounceTemplates(t *testing.T) {
+	listenAddrs := []multiaddr.Multiaddr{
+		multiaddr.StringCast("/ip4/192.168.1.2/tcp/4001"),
+		multiaddr.StringCast("/ip6/::1/tcp/4002"),
+		multiaddr.StringCast("/ip4/192.168.1.2/udp/4003/quic"),
+	}
+
+	c := NewDefaultConfig()
+	if addrs := c.AnnounceAddrs(listenAddrs); len(addrs) != len(listenAddrs) {
+		t.Fatalf("expected the listen addresses to be announced, got %v", addrs)
+	}
+
+	c.AnnounceAddresses = MaddrArray{multiaddr.StringCast("/ip4/1.2.3.4/tcp/5000")}
+	c.AnnounceTemplates = []string{
+		"/ip4/1.2.3.4/tcp/{PORT}",
+		"/ip6/2001:db8::1/tcp/{PORT}",
+		"/dns4/example.com/udp/{PORT}/quic",
+		// nothing listens on websocket
+		"/ip4/1.2.3.4/tcp/{PORT}/ws",
+	}
+	if err := c.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	var announced []string
+	for _, addr := range c.AnnounceAddrs(listenAddrs) {
+		announced = append(announced, addr.String())
+	}
+	expected := []string{
+		"/ip4/1.2.3.4/tcp/5000",
+		"/ip4/1.2.3.4/tcp/4001",
+		"/ip6/2001:db8::1/tcp/4002",
+		"/dns4/example.com/udp/4003/quic",
+	}
+	if strings.Join(announced, " ") != strings.Join(expected, " ") {
+		t.Fatalf("expected %v to be announced, got %v", expected, announced)
+	}
+
+	for _, template := range []string{
+		"/ip4/1.2.3.4/tcp/4001",
+		"/ip4/1.2.3.4/tcp/{PORT}/udp/{PORT}",
+		"/ip4/{PORT}/tcp/4001",
+		"/ip4/1.2.3.4/tcpp/{PORT}",
+	} {
+		c.AnnounceTemplates = []string{template}
+		if err := c.Validate(); err == nil {
+			t.Fatalf("expected template %s to be rejected", template)
+		}
+	}
+}
+
+func TestGossipSubParams(t *testing.T) {
+	var c Config
+	if err := json.Unmarshal([]byte(`{"PubSub": {
+		"GossipSubHeartbeat": {"Interval": 500000000},
+		"GossipSub": {"D": 12, "Dlo": 10, "Dhi": 16, "HistoryLength": 10, "HistoryGossip": 4}
+	}}`), &c); err != nil {
+		t.Fatal(err)
+	}
+
+	params := c.PubSub.GossipSubParams()
+	if params.D != 12 || params.Dlo != 10 || params.Dhi != 16 || params.HistoryLength != 10 || params.HistoryGossip != 4 {
+		t.Fatalf("expected the configured parameters, got %+v", params)
+	}
+	if params.HeartbeatInterval != 500*time.Millisecond {
+		t.Fatalf("expected the configured heartbeat interval, got %s", params.HeartbeatInterval)
+	}
+	// unset parameters keep their default
+	if params.Dlazy != ps.GossipSubDlazy || params.Dout != ps.GossipSubDout {
+		t.Fatalf("expected the default Dlazy and Dout, got %+v", params)
+	}
+
+	for _, input := range []string{
+		`{"PubSub": {"GossipSub": {"D": -1}}}`,
+		// the default D is 6
+		`{"PubSub": {"GossipSub": {"Dlo": 8}}}`,
+		`{"PubSub": {"GossipSub": {"D": 10, "Dlo": 8, "Dhi": 9}}}`,
+		`{"PubSub": {"GossipSub": {"Dout": 4}}}`,
+		`{"PubSub": {"GossipSub": {"HistoryLength": 2, "HistoryGossip": 3}}}`,
+	} {
+		if err := json.Unmarshal([]byte(input), &c); err == nil {
+			t.Fatalf("expected %s to be rejected", input)
+		}
+	}
+}
+
+func TestPubSubMessageID(t *testing.T) {
+	var c Config
+	if err := json.Unmarshal([]byte(`{"PubSub": {"MessageID": {"Scheme": "prefix", "PrefixLength": 16}}}`), &c); err != nil {
+		t.Fatal(err)
+	}
+	if c.PubSub.MessageID.Scheme != "prefix" || c.PubSub.MessageID.PrefixLength != 16 {
+		t.Fatalf("expected 16 byte prefix message ids, got %+v", c.PubSub.MessageID)
+	}
+
+	for _, input := range []string{
+		`{"PubSub": {"MessageID": {"Scheme": "random"}}}`,
+		`{"PubSub": {"MessageID": {"Scheme": "prefix"}}}`,
+		`{"PubSub": {"MessageID": {"Scheme": "content", "PrefixLength": 16}}}`,
+		`{"PubSub": {"SignStrict": false, "MessageID": {"Scheme": "prefix", "PrefixLength": 16}}}`,
+	} {
+		if err := json.Unmarshal([]byte(input), &c); err == nil {
+			t.Fatalf("expected %s to be rejected", input)
+		}
+	}
+}
+
+func TestUserAgent(t *testing.T) {
+	var c Config
+	if err := json.Unmarshal([]byte(`{}`), &c); err != nil {
+		t.Fatal(err)
+	}
+	if c.UserAgent != "" {
+		t.Fatalf("expected the user agent to be derived by default, got %q", c.UserAgent)
+	}
+
+	if err := json.Unmarshal([]byte(`{"UserAgent": "acme-p2pd/1.2"}`), &c); err != nil {
+		t.Fatal(err)
+	}
+	if c.UserAgent != "acme-p2pd/1.2" {
+		t.Fatalf("expected the configured user agent, got %q", c.UserAgent)
+	}
+
+	if err := json.Unmarshal([]byte(`{"UserAgent": " \t"}`), &c); err == nil {
+		t.Fatal("expected a blank user agent to be rejected")
+	}
+}
+
+func TestMetadata(t *testing.T) {
+	var c Config
+	if err := json.Unmarshal([]byte(`{"Metadata": {"role": "trainer"}}`), &c); err != nil {
+		t.Fatal(err)
+	}
+	if c.Metadata["role"] != "trainer" {
+		t.Fatalf("expected the configured metadata, got %v", c.Metadata)
+	}
+
+	md, err := ParseMetadata("role=aggregator, zone=eu-west")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(md) != 2 || md["role"] != "aggregator" || md["zone"] != "eu-west" {
+		t.Fatalf("expected two metadata entries, got %v", md)
+	}
+	if _, err := ParseMetadata("role"); err == nil {
+		t.Fatal("expected an entry without a value to be rejected")
+	}
+
+	for _, input := range []string{
+		`{"Metadata": {"": "trainer"}}`,
+		fmt.Sprintf(`{"Metadata": {"role": %q}}`, strings.Repeat("a", 4097)),
+	} {
+		if err := json.Unmarshal([]byte(input), &c); err == nil {
+			t.Fatalf("expected %.40s to be rejected", input)
+		}
+	}
+}
+
+func TestApplyEnv(t *testing.T) {
+	peer := "/ip4/147.75.80.110/tcp/4001/p2p/QmbFgm5zan8P6eWWmeyfncR5feYEMPbht5b1FW1C37aQ7y"
+	env := map[string]string{
+		"P2PD_LISTEN":                          "/ip4/127.0.0.1/tcp/5000",
+		"P2PD_DHT_MODE":                        DHTClientMode,
+		"P2PD_BOOTSTRAP":                       "1",
+		"P2PD_BOOTSTRAP_PEERS":                 peer,
+		"P2PD_BOOTSTRAP_RETRY_MULTIPLIER":      "1.5",
+		"P2PD_CONNECTION_MANAGER_GRACE_PERIOD": "30s",
+		"P2PD_PUBSUB_GOSSIPSUB_D":              "8",
+		"P2PD_HTTP_CONTROL_ADDRESS":            "127.0.0.1:8080",
+		"P2PD_TRANSPORTS":                      "tcp, quic",
+		"P2PD_PPROF_PORT":                      "6061",
+		"P2PD_QUIET":                           "",
+	}
+	lookup := func(name string) (string, bool) {
+		v, ok := env[name]
+		return v, ok
+	}
+
+	c := NewDefaultConfig()
+	c.Quiet = true
+	if err := c.ApplyEnv(lookup); err != nil {
+		t.Fatal(err)
+	}
+
+	if c.ListenAddr.String() != "/ip4/127.0.0.1/tcp/5000" {
+		t.Fatalf("expected the listen address from the environment, got %s", c.ListenAddr)
+	}
+	if c.DHT.Mode != DHTClientMode || !c.Bootstrap.Enabled || c.Bootstrap.Retry.Multiplier != 1.5 {
+		t.Fatalf("expected the DHT and bootstrap settings from the environment, got %+v %+v", c.DHT, c.Bootstrap)
+	}
+	if len(c.Bootstrap.Peers) != 1 || c.Bootstrap.Peers[0].String() != peer {
+		t.Fatalf("expected the bootstrap peers from the environment, got %v", c.Bootstrap.Peers)
+	}
+	if c.ConnectionManager.GracePeriod != 30*time.Second || c.PubSub.GossipSub.D != 8 || c.PProf.Port != 6061 {
+		t.Fatalf("expected the numeric settings from the environment, got %+v %+v %+v", c.ConnectionManager, c.PubSub.GossipSub, c.PProf)
+	}
+	if c.HTTPControl.Address != "127.0.0.1:8080" {
+		t.Fatalf("expected the HTTP control address from the environment, got %s", c.HTTPControl.Address)
+	}
+	if len(c.Transports) != 2 || c.Transports[0] != TransportTCP || c.Transports[1] != TransportQUIC {
+		t.Fatalf("expected the transports from the environment, got %v", c.Transports)
+	}
+	// empty variables are ignored
+	if !c.Quiet {
+		t.Fatal("expected an empty variable to leave the setting alone")
+	}
+
+	for name, value := range map[string]string{
+		"P2PD_BOOTSTRAP":                       "maybe",
+		"P2PD_CONNECTION_MANAGER_GRACE_PERIOD": "30",
+		"P2PD_BOOTSTRAP_PEERS":                 "not a multiaddr",
+	} {
+		c := NewDefaultConfig()
+		err := c.ApplyEnv(func(n string) (string, bool) {
+			if n == name {
+				return value, true
+			}
+			return "", false
+		})
+		if err == nil || !strings.Contains(err.Error(), name) {
+			t.Fatalf("expected %s=%s to be rejected naming the variable, got %v", name, value, err)
+		}
+	}
+}
+
+func TestEnvName(t *testing.T) {
+	for field, name := range map[string]string{
+		"HTTPControl":         "HTTP_CONTROL",
+		"MaxUnaryMessageSize": "MAX_UNARY_MESSAGE_SIZE",
+		"DHT":                 "DHT",
+		"ID":                  "ID",
+		"Dlo":                 "DLO",
+		"PProf":               "PPROF",
+		"ListenAddr":          "LISTEN",
+	} {
+		if got := envName(field); got != name {
+			t.Fatalf("expected %s to be set by %s, got %s", field, name, got)
+		}
+	}
+}
+
+func TestProvide(t *testing.T) {
+	const id = "QmbFgm5zan8P6eWWmeyfncR5feYEMPbht5b1FW1C37aQ7y"
+
+	var c Config
+	if err := json.Unmarshal([]byte(`{"DHT": {"Mode": "full"}, "Provide": {"CIDs": ["`+id+`"]}}`), &c); err != nil {
+		t.Fatal(err)
+	}
+	if c.Provide.Interval != 12*time.Hour {
+		t.Fatalf("expected the default reprovide interval, got %s", c.Provide.Interval)
+	}
+	cids, err := c.Provide.ParseCIDs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cids) != 1 || cids[0].String() != id {
+		t.Fatalf("expected %s to be provided, got %v", id, cids)
+	}
+
+	for _, input := range []string{
+		`{"Provide": {"CIDs": ["` + id + `"]}}`,
+		`{"DHT": {"Mode": "full"}, "Provide": {"CIDs": ["not a cid"]}}`,
+		`{"Provide": {"Interval": 0}}`,
+	} {
+		if err := json.Unmarshal([]byte(input), &c); err == nil {
+			t.Fatalf("expected %s to be rejected", input)
+		}
+	}
+}
+
+func TestRendezvous(t *testing.T) {
+	var c Config
+	if err := json.Unmarshal([]byte(`{"DHT": {"Mode": "full"}, "Rendezvous": {"Namespace": "swarm"}}`), &c); err != nil {
+		t.Fatal(err)
+	}
+	if c.Rendezvous.Namespace != "swarm" || c.Rendezvous.Interval != 10*time.Minute {
+		t.Fatalf("expected the namespace with the default interval, got %+v", c.Rendezvous)
+	}
+
+	for _, input := range []string{
+		`{"Rendezvous": {"Namespace": "swarm"}}`,
+		`{"Rendezvous": {"Interval": 0}}`,
+	} {
+		if err := json.Unmarshal([]byte(input), &c); err == nil {
+			t.Fatalf("expected %s to be rejected", input)
+		}
+	}
+}
+
+func TestCallHistorySize(t *testing.T) {
+	var c Config
+	if err := json.Unmarshal([]byte(`{"CallHistorySize": 100}`), &c); err != nil {
+		t.Fatal(err)
+	}
+	if c.CallHistorySize != 100 {
+		t.Fatalf("expected a call history of 100 calls, got %d", c.CallHistorySize)
+	}
+
+	if err := json.Unmarshal([]byte(`{"CallHistorySize": -1}`), &c); err == nil {
+		t.Fatal("expected a negative call history size to be rejected")
+	}
+}
+
+func TestTracing(t *testing.T) {
+	c := NewDefaultConfig()
+	if c.Tracing.SampleRatio != 1 {
+		t.Fatalf("expected every trace to be sampled by default, got %v", c.Tracing.SampleRatio)
+	}
+
+	if err := json.Unmarshal([]byte(`{"Tracing": {"Endpoint": "localhost:4317", "SampleRatio": 0.25}}`), &c); err != nil {
+		t.Fatal(err)
+	}
+	if c.Tracing.Endpoint != "localhost:4317" || c.Tracing.SampleRatio != 0.25 {
+		t.Fatalf("unexpected tracing config %+v", c.Tracing)
+	}
+
+	if err := json.Unmarshal([]byte(`{"Tracing": {"SampleRatio": 1.5}}`), &c); err == nil {
+		t.Fatal("expected a sample ratio above 1 to be rejected")
+	}
+}
+
+func TestMaxPersistentConns(t *testing.T) {
+	var c Config
+	if err := json.Unmarshal([]byte(`{"MaxPersistentConns": 16}`), &c); err != nil {
+		t.Fatal(err)
+	}
+	if c.MaxPersistentConns != 16 {
+		t.Fatalf("expected at most 16 persistent connections, got %d", c.MaxPersistentConns)
+	}
+
+	if err := json.Unmarshal([]byte(`{"MaxPersistentConns": -1}`), &c); err == nil {
+		t.Fatal("expected a negative maximum to be rejected")
+	}
+}
+
+func TestNegotiationTimeout(t *testing.T) {
+	var c Config
+	if err := json.Unmarshal([]byte(`{"NegotiationTimeout": 5000000000}`), &c); err != nil {
+		t.Fatal(err)
+	}
+	if c.NegotiationTimeout != 5*time.Second {
+		t.Fatalf("expected a negotiation timeout of 5s, got %s", c.NegotiationTimeout)
+	}
+
+	if err := json.Unmarshal([]byte(`{"NegotiationTimeout": -1}`), &c); err == nil {
+		t.Fatal("expected a negative negotiation timeout to be rejected")
+	}
+}
+
+func TestControlTLS(t *testing.T) {
+	var c Config
+	if err := json.Unmarshal([]byte(`{"ControlTLS": {"CAFile": "ca.pem", "CertFile": "cert.pem", "KeyFile": "key.pem"}}`), &c); err != nil {
+		t.Fatal(err)
+	}
+	if !c.ControlTLS.Enabled() || c.ControlTLS.KeyFile != "key.pem" {
+		t.Fatalf("expected control TLS to be enabled, got %+v", c.ControlTLS)
+	}
+
+	var partial Config
+	if err := json.Unmarshal([]byte(`{"ControlTLS": {"CertFile": "cert.pem", "KeyFile": "key.pem"}}`), &partial); err == nil {
+		t.Fatal("expected control TLS without a CA to be rejected")
+	}
+	if NewDefaultConfig().ControlTLS.Enabled() {
+		t.Fatal("expected control TLS to be disabled by default")
+	}
+}
+
+func TestMarshalRoundTrip(t *testing.T) {
+	c := NewDefaultConfig()
+	c.Bootstrap.Peers = MaddrArray{multiaddr.StringCast("/ip4/1.2.3.4/tcp/4001")}
+	out, err := json.Marshal(&c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded Config
+	if err := json.Unmarshal(out, &decoded); err != nil {
+		t.Fatalf("expected the marshaled config to be valid, got %s", err)
+	}
+	again, err := json.Marshal(&decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(again) != string(out) {
+		t.Fatalf("expected the config to survive a round trip, got %s, then %s", out, again)
+	}
+}
+
+func TestRelayReconnect(t *testing.T) {
+	var c Config
+	if err := json.Unmarshal([]byte(`{"Relay": {"Reconnect": {"MaxBackoff": 60000000000}}}`), &c); err != nil {
+		t.Fatal(err)
+	}
+	if r := c.Relay.Reconnect; r.InitialBackoff != 5*time.Second || r.MaxBackoff != time.Minute || r.Multiplier != 2 || r.Jitter != 0.2 {
+		t.Fatalf("expected the default backoff up to a minute, got %+v", r)
+	}
+
+	for _, input := range []string{
+		`{"Relay": {"Reconnect": {"InitialBackoff": 0}}}`,
+		`{"Relay": {"Reconnect": {"InitialBackoff": 600000000000}}}`,
+		`{"Relay": {"Reconnect": {"Multiplier": 0.5}}}`,
+		`{"Relay": {"Reconnect": {"Jitter": 1}}}`,
+	} {
+		var c Config
+		if err := json.Unmarshal([]byte(input), &c); err == nil {
+			t.Fatalf("expected %s to be rejected", input)
+		}
+	}
+}
+
+func TestExtraIdentities(t *testing.T) {
+	var c Config
+	if err := json.Unmarshal([]byte(`{"ExtraIdentities": ["key2=/unix/tmp/p2pd2.sock", "key3=/ip4/127.0.0.1/tcp/9000"]}`), &c); err != nil {
+		t.Fatal(err)
+	}
+	identities, err := c.ParseExtraIdentities()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(identities) != 2 || identities[0].KeyFile != "key2" || identities[1].ListenAddr.String() != "/ip4/127.0.0.1/tcp/9000" {
+		t.Fatalf("expected two extra identities, got %v", identities)
+	}
+
+	for _, input := range []string{
+		`{"ExtraIdentities": ["/unix/tmp/p2pd2.sock"]}`,
+		`{"ExtraIdentities": ["key2=not a multiaddr"]}`,
+		`{"ExtraIdentities": ["-=/unix/tmp/p2pd2.sock"]}`,
+		`{"ExtraIdentities": ["key2=/unix/tmp/p2pd.sock"]}`,
+		`{"ExtraIdentities": ["key2=/unix/tmp/p2pd2.sock", "key3=/unix/tmp/p2pd2.sock"]}`,
+		`{"ExtraIdentities": ["key2=/unix/tmp/p2pd2.sock"], "HostAddresses": ["/ip4/0.0.0.0/tcp/4001"]}`,
+		`{"ExtraIdentities": ["key2=/unix/tmp/p2pd2.sock"], "Peerstore": {"Path": "/tmp/peerstore"}}`,
+	} {
+		var c Config
+		if err := json.Unmarshal([]byte(input), &c); err == nil {
+			t.Fatalf("expected %s to be rejected", input)
+		}
+	}
+}
+
+func TestUnixSocket(t *testing.T) {
+	var c Config
+	if err := json.Unmarshal([]byte(`{"UnixSocket": {"Mode": "0660", "Group": "p2pd", "Backlog": 64}}`), &c); err != nil {
+		t.Fatal(err)
+	}
+	mode, err := c.UnixSocket.FileMode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode != 0660 || c.UnixSocket.Group != "p2pd" || c.UnixSocket.Backlog != 64 {
+		t.Fatalf("expected mode 0660 for group p2pd with a backlog of 64, got %s %+v", mode, c.UnixSocket)
+	}
+	if mode, err := NewDefaultConfig().UnixSocket.FileMode(); err != nil || mode != 0 {
+		t.Fatalf("expected the mode to be left to the umask by default, got %s, %v", mode, err)
+	}
+
+	for _, input := range []string{
+		`{"UnixSocket": {"Mode": "rw-rw----"}}`,
+		`{"UnixSocket": {"Mode": "01777"}}`,
+		`{"UnixSocket": {"Mode": "0"}}`,
+		`{"UnixSocket": {"Backlog": -1}}`,
+	} {
+		var c Config
+		if err := json.Unmarshal([]byte(input), &c); err == nil {
+			t.Fatalf("expected %s to be rejected", input)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/multiformats/go-multiaddr"
+)
+
+// EnvPrefix prefixes the names of the environment variables read by
+// ApplyEnv.
+const EnvPrefix = "P2PD_"
+
+// envNames names the fields whose environment variable isn't derived from
+// their name by envName.
+var envNames = map[string]string{
+	"ListenAddr":         "LISTEN",
+	"PubSub":             "PUBSUB",
+	"GossipSub":          "GOSSIPSUB",
+	"GossipSubHeartbeat": "GOSSIPSUB_HEARTBEAT",
+	"PProf":              "PPROF",
+	"AutoNat":            "AUTONAT",
+	"CIDs":               "CIDS",
+}
+
+var (
+	durationType  = reflect.TypeOf(time.Duration(0))
+	jsonMaddrType = reflect.TypeOf(JSONMaddr{})
+	maddrsType    = reflect.TypeOf(MaddrArray{})
+)
+
+// ApplyEnv overrides the fields of c set in the environment, as looked up by
+// lookup, e.g. os.LookupEnv. The variable of a field is named after its path
+// in the config, in upper snake case and prefixed with EnvPrefix: DHT.Mode is
+// set by P2PD_DHT_MODE and ConnectionManager.LowWaterMark by
+// P2PD_CONNECTION_MANAGER_LOW_WATER_MARK. ListenAddr is set by P2PD_LISTEN,
+// and the variable of a section with an Enabled field, e.g. P2PD_BOOTSTRAP,
+// sets that field.
+//
+// Durations are parsed by time.ParseDuration, lists and multiaddr lists are
+// comma-separated, maps are comma-separated key=value pairs, and variables set
+// to an empty string are ignored. Like json.Unmarshal, ApplyEnv leaves
+// validation to Validate.
+func (c *Config) ApplyEnv(lookup func(string) (string, bool)) error {
+	return applyEnv(reflect.ValueOf(c).Elem(), EnvPrefix, lookup)
+}
+
+func applyEnv(v reflect.Value, prefix string, lookup func(string) (string, bool)) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := prefix + envName(t.Field(i).Name)
+		fv := v.Field(i)
+
+		if fv.Kind() == reflect.Struct && fv.Type() != jsonMaddrType {
+			if enabled := fv.FieldByName("Enabled"); enabled.IsValid() {
+				if err := setEnv(enabled, name, lookup); err != nil {
+					return err
+				}
+			}
+			if err := applyEnv(fv, name+"_", lookup); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := setEnv(fv, name, lookup); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setEnv(v reflect.Value, name string, lookup func(string) (string, bool)) error {
+	s, ok := lookup(name)
+	if !ok || s == "" {
+		return nil
+	}
+
+	switch v.Type() {
+	case durationType:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		v.SetInt(int64(d))
+		return nil
+	case jsonMaddrType:
+		addr, err := multiaddr.NewMultiaddr(s)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		v.Set(reflect.ValueOf(JSONMaddr{addr}))
+		return nil
+	case maddrsType:
+		addrs, err := ParseMaddrs(name, s)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(addrs))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		v.SetBool(b)
+	case reflect.Int:
+		n, err := strconv.ParseInt(s, 10, 0)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		v.SetInt(n)
+	case reflect.Uint:
+		n, err := strconv.ParseUint(s, 10, 0)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		v.SetUint(n)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		items := strings.Split(s, ",")
+		for i := range items {
+			items[i] = strings.TrimSpace(items[i])
+		}
+		v.Set(reflect.ValueOf(items))
+	case reflect.Map:
+		md, err := ParseMetadata(s)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		v.Set(reflect.ValueOf(md))
+	default:
+		return fmt.Errorf("%s: unsupported config field type %s", name, v.Type())
+	}
+	return nil
+}
+
+// envName converts a field name to upper snake case, keeping acronyms
+// together: HTTPControl becomes HTTP_CONTROL.
+func envName(field string) string {
+	if name, ok := envNames[field]; ok {
+		return name
+	}
+
+	runes := []rune(field)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prevLower := unicode.IsLower(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || (nextLower && unicode.IsUpper(runes[i-1])) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
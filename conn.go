@@ -8,6 +8,7 @@ import (
 
 	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/peerstore"
 	"github.com/libp2p/go-libp2p-core/protocol"
 
 	pb "github.com/libp2p/go-libp2p-daemon/pb"
@@ -18,10 +19,14 @@ import (
 
 const DefaultTimeout = 60 * time.Second
 
+// controlMsgSizeMax bounds the size of the messages read from clients,
+// including on persistent connections.
+const controlMsgSizeMax = network.MessageSizeMax
+
 func (d *Daemon) handleConn(c net.Conn) {
 	defer c.Close()
 
-	r := ggio.NewDelimitedReader(c, network.MessageSizeMax)
+	r := ggio.NewDelimitedReader(c, controlMsgSizeMax)
 	w := ggio.NewDelimitedWriter(c)
 
 	for {
@@ -66,7 +71,11 @@ func (d *Daemon) handleConn(c net.Conn) {
 			}
 
 			if s != nil {
-				d.doStreamPipe(c, s)
+				if req.StreamOpen.GetFramed() {
+					d.doFramedStreamPipe(r, w, s)
+				} else {
+					d.doStreamPipe(c, s)
+				}
 				return
 			}
 
@@ -146,8 +155,164 @@ func (d *Daemon) handleConn(c net.Conn) {
 				return
 			}
 
+		case pb.Request_LIST_CONNECTIONS:
+			res := d.doListConnections(&req)
+			err := w.WriteMsg(res)
+			if err != nil {
+				log.Debugw("error writing response", "error", err)
+				return
+			}
+
+		case pb.Request_PING:
+			res := d.doPing(&req)
+			err := w.WriteMsg(res)
+			if err != nil {
+				log.Debugw("error writing response", "error", err)
+				return
+			}
+
+		case pb.Request_SET_BOOTSTRAP_PEERS:
+			res := d.doSetBootstrapPeers(&req)
+			err := w.WriteMsg(res)
+			if err != nil {
+				log.Debugw("error writing response", "error", err)
+				return
+			}
+
+		case pb.Request_PEER_LISTS:
+			res := d.doPeerLists(&req)
+			err := w.WriteMsg(res)
+			if err != nil {
+				log.Debugw("error writing response", "error", err)
+				return
+			}
+
+		case pb.Request_RESOURCE_USAGE:
+			res := d.doResourceUsage(&req)
+			err := w.WriteMsg(res)
+			if err != nil {
+				log.Debugw("error writing response", "error", err)
+				return
+			}
+
+		case pb.Request_LIST_RELAYS:
+			res := d.doListRelays(&req)
+			err := w.WriteMsg(res)
+			if err != nil {
+				log.Debugw("error writing response", "error", err)
+				return
+			}
+
+		case pb.Request_BANDWIDTH:
+			res := d.doBandwidth(&req)
+			err := w.WriteMsg(res)
+			if err != nil {
+				log.Debugw("error writing response", "error", err)
+				return
+			}
+
+		case pb.Request_FIND_PEER:
+			res := d.doFindPeer(&req)
+			err := w.WriteMsg(res)
+			if err != nil {
+				log.Debugw("error writing response", "error", err)
+				return
+			}
+
+		case pb.Request_ROUTING_TABLE:
+			res := d.doRoutingTable(&req)
+			err := w.WriteMsg(res)
+			if err != nil {
+				log.Debugw("error writing response", "error", err)
+				return
+			}
+
+		case pb.Request_DAEMON_INFO:
+			res := d.doDaemonInfo(&req)
+			err := w.WriteMsg(res)
+			if err != nil {
+				log.Debugw("error writing response", "error", err)
+				return
+			}
+
+		case pb.Request_AUTONAT:
+			res := d.doAutoNAT(&req)
+			err := w.WriteMsg(res)
+			if err != nil {
+				log.Debugw("error writing response", "error", err)
+				return
+			}
+
+		case pb.Request_CALL_HISTORY:
+			res := d.doCallHistory(&req)
+			err := w.WriteMsg(res)
+			if err != nil {
+				log.Debugw("error writing response", "error", err)
+				return
+			}
+
+		case pb.Request_LIST_ADDRS:
+			res := d.doListAddrs(&req)
+			err := w.WriteMsg(res)
+			if err != nil {
+				log.Debugw("error writing response", "error", err)
+				return
+			}
+
+		case pb.Request_PEERSTORE:
+			res := d.doPeerstore(&req)
+			err := w.WriteMsg(res)
+			if err != nil {
+				log.Debugw("error writing response", "error", err)
+				return
+			}
+
+		case pb.Request_PEER_METADATA:
+			res := d.doPeerMetadata(&req)
+			err := w.WriteMsg(res)
+			if err != nil {
+				log.Debugw("error writing response", "error", err)
+				return
+			}
+
+		case pb.Request_LOG_LEVEL:
+			res := d.doLogLevel(&req)
+			err := w.WriteMsg(res)
+			if err != nil {
+				log.Debugw("error writing response", "error", err)
+				return
+			}
+
+		case pb.Request_RENDEZVOUS_PEERS:
+			res := d.doRendezvousPeers(&req)
+			err := w.WriteMsg(res)
+			if err != nil {
+				log.Debugw("error writing response", "error", err)
+				return
+			}
+
+		case pb.Request_ROTATE_IDENTITY:
+			res := d.doRotateIdentity(&req)
+			err := w.WriteMsg(res)
+			if err != nil {
+				log.Debugw("error writing response", "error", err)
+				return
+			}
+
 		case pb.Request_PERSISTENT_CONN_UPGRADE:
+			// persistent connections are only closed by the client, so
+			// unblock them when the daemon is shutting down
+			done := make(chan struct{})
+			go func() {
+				select {
+				case <-d.ctx.Done():
+					c.Close()
+				case <-done:
+				}
+			}()
+
 			d.handlePersistentConn(r, w)
+			close(done)
 			return
 
 		default:
@@ -166,7 +331,11 @@ func (d *Daemon) doIdentify(req *pb.Request) *pb.Response {
 	}
 
 	res := okResponse()
-	res.Identify = &pb.IdentifyResponse{Id: id, Addrs: baddrs}
+	res.Identify = &pb.IdentifyResponse{
+		Id:               id,
+		Addrs:            baddrs,
+		SignedPeerRecord: d.signedPeerRecord(d.ID()),
+	}
 	return res
 }
 
@@ -194,16 +363,55 @@ func (d *Daemon) doConnect(req *pb.Request) *pb.Response {
 		addrs[x] = addr
 	}
 
+	ttl := req.Connect.GetAddrTTL()
+	if ttl < 0 {
+		return errorResponseString("address TTL can't be negative")
+	}
+	if req.Connect.SignedPeerRecord != nil {
+		// the signed addresses replace the unsigned ones in the peerstore,
+		// which ignores unsigned addresses of the peer from then on
+		recordTTL := peerstore.TempAddrTTL
+		if ttl > 0 {
+			recordTTL = time.Duration(ttl) * time.Second
+		}
+		addrs, err = d.consumeSignedPeerRecord(pid, req.Connect.SignedPeerRecord, recordTTL)
+		if err != nil {
+			log.Debugw("error consuming signed peer record", "peer", pid, "error", err)
+			return errorResponse(err)
+		}
+	} else if ttl > 0 {
+		// host.Connect adds the addrs with a temporary TTL, which doesn't
+		// shorten this one
+		d.host.Peerstore().AddAddrs(pid, addrs, time.Duration(ttl)*time.Second)
+	}
+
 	pi := peer.AddrInfo{ID: pid, Addrs: addrs}
 
+	before := d.host.Network().ConnsToPeer(pid)
+
 	log.Debug("connecting", "to", pid)
-	err = d.host.Connect(ctx, pi)
+	err = d.dialPreferred(ctx, pid, addrs, func(ctx context.Context) error {
+		return d.host.Connect(ctx, pi)
+	})
 	if err != nil {
 		log.Debugw("error opening connection", "to", pid, "error", err)
-		return errorResponse(err)
+		return dialErrorResponse(err)
 	}
 
-	return okResponse()
+	// report the connections actually in use, so clients can tell which
+	// transport was picked and whether it goes through a relay
+	conns := d.host.Network().ConnsToPeer(pid)
+	res := okResponse()
+	res.ConnectedPeers = []*pb.ConnectedPeer{d.connectedPeer(pid, conns)}
+	if conn := dialedConn(before, conns); conn != nil {
+		// identify is done once connected, so the peer's record is known if
+		// it sent one
+		res.Connect = &pb.ConnectResponse{
+			Addr:             conn.RemoteMultiaddr().Bytes(),
+			SignedPeerRecord: d.signedPeerRecord(pid),
+		}
+	}
+	return res
 }
 
 func (d *Daemon) doDisconnect(req *pb.Request) *pb.Response {
@@ -216,12 +424,21 @@ func (d *Daemon) doDisconnect(req *pb.Request) *pb.Response {
 		return errorResponse(err)
 	}
 
+	// protected connections are closed too: the connection manager only
+	// spares them when trimming
+	closed := int32(len(d.host.Network().ConnsToPeer(p)))
 	err = d.host.Network().ClosePeer(p)
 	if err != nil {
 		return errorResponse(err)
 	}
 
-	return okResponse()
+	if req.Disconnect.GetForgetAddrs() {
+		d.host.Peerstore().ClearAddrs(p)
+	}
+
+	res := okResponse()
+	res.Disconnect = &pb.DisconnectResponse{ClosedConns: &closed}
+	return res
 }
 
 func (d *Daemon) doStreamOpen(req *pb.Request) (*pb.Response, network.Stream) {
@@ -273,7 +490,7 @@ func (d *Daemon) doStreamHandler(req *pb.Request) *pb.Response {
 		if !ok {
 			d.host.SetStreamHandler(p, d.handleStream)
 		}
-		log.Debugw("set stream handler", "protocol", sp, "to", maddr)
+		log.Infow("set stream handler", "protocol", sp, "to", maddr)
 		d.handlers[p] = maddr
 	}
 
@@ -295,6 +512,94 @@ func (d *Daemon) doListPeers(req *pb.Request) *pb.Response {
 	return res
 }
 
+func (d *Daemon) doListConnections(req *pb.Request) *pb.Response {
+	n := d.host.Network()
+	peers := n.Peers()
+	connected := make([]*pb.ConnectedPeer, 0, len(peers))
+	for _, p := range peers {
+		conns := n.ConnsToPeer(p)
+		if len(conns) == 0 {
+			continue
+		}
+		connected = append(connected, d.connectedPeer(p, conns))
+	}
+
+	res := okResponse()
+	res.ConnectedPeers = connected
+	return res
+}
+
+// dialedConn picks the connection a dial opened among conns, given the
+// connections open before, or an already open one if the dial reused it.
+func dialedConn(before, conns []network.Conn) network.Conn {
+next:
+	for _, conn := range conns {
+		for _, old := range before {
+			if conn == old {
+				continue next
+			}
+		}
+		return conn
+	}
+	if len(conns) == 0 {
+		return nil
+	}
+	return conns[0]
+}
+
+func (d *Daemon) connectedPeer(p peer.ID, conns []network.Conn) *pb.ConnectedPeer {
+	info := &pb.ConnectedPeer{
+		Id:    []byte(p),
+		Conns: make([]*pb.ConnectionInfo, len(conns)),
+	}
+	for x, conn := range conns {
+		info.Conns[x] = d.connectionInfo(conn)
+	}
+	return info
+}
+
+// tlsProtocolID is the security protocol negotiated by libp2p-tls; QUIC
+// connections always use it.
+const tlsProtocolID = "/tls/1.0.0"
+
+func (d *Daemon) connectionInfo(conn network.Conn) *pb.ConnectionInfo {
+	addr := conn.RemoteMultiaddr()
+	relayed := isRelayAddr(addr)
+
+	var direction pb.ConnectionInfo_Direction
+	switch conn.Stat().Direction {
+	case network.DirInbound:
+		direction = pb.ConnectionInfo_INBOUND
+	case network.DirOutbound:
+		direction = pb.ConnectionInfo_OUTBOUND
+	default:
+		direction = pb.ConnectionInfo_UNKNOWN
+	}
+
+	info := &pb.ConnectionInfo{
+		Addr:      addr.Bytes(),
+		Direction: &direction,
+		Relayed:   &relayed,
+	}
+
+	// QUIC secures its connections itself, the others are secured by the
+	// upgrader with the transport recorded by the security tracker
+	security := d.security.get(conn)
+	if _, err := addr.ValueForProtocol(ma.P_QUIC); err == nil && !relayed {
+		security = tlsProtocolID
+	}
+	if security != "" {
+		info.Security = &security
+	}
+
+	return info
+}
+
+func isRelayAddr(addr ma.Multiaddr) bool {
+	_, err := addr.ValueForProtocol(ma.P_CIRCUIT)
+	return err == nil
+}
+
 func (d *Daemon) requestContext(utime int64) (context.Context, func()) {
 	timeout := DefaultTimeout
 	if utime > 0 {
@@ -2,11 +2,17 @@ package p2pd
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"time"
 
+	"github.com/libp2p/go-libp2p-core/connmgr"
+	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
 
+	basicconnmgr "github.com/libp2p/go-libp2p-connmgr"
 	pb "github.com/libp2p/go-libp2p-daemon/pb"
+	ma "github.com/multiformats/go-multiaddr"
 )
 
 func (d *Daemon) doConnManager(req *pb.Request) *pb.Response {
@@ -44,6 +50,28 @@ func (d *Daemon) doConnManager(req *pb.Request) *pb.Response {
 		d.host.ConnManager().UntagPeer(p, tag)
 		return okResponse()
 
+	case pb.ConnManagerRequest_PROTECT, pb.ConnManagerRequest_UNPROTECT:
+		if _, ok := d.host.ConnManager().(*connmgr.NullConnMgr); ok {
+			return errorResponseString("Connection manager is not enabled")
+		}
+
+		p, err := peer.IDFromBytes(req.ConnManager.GetPeer())
+		if err != nil {
+			return errorResponse(err)
+		}
+
+		tag := req.ConnManager.GetTag()
+		if tag == "" {
+			return errorResponseString("Malformed request; missing tag parameter")
+		}
+
+		if req.ConnManager.GetType() == pb.ConnManagerRequest_PROTECT {
+			d.host.ConnManager().Protect(p, tag)
+		} else {
+			d.host.ConnManager().Unprotect(p, tag)
+		}
+		return okResponse()
+
 	case pb.ConnManagerRequest_TRIM:
 		ctx, cancel := context.WithTimeout(d.ctx, 60*time.Second)
 		defer cancel()
@@ -51,8 +79,262 @@ func (d *Daemon) doConnManager(req *pb.Request) *pb.Response {
 		d.host.ConnManager().TrimOpenConns(ctx)
 		return okResponse()
 
+	case pb.ConnManagerRequest_SET_LIMITS:
+		cm, ok := d.host.ConnManager().(*ConnManager)
+		if !ok {
+			return errorResponseString("Connection manager is not enabled")
+		}
+
+		info := cm.GetInfo()
+		low, high, grace := info.LowWater, info.HighWater, info.GracePeriod
+		if req.ConnManager.LowWaterMark != nil {
+			low = int(req.ConnManager.GetLowWaterMark())
+		}
+		if req.ConnManager.HighWaterMark != nil {
+			high = int(req.ConnManager.GetHighWaterMark())
+		}
+		if req.ConnManager.GracePeriod != nil {
+			grace = time.Duration(req.ConnManager.GetGracePeriod()) * time.Second
+		}
+
+		if low < 0 || high < low {
+			return errorResponseString(fmt.Sprintf("invalid water marks: low %d, high %d", low, high))
+		}
+		if grace < 0 {
+			return errorResponseString("grace period can't be negative")
+		}
+
+		if err := cm.SetLimits(d.host.Network(), low, high, grace); err != nil {
+			return errorResponse(err)
+		}
+		return okResponse()
+
 	default:
 		log.Debugf("unexpected ConnManager request type", "type", req.ConnManager.GetType())
 		return errorResponseString("Unexpected request")
 	}
 }
+
+// ConnManager is a connection manager whose water marks and grace period can
+// be changed at runtime. The basic connection manager can't be reconfigured
+// in place, so a new one is swapped in, carrying over the connections, tags,
+// decaying tags and protections of the previous one.
+type ConnManager struct {
+	mx        sync.RWMutex
+	cm        *basicconnmgr.BasicConnMgr
+	protected map[peer.ID]map[string]struct{}
+	decaying  map[string]*decayingTag
+}
+
+var (
+	_ connmgr.ConnManager = (*ConnManager)(nil)
+	_ connmgr.Decayer     = (*ConnManager)(nil)
+)
+
+func NewConnManager(low, high int, grace time.Duration) *ConnManager {
+	return &ConnManager{
+		cm:        basicconnmgr.NewConnManager(low, high, grace),
+		protected: make(map[peer.ID]map[string]struct{}),
+		decaying:  make(map[string]*decayingTag),
+	}
+}
+
+func (m *ConnManager) current() *basicconnmgr.BasicConnMgr {
+	m.mx.RLock()
+	defer m.mx.RUnlock()
+	return m.cm
+}
+
+// SetLimits replaces the water marks and grace period. The connections open
+// on n are handed to the new manager as if they had just been opened, so they
+// get a fresh grace period; they are only trimmed once it expires and the
+// connection count goes over the new high water mark.
+func (m *ConnManager) SetLimits(n network.Network, low, high int, grace time.Duration) error {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	cm := basicconnmgr.NewConnManager(low, high, grace)
+
+	tags := make(map[string]connmgr.DecayingTag, len(m.decaying))
+	for name, t := range m.decaying {
+		tag, err := cm.RegisterDecayingTag(name, t.interval, t.decayFn, t.bumpFn)
+		if err != nil {
+			cm.Close()
+			return err
+		}
+		tags[name] = tag
+	}
+
+	notifee := cm.Notifee()
+	for _, c := range n.Conns() {
+		notifee.Connected(n, c)
+	}
+
+	for _, p := range n.Peers() {
+		info := m.cm.GetTagInfo(p)
+		if info == nil {
+			continue
+		}
+		for tag, value := range info.Tags {
+			if dt, ok := tags[tag]; ok {
+				if err := dt.Bump(p, value); err != nil {
+					log.Debugw("error carrying over decaying tag", "tag", tag, "peer", p, "error", err)
+				}
+			} else {
+				cm.TagPeer(p, tag, value)
+			}
+		}
+	}
+
+	for p, ptags := range m.protected {
+		for tag := range ptags {
+			cm.Protect(p, tag)
+		}
+	}
+
+	for name, tag := range tags {
+		m.decaying[name].tag = tag
+	}
+	old := m.cm
+	m.cm = cm
+
+	return old.Close()
+}
+
+// GetInfo returns the configuration and status of the current manager.
+func (m *ConnManager) GetInfo() basicconnmgr.CMInfo {
+	return m.current().GetInfo()
+}
+
+func (m *ConnManager) TagPeer(p peer.ID, tag string, value int) {
+	m.current().TagPeer(p, tag, value)
+}
+
+func (m *ConnManager) UntagPeer(p peer.ID, tag string) {
+	m.current().UntagPeer(p, tag)
+}
+
+func (m *ConnManager) UpsertTag(p peer.ID, tag string, upsert func(int) int) {
+	m.current().UpsertTag(p, tag, upsert)
+}
+
+func (m *ConnManager) GetTagInfo(p peer.ID) *connmgr.TagInfo {
+	return m.current().GetTagInfo(p)
+}
+
+func (m *ConnManager) TrimOpenConns(ctx context.Context) {
+	m.current().TrimOpenConns(ctx)
+}
+
+func (m *ConnManager) Notifee() network.Notifiee {
+	return (*connManagerNotifee)(m)
+}
+
+func (m *ConnManager) Protect(p peer.ID, tag string) {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	tags, ok := m.protected[p]
+	if !ok {
+		tags = make(map[string]struct{})
+		m.protected[p] = tags
+	}
+	tags[tag] = struct{}{}
+	m.cm.Protect(p, tag)
+}
+
+func (m *ConnManager) Unprotect(p peer.ID, tag string) bool {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	if tags, ok := m.protected[p]; ok {
+		delete(tags, tag)
+		if len(tags) == 0 {
+			delete(m.protected, p)
+		}
+	}
+	return m.cm.Unprotect(p, tag)
+}
+
+func (m *ConnManager) IsProtected(p peer.ID, tag string) bool {
+	return m.current().IsProtected(p, tag)
+}
+
+func (m *ConnManager) RegisterDecayingTag(name string, interval time.Duration, decayFn connmgr.DecayFn, bumpFn connmgr.BumpFn) (connmgr.DecayingTag, error) {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	tag, err := m.cm.RegisterDecayingTag(name, interval, decayFn, bumpFn)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &decayingTag{m: m, tag: tag, interval: interval, decayFn: decayFn, bumpFn: bumpFn}
+	m.decaying[name] = t
+	return t, nil
+}
+
+func (m *ConnManager) Close() error {
+	return m.current().Close()
+}
+
+// connManagerNotifee forwards connection events to the current manager. It
+// holds the read lock while doing so, so that no event is lost while a new
+// manager is being swapped in.
+type connManagerNotifee ConnManager
+
+func (nn *connManagerNotifee) Connected(n network.Network, c network.Conn) {
+	nn.mx.RLock()
+	defer nn.mx.RUnlock()
+	nn.cm.Notifee().Connected(n, c)
+}
+
+func (nn *connManagerNotifee) Disconnected(n network.Network, c network.Conn) {
+	nn.mx.RLock()
+	defer nn.mx.RUnlock()
+	nn.cm.Notifee().Disconnected(n, c)
+}
+
+func (nn *connManagerNotifee) Listen(n network.Network, addr ma.Multiaddr)      {}
+func (nn *connManagerNotifee) ListenClose(n network.Network, addr ma.Multiaddr) {}
+func (nn *connManagerNotifee) OpenedStream(network.Network, network.Stream)     {}
+func (nn *connManagerNotifee) ClosedStream(network.Network, network.Stream)     {}
+
+// decayingTag follows a decaying tag across manager swaps.
+type decayingTag struct {
+	m        *ConnManager
+	tag      connmgr.DecayingTag
+	interval time.Duration
+	decayFn  connmgr.DecayFn
+	bumpFn   connmgr.BumpFn
+}
+
+func (t *decayingTag) current() connmgr.DecayingTag {
+	t.m.mx.RLock()
+	defer t.m.mx.RUnlock()
+	return t.tag
+}
+
+func (t *decayingTag) Name() string {
+	return t.current().Name()
+}
+
+func (t *decayingTag) Interval() time.Duration {
+	return t.interval
+}
+
+func (t *decayingTag) Bump(p peer.ID, delta int) error {
+	return t.current().Bump(p, delta)
+}
+
+func (t *decayingTag) Remove(p peer.ID) error {
+	return t.current().Remove(p)
+}
+
+func (t *decayingTag) Close() error {
+	t.m.mx.Lock()
+	defer t.m.mx.Unlock()
+
+	delete(t.m.decaying, t.tag.Name())
+	return t.tag.Close()
+}
@@ -0,0 +1,81 @@
+package p2pd
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"time"
+
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+// controlTLSHandshakeTimeout bounds how long a client may take to
+// authenticate on a TLS control connection.
+const controlTLSHandshakeTimeout = 10 * time.Second
+
+// NewControlTLSConfig returns a TLS config for the control listeners that
+// presents the certificate in certFile, with its key in keyFile, and only
+// accepts clients with a certificate signed by a CA in caFile. Files are
+// PEM-encoded.
+func NewControlTLSConfig(caFile, certFile, keyFile string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("loading control certificate: %w", err)
+	}
+
+	caPEM, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading control CA: %w", err)
+	}
+	cas := x509.NewCertPool()
+	if !cas.AppendCertsFromPEM(caPEM) {
+		return nil, errors.New("no certificate found in the control CA file")
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    cas,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// SetControlTLS has clients connecting to the TCP control listeners complete
+// a TLS handshake with config, which should require and verify client
+// certificates, before any request is read; connections failing it are
+// closed. Unix socket listeners, which file permissions protect, are left
+// alone. A nil config disables TLS, which is the default.
+func (d *Daemon) SetControlTLS(config *tls.Config) {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	d.controlTLS = config
+}
+
+// secureControlConn completes the TLS handshake of a connection accepted on
+// a TCP control listener if TLS is enabled, and returns c as is otherwise.
+func (d *Daemon) secureControlConn(c net.Conn, listenAddr ma.Multiaddr) (net.Conn, error) {
+	d.mx.Lock()
+	config := d.controlTLS
+	d.mx.Unlock()
+
+	if config == nil || isUnixAddr(listenAddr) {
+		return c, nil
+	}
+
+	tc := tls.Server(c, config)
+	tc.SetDeadline(time.Now().Add(controlTLSHandshakeTimeout))
+	if err := tc.Handshake(); err != nil {
+		return nil, err
+	}
+	tc.SetDeadline(time.Time{})
+	return tc, nil
+}
+
+func isUnixAddr(addr ma.Multiaddr) bool {
+	c, _ := ma.SplitFirst(addr)
+	return c != nil && c.Protocol().Code == ma.P_UNIX
+}
@@ -2,7 +2,10 @@ package p2pd
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
+	"net"
 	"time"
 
 	"os"
@@ -12,11 +15,16 @@ import (
 
 	"github.com/libp2p/go-libp2p"
 	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/metrics"
+	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/libp2p/go-libp2p-core/protocol"
 	"github.com/libp2p/go-libp2p-core/routing"
 
+	ggio "github.com/gogo/protobuf/io"
+	"github.com/google/uuid"
 	multierror "github.com/hashicorp/go-multierror"
+	"github.com/ipfs/go-cid"
 	logging "github.com/ipfs/go-log"
 	dht "github.com/libp2p/go-libp2p-kad-dht"
 	dhtopts "github.com/libp2p/go-libp2p-kad-dht/opts"
@@ -27,28 +35,152 @@ import (
 
 var log = logging.Logger("p2pd")
 
-type Daemon struct {
-	ctx      context.Context
-	host     host.Host
-	listener manet.Listener
+// DefaultShutdownGracePeriod is how long the daemon waits for persistent
+// connections to finish when it is asked to shut down by a signal, unless
+// SetShutdownGracePeriod says otherwise.
+const DefaultShutdownGracePeriod = 10 * time.Second
+
+// DefaultMaxChunkedPayloadSize bounds the size of a payload sent in chunks,
+// unless SetUnaryChunking says otherwise.
+const DefaultMaxChunkedPayloadSize = 1 << 28
+
+// defaultPersistentConnQueueSize bounds the number of messages waiting to be
+// written to each persistent connection, unless SetPersistentConnQueueSize
+// says otherwise.
+const defaultPersistentConnQueueSize = 1024
+
+// defaultValidatorTimeout is how long the daemon waits for a client to
+// validate a pubsub message, unless the client asks for another timeout.
+// Messages left unvalidated are rejected.
+const defaultValidatorTimeout = 5 * time.Second
+
+// unaryConfig holds the settings of the unary and stream calls of a daemon.
+type unaryConfig struct {
+	// maxMessageSize bounds the size of messages accepted from remote peers
+	maxMessageSize int
+	// chunkSize is the size of the chunks payloads are split into; they are
+	// never split if zero
+	chunkSize int
+	// maxChunkedPayloadSize bounds the size of a payload sent in chunks
+	maxChunkedPayloadSize int
+	// retryDials has calls to a peer in dial backoff dial it once directly
+	retryDials bool
+	// negotiationTimeout bounds the protocol negotiation, unless zero
+	negotiationTimeout time.Duration
+	// loopback has calls to the daemon's own peer id go to its own handlers
+	loopback bool
+	// dialPreference ranks the addresses dials go over, unless empty
+	dialPreference AddrPreference
+	// dialPreferenceTimeout bounds the dial over each preferred tier, unless
+	// zero
+	dialPreferenceTimeout time.Duration
+}
 
-	dht    *dht.IpfsDHT
-	pubsub *ps.PubSub
+type Daemon struct {
+	ctx       context.Context
+	cancel    context.CancelFunc
+	host      host.Host
+	listeners []manet.Listener
+	// instanceID tells this daemon instance from the ones before and after a
+	// restart
+	instanceID uuid.UUID
+	startTime  time.Time
+
+	dht          *dht.IpfsDHT
+	dhtMode      string
+	pubsub       *ps.PubSub
+	pubsubRouter string
+	gater        *ConnectionGater
+	security     *securityTracker
 
 	mx sync.Mutex
 	// stream handlers: map of protocol.ID to multi-address
 	handlers map[protocol.ID]ma.Multiaddr
 	// closed is set when the daemon is shutting down
 	closed bool
+	// draining is set when the daemon stops accepting new unary handlers
+	// ahead of shutting down
+	draining bool
+	// rotator starts the daemon taking over on identity rotation, which is
+	// disabled if nil
+	rotator IdentityRotator
+	// successor is the daemon the control listeners were handed over to on
+	// identity rotation
+	successor *Daemon
 
 	registeredUnaryProtocols map[protocol.ID]bool
-
-	// callID (int64) to chan *pb.PersistentConnectionResponse
+	// unaryHandlerOwners maps unary protocols to the writer of the persistent
+	// connection handling them
+	unaryHandlerOwners map[protocol.ID]ggio.Writer
+	// topicValidatorOwners maps pubsub topics to the writer of the persistent
+	// connection validating their messages
+	topicValidatorOwners map[string]ggio.Writer
+	// keepAliveInterval is how long a persistent connection may stay idle
+	// before it is pinged; keepalive is disabled if zero
+	keepAliveInterval  time.Duration
+	keepAliveMaxMissed int
+	// controlTLS authenticates clients on TCP control listeners, unless nil
+	controlTLS *tls.Config
+	// maxPersistentConns caps the persistent connections open at once; they
+	// are unlimited if zero
+	maxPersistentConns int
+	// persistentConnCount is the number of persistent connections open
+	persistentConnCount int
+	// persistentConnQueueSize bounds the messages waiting to be written to
+	// each persistent connection
+	persistentConnQueueSize int
+	// shutdownGracePeriod bounds how long the daemon waits for persistent
+	// connections to finish when shut down by a signal
+	shutdownGracePeriod time.Duration
+	// unary holds the settings of unary and stream calls
+	unary unaryConfig
+	// relayMetricsInterval is how often the relay metrics are sampled
+	relayMetricsInterval time.Duration
+	// bwc counts the bandwidth used by the host, if it reports it
+	bwc metrics.Reporter
+	// relayHop is set when the host runs a relay service
+	relayHop bool
+	// relayMetrics is set once the relay metrics are tracked
+	relayMetrics bool
+	// reachability is the last one reported by AutoNAT
+	reachability network.Reachability
+
+	bootstrapMx    sync.Mutex
+	bootstrapPeers []ma.Multiaddr
+	// bootstrapping is set while Bootstrap runs
+	bootstrapping bool
+	// bootstrapped is set once Bootstrap has succeeded
+	bootstrapped      bool
+	keepBootstrapOnce sync.Once
+
+	provideMx sync.Mutex
+	// providing maps the content the daemon keeps providing to whether it was
+	// announced the last time
+	providing map[cid.Cid]bool
+	// reprovideInterval is how often that content is announced again
+	reprovideInterval time.Duration
+	reprovideOnce     sync.Once
+
+	rendezvousMx sync.Mutex
+	// rendezvousNS is the namespace passed to StartRendezvous, if any
+	rendezvousNS string
+	// rendezvousInterval is how often the daemon advertises itself under it
+	rendezvousInterval time.Duration
+	// rendezvousPeers are the peers found under it the last time
+	rendezvousPeers map[peer.ID]peer.AddrInfo
+
+	// calls keeps the last unary calls made by clients
+	calls callHistory
+
+	// callID (uuid) to responseWaiter
 	// used to return responses to goroutines awating them
 	responseWaiters sync.Map
-	// callID (int64) to chan context.CancelFunc
+	// callID (uuid) to inflightCall
 	// used to cancel request handlers
 	cancelUnary sync.Map
+	// stream id (uuid) to inboundStream
+	// used to pass the frames sent by clients to raw stream handlers
+	inboundStreams sync.Map
 
 	// this sync.Once ensures the goroutine awaiting deamon termination is
 	// only run once
@@ -57,11 +189,77 @@ type Daemon struct {
 	cancelTerminateTimer context.CancelFunc
 }
 
-func NewDaemon(ctx context.Context, maddr ma.Multiaddr, dhtMode string, opts ...libp2p.Option) (*Daemon, error) {
+// NewDaemon creates a daemon serving the control protocol on each of maddrs.
+// Every context of the daemon derives from ctx, so a dial timeout set on it
+// with network.WithDialPeerTimeout bounds the daemon's own dials, such as
+// those of control requests, bootstrapping and rendezvous, but not those of
+// the DHT and pubsub, which run under contexts of their own. Unix sockets
+// among maddrs get the mode, owner and backlog set on ctx with WithUnixSocket.
+func NewDaemon(ctx context.Context, maddrs []ma.Multiaddr, dhtMode string, opts ...libp2p.Option) (*Daemon, error) {
+	if len(maddrs) == 0 {
+		return nil, fmt.Errorf("no control listen address")
+	}
+
+	d, err := newDaemon(ctx, dhtMode, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, maddr := range maddrs {
+		if err := removeStaleUnixSocket(maddr); err != nil {
+			d.closeListeners()
+			d.host.Close()
+			d.cancel()
+			return nil, err
+		}
+
+		l, err := listenControl(ctx, maddr)
+		if err != nil {
+			d.closeListeners()
+			d.host.Close()
+			d.cancel()
+			return nil, err
+		}
+		d.listeners = append(d.listeners, l)
+	}
+
+	go d.trapSignals()
+
+	return d, nil
+}
+
+// NewEmbeddedDaemon creates a daemon without control listeners, for use as a
+// library in another program: the host, DHT and pubsub are reached directly
+// with Host, DHT and PubSub, or over HTTPControlHandler. Serve is not needed,
+// and signals are left for the program to handle. ctx is used as in NewDaemon.
+func NewEmbeddedDaemon(ctx context.Context, dhtMode string, opts ...libp2p.Option) (*Daemon, error) {
+	return newDaemon(ctx, dhtMode, opts...)
+}
+
+func newDaemon(ctx context.Context, dhtMode string, opts ...libp2p.Option) (*Daemon, error) {
+	ctx, cancel := context.WithCancel(ctx)
 	d := &Daemon{
 		ctx:                      ctx,
+		cancel:                   cancel,
 		handlers:                 make(map[protocol.ID]ma.Multiaddr),
 		registeredUnaryProtocols: make(map[protocol.ID]bool),
+		unaryHandlerOwners:       make(map[protocol.ID]ggio.Writer),
+		topicValidatorOwners:     make(map[string]ggio.Writer),
+		providing:                make(map[cid.Cid]bool),
+		bootstrapPeers:           BootstrapPeers,
+		instanceID:               uuid.New(),
+		startTime:                time.Now(),
+		dhtMode:                  dhtMode,
+		security:                 newSecurityTracker(),
+		persistentConnQueueSize:  defaultPersistentConnQueueSize,
+		shutdownGracePeriod:      DefaultShutdownGracePeriod,
+		unary: unaryConfig{
+			maxMessageSize:        network.MessageSizeMax,
+			maxChunkedPayloadSize: DefaultMaxChunkedPayloadSize,
+		},
+		relayMetricsInterval: defaultRelayMetricsInterval,
+		reprovideInterval:    defaultReprovideInterval,
+		rendezvousInterval:   defaultRendezvousInterval,
 	}
 
 	if dhtMode != "" {
@@ -74,27 +272,54 @@ func NewDaemon(ctx context.Context, maddr ma.Multiaddr, dhtMode string, opts ...
 
 		opts = append(opts, libp2p.Routing(d.DHTRoutingFactory(dhtOpts)))
 	}
+	opts = append(opts, d.security.option())
 
 	h, err := libp2p.New(ctx, opts...)
 	if err != nil {
+		cancel()
 		return nil, err
 	}
 	d.host = h
 
-	l, err := manet.Listen(maddr)
+	connectedness, err := newConnectednessEmitter(h)
 	if err != nil {
 		h.Close()
+		cancel()
+		return nil, err
+	}
+	if err := d.trackReachability(); err != nil {
+		h.Close()
+		cancel()
 		return nil, err
 	}
-	d.listener = l
 
-	go d.trapSignals()
+	h.Network().Notify(&network.NotifyBundle{
+		ConnectedF: func(n network.Network, c network.Conn) {
+			log.Infow("connection opened", "peer", c.RemotePeer(), "addr", c.RemoteMultiaddr(), "direction", c.Stat().Direction)
+			d.security.opened(c)
+			connectedness.update(n, c)
+		},
+		DisconnectedF: func(n network.Network, c network.Conn) {
+			log.Infow("connection closed", "peer", c.RemotePeer(), "addr", c.RemoteMultiaddr())
+			d.security.closed(c)
+			connectedness.update(n, c)
+		},
+	})
 
 	return d, nil
 }
 
+// Listener returns the first control listener, or nil for an embedded
+// daemon.
 func (d *Daemon) Listener() manet.Listener {
-	return d.listener
+	if len(d.listeners) == 0 {
+		return nil
+	}
+	return d.listeners[0]
+}
+
+func (d *Daemon) Listeners() []manet.Listener {
+	return d.listeners
 }
 
 func (d *Daemon) DHTRoutingFactory(opts []dhtopts.Option) func(host.Host) (routing.PeerRouting, error) {
@@ -104,15 +329,16 @@ func (d *Daemon) DHTRoutingFactory(opts []dhtopts.Option) func(host.Host) (routi
 			return nil, err
 		}
 		d.dht = dhtInst
+		go d.trackRoutingTableSize()
 		return dhtInst, nil
 	}
 
 	return makeRouting
 }
 
-func (d *Daemon) EnablePubsub(router string, sign, strict bool) error {
-	var opts []ps.Option
-
+// EnablePubsub starts pubsub with the router, floodsub or gossipsub; opts
+// are passed on to it, e.g. ps.WithGossipSubParams.
+func (d *Daemon) EnablePubsub(router string, sign, strict bool, opts ...ps.Option) error {
 	if !sign {
 		opts = append(opts, ps.WithMessageSigning(false))
 	} else if !strict {
@@ -126,6 +352,7 @@ func (d *Daemon) EnablePubsub(router string, sign, strict bool) error {
 			return err
 		}
 		d.pubsub = pubsub
+		d.pubsubRouter = router
 		return nil
 
 	case "gossipsub":
@@ -134,6 +361,7 @@ func (d *Daemon) EnablePubsub(router string, sign, strict bool) error {
 			return err
 		}
 		d.pubsub = pubsub
+		d.pubsubRouter = router
 		return nil
 
 	default:
@@ -142,6 +370,22 @@ func (d *Daemon) EnablePubsub(router string, sign, strict bool) error {
 
 }
 
+// Host returns the libp2p host of the daemon.
+func (d *Daemon) Host() host.Host {
+	return d.host
+}
+
+// DHT returns the DHT of the daemon, or nil if it runs without one.
+func (d *Daemon) DHT() *dht.IpfsDHT {
+	return d.dht
+}
+
+// PubSub returns the pubsub service of the daemon, or nil if it isn't
+// enabled.
+func (d *Daemon) PubSub() *ps.PubSub {
+	return d.pubsub
+}
+
 func (d *Daemon) ID() peer.ID {
 	return d.host.ID()
 }
@@ -150,20 +394,62 @@ func (d *Daemon) Addrs() []ma.Multiaddr {
 	return d.host.Addrs()
 }
 
+// InstanceID returns the id of this daemon instance, which is random and
+// reported to clients opening a persistent connection, so they can tell the
+// daemon restarted and lost their handlers.
+func (d *Daemon) InstanceID() uuid.UUID {
+	return d.instanceID
+}
+
+// Serve accepts control connections on all listeners until the daemon is
+// closed. It fails for an embedded daemon, which has none. After an identity
+// rotation, connections are passed on to the daemon taking over, and Serve
+// returns once that one is closed.
 func (d *Daemon) Serve() error {
+	if len(d.listeners) == 0 {
+		return errors.New("no control listeners to serve")
+	}
+
+	var wg sync.WaitGroup
+	for _, l := range d.listeners[1:] {
+		wg.Add(1)
+		go func(l manet.Listener) {
+			defer wg.Done()
+			d.serveListener(l)
+		}(l)
+	}
+
+	d.serveListener(d.listeners[0])
+	wg.Wait()
+	return nil
+}
+
+func (d *Daemon) serveListener(l manet.Listener) {
 	for {
-		if d.isClosed() {
-			return nil
+		if d.Current().isClosed() {
+			return
 		}
 
-		c, err := d.listener.Accept()
+		c, err := l.Accept()
 		if err != nil {
+			if d.Current().isClosed() {
+				return
+			}
 			log.Errorw("error accepting connection", "error", err)
 			continue
 		}
 
 		log.Debug("incoming connection")
-		go d.handleConn(c)
+		go func() {
+			sc, err := d.secureControlConn(c, l.Multiaddr())
+			if err != nil {
+				rejectedControlConns.Inc()
+				log.Warnw("rejecting control connection", "remote", c.RemoteMultiaddr(), "error", err)
+				c.Close()
+				return
+			}
+			d.Current().handleConn(sc)
+		}()
 	}
 }
 
@@ -186,22 +472,121 @@ func clearUnixSockets(path ma.Multiaddr) error {
 	return nil
 }
 
+// removeStaleUnixSocket unlinks a unix socket left behind by a daemon that
+// was not shut down cleanly. It fails if a daemon is still listening on it.
+func removeStaleUnixSocket(path ma.Multiaddr) error {
+	c, _ := ma.SplitFirst(path)
+	if c.Protocol().Code != ma.P_UNIX {
+		return nil
+	}
+
+	info, err := os.Stat(c.Value())
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if info.Mode()&os.ModeSocket == 0 {
+		// not ours to remove; let listen fail
+		return nil
+	}
+
+	conn, err := net.DialTimeout("unix", c.Value(), time.Second)
+	if err == nil {
+		conn.Close()
+		return fmt.Errorf("a daemon is already listening on %s", path)
+	}
+	if ne, ok := err.(net.Error); ok && ne.Timeout() {
+		// something holds the socket but doesn't accept; don't take it over
+		return fmt.Errorf("unix socket %s is in use: %w", path, err)
+	}
+
+	log.Warnw("removing stale unix socket", "path", c.Value())
+	return os.Remove(c.Value())
+}
+
 func (d *Daemon) Close() error {
 	d.mx.Lock()
+	if d.closed {
+		d.mx.Unlock()
+		return nil
+	}
 	d.closed = true
 	d.mx.Unlock()
 
+	d.cancel()
+
 	var merr *multierror.Error
 	if err := d.host.Close(); err != nil {
 		merr = multierror.Append(err)
 	}
 
-	listenAddr := d.listener.Multiaddr()
-	if err := d.listener.Close(); err != nil {
+	if err := d.closeListeners(); err != nil {
+		merr = multierror.Append(merr, err)
+	}
+
+	return merr.ErrorOrNil()
+}
+
+func (d *Daemon) closeListeners() error {
+	var merr *multierror.Error
+	for _, l := range d.listeners {
+		listenAddr := l.Multiaddr()
+		if err := l.Close(); err != nil {
+			merr = multierror.Append(merr, err)
+		}
+
+		if err := clearUnixSockets(listenAddr); err != nil {
+			merr = multierror.Append(merr, err)
+		}
+	}
+
+	return merr.ErrorOrNil()
+}
+
+// SetShutdownGracePeriod bounds how long the daemon waits for persistent
+// connections to finish when it is asked to shut down by a signal, and when
+// its identity is rotated without a grace period of its own. It is
+// DefaultShutdownGracePeriod by default.
+func (d *Daemon) SetShutdownGracePeriod(gracePeriod time.Duration) {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	d.shutdownGracePeriod = gracePeriod
+}
+
+// defaultGracePeriod returns the grace period set by SetShutdownGracePeriod.
+func (d *Daemon) defaultGracePeriod() time.Duration {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	return d.shutdownGracePeriod
+}
+
+// Shutdown cancels in-flight requests, closes the host and waits up to
+// gracePeriod for persistent connections to finish before closing the daemon.
+func (d *Daemon) Shutdown(gracePeriod time.Duration) error {
+	d.cancel()
+
+	var merr *multierror.Error
+	if err := d.host.Close(); err != nil {
 		merr = multierror.Append(merr, err)
 	}
 
-	if err := clearUnixSockets(listenAddr); err != nil {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		d.terminateWG.Wait()
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(gracePeriod):
+		log.Warnw("grace period expired before persistent connections finished", "grace", gracePeriod)
+	}
+
+	if err := d.Close(); err != nil {
 		merr = multierror.Append(merr, err)
 	}
 
@@ -226,3 +611,46 @@ func (d *Daemon) KillOnTimeout(timeout time.Duration) {
 		}
 	}()
 }
+
+// DrainOnTimeout is a graceful alternative to KillOnTimeout: when no
+// persistent connection is opened within timeout, the daemon stops accepting
+// new unary handlers and waits up to gracePeriod for persistent connections
+// and in-flight calls to finish before shutting down.
+func (d *Daemon) DrainOnTimeout(timeout, gracePeriod time.Duration) {
+	go func() {
+		ctx, cancel := context.WithCancel(d.ctx)
+		d.cancelTerminateTimer = cancel
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.NewTimer(timeout).C:
+			d.drain(gracePeriod)
+			d.Close()
+		}
+	}()
+}
+
+const drainPollInterval = 100 * time.Millisecond
+
+func (d *Daemon) drain(gracePeriod time.Duration) {
+	d.mx.Lock()
+	d.draining = true
+	d.mx.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		defer close(drained)
+
+		d.terminateWG.Wait()
+		for !d.isClosed() && (syncMapLen(&d.cancelUnary) > 0 || syncMapLen(&d.responseWaiters) > 0) {
+			time.Sleep(drainPollInterval)
+		}
+	}()
+
+	select {
+	case <-drained:
+	case <-time.After(gracePeriod):
+		log.Warnw("grace period expired before in-flight calls finished", "grace", gracePeriod)
+	}
+}
@@ -2,17 +2,28 @@ package p2pd
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"time"
 
+	"github.com/libp2p/go-libp2p-daemon/config"
 	pb "github.com/libp2p/go-libp2p-daemon/pb"
 
 	"github.com/libp2p/go-libp2p-core/crypto"
 	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/routing"
 
 	cid "github.com/ipfs/go-cid"
+	dht "github.com/libp2p/go-libp2p-kad-dht"
+	kb "github.com/libp2p/go-libp2p-kbucket"
 )
 
 const defaultProviderCount = 20
 
+// routingTableMetricsInterval is how often the routing table size metric is
+// updated.
+const routingTableMetricsInterval = 10 * time.Second
+
 func (d *Daemon) doDHT(req *pb.Request) (*pb.Response, <-chan *pb.DHTResponse, func()) {
 	if d.dht == nil {
 		return errorResponseString("DHT not enabled"), nil, nil
@@ -50,6 +61,15 @@ func (d *Daemon) doDHT(req *pb.Request) (*pb.Response, <-chan *pb.DHTResponse, f
 	case pb.DHTRequest_PROVIDE:
 		return d.doDHTProvide(req.Dht)
 
+	case pb.DHTRequest_START_PROVIDING:
+		return d.doDHTStartProviding(req.Dht)
+
+	case pb.DHTRequest_STOP_PROVIDING:
+		return d.doDHTStopProviding(req.Dht)
+
+	case pb.DHTRequest_LIST_PROVIDING:
+		return d.doDHTListProviding(req.Dht)
+
 	default:
 		log.Debugw("unexpected DHT request type", "type", req.Dht.GetType())
 		return errorResponseString("Unexpected request"), nil, nil
@@ -91,6 +111,9 @@ func (d *Daemon) doDHTFindProviders(req *pb.DHTRequest) (*pb.Response, <-chan *p
 		return errorResponse(err), nil, nil
 	}
 
+	if req.GetCount() < 0 {
+		return errorResponseString("count can't be negative"), nil, nil
+	}
 	count := defaultProviderCount
 	if req.GetCount() > 0 {
 		count = int(*req.Count)
@@ -136,7 +159,7 @@ func (d *Daemon) doDHTGetClosestPeers(req *pb.DHTRequest) (*pb.Response, <-chan
 		defer close(rch)
 		for _, p := range ch {
 			select {
-			case rch <- dhtResponsePeerID(p):
+			case rch <- dhtResponseClosestPeer(d.host.Peerstore().PeerInfo(p)):
 			case <-ctx.Done():
 				return
 			}
@@ -176,11 +199,16 @@ func (d *Daemon) doDHTGetValue(req *pb.DHTRequest) (*pb.Response, <-chan *pb.DHT
 		return errorResponseString("Malformed request; missing key parameter"), nil, nil
 	}
 
+	opts, err := dhtQuorumOptions(req)
+	if err != nil {
+		return errorResponse(err), nil, nil
+	}
+
 	ctx, cancel := d.dhtRequestContext(req)
 	defer cancel()
 
 	keyString := string(req.Key)
-	val, err := d.dht.GetValue(ctx, keyString)
+	val, err := d.dht.GetValue(ctx, keyString, opts...)
 	if err != nil {
 		return errorResponse(err), nil, nil
 	}
@@ -193,10 +221,15 @@ func (d *Daemon) doDHTSearchValue(req *pb.DHTRequest) (*pb.Response, <-chan *pb.
 		return errorResponseString("Malformed request; missing key parameter"), nil, nil
 	}
 
+	opts, err := dhtQuorumOptions(req)
+	if err != nil {
+		return errorResponse(err), nil, nil
+	}
+
 	ctx, cancel := d.dhtRequestContext(req)
 
 	keyString := string(req.Key)
-	ch, err := d.dht.SearchValue(ctx, keyString)
+	ch, err := d.dht.SearchValue(ctx, keyString, opts...)
 	if err != nil {
 		cancel()
 		return errorResponse(err), nil, nil
@@ -218,6 +251,21 @@ func (d *Daemon) doDHTSearchValue(req *pb.DHTRequest) (*pb.Response, <-chan *pb.
 	return dhtOkResponse(dhtResponseBegin()), rch, cancel
 }
 
+// dhtQuorumOptions returns the routing options for the number of peers that
+// must agree on a value before a query completes; the DHT picks a default if
+// the request doesn't set it.
+func dhtQuorumOptions(req *pb.DHTRequest) ([]routing.Option, error) {
+	if req.Quorum == nil {
+		return nil, nil
+	}
+
+	quorum := req.GetQuorum()
+	if quorum < 0 {
+		return nil, fmt.Errorf("quorum can't be negative")
+	}
+	return []routing.Option{dht.Quorum(int(quorum))}, nil
+}
+
 func (d *Daemon) doDHTPutValue(req *pb.DHTRequest) (*pb.Response, <-chan *pb.DHTResponse, func()) {
 	if req.Key == nil {
 		return errorResponseString("Malformed request; missing key parameter"), nil, nil
@@ -227,6 +275,12 @@ func (d *Daemon) doDHTPutValue(req *pb.DHTRequest) (*pb.Response, <-chan *pb.DHT
 		return errorResponseString("Malformed request; missing value parameter"), nil, nil
 	}
 
+	// a DHT in client mode keeps no records for other peers, so the value
+	// would only be stored locally
+	if d.dhtMode == config.DHTClientMode && d.dht.RoutingTable().Size() == 0 {
+		return errorResponseString("DHT in client mode has no DHT servers to put the value to"), nil, nil
+	}
+
 	ctx, cancel := d.dhtRequestContext(req)
 	defer cancel()
 
@@ -252,7 +306,9 @@ func (d *Daemon) doDHTProvide(req *pb.DHTRequest) (*pb.Response, <-chan *pb.DHTR
 	ctx, cancel := d.dhtRequestContext(req)
 	defer cancel()
 
-	err = d.dht.Provide(ctx, cid, true)
+	// only store the provider record locally if announce is false
+	announce := req.Announce == nil || req.GetAnnounce()
+	err = d.dht.Provide(ctx, cid, announce)
 	if err != nil {
 		return errorResponse(err), nil, nil
 	}
@@ -287,6 +343,14 @@ func dhtResponsePeerID(p peer.ID) *pb.DHTResponse {
 	return dhtResponseValue([]byte(p))
 }
 
+// dhtResponseClosestPeer carries the peer ID in the value, as older clients
+// expect, along with the addresses known for the peer.
+func dhtResponseClosestPeer(pi peer.AddrInfo) *pb.DHTResponse {
+	res := dhtResponsePeerInfo(pi)
+	res.Value = []byte(pi.ID)
+	return res
+}
+
 func dhtResponsePublicKey(key crypto.PubKey) (*pb.DHTResponse, error) {
 	bytes, err := crypto.MarshalPublicKey(key)
 	if err != nil {
@@ -319,3 +383,80 @@ func peerInfo2pb(pi peer.AddrInfo) *pb.PeerInfo {
 		Addrs: addrs,
 	}
 }
+
+// trackRoutingTableSize updates the routing table size metric until the
+// daemon is closed.
+func (d *Daemon) trackRoutingTableSize() {
+	ticker := time.NewTicker(routingTableMetricsInterval)
+	defer ticker.Stop()
+
+	for {
+		routingTablePeers.Set(float64(d.dht.RoutingTable().Size()))
+		select {
+		case <-ticker.C:
+		case <-d.ctx.Done():
+			return
+		}
+	}
+}
+
+func (d *Daemon) doRoutingTable(req *pb.Request) *pb.Response {
+	if d.dht == nil {
+		return errorResponseString("DHT not enabled")
+	}
+
+	if req.GetRoutingTable().GetRefresh() {
+		if err := d.refreshRoutingTable(req.RoutingTable); err != nil {
+			return errorResponse(err)
+		}
+	}
+
+	rt := d.dht.RoutingTable()
+	peerCount := int32(rt.Size())
+	routingTable := &pb.RoutingTableResponse{PeerCount: &peerCount}
+
+	if req.GetRoutingTable().GetPeers() {
+		local := kb.ConvertPeerID(d.host.ID())
+		buckets := make(map[int32]*pb.RoutingTableBucket)
+		for _, p := range rt.ListPeers() {
+			cpl := int32(kb.CommonPrefixLen(local, kb.ConvertPeerID(p)))
+			if buckets[cpl] == nil {
+				buckets[cpl] = &pb.RoutingTableBucket{Cpl: &cpl}
+				routingTable.Buckets = append(routingTable.Buckets, buckets[cpl])
+			}
+			buckets[cpl].Peers = append(buckets[cpl].Peers, []byte(p))
+		}
+		sort.Slice(routingTable.Buckets, func(i, j int) bool {
+			return routingTable.Buckets[i].GetCpl() < routingTable.Buckets[j].GetCpl()
+		})
+	}
+
+	res := okResponse()
+	res.RoutingTable = routingTable
+	return res
+}
+
+// refreshRoutingTable refreshes the routing table and waits for the refresh to
+// complete, or for the request to time out; the refresh goes on in the
+// background then.
+func (d *Daemon) refreshRoutingTable(req *pb.RoutingTableRequest) error {
+	ctx, cancel := d.requestContext(req.GetTimeout())
+	defer cancel()
+
+	refresh := d.dht.RefreshRoutingTable
+	if req.GetForce() {
+		refresh = d.dht.ForceRefresh
+	}
+
+	log.Debugw("refreshing the routing table", "force", req.GetForce())
+	select {
+	case err := <-refresh():
+		if err != nil {
+			log.Debugw("error refreshing the routing table", "error", err)
+			return fmt.Errorf("refreshing the routing table: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
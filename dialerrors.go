@@ -0,0 +1,47 @@
+package p2pd
+
+import (
+	"context"
+	"errors"
+	"net"
+	"syscall"
+
+	swarm "github.com/libp2p/go-libp2p-swarm"
+
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// dialErrorResponse is errorResponse for a failed dial, listing the error of
+// every address dialed if the swarm reports them.
+func dialErrorResponse(err error) *pb.Response {
+	res := errorResponse(err)
+
+	var dialErr *swarm.DialError
+	if !errors.As(err, &dialErr) {
+		return res
+	}
+	for _, te := range dialErr.DialErrors {
+		msg := te.Cause.Error()
+		res.Error.DialAttempts = append(res.Error.DialAttempts, &pb.DialAttempt{
+			Addr:   te.Address.Bytes(),
+			Error:  &msg,
+			Reason: dialFailureReason(te.Cause).Enum(),
+		})
+	}
+	return res
+}
+
+// dialFailureReason classifies the error of a dial to an address.
+func dialFailureReason(err error) pb.DialAttempt_Reason {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return pb.DialAttempt_REFUSED
+	case errors.Is(err, syscall.ENETUNREACH), errors.Is(err, syscall.EHOSTUNREACH):
+		return pb.DialAttempt_UNREACHABLE
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return pb.DialAttempt_TIMEOUT
+	default:
+		return pb.DialAttempt_OTHER
+	}
+}
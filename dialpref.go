@@ -0,0 +1,148 @@
+package p2pd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+)
+
+// SetDialPreference has the dials to peers when connecting and calling go
+// over the addresses pref ranks, see ParseDialPreference, trying each tier for
+// up to timeout before moving on to the next; a tier is only given up on once
+// its dial fails if timeout is zero. Dials are left to the swarm if pref is
+// empty, the default. The preference needs a connection gater, see
+// SetConnectionGater.
+func (d *Daemon) SetDialPreference(pref AddrPreference, timeout time.Duration) {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	d.unary.dialPreference = pref
+	d.unary.dialPreferenceTimeout = timeout
+}
+
+// AddrPreference lists address matchers from most to least preferred.
+//
+// The swarm dials all the addresses of a peer at once, only ranking them by
+// its own fixed order, so the daemon dials the addresses matching each entry
+// in turn instead, moving on to the next one when the dial fails or its
+// timeout expires, and then all the addresses matching none.
+// Each tier is held to its addresses by the connection gater of the daemon,
+// so the preference only applies with one; dials to the peer from elsewhere in
+// the daemon during a tier, such as the DHT's, are held to it too.
+type AddrPreference []addrMatcher
+
+type addrMatcher struct {
+	// code is the multiaddr protocol the address must have, unless ipnet is
+	// set
+	code  int
+	ipnet *net.IPNet
+}
+
+// ParseDialPreference parses a list of multiaddr protocol names, such as quic,
+// tcp, ws or ip6, and IP networks in CIDR notation, such as 10.0.0.0/8.
+// Relay addresses only match p2p-circuit, whatever the transport to the relay.
+func ParseDialPreference(prefs []string) (AddrPreference, error) {
+	matchers := make(AddrPreference, len(prefs))
+	for i, pref := range prefs {
+		if _, ipnet, err := net.ParseCIDR(pref); err == nil {
+			matchers[i] = addrMatcher{ipnet: ipnet}
+			continue
+		}
+		proto := ma.ProtocolWithName(pref)
+		if proto.Code == 0 {
+			return nil, fmt.Errorf("dial preference %q is neither a multiaddr protocol nor an IP network", pref)
+		}
+		matchers[i] = addrMatcher{code: proto.Code}
+	}
+	return matchers, nil
+}
+
+// Rank returns the index of the first entry addr matches, or -1 if none does.
+func (p AddrPreference) Rank(addr ma.Multiaddr) int {
+	for i, m := range p {
+		if m.match(addr) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (m addrMatcher) match(addr ma.Multiaddr) bool {
+	relay := isRelayAddr(addr)
+	if m.code == ma.P_CIRCUIT || relay {
+		return m.code == ma.P_CIRCUIT && relay
+	}
+	if m.ipnet != nil {
+		ip, err := manet.ToIP(addr)
+		return err == nil && m.ipnet.Contains(ip)
+	}
+	_, err := addr.ValueForProtocol(m.code)
+	return err == nil
+}
+
+// dialPreferred runs dial, which must dial p, once per tier of the dial
+// preference that addrs or the peerstore have an address in, until one
+// succeeds, and then unrestricted if some addresses match no tier. dial runs once as is if
+// there is no preference or p is already connected.
+func (d *Daemon) dialPreferred(ctx context.Context, p peer.ID, addrs []ma.Multiaddr, dial func(context.Context) error) error {
+	d.mx.Lock()
+	gater := d.gater
+	pref, timeout := d.unary.dialPreference, d.unary.dialPreferenceTimeout
+	d.mx.Unlock()
+
+	if len(pref) == 0 || gater == nil || d.host.Network().Connectedness(p) == network.Connected {
+		return dial(ctx)
+	}
+
+	tiers := make([]bool, len(pref))
+	unranked := false
+	for _, addrs := range [][]ma.Multiaddr{addrs, d.host.Peerstore().Addrs(p)} {
+		for _, addr := range addrs {
+			if rank := pref.Rank(addr); rank >= 0 {
+				tiers[rank] = true
+			} else {
+				unranked = true
+			}
+		}
+	}
+
+	var err error
+	for rank, known := range tiers {
+		if !known {
+			continue
+		}
+		if err = dialTier(ctx, gater, pref, timeout, p, rank, dial); err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return err
+		}
+		log.Debugw("dial over preferred addresses failed", "peer", p, "tier", rank, "error", err)
+	}
+	if err == nil || unranked {
+		return dial(ctx)
+	}
+	return err
+}
+
+// dialTier runs dial, for up to timeout unless zero, with dials to p held to
+// the addresses of pref's tier rank.
+func dialTier(ctx context.Context, gater *ConnectionGater, pref AddrPreference, timeout time.Duration, p peer.ID, rank int, dial func(context.Context) error) error {
+	release := gater.restrictDials(p, func(addr ma.Multiaddr) bool {
+		return pref.Rank(addr) == rank
+	})
+	defer release()
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return dial(ctx)
+}
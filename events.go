@@ -0,0 +1,202 @@
+package p2pd
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	ggio "github.com/gogo/protobuf/io"
+	"github.com/google/uuid"
+	"github.com/libp2p/go-libp2p-core/event"
+	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// eventTypes maps the event types a client can subscribe to onto the event
+// bus types emitted by the host.
+var eventTypes = map[pb.Event_Type]interface{}{
+	pb.Event_LOCAL_REACHABILITY_CHANGED: new(event.EvtLocalReachabilityChanged),
+	pb.Event_PEER_CONNECTEDNESS_CHANGED: new(peerConnectednessChanged),
+	pb.Event_LOCAL_ADDRESSES_UPDATED:    new(event.EvtLocalAddressesUpdated),
+}
+
+// doSubscribeEvents relays the selected event bus events to the client until
+// the client cancels the call or connCtx ends with the persistent connection.
+func (d *Daemon) doSubscribeEvents(connCtx context.Context, callID uuid.UUID, req *pb.SubscribeEventsRequest, w ggio.Writer) {
+	writeResponse := func(resp *pb.PersistentConnectionResponse) bool {
+		if err := w.WriteMsg(resp); err != nil {
+			log.Debugw("error writing message", "error", err)
+			return false
+		}
+		return true
+	}
+
+	if len(req.GetTypes()) == 0 {
+		writeResponse(errorUnaryCallString(callID, "no event types to subscribe to"))
+		return
+	}
+
+	types := make([]interface{}, 0, len(req.GetTypes()))
+	for _, t := range req.GetTypes() {
+		evtType, ok := eventTypes[t]
+		if !ok {
+			writeResponse(errorUnaryCallString(callID, fmt.Sprintf("unknown event type %d", t)))
+			return
+		}
+		types = append(types, evtType)
+	}
+
+	sub, err := d.host.EventBus().Subscribe(types)
+	if err != nil {
+		writeResponse(errorUnaryCall(callID, err))
+		return
+	}
+	defer sub.Close()
+
+	// the call is registered only once subscribed, so no event is missed by a
+	// client that sees it among the active calls
+	ctx, cancel := context.WithCancel(connCtx)
+	defer cancel()
+
+	if !d.trackCall(callID, inflightCall{cancel, w}) {
+		writeResponse(errorDuplicateCallID(callID))
+		return
+	}
+	defer d.cancelUnary.Delete(callID)
+
+	// the reachability forwarded last, if any: RefreshRelays and
+	// KeepStaticRelays emit the private reachability again to make autorelay
+	// look for relays, which isn't a change
+	var reachability *network.Reachability
+
+	for {
+		select {
+		case evt, ok := <-sub.Out():
+			if !ok {
+				writeResponse(okEndOfStream(callID))
+				return
+			}
+
+			if evt, ok := evt.(event.EvtLocalReachabilityChanged); ok {
+				if reachability != nil && *reachability == evt.Reachability {
+					continue
+				}
+				reachability = &evt.Reachability
+			}
+
+			pbEvt := eventToPb(evt)
+			if pbEvt == nil {
+				continue
+			}
+
+			resp := okUnaryCallResponse(callID)
+			resp.Message = &pb.PersistentConnectionResponse_Event{Event: pbEvt}
+			if !writeResponse(resp) {
+				return
+			}
+
+		case <-ctx.Done():
+			writeResponse(okUnaryCallCancelled(callID))
+			return
+		}
+	}
+}
+
+func eventToPb(evt interface{}) *pb.Event {
+	switch evt := evt.(type) {
+	case event.EvtLocalReachabilityChanged:
+		return &pb.Event{
+			Type:         pb.Event_LOCAL_REACHABILITY_CHANGED.Enum(),
+			Reachability: pb.Event_Reachability(evt.Reachability).Enum(),
+		}
+
+	case peerConnectednessChanged:
+		return &pb.Event{
+			Type:          pb.Event_PEER_CONNECTEDNESS_CHANGED.Enum(),
+			Peer:          []byte(evt.Peer),
+			Connectedness: pb.Event_Connectedness(evt.Connectedness).Enum(),
+			Addr:          evt.Addr.Bytes(),
+		}
+
+	case event.EvtLocalAddressesUpdated:
+		addrs := make([][]byte, len(evt.Current))
+		for i, a := range evt.Current {
+			addrs[i] = a.Address.Bytes()
+		}
+		return &pb.Event{
+			Type:  pb.Event_LOCAL_ADDRESSES_UPDATED.Enum(),
+			Addrs: addrs,
+		}
+	}
+
+	log.Debugw("unexpected event type", "type", fmt.Sprintf("%T", evt))
+	return nil
+}
+
+// peerConnectednessChanged is EvtPeerConnectednessChanged along with the
+// remote address of the connection that changed it, for the clients
+// subscribed to connectedness changes.
+type peerConnectednessChanged struct {
+	event.EvtPeerConnectednessChanged
+	// Addr is the remote address of the first connection opened to the
+	// peer, or of the last one closed.
+	Addr ma.Multiaddr
+}
+
+// connectednessEmitter emits EvtPeerConnectednessChanged on the host's event
+// bus, which the swarm doesn't do by itself, and peerConnectednessChanged. An
+// event is emitted when the first connection to a peer is opened and when the
+// last one is closed.
+type connectednessEmitter struct {
+	emitter     event.Emitter
+	addrEmitter event.Emitter
+
+	mx        sync.Mutex
+	connected map[peer.ID]struct{}
+}
+
+func newConnectednessEmitter(h host.Host) (*connectednessEmitter, error) {
+	emitter, err := h.EventBus().Emitter(new(event.EvtPeerConnectednessChanged))
+	if err != nil {
+		return nil, err
+	}
+	addrEmitter, err := h.EventBus().Emitter(new(peerConnectednessChanged))
+	if err != nil {
+		emitter.Close()
+		return nil, err
+	}
+
+	return &connectednessEmitter{
+		emitter:     emitter,
+		addrEmitter: addrEmitter,
+		connected:   make(map[peer.ID]struct{}),
+	}, nil
+}
+
+// update emits the events if c was the first connection opened to its peer,
+// or the last one closed.
+func (ce *connectednessEmitter) update(n network.Network, c network.Conn) {
+	ce.mx.Lock()
+	defer ce.mx.Unlock()
+
+	p := c.RemotePeer()
+	connectedness := n.Connectedness(p)
+	_, wasConnected := ce.connected[p]
+	if (connectedness == network.Connected) == wasConnected {
+		return
+	}
+
+	if wasConnected {
+		delete(ce.connected, p)
+	} else {
+		ce.connected[p] = struct{}{}
+	}
+
+	evt := event.EvtPeerConnectednessChanged{Peer: p, Connectedness: connectedness}
+	ce.emitter.Emit(evt)
+	ce.addrEmitter.Emit(peerConnectednessChanged{EvtPeerConnectednessChanged: evt, Addr: c.RemoteMultiaddr()})
+}
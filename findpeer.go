@@ -0,0 +1,44 @@
+package p2pd
+
+import (
+	"fmt"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/routing"
+
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// doFindPeer returns the addresses of a peer known to the peerstore, falling
+// back to the DHT, if enabled, when there are none. Addresses expire from the
+// peerstore with their TTL, so stale ones aren't returned.
+func (d *Daemon) doFindPeer(req *pb.Request) *pb.Response {
+	if req.FindPeer == nil {
+		return errorResponseString("Malformed request; missing parameters")
+	}
+
+	p, err := peer.IDFromBytes(req.FindPeer.GetPeer())
+	if err != nil {
+		return errorResponse(err)
+	}
+
+	pi := d.host.Peerstore().PeerInfo(p)
+	if len(pi.Addrs) == 0 && !req.FindPeer.GetSkipDHT() && d.dht != nil {
+		ctx, cancel := d.requestContext(req.FindPeer.GetTimeout())
+		defer cancel()
+
+		pi, err = d.dht.FindPeer(ctx, p)
+		if err != nil && err != routing.ErrNotFound {
+			log.Debugw("error looking up peer in the DHT", "peer", p, "error", err)
+			return errorResponse(err)
+		}
+	}
+
+	if len(pi.Addrs) == 0 {
+		return errorResponseString(fmt.Sprintf("peer %s not found", p.Pretty()))
+	}
+
+	res := okResponse()
+	res.Peer = peerInfo2pb(pi)
+	return res
+}
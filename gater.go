@@ -0,0 +1,348 @@
+package p2pd
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/connmgr"
+	"github.com/libp2p/go-libp2p-core/control"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+	ma "github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+)
+
+// ConnectionGater is the daemon's connection gater. It allows every
+// connection unless peer lists are set with SetPeerLists or an inbound rate
+// limit is set with LimitInboundRate.
+type ConnectionGater struct {
+	mx      sync.RWMutex
+	inbound *ipRateLimiter
+	// allowed is nil when every peer that isn't blocked is allowed
+	allowed map[peer.ID]struct{}
+	blocked map[peer.ID]struct{}
+	// dials holds the restrictions set with restrictDials
+	dials map[peer.ID][]*dialRestriction
+}
+
+type dialRestriction struct {
+	allow func(ma.Multiaddr) bool
+}
+
+var _ connmgr.ConnectionGater = (*ConnectionGater)(nil)
+
+func NewConnectionGater() *ConnectionGater {
+	return &ConnectionGater{}
+}
+
+// LimitInboundRate allows each remote IP to open conns inbound connections
+// per window, with bursts of up to burst connections. Excess connections are
+// rejected as soon as they are accepted, before the security handshake.
+func (g *ConnectionGater) LimitInboundRate(conns int, window time.Duration, burst int) {
+	g.mx.Lock()
+	defer g.mx.Unlock()
+
+	g.inbound = newIPRateLimiter(float64(conns)/window.Seconds(), burst)
+}
+
+// SetPeerLists replaces the peer lists. Connections to and from blocked
+// peers are rejected, and so are those with peers missing from allowed unless
+// it is empty. It doesn't affect connections that are already open.
+func (g *ConnectionGater) SetPeerLists(allowed, blocked []peer.ID) {
+	g.mx.Lock()
+	defer g.mx.Unlock()
+
+	g.allowed = nil
+	if len(allowed) > 0 {
+		g.allowed = peerSet(allowed)
+	}
+	g.blocked = peerSet(blocked)
+}
+
+// PeerLists returns the peer lists set with SetPeerLists.
+func (g *ConnectionGater) PeerLists() (allowed, blocked []peer.ID) {
+	g.mx.RLock()
+	defer g.mx.RUnlock()
+
+	return peerSlice(g.allowed), peerSlice(g.blocked)
+}
+
+// AllowPeer reports whether connections with p are allowed by the peer lists.
+func (g *ConnectionGater) AllowPeer(p peer.ID) bool {
+	g.mx.RLock()
+	defer g.mx.RUnlock()
+
+	if _, blocked := g.blocked[p]; blocked {
+		return false
+	}
+	if g.allowed == nil {
+		return true
+	}
+	_, allowed := g.allowed[p]
+	return allowed
+}
+
+func peerSet(peers []peer.ID) map[peer.ID]struct{} {
+	set := make(map[peer.ID]struct{}, len(peers))
+	for _, p := range peers {
+		set[p] = struct{}{}
+	}
+	return set
+}
+
+func peerSlice(set map[peer.ID]struct{}) []peer.ID {
+	peers := make([]peer.ID, 0, len(set))
+	for p := range set {
+		peers = append(peers, p)
+	}
+	return peers
+}
+
+func (g *ConnectionGater) InterceptPeerDial(p peer.ID) bool {
+	if !g.AllowPeer(p) {
+		log.Debugw("denying dial to peer", "peer", p)
+		rejectedConns.WithLabelValues(rejectedPeerList).Inc()
+		return false
+	}
+	return true
+}
+
+// InterceptAddrDial holds dials to the addresses restrictDials allows, if it
+// was called for the peer.
+func (g *ConnectionGater) InterceptAddrDial(p peer.ID, addr ma.Multiaddr) bool {
+	g.mx.RLock()
+	defer g.mx.RUnlock()
+
+	restrictions, ok := g.dials[p]
+	if !ok {
+		return true
+	}
+	for _, r := range restrictions {
+		if r.allow(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// restrictDials has dials to p only use the addresses allow accepts until
+// release is called. Dials to p from anywhere in the host are restricted, and
+// restrictions set at once add up.
+func (g *ConnectionGater) restrictDials(p peer.ID, allow func(ma.Multiaddr) bool) (release func()) {
+	r := &dialRestriction{allow: allow}
+
+	g.mx.Lock()
+	defer g.mx.Unlock()
+
+	if g.dials == nil {
+		g.dials = make(map[peer.ID][]*dialRestriction)
+	}
+	g.dials[p] = append(g.dials[p], r)
+
+	return func() {
+		g.mx.Lock()
+		defer g.mx.Unlock()
+
+		restrictions := g.dials[p]
+		for i, other := range restrictions {
+			if other == r {
+				restrictions = append(restrictions[:i], restrictions[i+1:]...)
+				break
+			}
+		}
+		if len(restrictions) == 0 {
+			delete(g.dials, p)
+		} else {
+			g.dials[p] = restrictions
+		}
+	}
+}
+
+func (g *ConnectionGater) InterceptAccept(addrs network.ConnMultiaddrs) bool {
+	g.mx.RLock()
+	inbound := g.inbound
+	g.mx.RUnlock()
+
+	if inbound == nil {
+		return true
+	}
+
+	// connections through a relay have no remote IP to limit
+	ip, err := manet.ToIP(addrs.RemoteMultiaddr())
+	if err != nil {
+		return true
+	}
+
+	if !inbound.allow(ip, time.Now()) {
+		log.Debugw("rejecting inbound connection over the rate limit", "addr", addrs.RemoteMultiaddr())
+		rejectedConns.WithLabelValues(rejectedRateLimit).Inc()
+		return false
+	}
+	return true
+}
+
+// InterceptSecured checks the peer lists again, as the remote peer of inbound
+// connections is only known once they are secured.
+func (g *ConnectionGater) InterceptSecured(dir network.Direction, p peer.ID, addrs network.ConnMultiaddrs) bool {
+	if !g.AllowPeer(p) {
+		log.Debugw("denying connection with peer", "peer", p, "direction", dir, "addr", addrs.RemoteMultiaddr())
+		rejectedConns.WithLabelValues(rejectedPeerList).Inc()
+		return false
+	}
+	return true
+}
+
+func (g *ConnectionGater) InterceptUpgraded(network.Conn) (bool, control.DisconnectReason) {
+	return true, 0
+}
+
+// SetConnectionGater lets clients update the peer lists of g, which must be
+// the connection gater of the daemon's host.
+func (d *Daemon) SetConnectionGater(g *ConnectionGater) {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	d.gater = g
+}
+
+func (d *Daemon) doPeerLists(req *pb.Request) *pb.Response {
+	if req.PeerLists == nil {
+		return errorResponseString("Malformed request; missing parameters")
+	}
+
+	d.mx.Lock()
+	gater := d.gater
+	d.mx.Unlock()
+
+	if gater == nil {
+		return errorResponseString("Connection gater is not enabled")
+	}
+
+	switch req.PeerLists.GetType() {
+	case pb.PeerListsRequest_GET:
+		// the current lists are returned below
+
+	case pb.PeerListsRequest_SET:
+		allowed, err := peerIDsFromBytes(req.PeerLists.GetAllowedPeers())
+		if err != nil {
+			return errorResponse(err)
+		}
+		blocked, err := peerIDsFromBytes(req.PeerLists.GetBlockedPeers())
+		if err != nil {
+			return errorResponse(err)
+		}
+
+		gater.SetPeerLists(allowed, blocked)
+		log.Infow("updated peer lists", "allowed", len(allowed), "blocked", len(blocked))
+
+		// the gater only sees new connections
+		for _, p := range d.host.Network().Peers() {
+			if !gater.AllowPeer(p) {
+				log.Debugw("disconnecting denied peer", "peer", p)
+				d.host.Network().ClosePeer(p)
+			}
+		}
+
+	default:
+		return errorResponseString("Unexpected request")
+	}
+
+	allowed, blocked := gater.PeerLists()
+	res := okResponse()
+	res.PeerLists = &pb.PeerListsResponse{
+		AllowedPeers: peerIDsToBytes(allowed),
+		BlockedPeers: peerIDsToBytes(blocked),
+	}
+	return res
+}
+
+func peerIDsFromBytes(bs [][]byte) ([]peer.ID, error) {
+	peers := make([]peer.ID, len(bs))
+	for i, b := range bs {
+		p, err := peer.IDFromBytes(b)
+		if err != nil {
+			return nil, err
+		}
+		peers[i] = p
+	}
+	return peers, nil
+}
+
+func peerIDsToBytes(peers []peer.ID) [][]byte {
+	bs := make([][]byte, len(peers))
+	for i, p := range peers {
+		bs[i] = []byte(p)
+	}
+	return bs
+}
+
+// ipRateLimiter keeps a token bucket per IP, refilled at rate tokens per
+// second up to burst tokens.
+type ipRateLimiter struct {
+	rate  float64
+	burst float64
+
+	mx        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastPrune time.Time
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func newIPRateLimiter(rate float64, burst int) *ipRateLimiter {
+	return &ipRateLimiter{
+		rate:    rate,
+		burst:   float64(burst),
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+func (l *ipRateLimiter) allow(ip net.IP, now time.Time) bool {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	l.prune(now)
+
+	key := ip.String()
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens = l.refill(b, now)
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+func (l *ipRateLimiter) refill(b *tokenBucket, now time.Time) float64 {
+	tokens := b.tokens + now.Sub(b.last).Seconds()*l.rate
+	if tokens > l.burst {
+		return l.burst
+	}
+	return tokens
+}
+
+// prune drops the buckets that are full again, as they behave like new ones.
+// It runs at most once per the time it takes to refill a bucket.
+func (l *ipRateLimiter) prune(now time.Time) {
+	if now.Sub(l.lastPrune).Seconds() < l.burst/l.rate {
+		return
+	}
+	l.lastPrune = now
+
+	for key, b := range l.buckets {
+		if l.refill(b, now) >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,258 @@
+package p2pd
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+type httpPeerInfo struct {
+	ID    string   `json:"id"`
+	Addrs []string `json:"addrs"`
+}
+
+type httpConnInfo struct {
+	Addr      string `json:"addr"`
+	Direction string `json:"direction"`
+	Relayed   bool   `json:"relayed"`
+	Security  string `json:"security,omitempty"`
+}
+
+type httpConnectedPeer struct {
+	ID    string         `json:"id"`
+	Conns []httpConnInfo `json:"conns"`
+}
+
+type httpConnectRequest struct {
+	Peer    string   `json:"peer"`
+	Addrs   []string `json:"addrs"`
+	Timeout int64    `json:"timeout,omitempty"`
+}
+
+type httpError struct {
+	Error string `json:"error"`
+}
+
+// HTTPControlHandler exposes a subset of the control protocol as JSON over
+// HTTP, for clients without protobuf bindings. Requests are translated to the
+// same handlers as the control socket. If token is not empty, requests must
+// carry it as a bearer token.
+//
+//	GET  /identify
+//	POST /connect          {"peer": <id>, "addrs": [<addr>, ...], "timeout": <seconds>}
+//	GET  /peers
+//	GET  /dht/findpeer?peer=<id>
+func (d *Daemon) HTTPControlHandler(token string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/identify", httpMethod(http.MethodGet, d.httpIdentify))
+	mux.HandleFunc("/connect", httpMethod(http.MethodPost, d.httpConnect))
+	mux.HandleFunc("/peers", httpMethod(http.MethodGet, d.httpListPeers))
+	mux.HandleFunc("/dht/findpeer", httpMethod(http.MethodGet, d.httpDHTFindPeer))
+
+	if token == "" {
+		return mux
+	}
+
+	expected := []byte("Bearer " + token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) != 1 {
+			writeHTTPError(w, http.StatusUnauthorized, "missing or invalid bearer token")
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+func httpMethod(method string, h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != method {
+			w.Header().Set("Allow", method)
+			writeHTTPError(w, http.StatusMethodNotAllowed, fmt.Sprintf("expected %s", method))
+			return
+		}
+		h(w, r)
+	}
+}
+
+func (d *Daemon) httpIdentify(w http.ResponseWriter, r *http.Request) {
+	res := d.doIdentify(&pb.Request{Type: pb.Request_IDENTIFY.Enum()})
+	if !checkHTTPResponse(w, res) {
+		return
+	}
+
+	info, err := httpPeerInfoFromPb(res.GetIdentify().GetId(), res.GetIdentify().GetAddrs())
+	if err != nil {
+		writeHTTPError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeHTTPResponse(w, info)
+}
+
+func (d *Daemon) httpConnect(w http.ResponseWriter, r *http.Request) {
+	var hreq httpConnectRequest
+	if err := json.NewDecoder(r.Body).Decode(&hreq); err != nil {
+		writeHTTPError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	p, err := peer.Decode(hreq.Peer)
+	if err != nil {
+		writeHTTPError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	addrs := make([][]byte, len(hreq.Addrs))
+	for i, s := range hreq.Addrs {
+		addr, err := ma.NewMultiaddr(s)
+		if err != nil {
+			writeHTTPError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		addrs[i] = addr.Bytes()
+	}
+
+	res := d.doConnect(&pb.Request{
+		Type: pb.Request_CONNECT.Enum(),
+		Connect: &pb.ConnectRequest{
+			Peer:    []byte(p),
+			Addrs:   addrs,
+			Timeout: &hreq.Timeout,
+		},
+	})
+	if !checkHTTPResponse(w, res) {
+		return
+	}
+
+	peers, err := httpConnectedPeersFromPb(res.GetConnectedPeers())
+	if err != nil {
+		writeHTTPError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeHTTPResponse(w, peers[0])
+}
+
+func (d *Daemon) httpListPeers(w http.ResponseWriter, r *http.Request) {
+	res := d.doListConnections(&pb.Request{Type: pb.Request_LIST_CONNECTIONS.Enum()})
+	if !checkHTTPResponse(w, res) {
+		return
+	}
+
+	peers, err := httpConnectedPeersFromPb(res.GetConnectedPeers())
+	if err != nil {
+		writeHTTPError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeHTTPResponse(w, peers)
+}
+
+func (d *Daemon) httpDHTFindPeer(w http.ResponseWriter, r *http.Request) {
+	p, err := peer.Decode(r.URL.Query().Get("peer"))
+	if err != nil {
+		writeHTTPError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	res, _, _ := d.doDHT(&pb.Request{
+		Type: pb.Request_DHT.Enum(),
+		Dht: &pb.DHTRequest{
+			Type: pb.DHTRequest_FIND_PEER.Enum(),
+			Peer: []byte(p),
+		},
+	})
+	if !checkHTTPResponse(w, res) {
+		return
+	}
+
+	pi := res.GetDht().GetPeer()
+	info, err := httpPeerInfoFromPb(pi.GetId(), pi.GetAddrs())
+	if err != nil {
+		writeHTTPError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeHTTPResponse(w, info)
+}
+
+// checkHTTPResponse writes the error carried by res, if any, and reports
+// whether the request succeeded.
+func checkHTTPResponse(w http.ResponseWriter, res *pb.Response) bool {
+	if res.GetType() == pb.Response_OK {
+		return true
+	}
+
+	writeHTTPError(w, http.StatusInternalServerError, res.GetError().GetMsg())
+	return false
+}
+
+func writeHTTPResponse(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Debugw("error writing http response", "error", err)
+	}
+}
+
+func writeHTTPError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(httpError{Error: msg}); err != nil {
+		log.Debugw("error writing http response", "error", err)
+	}
+}
+
+func httpPeerInfoFromPb(id []byte, addrs [][]byte) (httpPeerInfo, error) {
+	p, err := peer.IDFromBytes(id)
+	if err != nil {
+		return httpPeerInfo{}, err
+	}
+
+	info := httpPeerInfo{ID: p.Pretty(), Addrs: make([]string, len(addrs))}
+	for i, bs := range addrs {
+		addr, err := ma.NewMultiaddrBytes(bs)
+		if err != nil {
+			return httpPeerInfo{}, err
+		}
+		info.Addrs[i] = addr.String()
+	}
+
+	return info, nil
+}
+
+func httpConnectedPeersFromPb(pbPeers []*pb.ConnectedPeer) ([]httpConnectedPeer, error) {
+	peers := make([]httpConnectedPeer, len(pbPeers))
+	for i, pbPeer := range pbPeers {
+		p, err := peer.IDFromBytes(pbPeer.GetId())
+		if err != nil {
+			return nil, err
+		}
+
+		conns := make([]httpConnInfo, len(pbPeer.GetConns()))
+		for j, pbConn := range pbPeer.GetConns() {
+			addr, err := ma.NewMultiaddrBytes(pbConn.GetAddr())
+			if err != nil {
+				return nil, err
+			}
+
+			conns[j] = httpConnInfo{
+				Addr:      addr.String(),
+				Direction: strings.ToLower(pbConn.GetDirection().String()),
+				Relayed:   pbConn.GetRelayed(),
+				Security:  pbConn.GetSecurity(),
+			}
+		}
+
+		peers[i] = httpConnectedPeer{ID: p.Pretty(), Conns: conns}
+	}
+
+	return peers, nil
+}
@@ -1,11 +1,24 @@
 package p2pd
 
 import (
+	"fmt"
 	"io/ioutil"
+	"os"
+	"strings"
 
 	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
 )
 
+// DefaultRSAKeyBits is the size of the rsa keys generated by p2pd.
+const DefaultRSAKeyBits = 2048
+
+var keyTypes = map[string]int{
+	"rsa":       crypto.RSA,
+	"ed25519":   crypto.Ed25519,
+	"secp256k1": crypto.Secp256k1,
+}
+
 func ReadIdentity(path string) (crypto.PrivKey, error) {
 	bytes, err := ioutil.ReadFile(path)
 	if err != nil {
@@ -15,6 +28,17 @@ func ReadIdentity(path string) (crypto.PrivKey, error) {
 	return crypto.UnmarshalPrivateKey(bytes)
 }
 
+// DecodeIdentity decodes a private key in the format of ReadIdentity, base64
+// encoded, e.g. when it is passed through the environment.
+func DecodeIdentity(s string) (crypto.PrivKey, error) {
+	bytes, err := crypto.ConfigDecodeKey(strings.TrimSpace(s))
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.UnmarshalPrivateKey(bytes)
+}
+
 func WriteIdentity(k crypto.PrivKey, path string) error {
 	bytes, err := crypto.MarshalPrivateKey(k)
 	if err != nil {
@@ -23,3 +47,57 @@ func WriteIdentity(k crypto.PrivKey, path string) error {
 
 	return ioutil.WriteFile(path, bytes, 0400)
 }
+
+// NewIdentity generates a private key of the given type (rsa, ed25519 or
+// secp256k1) without writing it anywhere. bits is only used for rsa keys.
+func NewIdentity(keyType string, bits int) (crypto.PrivKey, error) {
+	typ, ok := keyTypes[keyType]
+	if !ok {
+		return nil, fmt.Errorf("unknown key type %s; must be rsa, ed25519 or secp256k1", keyType)
+	}
+
+	priv, _, err := crypto.GenerateKeyPair(typ, bits)
+	return priv, err
+}
+
+// keyTypeName returns the name of a key type in keyTypes.
+func keyTypeName(typ int) (string, bool) {
+	for name, t := range keyTypes {
+		if t == typ {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// GenerateIdentity generates a private key of the given type (rsa, ed25519 or
+// secp256k1) and writes it to path in the format read by ReadIdentity. bits
+// is only used for rsa keys. An existing file is never overwritten.
+func GenerateIdentity(keyType string, bits int, path string) (peer.ID, error) {
+	typ, ok := keyTypes[keyType]
+	if !ok {
+		return "", fmt.Errorf("unknown key type %s; must be rsa, ed25519 or secp256k1", keyType)
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		return "", fmt.Errorf("%s already exists", path)
+	} else if !os.IsNotExist(err) {
+		return "", err
+	}
+
+	priv, pub, err := crypto.GenerateKeyPair(typ, bits)
+	if err != nil {
+		return "", err
+	}
+
+	id, err := peer.IDFromPublicKey(pub)
+	if err != nil {
+		return "", err
+	}
+
+	if err := WriteIdentity(priv, path); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
@@ -0,0 +1,84 @@
+package p2pd
+
+import (
+	"runtime"
+	"runtime/debug"
+	"time"
+
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+
+	relay "github.com/libp2p/go-libp2p-circuit"
+)
+
+// buildPath and buildVersion are read once, as the build info doesn't change.
+var buildPath, buildVersion = readBuildInfo()
+
+func readBuildInfo() (string, string) {
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "", ""
+	}
+	return bi.Main.Path, bi.Main.Version
+}
+
+// BuildInfo returns the path and version of the main module the daemon was
+// built from. The version is "(devel)" when built from a working tree, and
+// both are empty when built without module support.
+func BuildInfo() (path, version string) {
+	return buildPath, buildVersion
+}
+
+// SetRelayService records whether the host was constructed with the relay
+// service (hop) enabled, which libp2p doesn't expose, for DAEMON_INFO. The
+// relay metrics are tracked while it is.
+func (d *Daemon) SetRelayService(hop bool) {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	d.relayHop = hop
+	if hop && !d.relayMetrics {
+		d.relayMetrics = true
+		go d.trackRelayCircuits()
+	}
+}
+
+func (d *Daemon) doDaemonInfo(req *pb.Request) *pb.Response {
+	startTime := d.startTime.UnixNano()
+	uptime := int64(time.Since(d.startTime))
+	goVersion := runtime.Version()
+
+	// the relay transport handles the relay protocol, as a client or a hop
+	relayEnabled := false
+	for _, proto := range d.host.Mux().Protocols() {
+		if proto == string(relay.ProtoID) {
+			relayEnabled = true
+			break
+		}
+	}
+
+	d.mx.Lock()
+	relayHop := d.relayHop
+	d.mx.Unlock()
+
+	info := &pb.DaemonInfoResponse{
+		StartTime: &startTime,
+		Uptime:    &uptime,
+		GoVersion: &goVersion,
+		Relay:     &relayEnabled,
+		RelayHop:  &relayHop,
+	}
+	if buildPath != "" {
+		info.Module = &buildPath
+		info.Version = &buildVersion
+	}
+	if d.dht != nil {
+		info.DhtMode = &d.dhtMode
+	}
+	if d.pubsub != nil {
+		info.PubsubRouter = &d.pubsubRouter
+	}
+
+	res := okResponse()
+	res.DaemonInfo = info
+	return res
+}
@@ -0,0 +1,249 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+
+	ggio "github.com/gogo/protobuf/io"
+	"github.com/gogo/protobuf/proto"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// PayloadTooLargeError is returned when a chunked payload is announced to be
+// larger than allowed.
+type PayloadTooLargeError struct {
+	Size uint64
+	Max  int
+}
+
+func (e PayloadTooLargeError) Error() string {
+	return fmt.Sprintf("chunked payload of %d bytes exceeds the maximum of %d", e.Size, e.Max)
+}
+
+// chunkWriteTimeout bounds how long each chunk written to a QueuedWriter
+// waits for room in its queue.
+const chunkWriteTimeout = 30 * time.Second
+
+// writeChunk writes a chunk to w. Once the message a payload belongs to is
+// written, its chunks wait for room in the queue of a QueuedWriter rather than
+// being dropped, as the payload is only usable whole and may have more chunks
+// than the queue holds.
+func writeChunk(w ggio.Writer, chunk proto.Message) error {
+	if qw, ok := w.(*QueuedWriter); ok {
+		return qw.WriteMsgWait(chunk, chunkWriteTimeout)
+	}
+	return w.WriteMsg(chunk)
+}
+
+// WriteChunkedRequest writes req to w, followed by the chunks of its payload
+// if it is larger than chunkSize. req is left as is.
+func WriteChunkedRequest(w ggio.Writer, req *pb.PersistentConnectionRequest, chunkSize int) error {
+	header, chunks := split(req, chunkSize)
+	if err := w.WriteMsg(header); err != nil {
+		return err
+	}
+	for _, c := range chunks {
+		if err := writeChunk(w, &pb.PersistentConnectionRequest{
+			CallId:  req.CallId,
+			Message: &pb.PersistentConnectionRequest_Chunk{Chunk: c},
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteChunkedResponse is WriteChunkedRequest for responses.
+func WriteChunkedResponse(w ggio.Writer, resp *pb.PersistentConnectionResponse, chunkSize int) error {
+	header, chunks := split(resp, chunkSize)
+	if err := w.WriteMsg(header); err != nil {
+		return err
+	}
+	for _, c := range chunks {
+		if err := writeChunk(w, &pb.PersistentConnectionResponse{
+			CallId:  resp.CallId,
+			Message: &pb.PersistentConnectionResponse_Chunk{Chunk: c},
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadChunkedRequest reads req from r, along with the chunks of its payload
+// if it announces any, which must directly follow it and total at most max
+// bytes.
+func ReadChunkedRequest(r ggio.Reader, req *pb.PersistentConnectionRequest, max int) error {
+	if err := r.ReadMsg(req); err != nil {
+		return err
+	}
+	a, err := NewChunkAssembly(req, max)
+	if a == nil || err != nil {
+		return err
+	}
+	for {
+		var msg pb.PersistentConnectionRequest
+		if err := r.ReadMsg(&msg); err != nil {
+			return err
+		}
+		if msg.GetChunk() == nil {
+			return fmt.Errorf("expected a payload chunk, got %T", msg.Message)
+		}
+		if done, err := a.Add(msg.GetChunk()); done != nil || err != nil {
+			return err
+		}
+	}
+}
+
+// ChunkAssembly reassembles the payload of a message sent in chunks.
+type ChunkAssembly struct {
+	msg  proto.Message
+	size uint64
+	next uint32
+	data []byte
+}
+
+// NewChunkAssembly starts reassembling the payload msg announces with a
+// chunked size, which must not exceed max. It returns nil if msg has its
+// payload in full.
+func NewChunkAssembly(msg proto.Message, max int) (*ChunkAssembly, error) {
+	_, size := payload(msg)
+	if size == nil || *size == nil {
+		return nil, nil
+	}
+	if **size > uint64(max) {
+		return nil, PayloadTooLargeError{**size, max}
+	}
+	return &ChunkAssembly{msg: msg, size: **size}, nil
+}
+
+// Add appends the next chunk to the payload. Once it is complete, Add returns
+// the message, with the payload in place of its chunked size, and nil before.
+func (a *ChunkAssembly) Add(c *pb.PayloadChunk) (proto.Message, error) {
+	if c.GetSeq() != a.next {
+		return nil, fmt.Errorf("expected payload chunk %d, got %d", a.next, c.GetSeq())
+	}
+	a.next++
+
+	if uint64(len(a.data)+len(c.Data)) > a.size {
+		return nil, fmt.Errorf("payload chunks exceed the announced size of %d bytes", a.size)
+	}
+	a.data = append(a.data, c.Data...)
+	if !c.GetLast() {
+		return nil, nil
+	}
+	if uint64(len(a.data)) != a.size {
+		return nil, fmt.Errorf("payload chunks total %d bytes, short of the announced %d", len(a.data), a.size)
+	}
+
+	data, size := payload(a.msg)
+	*data, *size = a.data, nil
+	return a.msg, nil
+}
+
+// split returns msg with its payload replaced by its chunked size, followed
+// by the chunks of the payload, if it is larger than chunkSize, and msg alone
+// otherwise, as it is when chunkSize isn't positive. msg is left as is.
+func split(msg proto.Message, chunkSize int) (proto.Message, []*pb.PayloadChunk) {
+	data, _ := payload(msg)
+	if data == nil || chunkSize <= 0 || len(*data) <= chunkSize {
+		return msg, nil
+	}
+
+	full := *data
+	header := shallowCopy(msg)
+	hdata, hsize := payload(header)
+	size := uint64(len(full))
+	*hdata, *hsize = []byte{}, &size
+
+	var chunks []*pb.PayloadChunk
+	for len(full) > 0 {
+		n := chunkSize
+		if n > len(full) {
+			n = len(full)
+		}
+		seq := uint32(len(chunks))
+		chunks = append(chunks, &pb.PayloadChunk{Seq: &seq, Data: full[:n]})
+		full = full[n:]
+	}
+	last := true
+	chunks[len(chunks)-1].Last = &last
+	return header, chunks
+}
+
+// payload points at the payload of msg that may be sent in chunks and at its
+// chunked size, or returns nils for messages without such a payload.
+func payload(msg proto.Message) (*[]byte, **uint64) {
+	switch m := msg.(type) {
+	case *pb.PersistentConnectionRequest:
+		switch {
+		case m.GetCallUnary() != nil:
+			return &m.GetCallUnary().Data, &m.GetCallUnary().ChunkedSize
+		case m.GetCallStream() != nil:
+			return &m.GetCallStream().Data, &m.GetCallStream().ChunkedSize
+		case m.GetUnaryResponse() != nil:
+			return unaryResponsePayload(m.GetUnaryResponse())
+		}
+	case *pb.PersistentConnectionResponse:
+		switch {
+		case m.GetRequestHandling() != nil:
+			return &m.GetRequestHandling().Data, &m.GetRequestHandling().ChunkedSize
+		case m.GetCallUnaryResponse() != nil:
+			return unaryResponsePayload(m.GetCallUnaryResponse())
+		}
+	}
+	return nil, nil
+}
+
+// unaryResponsePayload only chunks successful responses, errors being short.
+func unaryResponsePayload(r *pb.CallUnaryResponse) (*[]byte, **uint64) {
+	res, ok := r.Result.(*pb.CallUnaryResponse_Response)
+	if !ok {
+		return nil, nil
+	}
+	return &res.Response, &r.ChunkedSize
+}
+
+// shallowCopy copies msg down to the message holding its payload, which can
+// then be replaced without affecting msg.
+func shallowCopy(msg proto.Message) proto.Message {
+	switch m := msg.(type) {
+	case *pb.PersistentConnectionRequest:
+		c := *m
+		switch inner := m.Message.(type) {
+		case *pb.PersistentConnectionRequest_CallUnary:
+			call := *inner.CallUnary
+			c.Message = &pb.PersistentConnectionRequest_CallUnary{CallUnary: &call}
+		case *pb.PersistentConnectionRequest_CallStream:
+			call := *inner.CallStream
+			c.Message = &pb.PersistentConnectionRequest_CallStream{CallStream: &call}
+		case *pb.PersistentConnectionRequest_UnaryResponse:
+			c.Message = &pb.PersistentConnectionRequest_UnaryResponse{
+				UnaryResponse: copyUnaryResponse(inner.UnaryResponse),
+			}
+		}
+		return &c
+
+	case *pb.PersistentConnectionResponse:
+		c := *m
+		switch inner := m.Message.(type) {
+		case *pb.PersistentConnectionResponse_RequestHandling:
+			call := *inner.RequestHandling
+			c.Message = &pb.PersistentConnectionResponse_RequestHandling{RequestHandling: &call}
+		case *pb.PersistentConnectionResponse_CallUnaryResponse:
+			c.Message = &pb.PersistentConnectionResponse_CallUnaryResponse{
+				CallUnaryResponse: copyUnaryResponse(inner.CallUnaryResponse),
+			}
+		}
+		return &c
+	}
+	return msg
+}
+
+func copyUnaryResponse(r *pb.CallUnaryResponse) *pb.CallUnaryResponse {
+	c := *r
+	if res, ok := r.Result.(*pb.CallUnaryResponse_Response); ok {
+		c.Result = &pb.CallUnaryResponse_Response{Response: res.Response}
+	}
+	return &c
+}
@@ -0,0 +1,104 @@
+package utils
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// NewCloseNotifier starts reading ahead from r, buffering up to maxBuffered
+// bytes that haven't been read from the notifier yet.
+func NewCloseNotifier(r io.Reader, maxBuffered int) *CloseNotifier {
+	n := &CloseNotifier{
+		r:           r,
+		maxBuffered: maxBuffered,
+		failed:      make(chan struct{}),
+	}
+	n.cond = sync.NewCond(&n.m)
+	go n.readAhead()
+	return n
+}
+
+// CloseNotifier notices as soon as reading from a stream fails, typically
+// because the remote closed or reset it, without consuming the data the
+// remote sent meanwhile: that data is returned by Read.
+type CloseNotifier struct {
+	r           io.Reader
+	maxBuffered int
+
+	m      sync.Mutex
+	cond   *sync.Cond
+	buf    bytes.Buffer
+	err    error
+	closed bool
+	failed chan struct{}
+}
+
+func (n *CloseNotifier) readAhead() {
+	chunk := make([]byte, 4096)
+	for {
+		n.m.Lock()
+		for n.buf.Len() >= n.maxBuffered && !n.closed {
+			n.cond.Wait()
+		}
+		closed, room := n.closed, n.maxBuffered-n.buf.Len()
+		n.m.Unlock()
+
+		if closed {
+			return
+		}
+		if room > len(chunk) {
+			room = len(chunk)
+		}
+
+		k, err := n.r.Read(chunk[:room])
+
+		n.m.Lock()
+		n.buf.Write(chunk[:k])
+		n.err = err
+		n.cond.Broadcast()
+		n.m.Unlock()
+
+		if err != nil {
+			close(n.failed)
+			return
+		}
+	}
+}
+
+// Failed is closed once reading from the underlying reader fails, even if
+// buffered data is left to read.
+func (n *CloseNotifier) Failed() <-chan struct{} {
+	return n.failed
+}
+
+// Read returns the buffered data, then the error the underlying reader
+// failed with.
+func (n *CloseNotifier) Read(p []byte) (int, error) {
+	n.m.Lock()
+	defer n.m.Unlock()
+
+	for n.buf.Len() == 0 && n.err == nil && !n.closed {
+		n.cond.Wait()
+	}
+	if n.closed {
+		return 0, io.ErrClosedPipe
+	}
+	if n.buf.Len() == 0 {
+		return 0, n.err
+	}
+
+	k, _ := n.buf.Read(p)
+	n.cond.Broadcast()
+	return k, nil
+}
+
+// Close stops reading ahead; it doesn't close the underlying reader.
+func (n *CloseNotifier) Close() error {
+	n.m.Lock()
+	defer n.m.Unlock()
+
+	n.closed = true
+	n.cond.Broadcast()
+	return nil
+}
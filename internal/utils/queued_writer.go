@@ -0,0 +1,217 @@
+package utils
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	ggio "github.com/gogo/protobuf/io"
+	"github.com/gogo/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ErrQueueFull is returned by QueuedWriter.WriteMsg when too many messages
+// are waiting to be written, typically because the reader stopped reading.
+var ErrQueueFull = errors.New("outbound queue is full")
+
+// NewQueuedWriter writes messages to w from a single goroutine, queueing up
+// to size of them. depth is incremented for each queued message and
+// decremented once it is written or dropped.
+func NewQueuedWriter(w ggio.WriteCloser, size int, depth prometheus.Gauge) *QueuedWriter {
+	qw := &QueuedWriter{
+		w:      w,
+		queue:  make(chan proto.Message, size),
+		depth:  depth,
+		room:   make(chan struct{}, 1),
+		closed: make(chan struct{}),
+		failed: make(chan struct{}),
+	}
+	go qw.run()
+	return qw
+}
+
+// QueuedWriter can be written to concurrently without blocking on a slow
+// reader: messages are queued, and rejected with ErrQueueFull once the queue
+// is full. Messages are written in the order WriteMsg accepted them.
+type QueuedWriter struct {
+	w     ggio.WriteCloser
+	queue chan proto.Message
+	depth prometheus.Gauge
+	// room is signalled as messages are dequeued, for WriteMsgWait
+	room chan struct{}
+
+	m         sync.Mutex
+	err       error
+	closeOnce sync.Once
+	closed    chan struct{}
+	failed    chan struct{}
+}
+
+func (qw *QueuedWriter) run() {
+	for {
+		select {
+		case msg := <-qw.queue:
+			select {
+			case qw.room <- struct{}{}:
+			default:
+			}
+			if f, ok := msg.(*flushMarker); ok {
+				close(f.done)
+				continue
+			}
+			qw.depth.Dec()
+			if err := qw.w.WriteMsg(msg); err != nil {
+				qw.fail(err)
+				close(qw.failed)
+				return
+			}
+		case <-qw.closed:
+			return
+		}
+	}
+}
+
+// flushMarker is queued by Flush, and closes done once the messages queued
+// before it are written.
+type flushMarker struct {
+	done chan struct{}
+}
+
+func (*flushMarker) Reset()         {}
+func (*flushMarker) String() string { return "flush" }
+func (*flushMarker) ProtoMessage()  {}
+
+// Flush waits until the messages queued so far are written, for up to
+// timeout, e.g. before closing the writer, which drops them.
+func (qw *QueuedWriter) Flush(timeout time.Duration) error {
+	f := &flushMarker{done: make(chan struct{})}
+
+	qw.m.Lock()
+	if qw.err != nil {
+		qw.m.Unlock()
+		return qw.err
+	}
+	select {
+	case qw.queue <- f:
+	default:
+		qw.m.Unlock()
+		return ErrQueueFull
+	}
+	qw.m.Unlock()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-f.done:
+		return nil
+	case <-qw.failed:
+		return qw.Err()
+	case <-qw.closed:
+		return io.ErrClosedPipe
+	case <-timer.C:
+		return errors.New("timed out flushing the queue")
+	}
+}
+
+// fail makes further writes return err, and drops the queued messages.
+func (qw *QueuedWriter) fail(err error) {
+	qw.m.Lock()
+	defer qw.m.Unlock()
+
+	if qw.err == nil {
+		qw.err = err
+	}
+	for {
+		select {
+		case msg := <-qw.queue:
+			// flush markers aren't counted
+			if _, ok := msg.(*flushMarker); !ok {
+				qw.depth.Dec()
+			}
+		default:
+			return
+		}
+	}
+}
+
+// Failed is closed once writing a message to the underlying writer fails,
+// after which nothing more is written. It isn't closed by Close.
+func (qw *QueuedWriter) Failed() <-chan struct{} {
+	return qw.failed
+}
+
+// Err returns the error writing failed with, if any.
+func (qw *QueuedWriter) Err() error {
+	qw.m.Lock()
+	defer qw.m.Unlock()
+
+	return qw.err
+}
+
+// WriteMsg queues msg. It returns ErrQueueFull if the queue is full, and the
+// error writing failed with if an earlier message couldn't be written.
+func (qw *QueuedWriter) WriteMsg(msg proto.Message) error {
+	qw.m.Lock()
+	defer qw.m.Unlock()
+
+	if qw.err != nil {
+		return qw.err
+	}
+
+	qw.depth.Inc()
+	select {
+	case qw.queue <- msg:
+		return nil
+	default:
+		qw.depth.Dec()
+		return ErrQueueFull
+	}
+}
+
+// WriteMsgWait is WriteMsg, but waits for room in the queue for up to
+// timeout rather than returning ErrQueueFull right away, for messages that
+// must not be dropped.
+func (qw *QueuedWriter) WriteMsgWait(msg proto.Message, timeout time.Duration) error {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		if err := qw.WriteMsg(msg); err != ErrQueueFull {
+			return err
+		}
+		select {
+		case <-qw.room:
+		case <-qw.failed:
+			return qw.Err()
+		case <-qw.closed:
+			return io.ErrClosedPipe
+		case <-timer.C:
+			return ErrQueueFull
+		}
+	}
+}
+
+// Waiting returns a writer whose WriteMsg is WriteMsgWait with timeout.
+func (qw *QueuedWriter) Waiting(timeout time.Duration) ggio.Writer {
+	return waitingWriter{qw, timeout}
+}
+
+type waitingWriter struct {
+	qw      *QueuedWriter
+	timeout time.Duration
+}
+
+func (w waitingWriter) WriteMsg(msg proto.Message) error {
+	return w.qw.WriteMsgWait(msg, w.timeout)
+}
+
+// Close drops the queued messages and closes the underlying writer.
+func (qw *QueuedWriter) Close() error {
+	qw.closeOnce.Do(func() {
+		qw.fail(io.ErrClosedPipe)
+		close(qw.closed)
+	})
+	return qw.w.Close()
+}
@@ -0,0 +1,67 @@
+package p2pd
+
+import (
+	"context"
+	"io"
+	"time"
+
+	ggio "github.com/gogo/protobuf/io"
+	"github.com/google/uuid"
+
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// SetKeepAlive makes the daemon ping persistent connections on which nothing
+// was received for interval, and close them after maxMissed pings in a row go
+// unanswered. An interval of zero disables keepalive, which is the default.
+// It applies to persistent connections opened afterwards.
+func (d *Daemon) SetKeepAlive(interval time.Duration, maxMissed int) {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	d.keepAliveInterval = interval
+	d.keepAliveMaxMissed = maxMissed
+}
+
+// keepAlive pings the client of a persistent connection until ctx is done,
+// closing c once the client stops replying. received must be signalled
+// whenever a message is read from the connection.
+func (d *Daemon) keepAlive(ctx context.Context, w ggio.Writer, c io.Closer, received <-chan struct{}, interval time.Duration, maxMissed int) {
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+
+	missed := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-received:
+			missed = 0
+			if !timer.Stop() {
+				<-timer.C
+			}
+			timer.Reset(interval)
+
+		case <-timer.C:
+			if missed >= maxMissed {
+				log.Infow("closing unresponsive persistent connection", "missed", missed)
+				c.Close()
+				return
+			}
+
+			callID := uuid.New()
+			ping := &pb.PersistentConnectionResponse{
+				CallId:  callID[:],
+				Message: &pb.PersistentConnectionResponse_Ping{Ping: &pb.Ping{}},
+			}
+			if err := w.WriteMsg(ping); err != nil {
+				log.Debugw("error writing ping", "error", err)
+				c.Close()
+				return
+			}
+			missed++
+			timer.Reset(interval)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package p2pd
+
+import (
+	"sort"
+
+	logging "github.com/ipfs/go-log/v2"
+	"go.uber.org/zap/zapcore"
+
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// logLevels are the levels subsystems can log at, the most verbose first.
+var logLevels = []zapcore.Level{
+	zapcore.DebugLevel,
+	zapcore.InfoLevel,
+	zapcore.WarnLevel,
+	zapcore.ErrorLevel,
+	zapcore.DPanicLevel,
+	zapcore.PanicLevel,
+	zapcore.FatalLevel,
+}
+
+// doLogLevel sets the log level of a subsystem, or of all of them with *, if
+// the request has a level, and returns the level of every subsystem.
+func (d *Daemon) doLogLevel(req *pb.Request) *pb.Response {
+	if level := req.LogLevel.GetLevel(); level != "" {
+		subsystem := req.LogLevel.GetSubsystem()
+		if subsystem == "" {
+			return errorResponseString("Malformed request; missing subsystem")
+		}
+		if err := logging.SetLogLevel(subsystem, level); err != nil {
+			return errorResponse(err)
+		}
+		log.Infow("set log level", "subsystem", subsystem, "level", level)
+	}
+
+	res := okResponse()
+	res.LogLevels = subsystemLogLevels()
+	return res
+}
+
+func subsystemLogLevels() []*pb.SubsystemLogLevel {
+	names := logging.GetSubsystems()
+	sort.Strings(names)
+
+	levels := make([]*pb.SubsystemLogLevel, len(names))
+	for i, name := range names {
+		name, level := name, loggerLevel(name)
+		levels[i] = &pb.SubsystemLogLevel{Subsystem: &name, Level: &level}
+	}
+	return levels
+}
+
+// loggerLevel reads the level of a subsystem back from its logger, as go-log
+// doesn't tell it otherwise.
+func loggerLevel(name string) string {
+	core := logging.Logger(name).Desugar().Core()
+	for _, l := range logLevels {
+		if core.Enabled(l) {
+			return l.String()
+		}
+	}
+	return zapcore.FatalLevel.String()
+}
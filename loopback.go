@@ -0,0 +1,208 @@
+package p2pd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	ic "github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/multiformats/go-multistream"
+)
+
+// SetLoopbackCalls has unary and stream calls to the daemon's own peer id go
+// through an in-memory stream to its own handler, where they would fail
+// otherwise as the host can't dial itself. The calls then exercise the
+// persistent connections of the caller and the handler, and the message
+// exchange between them, without any network or transport overhead, which is
+// meant for benchmarks and profiling. Calls to other peers are unaffected. It
+// is disabled by default.
+func (d *Daemon) SetLoopbackCalls(loopback bool) {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	d.unary.loopback = loopback
+}
+
+var errLoopbackReset = errors.New("loopback stream reset")
+
+var loopbackStreamIDs int64
+
+// newLoopbackStream opens an in-memory stream to the host's own handler of
+// proto, negotiating the protocol with the host's mux as a remote peer would.
+func (d *Daemon) newLoopbackStream(ctx context.Context, proto protocol.ID) (network.Stream, error) {
+	conn := &loopbackConn{host: d.host.ID(), ps: d.host.Peerstore(), opened: time.Now()}
+	out, in := newLoopbackStreams(conn)
+
+	go func() {
+		p, handler, err := d.host.Mux().Negotiate(in)
+		if err != nil {
+			log.Debugw("error negotiating loopback stream", "error", err)
+			in.Reset()
+			return
+		}
+		handler(p, in)
+	}()
+
+	// the negotiation is answered right away, so ctx only matters if it is
+	// already done
+	if err := ctx.Err(); err != nil {
+		out.Reset()
+		return nil, err
+	}
+	if err := multistream.SelectProtoOrFail(string(proto), out); err != nil {
+		out.Reset()
+		return nil, err
+	}
+	out.SetProtocol(proto)
+	return out, nil
+}
+
+// loopbackStream is one end of an in-memory stream between the host and
+// itself. Closing it for writing ends what the other end reads; closing it
+// for reading fails what the other end writes.
+type loopbackStream struct {
+	r     *io.PipeReader
+	w     *io.PipeWriter
+	id    string
+	proto atomic.Value
+	dir   network.Direction
+	conn  *loopbackConn
+}
+
+func newLoopbackStreams(conn *loopbackConn) (out, in *loopbackStream) {
+	outR, inW := io.Pipe()
+	inR, outW := io.Pipe()
+	id := strconv.FormatInt(atomic.AddInt64(&loopbackStreamIDs, 1), 10)
+	out = &loopbackStream{r: outR, w: outW, id: id, dir: network.DirOutbound, conn: conn}
+	in = &loopbackStream{r: inR, w: inW, id: id, dir: network.DirInbound, conn: conn}
+	conn.streams = []network.Stream{out, in}
+	return out, in
+}
+
+func (s *loopbackStream) Read(b []byte) (int, error) {
+	return s.r.Read(b)
+}
+
+func (s *loopbackStream) Write(b []byte) (int, error) {
+	return s.w.Write(b)
+}
+
+func (s *loopbackStream) Close() error {
+	s.CloseRead()
+	return s.CloseWrite()
+}
+
+func (s *loopbackStream) CloseWrite() error {
+	return s.w.Close()
+}
+
+func (s *loopbackStream) CloseRead() error {
+	return s.r.CloseWithError(io.ErrClosedPipe)
+}
+
+func (s *loopbackStream) Reset() error {
+	s.r.CloseWithError(errLoopbackReset)
+	return s.w.CloseWithError(errLoopbackReset)
+}
+
+// SetDeadline is a no-op, as pipes have no deadlines; calls are bounded by
+// their context.
+func (s *loopbackStream) SetDeadline(time.Time) error {
+	return nil
+}
+
+func (s *loopbackStream) SetReadDeadline(time.Time) error {
+	return nil
+}
+
+func (s *loopbackStream) SetWriteDeadline(time.Time) error {
+	return nil
+}
+
+func (s *loopbackStream) ID() string {
+	return s.id
+}
+
+func (s *loopbackStream) Protocol() protocol.ID {
+	p, _ := s.proto.Load().(protocol.ID)
+	return p
+}
+
+func (s *loopbackStream) SetProtocol(p protocol.ID) {
+	s.proto.Store(p)
+}
+
+func (s *loopbackStream) Stat() network.Stat {
+	return network.Stat{Direction: s.dir, Opened: s.conn.opened}
+}
+
+func (s *loopbackStream) Conn() network.Conn {
+	return s.conn
+}
+
+// loopbackConn is the connection of a loopback stream, from the host to
+// itself.
+type loopbackConn struct {
+	host    peer.ID
+	ps      peerstore.Peerstore
+	opened  time.Time
+	streams []network.Stream
+}
+
+func (c *loopbackConn) Close() error {
+	for _, s := range c.streams {
+		s.Reset()
+	}
+	return nil
+}
+
+func (c *loopbackConn) LocalPeer() peer.ID {
+	return c.host
+}
+
+func (c *loopbackConn) LocalPrivateKey() ic.PrivKey {
+	return c.ps.PrivKey(c.host)
+}
+
+func (c *loopbackConn) RemotePeer() peer.ID {
+	return c.host
+}
+
+func (c *loopbackConn) RemotePublicKey() ic.PubKey {
+	return c.ps.PubKey(c.host)
+}
+
+// LocalMultiaddr is the /p2p address of the host, as loopback connections
+// have no transport address.
+func (c *loopbackConn) LocalMultiaddr() ma.Multiaddr {
+	addr, _ := ma.NewComponent("p2p", c.host.Pretty())
+	return addr
+}
+
+func (c *loopbackConn) RemoteMultiaddr() ma.Multiaddr {
+	return c.LocalMultiaddr()
+}
+
+func (c *loopbackConn) Stat() network.Stat {
+	return network.Stat{Direction: network.DirOutbound, Opened: c.opened}
+}
+
+func (c *loopbackConn) ID() string {
+	return "loopback"
+}
+
+func (c *loopbackConn) NewStream(context.Context) (network.Stream, error) {
+	return nil, errors.New("loopback connections carry a single stream")
+}
+
+func (c *loopbackConn) GetStreams() []network.Stream {
+	return c.streams
+}
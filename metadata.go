@@ -0,0 +1,117 @@
+package p2pd
+
+import (
+	"fmt"
+	"sort"
+
+	ggio "github.com/gogo/protobuf/io"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/protocol"
+
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// MetadataProtocol is the protocol daemons announce their metadata over.
+// Identify has no room for custom fields, so peers read the metadata on a
+// stream of its own once identify told them the protocol is supported; peers
+// without it, such as vanilla libp2p ones, are unaffected.
+const MetadataProtocol protocol.ID = "/p2pd/metadata/1.0.0"
+
+// MaxMetadataSize bounds the total size of the keys and values of the
+// metadata a daemon announces, and MaxMetadataEntries their number.
+const (
+	MaxMetadataSize    = 4096
+	MaxMetadataEntries = 64
+)
+
+// maxMetadataMsgSize bounds the metadata read from other peers, leaving room
+// for the encoding of the entries.
+const maxMetadataMsgSize = MaxMetadataSize + 16*MaxMetadataEntries
+
+// SetMetadata announces md to other peers over MetadataProtocol, e.g. the
+// role of the node for crawlers to group nodes by. An empty md stops
+// announcing metadata, which is the default.
+func (d *Daemon) SetMetadata(md map[string]string) error {
+	if len(md) == 0 {
+		d.host.RemoveStreamHandler(MetadataProtocol)
+		return nil
+	}
+
+	if len(md) > MaxMetadataEntries {
+		return fmt.Errorf("metadata has %d entries, over the maximum of %d", len(md), MaxMetadataEntries)
+	}
+	size := 0
+	for k, v := range md {
+		if k == "" {
+			return fmt.Errorf("metadata keys can't be empty")
+		}
+		size += len(k) + len(v)
+	}
+	if size > MaxMetadataSize {
+		return fmt.Errorf("metadata takes %d bytes, over the maximum of %d", size, MaxMetadataSize)
+	}
+
+	msg := metadataMessage(md)
+
+	d.host.SetStreamHandler(MetadataProtocol, func(s network.Stream) {
+		if err := ggio.NewDelimitedWriter(s).WriteMsg(msg); err != nil {
+			log.Debugw("error writing metadata", "peer", s.Conn().RemotePeer(), "error", err)
+			s.Reset()
+			return
+		}
+		s.Close()
+	})
+	return nil
+}
+
+func metadataMessage(md map[string]string) *pb.Metadata {
+	keys := make([]string, 0, len(md))
+	for k := range md {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	msg := &pb.Metadata{Entries: make([]*pb.MetadataEntry, len(keys))}
+	for i, k := range keys {
+		k, v := k, md[k]
+		msg.Entries[i] = &pb.MetadataEntry{Key: &k, Value: &v}
+	}
+	return msg
+}
+
+// doPeerMetadata reads the metadata a peer announces over MetadataProtocol.
+func (d *Daemon) doPeerMetadata(req *pb.Request) *pb.Response {
+	if req.PeerMetadata == nil {
+		return errorResponseString("Malformed request; missing parameters")
+	}
+
+	p, err := peer.IDFromBytes(req.PeerMetadata.GetPeer())
+	if err != nil {
+		return errorResponse(err)
+	}
+
+	ctx, cancel := d.requestContext(req.PeerMetadata.GetTimeout())
+	defer cancel()
+
+	s, err := d.host.NewStream(ctx, p, MetadataProtocol)
+	if err != nil {
+		log.Debugw("error opening metadata stream", "peer", p, "error", err)
+		return errorResponse(err)
+	}
+	defer s.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		s.SetReadDeadline(deadline)
+	}
+
+	msg := &pb.Metadata{}
+	if err := ggio.NewDelimitedReader(s, maxMetadataMsgSize).ReadMsg(msg); err != nil {
+		s.Reset()
+		return errorResponse(err)
+	}
+
+	res := okResponse()
+	res.Metadata = msg
+	return res
+}
@@ -0,0 +1,83 @@
+package p2pd
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const metricsNamespace = "p2pd"
+
+var bootstrapFailures = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: metricsNamespace,
+	Name:      "bootstrap_failed_attempts_total",
+	Help:      "Number of failed attempts to connect to the bootstrap peers",
+})
+
+var routingTablePeers = promauto.NewGauge(prometheus.GaugeOpts{
+	Namespace: metricsNamespace,
+	Name:      "dht_routing_table_peers",
+	Help:      "Number of peers in the DHT routing table",
+})
+
+var persistentConns = promauto.NewGauge(prometheus.GaugeOpts{
+	Namespace: metricsNamespace,
+	Name:      "persistent_connections",
+	Help:      "Number of open persistent client connections",
+})
+
+var rejectedPersistentConns = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: metricsNamespace,
+	Name:      "persistent_connections_rejected_total",
+	Help:      "Number of persistent client connections rejected for exceeding the maximum",
+})
+
+var oversizedClientMsgs = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: metricsNamespace,
+	Name:      "persistent_connection_oversized_messages_total",
+	Help:      "Number of persistent client connections closed for a message exceeding the maximum size",
+})
+
+var rejectedControlConns = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: metricsNamespace,
+	Name:      "rejected_control_connections_total",
+	Help:      "Number of control connections rejected for failing TLS client authentication",
+})
+
+var persistentConnQueued = promauto.NewGauge(prometheus.GaugeOpts{
+	Namespace: metricsNamespace,
+	Name:      "persistent_connection_queued_messages",
+	Help:      "Number of messages waiting to be written to persistent client connections",
+})
+
+var unaryCalls = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: metricsNamespace,
+	Name:      "unary_calls_total",
+	Help:      "Number of unary calls initiated by clients",
+})
+
+// results of unary calls, as recorded by unaryCallDuration
+const (
+	unaryCallSucceeded = "success"
+	unaryCallFailed    = "error"
+	unaryCallTimedOut  = "timeout"
+	unaryCallCancelled = "cancelled"
+)
+
+var unaryCallDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: metricsNamespace,
+	Name:      "unary_call_duration_seconds",
+	Help:      "Duration of unary calls initiated by clients, by result (success, error, timeout or cancelled)",
+	Buckets:   prometheus.ExponentialBuckets(0.001, 4, 10),
+}, []string{"result"})
+
+// reasons for rejecting connections, as recorded by rejectedConns
+const (
+	rejectedRateLimit = "rate_limit"
+	rejectedPeerList  = "peer_list"
+)
+
+var rejectedConns = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: metricsNamespace,
+	Name:      "rejected_connections_total",
+	Help:      "Number of connections rejected by the connection gater, by reason",
+}, []string{"reason"})
@@ -0,0 +1,83 @@
+package p2pclient
+
+import (
+	"errors"
+
+	ggio "github.com/gogo/protobuf/io"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+	"github.com/multiformats/go-multiaddr"
+)
+
+// Addrs are the addresses the daemon advertises, by origin.
+type Addrs struct {
+	// Listen are the addresses of the interfaces the daemon listens on.
+	Listen []multiaddr.Multiaddr
+	// Observed are external addresses, as observed by peers or mapped by the
+	// NAT.
+	Observed []multiaddr.Multiaddr
+	// Relay are circuit addresses going through a relay.
+	Relay []multiaddr.Multiaddr
+}
+
+// All returns the addresses of every origin.
+func (a *Addrs) All() []multiaddr.Multiaddr {
+	all := make([]multiaddr.Multiaddr, 0, len(a.Listen)+len(a.Observed)+len(a.Relay))
+	all = append(all, a.Listen...)
+	all = append(all, a.Observed...)
+	return append(all, a.Relay...)
+}
+
+// ListAddrs returns the addresses the daemon currently advertises. Unlike
+// the ones returned by Identify when the client started, they include the
+// external and relay addresses the daemon learned since.
+func (c *Client) ListAddrs() (*Addrs, error) {
+	control, err := c.newControlConn()
+	if err != nil {
+		return nil, err
+	}
+	defer control.Close()
+	r := ggio.NewDelimitedReader(control, MessageSizeMax)
+	w := ggio.NewDelimitedWriter(control)
+
+	if err := w.WriteMsg(&pb.Request{Type: pb.Request_LIST_ADDRS.Enum()}); err != nil {
+		return nil, err
+	}
+
+	res := &pb.Response{}
+	if err := r.ReadMsg(res); err != nil {
+		return nil, err
+	}
+
+	if err := res.GetError(); err != nil {
+		return nil, errors.New(err.GetMsg())
+	}
+
+	pbAddrs := res.GetAddrs()
+	if pbAddrs == nil {
+		return nil, errors.New("addrs response is empty")
+	}
+
+	addrs := &Addrs{}
+	if addrs.Listen, err = parseAddrs(pbAddrs.GetListen()); err != nil {
+		return nil, err
+	}
+	if addrs.Observed, err = parseAddrs(pbAddrs.GetObserved()); err != nil {
+		return nil, err
+	}
+	if addrs.Relay, err = parseAddrs(pbAddrs.GetRelay()); err != nil {
+		return nil, err
+	}
+	return addrs, nil
+}
+
+func parseAddrs(bs [][]byte) ([]multiaddr.Multiaddr, error) {
+	addrs := make([]multiaddr.Multiaddr, 0, len(bs))
+	for _, b := range bs {
+		addr, err := multiaddr.NewMultiaddrBytes(b)
+		if err != nil {
+			return nil, err
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs, nil
+}
@@ -0,0 +1,101 @@
+package p2pclient
+
+import (
+	"context"
+	"errors"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+
+	ggio "github.com/gogo/protobuf/io"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+// ReachabilityProbe is the answer of an AutoNAT server asked to dial the
+// daemon back.
+type ReachabilityProbe struct {
+	// Reachability is public if the server dialed back, private otherwise.
+	Reachability network.Reachability
+	Peer         peer.ID
+	// Addr is the address the server dialed back, if public.
+	Addr ma.Multiaddr
+}
+
+// Reachability returns the reachability last reported by the daemon's
+// AutoNAT.
+func (c *Client) Reachability() (network.Reachability, error) {
+	res, err := c.autoNAT(&pb.AutoNATRequest{})
+	if err != nil {
+		return network.ReachabilityUnknown, err
+	}
+	return network.Reachability(res.GetReachability()), nil
+}
+
+// ProbeReachability has the daemon ask an AutoNAT server among its connected
+// peers to dial it back right away. The probe doesn't change the reachability
+// reported by AutoNAT. It fails if no connected peer runs an AutoNAT server
+// that answers. If ctx has a deadline, the daemon gives up when it expires.
+func (c *Client) ProbeReachability(ctx context.Context) (*ReachabilityProbe, error) {
+	timeout, err := requestTimeout(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	probe := true
+	res, err := c.autoNAT(&pb.AutoNATRequest{Probe: &probe, Timeout: timeout})
+	if err != nil {
+		return nil, err
+	}
+
+	pbProbe := res.GetProbe()
+	if pbProbe == nil {
+		return nil, errors.New("daemon returned no probe result")
+	}
+
+	p, err := peer.IDFromBytes(pbProbe.GetPeer())
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ReachabilityProbe{
+		Reachability: network.Reachability(pbProbe.GetReachability()),
+		Peer:         p,
+	}
+	if pbProbe.Addr != nil {
+		result.Addr, err = ma.NewMultiaddrBytes(pbProbe.GetAddr())
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (c *Client) autoNAT(req *pb.AutoNATRequest) (*pb.AutoNATResponse, error) {
+	control, err := c.newControlConn()
+	if err != nil {
+		return nil, err
+	}
+	defer control.Close()
+	r := ggio.NewDelimitedReader(control, MessageSizeMax)
+	w := ggio.NewDelimitedWriter(control)
+
+	if err := w.WriteMsg(&pb.Request{Type: pb.Request_AUTONAT.Enum(), AutoNAT: req}); err != nil {
+		return nil, err
+	}
+
+	res := &pb.Response{}
+	if err := r.ReadMsg(res); err != nil {
+		return nil, err
+	}
+
+	if err := res.GetError(); err != nil {
+		return nil, errors.New(err.GetMsg())
+	}
+
+	info := res.GetAutoNAT()
+	if info == nil {
+		return nil, errors.New("autonat response is empty")
+	}
+	return info, nil
+}
@@ -0,0 +1,110 @@
+package p2pclient
+
+import (
+	"errors"
+
+	ggio "github.com/gogo/protobuf/io"
+	"github.com/libp2p/go-libp2p-core/metrics"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+func (c *Client) bandwidth(req *pb.BandwidthRequest) (*pb.BandwidthResponse, error) {
+	control, err := c.newControlConn()
+	if err != nil {
+		return nil, err
+	}
+	defer control.Close()
+	r := ggio.NewDelimitedReader(control, MessageSizeMax)
+	w := ggio.NewDelimitedWriter(control)
+
+	if err := w.WriteMsg(&pb.Request{
+		Type:      pb.Request_BANDWIDTH.Enum(),
+		Bandwidth: req,
+	}); err != nil {
+		return nil, err
+	}
+
+	res := &pb.Response{}
+	if err := r.ReadMsg(res); err != nil {
+		return nil, err
+	}
+
+	if err := res.GetError(); err != nil {
+		return nil, errors.New(err.GetMsg())
+	}
+
+	bandwidth := res.GetBandwidth()
+	if bandwidth == nil {
+		return nil, errors.New("bandwidth response is empty")
+	}
+	return bandwidth, nil
+}
+
+func convertBandwidthStats(s *pb.BandwidthStats) metrics.Stats {
+	return metrics.Stats{
+		TotalIn:  s.GetTotalIn(),
+		TotalOut: s.GetTotalOut(),
+		RateIn:   s.GetRateIn(),
+		RateOut:  s.GetRateOut(),
+	}
+}
+
+// BandwidthTotals returns the bandwidth used by the daemon in total. It fails
+// unless the daemon counts bandwidth, which it does when metrics are enabled.
+func (c *Client) BandwidthTotals() (metrics.Stats, error) {
+	bandwidth, err := c.bandwidth(&pb.BandwidthRequest{})
+	if err != nil {
+		return metrics.Stats{}, err
+	}
+	return convertBandwidthStats(bandwidth.GetTotal()), nil
+}
+
+// BandwidthForPeer returns the bandwidth used with peer p.
+func (c *Client) BandwidthForPeer(p peer.ID) (metrics.Stats, error) {
+	bandwidth, err := c.bandwidth(&pb.BandwidthRequest{Peer: []byte(p)})
+	if err != nil {
+		return metrics.Stats{}, err
+	}
+	if len(bandwidth.GetPeers()) != 1 {
+		return metrics.Stats{}, errors.New("bandwidth response lacks the peer's stats")
+	}
+	return convertBandwidthStats(bandwidth.GetPeers()[0].GetStats()), nil
+}
+
+// BandwidthByPeer returns the bandwidth used with each peer the daemon
+// exchanged data with.
+func (c *Client) BandwidthByPeer() (map[peer.ID]metrics.Stats, error) {
+	byPeer := true
+	bandwidth, err := c.bandwidth(&pb.BandwidthRequest{ByPeer: &byPeer})
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make(map[peer.ID]metrics.Stats, len(bandwidth.GetPeers()))
+	for _, pbPeer := range bandwidth.GetPeers() {
+		p, err := peer.IDFromBytes(pbPeer.GetPeer())
+		if err != nil {
+			return nil, err
+		}
+		stats[p] = convertBandwidthStats(pbPeer.GetStats())
+	}
+	return stats, nil
+}
+
+// BandwidthByProtocol returns the bandwidth used by each protocol the daemon
+// exchanged data on.
+func (c *Client) BandwidthByProtocol() (map[protocol.ID]metrics.Stats, error) {
+	byProtocol := true
+	bandwidth, err := c.bandwidth(&pb.BandwidthRequest{ByProtocol: &byProtocol})
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make(map[protocol.ID]metrics.Stats, len(bandwidth.GetProtocols()))
+	for _, pbProto := range bandwidth.GetProtocols() {
+		stats[protocol.ID(pbProto.GetProto())] = convertBandwidthStats(pbProto.GetStats())
+	}
+	return stats, nil
+}
@@ -0,0 +1,51 @@
+package p2pclient
+
+import (
+	"errors"
+
+	ggio "github.com/gogo/protobuf/io"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+	"github.com/multiformats/go-multiaddr"
+)
+
+// SetBootstrapPeers replaces the daemon's bootstrap peer set. Every address
+// must include the peer ID. If bootstrap is true, the daemon bootstraps with
+// the new peers before replying, and fails if another bootstrap is in
+// progress.
+func (c *Client) SetBootstrapPeers(addrs []multiaddr.Multiaddr, bootstrap bool) error {
+	control, err := c.newControlConn()
+	if err != nil {
+		return err
+	}
+	defer control.Close()
+	r := ggio.NewDelimitedReader(control, MessageSizeMax)
+	w := ggio.NewDelimitedWriter(control)
+
+	addrbytes := make([][]byte, len(addrs))
+	for i, addr := range addrs {
+		addrbytes[i] = addr.Bytes()
+	}
+
+	req := &pb.Request{
+		Type: pb.Request_SET_BOOTSTRAP_PEERS.Enum(),
+		SetBootstrapPeers: &pb.SetBootstrapPeersRequest{
+			Addrs:     addrbytes,
+			Bootstrap: &bootstrap,
+		},
+	}
+
+	if err := w.WriteMsg(req); err != nil {
+		return err
+	}
+
+	res := &pb.Response{}
+	if err := r.ReadMsg(res); err != nil {
+		return err
+	}
+
+	if err := res.GetError(); err != nil {
+		return errors.New(err.GetMsg())
+	}
+
+	return nil
+}
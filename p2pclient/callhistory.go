@@ -0,0 +1,65 @@
+package p2pclient
+
+import (
+	"errors"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/protocol"
+
+	ggio "github.com/gogo/protobuf/io"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// CallRecord describes a unary call made through the daemon.
+type CallRecord struct {
+	// Peer is empty if the call was made with an invalid peer ID.
+	Peer      peer.ID
+	Proto     protocol.ID
+	StartTime time.Time
+	Duration  time.Duration
+	// Result is success, error, timeout or cancelled.
+	Result string
+	// Error is the reason the call didn't succeed, if known.
+	Error string
+}
+
+// CallHistory returns the last unary calls made by the daemon's clients,
+// oldest first. It fails if the daemon doesn't keep a call history.
+func (c *Client) CallHistory() ([]CallRecord, error) {
+	control, err := c.newControlConn()
+	if err != nil {
+		return nil, err
+	}
+	defer control.Close()
+	r := ggio.NewDelimitedReader(control, MessageSizeMax)
+	w := ggio.NewDelimitedWriter(control)
+
+	if err := w.WriteMsg(&pb.Request{Type: pb.Request_CALL_HISTORY.Enum()}); err != nil {
+		return nil, err
+	}
+
+	res := &pb.Response{}
+	if err := r.ReadMsg(res); err != nil {
+		return nil, err
+	}
+
+	if err := res.GetError(); err != nil {
+		return nil, errors.New(err.GetMsg())
+	}
+
+	records := make([]CallRecord, len(res.GetCallHistory()))
+	for i, record := range res.GetCallHistory() {
+		records[i] = CallRecord{
+			Proto:     protocol.ID(record.GetProto()),
+			StartTime: time.Unix(0, record.GetStartTime()),
+			Duration:  time.Duration(record.GetDuration()),
+			Result:    record.GetResult(),
+			Error:     record.GetError(),
+		}
+		if p, err := peer.IDFromBytes(record.GetPeer()); err == nil {
+			records[i].Peer = p
+		}
+	}
+	return records, nil
+}
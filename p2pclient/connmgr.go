@@ -0,0 +1,73 @@
+package p2pclient
+
+import (
+	"errors"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+
+	ggio "github.com/gogo/protobuf/io"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+func (c *Client) doConnManager(cmReq *pb.ConnManagerRequest) error {
+	control, err := c.newControlConn()
+	if err != nil {
+		return err
+	}
+	defer control.Close()
+	r := ggio.NewDelimitedReader(control, MessageSizeMax)
+	w := ggio.NewDelimitedWriter(control)
+
+	req := &pb.Request{
+		Type:        pb.Request_CONNMANAGER.Enum(),
+		ConnManager: cmReq,
+	}
+
+	if err := w.WriteMsg(req); err != nil {
+		return err
+	}
+
+	res := &pb.Response{}
+	if err := r.ReadMsg(res); err != nil {
+		return err
+	}
+
+	if err := res.GetError(); err != nil {
+		return errors.New(err.GetMsg())
+	}
+
+	return nil
+}
+
+// SetConnManagerLimits changes the water marks and grace period of the
+// daemon's connection manager. The grace period is rounded down to seconds.
+func (c *Client) SetConnManagerLimits(low, high int, grace time.Duration) error {
+	lowWaterMark, highWaterMark, gracePeriod := int64(low), int64(high), int64(grace/time.Second)
+	return c.doConnManager(&pb.ConnManagerRequest{
+		Type:          pb.ConnManagerRequest_SET_LIMITS.Enum(),
+		LowWaterMark:  &lowWaterMark,
+		HighWaterMark: &highWaterMark,
+		GracePeriod:   &gracePeriod,
+	})
+}
+
+// Protect keeps the daemon's connection manager from trimming the
+// connections to p until tag is removed with Unprotect.
+func (c *Client) Protect(p peer.ID, tag string) error {
+	return c.doConnManager(&pb.ConnManagerRequest{
+		Type: pb.ConnManagerRequest_PROTECT.Enum(),
+		Peer: []byte(p),
+		Tag:  &tag,
+	})
+}
+
+// Unprotect removes a protection tag of p. The connections to p may be
+// trimmed again once none is left.
+func (c *Client) Unprotect(p peer.ID, tag string) error {
+	return c.doConnManager(&pb.ConnManagerRequest{
+		Type: pb.ConnManagerRequest_UNPROTECT.Enum(),
+		Peer: []byte(p),
+		Tag:  &tag,
+	})
+}
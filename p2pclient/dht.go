@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"net"
+	"time"
 
 	"github.com/libp2p/go-libp2p-core/crypto"
 	"github.com/libp2p/go-libp2p-core/peer"
@@ -206,6 +208,53 @@ func (c *Client) PutValue(key []byte, value []byte) error {
 	return err
 }
 
+// GetValueContext is like GetValue, but if ctx has a deadline, the daemon
+// gives up when it expires. If quorum is positive, the daemon waits for that
+// many peers to return a value before settling on the best one; otherwise the
+// DHT default applies.
+func (c *Client) GetValueContext(ctx context.Context, key []byte, quorum int) ([]byte, error) {
+	timeout, err := requestTimeout(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &pb.DHTRequest{
+		Type:    pb.DHTRequest_GET_VALUE.Enum(),
+		Key:     key,
+		Timeout: timeout,
+	}
+	if quorum > 0 {
+		q := int32(quorum)
+		req.Quorum = &q
+	}
+
+	msg, err := c.doDHTNonNil(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return msg.GetValue(), nil
+}
+
+// PutValueContext is like PutValue, but if ctx has a deadline, the daemon
+// gives up when it expires.
+func (c *Client) PutValueContext(ctx context.Context, key []byte, value []byte) error {
+	timeout, err := requestTimeout(ctx)
+	if err != nil {
+		return err
+	}
+
+	req := &pb.DHTRequest{
+		Type:    pb.DHTRequest_PUT_VALUE.Enum(),
+		Key:     key,
+		Value:   value,
+		Timeout: timeout,
+	}
+
+	_, err = c.doDHT(req)
+	return err
+}
+
 // Provide announces that our peer provides content described by a CID.
 func (c *Client) Provide(id cid.Cid) error {
 	req := &pb.DHTRequest{
@@ -217,6 +266,78 @@ func (c *Client) Provide(id cid.Cid) error {
 	return err
 }
 
+// ProvideContext is like Provide, but if ctx has a deadline, the daemon gives
+// up when it expires. If announce is false, the provider record is only kept
+// by the daemon and not announced to the network.
+func (c *Client) ProvideContext(ctx context.Context, id cid.Cid, announce bool) error {
+	timeout, err := requestTimeout(ctx)
+	if err != nil {
+		return err
+	}
+
+	req := &pb.DHTRequest{
+		Type:     pb.DHTRequest_PROVIDE.Enum(),
+		Cid:      id.Bytes(),
+		Timeout:  timeout,
+		Announce: &announce,
+	}
+
+	_, err = c.doDHT(req)
+	return err
+}
+
+// StartProviding has the daemon keep providing id: it is announced right
+// away, and again periodically so that its provider records don't expire.
+func (c *Client) StartProviding(id cid.Cid) error {
+	req := &pb.DHTRequest{
+		Type: pb.DHTRequest_START_PROVIDING.Enum(),
+		Cid:  id.Bytes(),
+	}
+
+	_, err := c.doDHT(req)
+	return err
+}
+
+// StopProviding has the daemon stop announcing id; the provider records
+// already announced expire on their own.
+func (c *Client) StopProviding(id cid.Cid) error {
+	req := &pb.DHTRequest{
+		Type: pb.DHTRequest_STOP_PROVIDING.Enum(),
+		Cid:  id.Bytes(),
+	}
+
+	_, err := c.doDHT(req)
+	return err
+}
+
+// ListProviding returns the content the daemon keeps providing.
+func (c *Client) ListProviding(ctx context.Context) ([]cid.Cid, error) {
+	req := newDHTReq(&pb.DHTRequest{
+		Type: pb.DHTRequest_LIST_PROVIDING.Enum(),
+	})
+
+	values, err := c.streamRequestValue(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// drain the stream even if a CID is invalid, so that it is closed
+	var cids []cid.Cid
+	var castErr error
+	for v := range values {
+		id, err := cid.Cast(v)
+		if err != nil {
+			castErr = err
+			continue
+		}
+		cids = append(cids, id)
+	}
+	if castErr != nil {
+		return nil, castErr
+	}
+	return cids, ctx.Err()
+}
+
 func convertResponseToPeerInfo(respc <-chan *pb.DHTResponse) <-chan PeerInfo {
 	out := make(chan PeerInfo, 10)
 
@@ -326,6 +447,26 @@ func (c *Client) FindProviders(ctx context.Context, cid cid.Cid) (<-chan PeerInf
 	return c.streamRequestPeerInfo(ctx, req)
 }
 
+// FindProvidersWithCount is like FindProviders, but stops after count
+// providers, or the daemon default if count is zero. If ctx has a deadline,
+// the daemon stops the query when it expires.
+func (c *Client) FindProvidersWithCount(ctx context.Context, cid cid.Cid, count int) (<-chan PeerInfo, error) {
+	timeout, err := requestTimeout(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	n := int32(count)
+	req := newDHTReq(&pb.DHTRequest{
+		Type:    pb.DHTRequest_FIND_PROVIDERS.Enum(),
+		Cid:     cid.Bytes(),
+		Count:   &n,
+		Timeout: timeout,
+	})
+
+	return c.streamRequestPeerInfo(ctx, req)
+}
+
 // GetClosestPeers queries the DHT routing table for peers that are closest
 // to a provided key.
 func (c *Client) GetClosestPeers(ctx context.Context, key []byte) (<-chan peer.ID, error) {
@@ -337,6 +478,39 @@ func (c *Client) GetClosestPeers(ctx context.Context, key []byte) (<-chan peer.I
 	return c.streamRequestPeerID(ctx, req)
 }
 
+// GetClosestPeersInfo is like GetClosestPeers, but also returns the addresses
+// the daemon knows for each peer. If ctx has a deadline, the daemon stops the
+// query when it expires.
+func (c *Client) GetClosestPeersInfo(ctx context.Context, key []byte) (<-chan PeerInfo, error) {
+	dhtReq := &pb.DHTRequest{
+		Type: pb.DHTRequest_GET_CLOSEST_PEERS.Enum(),
+		Key:  key,
+	}
+
+	timeout, err := requestTimeout(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dhtReq.Timeout = timeout
+
+	return c.streamRequestPeerInfo(ctx, newDHTReq(dhtReq))
+}
+
+// requestTimeout converts the deadline of ctx, if any, to the timeout in
+// seconds carried by control requests, rounding up.
+func requestTimeout(ctx context.Context) (*int64, error) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return nil, nil
+	}
+
+	timeout := int64(math.Ceil(time.Until(deadline).Seconds()))
+	if timeout <= 0 {
+		return nil, context.DeadlineExceeded
+	}
+	return &timeout, nil
+}
+
 // SearchValue queries the DHT for the best/most valid value stored at a key.
 // Later responses are better.
 func (c *Client) SearchValue(ctx context.Context, key []byte) (<-chan []byte, error) {
@@ -347,3 +521,87 @@ func (c *Client) SearchValue(ctx context.Context, key []byte) (<-chan []byte, er
 
 	return c.streamRequestValue(ctx, req)
 }
+
+// RoutingTable describes the daemon's DHT routing table.
+type RoutingTable struct {
+	// PeerCount is the number of peers in the routing table.
+	PeerCount int
+	// Buckets maps the number of leading bits the DHT id of peers has in
+	// common with the daemon's to the peers, if they were requested.
+	Buckets map[int][]peer.ID
+}
+
+// RoutingTable returns the size of the daemon's DHT routing table, and its
+// peers if withPeers is set.
+func (c *Client) RoutingTable(withPeers bool) (*RoutingTable, error) {
+	return c.routingTable(&pb.RoutingTableRequest{Peers: &withPeers})
+}
+
+// RefreshRoutingTable has the daemon refresh its DHT routing table, e.g. after
+// a change of network, rather than wait for its periodic refresh, and returns
+// the table with its peers once the refresh is complete. Buckets refreshed
+// recently are skipped unless force is set. The deadline of ctx, if any,
+// bounds the wait; the refresh goes on in the daemon if it expires.
+func (c *Client) RefreshRoutingTable(ctx context.Context, force bool) (*RoutingTable, error) {
+	timeout, err := requestTimeout(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	withPeers, refresh := true, true
+	return c.routingTable(&pb.RoutingTableRequest{
+		Peers:   &withPeers,
+		Refresh: &refresh,
+		Force:   &force,
+		Timeout: timeout,
+	})
+}
+
+func (c *Client) routingTable(rtReq *pb.RoutingTableRequest) (*RoutingTable, error) {
+	control, err := c.newControlConn()
+	if err != nil {
+		return nil, err
+	}
+	defer control.Close()
+	r := ggio.NewDelimitedReader(control, MessageSizeMax)
+	w := ggio.NewDelimitedWriter(control)
+
+	req := &pb.Request{
+		Type:         pb.Request_ROUTING_TABLE.Enum(),
+		RoutingTable: rtReq,
+	}
+	if err := w.WriteMsg(req); err != nil {
+		return nil, err
+	}
+
+	res := &pb.Response{}
+	if err := r.ReadMsg(res); err != nil {
+		return nil, err
+	}
+
+	if err := res.GetError(); err != nil {
+		return nil, errors.New(err.GetMsg())
+	}
+
+	pbTable := res.GetRoutingTable()
+	if pbTable == nil {
+		return nil, errors.New("daemon returned no routing table")
+	}
+
+	table := &RoutingTable{
+		PeerCount: int(pbTable.GetPeerCount()),
+		Buckets:   make(map[int][]peer.ID, len(pbTable.GetBuckets())),
+	}
+	for _, bucket := range pbTable.GetBuckets() {
+		peers := make([]peer.ID, 0, len(bucket.GetPeers()))
+		for _, bs := range bucket.GetPeers() {
+			p, err := peer.IDFromBytes(bs)
+			if err != nil {
+				return nil, err
+			}
+			peers = append(peers, p)
+		}
+		table.Buckets[int(bucket.GetCpl())] = peers
+	}
+	return table, nil
+}
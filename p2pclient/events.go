@@ -0,0 +1,127 @@
+package p2pclient
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+	"github.com/multiformats/go-multiaddr"
+)
+
+// Event is a host event relayed by the daemon. Reachability is set for
+// LOCAL_REACHABILITY_CHANGED, Peer, Connectedness and Addr for
+// PEER_CONNECTEDNESS_CHANGED and Addrs for LOCAL_ADDRESSES_UPDATED. If the
+// subscription fails, Err is set instead.
+type Event struct {
+	Type          pb.Event_Type
+	Reachability  network.Reachability
+	Peer          peer.ID
+	Connectedness network.Connectedness
+	// Addr is the remote address of the first connection opened to Peer, or
+	// of the last one closed.
+	Addr  multiaddr.Multiaddr
+	Addrs []multiaddr.Multiaddr
+	Err   error
+}
+
+// SubscribeEvents subscribes to the given types of host events. The events are
+// delivered on the returned channel, which is closed once ctx is cancelled. If
+// the subscription fails, the error is delivered as the last Event.
+func (c *Client) SubscribeEvents(ctx context.Context, types ...pb.Event_Type) (<-chan *Event, error) {
+	if len(types) == 0 {
+		return nil, errors.New("no event types to subscribe to")
+	}
+
+	w := c.getPersistentWriter()
+
+	callID := uuid.New()
+
+	frames := c.newStreamFuture(ctx, callID, w)
+
+	if err := w.WriteMsg(
+		&pb.PersistentConnectionRequest{
+			CallId: callID[:],
+			Message: &pb.PersistentConnectionRequest_SubscribeEvents{
+				SubscribeEvents: &pb.SubscribeEventsRequest{Types: types},
+			},
+		},
+	); err != nil {
+		c.streamFutures.Delete(callID)
+		return nil, err
+	}
+
+	out := make(chan *Event)
+	go func() {
+		defer close(out)
+		defer c.releaseStream(frames)
+
+		deliver := func(e *Event) {
+			select {
+			case out <- e:
+			case <-ctx.Done():
+			}
+		}
+
+		for {
+			frame, err := frames.next()
+			if err != nil {
+				deliver(&Event{Err: err})
+				return
+			}
+
+			switch {
+			case frame.GetDaemonError() != nil:
+				deliver(&Event{Err: newDaemonError(frame.GetDaemonError())})
+				return
+
+			case frame.GetCancel() != nil:
+				return
+
+			case frame.GetEndOfStream() != nil:
+				return
+
+			case frame.GetEvent() != nil:
+				deliver(eventFromPb(frame.GetEvent()))
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+func eventFromPb(pbEvt *pb.Event) *Event {
+	evt := &Event{
+		Type:          pbEvt.GetType(),
+		Reachability:  network.Reachability(pbEvt.GetReachability()),
+		Connectedness: network.Connectedness(pbEvt.GetConnectedness()),
+	}
+
+	if pbEvt.Peer != nil {
+		p, err := peer.IDFromBytes(pbEvt.GetPeer())
+		if err != nil {
+			return &Event{Err: err}
+		}
+		evt.Peer = p
+	}
+
+	if pbEvt.Addr != nil {
+		addr, err := multiaddr.NewMultiaddrBytes(pbEvt.GetAddr())
+		if err != nil {
+			return &Event{Err: err}
+		}
+		evt.Addr = addr
+	}
+
+	for _, addrbytes := range pbEvt.GetAddrs() {
+		addr, err := multiaddr.NewMultiaddrBytes(addrbytes)
+		if err != nil {
+			return &Event{Err: err}
+		}
+		evt.Addrs = append(evt.Addrs, addr)
+	}
+
+	return evt
+}
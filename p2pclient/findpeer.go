@@ -0,0 +1,54 @@
+package p2pclient
+
+import (
+	"context"
+	"errors"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+
+	ggio "github.com/gogo/protobuf/io"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// LookupPeer returns the addresses of a peer. Unlike FindPeer, it first
+// checks the daemon's peerstore, and only queries the DHT if the peer has no
+// addresses there and skipDHT is false. It fails if no address is found. If
+// ctx has a deadline, the daemon gives up querying the DHT when it expires.
+func (c *Client) LookupPeer(ctx context.Context, p peer.ID, skipDHT bool) (PeerInfo, error) {
+	timeout, err := requestTimeout(ctx)
+	if err != nil {
+		return PeerInfo{}, err
+	}
+
+	control, err := c.newControlConn()
+	if err != nil {
+		return PeerInfo{}, err
+	}
+	defer control.Close()
+	r := ggio.NewDelimitedReader(control, MessageSizeMax)
+	w := ggio.NewDelimitedWriter(control)
+
+	req := &pb.Request{
+		Type: pb.Request_FIND_PEER.Enum(),
+		FindPeer: &pb.FindPeerRequest{
+			Peer:    []byte(p),
+			SkipDHT: &skipDHT,
+			Timeout: timeout,
+		},
+	}
+
+	if err := w.WriteMsg(req); err != nil {
+		return PeerInfo{}, err
+	}
+
+	res := &pb.Response{}
+	if err := r.ReadMsg(res); err != nil {
+		return PeerInfo{}, err
+	}
+
+	if err := res.GetError(); err != nil {
+		return PeerInfo{}, errors.New(err.GetMsg())
+	}
+
+	return convertPbPeerInfo(res.GetPeer())
+}
@@ -0,0 +1,354 @@
+package p2pclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+
+	ggio "github.com/gogo/protobuf/io"
+	"github.com/google/uuid"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// streamFrameSize bounds the data written in each frame of a framed stream.
+const streamFrameSize = 1 << 16
+
+// streamWindow is the number of data frames of a stream accepted by a framed
+// stream handler the daemon may send ahead of those the handler read, which
+// are acked streamWindow/4 at a time.
+const streamWindow = 64
+
+// StreamResetError is returned by FramedStream.Read once the stream was
+// reset, rather than closed by the remote peer.
+type StreamResetError struct {
+	message string
+}
+
+func (e *StreamResetError) Error() string {
+	return fmt.Sprintf("stream reset: %s", e.message)
+}
+
+// FramedStream is a stream opened with NewFramedStream, or accepted by a
+// handler added with AddFramedStreamHandler. Unlike the one returned by
+// NewStream, each side can close its end of it on its own, and Read tells a
+// stream closed by the remote peer (io.EOF) from a reset one
+// (*StreamResetError).
+type FramedStream struct {
+	frames frameConn
+
+	wm sync.Mutex
+	// set once the stream was closed for writing
+	closedWrite int32
+
+	// data of the last frame that wasn't read yet, then the error to return
+	// once it is
+	buf     []byte
+	readErr error
+	// set once the remote peer closed its end of the stream
+	readEOF int32
+}
+
+// frameConn carries the frames of a FramedStream.
+type frameConn interface {
+	readFrame() (*pb.StreamFrame, error)
+	writeFrame(*pb.StreamFrame) error
+	// close ends the stream; done tells whether it is done both ways
+	close(done bool) error
+}
+
+// socketFrames carries the frames of a stream opened on its own control
+// connection.
+type socketFrames struct {
+	conn net.Conn
+	r    ggio.Reader
+	w    ggio.Writer
+}
+
+func (f *socketFrames) readFrame() (*pb.StreamFrame, error) {
+	var frame pb.StreamFrame
+	if err := f.r.ReadMsg(&frame); err != nil {
+		return nil, err
+	}
+	return &frame, nil
+}
+
+func (f *socketFrames) writeFrame(frame *pb.StreamFrame) error {
+	return f.w.WriteMsg(frame)
+}
+
+func (f *socketFrames) close(bool) error {
+	// the daemon resets the stream unless it is done
+	return f.conn.Close()
+}
+
+// NewFramedStream initializes a new stream on one of the protocols in protos
+// with the specified peer, like NewStream.
+func (c *Client) NewFramedStream(peer peer.ID, protos []string) (*StreamInfo, *FramedStream, error) {
+	info, control, err := c.openStream(peer, protos, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return info, &FramedStream{frames: &socketFrames{
+		conn: control,
+		r:    ggio.NewDelimitedReader(control, MessageSizeMax),
+		w:    ggio.NewDelimitedWriter(control),
+	}}, nil
+}
+
+func (s *FramedStream) Read(p []byte) (int, error) {
+	for len(s.buf) == 0 {
+		if s.readErr != nil {
+			return 0, s.readErr
+		}
+
+		frame, err := s.frames.readFrame()
+		if err != nil {
+			s.readErr = err
+			continue
+		}
+
+		s.buf = frame.GetData()
+		switch {
+		case frame.Error != nil:
+			s.readErr = &StreamResetError{message: frame.GetError()}
+		case frame.GetCloseWrite():
+			s.readErr = io.EOF
+			atomic.StoreInt32(&s.readEOF, 1)
+		}
+	}
+
+	n := copy(p, s.buf)
+	s.buf = s.buf[n:]
+	return n, nil
+}
+
+func (s *FramedStream) Write(p []byte) (int, error) {
+	s.wm.Lock()
+	defer s.wm.Unlock()
+
+	written := 0
+	for written < len(p) {
+		chunk := p[written:]
+		if len(chunk) > streamFrameSize {
+			chunk = chunk[:streamFrameSize]
+		}
+		if err := s.frames.writeFrame(&pb.StreamFrame{Data: chunk}); err != nil {
+			return written, err
+		}
+		written += len(chunk)
+	}
+	return written, nil
+}
+
+// CloseWrite closes the stream for writing; the remote peer reads io.EOF
+// once it read the data written before. The stream can still be read from.
+func (s *FramedStream) CloseWrite() error {
+	s.wm.Lock()
+	defer s.wm.Unlock()
+
+	closeWrite := true
+	if err := s.frames.writeFrame(&pb.StreamFrame{CloseWrite: &closeWrite}); err != nil {
+		return err
+	}
+	atomic.StoreInt32(&s.closedWrite, 1)
+	return nil
+}
+
+// Reset resets the stream. A Write waiting for the daemon to ack the frames
+// written before returns then.
+func (s *FramedStream) Reset() error {
+	// closing the frames before the stream is done resets it, without
+	// waiting for such a Write to give up wm
+	return s.frames.close(false)
+}
+
+// Close releases the stream. It is closed if it was done both ways, and reset
+// otherwise.
+func (s *FramedStream) Close() error {
+	done := atomic.LoadInt32(&s.closedWrite) == 1 && atomic.LoadInt32(&s.readEOF) == 1
+	return s.frames.close(done)
+}
+
+// persistentFrames carries the frames of a stream accepted by a framed
+// stream handler, over the persistent connection.
+type persistentFrames struct {
+	c        *Client
+	streamID uuid.UUID
+	// frames holds the frames received and not read yet: up to streamWindow
+	// data frames, and the frame closing or resetting the stream
+	frames chan *pb.StreamFrame
+	// credit holds a token per frame written and not acked yet by the daemon,
+	// up to the window it announced; nil for daemons that don't ack frames
+	credit chan struct{}
+	// unacked counts the data frames read since the last ack
+	unacked uint32
+	// fellBehind is set by the read loop, before closing done, once the
+	// daemon sent frames beyond the window, which resets the stream
+	fellBehind bool
+	done       chan struct{}
+	once       sync.Once
+}
+
+func (f *persistentFrames) readFrame() (*pb.StreamFrame, error) {
+	select {
+	case frame := <-f.frames:
+		if len(frame.Data) > 0 {
+			f.ack()
+		}
+		return frame, nil
+	case <-f.done:
+		if f.fellBehind {
+			msg := "handler fell behind the frames received"
+			return &pb.StreamFrame{Error: &msg}, nil
+		}
+		return nil, io.ErrClosedPipe
+	}
+}
+
+// ack tells the daemon once the handler read a quarter of the window, so that
+// it sends more.
+func (f *persistentFrames) ack() {
+	f.unacked++
+	if f.unacked < streamWindow/4 {
+		return
+	}
+	ack := f.unacked
+	f.unacked = 0
+	if err := f.writeFrame(&pb.StreamFrame{Ack: &ack}); err != nil {
+		log.Debugw("error acking stream frames", "stream", f.streamID, "error", err)
+	}
+}
+
+func (f *persistentFrames) writeFrame(frame *pb.StreamFrame) error {
+	// the daemon resets streams whose client writes beyond the window;
+	// resetting and acking frames needn't wait
+	if f.credit != nil && frame.Error == nil && frame.Ack == nil {
+		select {
+		case f.credit <- struct{}{}:
+		case <-f.done:
+			return io.ErrClosedPipe
+		}
+	}
+	return f.c.getPersistentWriter().WriteMsg(&pb.PersistentConnectionRequest{
+		CallId:  f.streamID[:],
+		Message: &pb.PersistentConnectionRequest_StreamFrame{StreamFrame: frame},
+	})
+}
+
+func (f *persistentFrames) close(done bool) error {
+	var err error
+	f.once.Do(func() {
+		if !done {
+			msg := "closed by client"
+			err = f.writeFrame(&pb.StreamFrame{Error: &msg})
+		}
+		f.c.inboundStreams.Delete(f.streamID)
+		close(f.done)
+	})
+	return err
+}
+
+// FramedStreamHandlerFunc is the type of callbacks executed upon receiving a
+// new stream on a protocol registered with AddFramedStreamHandler.
+type FramedStreamHandlerFunc func(*StreamInfo, *FramedStream)
+
+// AddFramedStreamHandler registers handler for incoming streams on proto,
+// which the daemon proxies over the persistent connection. The handler must
+// close the stream once it is done with it.
+func (c *Client) AddFramedStreamHandler(proto protocol.ID, handler FramedStreamHandlerFunc) error {
+	w := c.getPersistentWriter()
+
+	callID := uuid.New()
+
+	raw := true
+	window := uint32(streamWindow)
+	w.WriteMsg(
+		&pb.PersistentConnectionRequest{
+			CallId: callID[:],
+			Message: &pb.PersistentConnectionRequest_AddUnaryHandler{
+				AddUnaryHandler: &pb.AddUnaryHandlerRequest{
+					Proto:  (*string)(&proto),
+					Raw:    &raw,
+					Window: &window,
+				},
+			},
+		},
+	)
+
+	if _, err := c.getResponse(context.Background(), callID); err != nil {
+		return err
+	}
+
+	c.framedStreamHandlers.Store(proto, handler)
+
+	return nil
+}
+
+// acceptFramedStream starts handling a stream the daemon notified the client
+// of. It must be called from the persistent connection's read loop, so the
+// stream is registered before its frames are received.
+func (c *Client) acceptFramedStream(streamID uuid.UUID, pbInfo *pb.StreamInfo) {
+	f := &persistentFrames{
+		c:        c,
+		streamID: streamID,
+		frames:   make(chan *pb.StreamFrame, streamWindow+1),
+		done:     make(chan struct{}),
+	}
+	if window := pbInfo.GetWindow(); window > 0 {
+		f.credit = make(chan struct{}, window)
+	}
+	stream := &FramedStream{frames: f}
+
+	info, err := convertStreamInfo(pbInfo)
+	h, found := c.framedStreamHandlers.Load(protocol.ID(pbInfo.GetProto()))
+	if err != nil || !found {
+		log.Debugw("can't handle incoming stream", "proto", pbInfo.GetProto(), "error", err)
+		stream.Reset()
+		return
+	}
+
+	c.inboundStreams.Store(streamID, f)
+	go h.(FramedStreamHandlerFunc)(info, stream)
+}
+
+// deliverFrame passes a frame received on the persistent connection to the
+// stream it is for, in order. It never waits for the stream to be read, so
+// that a slow handler doesn't hold up the other calls of the connection; a
+// stream the daemon sends more frames than the window to, e.g. as it doesn't
+// know of it, is reset.
+func (c *Client) deliverFrame(streamID uuid.UUID, frame *pb.StreamFrame) {
+	v, found := c.inboundStreams.Load(streamID)
+	if !found {
+		return
+	}
+
+	f := v.(*persistentFrames)
+	if frame.Ack != nil {
+		for i := uint32(0); i < frame.GetAck(); i++ {
+			select {
+			case <-f.credit:
+			default:
+			}
+		}
+		return
+	}
+
+	if f.fellBehind {
+		return
+	}
+	select {
+	case f.frames <- frame:
+		return
+	default:
+	}
+
+	log.Warnw("framed stream handler fell behind; resetting the stream", "stream", streamID)
+	f.fellBehind = true
+	f.close(false)
+}
@@ -0,0 +1,78 @@
+package p2pclient
+
+import (
+	"errors"
+
+	ggio "github.com/gogo/protobuf/io"
+	"github.com/libp2p/go-libp2p-core/peer"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// GetPeerLists returns the peers the daemon restricts connections to and the
+// peers it never connects with.
+func (c *Client) GetPeerLists() (allowed, blocked []peer.ID, err error) {
+	return c.peerLists(&pb.PeerListsRequest{Type: pb.PeerListsRequest_GET.Enum()})
+}
+
+// SetPeerLists replaces the daemon's peer lists and closes the open
+// connections to peers that are no longer allowed. An empty allowed list
+// allows every peer that isn't blocked.
+func (c *Client) SetPeerLists(allowed, blocked []peer.ID) error {
+	_, _, err := c.peerLists(&pb.PeerListsRequest{
+		Type:         pb.PeerListsRequest_SET.Enum(),
+		AllowedPeers: peerIDsToBytes(allowed),
+		BlockedPeers: peerIDsToBytes(blocked),
+	})
+	return err
+}
+
+func (c *Client) peerLists(req *pb.PeerListsRequest) (allowed, blocked []peer.ID, err error) {
+	control, err := c.newControlConn()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer control.Close()
+	r := ggio.NewDelimitedReader(control, MessageSizeMax)
+	w := ggio.NewDelimitedWriter(control)
+
+	if err := w.WriteMsg(&pb.Request{Type: pb.Request_PEER_LISTS.Enum(), PeerLists: req}); err != nil {
+		return nil, nil, err
+	}
+
+	res := &pb.Response{}
+	if err := r.ReadMsg(res); err != nil {
+		return nil, nil, err
+	}
+
+	if err := res.GetError(); err != nil {
+		return nil, nil, errors.New(err.GetMsg())
+	}
+
+	if allowed, err = peerIDsFromBytes(res.GetPeerLists().GetAllowedPeers()); err != nil {
+		return nil, nil, err
+	}
+	if blocked, err = peerIDsFromBytes(res.GetPeerLists().GetBlockedPeers()); err != nil {
+		return nil, nil, err
+	}
+	return allowed, blocked, nil
+}
+
+func peerIDsToBytes(peers []peer.ID) [][]byte {
+	bs := make([][]byte, len(peers))
+	for i, p := range peers {
+		bs[i] = []byte(p)
+	}
+	return bs
+}
+
+func peerIDsFromBytes(bs [][]byte) ([]peer.ID, error) {
+	peers := make([]peer.ID, len(bs))
+	for i, b := range bs {
+		p, err := peer.IDFromBytes(b)
+		if err != nil {
+			return nil, err
+		}
+		peers[i] = p
+	}
+	return peers, nil
+}
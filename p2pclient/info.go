@@ -0,0 +1,70 @@
+package p2pclient
+
+import (
+	"errors"
+	"time"
+
+	ggio "github.com/gogo/protobuf/io"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// DaemonInfo characterizes a daemon: when it started, what it was built from
+// and the subsystems it runs.
+type DaemonInfo struct {
+	StartTime time.Time
+	Uptime    time.Duration
+	// Module and Version identify the main module the daemon was built from;
+	// they are empty if it was built without module support.
+	Module    string
+	Version   string
+	GoVersion string
+	// DHTMode is full, client or server, or empty without the DHT.
+	DHTMode string
+	// PubSubRouter is floodsub or gossipsub, or empty without pubsub.
+	PubSubRouter string
+	// Relay is set when the relay transport is enabled, and RelayHop when the
+	// daemon also relays connections for other peers.
+	Relay    bool
+	RelayHop bool
+}
+
+// DaemonInfo returns the start time, build info and enabled subsystems of
+// the daemon.
+func (c *Client) DaemonInfo() (*DaemonInfo, error) {
+	control, err := c.newControlConn()
+	if err != nil {
+		return nil, err
+	}
+	defer control.Close()
+	r := ggio.NewDelimitedReader(control, MessageSizeMax)
+	w := ggio.NewDelimitedWriter(control)
+
+	if err := w.WriteMsg(&pb.Request{Type: pb.Request_DAEMON_INFO.Enum()}); err != nil {
+		return nil, err
+	}
+
+	res := &pb.Response{}
+	if err := r.ReadMsg(res); err != nil {
+		return nil, err
+	}
+
+	if err := res.GetError(); err != nil {
+		return nil, errors.New(err.GetMsg())
+	}
+
+	info := res.GetDaemonInfo()
+	if info == nil {
+		return nil, errors.New("daemon info response is empty")
+	}
+	return &DaemonInfo{
+		StartTime:    time.Unix(0, info.GetStartTime()),
+		Uptime:       time.Duration(info.GetUptime()),
+		Module:       info.GetModule(),
+		Version:      info.GetVersion(),
+		GoVersion:    info.GetGoVersion(),
+		DHTMode:      info.GetDhtMode(),
+		PubSubRouter: info.GetPubsubRouter(),
+		Relay:        info.GetRelay(),
+		RelayHop:     info.GetRelayHop(),
+	}, nil
+}
@@ -0,0 +1,54 @@
+package p2pclient
+
+import (
+	"errors"
+
+	ggio "github.com/gogo/protobuf/io"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// LogLevels returns the log level of every subsystem of the daemon, by
+// subsystem.
+func (c *Client) LogLevels() (map[string]string, error) {
+	return c.logLevel(nil)
+}
+
+// SetLogLevel sets the log level of a subsystem of the daemon, such as dht or
+// pubsub, or of all of them if subsystem is *, until the daemon restarts.
+// Levels are debug, info, warn, error, dpanic, panic and fatal. It returns the
+// log level of every subsystem once set.
+func (c *Client) SetLogLevel(subsystem, level string) (map[string]string, error) {
+	return c.logLevel(&pb.LogLevelRequest{Subsystem: &subsystem, Level: &level})
+}
+
+func (c *Client) logLevel(req *pb.LogLevelRequest) (map[string]string, error) {
+	control, err := c.newControlConn()
+	if err != nil {
+		return nil, err
+	}
+	defer control.Close()
+	r := ggio.NewDelimitedReader(control, MessageSizeMax)
+	w := ggio.NewDelimitedWriter(control)
+
+	if err := w.WriteMsg(&pb.Request{
+		Type:     pb.Request_LOG_LEVEL.Enum(),
+		LogLevel: req,
+	}); err != nil {
+		return nil, err
+	}
+
+	res := &pb.Response{}
+	if err := r.ReadMsg(res); err != nil {
+		return nil, err
+	}
+
+	if err := res.GetError(); err != nil {
+		return nil, errors.New(err.GetMsg())
+	}
+
+	levels := make(map[string]string, len(res.GetLogLevels()))
+	for _, l := range res.GetLogLevels() {
+		levels[l.GetSubsystem()] = l.GetLevel()
+	}
+	return levels, nil
+}
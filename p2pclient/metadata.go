@@ -0,0 +1,55 @@
+package p2pclient
+
+import (
+	"context"
+	"errors"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+
+	ggio "github.com/gogo/protobuf/io"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// PeerMetadata returns the metadata p announces, as read by the daemon from
+// a stream to p. It fails for peers that announce none, such as vanilla
+// libp2p peers. If ctx has a deadline, the daemon gives up when it expires.
+func (c *Client) PeerMetadata(ctx context.Context, p peer.ID) (map[string]string, error) {
+	timeout, err := requestTimeout(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	control, err := c.newControlConn()
+	if err != nil {
+		return nil, err
+	}
+	defer control.Close()
+	r := ggio.NewDelimitedReader(control, MessageSizeMax)
+	w := ggio.NewDelimitedWriter(control)
+
+	req := &pb.Request{
+		Type: pb.Request_PEER_METADATA.Enum(),
+		PeerMetadata: &pb.PeerMetadataRequest{
+			Peer:    []byte(p),
+			Timeout: timeout,
+		},
+	}
+	if err := w.WriteMsg(req); err != nil {
+		return nil, err
+	}
+
+	res := &pb.Response{}
+	if err := r.ReadMsg(res); err != nil {
+		return nil, err
+	}
+
+	if err := res.GetError(); err != nil {
+		return nil, errors.New(err.GetMsg())
+	}
+
+	md := make(map[string]string, len(res.GetMetadata().GetEntries()))
+	for _, e := range res.GetMetadata().GetEntries() {
+		md[e.GetKey()] = e.GetValue()
+	}
+	return md, nil
+}
@@ -1,12 +1,17 @@
 package p2pclient
 
 import (
+	"crypto/tls"
 	"errors"
+	"net"
 	"sync"
+	"time"
 
+	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
 
 	ggio "github.com/gogo/protobuf/io"
+	"github.com/google/uuid"
 	logging "github.com/ipfs/go-log"
 	pb "github.com/libp2p/go-libp2p-daemon/pb"
 	multiaddr "github.com/multiformats/go-multiaddr"
@@ -18,21 +23,50 @@ var log = logging.Logger("p2pclient")
 // MessageSizeMax is cribbed from github.com/libp2p/go-libp2p-net
 const MessageSizeMax = 1 << 22 // 4 MB
 
+// UnaryChunkSize is the size of the chunks the payloads of unary and stream
+// calls, and the responses of unary handlers, are split into when larger, so
+// that payloads over MessageSizeMax can be sent. Payloads are never split if
+// zero, the default, since daemons predating chunking can't reassemble them;
+// it should match the daemon's.
+var UnaryChunkSize = 0
+
+// MaxChunkedPayloadSize bounds the size of a payload the daemon sends in
+// chunks.
+var MaxChunkedPayloadSize = 1 << 28
+
 // Client is the struct that manages a connection to a libp2p daemon.
 type Client struct {
 	controlMaddr multiaddr.Multiaddr
 	listenMaddr  multiaddr.Multiaddr
 	listener     manet.Listener
+	// controlTLS secures control connections, unless nil
+	controlTLS *tls.Config
 
 	mhandlers sync.Mutex
 	handlers  map[string]StreamHandlerFunc
 
 	openPersistentConn   sync.Once
 	persistentConnWriter ggio.WriteCloser
+	// persistentConnDone is closed once the persistent connection is lost,
+	// failing the calls still waiting for a response
+	persistentConnDone chan struct{}
+	// daemonInstanceID is reported by the daemon when the persistent
+	// connection is opened
+	daemonInstanceID uuid.UUID
 
 	// callID (uuid.UUID) -> persistentConnectionFuture
-	callFutures   sync.Map
+	callFutures sync.Map
+	// callID (uuid.UUID) -> *streamFuture, for stream calls whose responses
+	// must be delivered in order
+	streamFutures sync.Map
 	unaryHandlers sync.Map
+	// topic (string) -> TopicValidator
+	topicValidators sync.Map
+
+	framedStreamHandlers sync.Map
+	// stream id (uuid.UUID) -> *persistentFrames, for streams accepted by
+	// framed stream handlers
+	inboundStreams sync.Map
 }
 
 // NewClient creates a new libp2p daemon client, connecting to a daemon
@@ -51,8 +85,46 @@ func NewClient(controlMaddr, listenMaddr multiaddr.Multiaddr) (*Client, error) {
 	return client, nil
 }
 
-func (c *Client) newControlConn() (manet.Conn, error) {
-	return manet.Dial(c.controlMaddr)
+// NewClientWithTLS is like NewClient for a daemon requiring TLS on its
+// control listener. config should hold the client certificate and the CA the
+// daemon's certificate is checked against; unless it sets ServerName, the
+// certificate must be valid for the host of controlMaddr, which must be a TCP
+// address.
+func NewClientWithTLS(controlMaddr, listenMaddr multiaddr.Multiaddr, config *tls.Config) (*Client, error) {
+	client := &Client{
+		controlMaddr: controlMaddr,
+		controlTLS:   config,
+		handlers:     make(map[string]StreamHandlerFunc),
+	}
+
+	if err := client.listen(listenMaddr); err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+func (c *Client) newControlConn() (net.Conn, error) {
+	conn, err := manet.Dial(c.controlMaddr)
+	if err != nil {
+		return nil, err
+	}
+	if c.controlTLS == nil {
+		return conn, nil
+	}
+
+	config := c.controlTLS
+	if config.ServerName == "" {
+		// check the daemon's certificate against the host dialed
+		config = config.Clone()
+		config.ServerName, _, _ = net.SplitHostPort(conn.RemoteAddr().String())
+	}
+	tc := tls.Client(conn, config)
+	if err := tc.Handshake(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return tc, nil
 }
 
 // Identify queries the daemon for its peer ID and listen addresses.
@@ -79,13 +151,44 @@ func (c *Client) Identify() (peer.ID, []multiaddr.Multiaddr, error) {
 		return peer.ID(""), nil, errors.New(reserr.GetMsg())
 	}
 
-	idres := res.GetIdentify()
-	id, err := peer.IDFromBytes(idres.Id)
+	return parseIdentifyResponse(res.GetIdentify())
+}
+
+// SignedPeerRecord returns the envelope of the daemon's signed peer record,
+// which can be handed out to peers instead of its addresses: it can't be
+// tampered with. It returns nil if the daemon doesn't keep one.
+func (c *Client) SignedPeerRecord() ([]byte, error) {
+	control, err := c.newControlConn()
+	if err != nil {
+		return nil, err
+	}
+	defer control.Close()
+	r := ggio.NewDelimitedReader(control, MessageSizeMax)
+	w := ggio.NewDelimitedWriter(control)
+
+	if err := w.WriteMsg(&pb.Request{Type: pb.Request_IDENTIFY.Enum()}); err != nil {
+		return nil, err
+	}
+
+	res := &pb.Response{}
+	if err := r.ReadMsg(res); err != nil {
+		return nil, err
+	}
+
+	if err := res.GetError(); err != nil {
+		return nil, errors.New(err.GetMsg())
+	}
+
+	return res.GetIdentify().GetSignedPeerRecord(), nil
+}
+
+func parseIdentifyResponse(idres *pb.IdentifyResponse) (peer.ID, []multiaddr.Multiaddr, error) {
+	id, err := peer.IDFromBytes(idres.GetId())
 	if err != nil {
 		return peer.ID(""), nil, err
 	}
-	addrs := make([]multiaddr.Multiaddr, 0, len(idres.Addrs))
-	for i, addrbytes := range idres.Addrs {
+	addrs := make([]multiaddr.Multiaddr, 0, len(idres.GetAddrs()))
+	for i, addrbytes := range idres.GetAddrs() {
 		addr, err := multiaddr.NewMultiaddrBytes(addrbytes)
 		if err != nil {
 			log.Errorf("failed to parse multiaddr in position %d in response to identify request", i)
@@ -97,42 +200,328 @@ func (c *Client) Identify() (peer.ID, []multiaddr.Multiaddr, error) {
 	return id, addrs, nil
 }
 
+// RotateIdentity has the daemon start a new host with a freshly generated key
+// of keyType (rsa, ed25519 or secp256k1; the type of the current key if
+// empty) and returns its peer ID and addresses. The old host keeps serving
+// persistent connections and in-flight calls for up to gracePeriod (the
+// daemon's shutdown grace period if zero); handlers must be registered again
+// on a new persistent connection to reach the new host.
+func (c *Client) RotateIdentity(keyType string, gracePeriod time.Duration) (peer.ID, []multiaddr.Multiaddr, error) {
+	control, err := c.newControlConn()
+	if err != nil {
+		return peer.ID(""), nil, err
+	}
+	defer control.Close()
+	r := ggio.NewDelimitedReader(control, MessageSizeMax)
+	w := ggio.NewDelimitedWriter(control)
+
+	rotate := &pb.RotateIdentityRequest{}
+	if keyType != "" {
+		rotate.KeyType = &keyType
+	}
+	if gracePeriod > 0 {
+		ms := gracePeriod.Milliseconds()
+		rotate.GracePeriodMs = &ms
+	}
+	req := &pb.Request{
+		Type:           pb.Request_ROTATE_IDENTITY.Enum(),
+		RotateIdentity: rotate,
+	}
+	if err = w.WriteMsg(req); err != nil {
+		return peer.ID(""), nil, err
+	}
+
+	res := &pb.Response{}
+	if err = r.ReadMsg(res); err != nil {
+		return peer.ID(""), nil, err
+	}
+
+	if reserr := res.GetError(); reserr != nil {
+		return peer.ID(""), nil, errors.New(reserr.GetMsg())
+	}
+
+	return parseIdentifyResponse(res.GetIdentify())
+}
+
 // Connect establishes a connection to a peer after populating the Peerstore
-// entry for said peer with a list of addresses.
+// entry for said peer with a list of addresses. If the dial fails, the error
+// is a *ConnectError listing the addresses dialed.
 func (c *Client) Connect(p peer.ID, addrs []multiaddr.Multiaddr) error {
+	_, err := c.ConnectWithInfo(p, addrs)
+	return err
+}
+
+// ConnectWithInfo is like Connect, but also returns the connections open to
+// the peer afterwards. Their addresses tell which transport was used, and
+// whether the connection goes through a relay.
+func (c *Client) ConnectWithInfo(p peer.ID, addrs []multiaddr.Multiaddr) ([]ConnInfo, error) {
+	res, err := c.connect(&pb.ConnectRequest{
+		Peer:  []byte(p),
+		Addrs: addrsToBytes(addrs),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// older daemons don't report connections
+	if len(res.GetConnectedPeers()) == 0 {
+		return nil, nil
+	}
+
+	cp, err := convertPbConnectedPeer(res.GetConnectedPeers()[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return cp.Conns, nil
+}
+
+// ConnectOptions tune ConnectWithOptions.
+type ConnectOptions struct {
+	// AddrTTL is how long the daemon keeps the addresses in its peerstore;
+	// they are only kept temporarily if zero.
+	AddrTTL time.Duration
+	// Timeout bounds the dial; the daemon's default applies if zero.
+	Timeout time.Duration
+	// SignedPeerRecord is the envelope of a signed peer record of the peer,
+	// as returned by SignedPeerRecord. If set, the daemon checks it and dials
+	// its addresses instead of the ones passed; those are a fallback for
+	// peers without records.
+	SignedPeerRecord []byte
+}
+
+// ConnectWithOptions is like Connect, but keeps the addresses in the daemon's
+// peerstore for opts.AddrTTL. It returns the remote address of the
+// connection the dial opened, or of an already open one.
+func (c *Client) ConnectWithOptions(p peer.ID, addrs []multiaddr.Multiaddr, opts ConnectOptions) (multiaddr.Multiaddr, error) {
+	addr, _, err := c.ConnectWithRecord(p, addrs, opts)
+	return addr, err
+}
+
+// ConnectWithRecord is like ConnectWithOptions, but also returns the
+// envelope of the signed peer record the peer sent once connected, or nil if
+// it sent none.
+func (c *Client) ConnectWithRecord(p peer.ID, addrs []multiaddr.Multiaddr, opts ConnectOptions) (multiaddr.Multiaddr, []byte, error) {
+	req := &pb.ConnectRequest{
+		Peer:             []byte(p),
+		Addrs:            addrsToBytes(addrs),
+		SignedPeerRecord: opts.SignedPeerRecord,
+	}
+	if opts.AddrTTL > 0 {
+		ttl := int64(opts.AddrTTL / time.Second)
+		req.AddrTTL = &ttl
+	}
+	if opts.Timeout > 0 {
+		timeout := int64(opts.Timeout / time.Second)
+		req.Timeout = &timeout
+	}
+
+	res, err := c.connect(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if res.GetConnect() == nil {
+		return nil, nil, errors.New("daemon did not report the connection address")
+	}
+	addr, err := multiaddr.NewMultiaddrBytes(res.GetConnect().GetAddr())
+	if err != nil {
+		return nil, nil, err
+	}
+	return addr, res.GetConnect().GetSignedPeerRecord(), nil
+}
+
+func (c *Client) connect(req *pb.ConnectRequest) (*pb.Response, error) {
+	control, err := c.newControlConn()
+	if err != nil {
+		return nil, err
+	}
+	defer control.Close()
+	r := ggio.NewDelimitedReader(control, MessageSizeMax)
+	w := ggio.NewDelimitedWriter(control)
+
+	if err := w.WriteMsg(&pb.Request{Type: pb.Request_CONNECT.Enum(), Connect: req}); err != nil {
+		return nil, err
+	}
+
+	res := &pb.Response{}
+	if err := r.ReadMsg(res); err != nil {
+		return nil, err
+	}
+
+	if err := res.GetError(); err != nil {
+		return nil, newConnectError(err)
+	}
+
+	return res, nil
+}
+
+// ConnectError is returned when the daemon fails to connect to a peer. It
+// lists the error of every address dialed, if the daemon reported them.
+type ConnectError struct {
+	message  string
+	Attempts []DialAttempt
+}
+
+// DialAttempt is the failed dial to one of the addresses of a peer.
+type DialAttempt struct {
+	Addr multiaddr.Multiaddr
+	// Err is the error of the dial, as reported by the transport.
+	Err string
+	// Reason classifies Err; it is OTHER for errors the daemon can't tell
+	// apart.
+	Reason pb.DialAttempt_Reason
+}
+
+func newConnectError(pbErr *pb.ErrorResponse) error {
+	ce := &ConnectError{message: pbErr.GetMsg()}
+	for _, a := range pbErr.GetDialAttempts() {
+		addr, err := multiaddr.NewMultiaddrBytes(a.GetAddr())
+		if err != nil {
+			log.Debugw("skipping dial attempt with invalid address", "error", err)
+			continue
+		}
+		ce.Attempts = append(ce.Attempts, DialAttempt{
+			Addr:   addr,
+			Err:    a.GetError(),
+			Reason: a.GetReason(),
+		})
+	}
+	return ce
+}
+
+func (ce *ConnectError) Error() string {
+	return ce.message
+}
+
+// Disconnect closes every connection to p, including protected ones, and
+// returns how many were closed. With forgetAddrs, the daemon also removes the
+// addresses of p from its peerstore.
+func (c *Client) Disconnect(p peer.ID, forgetAddrs bool) (int, error) {
 	control, err := c.newControlConn()
 	if err != nil {
-		return err
+		return 0, err
 	}
 	defer control.Close()
 	r := ggio.NewDelimitedReader(control, MessageSizeMax)
 	w := ggio.NewDelimitedWriter(control)
 
+	req := &pb.Request{
+		Type: pb.Request_DISCONNECT.Enum(),
+		Disconnect: &pb.DisconnectRequest{
+			Peer:        []byte(p),
+			ForgetAddrs: &forgetAddrs,
+		},
+	}
+	if err := w.WriteMsg(req); err != nil {
+		return 0, err
+	}
+
+	res := &pb.Response{}
+	if err := r.ReadMsg(res); err != nil {
+		return 0, err
+	}
+
+	if err := res.GetError(); err != nil {
+		return 0, errors.New(err.GetMsg())
+	}
+
+	return int(res.GetDisconnect().GetClosedConns()), nil
+}
+
+func addrsToBytes(addrs []multiaddr.Multiaddr) [][]byte {
 	addrbytes := make([][]byte, len(addrs))
 	for i, addr := range addrs {
 		addrbytes[i] = addr.Bytes()
 	}
+	return addrbytes
+}
 
-	req := &pb.Request{
-		Type: pb.Request_CONNECT.Enum(),
-		Connect: &pb.ConnectRequest{
-			Peer:  []byte(p),
-			Addrs: addrbytes,
-		},
+// ConnInfo describes a single open connection to a peer.
+type ConnInfo struct {
+	Addr      multiaddr.Multiaddr
+	Direction network.Direction
+	Relayed   bool
+	// Security is the negotiated security protocol, or empty if the daemon
+	// could not determine it.
+	Security string
+}
+
+// ConnectedPeer is a peer the daemon is connected to, along with its open
+// connections.
+type ConnectedPeer struct {
+	ID    peer.ID
+	Conns []ConnInfo
+}
+
+// ListConnections queries the daemon for the peers it is connected to and
+// the connections open to each of them.
+func (c *Client) ListConnections() ([]ConnectedPeer, error) {
+	control, err := c.newControlConn()
+	if err != nil {
+		return nil, err
 	}
+	defer control.Close()
+	r := ggio.NewDelimitedReader(control, MessageSizeMax)
+	w := ggio.NewDelimitedWriter(control)
 
+	req := &pb.Request{Type: pb.Request_LIST_CONNECTIONS.Enum()}
 	if err := w.WriteMsg(req); err != nil {
-		return err
+		return nil, err
 	}
 
 	res := &pb.Response{}
 	if err := r.ReadMsg(res); err != nil {
-		return err
+		return nil, err
 	}
 
 	if err := res.GetError(); err != nil {
-		return errors.New(err.GetMsg())
+		return nil, errors.New(err.GetMsg())
+	}
+
+	peers := make([]ConnectedPeer, 0, len(res.GetConnectedPeers()))
+	for _, pbPeer := range res.GetConnectedPeers() {
+		cp, err := convertPbConnectedPeer(pbPeer)
+		if err != nil {
+			return nil, err
+		}
+		peers = append(peers, cp)
+	}
+
+	return peers, nil
+}
+
+func convertPbConnectedPeer(pbPeer *pb.ConnectedPeer) (ConnectedPeer, error) {
+	id, err := peer.IDFromBytes(pbPeer.GetId())
+	if err != nil {
+		return ConnectedPeer{}, err
+	}
+
+	cp := ConnectedPeer{ID: id, Conns: make([]ConnInfo, 0, len(pbPeer.GetConns()))}
+	for _, pbConn := range pbPeer.GetConns() {
+		addr, err := multiaddr.NewMultiaddrBytes(pbConn.GetAddr())
+		if err != nil {
+			log.Errorf("failed to parse connection multiaddr for peer %s", id)
+			continue
+		}
+
+		var dir network.Direction
+		switch pbConn.GetDirection() {
+		case pb.ConnectionInfo_INBOUND:
+			dir = network.DirInbound
+		case pb.ConnectionInfo_OUTBOUND:
+			dir = network.DirOutbound
+		default:
+			dir = network.DirUnknown
+		}
+
+		cp.Conns = append(cp.Conns, ConnInfo{
+			Addr:      addr,
+			Direction: dir,
+			Relayed:   pbConn.GetRelayed(),
+			Security:  pbConn.GetSecurity(),
+		})
 	}
 
-	return nil
+	return cp, nil
 }
@@ -0,0 +1,105 @@
+package p2pclient
+
+import (
+	"errors"
+	"time"
+
+	ggio "github.com/gogo/protobuf/io"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+	"github.com/multiformats/go-multiaddr"
+)
+
+// AddAddrs adds addresses of p learned out of band, e.g. from a registry, to
+// the daemon's peerstore for ttl, which is rounded down to the second and
+// can't be under a second; they are kept for an hour if ttl is zero. Later
+// dials to p use them without looking the peer up. Addresses of a peer the
+// daemon has a signed peer record of are ignored.
+func (c *Client) AddAddrs(p peer.ID, addrs []multiaddr.Multiaddr, ttl time.Duration) error {
+	req := &pb.PeerstoreRequest{
+		Type:  pb.PeerstoreRequest_ADD_ADDRS.Enum(),
+		Peer:  []byte(p),
+		Addrs: addrsToBytes(addrs),
+	}
+	if ttl > 0 {
+		seconds := int64(ttl / time.Second)
+		req.Ttl = &seconds
+	}
+	_, err := c.peerstore(req)
+	return err
+}
+
+// ClearAddrs removes every address of p from the daemon's peerstore. Open
+// connections to p are left alone.
+func (c *Client) ClearAddrs(p peer.ID) error {
+	_, err := c.peerstore(&pb.PeerstoreRequest{
+		Type: pb.PeerstoreRequest_CLEAR_ADDRS.Enum(),
+		Peer: []byte(p),
+	})
+	return err
+}
+
+// PeerProtocols returns the protocols p supports, as the daemon learned them
+// from identify; they are unknown until the daemon has connected to p.
+func (c *Client) PeerProtocols(p peer.ID) ([]protocol.ID, error) {
+	res, err := c.peerstore(&pb.PeerstoreRequest{
+		Type: pb.PeerstoreRequest_GET_PROTOCOLS.Enum(),
+		Peer: []byte(p),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toProtocolIDs(res.GetPeerstore().GetProtocols()), nil
+}
+
+// SupportsProtocols returns which of protos p supports, according to the
+// protocols the daemon learned from identify, so that calls to p can be
+// skipped without opening a stream when none is supported.
+func (c *Client) SupportsProtocols(p peer.ID, protos ...protocol.ID) ([]protocol.ID, error) {
+	strs := make([]string, len(protos))
+	for i, proto := range protos {
+		strs[i] = string(proto)
+	}
+	res, err := c.peerstore(&pb.PeerstoreRequest{
+		Type:   pb.PeerstoreRequest_GET_PROTOCOLS.Enum(),
+		Peer:   []byte(p),
+		Protos: strs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toProtocolIDs(res.GetPeerstore().GetSupported()), nil
+}
+
+func toProtocolIDs(strs []string) []protocol.ID {
+	protos := make([]protocol.ID, len(strs))
+	for i, s := range strs {
+		protos[i] = protocol.ID(s)
+	}
+	return protos
+}
+
+func (c *Client) peerstore(req *pb.PeerstoreRequest) (*pb.Response, error) {
+	control, err := c.newControlConn()
+	if err != nil {
+		return nil, err
+	}
+	defer control.Close()
+	r := ggio.NewDelimitedReader(control, MessageSizeMax)
+	w := ggio.NewDelimitedWriter(control)
+
+	if err := w.WriteMsg(&pb.Request{Type: pb.Request_PEERSTORE.Enum(), Peerstore: req}); err != nil {
+		return nil, err
+	}
+
+	res := &pb.Response{}
+	if err := r.ReadMsg(res); err != nil {
+		return nil, err
+	}
+
+	if err := res.GetError(); err != nil {
+		return nil, errors.New(err.GetMsg())
+	}
+	return res, nil
+}
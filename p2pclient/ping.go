@@ -0,0 +1,78 @@
+package p2pclient
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+
+	ggio "github.com/gogo/protobuf/io"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// PingResult holds the round trip times measured by a ping.
+type PingResult struct {
+	RTTs []time.Duration
+	Min  time.Duration
+	Avg  time.Duration
+	Max  time.Duration
+}
+
+// Ping runs count rounds of the libp2p ping protocol against a peer over a
+// single stream. If count is zero, the daemon picks a default. If ctx has a
+// deadline, the daemon gives up when it expires.
+func (c *Client) Ping(ctx context.Context, p peer.ID, count int) (*PingResult, error) {
+	timeout, err := requestTimeout(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	control, err := c.newControlConn()
+	if err != nil {
+		return nil, err
+	}
+	defer control.Close()
+	r := ggio.NewDelimitedReader(control, MessageSizeMax)
+	w := ggio.NewDelimitedWriter(control)
+
+	n := int32(count)
+	req := &pb.Request{
+		Type: pb.Request_PING.Enum(),
+		Ping: &pb.PingRequest{
+			Peer:    []byte(p),
+			Count:   &n,
+			Timeout: timeout,
+		},
+	}
+
+	if err := w.WriteMsg(req); err != nil {
+		return nil, err
+	}
+
+	res := &pb.Response{}
+	if err := r.ReadMsg(res); err != nil {
+		return nil, err
+	}
+
+	if err := res.GetError(); err != nil {
+		return nil, errors.New(err.GetMsg())
+	}
+
+	pbPing := res.GetPing()
+	if pbPing == nil {
+		return nil, errors.New("daemon returned no ping results")
+	}
+
+	rtts := make([]time.Duration, len(pbPing.GetRtts()))
+	for i, rtt := range pbPing.GetRtts() {
+		rtts[i] = time.Duration(rtt)
+	}
+
+	return &PingResult{
+		RTTs: rtts,
+		Min:  time.Duration(pbPing.GetMin()),
+		Avg:  time.Duration(pbPing.GetAvg()),
+		Max:  time.Duration(pbPing.GetMax()),
+	}, nil
+}
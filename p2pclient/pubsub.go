@@ -3,10 +3,12 @@ package p2pclient
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/libp2p/go-libp2p-core/peer"
 
 	ggio "github.com/gogo/protobuf/io"
+	"github.com/google/uuid"
 	pb "github.com/libp2p/go-libp2p-daemon/pb"
 )
 
@@ -108,9 +110,12 @@ func (c *Client) GetTopics() ([]string, error) {
 	return res.GetTopics(), nil
 }
 
-func (c *Client) ListPeers() ([]peer.ID, error) {
+// ListPeers returns the peers the daemon is exchanging messages with on
+// topic. The daemon must be subscribed to the topic.
+func (c *Client) ListPeers(topic string) ([]peer.ID, error) {
 	req := &pb.PSRequest{
-		Type: pb.PSRequest_LIST_PEERS.Enum(),
+		Type:  pb.PSRequest_LIST_PEERS.Enum(),
+		Topic: &topic,
 	}
 
 	res, err := c.doPubsub(req)
@@ -149,3 +154,170 @@ func (c *Client) Subscribe(ctx context.Context, topic string) (<-chan *pb.PSMess
 
 	return c.streamPubsubRequest(ctx, req)
 }
+
+// PubSubMessage is a pubsub message relayed by the daemon over the persistent
+// connection. If the subscription fails, Err is set instead.
+type PubSubMessage struct {
+	From  peer.ID
+	Data  []byte
+	Seqno []byte
+	Err   error
+}
+
+// SubscribeTopic subscribes to topic over the persistent connection. Messages
+// are delivered on the returned channel, which is closed once ctx is
+// cancelled; the daemon drops the subscription at that point, or when the
+// connection closes. If the subscription fails, the error is delivered as the
+// last PubSubMessage.
+func (c *Client) SubscribeTopic(ctx context.Context, topic string) (<-chan *PubSubMessage, error) {
+	w := c.getPersistentWriter()
+
+	callID := uuid.New()
+
+	frames := c.newStreamFuture(ctx, callID, w)
+
+	if err := w.WriteMsg(
+		&pb.PersistentConnectionRequest{
+			CallId: callID[:],
+			Message: &pb.PersistentConnectionRequest_Pubsub{
+				Pubsub: &pb.PSRequest{
+					Type:  pb.PSRequest_SUBSCRIBE.Enum(),
+					Topic: &topic,
+				},
+			},
+		},
+	); err != nil {
+		c.streamFutures.Delete(callID)
+		return nil, err
+	}
+
+	out := make(chan *PubSubMessage)
+	go func() {
+		defer close(out)
+		defer c.releaseStream(frames)
+
+		deliver := func(m *PubSubMessage) {
+			select {
+			case out <- m:
+			case <-ctx.Done():
+			}
+		}
+
+		for {
+			frame, err := frames.next()
+			if err != nil {
+				deliver(&PubSubMessage{Err: err})
+				return
+			}
+
+			switch {
+			case frame.GetDaemonError() != nil:
+				deliver(&PubSubMessage{Err: newDaemonError(frame.GetDaemonError())})
+				return
+
+			case frame.GetCancel() != nil:
+				return
+
+			case frame.GetEndOfStream() != nil:
+				return
+
+			case frame.GetPubsubMessage() != nil:
+				deliver(pubSubMessageFromPb(frame.GetPubsubMessage()))
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+func pubSubMessageFromPb(msg *pb.PSMessage) *PubSubMessage {
+	return &PubSubMessage{
+		From:  peer.ID(msg.GetFrom()),
+		Data:  msg.GetData(),
+		Seqno: msg.GetSeqno(),
+	}
+}
+
+// PublishTopic publishes data to topic over the persistent connection.
+func (c *Client) PublishTopic(topic string, data []byte) error {
+	w := c.getPersistentWriter()
+
+	callID := uuid.New()
+
+	if err := w.WriteMsg(
+		&pb.PersistentConnectionRequest{
+			CallId: callID[:],
+			Message: &pb.PersistentConnectionRequest_Pubsub{
+				Pubsub: &pb.PSRequest{
+					Type:  pb.PSRequest_PUBLISH.Enum(),
+					Topic: &topic,
+					Data:  data,
+				},
+			},
+		},
+	); err != nil {
+		return err
+	}
+
+	_, err := c.getResponse(context.Background(), callID)
+	return err
+}
+
+// TopicValidator decides whether a message received on a topic is delivered
+// and propagated to other peers.
+type TopicValidator func(topic string, msg *PubSubMessage) pb.ValidationResult_Result
+
+// AddTopicValidator has the daemon ask validator about each message received
+// on topic. Messages that aren't validated within timeout are rejected; a zero
+// timeout uses the daemon's default. The validator is removed when the
+// persistent connection closes.
+func (c *Client) AddTopicValidator(topic string, validator TopicValidator, timeout time.Duration) error {
+	w := c.getPersistentWriter()
+
+	callID := uuid.New()
+
+	req := &pb.AddTopicValidatorRequest{Topic: &topic}
+	if timeout != 0 {
+		timeoutMs := timeout.Milliseconds()
+		req.TimeoutMs = &timeoutMs
+	}
+
+	// messages may be sent for validation as soon as the daemon registers
+	// the validator
+	if _, loaded := c.topicValidators.LoadOrStore(topic, validator); loaded {
+		return fmt.Errorf("validator for topic %s already set", topic)
+	}
+
+	if err := w.WriteMsg(
+		&pb.PersistentConnectionRequest{
+			CallId:  callID[:],
+			Message: &pb.PersistentConnectionRequest_AddTopicValidator{AddTopicValidator: req},
+		},
+	); err != nil {
+		c.topicValidators.Delete(topic)
+		return err
+	}
+
+	if _, err := c.getResponse(context.Background(), callID); err != nil {
+		c.topicValidators.Delete(topic)
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) validateMessage(w ggio.Writer, callID []byte, req *pb.ValidateMessage) {
+	result := pb.ValidationResult_IGNORE
+	if v, found := c.topicValidators.Load(req.GetTopic()); found {
+		result = v.(TopicValidator)(req.GetTopic(), pubSubMessageFromPb(req.GetMessage()))
+	}
+
+	w.WriteMsg(
+		&pb.PersistentConnectionRequest{
+			CallId: callID,
+			Message: &pb.PersistentConnectionRequest_ValidationResult{
+				ValidationResult: &pb.ValidationResult{Result: &result},
+			},
+		},
+	)
+}
@@ -0,0 +1,69 @@
+package p2pclient
+
+import (
+	"errors"
+
+	ggio "github.com/gogo/protobuf/io"
+	"github.com/libp2p/go-libp2p-core/peer"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+	"github.com/multiformats/go-multiaddr"
+)
+
+// RelayInfo describes a relay the daemon advertises circuit addresses
+// through.
+type RelayInfo struct {
+	ID peer.ID
+	// Addrs are the advertised circuit addresses going through the relay.
+	Addrs []multiaddr.Multiaddr
+	// Connected tells whether the daemon is connected to the relay, which
+	// circuit relay v1 requires for the addresses to be reachable.
+	Connected bool
+}
+
+// ListRelays returns the relays the daemon currently advertises circuit
+// addresses through.
+func (c *Client) ListRelays() ([]RelayInfo, error) {
+	control, err := c.newControlConn()
+	if err != nil {
+		return nil, err
+	}
+	defer control.Close()
+	r := ggio.NewDelimitedReader(control, MessageSizeMax)
+	w := ggio.NewDelimitedWriter(control)
+
+	if err := w.WriteMsg(&pb.Request{Type: pb.Request_LIST_RELAYS.Enum()}); err != nil {
+		return nil, err
+	}
+
+	res := &pb.Response{}
+	if err := r.ReadMsg(res); err != nil {
+		return nil, err
+	}
+
+	if err := res.GetError(); err != nil {
+		return nil, errors.New(err.GetMsg())
+	}
+
+	relays := make([]RelayInfo, 0, len(res.GetRelays()))
+	for _, pbRelay := range res.GetRelays() {
+		id, err := peer.IDFromBytes(pbRelay.GetPeer())
+		if err != nil {
+			return nil, err
+		}
+
+		info := RelayInfo{
+			ID:        id,
+			Addrs:     make([]multiaddr.Multiaddr, 0, len(pbRelay.GetAddrs())),
+			Connected: pbRelay.GetConnected(),
+		}
+		for _, bs := range pbRelay.GetAddrs() {
+			addr, err := multiaddr.NewMultiaddrBytes(bs)
+			if err != nil {
+				return nil, err
+			}
+			info.Addrs = append(info.Addrs, addr)
+		}
+		relays = append(relays, info)
+	}
+	return relays, nil
+}
@@ -0,0 +1,44 @@
+package p2pclient
+
+import (
+	"errors"
+
+	ggio "github.com/gogo/protobuf/io"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// RendezvousPeers returns the peers the daemon found advertising under its
+// rendezvous namespace the last time it looked. It fails if the daemon has no
+// rendezvous namespace.
+func (c *Client) RendezvousPeers() ([]PeerInfo, error) {
+	control, err := c.newControlConn()
+	if err != nil {
+		return nil, err
+	}
+	defer control.Close()
+	r := ggio.NewDelimitedReader(control, MessageSizeMax)
+	w := ggio.NewDelimitedWriter(control)
+
+	if err := w.WriteMsg(&pb.Request{Type: pb.Request_RENDEZVOUS_PEERS.Enum()}); err != nil {
+		return nil, err
+	}
+
+	res := &pb.Response{}
+	if err := r.ReadMsg(res); err != nil {
+		return nil, err
+	}
+
+	if err := res.GetError(); err != nil {
+		return nil, errors.New(err.GetMsg())
+	}
+
+	peers := make([]PeerInfo, 0, len(res.GetPeers()))
+	for _, pbi := range res.GetPeers() {
+		pi, err := convertPbPeerInfo(pbi)
+		if err != nil {
+			return nil, err
+		}
+		peers = append(peers, pi)
+	}
+	return peers, nil
+}
@@ -0,0 +1,79 @@
+package p2pclient
+
+import (
+	"errors"
+
+	ggio "github.com/gogo/protobuf/io"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// ResourceScope counts the connections and streams open in a scope.
+type ResourceScope struct {
+	ConnsInbound    int64
+	ConnsOutbound   int64
+	StreamsInbound  int64
+	StreamsOutbound int64
+}
+
+// ResourceUsage is a snapshot of the connections and streams open on the
+// daemon, in total and by protocol and peer. Streams whose protocol is not
+// negotiated yet are counted under the empty protocol.
+type ResourceUsage struct {
+	System    ResourceScope
+	Protocols map[protocol.ID]ResourceScope
+	Peers     map[peer.ID]ResourceScope
+}
+
+// ResourceUsage returns the connections and streams currently open on the
+// daemon.
+func (c *Client) ResourceUsage() (*ResourceUsage, error) {
+	control, err := c.newControlConn()
+	if err != nil {
+		return nil, err
+	}
+	defer control.Close()
+	r := ggio.NewDelimitedReader(control, MessageSizeMax)
+	w := ggio.NewDelimitedWriter(control)
+
+	if err := w.WriteMsg(&pb.Request{Type: pb.Request_RESOURCE_USAGE.Enum()}); err != nil {
+		return nil, err
+	}
+
+	res := &pb.Response{}
+	if err := r.ReadMsg(res); err != nil {
+		return nil, err
+	}
+
+	if err := res.GetError(); err != nil {
+		return nil, errors.New(err.GetMsg())
+	}
+
+	pbUsage := res.GetResourceUsage()
+	usage := &ResourceUsage{
+		System:    convertPbResourceScope(pbUsage.GetSystem()),
+		Protocols: make(map[protocol.ID]ResourceScope, len(pbUsage.GetProtocols())),
+		Peers:     make(map[peer.ID]ResourceScope, len(pbUsage.GetPeers())),
+	}
+	for _, scope := range pbUsage.GetProtocols() {
+		usage.Protocols[protocol.ID(scope.GetProto())] = convertPbResourceScope(scope.GetUsage())
+	}
+	for _, scope := range pbUsage.GetPeers() {
+		p, err := peer.IDFromBytes(scope.GetPeer())
+		if err != nil {
+			return nil, err
+		}
+		usage.Peers[p] = convertPbResourceScope(scope.GetUsage())
+	}
+	return usage, nil
+}
+
+func convertPbResourceScope(scope *pb.ResourceScope) ResourceScope {
+	return ResourceScope{
+		ConnsInbound:    scope.GetConnsInbound(),
+		ConnsOutbound:   scope.GetConnsOutbound(),
+		StreamsInbound:  scope.GetStreamsInbound(),
+		StreamsOutbound: scope.GetStreamsOutbound(),
+	}
+}
@@ -0,0 +1,122 @@
+package p2pclient
+
+import (
+	"context"
+	"errors"
+
+	ggio "github.com/gogo/protobuf/io"
+	"github.com/google/uuid"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// StreamBufferSize bounds the responses of a stream call, event subscription
+// or topic subscription received but not yet delivered on its channel. The
+// persistent connection's read loop never waits for a stream's channel to be
+// read, so that a slow reader doesn't hold up the other calls; a stream whose
+// reader falls further behind is cancelled, ending with ErrStreamFellBehind.
+var StreamBufferSize = 256
+
+// ErrStreamFellBehind is delivered as the last response of a stream whose
+// channel wasn't read fast enough, once the responses received before are.
+var ErrStreamFellBehind = errors.New("stream cancelled as its reader fell behind")
+
+// streamFuture receives the responses of a stream, in order, from the
+// persistent connection's read loop.
+type streamFuture struct {
+	callID uuid.UUID
+	w      ggio.Writer
+	frames chan *pb.PersistentConnectionResponse
+	// behind is closed once frames overflowed; the responses received after
+	// that are dropped
+	behind chan struct{}
+	// fellBehind is only used by the read loop
+	fellBehind bool
+	// done is set to nil once the stream is cancelled
+	done <-chan struct{}
+}
+
+// newStreamFuture registers a stream call, whose responses are received by
+// next until ctx is done.
+func (c *Client) newStreamFuture(ctx context.Context, callID uuid.UUID, w ggio.Writer) *streamFuture {
+	f := &streamFuture{
+		callID: callID,
+		w:      w,
+		frames: make(chan *pb.PersistentConnectionResponse, StreamBufferSize),
+		behind: make(chan struct{}),
+		done:   ctx.Done(),
+	}
+	c.streamFutures.Store(callID, f)
+	return f
+}
+
+// releaseStream unregisters the stream once it ended, unless it fell behind:
+// the read loop then unregisters it once the daemon ends it.
+func (c *Client) releaseStream(f *streamFuture) {
+	select {
+	case <-f.behind:
+	default:
+		c.streamFutures.Delete(f.callID)
+	}
+}
+
+// next returns the next response of the stream. Once ctx is done, it asks the
+// daemon to cancel the stream and keeps returning responses until the daemon
+// confirms it. It returns ErrStreamFellBehind once the stream fell behind and
+// the responses received before were all returned.
+func (f *streamFuture) next() (*pb.PersistentConnectionResponse, error) {
+	for {
+		select {
+		case resp := <-f.frames:
+			return resp, nil
+
+		case <-f.behind:
+			// nothing is added to frames once behind is closed
+			select {
+			case resp := <-f.frames:
+				return resp, nil
+			default:
+				return nil, ErrStreamFellBehind
+			}
+
+		case <-f.done:
+			// keep reading until the daemon confirms the cancellation
+			f.done = nil
+			f.cancel()
+		}
+	}
+}
+
+func (f *streamFuture) cancel() {
+	f.w.WriteMsg(
+		&pb.PersistentConnectionRequest{
+			CallId:  f.callID[:],
+			Message: &pb.PersistentConnectionRequest_Cancel{Cancel: &pb.Cancel{}},
+		},
+	)
+}
+
+// deliverStreamResponse passes resp to the stream f without waiting, which
+// must be called from the read loop. The stream is cancelled if its buffer is
+// full.
+func (c *Client) deliverStreamResponse(f *streamFuture, resp *pb.PersistentConnectionResponse) {
+	last := resp.GetDaemonError() != nil || resp.GetCancel() != nil || resp.GetEndOfStream() != nil
+
+	if !f.fellBehind {
+		select {
+		case f.frames <- resp:
+			return
+		default:
+		}
+
+		log.Warnw("stream reader fell behind; cancelling the stream", "callID", f.callID)
+		f.fellBehind = true
+		close(f.behind)
+		if !last {
+			f.cancel()
+		}
+	}
+
+	if last {
+		c.streamFutures.Delete(f.callID)
+	}
+}
@@ -87,6 +87,14 @@ func readMsgSafe(c *byteReaderConn, msg proto.Message) error {
 // NewStream initializes a new stream on one of the protocols in protos with
 // the specified peer.
 func (c *Client) NewStream(peer peer.ID, protos []string) (*StreamInfo, io.ReadWriteCloser, error) {
+	info, control, err := c.openStream(peer, protos, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	return info, control, nil
+}
+
+func (c *Client) openStream(peer peer.ID, protos []string, framed bool) (*StreamInfo, *byteReaderConn, error) {
 	controlconn, err := c.newControlConn()
 	if err != nil {
 		return nil, nil, err
@@ -101,6 +109,9 @@ func (c *Client) NewStream(peer peer.ID, protos []string) (*StreamInfo, io.ReadW
 			Proto: protos,
 		},
 	}
+	if framed {
+		req.StreamOpen.Framed = &framed
+	}
 
 	if err = w.WriteMsg(req); err != nil {
 		control.Close()
@@ -114,10 +125,12 @@ func (c *Client) NewStream(peer peer.ID, protos []string) (*StreamInfo, io.ReadW
 		return nil, nil, err
 	}
 	if err := resp.GetError(); err != nil {
+		control.Close()
 		return nil, nil, fmt.Errorf("error from daemon: %s", err.GetMsg())
 	}
 	info, err := convertStreamInfo(resp.GetStreamInfo())
 	if err != nil {
+		control.Close()
 		return nil, nil, fmt.Errorf("parsing stream info: %s", err)
 	}
 
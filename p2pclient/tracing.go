@@ -0,0 +1,41 @@
+package p2pclient
+
+import (
+	"go.opentelemetry.io/otel/propagation"
+
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// tracePropagator passes the W3C trace context of the context of unary calls
+// on to the daemon, and from the daemon to the context of unary handlers, so
+// that traces span the clients and daemons on both ends.
+var tracePropagator = propagation.TraceContext{}
+
+// callTraceCarrier carries a trace context in the trace fields of a unary
+// call request.
+type callTraceCarrier struct {
+	call *pb.CallUnaryRequest
+}
+
+func (c callTraceCarrier) Get(key string) string {
+	switch key {
+	case "traceparent":
+		return c.call.GetTraceParent()
+	case "tracestate":
+		return c.call.GetTraceState()
+	}
+	return ""
+}
+
+func (c callTraceCarrier) Set(key, value string) {
+	switch key {
+	case "traceparent":
+		c.call.TraceParent = &value
+	case "tracestate":
+		c.call.TraceState = &value
+	}
+}
+
+func (c callTraceCarrier) Keys() []string {
+	return []string{"traceparent", "tracestate"}
+}
@@ -2,7 +2,9 @@ package p2pclient
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/libp2p/go-libp2p-core/network"
@@ -14,6 +16,10 @@ import (
 	ggio "github.com/gogo/protobuf/io"
 )
 
+// ErrPersistentConnClosed is returned by calls made over the persistent
+// connection that were still waiting for a response when it was lost.
+var ErrPersistentConnClosed = errors.New("persistent connection closed")
+
 type persistentConnectionResponseFuture chan *pb.PersistentConnectionResponse
 
 type UnaryHandlerFunc func(context.Context, []byte) ([]byte, error)
@@ -32,20 +38,82 @@ func (u UnaryHandlerFunc) handle(ctx context.Context, w ggio.Writer, req *pb.Per
 		}
 	}
 
-	w.WriteMsg(
+	utils.WriteChunkedRequest(w,
 		&pb.PersistentConnectionRequest{
 			CallId: req.CallId,
 			Message: &pb.PersistentConnectionRequest_UnaryResponse{
 				UnaryResponse: response,
 			},
 		},
+		UnaryChunkSize,
 	)
 }
 
+// pendingPayload is a message from the daemon whose payload follows in
+// chunks.
+type pendingPayload struct {
+	resp     *pb.PersistentConnectionResponse
+	assembly *utils.ChunkAssembly
+}
+
+// reassemblePayload keeps track of the messages whose payload follows in
+// chunks in chunked, by call id. It returns resp as is unless it is such a
+// message or a chunk, and the message with its full payload once its last
+// chunk is received; it returns nil otherwise. A payload that can't be
+// reassembled fails the call, with a daemon error for a call of ours and an
+// error response for an incoming one.
+func reassemblePayload(chunked map[uuid.UUID]pendingPayload, callID uuid.UUID, resp *pb.PersistentConnectionResponse, w ggio.Writer) *pb.PersistentConnectionResponse {
+	fail := func(header *pb.PersistentConnectionResponse, err error) *pb.PersistentConnectionResponse {
+		log.Debugw("error reassembling chunked payload", "callID", callID, "error", err)
+		if header.GetRequestHandling() != nil {
+			w.WriteMsg(makeErrPayloadMsg(header.CallId, err))
+			return nil
+		}
+		return makeDaemonErrorMsg(header.CallId, err)
+	}
+
+	chunk := resp.GetChunk()
+	if chunk == nil {
+		assembly, err := utils.NewChunkAssembly(resp, MaxChunkedPayloadSize)
+		if err != nil {
+			return fail(resp, err)
+		}
+		if assembly == nil {
+			return resp
+		}
+		chunked[callID] = pendingPayload{resp, assembly}
+		return nil
+	}
+
+	pending, found := chunked[callID]
+	if !found {
+		log.Debugw("dropping payload chunk of unknown call", "callID", callID)
+		return nil
+	}
+	msg, err := pending.assembly.Add(chunk)
+	if err != nil {
+		delete(chunked, callID)
+		return fail(pending.resp, err)
+	}
+	if msg == nil {
+		return nil
+	}
+	delete(chunked, callID)
+	return msg.(*pb.PersistentConnectionResponse)
+}
+
 func (c *Client) run(r ggio.Reader, w ggio.Writer) {
+	defer close(c.persistentConnDone)
+
+	// callID -> the message whose payload is still being received in chunks
+	chunked := make(map[uuid.UUID]pendingPayload)
+
 	for {
 		var resp pb.PersistentConnectionResponse
-		r.ReadMsg(&resp)
+		if err := r.ReadMsg(&resp); err != nil {
+			log.Debugw("error reading from persistent connection", "error", err)
+			return
+		}
 
 		callID, err := uuid.FromBytes(resp.CallId)
 		if err != nil {
@@ -53,32 +121,89 @@ func (c *Client) run(r ggio.Reader, w ggio.Writer) {
 			continue
 		}
 
+		if dErr := resp.GetDaemonError(); dErr != nil && callID == uuid.Nil {
+			// about the connection rather than a call, e.g. before the
+			// daemon closes it
+			log.Errorw("persistent connection error", "error", dErr.GetMessage(), "code", dErr.GetCode())
+			continue
+		}
+
+		if resp.GetDaemonError().GetCode() == pb.DaemonError_CALL_NOT_AWAITED {
+			// about a response of ours to an incoming call, which nothing
+			// here waits on
+			log.Debugw("incoming call no longer awaited its response", "callID", callID)
+			continue
+		}
+
+		// payload chunks are received in order, the message they belong to
+		// being handled once they are all received
+		msg := reassemblePayload(chunked, callID, &resp, w)
+		if msg == nil {
+			continue
+		}
+		resp = *msg
+
+		if f, found := c.streamFutures.Load(callID); found {
+			c.deliverStreamResponse(f.(*streamFuture), &resp)
+			continue
+		}
+
 		switch resp.Message.(type) {
 		case *pb.PersistentConnectionResponse_RequestHandling:
 			proto := protocol.ID(*resp.GetRequestHandling().Proto)
 
 			h, found := c.unaryHandlers.Load(proto)
+			if !found {
+				// e.g. a call that reached the daemon as the handler was
+				// being removed
+				w.WriteMsg(makeErrProtoNotFoundMsg(resp.CallId, string(proto)))
+				continue
+			}
 			handler, ok := h.(UnaryHandlerFunc)
 			if !ok {
 				log.Fatal("could not load handler for %s: failed to cast it to unary handler\n", proto)
 				return
 			}
 
-			if !found {
-				w.WriteMsg(makeErrProtoNotFoundMsg(resp.CallId, string(proto)))
-			}
-
 			go func() {
 				ctx, cancel := context.WithCancel(context.Background())
 				defer cancel()
+				ctx = tracePropagator.Extract(ctx, callTraceCarrier{resp.GetRequestHandling()})
 				handler.handle(ctx, w, &resp)
 			}()
 
-		case *pb.PersistentConnectionResponse_DaemonError, *pb.PersistentConnectionResponse_CallUnaryResponse, *pb.PersistentConnectionResponse_Cancel, nil:
-			go func() {
-				rC, _ := c.callFutures.LoadOrStore(callID, make(persistentConnectionResponseFuture))
-				rC.(persistentConnectionResponseFuture) <- &resp
-			}()
+		case *pb.PersistentConnectionResponse_ValidateMessage:
+			go c.validateMessage(w, resp.CallId, resp.GetValidateMessage())
+
+		case *pb.PersistentConnectionResponse_IncomingStream:
+			c.acceptFramedStream(callID, resp.GetIncomingStream())
+
+		case *pb.PersistentConnectionResponse_StreamFrame:
+			// delivered synchronously to preserve the order of the stream
+			c.deliverFrame(callID, resp.GetStreamFrame())
+
+		case *pb.PersistentConnectionResponse_Ping:
+			w.WriteMsg(
+				&pb.PersistentConnectionRequest{
+					CallId:  resp.CallId,
+					Message: &pb.PersistentConnectionRequest_Pong{Pong: &pb.Pong{}},
+				},
+			)
+
+		case *pb.PersistentConnectionResponse_DaemonError, *pb.PersistentConnectionResponse_CallUnaryResponse, *pb.PersistentConnectionResponse_Cancel, *pb.PersistentConnectionResponse_Stats, *pb.PersistentConnectionResponse_CancelAll, nil:
+			// futures hold a single response, so delivering one doesn't wait
+			// for the call to read it
+			rC, _ := c.callFutures.LoadOrStore(callID, make(persistentConnectionResponseFuture, 1))
+			if rC.(persistentConnectionResponseFuture) == nil {
+				// the call no longer waits for it
+				c.callFutures.Delete(callID)
+				continue
+			}
+			select {
+			case rC.(persistentConnectionResponseFuture) <- &resp:
+			default:
+				log.Debugw("dropping extra response to call", "callID", callID)
+			}
 		}
 	}
 
@@ -95,6 +220,7 @@ func (c *Client) getPersistentWriter() ggio.WriteCloser {
 			w := utils.NewSafeWriter(ggio.NewDelimitedWriter(conn))
 			w.WriteMsg(&pb.Request{Type: pb.Request_PERSISTENT_CONN_UPGRADE.Enum()})
 			c.persistentConnWriter = w
+			c.persistentConnDone = make(chan struct{})
 
 			r := ggio.NewDelimitedReader(conn, network.MessageSizeMax)
 
@@ -104,7 +230,10 @@ func (c *Client) getPersistentWriter() ggio.WriteCloser {
 			}
 
 			if *msg.Type != pb.Response_OK {
-				panic("failed to open persistent connection")
+				panic(fmt.Sprintf("failed to open persistent connection: %s", msg.GetError().GetMsg()))
+			}
+			if id, err := uuid.FromBytes(msg.GetInstanceId()); err == nil {
+				c.daemonInstanceID = id
 			}
 
 			go c.run(r, c.persistentConnWriter)
@@ -115,11 +244,44 @@ func (c *Client) getPersistentWriter() ggio.WriteCloser {
 	return c.persistentConnWriter
 }
 
-func (c *Client) getResponse(callID uuid.UUID) (*pb.PersistentConnectionResponse, error) {
-	rc, _ := c.callFutures.LoadOrStore(callID, make(persistentConnectionResponseFuture))
-	defer c.callFutures.Delete(callID)
+// DaemonInstanceID returns the id the daemon reported when the persistent
+// connection was opened, opening it if needed; it is uuid.Nil if the daemon
+// doesn't report one. The id changes when the daemon restarts, losing the
+// handlers added over persistent connections, so a client connecting again
+// can compare it with the previous one to tell it must add them again.
+func (c *Client) DaemonInstanceID() uuid.UUID {
+	c.getPersistentWriter()
+	return c.daemonInstanceID
+}
+
+// getResponse waits for the response to the call with callID, until ctx is
+// done or the persistent connection is lost.
+func (c *Client) getResponse(ctx context.Context, callID uuid.UUID) (*pb.PersistentConnectionResponse, error) {
+	rc, _ := c.callFutures.LoadOrStore(callID, make(persistentConnectionResponseFuture, 1))
+	future := rc.(persistentConnectionResponseFuture)
 
-	response := <-rc.(persistentConnectionResponseFuture)
+	var response *pb.PersistentConnectionResponse
+	select {
+	case response = <-future:
+		c.callFutures.Delete(callID)
+	case <-ctx.Done():
+		select {
+		case <-future:
+			c.callFutures.Delete(callID)
+		default:
+			// the response may still come, and is dropped then
+			c.callFutures.Store(callID, persistentConnectionResponseFuture(nil))
+		}
+		return nil, ctx.Err()
+	case <-c.persistentConnDone:
+		c.callFutures.Delete(callID)
+		// the response may have been among the last messages received
+		select {
+		case response = <-future:
+		default:
+			return nil, ErrPersistentConnClosed
+		}
+	}
 	if dErr := response.GetDaemonError(); dErr != nil {
 		return nil, newDaemonError(dErr)
 	}
@@ -128,22 +290,52 @@ func (c *Client) getResponse(callID uuid.UUID) (*pb.PersistentConnectionResponse
 }
 
 func (c *Client) AddUnaryHandler(proto protocol.ID, handler UnaryHandlerFunc) error {
+	return c.AddUnaryHandlerWithOptions(proto, handler, UnaryHandlerOptions{})
+}
+
+// UnaryHandlerOptions controls how the daemon dispatches incoming calls to a
+// unary handler.
+type UnaryHandlerOptions struct {
+	// MaxConcurrentCalls caps the number of calls handled at once; zero means
+	// no limit.
+	MaxConcurrentCalls int
+	// BlockOnLimit queues calls past MaxConcurrentCalls instead of resetting
+	// their streams.
+	BlockOnLimit bool
+	// Replace takes over the protocol if another handler is registered for
+	// it, e.g. by this client before it reconnected.
+	Replace bool
+}
+
+func (c *Client) AddUnaryHandlerWithOptions(proto protocol.ID, handler UnaryHandlerFunc, opts UnaryHandlerOptions) error {
 	w := c.getPersistentWriter()
 
 	callID := uuid.New()
 
+	req := &pb.AddUnaryHandlerRequest{
+		Proto: (*string)(&proto),
+	}
+	if opts.MaxConcurrentCalls != 0 {
+		maxCalls := int32(opts.MaxConcurrentCalls)
+		req.MaxConcurrentCalls = &maxCalls
+	}
+	if opts.BlockOnLimit {
+		req.OverflowPolicy = pb.AddUnaryHandlerRequest_BLOCK.Enum()
+	}
+	if opts.Replace {
+		req.Replace = &opts.Replace
+	}
+
 	w.WriteMsg(
 		&pb.PersistentConnectionRequest{
 			CallId: callID[:],
 			Message: &pb.PersistentConnectionRequest_AddUnaryHandler{
-				AddUnaryHandler: &pb.AddUnaryHandlerRequest{
-					Proto: (*string)(&proto),
-				},
+				AddUnaryHandler: req,
 			},
 		},
 	)
 
-	if _, err := c.getResponse(callID); err != nil {
+	if _, err := c.getResponse(context.Background(), callID); err != nil {
 		return err
 	}
 
@@ -152,6 +344,35 @@ func (c *Client) AddUnaryHandler(proto protocol.ID, handler UnaryHandlerFunc) er
 	return nil
 }
 
+// RemoveUnaryHandler removes the handler of proto added by this client,
+// leaving its other handlers alone. Calls already handed to the handler are
+// still answered.
+func (c *Client) RemoveUnaryHandler(proto protocol.ID) error {
+	w := c.getPersistentWriter()
+
+	callID := uuid.New()
+
+	w.WriteMsg(
+		&pb.PersistentConnectionRequest{
+			CallId: callID[:],
+			Message: &pb.PersistentConnectionRequest_RemoveUnaryHandler{
+				RemoveUnaryHandler: &pb.RemoveUnaryHandlerRequest{Proto: (*string)(&proto)},
+			},
+		},
+	)
+
+	if _, err := c.getResponse(context.Background(), callID); err != nil {
+		return err
+	}
+
+	c.unaryHandlers.Delete(proto)
+
+	return nil
+}
+
+// CallUnaryHandler calls the unary handler of proto on a peer. The trace
+// context of ctx, if any, is passed on to the daemons on both ends and to the
+// context of the handler.
 func (c *Client) CallUnaryHandler(
 	ctx context.Context,
 	peerID peer.ID,
@@ -173,18 +394,32 @@ func (c *Client) CallUnaryHandler(
 		return nil, err
 	}
 
+	callUnary := &pb.CallUnaryRequest{
+		Peer:  pid,
+		Proto: (*string)(&proto),
+		Data:  payload,
+	}
+
+	// let the daemon enforce the deadline as well, so the call doesn't
+	// outlive the context even if the cancel message is never received
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout := time.Until(deadline).Milliseconds()
+		if timeout <= 0 {
+			return nil, context.DeadlineExceeded
+		}
+		callUnary.TimeoutMs = &timeout
+	}
+	tracePropagator.Inject(ctx, callTraceCarrier{callUnary})
+
 	done := make(chan struct{})
-	w.WriteMsg(
+	utils.WriteChunkedRequest(w,
 		&pb.PersistentConnectionRequest{
 			CallId: cid,
 			Message: &pb.PersistentConnectionRequest_CallUnary{
-				CallUnary: &pb.CallUnaryRequest{
-					Peer:  pid,
-					Proto: (*string)(&proto),
-					Data:  payload,
-				},
+				CallUnary: callUnary,
 			},
 		},
+		UnaryChunkSize,
 	)
 
 	go func() {
@@ -203,13 +438,17 @@ func (c *Client) CallUnaryHandler(
 		}
 	}()
 
-	response, err := c.getResponse(callID)
+	response, err := c.getResponse(ctx, callID)
 	if err != nil {
 		return nil, err
 	}
 
 	if response.GetCancel() != nil {
-		return nil, ctx.Err()
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		// cancelled on the daemon side, e.g. by CancelAllCalls
+		return nil, context.Canceled
 	}
 
 	result := response.GetCallUnaryResponse()
@@ -225,12 +464,174 @@ func (c *Client) CallUnaryHandler(
 	}
 }
 
+// PersistentConnStats describes the unary handlers registered on the daemon
+// and the calls it currently has in flight.
+type PersistentConnStats struct {
+	// UnaryHandlers maps each registered protocol to whether it is enabled.
+	UnaryHandlers map[protocol.ID]bool
+	// ActiveCalls is the number of outgoing calls still in progress.
+	ActiveCalls int
+	// PendingResponses is the number of incoming calls awaiting a response
+	// from a client.
+	PendingResponses int
+}
+
+// GetPersistentConnStats queries the daemon for its registered unary handlers
+// and in-flight calls. It is cheap enough to be used as a liveness check.
+func (c *Client) GetPersistentConnStats() (*PersistentConnStats, error) {
+	w := c.getPersistentWriter()
+
+	callID := uuid.New()
+
+	if err := w.WriteMsg(
+		&pb.PersistentConnectionRequest{
+			CallId:  callID[:],
+			Message: &pb.PersistentConnectionRequest_GetStats{GetStats: &pb.GetStatsRequest{}},
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	response, err := c.getResponse(context.Background(), callID)
+	if err != nil {
+		return nil, err
+	}
+
+	pbStats := response.GetStats()
+	stats := &PersistentConnStats{
+		UnaryHandlers:    make(map[protocol.ID]bool, len(pbStats.GetUnaryHandlers())),
+		ActiveCalls:      int(pbStats.GetActiveCalls()),
+		PendingResponses: int(pbStats.GetPendingResponses()),
+	}
+	for _, h := range pbStats.GetUnaryHandlers() {
+		stats.UnaryHandlers[protocol.ID(h.GetProto())] = h.GetEnabled()
+	}
+
+	return stats, nil
+}
+
+// CancelAllCalls cancels every call made on this client's persistent
+// connection that is still in flight, and returns how many were cancelled.
+// Calls made by other clients of the daemon are left alone.
+func (c *Client) CancelAllCalls() (int, error) {
+	w := c.getPersistentWriter()
+
+	callID := uuid.New()
+
+	if err := w.WriteMsg(
+		&pb.PersistentConnectionRequest{
+			CallId:  callID[:],
+			Message: &pb.PersistentConnectionRequest_CancelAll{CancelAll: &pb.CancelAll{}},
+		},
+	); err != nil {
+		return 0, err
+	}
+
+	response, err := c.getResponse(context.Background(), callID)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(response.GetCancelAll().GetCancelled()), nil
+}
+
+// StreamResponse is a single message received from a remote stream handler.
+// Exactly one of Data and Err is set.
+type StreamResponse struct {
+	Data []byte
+	Err  error
+}
+
+// CallStreamHandler calls a remote handler which may reply with several
+// messages. The replies are delivered in order on the returned channel, which
+// is closed once the remote handler finishes or ctx is cancelled. If the call
+// fails, the error is delivered as the last StreamResponse.
+func (c *Client) CallStreamHandler(
+	ctx context.Context,
+	peerID peer.ID,
+	proto protocol.ID,
+	payload []byte,
+) (<-chan *StreamResponse, error) {
+
+	w := c.getPersistentWriter()
+
+	callID := uuid.New()
+
+	frames := c.newStreamFuture(ctx, callID, w)
+
+	if err := utils.WriteChunkedRequest(w,
+		&pb.PersistentConnectionRequest{
+			CallId: callID[:],
+			Message: &pb.PersistentConnectionRequest_CallStream{
+				CallStream: &pb.CallUnaryRequest{
+					Peer:  []byte(peerID),
+					Proto: (*string)(&proto),
+					Data:  payload,
+				},
+			},
+		},
+		UnaryChunkSize,
+	); err != nil {
+		c.streamFutures.Delete(callID)
+		return nil, err
+	}
+
+	out := make(chan *StreamResponse)
+	go func() {
+		defer close(out)
+		defer c.releaseStream(frames)
+
+		deliver := func(r *StreamResponse) {
+			select {
+			case out <- r:
+			case <-ctx.Done():
+			}
+		}
+
+		for {
+			frame, err := frames.next()
+			if err != nil {
+				deliver(&StreamResponse{Err: err})
+				return
+			}
+
+			switch {
+			case frame.GetDaemonError() != nil:
+				deliver(&StreamResponse{Err: newDaemonError(frame.GetDaemonError())})
+				return
+
+			case frame.GetCancel() != nil:
+				return
+
+			case frame.GetEndOfStream() != nil:
+				return
+
+			case len(frame.GetCallUnaryResponse().GetError()) != 0:
+				deliver(&StreamResponse{Err: newP2PHandlerError(frame.GetCallUnaryResponse())})
+
+			default:
+				deliver(&StreamResponse{Data: frame.GetCallUnaryResponse().GetResponse()})
+			}
+		}
+	}()
+
+	return out, nil
+}
+
 func newDaemonError(dErr *pb.DaemonError) error {
-	return &DaemonError{message: *dErr.Message}
+	return &DaemonError{message: dErr.GetMessage(), code: dErr.GetCode()}
 }
 
 type DaemonError struct {
 	message string
+	code    pb.DaemonError_Code
+}
+
+// Code classifies the failure, e.g. to tell whether the call can be retried.
+// It is UNKNOWN for failures without a specific code, and for daemons that
+// don't report codes.
+func (de *DaemonError) Code() pb.DaemonError_Code {
+	return de.code
 }
 
 func (de *DaemonError) Error() string {
@@ -261,3 +662,28 @@ func makeErrProtoNotFoundMsg(callID []byte, proto string) *pb.PersistentConnecti
 		},
 	}
 }
+
+func makeErrPayloadMsg(callID []byte, err error) *pb.PersistentConnectionRequest {
+	return &pb.PersistentConnectionRequest{
+		CallId: callID,
+		Message: &pb.PersistentConnectionRequest_UnaryResponse{
+			UnaryResponse: &pb.CallUnaryResponse{
+				Result: &pb.CallUnaryResponse_Error{Error: []byte(err.Error())},
+			},
+		},
+	}
+}
+
+func makeDaemonErrorMsg(callID []byte, err error) *pb.PersistentConnectionResponse {
+	code := pb.DaemonError_UNKNOWN
+	if errors.As(err, new(utils.PayloadTooLargeError)) {
+		code = pb.DaemonError_MESSAGE_TOO_LARGE
+	}
+	msg := err.Error()
+	return &pb.PersistentConnectionResponse{
+		CallId: callID,
+		Message: &pb.PersistentConnectionResponse_DaemonError{
+			DaemonError: &pb.DaemonError{Message: &msg, Code: &code},
+		},
+	}
+}
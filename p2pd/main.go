@@ -3,6 +3,7 @@ package main
 import (
 	"bufio"
 	"context"
+	cryptotls "crypto/tls"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -10,30 +11,66 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"os/user"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/metrics"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	autorelay "github.com/libp2p/go-libp2p/p2p/host/relay"
 
+	logging "github.com/ipfs/go-log/v2"
 	relay "github.com/libp2p/go-libp2p-circuit"
-	connmgr "github.com/libp2p/go-libp2p-connmgr"
 	p2pd "github.com/libp2p/go-libp2p-daemon"
 	config "github.com/libp2p/go-libp2p-daemon/config"
 	noise "github.com/libp2p/go-libp2p-noise"
 	ps "github.com/libp2p/go-libp2p-pubsub"
 	quic "github.com/libp2p/go-libp2p-quic-transport"
 	tls "github.com/libp2p/go-libp2p-tls"
+	tcp "github.com/libp2p/go-tcp-transport"
+	ws "github.com/libp2p/go-ws-transport"
 	multiaddr "github.com/multiformats/go-multiaddr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	promhttp "github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
 
-	_ "net/http/pprof"
+	httppprof "net/http/pprof"
 )
 
-func pprofHTTP(port int) {
+var privateNetwork = promauto.NewGauge(prometheus.GaugeOpts{
+	Namespace: "p2pd",
+	Name:      "private_network",
+	Help:      "Set to 1 when the host only connects to peers sharing its pre-shared key",
+})
+
+// newPProfMux returns a mux serving the pprof handlers under /debug/pprof/,
+// rather than relying on the ones net/http/pprof adds to the default mux.
+func newPProfMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", httppprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", httppprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", httppprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", httppprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", httppprof.Trace)
+	return mux
+}
+
+func pprofHTTP(port int, handler http.Handler) {
 	listen := func(p int) error {
 		addr := fmt.Sprintf("localhost:%d", p)
 		log.Printf("registering pprof debug http handler at: http://%s/debug/pprof/\n", addr)
-		switch err := http.ListenAndServe(addr, nil); err {
+		switch err := http.ListenAndServe(addr, handler); err {
 		case nil:
 			// all good, server is running and exited normally.
 			return nil
@@ -61,12 +98,319 @@ func pprofHTTP(port int) {
 	}
 }
 
+// writeStartupInfo writes the daemon's startup info to stdout if path is -,
+// or to path otherwise. The file is renamed into place, so that clients
+// waiting for it never read a partial line.
+func writeStartupInfo(d *p2pd.Daemon, path string) error {
+	if path == "-" {
+		return d.WriteStartupInfo(os.Stdout)
+	}
+
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if err := d.WriteStartupInfo(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// replaceIdentity writes key to path in place of the identity there, e.g.
+// after rotating it.
+func replaceIdentity(key crypto.PrivKey, path string) error {
+	// WriteIdentity leaves the file read-only, so a leftover can't be
+	// truncated
+	tmp := path + ".tmp"
+	if err := os.Remove(tmp); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := p2pd.WriteIdentity(key, tmp); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// sharedPeerstore keeps the persistent peerstore open when a host using it
+// closes, so that the hosts started on identity rotation can use it too; it
+// is closed when the daemon exits.
+type sharedPeerstore struct {
+	peerstore.Peerstore
+}
+
+func (sharedPeerstore) Close() error {
+	return nil
+}
+
+// defaultUserAgent identifies the daemon by the path and version of its main
+// module, like libp2p does, so that forks and releases can be told apart.
+func defaultUserAgent() string {
+	path, version := p2pd.BuildInfo()
+	if path == "" {
+		return "p2pd/0.1"
+	}
+	if version == "" || version == "(devel)" {
+		return path
+	}
+	return fmt.Sprintf("%s@%s", path, version)
+}
+
+// tracingShutdownTimeout bounds how long the spans left are flushed on exit.
+const tracingShutdownTimeout = 5 * time.Second
+
+// setupTracing has the spans of unary calls exported to the OTLP collector of
+// t, returning a function flushing the spans left.
+func setupTracing(t config.Tracing) (func(context.Context) error, error) {
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(t.Endpoint)}
+	if t.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(context.Background(), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(t.SampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String("p2pd"))),
+	)
+	otel.SetTracerProvider(tp)
+	return tp.Shutdown, nil
+}
+
+// setupLogging configures the go-log backend shared by the daemon and libp2p.
+// Settings left empty keep the values go-log picked from the environment,
+// except that the level falls back to error when the format is set.
+func setupLogging(l config.Logging) error {
+	if l.Format == "" {
+		if l.Level == "" {
+			return nil
+		}
+		return logging.SetLogLevel("*", l.Level)
+	}
+
+	cfg := logging.Config{Stderr: true, Level: envLogLevel()}
+	switch l.Format {
+	case config.LogFormatJSON:
+		cfg.Format = logging.JSONOutput
+	case config.LogFormatText:
+		cfg.Format = logging.PlaintextOutput
+	}
+	if l.Level != "" {
+		level, err := logging.LevelFromString(l.Level)
+		if err != nil {
+			return err
+		}
+		cfg.Level = level
+	}
+
+	logging.SetupLogging(cfg)
+	return nil
+}
+
+// envLogLevel returns the level go-log set all loggers to from the environment
+// when the daemon started, which setting up the format would otherwise reset.
+func envLogLevel() logging.LogLevel {
+	lvl := os.Getenv("GOLOG_LOG_LEVEL")
+	if lvl == "" {
+		lvl = os.Getenv("IPFS_LOGGING")
+	}
+	if lvl == "" {
+		return logging.LevelError
+	}
+	level, err := logging.LevelFromString(lvl)
+	if err != nil {
+		return logging.LevelError
+	}
+	return level
+}
+
+// loadIdentity reads the private key from the environment variable idEnv, if
+// set, or else from id, which is either a key file or - for stdin.
+func loadIdentity(id, idEnv string, configStdin bool) (crypto.PrivKey, error) {
+	switch {
+	case idEnv != "" && id != "":
+		return nil, fmt.Errorf("the identity can't be set both from a file and from the environment")
+	case idEnv != "":
+		s, ok := os.LookupEnv(idEnv)
+		if !ok {
+			return nil, fmt.Errorf("environment variable %s is not set", idEnv)
+		}
+		return p2pd.DecodeIdentity(s)
+	case id == "-":
+		if configStdin {
+			return nil, fmt.Errorf("can't read both the config and the identity from stdin")
+		}
+		body, err := ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, err
+		}
+		return p2pd.DecodeIdentity(string(body))
+	case id != "":
+		return p2pd.ReadIdentity(id)
+	default:
+		return nil, nil
+	}
+}
+
+// checkConfig parses the settings the daemon otherwise only parses while
+// starting: the identities, the private network key, the peer lists, the
+// static relays, the provided CIDs, the dial preference, the control TLS
+// files and the owner of the unix sockets.
+func checkConfig(c *config.Config, idEnv string, configStdin bool) error {
+	if _, err := loadIdentity(c.ID, idEnv, configStdin); err != nil {
+		return fmt.Errorf("identity: %w", err)
+	}
+	if _, err := c.PrivateNetwork.LoadKey(); err != nil {
+		return fmt.Errorf("private network key: %w", err)
+	}
+	if _, _, err := c.PeerLists(); err != nil {
+		return err
+	}
+	if _, err := peer.AddrInfosFromP2pAddrs(c.Relay.StaticRelays...); err != nil {
+		return fmt.Errorf("static relays: %w", err)
+	}
+	if _, err := c.Provide.ParseCIDs(); err != nil {
+		return err
+	}
+	if _, err := p2pd.ParseDialPreference(c.Dial.Preference); err != nil {
+		return err
+	}
+	if c.ControlTLS.Enabled() {
+		if _, err := p2pd.NewControlTLSConfig(c.ControlTLS.CAFile, c.ControlTLS.CertFile, c.ControlTLS.KeyFile); err != nil {
+			return err
+		}
+	}
+	extras, err := c.ParseExtraIdentities()
+	if err != nil {
+		return err
+	}
+	for _, id := range extras {
+		if _, err := p2pd.ReadIdentity(id.KeyFile); err != nil {
+			return fmt.Errorf("extra identity %s: %w", id.KeyFile, err)
+		}
+	}
+	if _, _, err := lookupOwner(c.UnixSocket.Owner, c.UnixSocket.Group); err != nil {
+		return fmt.Errorf("unix socket owner: %w", err)
+	}
+	if len(c.Security.Order()) == 0 {
+		return fmt.Errorf("at least one channel security protocol must be enabled")
+	}
+	return nil
+}
+
+// lookupOwner resolves the owner and group of the unix control sockets, given
+// by name or numeric ID; they are -1 if empty.
+func lookupOwner(owner, group string) (uid, gid int, err error) {
+	uid, gid = -1, -1
+	if owner != "" {
+		if uid, err = strconv.Atoi(owner); err != nil {
+			u, err := user.Lookup(owner)
+			if err != nil {
+				return 0, 0, err
+			}
+			if uid, err = strconv.Atoi(u.Uid); err != nil {
+				return 0, 0, fmt.Errorf("user %s has no numeric ID: %w", owner, err)
+			}
+		}
+	}
+	if group != "" {
+		if gid, err = strconv.Atoi(group); err != nil {
+			g, err := user.LookupGroup(group)
+			if err != nil {
+				return 0, 0, err
+			}
+			if gid, err = strconv.Atoi(g.Gid); err != nil {
+				return 0, 0, fmt.Errorf("group %s has no numeric ID: %w", group, err)
+			}
+		}
+	}
+	return uid, gid, nil
+}
+
+// printDaemonInfo prints the control sockets, peer ID and addresses of d.
+func printDaemonInfo(d *p2pd.Daemon) {
+	for _, l := range d.Listeners() {
+		fmt.Printf("Control socket: %s\n", l.Multiaddr().String())
+	}
+	fmt.Printf("Peer ID: %s\n", d.ID().Pretty())
+	fmt.Printf("Peer Addrs:\n")
+	for _, addr := range d.Addrs() {
+		fmt.Printf("%s\n", addr.String())
+	}
+}
+
+func transportOptions(transports []string) []libp2p.Option {
+	opts := make([]libp2p.Option, 0, len(transports))
+	for _, t := range transports {
+		switch t {
+		case config.TransportTCP:
+			opts = append(opts, libp2p.Transport(tcp.NewTCPTransport))
+		case config.TransportQUIC:
+			opts = append(opts, libp2p.Transport(quic.NewTransport))
+		case config.TransportWebsocket:
+			opts = append(opts, libp2p.Transport(ws.New))
+		}
+	}
+	return opts
+}
+
+// defaultListenAddrs returns wildcard listen addrs for the transports; the
+// websocket transport only listens on explicitly configured addrs.
+func defaultListenAddrs(transports []string) []multiaddr.Multiaddr {
+	var addrs []multiaddr.Multiaddr
+	for _, t := range transports {
+		switch t {
+		case config.TransportTCP:
+			addrs = append(addrs,
+				multiaddr.StringCast("/ip4/0.0.0.0/tcp/0"),
+				multiaddr.StringCast("/ip6/::/tcp/0"))
+		case config.TransportQUIC:
+			addrs = append(addrs,
+				multiaddr.StringCast("/ip4/0.0.0.0/udp/0/quic"),
+				multiaddr.StringCast("/ip6/::/udp/0/quic"))
+		}
+	}
+	return addrs
+}
+
+// splitFlag splits a comma separated flag, which is an empty list if empty.
+func splitFlag(s string) []string {
+	if s == "" {
+		return []string{}
+	}
+	return strings.Split(s, ",")
+}
+
+// parseMaddrsFlag parses a comma separated multiaddr flag, which is an empty
+// list if empty.
+func parseMaddrsFlag(field, s string) (config.MaddrArray, error) {
+	if s == "" {
+		return make(config.MaddrArray, 0), nil
+	}
+	return config.ParseMaddrs(field, s)
+}
+
 func main() {
-	maddrString := flag.String("listen", "/unix/tmp/p2pd.sock", "daemon control listen multiaddr")
+	maddrString := flag.String("listen", "/unix/tmp/p2pd.sock", "comma separated list of daemon control listen multiaddrs")
 	quiet := flag.Bool("q", false, "be quiet")
-	id := flag.String("id", "", "peer identity; private key file")
+	logLevel := flag.String("logLevel", "", "Minimum level of the emitted logs (debug, info, warn, error); taken from GOLOG_LOG_LEVEL if empty")
+	logFormat := flag.String("logFormat", "", "Format of the emitted logs (json or text); taken from GOLOG_LOG_FMT if empty")
+	id := flag.String("id", "", "peer identity; private key file, or - to read it base64 encoded from stdin")
+	genKey := flag.String("genKey", "", "generates a private key at this path in the format expected by -id, prints its peer ID and exits")
+	keyType := flag.String("keyType", "ed25519", "type of the key generated by -genKey; ed25519, secp256k1 or rsa")
+	idEnv := flag.String("idEnv", "", "peer identity; environment variable holding the base64 encoded private key")
 	bootstrap := flag.Bool("b", false, "connects to bootstrap peers and bootstraps the dht if enabled")
 	bootstrapPeers := flag.String("bootstrapPeers", "", "comma separated list of bootstrap peers; defaults to the IPFS DHT peers")
+	bootstrapMaxAttempts := flag.Int("bootstrapMaxAttempts", 5, "maximum number of bootstrap attempts; 0 retries until the daemon is closed")
+	bootstrapBackoff := flag.Duration("bootstrapBackoff", time.Second, "delay before the first bootstrap retry")
+	bootstrapBackoffMultiplier := flag.Float64("bootstrapBackoffMultiplier", 2, "factor by which the delay grows after every failed bootstrap attempt")
 	dht := flag.Bool("dht", false, "Enables the DHT in full node mode")
 	dhtClient := flag.Bool("dhtClient", false, "Enables the DHT in client mode")
 	dhtServer := flag.Bool("dhtServer", false, "Enables the DHT in server mode (use 'dht' unless you actually need this)")
@@ -74,7 +418,16 @@ func main() {
 	connMgrLo := flag.Int("connLo", 256, "Connection Manager Low Water mark")
 	connMgrHi := flag.Int("connHi", 512, "Connection Manager High Water mark")
 	connMgrGrace := flag.Duration("connGrace", 120*time.Second, "Connection Manager grace period (in seconds)")
-	QUIC := flag.Bool("quic", true, "Enables the QUIC transport")
+	allowedPeers := flag.String("allowedPeers", "", "comma separated list of peer IDs connections are restricted to; all peers are allowed if empty")
+	blockedPeers := flag.String("blockedPeers", "", "comma separated list of peer IDs connections are never allowed with")
+	extraIdentities := flag.String("extraIdentities", "", "comma separated list of more peers to run in this process, "+
+		"each as a key file and a control listen multiaddr separated by =, e.g. key2=/unix/tmp/p2pd2.sock")
+	inboundRateLimit := flag.Bool("inboundRateLimit", false, "Limits the rate of inbound connections from each remote IP")
+	inboundRateLimitConns := flag.Int("inboundRateLimitConns", 16, "Number of inbound connections allowed from a remote IP per window")
+	inboundRateLimitWindow := flag.Duration("inboundRateLimitWindow", time.Minute, "Window of the inbound connection rate limit")
+	inboundRateLimitBurst := flag.Int("inboundRateLimitBurst", 32, "Number of inbound connections allowed in a row from a remote IP")
+	QUIC := flag.Bool("quic", true, "Enables the QUIC transport; deprecated, use -transports")
+	transports := flag.String("transports", "", "comma separated list of enabled transports (tcp, quic, websocket); defaults to all of them")
 	natPortMap := flag.Bool("natPortMap", false, "Enables NAT port mapping")
 	pubsub := flag.Bool("pubsub", false, "Enables pubsub")
 	pubsubRouter := flag.String("pubsubRouter", "gossipsub", "Specifies the pubsub router implementation")
@@ -82,35 +435,139 @@ func main() {
 	pubsubSignStrict := flag.Bool("pubsubSignStrict", true, "Enables or disables pubsub strict signature verification")
 	gossipsubHeartbeatInterval := flag.Duration("gossipsubHeartbeatInterval", 0, "Specifies the gossipsub heartbeat interval")
 	gossipsubHeartbeatInitialDelay := flag.Duration("gossipsubHeartbeatInitialDelay", 0, "Specifies the gossipsub initial heartbeat delay")
-	relayEnabled := flag.Bool("relay", true, "Enables circuit relay")
-	relayActive := flag.Bool("relayActive", false, "Enables active mode for relay")
-	relayHop := flag.Bool("relayHop", false, "Enables hop for relay")
-	relayHopLimit := flag.Int("relayHopLimit", 0, "Sets the hop limit for hop relays")
+	gossipsubD := flag.Int("gossipsubD", 0, "Specifies the gossipsub mesh degree; the gossipsub default if zero")
+	gossipsubDlo := flag.Int("gossipsubDlo", 0, "Specifies the lower bound of the gossipsub mesh degree; the gossipsub default if zero")
+	gossipsubDhi := flag.Int("gossipsubDhi", 0, "Specifies the upper bound of the gossipsub mesh degree; the gossipsub default if zero")
+	gossipsubDout := flag.Int("gossipsubDout", 0, "Specifies the outbound connection quota of gossipsub meshes; the gossipsub default if zero")
+	gossipsubDlazy := flag.Int("gossipsubDlazy", 0, "Specifies the minimum number of peers gossipsub gossips to; the gossipsub default if zero")
+	gossipsubHistoryLength := flag.Int("gossipsubHistoryLength", 0, "Specifies the number of heartbeats gossipsub caches messages for; the gossipsub default if zero")
+	gossipsubHistoryGossip := flag.Int("gossipsubHistoryGossip", 0, "Specifies the number of heartbeats gossipsub advertises cached messages for; the gossipsub default if zero")
+	pubsubMessageID := flag.String("pubsubMessageID", "author-seqno", "Specifies how pubsub messages are identified for deduplication: author-seqno, content or prefix")
+	pubsubMessageIDPrefix := flag.Int("pubsubMessageIDPrefix", 0, "Specifies the number of leading data bytes identifying pubsub messages with the prefix message ID scheme")
+	relayEnabled := flag.Bool("relay", true, "Enables circuit relay, dialing and accepting relayed connections")
+	relayActive := flag.Bool("relayActive", false, "Makes the relay service dial the peers it relays to; requires relayHop")
+	relayHop := flag.Bool("relayHop", false, "Enables the relay service, relaying connections for other peers; the node is a relay client only otherwise")
+	relayHopLimit := flag.Int("relayHopLimit", 0, "Sets the hop limit for hop relays; requires relayHop")
 	relayDiscovery := flag.Bool("relayDiscovery", false, "Enables passive discovery for relay")
 	autoRelay := flag.Bool("autoRelay", false, "Enables autorelay")
-	autonat := flag.Bool("autonat", false, "Enables the AutoNAT service")
-	hostAddrs := flag.String("hostAddrs", "", "comma separated list of multiaddrs the host should listen on")
+	staticRelays := flag.String("staticRelays", "", "comma separated list of relays for autorelay to use instead of discovering them through the DHT")
+	desiredRelays := flag.Int("desiredRelays", 1, "Number of relays autorelay uses at once")
+	relayRefreshInterval := flag.Duration("relayRefreshInterval", 0, "How often autorelay looks for relays again while it uses fewer than desiredRelays; disabled if zero")
+	relayReconnectBackoff := flag.Duration("relayReconnectBackoff", 5*time.Second, "Delay before retrying to connect to a static relay, doubling after every failure")
+	relayReconnectMaxBackoff := flag.Duration("relayReconnectMaxBackoff", 5*time.Minute, "Maximum delay between attempts to connect to a static relay")
+	autonat := flag.Bool("autonat", false, "Enables the AutoNAT service, answering reachability probes from other peers; it doesn't enable hole punching")
+	hostAddrs := flag.String("hostAddrs", "", "comma separated list of multiaddrs the host should listen on; /ws addresses need the websocket transport")
 	announceAddrs := flag.String("announceAddrs", "", "comma separated list of multiaddrs the host should announce to the network")
+	announceTemplates := flag.String("announceTemplates", "", "comma separated list of multiaddrs the host should announce with {PORT} replaced by the port it listens on, e.g. /ip4/1.2.3.4/tcp/{PORT}")
+	addressFilter := flag.String("addressFilter", "", "Restricts the listen and announced addresses to ip4 or ip6 ones; both lets either through")
 	noListen := flag.Bool("noListenAddrs", false, "sets the host to listen on no addresses")
 	metricsAddr := flag.String("metricsAddr", "", "an address to bind the metrics handler to")
+	metricsPath := flag.String("metricsPath", "/metrics", "The path metrics are served at")
 	configFilename := flag.String("f", "", "a file from which to read a json representation of the deamon config")
 	configStdin := flag.Bool("i", false, "have the daemon read the json config from stdin")
+	validate := flag.Bool("validate", false, "checks the config resolved from the file or stdin, the environment and the flags, "+
+		"prints it as json and exits without starting the daemon; exits non-zero if it is invalid")
 	pprof := flag.Bool("pprof", false, "Enables the HTTP pprof handler, listening on the first port "+
 		"available in the range [6060-7800], or on the user-provided port via -pprofPort")
 	pprofPort := flag.Uint("pprofPort", 0, "Binds the HTTP pprof handler to a specific port; "+
 		"has no effect unless the pprof option is enabled")
+	pprofServeMetrics := flag.Bool("pprofServeMetrics", false, "Also serves metrics on the HTTP pprof handler's port; "+
+		"has no effect unless the pprof option is enabled")
 	useNoise := flag.Bool("noise", true, "Enables Noise channel security protocol")
 	useTls := flag.Bool("tls", true, "Enables TLS1.3 channel security protocol")
+	pskFile := flag.String("pskFile", "", "Restricts connections to peers sharing the pre-shared key in this swarm.key file; disables QUIC")
+	psk := flag.String("psk", "", "Restricts connections to peers sharing this hex encoded 32-byte pre-shared key; disables QUIC")
+	securityPreference := flag.String("securityPreference", "",
+		"Comma separated list of security protocols (noise, tls), from most to least preferred")
 	forceReachabilityPublic := flag.Bool("forceReachabilityPublic", false, "Set up ForceReachability as public for autonat")
 	forceReachabilityPrivate := flag.Bool("forceReachabilityPrivate", false, "Set up ForceReachability as private for autonat")
 	idleTimeout := flag.Duration("idleTimeout", 0,
 		"Kills the daemon if no client opens a persistent connection in idleTimeout seconds."+
 			" The zero value (default) disables this feature")
+	drainOnTimeout := flag.Bool("drainOnTimeout", false,
+		"On idleTimeout, stop accepting new unary handlers and wait for in-flight calls"+
+			" to finish before shutting down instead of killing the daemon immediately")
+	drainGracePeriod := flag.Duration("drainGracePeriod", 30*time.Second,
+		"Maximum time to wait for in-flight calls to finish when draining; has no effect unless drainOnTimeout is enabled")
+	peerstorePath := flag.String("peerstorePath", "", "Keeps the peerstore in a datastore at this path so it survives restarts; in memory if empty")
+	peerstoreFlushInterval := flag.Duration("peerstoreFlushInterval", time.Minute, "How often the persistent peerstore is flushed to disk")
+	readinessAddr := flag.String("readinessAddr", "", "A dedicated address to bind the readiness probe to; it is also served at /ready on the metrics address")
+	readinessCriterion := flag.String("readinessCriterion", config.ReadyListening,
+		"When the daemon reports ready: listening, bootstrapped or dht")
+	readyInfo := flag.String("readyInfo", "", "Writes a JSON line with the peer ID and addresses once started, "+
+		"to stdout if -, or to this file")
+	httpControl := flag.String("httpControl", "", "An address to bind the JSON over HTTP control gateway to; disabled if empty")
+	httpControlToken := flag.String("httpControlToken", "", "Bearer token required by the HTTP control gateway")
+	socketMode := flag.String("socketMode", "", "Octal file mode of unix control sockets, e.g. 0660; left to the umask if empty")
+	socketOwner := flag.String("socketOwner", "", "User owning unix control sockets, by name or numeric ID")
+	socketGroup := flag.String("socketGroup", "", "Group owning unix control sockets, by name or numeric ID")
+	socketBacklog := flag.Int("socketBacklog", 0, "Listen backlog of unix control sockets; the system default if zero")
+	controlTLSCA := flag.String("controlTLSCA", "", "PEM file of the CA client certificates must be signed by on TCP control listeners; "+
+		"requires -controlTLSCert and -controlTLSKey")
+	controlTLSCert := flag.String("controlTLSCert", "", "PEM file of the certificate presented to clients on TCP control listeners")
+	controlTLSKey := flag.String("controlTLSKey", "", "PEM file of the key of -controlTLSCert")
+	shutdownGracePeriod := flag.Duration("shutdownGracePeriod", p2pd.DefaultShutdownGracePeriod,
+		"Maximum time to wait for persistent connections to finish on SIGINT or SIGTERM")
+	maxUnaryMessageSize := flag.Int("maxUnaryMessageSize", network.MessageSizeMax,
+		"Maximum size in bytes of messages accepted from remote peers on unary streams")
+	unaryChunkSize := flag.Int("unaryChunkSize", 0,
+		"Size in bytes of the chunks unary payloads larger than it are split into; "+
+			"payloads aren't split if zero, as peers and clients must support chunking to receive them")
+	maxChunkedPayloadSize := flag.Int("maxChunkedPayloadSize", p2pd.DefaultMaxChunkedPayloadSize,
+		"Maximum size in bytes of a unary payload received in chunks")
+	retryUnaryDials := flag.Bool("retryUnaryDials", false,
+		"Retries opening the stream of a unary call once, bypassing the dial backoff, if the peer is in backoff")
+	negotiationTimeout := flag.Duration("negotiationTimeout", 0,
+		"How long a peer may take to negotiate the protocol of a unary call once connected, apart from the call's timeout; unbounded if zero")
+	loopbackCalls := flag.Bool("loopbackCalls", false,
+		"Routes unary calls to the daemon's own peer ID through an in-memory stream to its own handler; meant for benchmarks and profiling")
+	callHistorySize := flag.Int("callHistorySize", 0,
+		"Number of recent unary calls kept for post-mortem inspection over the control protocol; disabled if zero")
+	maxPersistentConns := flag.Int("maxPersistentConns", 0,
+		"Maximum number of persistent control connections open at once; further upgrade requests are rejected. Unlimited if zero")
+	keepAliveInterval := flag.Duration("keepAliveInterval", 0,
+		"Pings persistent control connections idle for this long, closing them once pings go unanswered; disabled if zero")
+	keepAliveMaxMissed := flag.Int("keepAliveMaxMissed", 3,
+		"Number of unanswered pings in a row after which a persistent control connection is closed")
+	dialTimeout := flag.Duration("dialTimeout", 0,
+		"How long the daemon's own dials to a peer may take over all of its addresses, not those of the DHT and pubsub; libp2p's default if zero")
+	maxConcurrentDials := flag.Int("maxConcurrentDials", 0,
+		"Maximum number of outbound dials in progress at once over TCP and other file descriptor consuming transports; libp2p's default if zero")
+	dialPreference := flag.String("dialPreference", "",
+		"Comma separated multiaddr protocol names and IP networks to dial peers over first, most preferred first, e.g. quic,tcp or 10.0.0.0/8,p2p-circuit")
+	dialPreferenceTimeout := flag.Duration("dialPreferenceTimeout", 0,
+		"How long the dial over each preferred kind of address may take before the next one is dialed; only once it fails if zero")
+	provide := flag.String("provide", "",
+		"Comma separated list of CIDs announced to the DHT every -reprovideInterval; requires the DHT")
+	reprovideInterval := flag.Duration("reprovideInterval", 12*time.Hour,
+		"How often the provided CIDs are announced again, so that their provider records don't expire")
+	rendezvous := flag.String("rendezvous", "",
+		"Namespace the daemon advertises itself under in the DHT, connecting to the other peers advertising under it; requires the DHT")
+	rendezvousInterval := flag.Duration("rendezvousInterval", 10*time.Minute,
+		"How often the daemon advertises itself under -rendezvous and looks for the other peers there")
+	tracingEndpoint := flag.String("tracingEndpoint", "",
+		"host:port of an OTLP/gRPC collector the spans of unary calls are exported to; tracing is disabled if empty")
+	tracingInsecure := flag.Bool("tracingInsecure", false, "Connects to the tracing collector without TLS")
+	tracingSampleRatio := flag.Float64("tracingSampleRatio", 1,
+		"Fraction of the traces started by the daemon that are sampled; calls continuing a trace follow its sampling decision")
+	userAgent := flag.String("userAgent", "",
+		"User agent announced to other peers in identify exchanges; derived from the build info of the daemon if unset")
+	metadata := flag.String("metadata", "",
+		"comma separated key=value pairs announced to other peers over the /p2pd/metadata/1.0.0 protocol, e.g. role=trainer")
 
 	flag.Parse()
 
+	if *genKey != "" {
+		peerID, err := p2pd.GenerateIdentity(*keyType, p2pd.DefaultRSAKeyBits, *genKey)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Peer ID: %s\n", peerID.Pretty())
+		return
+	}
+
 	var c config.Config
-	opts := []libp2p.Option{libp2p.UserAgent("p2pd/0.1")}
+	var opts []libp2p.Option
 
 	if *configStdin {
 		stdin := bufio.NewReader(os.Stdin)
@@ -133,195 +590,532 @@ func main() {
 		c = config.NewDefaultConfig()
 	}
 
-	maddr, err := multiaddr.NewMultiaddr(*maddrString)
-	if err != nil {
+	if err := c.ApplyEnv(os.LookupEnv); err != nil {
 		log.Fatal(err)
 	}
-	c.ListenAddr = config.JSONMaddr{Multiaddr: maddr}
 
-	if *id != "" {
+	// flags override the environment, which overrides the config file
+	setFlags := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) { setFlags[f.Name] = true })
+
+	listenAddrs := []multiaddr.Multiaddr{c.ListenAddr.Multiaddr}
+	if setFlags["listen"] {
+		addrs, err := config.ParseMaddrs("-listen", *maddrString)
+		if err != nil {
+			log.Fatal(err)
+		}
+		listenAddrs = addrs
+		c.ListenAddr = config.JSONMaddr{Multiaddr: listenAddrs[0]}
+	}
+
+	// every flag overrides the config when passed, even to false, zero or an
+	// empty list, and leaves it alone otherwise
+	if setFlags["id"] {
 		c.ID = *id
 	}
 
-	if *hostAddrs != "" {
-		addrStrings := strings.Split(*hostAddrs, ",")
-		ha := make([]multiaddr.Multiaddr, len(addrStrings))
-		for i, s := range addrStrings {
-			ma, err := multiaddr.NewMultiaddr(s)
-			if err != nil {
-				log.Fatal(err)
-			}
-			(ha)[i] = ma
+	if setFlags["hostAddrs"] {
+		ha, err := parseMaddrsFlag("-hostAddrs", *hostAddrs)
+		if err != nil {
+			log.Fatal(err)
 		}
 		c.HostAddresses = ha
 	}
 
-	if *announceAddrs != "" {
-		addrStrings := strings.Split(*announceAddrs, ",")
-		ha := make([]multiaddr.Multiaddr, len(addrStrings))
-		for i, s := range addrStrings {
-			ma, err := multiaddr.NewMultiaddr(s)
-			if err != nil {
-				log.Fatal(err)
-			}
-			(ha)[i] = ma
+	if setFlags["announceAddrs"] {
+		ha, err := parseMaddrsFlag("-announceAddrs", *announceAddrs)
+		if err != nil {
+			log.Fatal(err)
 		}
 		c.AnnounceAddresses = ha
 	}
 
-	if *connMgr {
-		c.ConnectionManager.Enabled = true
+	if setFlags["announceTemplates"] {
+		c.AnnounceTemplates = splitFlag(*announceTemplates)
+	}
+
+	if setFlags["addressFilter"] {
+		c.AddressFilter = *addressFilter
+	}
+
+	if setFlags["connManager"] {
+		c.ConnectionManager.Enabled = *connMgr
+	}
+	if setFlags["connGrace"] {
 		c.ConnectionManager.GracePeriod = *connMgrGrace
+	}
+	if setFlags["connHi"] {
 		c.ConnectionManager.HighWaterMark = *connMgrHi
+	}
+	if setFlags["connLo"] {
 		c.ConnectionManager.LowWaterMark = *connMgrLo
 	}
 
-	if QUIC != nil {
+	if setFlags["allowedPeers"] {
+		c.AllowedPeers = splitFlag(*allowedPeers)
+	}
+	if setFlags["blockedPeers"] {
+		c.BlockedPeers = splitFlag(*blockedPeers)
+	}
+	if setFlags["extraIdentities"] {
+		c.ExtraIdentities = splitFlag(*extraIdentities)
+	}
+
+	if setFlags["inboundRateLimit"] {
+		c.InboundRateLimit.Enabled = *inboundRateLimit
+	}
+	if setFlags["inboundRateLimitConns"] {
+		c.InboundRateLimit.Connections = *inboundRateLimitConns
+	}
+	if setFlags["inboundRateLimitWindow"] {
+		c.InboundRateLimit.Window = *inboundRateLimitWindow
+	}
+	if setFlags["inboundRateLimitBurst"] {
+		c.InboundRateLimit.Burst = *inboundRateLimitBurst
+	}
+
+	if setFlags["quic"] {
 		c.QUIC = *QUIC
 	}
+	if setFlags["transports"] {
+		c.Transports = splitFlag(*transports)
+	}
 
-	if *natPortMap {
-		c.NatPortMap = true
+	if setFlags["natPortMap"] {
+		c.NatPortMap = *natPortMap
 	}
 
-	if *relayEnabled {
-		c.Relay.Enabled = true
-		if *relayActive {
-			c.Relay.Active = true
-		}
-		if *relayHop {
-			c.Relay.Hop = true
-		}
-		if *relayDiscovery {
-			c.Relay.Discovery = true
-		}
-		if *relayHopLimit > 0 {
-			c.Relay.HopLimit = *relayHopLimit
+	// Validate rejects the relay settings that contradict each other, e.g.
+	// -relayActive without -relayHop
+	if setFlags["relay"] {
+		c.Relay.Enabled = *relayEnabled
+	}
+	if setFlags["relayActive"] {
+		c.Relay.Active = *relayActive
+	}
+	if setFlags["relayHop"] {
+		c.Relay.Hop = *relayHop
+	}
+	if setFlags["relayDiscovery"] {
+		c.Relay.Discovery = *relayDiscovery
+	}
+	if setFlags["relayHopLimit"] {
+		c.Relay.HopLimit = *relayHopLimit
+	}
+
+	if setFlags["autoRelay"] {
+		c.Relay.Auto = *autoRelay
+	}
+
+	if setFlags["staticRelays"] {
+		relays, err := parseMaddrsFlag("-staticRelays", *staticRelays)
+		if err != nil {
+			log.Fatal(err)
 		}
+		c.Relay.StaticRelays = relays
 	}
 
-	if *autoRelay {
-		c.Relay.Auto = true
+	if setFlags["desiredRelays"] {
+		c.Relay.DesiredRelays = *desiredRelays
 	}
 
-	if *noListen {
-		c.NoListen = true
+	if setFlags["relayRefreshInterval"] {
+		c.Relay.RefreshInterval = *relayRefreshInterval
+	}
+	if setFlags["relayReconnectBackoff"] {
+		c.Relay.Reconnect.InitialBackoff = *relayReconnectBackoff
+	}
+	if setFlags["relayReconnectMaxBackoff"] {
+		c.Relay.Reconnect.MaxBackoff = *relayReconnectMaxBackoff
 	}
 
-	if *autonat {
-		c.AutoNat = true
+	if setFlags["noListenAddrs"] {
+		c.NoListen = *noListen
 	}
 
-	if *pubsub {
-		c.PubSub.Enabled = true
+	if setFlags["autonat"] {
+		c.AutoNat = *autonat
+	}
+
+	if setFlags["pubsub"] {
+		c.PubSub.Enabled = *pubsub
+	}
+	if setFlags["pubsubRouter"] {
 		c.PubSub.Router = *pubsubRouter
+	}
+	if setFlags["pubsubSign"] {
 		c.PubSub.Sign = *pubsubSign
+	}
+	if setFlags["pubsubSignStrict"] {
 		c.PubSub.SignStrict = *pubsubSignStrict
-		if *gossipsubHeartbeatInterval > 0 {
-			c.PubSub.GossipSubHeartbeat.Interval = *gossipsubHeartbeatInterval
-		}
-		if *gossipsubHeartbeatInitialDelay > 0 {
-			c.PubSub.GossipSubHeartbeat.InitialDelay = *gossipsubHeartbeatInitialDelay
+	}
+	if setFlags["pubsubMessageID"] {
+		c.PubSub.MessageID.Scheme = *pubsubMessageID
+	}
+	if setFlags["pubsubMessageIDPrefix"] {
+		c.PubSub.MessageID.PrefixLength = *pubsubMessageIDPrefix
+	}
+	if setFlags["gossipsubHeartbeatInterval"] {
+		c.PubSub.GossipSubHeartbeat.Interval = *gossipsubHeartbeatInterval
+	}
+	if setFlags["gossipsubHeartbeatInitialDelay"] {
+		c.PubSub.GossipSubHeartbeat.InitialDelay = *gossipsubHeartbeatInitialDelay
+	}
+	for _, param := range []struct {
+		name  string
+		flag  int
+		param *int
+	}{
+		{"gossipsubD", *gossipsubD, &c.PubSub.GossipSub.D},
+		{"gossipsubDlo", *gossipsubDlo, &c.PubSub.GossipSub.Dlo},
+		{"gossipsubDhi", *gossipsubDhi, &c.PubSub.GossipSub.Dhi},
+		{"gossipsubDout", *gossipsubDout, &c.PubSub.GossipSub.Dout},
+		{"gossipsubDlazy", *gossipsubDlazy, &c.PubSub.GossipSub.Dlazy},
+		{"gossipsubHistoryLength", *gossipsubHistoryLength, &c.PubSub.GossipSub.HistoryLength},
+		{"gossipsubHistoryGossip", *gossipsubHistoryGossip, &c.PubSub.GossipSub.HistoryGossip},
+	} {
+		if setFlags[param.name] {
+			*param.param = param.flag
 		}
 	}
 
-	if *bootstrapPeers != "" {
-		addrStrings := strings.Split(*bootstrapPeers, ",")
-		bps := make([]multiaddr.Multiaddr, len(addrStrings))
-		for i, s := range addrStrings {
-			ma, err := multiaddr.NewMultiaddr(s)
-			if err != nil {
-				log.Fatal(err)
-			}
-			(bps)[i] = ma
+	if setFlags["bootstrapPeers"] {
+		bps, err := parseMaddrsFlag("-bootstrapPeers", *bootstrapPeers)
+		if err != nil {
+			log.Fatal(err)
 		}
 		c.Bootstrap.Peers = bps
 	}
 
-	if *bootstrap {
-		c.Bootstrap.Enabled = true
+	if setFlags["b"] {
+		c.Bootstrap.Enabled = *bootstrap
+	}
+	if setFlags["bootstrapMaxAttempts"] {
+		c.Bootstrap.Retry.MaxAttempts = *bootstrapMaxAttempts
+	}
+	if setFlags["bootstrapBackoff"] {
+		c.Bootstrap.Retry.InitialBackoff = *bootstrapBackoff
+	}
+	if setFlags["bootstrapBackoffMultiplier"] {
+		c.Bootstrap.Retry.Multiplier = *bootstrapBackoffMultiplier
+	}
+
+	if setFlags["q"] {
+		c.Quiet = *quiet
 	}
 
-	if *quiet {
-		c.Quiet = true
+	if setFlags["logLevel"] {
+		c.Logging.Level = *logLevel
+	}
+	if setFlags["logFormat"] {
+		c.Logging.Format = *logFormat
 	}
 
-	if *metricsAddr != "" {
+	if setFlags["metricsAddr"] {
 		c.MetricsAddress = *metricsAddr
 	}
+	if setFlags["metricsPath"] {
+		c.MetricsPath = *metricsPath
+	}
+
+	if setFlags["httpControl"] {
+		c.HTTPControl.Address = *httpControl
+	}
+
+	if setFlags["socketMode"] {
+		c.UnixSocket.Mode = *socketMode
+	}
+	if setFlags["socketOwner"] {
+		c.UnixSocket.Owner = *socketOwner
+	}
+	if setFlags["socketGroup"] {
+		c.UnixSocket.Group = *socketGroup
+	}
+	if setFlags["socketBacklog"] {
+		c.UnixSocket.Backlog = *socketBacklog
+	}
+
+	if setFlags["controlTLSCA"] {
+		c.ControlTLS.CAFile = *controlTLSCA
+	}
+	if setFlags["controlTLSCert"] {
+		c.ControlTLS.CertFile = *controlTLSCert
+	}
+	if setFlags["controlTLSKey"] {
+		c.ControlTLS.KeyFile = *controlTLSKey
+	}
+
+	if setFlags["readinessAddr"] {
+		c.Readiness.Address = *readinessAddr
+	}
+	if setFlags["readinessCriterion"] {
+		c.Readiness.Criterion = *readinessCriterion
+	}
+	if setFlags["readyInfo"] {
+		c.Readiness.InfoFile = *readyInfo
+	}
+
+	if setFlags["peerstorePath"] {
+		c.Peerstore.Path = *peerstorePath
+	}
+	if setFlags["peerstoreFlushInterval"] {
+		c.Peerstore.FlushInterval = *peerstoreFlushInterval
+	}
+
+	if setFlags["httpControlToken"] {
+		c.HTTPControl.Token = *httpControlToken
+	}
+
+	if setFlags["maxUnaryMessageSize"] {
+		c.MaxUnaryMessageSize = *maxUnaryMessageSize
+	}
+
+	if setFlags["unaryChunkSize"] {
+		c.UnaryChunkSize = *unaryChunkSize
+	}
+
+	if setFlags["maxChunkedPayloadSize"] {
+		c.MaxChunkedPayloadSize = *maxChunkedPayloadSize
+	}
+
+	if setFlags["retryUnaryDials"] {
+		c.RetryUnaryDials = *retryUnaryDials
+	}
+
+	if setFlags["negotiationTimeout"] {
+		c.NegotiationTimeout = *negotiationTimeout
+	}
+
+	if setFlags["loopbackCalls"] {
+		c.LoopbackCalls = *loopbackCalls
+	}
+
+	if setFlags["callHistorySize"] {
+		c.CallHistorySize = *callHistorySize
+	}
+
+	if setFlags["maxPersistentConns"] {
+		c.MaxPersistentConns = *maxPersistentConns
+	}
+
+	if setFlags["keepAliveInterval"] {
+		c.KeepAlive.Interval = *keepAliveInterval
+	}
+	if setFlags["keepAliveMaxMissed"] {
+		c.KeepAlive.MaxMissed = *keepAliveMaxMissed
+	}
+
+	if setFlags["dialTimeout"] {
+		c.Dial.Timeout = *dialTimeout
+	}
+	if setFlags["maxConcurrentDials"] {
+		c.Dial.MaxConcurrent = *maxConcurrentDials
+	}
+	if setFlags["dialPreference"] {
+		c.Dial.Preference = splitFlag(*dialPreference)
+	}
+	if setFlags["dialPreferenceTimeout"] {
+		c.Dial.PreferenceTimeout = *dialPreferenceTimeout
+	}
+
+	if setFlags["provide"] {
+		c.Provide.CIDs = splitFlag(*provide)
+	}
+	if setFlags["reprovideInterval"] {
+		c.Provide.Interval = *reprovideInterval
+	}
+	if setFlags["rendezvous"] {
+		c.Rendezvous.Namespace = *rendezvous
+	}
+	if setFlags["rendezvousInterval"] {
+		c.Rendezvous.Interval = *rendezvousInterval
+	}
+
+	if setFlags["tracingEndpoint"] {
+		c.Tracing.Endpoint = *tracingEndpoint
+	}
+	if setFlags["tracingInsecure"] {
+		c.Tracing.Insecure = *tracingInsecure
+	}
+	if setFlags["tracingSampleRatio"] {
+		c.Tracing.SampleRatio = *tracingSampleRatio
+	}
 
-	if *dht {
-		c.DHT.Mode = config.DHTFullMode
-	} else if *dhtClient {
-		c.DHT.Mode = config.DHTClientMode
-	} else if *dhtServer {
-		c.DHT.Mode = config.DHTServerMode
+	if setFlags["userAgent"] {
+		if *userAgent == "" {
+			log.Fatal("-userAgent can't be empty")
+		}
+		c.UserAgent = *userAgent
+	}
+
+	if setFlags["metadata"] {
+		c.Metadata = make(map[string]string)
+		if *metadata != "" {
+			md, err := config.ParseMetadata(*metadata)
+			if err != nil {
+				log.Fatal(err)
+			}
+			c.Metadata = md
+		}
 	}
 
-	if *pprof {
-		c.PProf.Enabled = true
-		if pprofPort != nil {
-			c.PProf.Port = *pprofPort
+	// the DHT flags each pick a mode, -dht before -dhtClient before
+	// -dhtServer, and turn the DHT off when passed as false in its mode
+	for _, mode := range []struct {
+		name string
+		on   bool
+		mode string
+	}{
+		{"dhtServer", *dhtServer, config.DHTServerMode},
+		{"dhtClient", *dhtClient, config.DHTClientMode},
+		{"dht", *dht, config.DHTFullMode},
+	} {
+		if !setFlags[mode.name] {
+			continue
+		}
+		if mode.on {
+			c.DHT.Mode = mode.mode
+		} else if c.DHT.Mode == mode.mode {
+			c.DHT.Mode = ""
 		}
 	}
 
-	if useTls != nil {
+	if setFlags["pprof"] {
+		c.PProf.Enabled = *pprof
+	}
+	if setFlags["pprofPort"] {
+		c.PProf.Port = *pprofPort
+	}
+	if setFlags["pprofServeMetrics"] {
+		c.PProf.ServeMetrics = *pprofServeMetrics
+	}
+
+	if setFlags["tls"] {
 		c.Security.TLS = *useTls
 	}
-	if useNoise != nil {
+	if setFlags["noise"] {
 		c.Security.Noise = *useNoise
 	}
+	if setFlags["pskFile"] {
+		c.PrivateNetwork.KeyFile = *pskFile
+	}
+	if setFlags["psk"] {
+		c.PrivateNetwork.Key = *psk
+	}
+	if setFlags["securityPreference"] {
+		c.Security.Preference = splitFlag(*securityPreference)
+	}
 
 	if err := c.Validate(); err != nil {
 		log.Fatal(err)
 	}
 
+	if *validate {
+		if err := checkConfig(&c, *idEnv, *configStdin); err != nil {
+			log.Fatal(err)
+		}
+		out, err := json.MarshalIndent(&c, "", "  ")
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(string(out))
+		return
+	}
+
+	if err := setupLogging(c.Logging); err != nil {
+		log.Fatal(err)
+	}
+
 	if c.PProf.Enabled {
+		mux := newPProfMux()
+		if c.PProf.ServeMetrics {
+			mux.Handle(c.MetricsPath, promhttp.Handler())
+		}
 		// an invalid port number will fail within the function.
-		go pprofHTTP(int(c.PProf.Port))
+		go pprofHTTP(int(c.PProf.Port), mux)
 	}
 
 	// collect opts
-	if c.ID != "" {
-		key, err := p2pd.ReadIdentity(c.ID)
+	if c.UserAgent != "" {
+		opts = append(opts, libp2p.UserAgent(c.UserAgent))
+	} else {
+		opts = append(opts, libp2p.UserAgent(defaultUserAgent()))
+	}
+
+	key, err := loadIdentity(c.ID, *idEnv, *configStdin)
+	if err != nil {
+		log.Fatal(err)
+	}
+	pskey, err := c.PrivateNetwork.LoadKey()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if pskey != nil {
+		opts = append(opts, libp2p.PrivateNetwork(pskey))
+		privateNetwork.Set(1)
+	}
+
+	if c.Tracing.Endpoint != "" {
+		shutdownTracing, err := setupTracing(c.Tracing)
 		if err != nil {
 			log.Fatal(err)
 		}
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+			defer cancel()
+			if err := shutdownTracing(ctx); err != nil {
+				log.Println(err)
+			}
+		}()
+	}
 
-		opts = append(opts, libp2p.Identity(key))
+	if c.Peerstore.Path != "" {
+		pstore, closePeerstore, err := p2pd.NewPersistentPeerstore(context.Background(), c.Peerstore.Path, c.Peerstore.FlushInterval)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer closePeerstore()
+
+		opts = append(opts, libp2p.Peerstore(sharedPeerstore{pstore}))
 	}
 
 	if len(c.HostAddresses) > 0 {
 		opts = append(opts, libp2p.ListenAddrs(c.HostAddresses...))
 	}
 
-	if len(c.AnnounceAddresses) > 0 {
-		opts = append(opts, libp2p.AddrsFactory(func([]multiaddr.Multiaddr) []multiaddr.Multiaddr {
-			return c.AnnounceAddresses
+	if len(c.AnnounceAddresses) > 0 || len(c.AnnounceTemplates) > 0 || c.AddressFilter != config.AddressFilterBoth {
+		opts = append(opts, libp2p.AddrsFactory(func(addrs []multiaddr.Multiaddr) []multiaddr.Multiaddr {
+			return c.FilterAddrs(c.AnnounceAddrs(addrs))
 		}))
 	}
 
-	if c.ConnectionManager.Enabled {
-		cm := connmgr.NewConnManager(c.ConnectionManager.LowWaterMark,
-			c.ConnectionManager.HighWaterMark,
-			c.ConnectionManager.GracePeriod)
-		opts = append(opts, libp2p.ConnectionManager(cm))
+	gater := p2pd.NewConnectionGater()
+	if c.InboundRateLimit.Enabled {
+		gater.LimitInboundRate(c.InboundRateLimit.Connections,
+			c.InboundRateLimit.Window,
+			c.InboundRateLimit.Burst)
+	}
+	allowed, blocked, err := c.PeerLists()
+	if err != nil {
+		log.Fatal(err)
 	}
+	gater.SetPeerLists(allowed, blocked)
+	opts = append(opts, libp2p.ConnectionGater(gater))
 
-	if c.QUIC {
-		opts = append(opts,
-			libp2p.DefaultTransports,
-			libp2p.Transport(quic.NewTransport),
-		)
-		if len(c.HostAddresses) == 0 {
-			log.Fatal("if we explicitly specify a transport, we must also explicitly specify the listen addrs")
-		}
+	opts = append(opts, transportOptions(c.EnabledTransports())...)
+	if len(c.HostAddresses) == 0 && !c.NoListen {
+		// libp2p only falls back to its default listen addrs when no transport
+		// is set explicitly
+		opts = append(opts, libp2p.ListenAddrs(c.FilterAddrs(defaultListenAddrs(c.EnabledTransports()))...))
 	}
 
 	if c.NatPortMap {
 		opts = append(opts, libp2p.NATPortMap())
 	}
 
+	// this only runs the AutoNAT service: reachability detection runs
+	// regardless, and this libp2p version has no hole punching (DCUtR) for
+	// AutoNat to turn on along with it
 	if c.AutoNat {
 		opts = append(opts, libp2p.EnableNATService())
 	}
@@ -341,11 +1135,23 @@ func main() {
 
 		if c.Relay.Auto {
 			opts = append(opts, libp2p.EnableAutoRelay())
+
+			if len(c.Relay.StaticRelays) > 0 {
+				relays, err := peer.AddrInfosFromP2pAddrs(c.Relay.StaticRelays...)
+				if err != nil {
+					log.Fatal(err)
+				}
+				opts = append(opts, libp2p.StaticRelays(relays))
+			}
+			autorelay.DesiredRelays = c.Relay.DesiredRelays
 		}
 
 		if c.Relay.HopLimit > 0 {
 			relay.HopStreamLimit = c.Relay.HopLimit
 		}
+	} else {
+		// libp2p enables the relay transport unless told otherwise
+		opts = append(opts, libp2p.DisableRelay())
 	}
 
 	if c.NoListen {
@@ -353,11 +1159,14 @@ func main() {
 	}
 
 	var securityOpts []libp2p.Option
-	if c.Security.Noise {
-		securityOpts = append(securityOpts, libp2p.Security(noise.ID, noise.New))
-	}
-	if c.Security.TLS {
-		securityOpts = append(securityOpts, libp2p.Security(tls.ID, tls.New))
+	// libp2p offers security protocols in the order they are added
+	for _, proto := range c.Security.Order() {
+		switch proto {
+		case config.SecurityNoise:
+			securityOpts = append(securityOpts, libp2p.Security(noise.ID, noise.New))
+		case config.SecurityTLS:
+			securityOpts = append(securityOpts, libp2p.Security(tls.ID, tls.New))
+		}
 	}
 
 	if len(securityOpts) == 0 {
@@ -373,62 +1182,280 @@ func main() {
 		opts = append(opts, libp2p.ForceReachabilityPublic())
 	}
 
-	// start daemon
-	d, err := p2pd.NewDaemon(context.Background(), &c.ListenAddr, c.DHT.Mode, opts...)
+	var bwc *metrics.BandwidthCounter
+	if c.MetricsAddress != "" {
+		bwc = metrics.NewBandwidthCounter()
+		opts = append(opts, libp2p.BandwidthReporter(bwc))
+	}
+
+	// validated along with the config
+	unixSocketMode, _ := c.UnixSocket.FileMode()
+	unixSocketUID, unixSocketGID, err := lookupOwner(c.UnixSocket.Owner, c.UnixSocket.Group)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	dialPref, err := p2pd.ParseDialPreference(c.Dial.Preference)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	if *idleTimeout > 0 {
-		d.KillOnTimeout(*idleTimeout)
+	provided, err := c.Provide.ParseCIDs()
+	if err != nil {
+		log.Fatal(err)
 	}
 
-	if c.PubSub.Enabled {
-		if c.PubSub.GossipSubHeartbeat.Interval > 0 {
-			ps.GossipSubHeartbeatInterval = c.PubSub.GossipSubHeartbeat.Interval
+	// the connection manager is closed along with the host, so every host,
+	// including those started on identity rotation, gets its own
+	hostOpts := func(key crypto.PrivKey) []libp2p.Option {
+		hopts := append([]libp2p.Option{}, opts...)
+		if key != nil {
+			hopts = append(hopts, libp2p.Identity(key))
 		}
-		if c.PubSub.GossipSubHeartbeat.InitialDelay > 0 {
-			ps.GossipSubHeartbeatInitialDelay = c.PubSub.GossipSubHeartbeat.InitialDelay
+		if c.ConnectionManager.Enabled {
+			cm := p2pd.NewConnManager(c.ConnectionManager.LowWaterMark,
+				c.ConnectionManager.HighWaterMark,
+				c.ConnectionManager.GracePeriod)
+			hopts = append(hopts, libp2p.ConnectionManager(cm))
 		}
+		return hopts
+	}
 
-		err = d.EnablePubsub(c.PubSub.Router, c.PubSub.Sign, c.PubSub.SignStrict)
+	var controlTLS *cryptotls.Config
+	if c.ControlTLS.Enabled() {
+		controlTLS, err = p2pd.NewControlTLSConfig(c.ControlTLS.CAFile, c.ControlTLS.CertFile, c.ControlTLS.KeyFile)
 		if err != nil {
 			log.Fatal(err)
 		}
 	}
 
-	if len(c.Bootstrap.Peers) > 0 {
-		p2pd.BootstrapPeers = c.Bootstrap.Peers
+	configure := func(d *p2pd.Daemon) error {
+		d.SetShutdownGracePeriod(*shutdownGracePeriod)
+		d.SetMaxUnaryMessageSize(c.MaxUnaryMessageSize)
+		d.SetUnaryChunking(c.UnaryChunkSize, c.MaxChunkedPayloadSize)
+		d.SetRetryUnaryDials(c.RetryUnaryDials)
+		d.SetNegotiationTimeout(c.NegotiationTimeout)
+		d.SetLoopbackCalls(c.LoopbackCalls)
+		d.SetDialPreference(dialPref, c.Dial.PreferenceTimeout)
+		d.SetReprovideInterval(c.Provide.Interval)
+		d.SetRendezvousInterval(c.Rendezvous.Interval)
+		d.SetConnectionGater(gater)
+		d.SetControlTLS(controlTLS)
+		d.SetKeepAlive(c.KeepAlive.Interval, c.KeepAlive.MaxMissed)
+		d.SetCallHistorySize(c.CallHistorySize)
+		d.SetMaxPersistentConns(c.MaxPersistentConns)
+		if err := d.SetMetadata(c.Metadata); err != nil {
+			return err
+		}
+		d.SetRelayService(c.Relay.Enabled && c.Relay.Hop)
+		if bwc != nil {
+			d.SetBandwidthReporter(bwc)
+		}
+
+		if c.Relay.Auto && c.Relay.RefreshInterval > 0 {
+			if err := d.RefreshRelays(c.Relay.RefreshInterval, c.Relay.DesiredRelays); err != nil {
+				return err
+			}
+		}
+		if c.Relay.Auto && len(c.Relay.StaticRelays) > 0 {
+			relays, err := peer.AddrInfosFromP2pAddrs(c.Relay.StaticRelays...)
+			if err != nil {
+				return err
+			}
+			err = d.KeepStaticRelays(relays, p2pd.RelayReconnectPolicy{
+				InitialBackoff: c.Relay.Reconnect.InitialBackoff,
+				MaxBackoff:     c.Relay.Reconnect.MaxBackoff,
+				Multiplier:     c.Relay.Reconnect.Multiplier,
+				Jitter:         c.Relay.Reconnect.Jitter,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if *idleTimeout > 0 {
+			if *drainOnTimeout {
+				d.DrainOnTimeout(*idleTimeout, *drainGracePeriod)
+			} else {
+				d.KillOnTimeout(*idleTimeout)
+			}
+		}
+
+		if c.PubSub.Enabled {
+			var psOpts []ps.Option
+			if c.PubSub.Router == "gossipsub" {
+				psOpts = append(psOpts, ps.WithGossipSubParams(c.PubSub.GossipSubParams()))
+			}
+			msgID, err := p2pd.NewMessageIDFn(c.PubSub.MessageID.Scheme, c.PubSub.MessageID.PrefixLength)
+			if err != nil {
+				return err
+			}
+			psOpts = append(psOpts, ps.WithMessageIdFn(msgID))
+
+			err = d.EnablePubsub(c.PubSub.Router, c.PubSub.Sign, c.PubSub.SignStrict, psOpts...)
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(c.Bootstrap.Peers) > 0 {
+			if err := d.SetBootstrapPeers(c.Bootstrap.Peers); err != nil {
+				return err
+			}
+		}
+
+		if c.Bootstrap.Enabled {
+			err := d.BootstrapWithRetry(p2pd.BootstrapRetryPolicy{
+				MaxAttempts:    c.Bootstrap.Retry.MaxAttempts,
+				InitialBackoff: c.Bootstrap.Retry.InitialBackoff,
+				Multiplier:     c.Bootstrap.Retry.Multiplier,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(provided) > 0 {
+			if err := d.StartProviding(provided...); err != nil {
+				return err
+			}
+		}
+
+		if c.Rendezvous.Namespace != "" {
+			if err := d.StartRendezvous(c.Rendezvous.Namespace); err != nil {
+				return err
+			}
+		}
+
+		return nil
 	}
 
-	if c.Bootstrap.Enabled {
-		err = d.Bootstrap()
+	// the swarm reads its limit on dials in progress at once from the
+	// environment when the host is built, and has no option for it
+	if c.Dial.MaxConcurrent > 0 {
+		os.Setenv("LIBP2P_SWARM_FD_LIMIT", strconv.Itoa(c.Dial.MaxConcurrent))
+	}
+
+	// every context of a daemon derives from the one it is created with
+	daemonCtx := context.Background()
+	if c.Dial.Timeout > 0 {
+		daemonCtx = network.WithDialPeerTimeout(daemonCtx, c.Dial.Timeout)
+	}
+	daemonCtx = p2pd.WithUnixSocket(daemonCtx, unixSocketMode, unixSocketUID, unixSocketGID, c.UnixSocket.Backlog)
+
+	// start daemon
+	d, err := p2pd.NewDaemon(daemonCtx, listenAddrs, c.DHT.Mode, hostOpts(key)...)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := configure(d); err != nil {
+		log.Fatal(err)
+	}
+
+	d.SetIdentityRotator(func(newKey crypto.PrivKey) (*p2pd.Daemon, error) {
+		if err := p2pd.CheckRotatableAddrs(c.HostAddresses); err != nil {
+			return nil, err
+		}
+		nd, err := p2pd.NewEmbeddedDaemon(daemonCtx, c.DHT.Mode, hostOpts(newKey)...)
+		if err != nil {
+			return nil, err
+		}
+		if err := configure(nd); err != nil {
+			nd.Close()
+			return nil, err
+		}
+
+		// keep the new identity across restarts when it was read from a file
+		if c.ID != "" && c.ID != "-" {
+			if err := replaceIdentity(newKey, c.ID); err != nil {
+				nd.Close()
+				return nil, err
+			}
+		} else if key != nil {
+			log.Printf("the rotated identity %s is not kept when the daemon restarts", nd.ID().Pretty())
+		}
+
+		return nd, nil
+	})
+
+	// the extra identities share the process, its options and signals, but
+	// neither identity rotation nor the HTTP endpoints
+	extras, err := c.ParseExtraIdentities()
+	if err != nil {
+		log.Fatal(err)
+	}
+	daemons := []*p2pd.Daemon{d}
+	for _, id := range extras {
+		key, err := p2pd.ReadIdentity(id.KeyFile)
 		if err != nil {
 			log.Fatal(err)
 		}
+		ed, err := p2pd.NewDaemon(daemonCtx, []multiaddr.Multiaddr{id.ListenAddr}, c.DHT.Mode, hostOpts(key)...)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := configure(ed); err != nil {
+			log.Fatal(err)
+		}
+		daemons = append(daemons, ed)
 	}
 
 	if !c.Quiet {
-		fmt.Printf("Control socket: %s\n", c.ListenAddr.String())
-		fmt.Printf("Peer ID: %s\n", d.ID().Pretty())
-		fmt.Printf("Peer Addrs:\n")
-		for _, addr := range d.Addrs() {
-			fmt.Printf("%s\n", addr.String())
+		printDaemonInfo(d)
+		for _, ed := range daemons[1:] {
+			fmt.Printf("Extra identity:\n")
+			printDaemonInfo(ed)
+		}
+		if pskey != nil {
+			fmt.Printf("Private network: active\n")
 		}
 		if c.Bootstrap.Enabled && len(c.Bootstrap.Peers) > 0 {
 			fmt.Printf("Bootstrap peers:\n")
-			for _, p := range p2pd.BootstrapPeers {
+			for _, p := range c.Bootstrap.Peers {
 				fmt.Printf("%s\n", p)
 			}
 		}
 	}
 
+	if c.Readiness.InfoFile != "" {
+		if err := writeStartupInfo(d, c.Readiness.InfoFile); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	// the HTTP endpoints follow the control socket on identity rotation
+	readiness := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		d.Current().ReadinessHandler(c.Readiness.Criterion).ServeHTTP(w, r)
+	})
+	if c.Readiness.Address != "" {
+		go func() { log.Println(http.ListenAndServe(c.Readiness.Address, readiness)) }()
+	}
+
 	if c.MetricsAddress != "" {
-		http.Handle("/metrics", promhttp.Handler())
-		go func() { log.Println(http.ListenAndServe(c.MetricsAddress, nil)) }()
+		mux := http.NewServeMux()
+		mux.Handle(c.MetricsPath, promhttp.Handler())
+		mux.Handle("/ready", readiness)
+		go func() { log.Println(http.ListenAndServe(c.MetricsAddress, mux)) }()
 	}
 
-	if err := d.Serve(); err != nil {
-		log.Fatal(err)
+	if c.HTTPControl.Address != "" {
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			d.Current().HTTPControlHandler(c.HTTPControl.Token).ServeHTTP(w, r)
+		})
+		go func() { log.Println(http.ListenAndServe(c.HTTPControl.Address, handler)) }()
+	}
+
+	// every daemon shuts down on SIGINT and SIGTERM, and the process exits
+	// once they all have
+	var wg sync.WaitGroup
+	for _, ed := range daemons {
+		wg.Add(1)
+		go func(ed *p2pd.Daemon) {
+			defer wg.Done()
+			if err := ed.Serve(); err != nil {
+				log.Fatal(err)
+			}
+		}(ed)
 	}
+	wg.Wait()
 }
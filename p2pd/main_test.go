@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/libp2p/go-libp2p-daemon/config"
+)
+
+// runMainEnv has the test binary run main instead of the tests, so that the
+// tests can run the daemon in a child process.
+const runMainEnv = "P2PD_TEST_RUN_MAIN"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(runMainEnv) == "1" {
+		main()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// resolveConfig runs the daemon with -validate and returns the config it
+// resolved from args and env.
+func resolveConfig(t *testing.T, args []string, env ...string) config.Config {
+	t.Helper()
+
+	cmd := exec.Command(os.Args[0], append(args, "-validate")...)
+	cmd.Env = append(append(os.Environ(), runMainEnv+"=1"), env...)
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("resolving the config of %v: %s", args, err)
+	}
+
+	var c config.Config
+	if err := json.Unmarshal(out, &c); err != nil {
+		t.Fatalf("parsing the resolved config %q: %s", out, err)
+	}
+	return c
+}
+
+func TestConfigPrecedence(t *testing.T) {
+	dir, err := ioutil.TempDir("", "p2pd-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "config.json")
+	err = ioutil.WriteFile(file, []byte(`{
+		"PubSub": {"Router": "floodsub"},
+		"ConnectionManager": {"Enabled": true, "LowWaterMark": 10, "HighWaterMark": 20},
+		"Bootstrap": {"Retry": {"MaxAttempts": 2}},
+		"MetricsPath": "/file",
+		"NatPortMap": true
+	}`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	env := []string{
+		"P2PD_PUBSUB=1",
+		"P2PD_CONNECTION_MANAGER_HIGH_WATER_MARK=30",
+		"P2PD_METRICS_PATH=/env",
+		"P2PD_RELAY_HOP=1",
+	}
+
+	// the environment overrides the file, and the settings of neither are
+	// reset by unrelated flags
+	c := resolveConfig(t, []string{"-f", file, "-b"}, env...)
+	if !c.PubSub.Enabled || c.PubSub.Router != "floodsub" {
+		t.Fatalf("expected pubsub enabled by the environment with the router of the file, got %+v", c.PubSub)
+	}
+	if !c.ConnectionManager.Enabled || c.ConnectionManager.LowWaterMark != 10 || c.ConnectionManager.HighWaterMark != 30 {
+		t.Fatalf("expected the connection manager of the file and the environment, got %+v", c.ConnectionManager)
+	}
+	if !c.Bootstrap.Enabled || c.Bootstrap.Retry.MaxAttempts != 2 {
+		t.Fatalf("expected -b to keep the bootstrap retries of the file, got %+v", c.Bootstrap)
+	}
+	if c.MetricsPath != "/env" || !c.NatPortMap || !c.Relay.Hop {
+		t.Fatalf("expected the metrics path and relay hop of the environment and the port mapping of the file, got %s %t %t",
+			c.MetricsPath, c.Relay.Hop, c.NatPortMap)
+	}
+
+	// flags override both, even when set to false
+	c = resolveConfig(t, []string{"-f", file,
+		"-pubsub=false",
+		"-connManager=false",
+		"-connHi=40",
+		"-metricsPath=/flag",
+		"-natPortMap=false",
+		"-relayHop=false",
+	}, env...)
+	if c.PubSub.Enabled || c.PubSub.Router != "floodsub" {
+		t.Fatalf("expected -pubsub=false to disable pubsub and keep the router of the file, got %+v", c.PubSub)
+	}
+	if c.ConnectionManager.Enabled || c.ConnectionManager.LowWaterMark != 10 || c.ConnectionManager.HighWaterMark != 40 {
+		t.Fatalf("expected the connection manager of the flags and the file, got %+v", c.ConnectionManager)
+	}
+	if c.MetricsPath != "/flag" || c.NatPortMap || c.Relay.Hop {
+		t.Fatalf("expected the metrics path, port mapping and relay hop of the flags, got %s %t %t",
+			c.MetricsPath, c.NatPortMap, c.Relay.Hop)
+	}
+}
@@ -4,6 +4,7 @@
 package p2pd_pb
 
 import (
+	encoding_binary "encoding/binary"
 	fmt "fmt"
 	github_com_gogo_protobuf_proto "github.com/gogo/protobuf/proto"
 	proto "github.com/gogo/protobuf/proto"
@@ -36,19 +37,55 @@ const (
 	Request_DISCONNECT              Request_Type = 7
 	Request_PUBSUB                  Request_Type = 8
 	Request_PERSISTENT_CONN_UPGRADE Request_Type = 9
+	Request_LIST_CONNECTIONS        Request_Type = 10
+	Request_PING                    Request_Type = 11
+	Request_SET_BOOTSTRAP_PEERS     Request_Type = 12
+	Request_PEER_LISTS              Request_Type = 13
+	Request_RESOURCE_USAGE          Request_Type = 14
+	Request_LIST_RELAYS             Request_Type = 15
+	Request_BANDWIDTH               Request_Type = 16
+	Request_FIND_PEER               Request_Type = 17
+	Request_ROUTING_TABLE           Request_Type = 18
+	Request_ROTATE_IDENTITY         Request_Type = 19
+	Request_DAEMON_INFO             Request_Type = 20
+	Request_AUTONAT                 Request_Type = 21
+	Request_CALL_HISTORY            Request_Type = 22
+	Request_LIST_ADDRS              Request_Type = 23
+	Request_PEERSTORE               Request_Type = 24
+	Request_PEER_METADATA           Request_Type = 25
+	Request_LOG_LEVEL               Request_Type = 26
+	Request_RENDEZVOUS_PEERS        Request_Type = 27
 )
 
 var Request_Type_name = map[int32]string{
-	0: "IDENTIFY",
-	1: "CONNECT",
-	2: "STREAM_OPEN",
-	3: "STREAM_HANDLER",
-	4: "DHT",
-	5: "LIST_PEERS",
-	6: "CONNMANAGER",
-	7: "DISCONNECT",
-	8: "PUBSUB",
-	9: "PERSISTENT_CONN_UPGRADE",
+	0:  "IDENTIFY",
+	1:  "CONNECT",
+	2:  "STREAM_OPEN",
+	3:  "STREAM_HANDLER",
+	4:  "DHT",
+	5:  "LIST_PEERS",
+	6:  "CONNMANAGER",
+	7:  "DISCONNECT",
+	8:  "PUBSUB",
+	9:  "PERSISTENT_CONN_UPGRADE",
+	10: "LIST_CONNECTIONS",
+	11: "PING",
+	12: "SET_BOOTSTRAP_PEERS",
+	13: "PEER_LISTS",
+	14: "RESOURCE_USAGE",
+	15: "LIST_RELAYS",
+	16: "BANDWIDTH",
+	17: "FIND_PEER",
+	18: "ROUTING_TABLE",
+	19: "ROTATE_IDENTITY",
+	20: "DAEMON_INFO",
+	21: "AUTONAT",
+	22: "CALL_HISTORY",
+	23: "LIST_ADDRS",
+	24: "PEERSTORE",
+	25: "PEER_METADATA",
+	26: "LOG_LEVEL",
+	27: "RENDEZVOUS_PEERS",
 }
 
 var Request_Type_value = map[string]int32{
@@ -62,6 +99,24 @@ var Request_Type_value = map[string]int32{
 	"DISCONNECT":              7,
 	"PUBSUB":                  8,
 	"PERSISTENT_CONN_UPGRADE": 9,
+	"LIST_CONNECTIONS":        10,
+	"PING":                    11,
+	"SET_BOOTSTRAP_PEERS":     12,
+	"PEER_LISTS":              13,
+	"RESOURCE_USAGE":          14,
+	"LIST_RELAYS":             15,
+	"BANDWIDTH":               16,
+	"FIND_PEER":               17,
+	"ROUTING_TABLE":           18,
+	"ROTATE_IDENTITY":         19,
+	"DAEMON_INFO":             20,
+	"AUTONAT":                 21,
+	"CALL_HISTORY":            22,
+	"LIST_ADDRS":              23,
+	"PEERSTORE":               24,
+	"PEER_METADATA":           25,
+	"LOG_LEVEL":               26,
+	"RENDEZVOUS_PEERS":        27,
 }
 
 func (x Request_Type) Enum() *Request_Type {
@@ -127,6 +182,135 @@ func (Response_Type) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_7333f0e9b622f7df, []int{1, 0}
 }
 
+type PeerstoreRequest_Type int32
+
+const (
+	PeerstoreRequest_ADD_ADDRS     PeerstoreRequest_Type = 0
+	PeerstoreRequest_CLEAR_ADDRS   PeerstoreRequest_Type = 1
+	PeerstoreRequest_GET_PROTOCOLS PeerstoreRequest_Type = 2
+)
+
+var PeerstoreRequest_Type_name = map[int32]string{
+	0: "ADD_ADDRS",
+	1: "CLEAR_ADDRS",
+	2: "GET_PROTOCOLS",
+}
+
+var PeerstoreRequest_Type_value = map[string]int32{
+	"ADD_ADDRS":     0,
+	"CLEAR_ADDRS":   1,
+	"GET_PROTOCOLS": 2,
+}
+
+func (x PeerstoreRequest_Type) Enum() *PeerstoreRequest_Type {
+	p := new(PeerstoreRequest_Type)
+	*p = x
+	return p
+}
+
+func (x PeerstoreRequest_Type) String() string {
+	return proto.EnumName(PeerstoreRequest_Type_name, int32(x))
+}
+
+func (x *PeerstoreRequest_Type) UnmarshalJSON(data []byte) error {
+	value, err := proto.UnmarshalJSONEnum(PeerstoreRequest_Type_value, data, "PeerstoreRequest_Type")
+	if err != nil {
+		return err
+	}
+	*x = PeerstoreRequest_Type(value)
+	return nil
+}
+
+func (PeerstoreRequest_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{24, 0}
+}
+
+type PeerListsRequest_Type int32
+
+const (
+	PeerListsRequest_GET PeerListsRequest_Type = 0
+	PeerListsRequest_SET PeerListsRequest_Type = 1
+)
+
+var PeerListsRequest_Type_name = map[int32]string{
+	0: "GET",
+	1: "SET",
+}
+
+var PeerListsRequest_Type_value = map[string]int32{
+	"GET": 0,
+	"SET": 1,
+}
+
+func (x PeerListsRequest_Type) Enum() *PeerListsRequest_Type {
+	p := new(PeerListsRequest_Type)
+	*p = x
+	return p
+}
+
+func (x PeerListsRequest_Type) String() string {
+	return proto.EnumName(PeerListsRequest_Type_name, int32(x))
+}
+
+func (x *PeerListsRequest_Type) UnmarshalJSON(data []byte) error {
+	value, err := proto.UnmarshalJSONEnum(PeerListsRequest_Type_value, data, "PeerListsRequest_Type")
+	if err != nil {
+		return err
+	}
+	*x = PeerListsRequest_Type(value)
+	return nil
+}
+
+func (PeerListsRequest_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{26, 0}
+}
+
+type DialAttempt_Reason int32
+
+const (
+	DialAttempt_OTHER       DialAttempt_Reason = 0
+	DialAttempt_REFUSED     DialAttempt_Reason = 1
+	DialAttempt_TIMEOUT     DialAttempt_Reason = 2
+	DialAttempt_UNREACHABLE DialAttempt_Reason = 3
+)
+
+var DialAttempt_Reason_name = map[int32]string{
+	0: "OTHER",
+	1: "REFUSED",
+	2: "TIMEOUT",
+	3: "UNREACHABLE",
+}
+
+var DialAttempt_Reason_value = map[string]int32{
+	"OTHER":       0,
+	"REFUSED":     1,
+	"TIMEOUT":     2,
+	"UNREACHABLE": 3,
+}
+
+func (x DialAttempt_Reason) Enum() *DialAttempt_Reason {
+	p := new(DialAttempt_Reason)
+	*p = x
+	return p
+}
+
+func (x DialAttempt_Reason) String() string {
+	return proto.EnumName(DialAttempt_Reason_name, int32(x))
+}
+
+func (x *DialAttempt_Reason) UnmarshalJSON(data []byte) error {
+	value, err := proto.UnmarshalJSONEnum(DialAttempt_Reason_value, data, "DialAttempt_Reason")
+	if err != nil {
+		return err
+	}
+	*x = DialAttempt_Reason(value)
+	return nil
+}
+
+func (DialAttempt_Reason) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{45, 0}
+}
+
 type DHTRequest_Type int32
 
 const (
@@ -139,18 +323,26 @@ const (
 	DHTRequest_SEARCH_VALUE                 DHTRequest_Type = 6
 	DHTRequest_PUT_VALUE                    DHTRequest_Type = 7
 	DHTRequest_PROVIDE                      DHTRequest_Type = 8
+	// add cid to, or remove it from, the content the daemon announces
+	// periodically, or list that content
+	DHTRequest_START_PROVIDING DHTRequest_Type = 9
+	DHTRequest_STOP_PROVIDING  DHTRequest_Type = 10
+	DHTRequest_LIST_PROVIDING  DHTRequest_Type = 11
 )
 
 var DHTRequest_Type_name = map[int32]string{
-	0: "FIND_PEER",
-	1: "FIND_PEERS_CONNECTED_TO_PEER",
-	2: "FIND_PROVIDERS",
-	3: "GET_CLOSEST_PEERS",
-	4: "GET_PUBLIC_KEY",
-	5: "GET_VALUE",
-	6: "SEARCH_VALUE",
-	7: "PUT_VALUE",
-	8: "PROVIDE",
+	0:  "FIND_PEER",
+	1:  "FIND_PEERS_CONNECTED_TO_PEER",
+	2:  "FIND_PROVIDERS",
+	3:  "GET_CLOSEST_PEERS",
+	4:  "GET_PUBLIC_KEY",
+	5:  "GET_VALUE",
+	6:  "SEARCH_VALUE",
+	7:  "PUT_VALUE",
+	8:  "PROVIDE",
+	9:  "START_PROVIDING",
+	10: "STOP_PROVIDING",
+	11: "LIST_PROVIDING",
 }
 
 var DHTRequest_Type_value = map[string]int32{
@@ -163,6 +355,9 @@ var DHTRequest_Type_value = map[string]int32{
 	"SEARCH_VALUE":                 6,
 	"PUT_VALUE":                    7,
 	"PROVIDE":                      8,
+	"START_PROVIDING":              9,
+	"STOP_PROVIDING":               10,
+	"LIST_PROVIDING":               11,
 }
 
 func (x DHTRequest_Type) Enum() *DHTRequest_Type {
@@ -185,7 +380,7 @@ func (x *DHTRequest_Type) UnmarshalJSON(data []byte) error {
 }
 
 func (DHTRequest_Type) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_7333f0e9b622f7df, []int{10, 0}
+	return fileDescriptor_7333f0e9b622f7df, []int{47, 0}
 }
 
 type DHTResponse_Type int32
@@ -228,7 +423,50 @@ func (x *DHTResponse_Type) UnmarshalJSON(data []byte) error {
 }
 
 func (DHTResponse_Type) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_7333f0e9b622f7df, []int{11, 0}
+	return fileDescriptor_7333f0e9b622f7df, []int{48, 0}
+}
+
+type ConnectionInfo_Direction int32
+
+const (
+	ConnectionInfo_UNKNOWN  ConnectionInfo_Direction = 0
+	ConnectionInfo_INBOUND  ConnectionInfo_Direction = 1
+	ConnectionInfo_OUTBOUND ConnectionInfo_Direction = 2
+)
+
+var ConnectionInfo_Direction_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "INBOUND",
+	2: "OUTBOUND",
+}
+
+var ConnectionInfo_Direction_value = map[string]int32{
+	"UNKNOWN":  0,
+	"INBOUND":  1,
+	"OUTBOUND": 2,
+}
+
+func (x ConnectionInfo_Direction) Enum() *ConnectionInfo_Direction {
+	p := new(ConnectionInfo_Direction)
+	*p = x
+	return p
+}
+
+func (x ConnectionInfo_Direction) String() string {
+	return proto.EnumName(ConnectionInfo_Direction_name, int32(x))
+}
+
+func (x *ConnectionInfo_Direction) UnmarshalJSON(data []byte) error {
+	value, err := proto.UnmarshalJSONEnum(ConnectionInfo_Direction_value, data, "ConnectionInfo_Direction")
+	if err != nil {
+		return err
+	}
+	*x = ConnectionInfo_Direction(value)
+	return nil
+}
+
+func (ConnectionInfo_Direction) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{51, 0}
 }
 
 type ConnManagerRequest_Type int32
@@ -237,18 +475,27 @@ const (
 	ConnManagerRequest_TAG_PEER   ConnManagerRequest_Type = 0
 	ConnManagerRequest_UNTAG_PEER ConnManagerRequest_Type = 1
 	ConnManagerRequest_TRIM       ConnManagerRequest_Type = 2
+	ConnManagerRequest_SET_LIMITS ConnManagerRequest_Type = 3
+	ConnManagerRequest_PROTECT    ConnManagerRequest_Type = 4
+	ConnManagerRequest_UNPROTECT  ConnManagerRequest_Type = 5
 )
 
 var ConnManagerRequest_Type_name = map[int32]string{
 	0: "TAG_PEER",
 	1: "UNTAG_PEER",
 	2: "TRIM",
+	3: "SET_LIMITS",
+	4: "PROTECT",
+	5: "UNPROTECT",
 }
 
 var ConnManagerRequest_Type_value = map[string]int32{
 	"TAG_PEER":   0,
 	"UNTAG_PEER": 1,
 	"TRIM":       2,
+	"SET_LIMITS": 3,
+	"PROTECT":    4,
+	"UNPROTECT":  5,
 }
 
 func (x ConnManagerRequest_Type) Enum() *ConnManagerRequest_Type {
@@ -271,7 +518,7 @@ func (x *ConnManagerRequest_Type) UnmarshalJSON(data []byte) error {
 }
 
 func (ConnManagerRequest_Type) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_7333f0e9b622f7df, []int{13, 0}
+	return fileDescriptor_7333f0e9b622f7df, []int{52, 0}
 }
 
 type PSRequest_Type int32
@@ -317,21 +564,327 @@ func (x *PSRequest_Type) UnmarshalJSON(data []byte) error {
 }
 
 func (PSRequest_Type) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_7333f0e9b622f7df, []int{15, 0}
+	return fileDescriptor_7333f0e9b622f7df, []int{55, 0}
+}
+
+type ValidationResult_Result int32
+
+const (
+	ValidationResult_ACCEPT ValidationResult_Result = 0
+	ValidationResult_REJECT ValidationResult_Result = 1
+	ValidationResult_IGNORE ValidationResult_Result = 2
+)
+
+var ValidationResult_Result_name = map[int32]string{
+	0: "ACCEPT",
+	1: "REJECT",
+	2: "IGNORE",
+}
+
+var ValidationResult_Result_value = map[string]int32{
+	"ACCEPT": 0,
+	"REJECT": 1,
+	"IGNORE": 2,
+}
+
+func (x ValidationResult_Result) Enum() *ValidationResult_Result {
+	p := new(ValidationResult_Result)
+	*p = x
+	return p
+}
+
+func (x ValidationResult_Result) String() string {
+	return proto.EnumName(ValidationResult_Result_name, int32(x))
+}
+
+func (x *ValidationResult_Result) UnmarshalJSON(data []byte) error {
+	value, err := proto.UnmarshalJSONEnum(ValidationResult_Result_value, data, "ValidationResult_Result")
+	if err != nil {
+		return err
+	}
+	*x = ValidationResult_Result(value)
+	return nil
+}
+
+func (ValidationResult_Result) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{59, 0}
+}
+
+// what to do with incoming calls past maxConcurrentCalls
+type AddUnaryHandlerRequest_OverflowPolicy int32
+
+const (
+	AddUnaryHandlerRequest_REJECT AddUnaryHandlerRequest_OverflowPolicy = 0
+	AddUnaryHandlerRequest_BLOCK  AddUnaryHandlerRequest_OverflowPolicy = 1
+)
+
+var AddUnaryHandlerRequest_OverflowPolicy_name = map[int32]string{
+	0: "REJECT",
+	1: "BLOCK",
+}
+
+var AddUnaryHandlerRequest_OverflowPolicy_value = map[string]int32{
+	"REJECT": 0,
+	"BLOCK":  1,
+}
+
+func (x AddUnaryHandlerRequest_OverflowPolicy) Enum() *AddUnaryHandlerRequest_OverflowPolicy {
+	p := new(AddUnaryHandlerRequest_OverflowPolicy)
+	*p = x
+	return p
+}
+
+func (x AddUnaryHandlerRequest_OverflowPolicy) String() string {
+	return proto.EnumName(AddUnaryHandlerRequest_OverflowPolicy_name, int32(x))
+}
+
+func (x *AddUnaryHandlerRequest_OverflowPolicy) UnmarshalJSON(data []byte) error {
+	value, err := proto.UnmarshalJSONEnum(AddUnaryHandlerRequest_OverflowPolicy_value, data, "AddUnaryHandlerRequest_OverflowPolicy")
+	if err != nil {
+		return err
+	}
+	*x = AddUnaryHandlerRequest_OverflowPolicy(value)
+	return nil
+}
+
+func (AddUnaryHandlerRequest_OverflowPolicy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{63, 0}
+}
+
+// stable codes for failures clients may want to handle, e.g. to retry
+type DaemonError_Code int32
+
+const (
+	DaemonError_UNKNOWN                DaemonError_Code = 0
+	DaemonError_NO_ADDRESSES           DaemonError_Code = 1
+	DaemonError_DIAL_BACKOFF           DaemonError_Code = 2
+	DaemonError_DIAL_FAILED            DaemonError_Code = 3
+	DaemonError_CONNECTION_DENIED      DaemonError_Code = 4
+	DaemonError_PROTOCOL_NOT_SUPPORTED DaemonError_Code = 5
+	DaemonError_TIMEOUT                DaemonError_Code = 6
+	DaemonError_CANCELLED              DaemonError_Code = 7
+	DaemonError_MESSAGE_TOO_LARGE      DaemonError_Code = 8
+	DaemonError_DUPLICATE_CALL_ID      DaemonError_Code = 9
+	// the peer was reached, but didn't negotiate the protocol in time
+	DaemonError_NEGOTIATION_TIMEOUT DaemonError_Code = 10
+	// a response was sent for an incoming call no longer awaiting one, e.g.
+	// as its caller went away
+	DaemonError_CALL_NOT_AWAITED DaemonError_Code = 11
+	// a call was cancelled, or an incoming call answered, on another
+	// connection than the one issuing or awaiting it
+	DaemonError_CALL_NOT_OWNED DaemonError_Code = 12
+)
+
+var DaemonError_Code_name = map[int32]string{
+	0:  "UNKNOWN",
+	1:  "NO_ADDRESSES",
+	2:  "DIAL_BACKOFF",
+	3:  "DIAL_FAILED",
+	4:  "CONNECTION_DENIED",
+	5:  "PROTOCOL_NOT_SUPPORTED",
+	6:  "TIMEOUT",
+	7:  "CANCELLED",
+	8:  "MESSAGE_TOO_LARGE",
+	9:  "DUPLICATE_CALL_ID",
+	10: "NEGOTIATION_TIMEOUT",
+	11: "CALL_NOT_AWAITED",
+	12: "CALL_NOT_OWNED",
+}
+
+var DaemonError_Code_value = map[string]int32{
+	"UNKNOWN":                0,
+	"NO_ADDRESSES":           1,
+	"DIAL_BACKOFF":           2,
+	"DIAL_FAILED":            3,
+	"CONNECTION_DENIED":      4,
+	"PROTOCOL_NOT_SUPPORTED": 5,
+	"TIMEOUT":                6,
+	"CANCELLED":              7,
+	"MESSAGE_TOO_LARGE":      8,
+	"DUPLICATE_CALL_ID":      9,
+	"NEGOTIATION_TIMEOUT":    10,
+	"CALL_NOT_AWAITED":       11,
+	"CALL_NOT_OWNED":         12,
+}
+
+func (x DaemonError_Code) Enum() *DaemonError_Code {
+	p := new(DaemonError_Code)
+	*p = x
+	return p
+}
+
+func (x DaemonError_Code) String() string {
+	return proto.EnumName(DaemonError_Code_name, int32(x))
+}
+
+func (x *DaemonError_Code) UnmarshalJSON(data []byte) error {
+	value, err := proto.UnmarshalJSONEnum(DaemonError_Code_value, data, "DaemonError_Code")
+	if err != nil {
+		return err
+	}
+	*x = DaemonError_Code(value)
+	return nil
+}
+
+func (DaemonError_Code) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{65, 0}
+}
+
+type Event_Type int32
+
+const (
+	Event_LOCAL_REACHABILITY_CHANGED Event_Type = 0
+	Event_PEER_CONNECTEDNESS_CHANGED Event_Type = 1
+	Event_LOCAL_ADDRESSES_UPDATED    Event_Type = 2
+)
+
+var Event_Type_name = map[int32]string{
+	0: "LOCAL_REACHABILITY_CHANGED",
+	1: "PEER_CONNECTEDNESS_CHANGED",
+	2: "LOCAL_ADDRESSES_UPDATED",
+}
+
+var Event_Type_value = map[string]int32{
+	"LOCAL_REACHABILITY_CHANGED": 0,
+	"PEER_CONNECTEDNESS_CHANGED": 1,
+	"LOCAL_ADDRESSES_UPDATED":    2,
+}
+
+func (x Event_Type) Enum() *Event_Type {
+	p := new(Event_Type)
+	*p = x
+	return p
+}
+
+func (x Event_Type) String() string {
+	return proto.EnumName(Event_Type_name, int32(x))
+}
+
+func (x *Event_Type) UnmarshalJSON(data []byte) error {
+	value, err := proto.UnmarshalJSONEnum(Event_Type_value, data, "Event_Type")
+	if err != nil {
+		return err
+	}
+	*x = Event_Type(value)
+	return nil
+}
+
+func (Event_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{76, 0}
+}
+
+type Event_Reachability int32
+
+const (
+	Event_UNKNOWN Event_Reachability = 0
+	Event_PUBLIC  Event_Reachability = 1
+	Event_PRIVATE Event_Reachability = 2
+)
+
+var Event_Reachability_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "PUBLIC",
+	2: "PRIVATE",
+}
+
+var Event_Reachability_value = map[string]int32{
+	"UNKNOWN": 0,
+	"PUBLIC":  1,
+	"PRIVATE": 2,
+}
+
+func (x Event_Reachability) Enum() *Event_Reachability {
+	p := new(Event_Reachability)
+	*p = x
+	return p
+}
+
+func (x Event_Reachability) String() string {
+	return proto.EnumName(Event_Reachability_name, int32(x))
+}
+
+func (x *Event_Reachability) UnmarshalJSON(data []byte) error {
+	value, err := proto.UnmarshalJSONEnum(Event_Reachability_value, data, "Event_Reachability")
+	if err != nil {
+		return err
+	}
+	*x = Event_Reachability(value)
+	return nil
+}
+
+func (Event_Reachability) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{76, 1}
+}
+
+type Event_Connectedness int32
+
+const (
+	Event_NOT_CONNECTED  Event_Connectedness = 0
+	Event_CONNECTED      Event_Connectedness = 1
+	Event_CAN_CONNECT    Event_Connectedness = 2
+	Event_CANNOT_CONNECT Event_Connectedness = 3
+)
+
+var Event_Connectedness_name = map[int32]string{
+	0: "NOT_CONNECTED",
+	1: "CONNECTED",
+	2: "CAN_CONNECT",
+	3: "CANNOT_CONNECT",
+}
+
+var Event_Connectedness_value = map[string]int32{
+	"NOT_CONNECTED":  0,
+	"CONNECTED":      1,
+	"CAN_CONNECT":    2,
+	"CANNOT_CONNECT": 3,
+}
+
+func (x Event_Connectedness) Enum() *Event_Connectedness {
+	p := new(Event_Connectedness)
+	*p = x
+	return p
+}
+
+func (x Event_Connectedness) String() string {
+	return proto.EnumName(Event_Connectedness_name, int32(x))
+}
+
+func (x *Event_Connectedness) UnmarshalJSON(data []byte) error {
+	value, err := proto.UnmarshalJSONEnum(Event_Connectedness_value, data, "Event_Connectedness")
+	if err != nil {
+		return err
+	}
+	*x = Event_Connectedness(value)
+	return nil
+}
+
+func (Event_Connectedness) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{76, 2}
 }
 
 type Request struct {
-	Type                 *Request_Type         `protobuf:"varint,1,req,name=type,enum=p2pd.pb.Request_Type" json:"type,omitempty"`
-	Connect              *ConnectRequest       `protobuf:"bytes,2,opt,name=connect" json:"connect,omitempty"`
-	StreamOpen           *StreamOpenRequest    `protobuf:"bytes,3,opt,name=streamOpen" json:"streamOpen,omitempty"`
-	StreamHandler        *StreamHandlerRequest `protobuf:"bytes,4,opt,name=streamHandler" json:"streamHandler,omitempty"`
-	Dht                  *DHTRequest           `protobuf:"bytes,5,opt,name=dht" json:"dht,omitempty"`
-	ConnManager          *ConnManagerRequest   `protobuf:"bytes,6,opt,name=connManager" json:"connManager,omitempty"`
-	Disconnect           *DisconnectRequest    `protobuf:"bytes,7,opt,name=disconnect" json:"disconnect,omitempty"`
-	Pubsub               *PSRequest            `protobuf:"bytes,8,opt,name=pubsub" json:"pubsub,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+	Type                 *Request_Type             `protobuf:"varint,1,req,name=type,enum=p2pd.pb.Request_Type" json:"type,omitempty"`
+	Connect              *ConnectRequest           `protobuf:"bytes,2,opt,name=connect" json:"connect,omitempty"`
+	StreamOpen           *StreamOpenRequest        `protobuf:"bytes,3,opt,name=streamOpen" json:"streamOpen,omitempty"`
+	StreamHandler        *StreamHandlerRequest     `protobuf:"bytes,4,opt,name=streamHandler" json:"streamHandler,omitempty"`
+	Dht                  *DHTRequest               `protobuf:"bytes,5,opt,name=dht" json:"dht,omitempty"`
+	ConnManager          *ConnManagerRequest       `protobuf:"bytes,6,opt,name=connManager" json:"connManager,omitempty"`
+	Disconnect           *DisconnectRequest        `protobuf:"bytes,7,opt,name=disconnect" json:"disconnect,omitempty"`
+	Pubsub               *PSRequest                `protobuf:"bytes,8,opt,name=pubsub" json:"pubsub,omitempty"`
+	Ping                 *PingRequest              `protobuf:"bytes,9,opt,name=ping" json:"ping,omitempty"`
+	SetBootstrapPeers    *SetBootstrapPeersRequest `protobuf:"bytes,10,opt,name=setBootstrapPeers" json:"setBootstrapPeers,omitempty"`
+	PeerLists            *PeerListsRequest         `protobuf:"bytes,11,opt,name=peerLists" json:"peerLists,omitempty"`
+	Bandwidth            *BandwidthRequest         `protobuf:"bytes,12,opt,name=bandwidth" json:"bandwidth,omitempty"`
+	FindPeer             *FindPeerRequest          `protobuf:"bytes,13,opt,name=findPeer" json:"findPeer,omitempty"`
+	RoutingTable         *RoutingTableRequest      `protobuf:"bytes,14,opt,name=routingTable" json:"routingTable,omitempty"`
+	RotateIdentity       *RotateIdentityRequest    `protobuf:"bytes,15,opt,name=rotateIdentity" json:"rotateIdentity,omitempty"`
+	AutoNAT              *AutoNATRequest           `protobuf:"bytes,16,opt,name=autoNAT" json:"autoNAT,omitempty"`
+	Peerstore            *PeerstoreRequest         `protobuf:"bytes,17,opt,name=peerstore" json:"peerstore,omitempty"`
+	PeerMetadata         *PeerMetadataRequest      `protobuf:"bytes,18,opt,name=peerMetadata" json:"peerMetadata,omitempty"`
+	LogLevel             *LogLevelRequest          `protobuf:"bytes,19,opt,name=logLevel" json:"logLevel,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
 }
 
 func (m *Request) Reset()         { *m = Request{} }
@@ -423,42 +976,143 @@ func (m *Request) GetPubsub() *PSRequest {
 	return nil
 }
 
-type Response struct {
-	Type                 *Response_Type    `protobuf:"varint,1,req,name=type,enum=p2pd.pb.Response_Type" json:"type,omitempty"`
-	Error                *ErrorResponse    `protobuf:"bytes,2,opt,name=error" json:"error,omitempty"`
-	StreamInfo           *StreamInfo       `protobuf:"bytes,3,opt,name=streamInfo" json:"streamInfo,omitempty"`
-	Identify             *IdentifyResponse `protobuf:"bytes,4,opt,name=identify" json:"identify,omitempty"`
-	Dht                  *DHTResponse      `protobuf:"bytes,5,opt,name=dht" json:"dht,omitempty"`
-	Peers                []*PeerInfo       `protobuf:"bytes,6,rep,name=peers" json:"peers,omitempty"`
-	Pubsub               *PSResponse       `protobuf:"bytes,7,opt,name=pubsub" json:"pubsub,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+func (m *Request) GetPing() *PingRequest {
+	if m != nil {
+		return m.Ping
+	}
+	return nil
 }
 
-func (m *Response) Reset()         { *m = Response{} }
-func (m *Response) String() string { return proto.CompactTextString(m) }
-func (*Response) ProtoMessage()    {}
-func (*Response) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7333f0e9b622f7df, []int{1}
-}
-func (m *Response) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
+func (m *Request) GetSetBootstrapPeers() *SetBootstrapPeersRequest {
+	if m != nil {
+		return m.SetBootstrapPeers
+	}
+	return nil
 }
-func (m *Response) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_Response.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalToSizedBuffer(b)
-		if err != nil {
-			return nil, err
-		}
-		return b[:n], nil
+
+func (m *Request) GetPeerLists() *PeerListsRequest {
+	if m != nil {
+		return m.PeerLists
 	}
+	return nil
 }
-func (m *Response) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Response.Merge(m, src)
+
+func (m *Request) GetBandwidth() *BandwidthRequest {
+	if m != nil {
+		return m.Bandwidth
+	}
+	return nil
+}
+
+func (m *Request) GetFindPeer() *FindPeerRequest {
+	if m != nil {
+		return m.FindPeer
+	}
+	return nil
+}
+
+func (m *Request) GetRoutingTable() *RoutingTableRequest {
+	if m != nil {
+		return m.RoutingTable
+	}
+	return nil
+}
+
+func (m *Request) GetRotateIdentity() *RotateIdentityRequest {
+	if m != nil {
+		return m.RotateIdentity
+	}
+	return nil
+}
+
+func (m *Request) GetAutoNAT() *AutoNATRequest {
+	if m != nil {
+		return m.AutoNAT
+	}
+	return nil
+}
+
+func (m *Request) GetPeerstore() *PeerstoreRequest {
+	if m != nil {
+		return m.Peerstore
+	}
+	return nil
+}
+
+func (m *Request) GetPeerMetadata() *PeerMetadataRequest {
+	if m != nil {
+		return m.PeerMetadata
+	}
+	return nil
+}
+
+func (m *Request) GetLogLevel() *LogLevelRequest {
+	if m != nil {
+		return m.LogLevel
+	}
+	return nil
+}
+
+type Response struct {
+	Type           *Response_Type         `protobuf:"varint,1,req,name=type,enum=p2pd.pb.Response_Type" json:"type,omitempty"`
+	Error          *ErrorResponse         `protobuf:"bytes,2,opt,name=error" json:"error,omitempty"`
+	StreamInfo     *StreamInfo            `protobuf:"bytes,3,opt,name=streamInfo" json:"streamInfo,omitempty"`
+	Identify       *IdentifyResponse      `protobuf:"bytes,4,opt,name=identify" json:"identify,omitempty"`
+	Dht            *DHTResponse           `protobuf:"bytes,5,opt,name=dht" json:"dht,omitempty"`
+	Peers          []*PeerInfo            `protobuf:"bytes,6,rep,name=peers" json:"peers,omitempty"`
+	Pubsub         *PSResponse            `protobuf:"bytes,7,opt,name=pubsub" json:"pubsub,omitempty"`
+	ConnectedPeers []*ConnectedPeer       `protobuf:"bytes,8,rep,name=connectedPeers" json:"connectedPeers,omitempty"`
+	Ping           *PingResponse          `protobuf:"bytes,9,opt,name=ping" json:"ping,omitempty"`
+	PeerLists      *PeerListsResponse     `protobuf:"bytes,10,opt,name=peerLists" json:"peerLists,omitempty"`
+	ResourceUsage  *ResourceUsageResponse `protobuf:"bytes,11,opt,name=resourceUsage" json:"resourceUsage,omitempty"`
+	Relays         []*RelayInfo           `protobuf:"bytes,12,rep,name=relays" json:"relays,omitempty"`
+	Connect        *ConnectResponse       `protobuf:"bytes,13,opt,name=connect" json:"connect,omitempty"`
+	Bandwidth      *BandwidthResponse     `protobuf:"bytes,14,opt,name=bandwidth" json:"bandwidth,omitempty"`
+	Peer           *PeerInfo              `protobuf:"bytes,15,opt,name=peer" json:"peer,omitempty"`
+	// set in the response to PERSISTENT_CONN_UPGRADE: a random id of the daemon
+	// instance, which changes when the daemon restarts. Handlers registered on
+	// persistent connections don't survive a restart, so a client reconnecting
+	// to a daemon with another instance id must register them again.
+	InstanceId   []byte                `protobuf:"bytes,16,opt,name=instanceId" json:"instanceId,omitempty"`
+	RoutingTable *RoutingTableResponse `protobuf:"bytes,17,opt,name=routingTable" json:"routingTable,omitempty"`
+	Disconnect   *DisconnectResponse   `protobuf:"bytes,18,opt,name=disconnect" json:"disconnect,omitempty"`
+	DaemonInfo   *DaemonInfoResponse   `protobuf:"bytes,19,opt,name=daemonInfo" json:"daemonInfo,omitempty"`
+	AutoNAT      *AutoNATResponse      `protobuf:"bytes,20,opt,name=autoNAT" json:"autoNAT,omitempty"`
+	// oldest first
+	CallHistory []*CallRecord      `protobuf:"bytes,21,rep,name=callHistory" json:"callHistory,omitempty"`
+	Addrs       *AddrsResponse     `protobuf:"bytes,22,opt,name=addrs" json:"addrs,omitempty"`
+	Peerstore   *PeerstoreResponse `protobuf:"bytes,23,opt,name=peerstore" json:"peerstore,omitempty"`
+	Metadata    *Metadata          `protobuf:"bytes,24,opt,name=metadata" json:"metadata,omitempty"`
+	// the level of every subsystem, sorted by name
+	LogLevels            []*SubsystemLogLevel `protobuf:"bytes,25,rep,name=logLevels" json:"logLevels,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Response) Reset()         { *m = Response{} }
+func (m *Response) String() string { return proto.CompactTextString(m) }
+func (*Response) ProtoMessage()    {}
+func (*Response) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{1}
+}
+func (m *Response) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Response) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Response.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Response) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Response.Merge(m, src)
 }
 func (m *Response) XXX_Size() int {
 	return m.Size()
@@ -518,6 +1172,132 @@ func (m *Response) GetPubsub() *PSResponse {
 	return nil
 }
 
+func (m *Response) GetConnectedPeers() []*ConnectedPeer {
+	if m != nil {
+		return m.ConnectedPeers
+	}
+	return nil
+}
+
+func (m *Response) GetPing() *PingResponse {
+	if m != nil {
+		return m.Ping
+	}
+	return nil
+}
+
+func (m *Response) GetPeerLists() *PeerListsResponse {
+	if m != nil {
+		return m.PeerLists
+	}
+	return nil
+}
+
+func (m *Response) GetResourceUsage() *ResourceUsageResponse {
+	if m != nil {
+		return m.ResourceUsage
+	}
+	return nil
+}
+
+func (m *Response) GetRelays() []*RelayInfo {
+	if m != nil {
+		return m.Relays
+	}
+	return nil
+}
+
+func (m *Response) GetConnect() *ConnectResponse {
+	if m != nil {
+		return m.Connect
+	}
+	return nil
+}
+
+func (m *Response) GetBandwidth() *BandwidthResponse {
+	if m != nil {
+		return m.Bandwidth
+	}
+	return nil
+}
+
+func (m *Response) GetPeer() *PeerInfo {
+	if m != nil {
+		return m.Peer
+	}
+	return nil
+}
+
+func (m *Response) GetInstanceId() []byte {
+	if m != nil {
+		return m.InstanceId
+	}
+	return nil
+}
+
+func (m *Response) GetRoutingTable() *RoutingTableResponse {
+	if m != nil {
+		return m.RoutingTable
+	}
+	return nil
+}
+
+func (m *Response) GetDisconnect() *DisconnectResponse {
+	if m != nil {
+		return m.Disconnect
+	}
+	return nil
+}
+
+func (m *Response) GetDaemonInfo() *DaemonInfoResponse {
+	if m != nil {
+		return m.DaemonInfo
+	}
+	return nil
+}
+
+func (m *Response) GetAutoNAT() *AutoNATResponse {
+	if m != nil {
+		return m.AutoNAT
+	}
+	return nil
+}
+
+func (m *Response) GetCallHistory() []*CallRecord {
+	if m != nil {
+		return m.CallHistory
+	}
+	return nil
+}
+
+func (m *Response) GetAddrs() *AddrsResponse {
+	if m != nil {
+		return m.Addrs
+	}
+	return nil
+}
+
+func (m *Response) GetPeerstore() *PeerstoreResponse {
+	if m != nil {
+		return m.Peerstore
+	}
+	return nil
+}
+
+func (m *Response) GetMetadata() *Metadata {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *Response) GetLogLevels() []*SubsystemLogLevel {
+	if m != nil {
+		return m.LogLevels
+	}
+	return nil
+}
+
 type PersistentConnectionRequest struct {
 	CallId []byte `protobuf:"bytes,1,req,name=callId" json:"callId,omitempty"`
 	// Types that are valid to be assigned to Message:
@@ -525,6 +1305,17 @@ type PersistentConnectionRequest struct {
 	//	*PersistentConnectionRequest_CallUnary
 	//	*PersistentConnectionRequest_UnaryResponse
 	//	*PersistentConnectionRequest_Cancel
+	//	*PersistentConnectionRequest_CallStream
+	//	*PersistentConnectionRequest_GetStats
+	//	*PersistentConnectionRequest_SubscribeEvents
+	//	*PersistentConnectionRequest_Pong
+	//	*PersistentConnectionRequest_CancelAll
+	//	*PersistentConnectionRequest_StreamFrame
+	//	*PersistentConnectionRequest_Pubsub
+	//	*PersistentConnectionRequest_AddTopicValidator
+	//	*PersistentConnectionRequest_ValidationResult
+	//	*PersistentConnectionRequest_RemoveUnaryHandler
+	//	*PersistentConnectionRequest_Chunk
 	Message              isPersistentConnectionRequest_Message `protobuf_oneof:"message"`
 	XXX_NoUnkeyedLiteral struct{}                              `json:"-"`
 	XXX_unrecognized     []byte                                `json:"-"`
@@ -582,11 +1373,55 @@ type PersistentConnectionRequest_UnaryResponse struct {
 type PersistentConnectionRequest_Cancel struct {
 	Cancel *Cancel `protobuf:"bytes,5,opt,name=cancel,oneof" json:"cancel,omitempty"`
 }
+type PersistentConnectionRequest_CallStream struct {
+	CallStream *CallUnaryRequest `protobuf:"bytes,6,opt,name=callStream,oneof" json:"callStream,omitempty"`
+}
+type PersistentConnectionRequest_GetStats struct {
+	GetStats *GetStatsRequest `protobuf:"bytes,7,opt,name=getStats,oneof" json:"getStats,omitempty"`
+}
+type PersistentConnectionRequest_SubscribeEvents struct {
+	SubscribeEvents *SubscribeEventsRequest `protobuf:"bytes,8,opt,name=subscribeEvents,oneof" json:"subscribeEvents,omitempty"`
+}
+type PersistentConnectionRequest_Pong struct {
+	Pong *Pong `protobuf:"bytes,9,opt,name=pong,oneof" json:"pong,omitempty"`
+}
+type PersistentConnectionRequest_CancelAll struct {
+	CancelAll *CancelAll `protobuf:"bytes,10,opt,name=cancelAll,oneof" json:"cancelAll,omitempty"`
+}
+type PersistentConnectionRequest_StreamFrame struct {
+	StreamFrame *StreamFrame `protobuf:"bytes,11,opt,name=streamFrame,oneof" json:"streamFrame,omitempty"`
+}
+type PersistentConnectionRequest_Pubsub struct {
+	Pubsub *PSRequest `protobuf:"bytes,12,opt,name=pubsub,oneof" json:"pubsub,omitempty"`
+}
+type PersistentConnectionRequest_AddTopicValidator struct {
+	AddTopicValidator *AddTopicValidatorRequest `protobuf:"bytes,13,opt,name=addTopicValidator,oneof" json:"addTopicValidator,omitempty"`
+}
+type PersistentConnectionRequest_ValidationResult struct {
+	ValidationResult *ValidationResult `protobuf:"bytes,14,opt,name=validationResult,oneof" json:"validationResult,omitempty"`
+}
+type PersistentConnectionRequest_RemoveUnaryHandler struct {
+	RemoveUnaryHandler *RemoveUnaryHandlerRequest `protobuf:"bytes,15,opt,name=removeUnaryHandler,oneof" json:"removeUnaryHandler,omitempty"`
+}
+type PersistentConnectionRequest_Chunk struct {
+	Chunk *PayloadChunk `protobuf:"bytes,16,opt,name=chunk,oneof" json:"chunk,omitempty"`
+}
 
-func (*PersistentConnectionRequest_AddUnaryHandler) isPersistentConnectionRequest_Message() {}
-func (*PersistentConnectionRequest_CallUnary) isPersistentConnectionRequest_Message()       {}
-func (*PersistentConnectionRequest_UnaryResponse) isPersistentConnectionRequest_Message()   {}
-func (*PersistentConnectionRequest_Cancel) isPersistentConnectionRequest_Message()          {}
+func (*PersistentConnectionRequest_AddUnaryHandler) isPersistentConnectionRequest_Message()    {}
+func (*PersistentConnectionRequest_CallUnary) isPersistentConnectionRequest_Message()          {}
+func (*PersistentConnectionRequest_UnaryResponse) isPersistentConnectionRequest_Message()      {}
+func (*PersistentConnectionRequest_Cancel) isPersistentConnectionRequest_Message()             {}
+func (*PersistentConnectionRequest_CallStream) isPersistentConnectionRequest_Message()         {}
+func (*PersistentConnectionRequest_GetStats) isPersistentConnectionRequest_Message()           {}
+func (*PersistentConnectionRequest_SubscribeEvents) isPersistentConnectionRequest_Message()    {}
+func (*PersistentConnectionRequest_Pong) isPersistentConnectionRequest_Message()               {}
+func (*PersistentConnectionRequest_CancelAll) isPersistentConnectionRequest_Message()          {}
+func (*PersistentConnectionRequest_StreamFrame) isPersistentConnectionRequest_Message()        {}
+func (*PersistentConnectionRequest_Pubsub) isPersistentConnectionRequest_Message()             {}
+func (*PersistentConnectionRequest_AddTopicValidator) isPersistentConnectionRequest_Message()  {}
+func (*PersistentConnectionRequest_ValidationResult) isPersistentConnectionRequest_Message()   {}
+func (*PersistentConnectionRequest_RemoveUnaryHandler) isPersistentConnectionRequest_Message() {}
+func (*PersistentConnectionRequest_Chunk) isPersistentConnectionRequest_Message()              {}
 
 func (m *PersistentConnectionRequest) GetMessage() isPersistentConnectionRequest_Message {
 	if m != nil {
@@ -630,6 +1465,83 @@ func (m *PersistentConnectionRequest) GetCancel() *Cancel {
 	return nil
 }
 
+func (m *PersistentConnectionRequest) GetCallStream() *CallUnaryRequest {
+	if x, ok := m.GetMessage().(*PersistentConnectionRequest_CallStream); ok {
+		return x.CallStream
+	}
+	return nil
+}
+
+func (m *PersistentConnectionRequest) GetGetStats() *GetStatsRequest {
+	if x, ok := m.GetMessage().(*PersistentConnectionRequest_GetStats); ok {
+		return x.GetStats
+	}
+	return nil
+}
+
+func (m *PersistentConnectionRequest) GetSubscribeEvents() *SubscribeEventsRequest {
+	if x, ok := m.GetMessage().(*PersistentConnectionRequest_SubscribeEvents); ok {
+		return x.SubscribeEvents
+	}
+	return nil
+}
+
+func (m *PersistentConnectionRequest) GetPong() *Pong {
+	if x, ok := m.GetMessage().(*PersistentConnectionRequest_Pong); ok {
+		return x.Pong
+	}
+	return nil
+}
+
+func (m *PersistentConnectionRequest) GetCancelAll() *CancelAll {
+	if x, ok := m.GetMessage().(*PersistentConnectionRequest_CancelAll); ok {
+		return x.CancelAll
+	}
+	return nil
+}
+
+func (m *PersistentConnectionRequest) GetStreamFrame() *StreamFrame {
+	if x, ok := m.GetMessage().(*PersistentConnectionRequest_StreamFrame); ok {
+		return x.StreamFrame
+	}
+	return nil
+}
+
+func (m *PersistentConnectionRequest) GetPubsub() *PSRequest {
+	if x, ok := m.GetMessage().(*PersistentConnectionRequest_Pubsub); ok {
+		return x.Pubsub
+	}
+	return nil
+}
+
+func (m *PersistentConnectionRequest) GetAddTopicValidator() *AddTopicValidatorRequest {
+	if x, ok := m.GetMessage().(*PersistentConnectionRequest_AddTopicValidator); ok {
+		return x.AddTopicValidator
+	}
+	return nil
+}
+
+func (m *PersistentConnectionRequest) GetValidationResult() *ValidationResult {
+	if x, ok := m.GetMessage().(*PersistentConnectionRequest_ValidationResult); ok {
+		return x.ValidationResult
+	}
+	return nil
+}
+
+func (m *PersistentConnectionRequest) GetRemoveUnaryHandler() *RemoveUnaryHandlerRequest {
+	if x, ok := m.GetMessage().(*PersistentConnectionRequest_RemoveUnaryHandler); ok {
+		return x.RemoveUnaryHandler
+	}
+	return nil
+}
+
+func (m *PersistentConnectionRequest) GetChunk() *PayloadChunk {
+	if x, ok := m.GetMessage().(*PersistentConnectionRequest_Chunk); ok {
+		return x.Chunk
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*PersistentConnectionRequest) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -637,6 +1549,17 @@ func (*PersistentConnectionRequest) XXX_OneofWrappers() []interface{} {
 		(*PersistentConnectionRequest_CallUnary)(nil),
 		(*PersistentConnectionRequest_UnaryResponse)(nil),
 		(*PersistentConnectionRequest_Cancel)(nil),
+		(*PersistentConnectionRequest_CallStream)(nil),
+		(*PersistentConnectionRequest_GetStats)(nil),
+		(*PersistentConnectionRequest_SubscribeEvents)(nil),
+		(*PersistentConnectionRequest_Pong)(nil),
+		(*PersistentConnectionRequest_CancelAll)(nil),
+		(*PersistentConnectionRequest_StreamFrame)(nil),
+		(*PersistentConnectionRequest_Pubsub)(nil),
+		(*PersistentConnectionRequest_AddTopicValidator)(nil),
+		(*PersistentConnectionRequest_ValidationResult)(nil),
+		(*PersistentConnectionRequest_RemoveUnaryHandler)(nil),
+		(*PersistentConnectionRequest_Chunk)(nil),
 	}
 }
 
@@ -647,6 +1570,16 @@ type PersistentConnectionResponse struct {
 	//	*PersistentConnectionResponse_RequestHandling
 	//	*PersistentConnectionResponse_DaemonError
 	//	*PersistentConnectionResponse_Cancel
+	//	*PersistentConnectionResponse_EndOfStream
+	//	*PersistentConnectionResponse_Stats
+	//	*PersistentConnectionResponse_Event
+	//	*PersistentConnectionResponse_Ping
+	//	*PersistentConnectionResponse_CancelAll
+	//	*PersistentConnectionResponse_IncomingStream
+	//	*PersistentConnectionResponse_StreamFrame
+	//	*PersistentConnectionResponse_PubsubMessage
+	//	*PersistentConnectionResponse_ValidateMessage
+	//	*PersistentConnectionResponse_Chunk
 	Message              isPersistentConnectionResponse_Message `protobuf_oneof:"message"`
 	XXX_NoUnkeyedLiteral struct{}                               `json:"-"`
 	XXX_unrecognized     []byte                                 `json:"-"`
@@ -704,11 +1637,51 @@ type PersistentConnectionResponse_DaemonError struct {
 type PersistentConnectionResponse_Cancel struct {
 	Cancel *Cancel `protobuf:"bytes,5,opt,name=cancel,oneof" json:"cancel,omitempty"`
 }
+type PersistentConnectionResponse_EndOfStream struct {
+	EndOfStream *EndOfStream `protobuf:"bytes,6,opt,name=endOfStream,oneof" json:"endOfStream,omitempty"`
+}
+type PersistentConnectionResponse_Stats struct {
+	Stats *StatsResponse `protobuf:"bytes,7,opt,name=stats,oneof" json:"stats,omitempty"`
+}
+type PersistentConnectionResponse_Event struct {
+	Event *Event `protobuf:"bytes,8,opt,name=event,oneof" json:"event,omitempty"`
+}
+type PersistentConnectionResponse_Ping struct {
+	Ping *Ping `protobuf:"bytes,9,opt,name=ping,oneof" json:"ping,omitempty"`
+}
+type PersistentConnectionResponse_CancelAll struct {
+	CancelAll *CancelAllResponse `protobuf:"bytes,10,opt,name=cancelAll,oneof" json:"cancelAll,omitempty"`
+}
+type PersistentConnectionResponse_IncomingStream struct {
+	IncomingStream *StreamInfo `protobuf:"bytes,11,opt,name=incomingStream,oneof" json:"incomingStream,omitempty"`
+}
+type PersistentConnectionResponse_StreamFrame struct {
+	StreamFrame *StreamFrame `protobuf:"bytes,12,opt,name=streamFrame,oneof" json:"streamFrame,omitempty"`
+}
+type PersistentConnectionResponse_PubsubMessage struct {
+	PubsubMessage *PSMessage `protobuf:"bytes,13,opt,name=pubsubMessage,oneof" json:"pubsubMessage,omitempty"`
+}
+type PersistentConnectionResponse_ValidateMessage struct {
+	ValidateMessage *ValidateMessage `protobuf:"bytes,14,opt,name=validateMessage,oneof" json:"validateMessage,omitempty"`
+}
+type PersistentConnectionResponse_Chunk struct {
+	Chunk *PayloadChunk `protobuf:"bytes,15,opt,name=chunk,oneof" json:"chunk,omitempty"`
+}
 
 func (*PersistentConnectionResponse_CallUnaryResponse) isPersistentConnectionResponse_Message() {}
 func (*PersistentConnectionResponse_RequestHandling) isPersistentConnectionResponse_Message()   {}
 func (*PersistentConnectionResponse_DaemonError) isPersistentConnectionResponse_Message()       {}
 func (*PersistentConnectionResponse_Cancel) isPersistentConnectionResponse_Message()            {}
+func (*PersistentConnectionResponse_EndOfStream) isPersistentConnectionResponse_Message()       {}
+func (*PersistentConnectionResponse_Stats) isPersistentConnectionResponse_Message()             {}
+func (*PersistentConnectionResponse_Event) isPersistentConnectionResponse_Message()             {}
+func (*PersistentConnectionResponse_Ping) isPersistentConnectionResponse_Message()              {}
+func (*PersistentConnectionResponse_CancelAll) isPersistentConnectionResponse_Message()         {}
+func (*PersistentConnectionResponse_IncomingStream) isPersistentConnectionResponse_Message()    {}
+func (*PersistentConnectionResponse_StreamFrame) isPersistentConnectionResponse_Message()       {}
+func (*PersistentConnectionResponse_PubsubMessage) isPersistentConnectionResponse_Message()     {}
+func (*PersistentConnectionResponse_ValidateMessage) isPersistentConnectionResponse_Message()   {}
+func (*PersistentConnectionResponse_Chunk) isPersistentConnectionResponse_Message()             {}
 
 func (m *PersistentConnectionResponse) GetMessage() isPersistentConnectionResponse_Message {
 	if m != nil {
@@ -752,39 +1725,121 @@ func (m *PersistentConnectionResponse) GetCancel() *Cancel {
 	return nil
 }
 
-// XXX_OneofWrappers is for the internal use of the proto package.
-func (*PersistentConnectionResponse) XXX_OneofWrappers() []interface{} {
-	return []interface{}{
-		(*PersistentConnectionResponse_CallUnaryResponse)(nil),
-		(*PersistentConnectionResponse_RequestHandling)(nil),
-		(*PersistentConnectionResponse_DaemonError)(nil),
-		(*PersistentConnectionResponse_Cancel)(nil),
+func (m *PersistentConnectionResponse) GetEndOfStream() *EndOfStream {
+	if x, ok := m.GetMessage().(*PersistentConnectionResponse_EndOfStream); ok {
+		return x.EndOfStream
 	}
+	return nil
 }
 
-type IdentifyResponse struct {
-	Id                   []byte   `protobuf:"bytes,1,req,name=id" json:"id,omitempty"`
-	Addrs                [][]byte `protobuf:"bytes,2,rep,name=addrs" json:"addrs,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *PersistentConnectionResponse) GetStats() *StatsResponse {
+	if x, ok := m.GetMessage().(*PersistentConnectionResponse_Stats); ok {
+		return x.Stats
+	}
+	return nil
 }
 
-func (m *IdentifyResponse) Reset()         { *m = IdentifyResponse{} }
-func (m *IdentifyResponse) String() string { return proto.CompactTextString(m) }
-func (*IdentifyResponse) ProtoMessage()    {}
-func (*IdentifyResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7333f0e9b622f7df, []int{4}
+func (m *PersistentConnectionResponse) GetEvent() *Event {
+	if x, ok := m.GetMessage().(*PersistentConnectionResponse_Event); ok {
+		return x.Event
+	}
+	return nil
 }
-func (m *IdentifyResponse) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
+
+func (m *PersistentConnectionResponse) GetPing() *Ping {
+	if x, ok := m.GetMessage().(*PersistentConnectionResponse_Ping); ok {
+		return x.Ping
+	}
+	return nil
 }
-func (m *IdentifyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_IdentifyResponse.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalToSizedBuffer(b)
+
+func (m *PersistentConnectionResponse) GetCancelAll() *CancelAllResponse {
+	if x, ok := m.GetMessage().(*PersistentConnectionResponse_CancelAll); ok {
+		return x.CancelAll
+	}
+	return nil
+}
+
+func (m *PersistentConnectionResponse) GetIncomingStream() *StreamInfo {
+	if x, ok := m.GetMessage().(*PersistentConnectionResponse_IncomingStream); ok {
+		return x.IncomingStream
+	}
+	return nil
+}
+
+func (m *PersistentConnectionResponse) GetStreamFrame() *StreamFrame {
+	if x, ok := m.GetMessage().(*PersistentConnectionResponse_StreamFrame); ok {
+		return x.StreamFrame
+	}
+	return nil
+}
+
+func (m *PersistentConnectionResponse) GetPubsubMessage() *PSMessage {
+	if x, ok := m.GetMessage().(*PersistentConnectionResponse_PubsubMessage); ok {
+		return x.PubsubMessage
+	}
+	return nil
+}
+
+func (m *PersistentConnectionResponse) GetValidateMessage() *ValidateMessage {
+	if x, ok := m.GetMessage().(*PersistentConnectionResponse_ValidateMessage); ok {
+		return x.ValidateMessage
+	}
+	return nil
+}
+
+func (m *PersistentConnectionResponse) GetChunk() *PayloadChunk {
+	if x, ok := m.GetMessage().(*PersistentConnectionResponse_Chunk); ok {
+		return x.Chunk
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*PersistentConnectionResponse) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*PersistentConnectionResponse_CallUnaryResponse)(nil),
+		(*PersistentConnectionResponse_RequestHandling)(nil),
+		(*PersistentConnectionResponse_DaemonError)(nil),
+		(*PersistentConnectionResponse_Cancel)(nil),
+		(*PersistentConnectionResponse_EndOfStream)(nil),
+		(*PersistentConnectionResponse_Stats)(nil),
+		(*PersistentConnectionResponse_Event)(nil),
+		(*PersistentConnectionResponse_Ping)(nil),
+		(*PersistentConnectionResponse_CancelAll)(nil),
+		(*PersistentConnectionResponse_IncomingStream)(nil),
+		(*PersistentConnectionResponse_StreamFrame)(nil),
+		(*PersistentConnectionResponse_PubsubMessage)(nil),
+		(*PersistentConnectionResponse_ValidateMessage)(nil),
+		(*PersistentConnectionResponse_Chunk)(nil),
+	}
+}
+
+type IdentifyResponse struct {
+	Id    []byte   `protobuf:"bytes,1,req,name=id" json:"id,omitempty"`
+	Addrs [][]byte `protobuf:"bytes,2,rep,name=addrs" json:"addrs,omitempty"`
+	// the envelope of the host's signed peer record, if its peerstore keeps one
+	SignedPeerRecord     []byte   `protobuf:"bytes,3,opt,name=signedPeerRecord" json:"signedPeerRecord,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IdentifyResponse) Reset()         { *m = IdentifyResponse{} }
+func (m *IdentifyResponse) String() string { return proto.CompactTextString(m) }
+func (*IdentifyResponse) ProtoMessage()    {}
+func (*IdentifyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{4}
+}
+func (m *IdentifyResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *IdentifyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_IdentifyResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
 			return nil, err
 		}
@@ -817,27 +1872,39 @@ func (m *IdentifyResponse) GetAddrs() [][]byte {
 	return nil
 }
 
-type ConnectRequest struct {
-	Peer                 []byte   `protobuf:"bytes,1,req,name=peer" json:"peer,omitempty"`
-	Addrs                [][]byte `protobuf:"bytes,2,rep,name=addrs" json:"addrs,omitempty"`
-	Timeout              *int64   `protobuf:"varint,3,opt,name=timeout" json:"timeout,omitempty"`
+func (m *IdentifyResponse) GetSignedPeerRecord() []byte {
+	if m != nil {
+		return m.SignedPeerRecord
+	}
+	return nil
+}
+
+// RotateIdentityRequest has the daemon start a new host with a freshly
+// generated key and hand the control socket over to it
+type RotateIdentityRequest struct {
+	// type of the new key: rsa, ed25519 or secp256k1; the type of the current
+	// key if unset
+	KeyType *string `protobuf:"bytes,1,opt,name=keyType" json:"keyType,omitempty"`
+	// how long the old host keeps serving persistent connections and in-flight
+	// calls before closing, in milliseconds
+	GracePeriodMs        *int64   `protobuf:"varint,2,opt,name=gracePeriodMs" json:"gracePeriodMs,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ConnectRequest) Reset()         { *m = ConnectRequest{} }
-func (m *ConnectRequest) String() string { return proto.CompactTextString(m) }
-func (*ConnectRequest) ProtoMessage()    {}
-func (*ConnectRequest) Descriptor() ([]byte, []int) {
+func (m *RotateIdentityRequest) Reset()         { *m = RotateIdentityRequest{} }
+func (m *RotateIdentityRequest) String() string { return proto.CompactTextString(m) }
+func (*RotateIdentityRequest) ProtoMessage()    {}
+func (*RotateIdentityRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_7333f0e9b622f7df, []int{5}
 }
-func (m *ConnectRequest) XXX_Unmarshal(b []byte) error {
+func (m *RotateIdentityRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ConnectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *RotateIdentityRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ConnectRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_RotateIdentityRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -847,60 +1914,67 @@ func (m *ConnectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, erro
 		return b[:n], nil
 	}
 }
-func (m *ConnectRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ConnectRequest.Merge(m, src)
+func (m *RotateIdentityRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RotateIdentityRequest.Merge(m, src)
 }
-func (m *ConnectRequest) XXX_Size() int {
+func (m *RotateIdentityRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *ConnectRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ConnectRequest.DiscardUnknown(m)
+func (m *RotateIdentityRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RotateIdentityRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ConnectRequest proto.InternalMessageInfo
-
-func (m *ConnectRequest) GetPeer() []byte {
-	if m != nil {
-		return m.Peer
-	}
-	return nil
-}
+var xxx_messageInfo_RotateIdentityRequest proto.InternalMessageInfo
 
-func (m *ConnectRequest) GetAddrs() [][]byte {
-	if m != nil {
-		return m.Addrs
+func (m *RotateIdentityRequest) GetKeyType() string {
+	if m != nil && m.KeyType != nil {
+		return *m.KeyType
 	}
-	return nil
+	return ""
 }
 
-func (m *ConnectRequest) GetTimeout() int64 {
-	if m != nil && m.Timeout != nil {
-		return *m.Timeout
+func (m *RotateIdentityRequest) GetGracePeriodMs() int64 {
+	if m != nil && m.GracePeriodMs != nil {
+		return *m.GracePeriodMs
 	}
 	return 0
 }
 
-type StreamOpenRequest struct {
-	Peer                 []byte   `protobuf:"bytes,1,req,name=peer" json:"peer,omitempty"`
-	Proto                []string `protobuf:"bytes,2,rep,name=proto" json:"proto,omitempty"`
-	Timeout              *int64   `protobuf:"varint,3,opt,name=timeout" json:"timeout,omitempty"`
+// DaemonInfoResponse characterizes the daemon; times are in nanoseconds
+type DaemonInfoResponse struct {
+	// unix time the daemon started at
+	StartTime *int64 `protobuf:"varint,1,req,name=startTime" json:"startTime,omitempty"`
+	Uptime    *int64 `protobuf:"varint,2,req,name=uptime" json:"uptime,omitempty"`
+	// path and version of the main module the daemon was built from, if built
+	// with module support
+	Module    *string `protobuf:"bytes,3,opt,name=module" json:"module,omitempty"`
+	Version   *string `protobuf:"bytes,4,opt,name=version" json:"version,omitempty"`
+	GoVersion *string `protobuf:"bytes,5,req,name=goVersion" json:"goVersion,omitempty"`
+	// full, client or server; empty without the DHT
+	DhtMode *string `protobuf:"bytes,6,opt,name=dhtMode" json:"dhtMode,omitempty"`
+	// floodsub or gossipsub; empty without pubsub
+	PubsubRouter *string `protobuf:"bytes,7,opt,name=pubsubRouter" json:"pubsubRouter,omitempty"`
+	// the relay transport, to dial and listen through relays
+	Relay *bool `protobuf:"varint,8,req,name=relay" json:"relay,omitempty"`
+	// the relay service, relaying connections for other peers
+	RelayHop             *bool    `protobuf:"varint,9,req,name=relayHop" json:"relayHop,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *StreamOpenRequest) Reset()         { *m = StreamOpenRequest{} }
-func (m *StreamOpenRequest) String() string { return proto.CompactTextString(m) }
-func (*StreamOpenRequest) ProtoMessage()    {}
-func (*StreamOpenRequest) Descriptor() ([]byte, []int) {
+func (m *DaemonInfoResponse) Reset()         { *m = DaemonInfoResponse{} }
+func (m *DaemonInfoResponse) String() string { return proto.CompactTextString(m) }
+func (*DaemonInfoResponse) ProtoMessage()    {}
+func (*DaemonInfoResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_7333f0e9b622f7df, []int{6}
 }
-func (m *StreamOpenRequest) XXX_Unmarshal(b []byte) error {
+func (m *DaemonInfoResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *StreamOpenRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *DaemonInfoResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_StreamOpenRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_DaemonInfoResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -910,59 +1984,104 @@ func (m *StreamOpenRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, e
 		return b[:n], nil
 	}
 }
-func (m *StreamOpenRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_StreamOpenRequest.Merge(m, src)
+func (m *DaemonInfoResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DaemonInfoResponse.Merge(m, src)
 }
-func (m *StreamOpenRequest) XXX_Size() int {
+func (m *DaemonInfoResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *StreamOpenRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_StreamOpenRequest.DiscardUnknown(m)
+func (m *DaemonInfoResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DaemonInfoResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_StreamOpenRequest proto.InternalMessageInfo
+var xxx_messageInfo_DaemonInfoResponse proto.InternalMessageInfo
 
-func (m *StreamOpenRequest) GetPeer() []byte {
-	if m != nil {
-		return m.Peer
+func (m *DaemonInfoResponse) GetStartTime() int64 {
+	if m != nil && m.StartTime != nil {
+		return *m.StartTime
 	}
-	return nil
+	return 0
 }
 
-func (m *StreamOpenRequest) GetProto() []string {
-	if m != nil {
-		return m.Proto
+func (m *DaemonInfoResponse) GetUptime() int64 {
+	if m != nil && m.Uptime != nil {
+		return *m.Uptime
 	}
-	return nil
+	return 0
 }
 
-func (m *StreamOpenRequest) GetTimeout() int64 {
-	if m != nil && m.Timeout != nil {
-		return *m.Timeout
+func (m *DaemonInfoResponse) GetModule() string {
+	if m != nil && m.Module != nil {
+		return *m.Module
 	}
-	return 0
+	return ""
 }
 
-type StreamHandlerRequest struct {
-	Addr                 []byte   `protobuf:"bytes,1,req,name=addr" json:"addr,omitempty"`
-	Proto                []string `protobuf:"bytes,2,rep,name=proto" json:"proto,omitempty"`
+func (m *DaemonInfoResponse) GetVersion() string {
+	if m != nil && m.Version != nil {
+		return *m.Version
+	}
+	return ""
+}
+
+func (m *DaemonInfoResponse) GetGoVersion() string {
+	if m != nil && m.GoVersion != nil {
+		return *m.GoVersion
+	}
+	return ""
+}
+
+func (m *DaemonInfoResponse) GetDhtMode() string {
+	if m != nil && m.DhtMode != nil {
+		return *m.DhtMode
+	}
+	return ""
+}
+
+func (m *DaemonInfoResponse) GetPubsubRouter() string {
+	if m != nil && m.PubsubRouter != nil {
+		return *m.PubsubRouter
+	}
+	return ""
+}
+
+func (m *DaemonInfoResponse) GetRelay() bool {
+	if m != nil && m.Relay != nil {
+		return *m.Relay
+	}
+	return false
+}
+
+func (m *DaemonInfoResponse) GetRelayHop() bool {
+	if m != nil && m.RelayHop != nil {
+		return *m.RelayHop
+	}
+	return false
+}
+
+// AutoNATRequest has the daemon probe its reachability right away, rather
+// than waiting for AutoNAT to do it
+type AutoNATRequest struct {
+	// ask an AutoNAT server among the connected peers to dial back
+	Probe                *bool    `protobuf:"varint,1,opt,name=probe" json:"probe,omitempty"`
+	Timeout              *int64   `protobuf:"varint,2,opt,name=timeout" json:"timeout,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *StreamHandlerRequest) Reset()         { *m = StreamHandlerRequest{} }
-func (m *StreamHandlerRequest) String() string { return proto.CompactTextString(m) }
-func (*StreamHandlerRequest) ProtoMessage()    {}
-func (*StreamHandlerRequest) Descriptor() ([]byte, []int) {
+func (m *AutoNATRequest) Reset()         { *m = AutoNATRequest{} }
+func (m *AutoNATRequest) String() string { return proto.CompactTextString(m) }
+func (*AutoNATRequest) ProtoMessage()    {}
+func (*AutoNATRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_7333f0e9b622f7df, []int{7}
 }
-func (m *StreamHandlerRequest) XXX_Unmarshal(b []byte) error {
+func (m *AutoNATRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *StreamHandlerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *AutoNATRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_StreamHandlerRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_AutoNATRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -972,51 +2091,54 @@ func (m *StreamHandlerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte
 		return b[:n], nil
 	}
 }
-func (m *StreamHandlerRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_StreamHandlerRequest.Merge(m, src)
+func (m *AutoNATRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AutoNATRequest.Merge(m, src)
 }
-func (m *StreamHandlerRequest) XXX_Size() int {
+func (m *AutoNATRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *StreamHandlerRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_StreamHandlerRequest.DiscardUnknown(m)
+func (m *AutoNATRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AutoNATRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_StreamHandlerRequest proto.InternalMessageInfo
+var xxx_messageInfo_AutoNATRequest proto.InternalMessageInfo
 
-func (m *StreamHandlerRequest) GetAddr() []byte {
-	if m != nil {
-		return m.Addr
+func (m *AutoNATRequest) GetProbe() bool {
+	if m != nil && m.Probe != nil {
+		return *m.Probe
 	}
-	return nil
+	return false
 }
 
-func (m *StreamHandlerRequest) GetProto() []string {
-	if m != nil {
-		return m.Proto
+func (m *AutoNATRequest) GetTimeout() int64 {
+	if m != nil && m.Timeout != nil {
+		return *m.Timeout
 	}
-	return nil
+	return 0
 }
 
-type ErrorResponse struct {
-	Msg                  *string  `protobuf:"bytes,1,req,name=msg" json:"msg,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type AutoNATResponse struct {
+	// the reachability last reported by AutoNAT
+	Reachability *Event_Reachability `protobuf:"varint,1,req,name=reachability,enum=p2pd.pb.Event_Reachability" json:"reachability,omitempty"`
+	// set when a probe was asked for
+	Probe                *AutoNATProbe `protobuf:"bytes,2,opt,name=probe" json:"probe,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
-func (m *ErrorResponse) Reset()         { *m = ErrorResponse{} }
-func (m *ErrorResponse) String() string { return proto.CompactTextString(m) }
-func (*ErrorResponse) ProtoMessage()    {}
-func (*ErrorResponse) Descriptor() ([]byte, []int) {
+func (m *AutoNATResponse) Reset()         { *m = AutoNATResponse{} }
+func (m *AutoNATResponse) String() string { return proto.CompactTextString(m) }
+func (*AutoNATResponse) ProtoMessage()    {}
+func (*AutoNATResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_7333f0e9b622f7df, []int{8}
 }
-func (m *ErrorResponse) XXX_Unmarshal(b []byte) error {
+func (m *AutoNATResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ErrorResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *AutoNATResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ErrorResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_AutoNATResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1026,46 +2148,56 @@ func (m *ErrorResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error
 		return b[:n], nil
 	}
 }
-func (m *ErrorResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ErrorResponse.Merge(m, src)
+func (m *AutoNATResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AutoNATResponse.Merge(m, src)
 }
-func (m *ErrorResponse) XXX_Size() int {
+func (m *AutoNATResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *ErrorResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ErrorResponse.DiscardUnknown(m)
+func (m *AutoNATResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AutoNATResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ErrorResponse proto.InternalMessageInfo
+var xxx_messageInfo_AutoNATResponse proto.InternalMessageInfo
 
-func (m *ErrorResponse) GetMsg() string {
-	if m != nil && m.Msg != nil {
-		return *m.Msg
+func (m *AutoNATResponse) GetReachability() Event_Reachability {
+	if m != nil && m.Reachability != nil {
+		return *m.Reachability
 	}
-	return ""
+	return Event_UNKNOWN
 }
 
-type StreamInfo struct {
-	Peer                 []byte   `protobuf:"bytes,1,req,name=peer" json:"peer,omitempty"`
-	Addr                 []byte   `protobuf:"bytes,2,req,name=addr" json:"addr,omitempty"`
-	Proto                *string  `protobuf:"bytes,3,req,name=proto" json:"proto,omitempty"`
+func (m *AutoNATResponse) GetProbe() *AutoNATProbe {
+	if m != nil {
+		return m.Probe
+	}
+	return nil
+}
+
+type AutoNATProbe struct {
+	// PUBLIC if the server dialed back, PRIVATE if it failed to
+	Reachability *Event_Reachability `protobuf:"varint,1,req,name=reachability,enum=p2pd.pb.Event_Reachability" json:"reachability,omitempty"`
+	// the AutoNAT server that answered
+	Peer []byte `protobuf:"bytes,2,req,name=peer" json:"peer,omitempty"`
+	// the address the server dialed back, when PUBLIC
+	Addr                 []byte   `protobuf:"bytes,3,opt,name=addr" json:"addr,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *StreamInfo) Reset()         { *m = StreamInfo{} }
-func (m *StreamInfo) String() string { return proto.CompactTextString(m) }
-func (*StreamInfo) ProtoMessage()    {}
-func (*StreamInfo) Descriptor() ([]byte, []int) {
+func (m *AutoNATProbe) Reset()         { *m = AutoNATProbe{} }
+func (m *AutoNATProbe) String() string { return proto.CompactTextString(m) }
+func (*AutoNATProbe) ProtoMessage()    {}
+func (*AutoNATProbe) Descriptor() ([]byte, []int) {
 	return fileDescriptor_7333f0e9b622f7df, []int{9}
 }
-func (m *StreamInfo) XXX_Unmarshal(b []byte) error {
+func (m *AutoNATProbe) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *StreamInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *AutoNATProbe) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_StreamInfo.Marshal(b, m, deterministic)
+		return xxx_messageInfo_AutoNATProbe.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1075,64 +2207,68 @@ func (m *StreamInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *StreamInfo) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_StreamInfo.Merge(m, src)
+func (m *AutoNATProbe) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AutoNATProbe.Merge(m, src)
 }
-func (m *StreamInfo) XXX_Size() int {
+func (m *AutoNATProbe) XXX_Size() int {
 	return m.Size()
 }
-func (m *StreamInfo) XXX_DiscardUnknown() {
-	xxx_messageInfo_StreamInfo.DiscardUnknown(m)
+func (m *AutoNATProbe) XXX_DiscardUnknown() {
+	xxx_messageInfo_AutoNATProbe.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_StreamInfo proto.InternalMessageInfo
+var xxx_messageInfo_AutoNATProbe proto.InternalMessageInfo
 
-func (m *StreamInfo) GetPeer() []byte {
+func (m *AutoNATProbe) GetReachability() Event_Reachability {
+	if m != nil && m.Reachability != nil {
+		return *m.Reachability
+	}
+	return Event_UNKNOWN
+}
+
+func (m *AutoNATProbe) GetPeer() []byte {
 	if m != nil {
 		return m.Peer
 	}
 	return nil
 }
 
-func (m *StreamInfo) GetAddr() []byte {
+func (m *AutoNATProbe) GetAddr() []byte {
 	if m != nil {
 		return m.Addr
 	}
 	return nil
 }
 
-func (m *StreamInfo) GetProto() string {
-	if m != nil && m.Proto != nil {
-		return *m.Proto
-	}
-	return ""
-}
-
-type DHTRequest struct {
-	Type                 *DHTRequest_Type `protobuf:"varint,1,req,name=type,enum=p2pd.pb.DHTRequest_Type" json:"type,omitempty"`
-	Peer                 []byte           `protobuf:"bytes,2,opt,name=peer" json:"peer,omitempty"`
-	Cid                  []byte           `protobuf:"bytes,3,opt,name=cid" json:"cid,omitempty"`
-	Key                  []byte           `protobuf:"bytes,4,opt,name=key" json:"key,omitempty"`
-	Value                []byte           `protobuf:"bytes,5,opt,name=value" json:"value,omitempty"`
-	Count                *int32           `protobuf:"varint,6,opt,name=count" json:"count,omitempty"`
-	Timeout              *int64           `protobuf:"varint,7,opt,name=timeout" json:"timeout,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
-	XXX_unrecognized     []byte           `json:"-"`
-	XXX_sizecache        int32            `json:"-"`
+// CallRecord describes one of the last unary calls made by clients; times
+// are in nanoseconds
+type CallRecord struct {
+	Peer  []byte  `protobuf:"bytes,1,req,name=peer" json:"peer,omitempty"`
+	Proto *string `protobuf:"bytes,2,req,name=proto" json:"proto,omitempty"`
+	// unix time the call started at
+	StartTime *int64 `protobuf:"varint,3,req,name=startTime" json:"startTime,omitempty"`
+	Duration  *int64 `protobuf:"varint,4,req,name=duration" json:"duration,omitempty"`
+	// success, error, timeout or cancelled
+	Result *string `protobuf:"bytes,5,req,name=result" json:"result,omitempty"`
+	// set when the call didn't succeed
+	Error                *string  `protobuf:"bytes,6,opt,name=error" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DHTRequest) Reset()         { *m = DHTRequest{} }
-func (m *DHTRequest) String() string { return proto.CompactTextString(m) }
-func (*DHTRequest) ProtoMessage()    {}
-func (*DHTRequest) Descriptor() ([]byte, []int) {
+func (m *CallRecord) Reset()         { *m = CallRecord{} }
+func (m *CallRecord) String() string { return proto.CompactTextString(m) }
+func (*CallRecord) ProtoMessage()    {}
+func (*CallRecord) Descriptor() ([]byte, []int) {
 	return fileDescriptor_7333f0e9b622f7df, []int{10}
 }
-func (m *DHTRequest) XXX_Unmarshal(b []byte) error {
+func (m *CallRecord) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *DHTRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *CallRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_DHTRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_CallRecord.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1142,88 +2278,86 @@ func (m *DHTRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *DHTRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DHTRequest.Merge(m, src)
+func (m *CallRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CallRecord.Merge(m, src)
 }
-func (m *DHTRequest) XXX_Size() int {
+func (m *CallRecord) XXX_Size() int {
 	return m.Size()
 }
-func (m *DHTRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_DHTRequest.DiscardUnknown(m)
+func (m *CallRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_CallRecord.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DHTRequest proto.InternalMessageInfo
+var xxx_messageInfo_CallRecord proto.InternalMessageInfo
 
-func (m *DHTRequest) GetType() DHTRequest_Type {
-	if m != nil && m.Type != nil {
-		return *m.Type
-	}
-	return DHTRequest_FIND_PEER
-}
-
-func (m *DHTRequest) GetPeer() []byte {
+func (m *CallRecord) GetPeer() []byte {
 	if m != nil {
 		return m.Peer
 	}
 	return nil
 }
 
-func (m *DHTRequest) GetCid() []byte {
-	if m != nil {
-		return m.Cid
+func (m *CallRecord) GetProto() string {
+	if m != nil && m.Proto != nil {
+		return *m.Proto
 	}
-	return nil
+	return ""
 }
 
-func (m *DHTRequest) GetKey() []byte {
-	if m != nil {
-		return m.Key
+func (m *CallRecord) GetStartTime() int64 {
+	if m != nil && m.StartTime != nil {
+		return *m.StartTime
 	}
-	return nil
+	return 0
 }
 
-func (m *DHTRequest) GetValue() []byte {
-	if m != nil {
-		return m.Value
+func (m *CallRecord) GetDuration() int64 {
+	if m != nil && m.Duration != nil {
+		return *m.Duration
 	}
-	return nil
+	return 0
 }
 
-func (m *DHTRequest) GetCount() int32 {
-	if m != nil && m.Count != nil {
-		return *m.Count
+func (m *CallRecord) GetResult() string {
+	if m != nil && m.Result != nil {
+		return *m.Result
 	}
-	return 0
+	return ""
 }
 
-func (m *DHTRequest) GetTimeout() int64 {
-	if m != nil && m.Timeout != nil {
-		return *m.Timeout
+func (m *CallRecord) GetError() string {
+	if m != nil && m.Error != nil {
+		return *m.Error
 	}
-	return 0
+	return ""
 }
 
-type DHTResponse struct {
-	Type                 *DHTResponse_Type `protobuf:"varint,1,req,name=type,enum=p2pd.pb.DHTResponse_Type" json:"type,omitempty"`
-	Peer                 *PeerInfo         `protobuf:"bytes,2,opt,name=peer" json:"peer,omitempty"`
-	Value                []byte            `protobuf:"bytes,3,opt,name=value" json:"value,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+type ConnectRequest struct {
+	Peer    []byte   `protobuf:"bytes,1,req,name=peer" json:"peer,omitempty"`
+	Addrs   [][]byte `protobuf:"bytes,2,rep,name=addrs" json:"addrs,omitempty"`
+	Timeout *int64   `protobuf:"varint,3,opt,name=timeout" json:"timeout,omitempty"`
+	// seconds the addrs are kept in the peerstore; temporary if unset
+	AddrTTL *int64 `protobuf:"varint,4,opt,name=addrTTL" json:"addrTTL,omitempty"`
+	// the envelope of a signed peer record of the peer, as returned by IDENTIFY;
+	// its addresses are dialed instead of addrs
+	SignedPeerRecord     []byte   `protobuf:"bytes,5,opt,name=signedPeerRecord" json:"signedPeerRecord,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DHTResponse) Reset()         { *m = DHTResponse{} }
-func (m *DHTResponse) String() string { return proto.CompactTextString(m) }
-func (*DHTResponse) ProtoMessage()    {}
-func (*DHTResponse) Descriptor() ([]byte, []int) {
+func (m *ConnectRequest) Reset()         { *m = ConnectRequest{} }
+func (m *ConnectRequest) String() string { return proto.CompactTextString(m) }
+func (*ConnectRequest) ProtoMessage()    {}
+func (*ConnectRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_7333f0e9b622f7df, []int{11}
 }
-func (m *DHTResponse) XXX_Unmarshal(b []byte) error {
+func (m *ConnectRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *DHTResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ConnectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_DHTResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ConnectRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1233,59 +2367,75 @@ func (m *DHTResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *DHTResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DHTResponse.Merge(m, src)
+func (m *ConnectRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConnectRequest.Merge(m, src)
 }
-func (m *DHTResponse) XXX_Size() int {
+func (m *ConnectRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *DHTResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_DHTResponse.DiscardUnknown(m)
+func (m *ConnectRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConnectRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DHTResponse proto.InternalMessageInfo
+var xxx_messageInfo_ConnectRequest proto.InternalMessageInfo
 
-func (m *DHTResponse) GetType() DHTResponse_Type {
-	if m != nil && m.Type != nil {
-		return *m.Type
+func (m *ConnectRequest) GetPeer() []byte {
+	if m != nil {
+		return m.Peer
 	}
-	return DHTResponse_BEGIN
+	return nil
 }
 
-func (m *DHTResponse) GetPeer() *PeerInfo {
+func (m *ConnectRequest) GetAddrs() [][]byte {
 	if m != nil {
-		return m.Peer
+		return m.Addrs
 	}
 	return nil
 }
 
-func (m *DHTResponse) GetValue() []byte {
+func (m *ConnectRequest) GetTimeout() int64 {
+	if m != nil && m.Timeout != nil {
+		return *m.Timeout
+	}
+	return 0
+}
+
+func (m *ConnectRequest) GetAddrTTL() int64 {
+	if m != nil && m.AddrTTL != nil {
+		return *m.AddrTTL
+	}
+	return 0
+}
+
+func (m *ConnectRequest) GetSignedPeerRecord() []byte {
 	if m != nil {
-		return m.Value
+		return m.SignedPeerRecord
 	}
 	return nil
 }
 
-type PeerInfo struct {
-	Id                   []byte   `protobuf:"bytes,1,req,name=id" json:"id,omitempty"`
-	Addrs                [][]byte `protobuf:"bytes,2,rep,name=addrs" json:"addrs,omitempty"`
+type ConnectResponse struct {
+	// the remote address of the connection opened, or already open, to the peer
+	Addr []byte `protobuf:"bytes,1,req,name=addr" json:"addr,omitempty"`
+	// the envelope of the peer's signed peer record, if it sent one
+	SignedPeerRecord     []byte   `protobuf:"bytes,2,opt,name=signedPeerRecord" json:"signedPeerRecord,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *PeerInfo) Reset()         { *m = PeerInfo{} }
-func (m *PeerInfo) String() string { return proto.CompactTextString(m) }
-func (*PeerInfo) ProtoMessage()    {}
-func (*PeerInfo) Descriptor() ([]byte, []int) {
+func (m *ConnectResponse) Reset()         { *m = ConnectResponse{} }
+func (m *ConnectResponse) String() string { return proto.CompactTextString(m) }
+func (*ConnectResponse) ProtoMessage()    {}
+func (*ConnectResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_7333f0e9b622f7df, []int{12}
 }
-func (m *PeerInfo) XXX_Unmarshal(b []byte) error {
+func (m *ConnectResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *PeerInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ConnectResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_PeerInfo.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ConnectResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1295,54 +2445,53 @@ func (m *PeerInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *PeerInfo) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PeerInfo.Merge(m, src)
+func (m *ConnectResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConnectResponse.Merge(m, src)
 }
-func (m *PeerInfo) XXX_Size() int {
+func (m *ConnectResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *PeerInfo) XXX_DiscardUnknown() {
-	xxx_messageInfo_PeerInfo.DiscardUnknown(m)
+func (m *ConnectResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConnectResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PeerInfo proto.InternalMessageInfo
+var xxx_messageInfo_ConnectResponse proto.InternalMessageInfo
 
-func (m *PeerInfo) GetId() []byte {
+func (m *ConnectResponse) GetAddr() []byte {
 	if m != nil {
-		return m.Id
+		return m.Addr
 	}
 	return nil
 }
 
-func (m *PeerInfo) GetAddrs() [][]byte {
+func (m *ConnectResponse) GetSignedPeerRecord() []byte {
 	if m != nil {
-		return m.Addrs
+		return m.SignedPeerRecord
 	}
 	return nil
 }
 
-type ConnManagerRequest struct {
-	Type                 *ConnManagerRequest_Type `protobuf:"varint,1,req,name=type,enum=p2pd.pb.ConnManagerRequest_Type" json:"type,omitempty"`
-	Peer                 []byte                   `protobuf:"bytes,2,opt,name=peer" json:"peer,omitempty"`
-	Tag                  *string                  `protobuf:"bytes,3,opt,name=tag" json:"tag,omitempty"`
-	Weight               *int64                   `protobuf:"varint,4,opt,name=weight" json:"weight,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
-	XXX_unrecognized     []byte                   `json:"-"`
-	XXX_sizecache        int32                    `json:"-"`
+type PingRequest struct {
+	Peer                 []byte   `protobuf:"bytes,1,req,name=peer" json:"peer,omitempty"`
+	Count                *int32   `protobuf:"varint,2,opt,name=count" json:"count,omitempty"`
+	Timeout              *int64   `protobuf:"varint,3,opt,name=timeout" json:"timeout,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ConnManagerRequest) Reset()         { *m = ConnManagerRequest{} }
-func (m *ConnManagerRequest) String() string { return proto.CompactTextString(m) }
-func (*ConnManagerRequest) ProtoMessage()    {}
-func (*ConnManagerRequest) Descriptor() ([]byte, []int) {
+func (m *PingRequest) Reset()         { *m = PingRequest{} }
+func (m *PingRequest) String() string { return proto.CompactTextString(m) }
+func (*PingRequest) ProtoMessage()    {}
+func (*PingRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_7333f0e9b622f7df, []int{13}
 }
-func (m *ConnManagerRequest) XXX_Unmarshal(b []byte) error {
+func (m *PingRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ConnManagerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *PingRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ConnManagerRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_PingRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1352,65 +2501,61 @@ func (m *ConnManagerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *ConnManagerRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ConnManagerRequest.Merge(m, src)
+func (m *PingRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PingRequest.Merge(m, src)
 }
-func (m *ConnManagerRequest) XXX_Size() int {
+func (m *PingRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *ConnManagerRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ConnManagerRequest.DiscardUnknown(m)
+func (m *PingRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PingRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ConnManagerRequest proto.InternalMessageInfo
-
-func (m *ConnManagerRequest) GetType() ConnManagerRequest_Type {
-	if m != nil && m.Type != nil {
-		return *m.Type
-	}
-	return ConnManagerRequest_TAG_PEER
-}
+var xxx_messageInfo_PingRequest proto.InternalMessageInfo
 
-func (m *ConnManagerRequest) GetPeer() []byte {
+func (m *PingRequest) GetPeer() []byte {
 	if m != nil {
 		return m.Peer
 	}
 	return nil
 }
 
-func (m *ConnManagerRequest) GetTag() string {
-	if m != nil && m.Tag != nil {
-		return *m.Tag
+func (m *PingRequest) GetCount() int32 {
+	if m != nil && m.Count != nil {
+		return *m.Count
 	}
-	return ""
+	return 0
 }
 
-func (m *ConnManagerRequest) GetWeight() int64 {
-	if m != nil && m.Weight != nil {
-		return *m.Weight
+func (m *PingRequest) GetTimeout() int64 {
+	if m != nil && m.Timeout != nil {
+		return *m.Timeout
 	}
 	return 0
 }
 
-type DisconnectRequest struct {
+// PeerMetadataRequest asks a peer for the metadata it announces over the
+// /p2pd/metadata/1.0.0 protocol
+type PeerMetadataRequest struct {
 	Peer                 []byte   `protobuf:"bytes,1,req,name=peer" json:"peer,omitempty"`
+	Timeout              *int64   `protobuf:"varint,2,opt,name=timeout" json:"timeout,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DisconnectRequest) Reset()         { *m = DisconnectRequest{} }
-func (m *DisconnectRequest) String() string { return proto.CompactTextString(m) }
-func (*DisconnectRequest) ProtoMessage()    {}
-func (*DisconnectRequest) Descriptor() ([]byte, []int) {
+func (m *PeerMetadataRequest) Reset()         { *m = PeerMetadataRequest{} }
+func (m *PeerMetadataRequest) String() string { return proto.CompactTextString(m) }
+func (*PeerMetadataRequest) ProtoMessage()    {}
+func (*PeerMetadataRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_7333f0e9b622f7df, []int{14}
 }
-func (m *DisconnectRequest) XXX_Unmarshal(b []byte) error {
+func (m *PeerMetadataRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *DisconnectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *PeerMetadataRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_DisconnectRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_PeerMetadataRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1420,46 +2565,53 @@ func (m *DisconnectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, e
 		return b[:n], nil
 	}
 }
-func (m *DisconnectRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DisconnectRequest.Merge(m, src)
+func (m *PeerMetadataRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerMetadataRequest.Merge(m, src)
 }
-func (m *DisconnectRequest) XXX_Size() int {
+func (m *PeerMetadataRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *DisconnectRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_DisconnectRequest.DiscardUnknown(m)
+func (m *PeerMetadataRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerMetadataRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DisconnectRequest proto.InternalMessageInfo
+var xxx_messageInfo_PeerMetadataRequest proto.InternalMessageInfo
 
-func (m *DisconnectRequest) GetPeer() []byte {
+func (m *PeerMetadataRequest) GetPeer() []byte {
 	if m != nil {
 		return m.Peer
 	}
 	return nil
 }
 
-type PSRequest struct {
-	Type                 *PSRequest_Type `protobuf:"varint,1,req,name=type,enum=p2pd.pb.PSRequest_Type" json:"type,omitempty"`
-	Topic                *string         `protobuf:"bytes,2,opt,name=topic" json:"topic,omitempty"`
-	Data                 []byte          `protobuf:"bytes,3,opt,name=data" json:"data,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+func (m *PeerMetadataRequest) GetTimeout() int64 {
+	if m != nil && m.Timeout != nil {
+		return *m.Timeout
+	}
+	return 0
 }
 
-func (m *PSRequest) Reset()         { *m = PSRequest{} }
-func (m *PSRequest) String() string { return proto.CompactTextString(m) }
-func (*PSRequest) ProtoMessage()    {}
-func (*PSRequest) Descriptor() ([]byte, []int) {
+// Metadata is the metadata a daemon announces to other peers, as written on
+// /p2pd/metadata/1.0.0 streams; entries are sorted by key
+type Metadata struct {
+	Entries              []*MetadataEntry `protobuf:"bytes,1,rep,name=entries" json:"entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *Metadata) Reset()         { *m = Metadata{} }
+func (m *Metadata) String() string { return proto.CompactTextString(m) }
+func (*Metadata) ProtoMessage()    {}
+func (*Metadata) Descriptor() ([]byte, []int) {
 	return fileDescriptor_7333f0e9b622f7df, []int{15}
 }
-func (m *PSRequest) XXX_Unmarshal(b []byte) error {
+func (m *Metadata) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *PSRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *Metadata) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_PSRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_Metadata.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1469,63 +2621,45 @@ func (m *PSRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *PSRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PSRequest.Merge(m, src)
+func (m *Metadata) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Metadata.Merge(m, src)
 }
-func (m *PSRequest) XXX_Size() int {
+func (m *Metadata) XXX_Size() int {
 	return m.Size()
 }
-func (m *PSRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_PSRequest.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_PSRequest proto.InternalMessageInfo
-
-func (m *PSRequest) GetType() PSRequest_Type {
-	if m != nil && m.Type != nil {
-		return *m.Type
-	}
-	return PSRequest_GET_TOPICS
+func (m *Metadata) XXX_DiscardUnknown() {
+	xxx_messageInfo_Metadata.DiscardUnknown(m)
 }
 
-func (m *PSRequest) GetTopic() string {
-	if m != nil && m.Topic != nil {
-		return *m.Topic
-	}
-	return ""
-}
+var xxx_messageInfo_Metadata proto.InternalMessageInfo
 
-func (m *PSRequest) GetData() []byte {
+func (m *Metadata) GetEntries() []*MetadataEntry {
 	if m != nil {
-		return m.Data
+		return m.Entries
 	}
 	return nil
 }
 
-type PSMessage struct {
-	From                 []byte   `protobuf:"bytes,1,opt,name=from" json:"from,omitempty"`
-	Data                 []byte   `protobuf:"bytes,2,opt,name=data" json:"data,omitempty"`
-	Seqno                []byte   `protobuf:"bytes,3,opt,name=seqno" json:"seqno,omitempty"`
-	TopicIDs             []string `protobuf:"bytes,4,rep,name=topicIDs" json:"topicIDs,omitempty"`
-	Signature            []byte   `protobuf:"bytes,5,opt,name=signature" json:"signature,omitempty"`
-	Key                  []byte   `protobuf:"bytes,6,opt,name=key" json:"key,omitempty"`
+type MetadataEntry struct {
+	Key                  *string  `protobuf:"bytes,1,req,name=key" json:"key,omitempty"`
+	Value                *string  `protobuf:"bytes,2,req,name=value" json:"value,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *PSMessage) Reset()         { *m = PSMessage{} }
-func (m *PSMessage) String() string { return proto.CompactTextString(m) }
-func (*PSMessage) ProtoMessage()    {}
-func (*PSMessage) Descriptor() ([]byte, []int) {
+func (m *MetadataEntry) Reset()         { *m = MetadataEntry{} }
+func (m *MetadataEntry) String() string { return proto.CompactTextString(m) }
+func (*MetadataEntry) ProtoMessage()    {}
+func (*MetadataEntry) Descriptor() ([]byte, []int) {
 	return fileDescriptor_7333f0e9b622f7df, []int{16}
 }
-func (m *PSMessage) XXX_Unmarshal(b []byte) error {
+func (m *MetadataEntry) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *PSMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *MetadataEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_PSMessage.Marshal(b, m, deterministic)
+		return xxx_messageInfo_MetadataEntry.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1535,80 +2669,110 @@ func (m *PSMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *PSMessage) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PSMessage.Merge(m, src)
+func (m *MetadataEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetadataEntry.Merge(m, src)
 }
-func (m *PSMessage) XXX_Size() int {
+func (m *MetadataEntry) XXX_Size() int {
 	return m.Size()
 }
-func (m *PSMessage) XXX_DiscardUnknown() {
-	xxx_messageInfo_PSMessage.DiscardUnknown(m)
+func (m *MetadataEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetadataEntry.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PSMessage proto.InternalMessageInfo
+var xxx_messageInfo_MetadataEntry proto.InternalMessageInfo
 
-func (m *PSMessage) GetFrom() []byte {
-	if m != nil {
-		return m.From
+func (m *MetadataEntry) GetKey() string {
+	if m != nil && m.Key != nil {
+		return *m.Key
 	}
-	return nil
+	return ""
 }
 
-func (m *PSMessage) GetData() []byte {
-	if m != nil {
-		return m.Data
+func (m *MetadataEntry) GetValue() string {
+	if m != nil && m.Value != nil {
+		return *m.Value
 	}
-	return nil
+	return ""
 }
 
-func (m *PSMessage) GetSeqno() []byte {
-	if m != nil {
-		return m.Seqno
-	}
-	return nil
+// LogLevelRequest sets the log level of a subsystem, or of all of them if
+// subsystem is *, when level is set; the levels are returned either way
+type LogLevelRequest struct {
+	Subsystem *string `protobuf:"bytes,1,opt,name=subsystem" json:"subsystem,omitempty"`
+	// debug, info, warn, error, dpanic, panic or fatal
+	Level                *string  `protobuf:"bytes,2,opt,name=level" json:"level,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *PSMessage) GetTopicIDs() []string {
-	if m != nil {
-		return m.TopicIDs
+func (m *LogLevelRequest) Reset()         { *m = LogLevelRequest{} }
+func (m *LogLevelRequest) String() string { return proto.CompactTextString(m) }
+func (*LogLevelRequest) ProtoMessage()    {}
+func (*LogLevelRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{17}
+}
+func (m *LogLevelRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LogLevelRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LogLevelRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return nil
+}
+func (m *LogLevelRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogLevelRequest.Merge(m, src)
+}
+func (m *LogLevelRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *LogLevelRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogLevelRequest.DiscardUnknown(m)
 }
 
-func (m *PSMessage) GetSignature() []byte {
-	if m != nil {
-		return m.Signature
+var xxx_messageInfo_LogLevelRequest proto.InternalMessageInfo
+
+func (m *LogLevelRequest) GetSubsystem() string {
+	if m != nil && m.Subsystem != nil {
+		return *m.Subsystem
 	}
-	return nil
+	return ""
 }
 
-func (m *PSMessage) GetKey() []byte {
-	if m != nil {
-		return m.Key
+func (m *LogLevelRequest) GetLevel() string {
+	if m != nil && m.Level != nil {
+		return *m.Level
 	}
-	return nil
+	return ""
 }
 
-type PSResponse struct {
-	Topics               []string `protobuf:"bytes,1,rep,name=topics" json:"topics,omitempty"`
-	PeerIDs              [][]byte `protobuf:"bytes,2,rep,name=peerIDs" json:"peerIDs,omitempty"`
+type SubsystemLogLevel struct {
+	Subsystem            *string  `protobuf:"bytes,1,req,name=subsystem" json:"subsystem,omitempty"`
+	Level                *string  `protobuf:"bytes,2,req,name=level" json:"level,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *PSResponse) Reset()         { *m = PSResponse{} }
-func (m *PSResponse) String() string { return proto.CompactTextString(m) }
-func (*PSResponse) ProtoMessage()    {}
-func (*PSResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7333f0e9b622f7df, []int{17}
+func (m *SubsystemLogLevel) Reset()         { *m = SubsystemLogLevel{} }
+func (m *SubsystemLogLevel) String() string { return proto.CompactTextString(m) }
+func (*SubsystemLogLevel) ProtoMessage()    {}
+func (*SubsystemLogLevel) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{18}
 }
-func (m *PSResponse) XXX_Unmarshal(b []byte) error {
+func (m *SubsystemLogLevel) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *PSResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *SubsystemLogLevel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_PSResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_SubsystemLogLevel.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1618,53 +2782,56 @@ func (m *PSResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *PSResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PSResponse.Merge(m, src)
+func (m *SubsystemLogLevel) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubsystemLogLevel.Merge(m, src)
 }
-func (m *PSResponse) XXX_Size() int {
+func (m *SubsystemLogLevel) XXX_Size() int {
 	return m.Size()
 }
-func (m *PSResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_PSResponse.DiscardUnknown(m)
+func (m *SubsystemLogLevel) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubsystemLogLevel.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PSResponse proto.InternalMessageInfo
+var xxx_messageInfo_SubsystemLogLevel proto.InternalMessageInfo
 
-func (m *PSResponse) GetTopics() []string {
-	if m != nil {
-		return m.Topics
+func (m *SubsystemLogLevel) GetSubsystem() string {
+	if m != nil && m.Subsystem != nil {
+		return *m.Subsystem
 	}
-	return nil
+	return ""
 }
 
-func (m *PSResponse) GetPeerIDs() [][]byte {
-	if m != nil {
-		return m.PeerIDs
+func (m *SubsystemLogLevel) GetLevel() string {
+	if m != nil && m.Level != nil {
+		return *m.Level
 	}
-	return nil
+	return ""
 }
 
-type CallUnaryRequest struct {
-	Peer                 []byte   `protobuf:"bytes,1,req,name=peer" json:"peer,omitempty"`
-	Proto                *string  `protobuf:"bytes,2,req,name=proto" json:"proto,omitempty"`
-	Data                 []byte   `protobuf:"bytes,3,req,name=data" json:"data,omitempty"`
+// FindPeerRequest looks up the addresses of a peer in the peerstore, then
+// through the DHT if there are none
+type FindPeerRequest struct {
+	Peer []byte `protobuf:"bytes,1,req,name=peer" json:"peer,omitempty"`
+	// only looks up the peerstore
+	SkipDHT              *bool    `protobuf:"varint,2,opt,name=skipDHT" json:"skipDHT,omitempty"`
+	Timeout              *int64   `protobuf:"varint,3,opt,name=timeout" json:"timeout,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CallUnaryRequest) Reset()         { *m = CallUnaryRequest{} }
-func (m *CallUnaryRequest) String() string { return proto.CompactTextString(m) }
-func (*CallUnaryRequest) ProtoMessage()    {}
-func (*CallUnaryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7333f0e9b622f7df, []int{18}
+func (m *FindPeerRequest) Reset()         { *m = FindPeerRequest{} }
+func (m *FindPeerRequest) String() string { return proto.CompactTextString(m) }
+func (*FindPeerRequest) ProtoMessage()    {}
+func (*FindPeerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{19}
 }
-func (m *CallUnaryRequest) XXX_Unmarshal(b []byte) error {
+func (m *FindPeerRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *CallUnaryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *FindPeerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_CallUnaryRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_FindPeerRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1674,61 +2841,65 @@ func (m *CallUnaryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, er
 		return b[:n], nil
 	}
 }
-func (m *CallUnaryRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CallUnaryRequest.Merge(m, src)
+func (m *FindPeerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindPeerRequest.Merge(m, src)
 }
-func (m *CallUnaryRequest) XXX_Size() int {
+func (m *FindPeerRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *CallUnaryRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_CallUnaryRequest.DiscardUnknown(m)
+func (m *FindPeerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindPeerRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CallUnaryRequest proto.InternalMessageInfo
+var xxx_messageInfo_FindPeerRequest proto.InternalMessageInfo
 
-func (m *CallUnaryRequest) GetPeer() []byte {
+func (m *FindPeerRequest) GetPeer() []byte {
 	if m != nil {
 		return m.Peer
 	}
 	return nil
 }
 
-func (m *CallUnaryRequest) GetProto() string {
-	if m != nil && m.Proto != nil {
-		return *m.Proto
+func (m *FindPeerRequest) GetSkipDHT() bool {
+	if m != nil && m.SkipDHT != nil {
+		return *m.SkipDHT
 	}
-	return ""
+	return false
 }
 
-func (m *CallUnaryRequest) GetData() []byte {
-	if m != nil {
-		return m.Data
+func (m *FindPeerRequest) GetTimeout() int64 {
+	if m != nil && m.Timeout != nil {
+		return *m.Timeout
 	}
-	return nil
+	return 0
 }
 
-type CallUnaryResponse struct {
-	// Types that are valid to be assigned to Result:
-	//	*CallUnaryResponse_Response
-	//	*CallUnaryResponse_Error
-	Result               isCallUnaryResponse_Result `protobuf_oneof:"result"`
-	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
-	XXX_unrecognized     []byte                     `json:"-"`
-	XXX_sizecache        int32                      `json:"-"`
+type RoutingTableRequest struct {
+	// also lists the peers in the routing table
+	Peers *bool `protobuf:"varint,1,opt,name=peers" json:"peers,omitempty"`
+	// refreshes the routing table before replying, as the DHT does
+	// periodically
+	Refresh *bool `protobuf:"varint,2,opt,name=refresh" json:"refresh,omitempty"`
+	// refreshes every bucket, even those refreshed recently
+	Force                *bool    `protobuf:"varint,3,opt,name=force" json:"force,omitempty"`
+	Timeout              *int64   `protobuf:"varint,4,opt,name=timeout" json:"timeout,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CallUnaryResponse) Reset()         { *m = CallUnaryResponse{} }
-func (m *CallUnaryResponse) String() string { return proto.CompactTextString(m) }
-func (*CallUnaryResponse) ProtoMessage()    {}
-func (*CallUnaryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7333f0e9b622f7df, []int{19}
+func (m *RoutingTableRequest) Reset()         { *m = RoutingTableRequest{} }
+func (m *RoutingTableRequest) String() string { return proto.CompactTextString(m) }
+func (*RoutingTableRequest) ProtoMessage()    {}
+func (*RoutingTableRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{20}
 }
-func (m *CallUnaryResponse) XXX_Unmarshal(b []byte) error {
+func (m *RoutingTableRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *CallUnaryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *RoutingTableRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_CallUnaryResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_RoutingTableRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1738,82 +2909,123 @@ func (m *CallUnaryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, e
 		return b[:n], nil
 	}
 }
-func (m *CallUnaryResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CallUnaryResponse.Merge(m, src)
+func (m *RoutingTableRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RoutingTableRequest.Merge(m, src)
 }
-func (m *CallUnaryResponse) XXX_Size() int {
+func (m *RoutingTableRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *CallUnaryResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_CallUnaryResponse.DiscardUnknown(m)
+func (m *RoutingTableRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RoutingTableRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CallUnaryResponse proto.InternalMessageInfo
+var xxx_messageInfo_RoutingTableRequest proto.InternalMessageInfo
 
-type isCallUnaryResponse_Result interface {
-	isCallUnaryResponse_Result()
-	MarshalTo([]byte) (int, error)
-	Size() int
+func (m *RoutingTableRequest) GetPeers() bool {
+	if m != nil && m.Peers != nil {
+		return *m.Peers
+	}
+	return false
 }
 
-type CallUnaryResponse_Response struct {
-	Response []byte `protobuf:"bytes,1,opt,name=response,oneof" json:"response,omitempty"`
-}
-type CallUnaryResponse_Error struct {
-	Error []byte `protobuf:"bytes,2,opt,name=error,oneof" json:"error,omitempty"`
+func (m *RoutingTableRequest) GetRefresh() bool {
+	if m != nil && m.Refresh != nil {
+		return *m.Refresh
+	}
+	return false
 }
 
-func (*CallUnaryResponse_Response) isCallUnaryResponse_Result() {}
-func (*CallUnaryResponse_Error) isCallUnaryResponse_Result()    {}
+func (m *RoutingTableRequest) GetForce() bool {
+	if m != nil && m.Force != nil {
+		return *m.Force
+	}
+	return false
+}
 
-func (m *CallUnaryResponse) GetResult() isCallUnaryResponse_Result {
-	if m != nil {
-		return m.Result
+func (m *RoutingTableRequest) GetTimeout() int64 {
+	if m != nil && m.Timeout != nil {
+		return *m.Timeout
 	}
-	return nil
+	return 0
 }
 
-func (m *CallUnaryResponse) GetResponse() []byte {
-	if x, ok := m.GetResult().(*CallUnaryResponse_Response); ok {
-		return x.Response
+// RoutingTableBucket lists the routing table peers whose DHT id has cpl
+// leading bits in common with the local one
+type RoutingTableBucket struct {
+	Cpl                  *int32   `protobuf:"varint,1,req,name=cpl" json:"cpl,omitempty"`
+	Peers                [][]byte `protobuf:"bytes,2,rep,name=peers" json:"peers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RoutingTableBucket) Reset()         { *m = RoutingTableBucket{} }
+func (m *RoutingTableBucket) String() string { return proto.CompactTextString(m) }
+func (*RoutingTableBucket) ProtoMessage()    {}
+func (*RoutingTableBucket) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{21}
+}
+func (m *RoutingTableBucket) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RoutingTableBucket) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RoutingTableBucket.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return nil
+}
+func (m *RoutingTableBucket) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RoutingTableBucket.Merge(m, src)
+}
+func (m *RoutingTableBucket) XXX_Size() int {
+	return m.Size()
+}
+func (m *RoutingTableBucket) XXX_DiscardUnknown() {
+	xxx_messageInfo_RoutingTableBucket.DiscardUnknown(m)
 }
 
-func (m *CallUnaryResponse) GetError() []byte {
-	if x, ok := m.GetResult().(*CallUnaryResponse_Error); ok {
-		return x.Error
+var xxx_messageInfo_RoutingTableBucket proto.InternalMessageInfo
+
+func (m *RoutingTableBucket) GetCpl() int32 {
+	if m != nil && m.Cpl != nil {
+		return *m.Cpl
 	}
-	return nil
+	return 0
 }
 
-// XXX_OneofWrappers is for the internal use of the proto package.
-func (*CallUnaryResponse) XXX_OneofWrappers() []interface{} {
-	return []interface{}{
-		(*CallUnaryResponse_Response)(nil),
-		(*CallUnaryResponse_Error)(nil),
+func (m *RoutingTableBucket) GetPeers() [][]byte {
+	if m != nil {
+		return m.Peers
 	}
+	return nil
 }
 
-type AddUnaryHandlerRequest struct {
-	Proto                *string  `protobuf:"bytes,1,req,name=proto" json:"proto,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type RoutingTableResponse struct {
+	PeerCount            *int32                `protobuf:"varint,1,req,name=peerCount" json:"peerCount,omitempty"`
+	Buckets              []*RoutingTableBucket `protobuf:"bytes,2,rep,name=buckets" json:"buckets,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
-func (m *AddUnaryHandlerRequest) Reset()         { *m = AddUnaryHandlerRequest{} }
-func (m *AddUnaryHandlerRequest) String() string { return proto.CompactTextString(m) }
-func (*AddUnaryHandlerRequest) ProtoMessage()    {}
-func (*AddUnaryHandlerRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7333f0e9b622f7df, []int{20}
+func (m *RoutingTableResponse) Reset()         { *m = RoutingTableResponse{} }
+func (m *RoutingTableResponse) String() string { return proto.CompactTextString(m) }
+func (*RoutingTableResponse) ProtoMessage()    {}
+func (*RoutingTableResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{22}
 }
-func (m *AddUnaryHandlerRequest) XXX_Unmarshal(b []byte) error {
+func (m *RoutingTableResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *AddUnaryHandlerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *RoutingTableResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_AddUnaryHandlerRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_RoutingTableResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1823,44 +3035,54 @@ func (m *AddUnaryHandlerRequest) XXX_Marshal(b []byte, deterministic bool) ([]by
 		return b[:n], nil
 	}
 }
-func (m *AddUnaryHandlerRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AddUnaryHandlerRequest.Merge(m, src)
+func (m *RoutingTableResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RoutingTableResponse.Merge(m, src)
 }
-func (m *AddUnaryHandlerRequest) XXX_Size() int {
+func (m *RoutingTableResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *AddUnaryHandlerRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_AddUnaryHandlerRequest.DiscardUnknown(m)
+func (m *RoutingTableResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RoutingTableResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AddUnaryHandlerRequest proto.InternalMessageInfo
+var xxx_messageInfo_RoutingTableResponse proto.InternalMessageInfo
 
-func (m *AddUnaryHandlerRequest) GetProto() string {
-	if m != nil && m.Proto != nil {
-		return *m.Proto
+func (m *RoutingTableResponse) GetPeerCount() int32 {
+	if m != nil && m.PeerCount != nil {
+		return *m.PeerCount
 	}
-	return ""
+	return 0
 }
 
-type DaemonError struct {
-	Message              *string  `protobuf:"bytes,1,opt,name=message" json:"message,omitempty"`
+func (m *RoutingTableResponse) GetBuckets() []*RoutingTableBucket {
+	if m != nil {
+		return m.Buckets
+	}
+	return nil
+}
+
+type SetBootstrapPeersRequest struct {
+	// p2p multiaddrs, including the peer id
+	Addrs [][]byte `protobuf:"bytes,1,rep,name=addrs" json:"addrs,omitempty"`
+	// bootstraps right away with the new peers
+	Bootstrap            *bool    `protobuf:"varint,2,opt,name=bootstrap" json:"bootstrap,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DaemonError) Reset()         { *m = DaemonError{} }
-func (m *DaemonError) String() string { return proto.CompactTextString(m) }
-func (*DaemonError) ProtoMessage()    {}
-func (*DaemonError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7333f0e9b622f7df, []int{21}
+func (m *SetBootstrapPeersRequest) Reset()         { *m = SetBootstrapPeersRequest{} }
+func (m *SetBootstrapPeersRequest) String() string { return proto.CompactTextString(m) }
+func (*SetBootstrapPeersRequest) ProtoMessage()    {}
+func (*SetBootstrapPeersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{23}
 }
-func (m *DaemonError) XXX_Unmarshal(b []byte) error {
+func (m *SetBootstrapPeersRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *DaemonError) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *SetBootstrapPeersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_DaemonError.Marshal(b, m, deterministic)
+		return xxx_messageInfo_SetBootstrapPeersRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1870,43 +3092,58 @@ func (m *DaemonError) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *DaemonError) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DaemonError.Merge(m, src)
+func (m *SetBootstrapPeersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetBootstrapPeersRequest.Merge(m, src)
 }
-func (m *DaemonError) XXX_Size() int {
+func (m *SetBootstrapPeersRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *DaemonError) XXX_DiscardUnknown() {
-	xxx_messageInfo_DaemonError.DiscardUnknown(m)
+func (m *SetBootstrapPeersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetBootstrapPeersRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DaemonError proto.InternalMessageInfo
+var xxx_messageInfo_SetBootstrapPeersRequest proto.InternalMessageInfo
 
-func (m *DaemonError) GetMessage() string {
-	if m != nil && m.Message != nil {
-		return *m.Message
+func (m *SetBootstrapPeersRequest) GetAddrs() [][]byte {
+	if m != nil {
+		return m.Addrs
 	}
-	return ""
+	return nil
 }
 
-type Cancel struct {
+func (m *SetBootstrapPeersRequest) GetBootstrap() bool {
+	if m != nil && m.Bootstrap != nil {
+		return *m.Bootstrap
+	}
+	return false
+}
+
+// PeerstoreRequest edits the addresses the peerstore keeps for a peer
+type PeerstoreRequest struct {
+	Type  *PeerstoreRequest_Type `protobuf:"varint,1,req,name=type,enum=p2pd.pb.PeerstoreRequest_Type" json:"type,omitempty"`
+	Peer  []byte                 `protobuf:"bytes,2,req,name=peer" json:"peer,omitempty"`
+	Addrs [][]byte               `protobuf:"bytes,3,rep,name=addrs" json:"addrs,omitempty"`
+	// seconds ADD_ADDRS keeps the addrs for; an hour if unset
+	Ttl *int64 `protobuf:"varint,4,opt,name=ttl" json:"ttl,omitempty"`
+	// protocols GET_PROTOCOLS checks the peer supports
+	Protos               []string `protobuf:"bytes,5,rep,name=protos" json:"protos,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Cancel) Reset()         { *m = Cancel{} }
-func (m *Cancel) String() string { return proto.CompactTextString(m) }
-func (*Cancel) ProtoMessage()    {}
-func (*Cancel) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7333f0e9b622f7df, []int{22}
+func (m *PeerstoreRequest) Reset()         { *m = PeerstoreRequest{} }
+func (m *PeerstoreRequest) String() string { return proto.CompactTextString(m) }
+func (*PeerstoreRequest) ProtoMessage()    {}
+func (*PeerstoreRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{24}
 }
-func (m *Cancel) XXX_Unmarshal(b []byte) error {
+func (m *PeerstoreRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *Cancel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *PeerstoreRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_Cancel.Marshal(b, m, deterministic)
+		return xxx_messageInfo_PeerstoreRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1916,2236 +3153,20008 @@ func (m *Cancel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *Cancel) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Cancel.Merge(m, src)
+func (m *PeerstoreRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerstoreRequest.Merge(m, src)
 }
-func (m *Cancel) XXX_Size() int {
+func (m *PeerstoreRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *Cancel) XXX_DiscardUnknown() {
-	xxx_messageInfo_Cancel.DiscardUnknown(m)
+func (m *PeerstoreRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerstoreRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Cancel proto.InternalMessageInfo
+var xxx_messageInfo_PeerstoreRequest proto.InternalMessageInfo
 
-func init() {
-	proto.RegisterEnum("p2pd.pb.Request_Type", Request_Type_name, Request_Type_value)
-	proto.RegisterEnum("p2pd.pb.Response_Type", Response_Type_name, Response_Type_value)
-	proto.RegisterEnum("p2pd.pb.DHTRequest_Type", DHTRequest_Type_name, DHTRequest_Type_value)
-	proto.RegisterEnum("p2pd.pb.DHTResponse_Type", DHTResponse_Type_name, DHTResponse_Type_value)
-	proto.RegisterEnum("p2pd.pb.ConnManagerRequest_Type", ConnManagerRequest_Type_name, ConnManagerRequest_Type_value)
-	proto.RegisterEnum("p2pd.pb.PSRequest_Type", PSRequest_Type_name, PSRequest_Type_value)
-	proto.RegisterType((*Request)(nil), "p2pd.pb.Request")
-	proto.RegisterType((*Response)(nil), "p2pd.pb.Response")
-	proto.RegisterType((*PersistentConnectionRequest)(nil), "p2pd.pb.PersistentConnectionRequest")
-	proto.RegisterType((*PersistentConnectionResponse)(nil), "p2pd.pb.PersistentConnectionResponse")
-	proto.RegisterType((*IdentifyResponse)(nil), "p2pd.pb.IdentifyResponse")
-	proto.RegisterType((*ConnectRequest)(nil), "p2pd.pb.ConnectRequest")
-	proto.RegisterType((*StreamOpenRequest)(nil), "p2pd.pb.StreamOpenRequest")
-	proto.RegisterType((*StreamHandlerRequest)(nil), "p2pd.pb.StreamHandlerRequest")
-	proto.RegisterType((*ErrorResponse)(nil), "p2pd.pb.ErrorResponse")
-	proto.RegisterType((*StreamInfo)(nil), "p2pd.pb.StreamInfo")
-	proto.RegisterType((*DHTRequest)(nil), "p2pd.pb.DHTRequest")
-	proto.RegisterType((*DHTResponse)(nil), "p2pd.pb.DHTResponse")
-	proto.RegisterType((*PeerInfo)(nil), "p2pd.pb.PeerInfo")
-	proto.RegisterType((*ConnManagerRequest)(nil), "p2pd.pb.ConnManagerRequest")
-	proto.RegisterType((*DisconnectRequest)(nil), "p2pd.pb.DisconnectRequest")
-	proto.RegisterType((*PSRequest)(nil), "p2pd.pb.PSRequest")
-	proto.RegisterType((*PSMessage)(nil), "p2pd.pb.PSMessage")
-	proto.RegisterType((*PSResponse)(nil), "p2pd.pb.PSResponse")
-	proto.RegisterType((*CallUnaryRequest)(nil), "p2pd.pb.CallUnaryRequest")
-	proto.RegisterType((*CallUnaryResponse)(nil), "p2pd.pb.CallUnaryResponse")
-	proto.RegisterType((*AddUnaryHandlerRequest)(nil), "p2pd.pb.AddUnaryHandlerRequest")
-	proto.RegisterType((*DaemonError)(nil), "p2pd.pb.DaemonError")
-	proto.RegisterType((*Cancel)(nil), "p2pd.pb.Cancel")
+func (m *PeerstoreRequest) GetType() PeerstoreRequest_Type {
+	if m != nil && m.Type != nil {
+		return *m.Type
+	}
+	return PeerstoreRequest_ADD_ADDRS
 }
 
-func init() { proto.RegisterFile("p2pd.proto", fileDescriptor_7333f0e9b622f7df) }
-
-var fileDescriptor_7333f0e9b622f7df = []byte{
-	// 1405 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0xcb, 0x6e, 0xdb, 0xc6,
-	0x1a, 0x16, 0x49, 0x5d, 0x7f, 0xc9, 0x32, 0x3d, 0x71, 0x1c, 0x26, 0xf1, 0xc9, 0xf1, 0x21, 0x90,
-	0x13, 0xe7, 0x72, 0x8c, 0x53, 0xb7, 0x05, 0xd2, 0x02, 0x2d, 0xaa, 0x0b, 0x63, 0x31, 0xb1, 0x25,
-	0x61, 0x48, 0xa5, 0xc8, 0x4a, 0x60, 0xc4, 0x89, 0x23, 0xd4, 0x26, 0x15, 0x92, 0x6a, 0xe1, 0x07,
-	0xe9, 0xbe, 0xab, 0x02, 0x5d, 0x77, 0xd1, 0xf6, 0x0d, 0xba, 0xec, 0x23, 0x14, 0xde, 0x75, 0xd3,
-	0x67, 0x28, 0xe6, 0xc6, 0x8b, 0xac, 0xa4, 0x41, 0x77, 0xf3, 0xcf, 0x7c, 0xff, 0x65, 0xbe, 0xf9,
-	0x67, 0xbe, 0x01, 0x58, 0x1c, 0x2e, 0xfc, 0x83, 0x45, 0x14, 0x26, 0x21, 0xaa, 0xf1, 0xf1, 0x4b,
-	0xf3, 0xb2, 0x0c, 0x35, 0x4c, 0xde, 0x2c, 0x49, 0x9c, 0xa0, 0xfb, 0x50, 0x4e, 0x2e, 0x16, 0xc4,
-	0x50, 0xf6, 0xd4, 0xfd, 0xf6, 0xe1, 0xf5, 0x03, 0x81, 0x39, 0x10, 0xeb, 0x07, 0xee, 0xc5, 0x82,
-	0x60, 0x06, 0x41, 0x1f, 0x40, 0x6d, 0x16, 0x06, 0x01, 0x99, 0x25, 0x86, 0xba, 0xa7, 0xec, 0x37,
-	0x0f, 0x6f, 0xa4, 0xe8, 0x1e, 0x9f, 0x17, 0x4e, 0x58, 0xe2, 0xd0, 0xa7, 0x00, 0x71, 0x12, 0x11,
-	0xef, 0x7c, 0xb4, 0x20, 0x81, 0xa1, 0x31, 0xaf, 0x5b, 0xa9, 0x97, 0x93, 0x2e, 0x49, 0xc7, 0x1c,
-	0x1a, 0xf5, 0x60, 0x83, 0x5b, 0x03, 0x2f, 0xf0, 0xcf, 0x48, 0x64, 0x94, 0x99, 0xfb, 0xbf, 0x56,
-	0xdc, 0xc5, 0xaa, 0x8c, 0x50, 0xf4, 0x41, 0x77, 0x41, 0xf3, 0x5f, 0x27, 0x46, 0x85, 0xb9, 0x5e,
-	0x4b, 0x5d, 0xfb, 0x03, 0x57, 0x3a, 0xd0, 0x75, 0xf4, 0x19, 0x34, 0x69, 0xc9, 0x27, 0x5e, 0xe0,
-	0x9d, 0x92, 0xc8, 0xa8, 0x32, 0xf8, 0xed, 0xc2, 0xf6, 0xc4, 0x9a, 0x74, 0xcb, 0xe3, 0xe9, 0x36,
-	0xfd, 0x79, 0x2c, 0xc9, 0xa9, 0xad, 0x6c, 0xb3, 0x9f, 0x2e, 0xa5, 0xdb, 0xcc, 0xd0, 0xe8, 0x01,
-	0x54, 0x17, 0xcb, 0x97, 0xf1, 0xf2, 0xa5, 0x51, 0x67, 0x7e, 0x28, 0xf5, 0x1b, 0x3b, 0x12, 0x2f,
-	0x10, 0xe6, 0x0f, 0x0a, 0x94, 0xe9, 0x81, 0xa0, 0x16, 0xd4, 0xed, 0xbe, 0x35, 0x74, 0xed, 0x27,
-	0x2f, 0xf4, 0x12, 0x6a, 0x42, 0xad, 0x37, 0x1a, 0x0e, 0xad, 0x9e, 0xab, 0x2b, 0x68, 0x13, 0x9a,
-	0x8e, 0x8b, 0xad, 0xce, 0xc9, 0x74, 0x34, 0xb6, 0x86, 0xba, 0x8a, 0x10, 0xb4, 0xc5, 0xc4, 0xa0,
-	0x33, 0xec, 0x1f, 0x5b, 0x58, 0xd7, 0x50, 0x0d, 0xb4, 0xfe, 0xc0, 0xd5, 0xcb, 0xa8, 0x0d, 0x70,
-	0x6c, 0x3b, 0xee, 0x74, 0x6c, 0x59, 0xd8, 0xd1, 0x2b, 0xd4, 0x9b, 0x86, 0x3a, 0xe9, 0x0c, 0x3b,
-	0x47, 0x16, 0xd6, 0xab, 0x14, 0xd0, 0xb7, 0x1d, 0x19, 0xbe, 0x86, 0x00, 0xaa, 0xe3, 0x49, 0xd7,
-	0x99, 0x74, 0xf5, 0x3a, 0xba, 0x0d, 0x37, 0xc6, 0x16, 0x76, 0x6c, 0xc7, 0xb5, 0x86, 0xee, 0x94,
-	0x62, 0xa6, 0x93, 0xf1, 0x11, 0xee, 0xf4, 0x2d, 0xbd, 0x61, 0xfe, 0xa1, 0x42, 0x1d, 0x93, 0x78,
-	0x11, 0x06, 0x31, 0x41, 0x0f, 0x0a, 0x5d, 0xb6, 0x93, 0xeb, 0x32, 0x0e, 0xc8, 0xb7, 0xd9, 0x23,
-	0xa8, 0x90, 0x28, 0x0a, 0x23, 0xd1, 0x64, 0x19, 0xd8, 0xa2, 0xb3, 0xd2, 0x03, 0x73, 0x10, 0xfa,
-	0x50, 0x76, 0x98, 0x1d, 0xbc, 0x0a, 0x45, 0x87, 0x5d, 0x5b, 0x69, 0x11, 0xba, 0x84, 0x73, 0x30,
-	0xf4, 0x31, 0xd4, 0xe7, 0x3e, 0x09, 0x92, 0xf9, 0xab, 0x0b, 0xd1, 0x55, 0x37, 0x53, 0x17, 0x5b,
-	0x2c, 0xa4, 0x89, 0x52, 0x28, 0xfa, 0x6f, 0xbe, 0x99, 0xb6, 0x8b, 0xcd, 0x24, 0xc0, 0xac, 0x9b,
-	0xee, 0x41, 0x65, 0x41, 0x48, 0x14, 0x1b, 0xd5, 0x3d, 0x6d, 0xbf, 0x79, 0xb8, 0x95, 0x9d, 0x28,
-	0x21, 0x11, 0x2b, 0x86, 0xaf, 0xa3, 0x87, 0xe9, 0xd9, 0xd7, 0x56, 0x0a, 0xa7, 0x67, 0x2f, 0x42,
-	0xca, 0xc3, 0xbf, 0x29, 0xce, 0xbe, 0x0a, 0xea, 0xe8, 0x99, 0x5e, 0x42, 0x0d, 0xa8, 0x58, 0x18,
-	0x8f, 0xb0, 0xae, 0x98, 0x3f, 0xa9, 0x70, 0x7b, 0x4c, 0xa2, 0x78, 0x1e, 0x27, 0x24, 0x48, 0xc4,
-	0x65, 0x9c, 0x87, 0xf2, 0x5a, 0xa1, 0x1d, 0xa8, 0xce, 0xbc, 0xb3, 0x33, 0xdb, 0x67, 0x07, 0xd0,
-	0xc2, 0xc2, 0x42, 0xcf, 0x60, 0xd3, 0xf3, 0xfd, 0x49, 0xe0, 0x45, 0x17, 0xf2, 0x92, 0x71, 0xd2,
-	0xff, 0x9d, 0x16, 0xd2, 0x29, 0xae, 0x8b, 0x88, 0x83, 0x12, 0x5e, 0xf5, 0x44, 0x9f, 0x40, 0x83,
-	0x86, 0x65, 0x73, 0xe2, 0x20, 0x32, 0x56, 0x7b, 0x72, 0x25, 0x0b, 0x90, 0xa1, 0x51, 0x17, 0x36,
-	0x96, 0x7c, 0x91, 0xef, 0x59, 0x1c, 0xca, 0xad, 0x75, 0xee, 0x1c, 0x31, 0x28, 0xe1, 0xa2, 0x0b,
-	0xba, 0x4f, 0xf7, 0x18, 0xcc, 0xc8, 0x99, 0x38, 0x9f, 0xcd, 0x9c, 0x33, 0x9d, 0x1e, 0x94, 0xb0,
-	0x00, 0x74, 0x1b, 0x50, 0x3b, 0x27, 0x71, 0xec, 0x9d, 0x12, 0xf3, 0x17, 0x15, 0x76, 0xd7, 0x33,
-	0x27, 0xc2, 0xbe, 0x8d, 0xba, 0xa7, 0xb0, 0x35, 0x5b, 0x2d, 0x4a, 0x90, 0xf7, 0xee, 0xb2, 0xaf,
-	0xba, 0x21, 0x0b, 0x36, 0x23, 0x41, 0x0b, 0xe5, 0x72, 0x1e, 0x9c, 0xbe, 0x0f, 0x7f, 0xab, 0x3e,
-	0xe8, 0x31, 0x34, 0x7d, 0x8f, 0x9c, 0x87, 0x01, 0xbb, 0x28, 0x82, 0xc3, 0x5c, 0x9b, 0x66, 0x6b,
-	0x83, 0x12, 0xce, 0x43, 0xff, 0x21, 0x77, 0x8f, 0x41, 0x5f, 0xbd, 0x2c, 0xa8, 0x0d, 0xea, 0x5c,
-	0x52, 0xa5, 0xce, 0x7d, 0xb4, 0x0d, 0x15, 0xcf, 0xf7, 0xa3, 0xd8, 0x50, 0xf7, 0xb4, 0xfd, 0x16,
-	0xe6, 0x86, 0xe9, 0x42, 0xbb, 0xa8, 0x18, 0x08, 0x41, 0x99, 0x5e, 0x09, 0xe1, 0xc9, 0xc6, 0xeb,
-	0x7d, 0x91, 0x01, 0xb5, 0x64, 0x7e, 0x4e, 0xc2, 0x65, 0xc2, 0x48, 0xd2, 0xb0, 0x34, 0xcd, 0x2f,
-	0x61, 0xeb, 0x8a, 0xa2, 0xbc, 0x2d, 0x30, 0x53, 0x44, 0x16, 0xb8, 0x81, 0xb9, 0xf1, 0x8e, 0xc0,
-	0x5f, 0xc0, 0xf6, 0x3a, 0xad, 0xa1, 0xb1, 0x69, 0x4d, 0x32, 0x36, 0x1d, 0xaf, 0x8f, 0x6d, 0xfe,
-	0x07, 0x36, 0x0a, 0xaf, 0x17, 0xd2, 0x41, 0x3b, 0x8f, 0x4f, 0x99, 0x67, 0x03, 0xd3, 0xa1, 0xf9,
-	0x14, 0x20, 0x7b, 0xad, 0xd6, 0x96, 0x2d, 0xd3, 0xa9, 0xeb, 0xd2, 0x69, 0x2c, 0x92, 0x48, 0xf7,
-	0xa7, 0x0a, 0x90, 0x49, 0x1c, 0x7a, 0x54, 0x78, 0x7d, 0x8d, 0x35, 0x2a, 0x98, 0x7f, 0x7f, 0x65,
-	0x6a, 0xda, 0xcc, 0x32, 0xb5, 0x0e, 0xda, 0x6c, 0xee, 0x33, 0x5e, 0x5a, 0x98, 0x0e, 0xe9, 0xcc,
-	0x57, 0x84, 0xbf, 0x9e, 0x2d, 0x4c, 0x87, 0xb4, 0x94, 0xaf, 0xbd, 0xb3, 0x25, 0x61, 0x3d, 0xd4,
-	0xc2, 0xdc, 0xa0, 0xb3, 0xb3, 0x70, 0x19, 0x24, 0x4c, 0x53, 0x2b, 0x98, 0x1b, 0x79, 0xae, 0x6b,
-	0x45, 0xae, 0x7f, 0x94, 0x12, 0xb7, 0x01, 0x8d, 0x27, 0xf6, 0xb0, 0xcf, 0x94, 0x49, 0x2f, 0xa1,
-	0x3d, 0xd8, 0x4d, 0x4d, 0x67, 0x2a, 0xf4, 0xc8, 0xea, 0x4f, 0xdd, 0x11, 0x47, 0x28, 0x54, 0xe7,
-	0x38, 0x02, 0x8f, 0x9e, 0xdb, 0x7d, 0x2a, 0x67, 0x2a, 0xba, 0x0e, 0x5b, 0x47, 0x96, 0x3b, 0xed,
-	0x1d, 0x8f, 0x1c, 0x2b, 0x55, 0x39, 0x8d, 0x42, 0xe9, 0xf4, 0x78, 0xd2, 0x3d, 0xb6, 0x7b, 0xd3,
-	0x67, 0xd6, 0x0b, 0xbd, 0x4c, 0xf3, 0xd1, 0xb9, 0xe7, 0x9d, 0xe3, 0x89, 0xa5, 0x57, 0x90, 0x0e,
-	0x2d, 0xc7, 0xea, 0xe0, 0xde, 0x40, 0xcc, 0x54, 0x29, 0x60, 0x3c, 0x91, 0x80, 0x1a, 0x15, 0x5d,
-	0x91, 0x49, 0xaf, 0x9b, 0xdf, 0x29, 0xd0, 0xcc, 0xc9, 0x00, 0xfa, 0x5f, 0x81, 0xf1, 0x9b, 0xeb,
-	0xa4, 0x22, 0x4f, 0xf9, 0xdd, 0x1c, 0xe5, 0x6b, 0xf5, 0x22, 0xed, 0x5b, 0xce, 0xb0, 0x96, 0x63,
-	0xd8, 0xbc, 0x2b, 0x08, 0x6b, 0x40, 0xa5, 0x6b, 0x1d, 0xd9, 0x43, 0x2e, 0x0d, 0xbc, 0x4c, 0x85,
-	0x2a, 0xbd, 0x35, 0xec, 0xeb, 0xaa, 0xf9, 0x7f, 0xa8, 0xcb, 0x70, 0xef, 0x79, 0x4b, 0x7f, 0x56,
-	0x00, 0x5d, 0xfd, 0xf9, 0xa0, 0x8f, 0x0a, 0x7b, 0xdb, 0x7b, 0xc7, 0x27, 0xe9, 0x3d, 0xba, 0x2a,
-	0xf1, 0xf8, 0x5b, 0xd7, 0xc0, 0x74, 0x48, 0x5f, 0xdb, 0x6f, 0xc8, 0xfc, 0xf4, 0x75, 0xc2, 0x1a,
-	0x4b, 0xc3, 0xc2, 0x32, 0x0f, 0xb2, 0x7f, 0x8f, 0xdb, 0x39, 0x92, 0x3d, 0xd1, 0x06, 0x98, 0x0c,
-	0x53, 0x5b, 0x41, 0x75, 0x28, 0xbb, 0xd8, 0x3e, 0xd1, 0x55, 0xf3, 0x1e, 0x6c, 0x5d, 0xf9, 0x75,
-	0xad, 0xbb, 0x53, 0xe6, 0xf7, 0x0a, 0x34, 0xd2, 0x7f, 0x16, 0x7a, 0x58, 0xd8, 0xda, 0x8d, 0xab,
-	0x3f, 0xb1, 0xfc, 0x8e, 0xb6, 0xa1, 0x92, 0x84, 0x8b, 0xf9, 0x8c, 0x6d, 0xa9, 0x81, 0xb9, 0x41,
-	0x93, 0xf8, 0x5e, 0xe2, 0x89, 0x23, 0x62, 0x63, 0xb3, 0x2b, 0xaa, 0x6f, 0x03, 0xd0, 0x16, 0x73,
-	0x47, 0x63, 0xbb, 0xe7, 0xf0, 0xfa, 0x73, 0x9f, 0x2f, 0x85, 0xb5, 0x14, 0x6d, 0x49, 0x67, 0xa0,
-	0xab, 0xb4, 0xdd, 0x9c, 0x49, 0xd7, 0xe9, 0x61, 0xbb, 0x6b, 0xe9, 0x9a, 0xf9, 0x2d, 0x2b, 0xf4,
-	0x84, 0x3f, 0xbd, 0x34, 0xcb, 0xab, 0x28, 0x3c, 0x37, 0x14, 0x9e, 0x85, 0x8e, 0xd3, 0xcc, 0x6a,
-	0x96, 0x99, 0xd6, 0x18, 0x93, 0x37, 0x41, 0x28, 0x3b, 0x86, 0x19, 0xe8, 0x16, 0xd4, 0x59, 0xb1,
-	0x76, 0x3f, 0x36, 0xca, 0xec, 0x99, 0x4a, 0x6d, 0xb4, 0x0b, 0x8d, 0x78, 0x7e, 0x1a, 0x78, 0xc9,
-	0x32, 0x92, 0x37, 0x39, 0x9b, 0x90, 0xb7, 0xbe, 0x9a, 0xde, 0x7a, 0xf3, 0x73, 0x80, 0xec, 0xaf,
-	0x42, 0xcf, 0x8f, 0x45, 0x8a, 0x0d, 0x85, 0xc5, 0x15, 0x16, 0xbd, 0xef, 0x94, 0x6e, 0x9a, 0x90,
-	0xb7, 0x98, 0x34, 0xcd, 0x31, 0xe8, 0xab, 0xda, 0xf6, 0x77, 0x6f, 0x76, 0xf6, 0xd0, 0xe5, 0xd8,
-	0x56, 0x53, 0xb6, 0x1d, 0xd8, 0xba, 0xa2, 0xbb, 0x68, 0x17, 0xea, 0x91, 0x54, 0x69, 0x46, 0xda,
-	0xa0, 0x84, 0xd3, 0x19, 0xb4, 0x93, 0xff, 0x72, 0xd2, 0x25, 0x6e, 0x76, 0xeb, 0x50, 0x8d, 0x48,
-	0xbc, 0x3c, 0xa3, 0x0d, 0xb8, 0xb3, 0xfe, 0x27, 0x94, 0x15, 0xa6, 0xe4, 0x5f, 0xe0, 0x7b, 0xd0,
-	0xcc, 0xe9, 0x2d, 0xdd, 0xbf, 0x50, 0x4d, 0x96, 0xbd, 0x81, 0x53, 0x11, 0xad, 0x43, 0x95, 0x6b,
-	0x6c, 0xb7, 0xf5, 0xeb, 0xe5, 0x1d, 0xe5, 0xb7, 0xcb, 0x3b, 0xca, 0xef, 0x97, 0x77, 0x94, 0xbf,
-	0x02, 0x00, 0x00, 0xff, 0xff, 0x93, 0x31, 0xe5, 0xba, 0xb9, 0x0d, 0x00, 0x00,
+func (m *PeerstoreRequest) GetPeer() []byte {
+	if m != nil {
+		return m.Peer
+	}
+	return nil
 }
 
-func (m *Request) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *PeerstoreRequest) GetAddrs() [][]byte {
+	if m != nil {
+		return m.Addrs
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *Request) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *PeerstoreRequest) GetTtl() int64 {
+	if m != nil && m.Ttl != nil {
+		return *m.Ttl
+	}
+	return 0
 }
 
-func (m *Request) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+func (m *PeerstoreRequest) GetProtos() []string {
+	if m != nil {
+		return m.Protos
 	}
-	if m.Pubsub != nil {
-		{
-			size, err := m.Pubsub.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+	return nil
+}
+
+type PeerstoreResponse struct {
+	// protocols the peer announced, as learned from identify
+	Protocols []string `protobuf:"bytes,1,rep,name=protocols" json:"protocols,omitempty"`
+	// the requested protos the peer supports
+	Supported            []string `protobuf:"bytes,2,rep,name=supported" json:"supported,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerstoreResponse) Reset()         { *m = PeerstoreResponse{} }
+func (m *PeerstoreResponse) String() string { return proto.CompactTextString(m) }
+func (*PeerstoreResponse) ProtoMessage()    {}
+func (*PeerstoreResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{25}
+}
+func (m *PeerstoreResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PeerstoreResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PeerstoreResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x42
+		return b[:n], nil
 	}
-	if m.Disconnect != nil {
-		{
-			size, err := m.Disconnect.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintP2Pd(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x3a
+}
+func (m *PeerstoreResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerstoreResponse.Merge(m, src)
+}
+func (m *PeerstoreResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *PeerstoreResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerstoreResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerstoreResponse proto.InternalMessageInfo
+
+func (m *PeerstoreResponse) GetProtocols() []string {
+	if m != nil {
+		return m.Protocols
 	}
-	if m.ConnManager != nil {
-		{
-			size, err := m.ConnManager.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintP2Pd(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x32
+	return nil
+}
+
+func (m *PeerstoreResponse) GetSupported() []string {
+	if m != nil {
+		return m.Supported
 	}
-	if m.Dht != nil {
-		{
-			size, err := m.Dht.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+	return nil
+}
+
+type PeerListsRequest struct {
+	Type *PeerListsRequest_Type `protobuf:"varint,1,req,name=type,enum=p2pd.pb.PeerListsRequest_Type" json:"type,omitempty"`
+	// SET replaces both lists; an empty allow list allows every peer that
+	// isn't blocked
+	AllowedPeers         [][]byte `protobuf:"bytes,2,rep,name=allowedPeers" json:"allowedPeers,omitempty"`
+	BlockedPeers         [][]byte `protobuf:"bytes,3,rep,name=blockedPeers" json:"blockedPeers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerListsRequest) Reset()         { *m = PeerListsRequest{} }
+func (m *PeerListsRequest) String() string { return proto.CompactTextString(m) }
+func (*PeerListsRequest) ProtoMessage()    {}
+func (*PeerListsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{26}
+}
+func (m *PeerListsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PeerListsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PeerListsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x2a
+		return b[:n], nil
 	}
-	if m.StreamHandler != nil {
-		{
-			size, err := m.StreamHandler.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintP2Pd(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x22
+}
+func (m *PeerListsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerListsRequest.Merge(m, src)
+}
+func (m *PeerListsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *PeerListsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerListsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerListsRequest proto.InternalMessageInfo
+
+func (m *PeerListsRequest) GetType() PeerListsRequest_Type {
+	if m != nil && m.Type != nil {
+		return *m.Type
 	}
-	if m.StreamOpen != nil {
-		{
-			size, err := m.StreamOpen.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintP2Pd(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x1a
+	return PeerListsRequest_GET
+}
+
+func (m *PeerListsRequest) GetAllowedPeers() [][]byte {
+	if m != nil {
+		return m.AllowedPeers
 	}
-	if m.Connect != nil {
-		{
-			size, err := m.Connect.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintP2Pd(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
+	return nil
+}
+
+func (m *PeerListsRequest) GetBlockedPeers() [][]byte {
+	if m != nil {
+		return m.BlockedPeers
 	}
-	if m.Type == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+	return nil
+}
+
+type PeerListsResponse struct {
+	AllowedPeers         [][]byte `protobuf:"bytes,1,rep,name=allowedPeers" json:"allowedPeers,omitempty"`
+	BlockedPeers         [][]byte `protobuf:"bytes,2,rep,name=blockedPeers" json:"blockedPeers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerListsResponse) Reset()         { *m = PeerListsResponse{} }
+func (m *PeerListsResponse) String() string { return proto.CompactTextString(m) }
+func (*PeerListsResponse) ProtoMessage()    {}
+func (*PeerListsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{27}
+}
+func (m *PeerListsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PeerListsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PeerListsResponse.Marshal(b, m, deterministic)
 	} else {
-		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Type))
-		i--
-		dAtA[i] = 0x8
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *PeerListsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerListsResponse.Merge(m, src)
+}
+func (m *PeerListsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *PeerListsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerListsResponse.DiscardUnknown(m)
 }
 
-func (m *Response) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_PeerListsResponse proto.InternalMessageInfo
+
+func (m *PeerListsResponse) GetAllowedPeers() [][]byte {
+	if m != nil {
+		return m.AllowedPeers
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *Response) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *PeerListsResponse) GetBlockedPeers() [][]byte {
+	if m != nil {
+		return m.BlockedPeers
+	}
+	return nil
 }
 
-func (m *Response) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Pubsub != nil {
-		{
-			size, err := m.Pubsub.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintP2Pd(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x3a
-	}
-	if len(m.Peers) > 0 {
-		for iNdEx := len(m.Peers) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Peers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintP2Pd(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x32
-		}
-	}
-	if m.Dht != nil {
-		{
-			size, err := m.Dht.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintP2Pd(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x2a
-	}
-	if m.Identify != nil {
-		{
-			size, err := m.Identify.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintP2Pd(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x22
-	}
-	if m.StreamInfo != nil {
-		{
-			size, err := m.StreamInfo.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintP2Pd(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x1a
-	}
-	if m.Error != nil {
-		{
-			size, err := m.Error.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintP2Pd(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
-	}
-	if m.Type == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+type ResourceScope struct {
+	ConnsInbound         *int64   `protobuf:"varint,1,req,name=connsInbound" json:"connsInbound,omitempty"`
+	ConnsOutbound        *int64   `protobuf:"varint,2,req,name=connsOutbound" json:"connsOutbound,omitempty"`
+	StreamsInbound       *int64   `protobuf:"varint,3,req,name=streamsInbound" json:"streamsInbound,omitempty"`
+	StreamsOutbound      *int64   `protobuf:"varint,4,req,name=streamsOutbound" json:"streamsOutbound,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResourceScope) Reset()         { *m = ResourceScope{} }
+func (m *ResourceScope) String() string { return proto.CompactTextString(m) }
+func (*ResourceScope) ProtoMessage()    {}
+func (*ResourceScope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{28}
+}
+func (m *ResourceScope) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResourceScope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResourceScope.Marshal(b, m, deterministic)
 	} else {
-		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Type))
-		i--
-		dAtA[i] = 0x8
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *ResourceScope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResourceScope.Merge(m, src)
+}
+func (m *ResourceScope) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResourceScope) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResourceScope.DiscardUnknown(m)
 }
 
-func (m *PersistentConnectionRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_ResourceScope proto.InternalMessageInfo
+
+func (m *ResourceScope) GetConnsInbound() int64 {
+	if m != nil && m.ConnsInbound != nil {
+		return *m.ConnsInbound
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *PersistentConnectionRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *ResourceScope) GetConnsOutbound() int64 {
+	if m != nil && m.ConnsOutbound != nil {
+		return *m.ConnsOutbound
+	}
+	return 0
 }
 
-func (m *PersistentConnectionRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Message != nil {
-		{
-			size := m.Message.Size()
-			i -= size
-			if _, err := m.Message.MarshalTo(dAtA[i:]); err != nil {
-				return 0, err
-			}
-		}
+func (m *ResourceScope) GetStreamsInbound() int64 {
+	if m != nil && m.StreamsInbound != nil {
+		return *m.StreamsInbound
 	}
-	if m.CallId == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("callId")
-	} else {
-		i -= len(m.CallId)
-		copy(dAtA[i:], m.CallId)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.CallId)))
-		i--
-		dAtA[i] = 0xa
+	return 0
+}
+
+func (m *ResourceScope) GetStreamsOutbound() int64 {
+	if m != nil && m.StreamsOutbound != nil {
+		return *m.StreamsOutbound
 	}
-	return len(dAtA) - i, nil
+	return 0
 }
 
-func (m *PersistentConnectionRequest_AddUnaryHandler) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type ProtocolResourceScope struct {
+	// streams whose protocol is not negotiated yet have an empty proto
+	Proto                *string        `protobuf:"bytes,1,req,name=proto" json:"proto,omitempty"`
+	Usage                *ResourceScope `protobuf:"bytes,2,req,name=usage" json:"usage,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
-func (m *PersistentConnectionRequest_AddUnaryHandler) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.AddUnaryHandler != nil {
-		{
-			size, err := m.AddUnaryHandler.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+func (m *ProtocolResourceScope) Reset()         { *m = ProtocolResourceScope{} }
+func (m *ProtocolResourceScope) String() string { return proto.CompactTextString(m) }
+func (*ProtocolResourceScope) ProtoMessage()    {}
+func (*ProtocolResourceScope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{29}
+}
+func (m *ProtocolResourceScope) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ProtocolResourceScope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ProtocolResourceScope.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x12
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-func (m *PersistentConnectionRequest_CallUnary) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *ProtocolResourceScope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProtocolResourceScope.Merge(m, src)
+}
+func (m *ProtocolResourceScope) XXX_Size() int {
+	return m.Size()
+}
+func (m *ProtocolResourceScope) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProtocolResourceScope.DiscardUnknown(m)
 }
 
-func (m *PersistentConnectionRequest_CallUnary) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.CallUnary != nil {
-		{
-			size, err := m.CallUnary.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintP2Pd(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x1a
+var xxx_messageInfo_ProtocolResourceScope proto.InternalMessageInfo
+
+func (m *ProtocolResourceScope) GetProto() string {
+	if m != nil && m.Proto != nil {
+		return *m.Proto
 	}
-	return len(dAtA) - i, nil
-}
-func (m *PersistentConnectionRequest_UnaryResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	return ""
 }
 
-func (m *PersistentConnectionRequest_UnaryResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.UnaryResponse != nil {
-		{
-			size, err := m.UnaryResponse.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintP2Pd(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x22
+func (m *ProtocolResourceScope) GetUsage() *ResourceScope {
+	if m != nil {
+		return m.Usage
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
-func (m *PersistentConnectionRequest_Cancel) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+
+type PeerResourceScope struct {
+	Peer                 []byte         `protobuf:"bytes,1,req,name=peer" json:"peer,omitempty"`
+	Usage                *ResourceScope `protobuf:"bytes,2,req,name=usage" json:"usage,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
-func (m *PersistentConnectionRequest_Cancel) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.Cancel != nil {
-		{
-			size, err := m.Cancel.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+func (m *PeerResourceScope) Reset()         { *m = PeerResourceScope{} }
+func (m *PeerResourceScope) String() string { return proto.CompactTextString(m) }
+func (*PeerResourceScope) ProtoMessage()    {}
+func (*PeerResourceScope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{30}
+}
+func (m *PeerResourceScope) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PeerResourceScope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PeerResourceScope.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x2a
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-func (m *PersistentConnectionResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *PeerResourceScope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerResourceScope.Merge(m, src)
+}
+func (m *PeerResourceScope) XXX_Size() int {
+	return m.Size()
+}
+func (m *PeerResourceScope) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerResourceScope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerResourceScope proto.InternalMessageInfo
+
+func (m *PeerResourceScope) GetPeer() []byte {
+	if m != nil {
+		return m.Peer
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *PersistentConnectionResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *PeerResourceScope) GetUsage() *ResourceScope {
+	if m != nil {
+		return m.Usage
+	}
+	return nil
 }
 
-func (m *PersistentConnectionResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Message != nil {
-		{
-			size := m.Message.Size()
-			i -= size
-			if _, err := m.Message.MarshalTo(dAtA[i:]); err != nil {
-				return 0, err
-			}
-		}
-	}
-	if m.CallId == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("callId")
-	} else {
-		i -= len(m.CallId)
-		copy(dAtA[i:], m.CallId)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.CallId)))
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
+// ResourceUsageResponse reports the connections and streams currently open,
+// in total and by protocol and peer
+type ResourceUsageResponse struct {
+	System               *ResourceScope           `protobuf:"bytes,1,req,name=system" json:"system,omitempty"`
+	Protocols            []*ProtocolResourceScope `protobuf:"bytes,2,rep,name=protocols" json:"protocols,omitempty"`
+	Peers                []*PeerResourceScope     `protobuf:"bytes,3,rep,name=peers" json:"peers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
 }
 
-func (m *PersistentConnectionResponse_CallUnaryResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *ResourceUsageResponse) Reset()         { *m = ResourceUsageResponse{} }
+func (m *ResourceUsageResponse) String() string { return proto.CompactTextString(m) }
+func (*ResourceUsageResponse) ProtoMessage()    {}
+func (*ResourceUsageResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{31}
 }
-
-func (m *PersistentConnectionResponse_CallUnaryResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.CallUnaryResponse != nil {
-		{
-			size, err := m.CallUnaryResponse.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+func (m *ResourceUsageResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResourceUsageResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResourceUsageResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x12
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-func (m *PersistentConnectionResponse_RequestHandling) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *ResourceUsageResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResourceUsageResponse.Merge(m, src)
+}
+func (m *ResourceUsageResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResourceUsageResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResourceUsageResponse.DiscardUnknown(m)
 }
 
-func (m *PersistentConnectionResponse_RequestHandling) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.RequestHandling != nil {
-		{
-			size, err := m.RequestHandling.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintP2Pd(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x1a
+var xxx_messageInfo_ResourceUsageResponse proto.InternalMessageInfo
+
+func (m *ResourceUsageResponse) GetSystem() *ResourceScope {
+	if m != nil {
+		return m.System
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
-func (m *PersistentConnectionResponse_DaemonError) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+
+func (m *ResourceUsageResponse) GetProtocols() []*ProtocolResourceScope {
+	if m != nil {
+		return m.Protocols
+	}
+	return nil
 }
 
-func (m *PersistentConnectionResponse_DaemonError) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.DaemonError != nil {
-		{
-			size, err := m.DaemonError.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintP2Pd(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x22
+func (m *ResourceUsageResponse) GetPeers() []*PeerResourceScope {
+	if m != nil {
+		return m.Peers
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
-func (m *PersistentConnectionResponse_Cancel) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+
+// BandwidthRequest selects the bandwidth stats to report besides the totals
+type BandwidthRequest struct {
+	// reports the stats of each peer, or of this one only if set
+	ByPeer *bool  `protobuf:"varint,1,opt,name=byPeer" json:"byPeer,omitempty"`
+	Peer   []byte `protobuf:"bytes,2,opt,name=peer" json:"peer,omitempty"`
+	// reports the stats of each protocol
+	ByProtocol           *bool    `protobuf:"varint,3,opt,name=byProtocol" json:"byProtocol,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *PersistentConnectionResponse_Cancel) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.Cancel != nil {
-		{
-			size, err := m.Cancel.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+func (m *BandwidthRequest) Reset()         { *m = BandwidthRequest{} }
+func (m *BandwidthRequest) String() string { return proto.CompactTextString(m) }
+func (*BandwidthRequest) ProtoMessage()    {}
+func (*BandwidthRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{32}
+}
+func (m *BandwidthRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BandwidthRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BandwidthRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x2a
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-func (m *IdentifyResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *BandwidthRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BandwidthRequest.Merge(m, src)
 }
-
-func (m *IdentifyResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *BandwidthRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *BandwidthRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BandwidthRequest.DiscardUnknown(m)
 }
 
-func (m *IdentifyResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.Addrs) > 0 {
-		for iNdEx := len(m.Addrs) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Addrs[iNdEx])
-			copy(dAtA[i:], m.Addrs[iNdEx])
-			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Addrs[iNdEx])))
-			i--
-			dAtA[i] = 0x12
-		}
+var xxx_messageInfo_BandwidthRequest proto.InternalMessageInfo
+
+func (m *BandwidthRequest) GetByPeer() bool {
+	if m != nil && m.ByPeer != nil {
+		return *m.ByPeer
 	}
-	if m.Id == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("id")
-	} else {
-		i -= len(m.Id)
-		copy(dAtA[i:], m.Id)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Id)))
-		i--
-		dAtA[i] = 0xa
+	return false
+}
+
+func (m *BandwidthRequest) GetPeer() []byte {
+	if m != nil {
+		return m.Peer
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *ConnectRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *BandwidthRequest) GetByProtocol() bool {
+	if m != nil && m.ByProtocol != nil {
+		return *m.ByProtocol
 	}
-	return dAtA[:n], nil
+	return false
 }
 
-func (m *ConnectRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// BandwidthStats are the bytes transferred and the current rates, in bytes
+// per second
+type BandwidthStats struct {
+	TotalIn              *int64   `protobuf:"varint,1,req,name=totalIn" json:"totalIn,omitempty"`
+	TotalOut             *int64   `protobuf:"varint,2,req,name=totalOut" json:"totalOut,omitempty"`
+	RateIn               *float64 `protobuf:"fixed64,3,req,name=rateIn" json:"rateIn,omitempty"`
+	RateOut              *float64 `protobuf:"fixed64,4,req,name=rateOut" json:"rateOut,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ConnectRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Timeout != nil {
-		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Timeout))
-		i--
-		dAtA[i] = 0x18
-	}
-	if len(m.Addrs) > 0 {
-		for iNdEx := len(m.Addrs) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Addrs[iNdEx])
-			copy(dAtA[i:], m.Addrs[iNdEx])
-			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Addrs[iNdEx])))
-			i--
-			dAtA[i] = 0x12
+func (m *BandwidthStats) Reset()         { *m = BandwidthStats{} }
+func (m *BandwidthStats) String() string { return proto.CompactTextString(m) }
+func (*BandwidthStats) ProtoMessage()    {}
+func (*BandwidthStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{33}
+}
+func (m *BandwidthStats) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BandwidthStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BandwidthStats.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if m.Peer == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
-	} else {
-		i -= len(m.Peer)
-		copy(dAtA[i:], m.Peer)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
-		i--
-		dAtA[i] = 0xa
+}
+func (m *BandwidthStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BandwidthStats.Merge(m, src)
+}
+func (m *BandwidthStats) XXX_Size() int {
+	return m.Size()
+}
+func (m *BandwidthStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_BandwidthStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BandwidthStats proto.InternalMessageInfo
+
+func (m *BandwidthStats) GetTotalIn() int64 {
+	if m != nil && m.TotalIn != nil {
+		return *m.TotalIn
 	}
-	return len(dAtA) - i, nil
+	return 0
 }
 
-func (m *StreamOpenRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *BandwidthStats) GetTotalOut() int64 {
+	if m != nil && m.TotalOut != nil {
+		return *m.TotalOut
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *StreamOpenRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *BandwidthStats) GetRateIn() float64 {
+	if m != nil && m.RateIn != nil {
+		return *m.RateIn
+	}
+	return 0
 }
 
-func (m *StreamOpenRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Timeout != nil {
-		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Timeout))
-		i--
-		dAtA[i] = 0x18
+func (m *BandwidthStats) GetRateOut() float64 {
+	if m != nil && m.RateOut != nil {
+		return *m.RateOut
 	}
-	if len(m.Proto) > 0 {
-		for iNdEx := len(m.Proto) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Proto[iNdEx])
-			copy(dAtA[i:], m.Proto[iNdEx])
-			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Proto[iNdEx])))
-			i--
-			dAtA[i] = 0x12
+	return 0
+}
+
+type PeerBandwidth struct {
+	Peer                 []byte          `protobuf:"bytes,1,req,name=peer" json:"peer,omitempty"`
+	Stats                *BandwidthStats `protobuf:"bytes,2,req,name=stats" json:"stats,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *PeerBandwidth) Reset()         { *m = PeerBandwidth{} }
+func (m *PeerBandwidth) String() string { return proto.CompactTextString(m) }
+func (*PeerBandwidth) ProtoMessage()    {}
+func (*PeerBandwidth) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{34}
+}
+func (m *PeerBandwidth) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PeerBandwidth) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PeerBandwidth.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if m.Peer == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
-	} else {
-		i -= len(m.Peer)
-		copy(dAtA[i:], m.Peer)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
-		i--
-		dAtA[i] = 0xa
+}
+func (m *PeerBandwidth) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerBandwidth.Merge(m, src)
+}
+func (m *PeerBandwidth) XXX_Size() int {
+	return m.Size()
+}
+func (m *PeerBandwidth) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerBandwidth.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerBandwidth proto.InternalMessageInfo
+
+func (m *PeerBandwidth) GetPeer() []byte {
+	if m != nil {
+		return m.Peer
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *StreamHandlerRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *PeerBandwidth) GetStats() *BandwidthStats {
+	if m != nil {
+		return m.Stats
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *StreamHandlerRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type ProtocolBandwidth struct {
+	Proto                *string         `protobuf:"bytes,1,req,name=proto" json:"proto,omitempty"`
+	Stats                *BandwidthStats `protobuf:"bytes,2,req,name=stats" json:"stats,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
-func (m *StreamHandlerRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.Proto) > 0 {
-		for iNdEx := len(m.Proto) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Proto[iNdEx])
-			copy(dAtA[i:], m.Proto[iNdEx])
-			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Proto[iNdEx])))
-			i--
-			dAtA[i] = 0x12
+func (m *ProtocolBandwidth) Reset()         { *m = ProtocolBandwidth{} }
+func (m *ProtocolBandwidth) String() string { return proto.CompactTextString(m) }
+func (*ProtocolBandwidth) ProtoMessage()    {}
+func (*ProtocolBandwidth) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{35}
+}
+func (m *ProtocolBandwidth) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ProtocolBandwidth) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ProtocolBandwidth.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if m.Addr == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("addr")
-	} else {
-		i -= len(m.Addr)
-		copy(dAtA[i:], m.Addr)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Addr)))
-		i--
-		dAtA[i] = 0xa
+}
+func (m *ProtocolBandwidth) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProtocolBandwidth.Merge(m, src)
+}
+func (m *ProtocolBandwidth) XXX_Size() int {
+	return m.Size()
+}
+func (m *ProtocolBandwidth) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProtocolBandwidth.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProtocolBandwidth proto.InternalMessageInfo
+
+func (m *ProtocolBandwidth) GetProto() string {
+	if m != nil && m.Proto != nil {
+		return *m.Proto
 	}
-	return len(dAtA) - i, nil
+	return ""
 }
 
-func (m *ErrorResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *ProtocolBandwidth) GetStats() *BandwidthStats {
+	if m != nil {
+		return m.Stats
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *ErrorResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type BandwidthResponse struct {
+	Total                *BandwidthStats      `protobuf:"bytes,1,req,name=total" json:"total,omitempty"`
+	Peers                []*PeerBandwidth     `protobuf:"bytes,2,rep,name=peers" json:"peers,omitempty"`
+	Protocols            []*ProtocolBandwidth `protobuf:"bytes,3,rep,name=protocols" json:"protocols,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
-func (m *ErrorResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Msg == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("msg")
+func (m *BandwidthResponse) Reset()         { *m = BandwidthResponse{} }
+func (m *BandwidthResponse) String() string { return proto.CompactTextString(m) }
+func (*BandwidthResponse) ProtoMessage()    {}
+func (*BandwidthResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{36}
+}
+func (m *BandwidthResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BandwidthResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BandwidthResponse.Marshal(b, m, deterministic)
 	} else {
-		i -= len(*m.Msg)
-		copy(dAtA[i:], *m.Msg)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Msg)))
-		i--
-		dAtA[i] = 0xa
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *BandwidthResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BandwidthResponse.Merge(m, src)
+}
+func (m *BandwidthResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *BandwidthResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BandwidthResponse.DiscardUnknown(m)
 }
 
-func (m *StreamInfo) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_BandwidthResponse proto.InternalMessageInfo
+
+func (m *BandwidthResponse) GetTotal() *BandwidthStats {
+	if m != nil {
+		return m.Total
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *StreamInfo) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *BandwidthResponse) GetPeers() []*PeerBandwidth {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
 }
 
-func (m *StreamInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+func (m *BandwidthResponse) GetProtocols() []*ProtocolBandwidth {
+	if m != nil {
+		return m.Protocols
 	}
-	if m.Proto == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("proto")
+	return nil
+}
+
+// AddrsResponse is the address set the host advertises when asked, by origin
+type AddrsResponse struct {
+	// addresses of the interfaces the host listens on
+	Listen [][]byte `protobuf:"bytes,1,rep,name=listen" json:"listen,omitempty"`
+	// external addresses, as observed by peers or mapped by the NAT
+	Observed [][]byte `protobuf:"bytes,2,rep,name=observed" json:"observed,omitempty"`
+	// circuit addresses going through a relay
+	Relay                [][]byte `protobuf:"bytes,3,rep,name=relay" json:"relay,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddrsResponse) Reset()         { *m = AddrsResponse{} }
+func (m *AddrsResponse) String() string { return proto.CompactTextString(m) }
+func (*AddrsResponse) ProtoMessage()    {}
+func (*AddrsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{37}
+}
+func (m *AddrsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AddrsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AddrsResponse.Marshal(b, m, deterministic)
 	} else {
-		i -= len(*m.Proto)
-		copy(dAtA[i:], *m.Proto)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Proto)))
-		i--
-		dAtA[i] = 0x1a
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if m.Addr == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("addr")
-	} else {
-		i -= len(m.Addr)
-		copy(dAtA[i:], m.Addr)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Addr)))
-		i--
-		dAtA[i] = 0x12
+}
+func (m *AddrsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddrsResponse.Merge(m, src)
+}
+func (m *AddrsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *AddrsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddrsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddrsResponse proto.InternalMessageInfo
+
+func (m *AddrsResponse) GetListen() [][]byte {
+	if m != nil {
+		return m.Listen
 	}
-	if m.Peer == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
-	} else {
-		i -= len(m.Peer)
-		copy(dAtA[i:], m.Peer)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
-		i--
-		dAtA[i] = 0xa
+	return nil
+}
+
+func (m *AddrsResponse) GetObserved() [][]byte {
+	if m != nil {
+		return m.Observed
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *DHTRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *AddrsResponse) GetRelay() [][]byte {
+	if m != nil {
+		return m.Relay
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *DHTRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// RelayInfo describes a relay the host advertises circuit addresses through
+type RelayInfo struct {
+	Peer []byte `protobuf:"bytes,1,req,name=peer" json:"peer,omitempty"`
+	// the advertised circuit addresses going through the relay
+	Addrs                [][]byte `protobuf:"bytes,2,rep,name=addrs" json:"addrs,omitempty"`
+	Connected            *bool    `protobuf:"varint,3,req,name=connected" json:"connected,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DHTRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Timeout != nil {
-		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Timeout))
-		i--
-		dAtA[i] = 0x38
-	}
-	if m.Count != nil {
-		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Count))
-		i--
-		dAtA[i] = 0x30
-	}
-	if m.Value != nil {
-		i -= len(m.Value)
-		copy(dAtA[i:], m.Value)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Value)))
-		i--
-		dAtA[i] = 0x2a
-	}
-	if m.Key != nil {
-		i -= len(m.Key)
-		copy(dAtA[i:], m.Key)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Key)))
-		i--
-		dAtA[i] = 0x22
-	}
-	if m.Cid != nil {
-		i -= len(m.Cid)
-		copy(dAtA[i:], m.Cid)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Cid)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if m.Peer != nil {
-		i -= len(m.Peer)
-		copy(dAtA[i:], m.Peer)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if m.Type == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+func (m *RelayInfo) Reset()         { *m = RelayInfo{} }
+func (m *RelayInfo) String() string { return proto.CompactTextString(m) }
+func (*RelayInfo) ProtoMessage()    {}
+func (*RelayInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{38}
+}
+func (m *RelayInfo) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RelayInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RelayInfo.Marshal(b, m, deterministic)
 	} else {
-		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Type))
-		i--
-		dAtA[i] = 0x8
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-
-func (m *DHTResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *RelayInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RelayInfo.Merge(m, src)
 }
-
-func (m *DHTResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *RelayInfo) XXX_Size() int {
+	return m.Size()
+}
+func (m *RelayInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_RelayInfo.DiscardUnknown(m)
 }
 
-func (m *DHTResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Value != nil {
-		i -= len(m.Value)
-		copy(dAtA[i:], m.Value)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Value)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if m.Peer != nil {
-		{
-			size, err := m.Peer.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintP2Pd(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
+var xxx_messageInfo_RelayInfo proto.InternalMessageInfo
+
+func (m *RelayInfo) GetPeer() []byte {
+	if m != nil {
+		return m.Peer
 	}
-	if m.Type == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
-	} else {
-		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Type))
-		i--
-		dAtA[i] = 0x8
+	return nil
+}
+
+func (m *RelayInfo) GetAddrs() [][]byte {
+	if m != nil {
+		return m.Addrs
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *PeerInfo) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *RelayInfo) GetConnected() bool {
+	if m != nil && m.Connected != nil {
+		return *m.Connected
 	}
-	return dAtA[:n], nil
+	return false
 }
 
-func (m *PeerInfo) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// round trip times are in nanoseconds
+type PingResponse struct {
+	Rtts                 []int64  `protobuf:"varint,1,rep,name=rtts" json:"rtts,omitempty"`
+	Min                  *int64   `protobuf:"varint,2,req,name=min" json:"min,omitempty"`
+	Avg                  *int64   `protobuf:"varint,3,req,name=avg" json:"avg,omitempty"`
+	Max                  *int64   `protobuf:"varint,4,req,name=max" json:"max,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *PeerInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.Addrs) > 0 {
-		for iNdEx := len(m.Addrs) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Addrs[iNdEx])
-			copy(dAtA[i:], m.Addrs[iNdEx])
-			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Addrs[iNdEx])))
-			i--
-			dAtA[i] = 0x12
-		}
-	}
-	if m.Id == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("id")
+func (m *PingResponse) Reset()         { *m = PingResponse{} }
+func (m *PingResponse) String() string { return proto.CompactTextString(m) }
+func (*PingResponse) ProtoMessage()    {}
+func (*PingResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{39}
+}
+func (m *PingResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PingResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PingResponse.Marshal(b, m, deterministic)
 	} else {
-		i -= len(m.Id)
-		copy(dAtA[i:], m.Id)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Id)))
-		i--
-		dAtA[i] = 0xa
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-
-func (m *ConnManagerRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *PingResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PingResponse.Merge(m, src)
 }
-
-func (m *ConnManagerRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *PingResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *PingResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PingResponse.DiscardUnknown(m)
 }
 
-func (m *ConnManagerRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Weight != nil {
-		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Weight))
-		i--
-		dAtA[i] = 0x20
-	}
-	if m.Tag != nil {
-		i -= len(*m.Tag)
-		copy(dAtA[i:], *m.Tag)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Tag)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if m.Peer != nil {
-		i -= len(m.Peer)
-		copy(dAtA[i:], m.Peer)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if m.Type == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
-	} else {
-		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Type))
-		i--
-		dAtA[i] = 0x8
+var xxx_messageInfo_PingResponse proto.InternalMessageInfo
+
+func (m *PingResponse) GetRtts() []int64 {
+	if m != nil {
+		return m.Rtts
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *DisconnectRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *PingResponse) GetMin() int64 {
+	if m != nil && m.Min != nil {
+		return *m.Min
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *DisconnectRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *PingResponse) GetAvg() int64 {
+	if m != nil && m.Avg != nil {
+		return *m.Avg
+	}
+	return 0
 }
 
-func (m *DisconnectRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Peer == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
-	} else {
-		i -= len(m.Peer)
-		copy(dAtA[i:], m.Peer)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
-		i--
-		dAtA[i] = 0xa
+func (m *PingResponse) GetMax() int64 {
+	if m != nil && m.Max != nil {
+		return *m.Max
 	}
-	return len(dAtA) - i, nil
+	return 0
 }
 
-func (m *PSRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+type StreamOpenRequest struct {
+	Peer    []byte   `protobuf:"bytes,1,req,name=peer" json:"peer,omitempty"`
+	Proto   []string `protobuf:"bytes,2,rep,name=proto" json:"proto,omitempty"`
+	Timeout *int64   `protobuf:"varint,3,opt,name=timeout" json:"timeout,omitempty"`
+	// pipe the stream as StreamFrame messages rather than raw bytes
+	Framed               *bool    `protobuf:"varint,4,opt,name=framed" json:"framed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *PSRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *StreamOpenRequest) Reset()         { *m = StreamOpenRequest{} }
+func (m *StreamOpenRequest) String() string { return proto.CompactTextString(m) }
+func (*StreamOpenRequest) ProtoMessage()    {}
+func (*StreamOpenRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{40}
 }
-
-func (m *PSRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Data != nil {
-		i -= len(m.Data)
-		copy(dAtA[i:], m.Data)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Data)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if m.Topic != nil {
-		i -= len(*m.Topic)
-		copy(dAtA[i:], *m.Topic)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Topic)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if m.Type == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+func (m *StreamOpenRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StreamOpenRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StreamOpenRequest.Marshal(b, m, deterministic)
 	} else {
-		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Type))
-		i--
-		dAtA[i] = 0x8
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *StreamOpenRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamOpenRequest.Merge(m, src)
+}
+func (m *StreamOpenRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *StreamOpenRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamOpenRequest.DiscardUnknown(m)
 }
 
-func (m *PSMessage) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_StreamOpenRequest proto.InternalMessageInfo
+
+func (m *StreamOpenRequest) GetPeer() []byte {
+	if m != nil {
+		return m.Peer
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *PSMessage) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *StreamOpenRequest) GetProto() []string {
+	if m != nil {
+		return m.Proto
+	}
+	return nil
 }
 
-func (m *PSMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Key != nil {
-		i -= len(m.Key)
-		copy(dAtA[i:], m.Key)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Key)))
-		i--
-		dAtA[i] = 0x32
+func (m *StreamOpenRequest) GetTimeout() int64 {
+	if m != nil && m.Timeout != nil {
+		return *m.Timeout
 	}
-	if m.Signature != nil {
-		i -= len(m.Signature)
-		copy(dAtA[i:], m.Signature)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Signature)))
-		i--
-		dAtA[i] = 0x2a
+	return 0
+}
+
+func (m *StreamOpenRequest) GetFramed() bool {
+	if m != nil && m.Framed != nil {
+		return *m.Framed
 	}
-	if len(m.TopicIDs) > 0 {
-		for iNdEx := len(m.TopicIDs) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.TopicIDs[iNdEx])
-			copy(dAtA[i:], m.TopicIDs[iNdEx])
-			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.TopicIDs[iNdEx])))
-			i--
-			dAtA[i] = 0x22
+	return false
+}
+
+// StreamFrame carries the data of a framed stream, in both directions
+type StreamFrame struct {
+	Data []byte `protobuf:"bytes,1,opt,name=data" json:"data,omitempty"`
+	// the sender won't write to the stream anymore
+	CloseWrite *bool `protobuf:"varint,2,opt,name=closeWrite" json:"closeWrite,omitempty"`
+	// the stream was reset: sent by the daemon with the reason, or by the
+	// client to reset the stream
+	Error *string `protobuf:"bytes,3,opt,name=error" json:"error,omitempty"`
+	// sent on streams proxied over the persistent connection: by the daemon,
+	// the number of frames of the client written to the stream since the
+	// previous ack, see StreamInfo.window; by the client, the number of data
+	// frames of the daemon it read since the previous ack, see
+	// AddUnaryHandlerRequest.window
+	Ack                  *uint32  `protobuf:"varint,4,opt,name=ack" json:"ack,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StreamFrame) Reset()         { *m = StreamFrame{} }
+func (m *StreamFrame) String() string { return proto.CompactTextString(m) }
+func (*StreamFrame) ProtoMessage()    {}
+func (*StreamFrame) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{41}
+}
+func (m *StreamFrame) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StreamFrame) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StreamFrame.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if m.Seqno != nil {
-		i -= len(m.Seqno)
-		copy(dAtA[i:], m.Seqno)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Seqno)))
-		i--
-		dAtA[i] = 0x1a
+}
+func (m *StreamFrame) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamFrame.Merge(m, src)
+}
+func (m *StreamFrame) XXX_Size() int {
+	return m.Size()
+}
+func (m *StreamFrame) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamFrame.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamFrame proto.InternalMessageInfo
+
+func (m *StreamFrame) GetData() []byte {
+	if m != nil {
+		return m.Data
 	}
-	if m.Data != nil {
-		i -= len(m.Data)
-		copy(dAtA[i:], m.Data)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Data)))
-		i--
-		dAtA[i] = 0x12
+	return nil
+}
+
+func (m *StreamFrame) GetCloseWrite() bool {
+	if m != nil && m.CloseWrite != nil {
+		return *m.CloseWrite
 	}
-	if m.From != nil {
-		i -= len(m.From)
-		copy(dAtA[i:], m.From)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.From)))
-		i--
-		dAtA[i] = 0xa
+	return false
+}
+
+func (m *StreamFrame) GetError() string {
+	if m != nil && m.Error != nil {
+		return *m.Error
 	}
-	return len(dAtA) - i, nil
+	return ""
 }
 
-func (m *PSResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *StreamFrame) GetAck() uint32 {
+	if m != nil && m.Ack != nil {
+		return *m.Ack
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *PSResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// PayloadChunk carries a piece of a payload too large for a single message.
+// The message the payload belongs to is sent first, with an empty payload and
+// its size in chunkedSize, followed by the chunks in order, numbered from
+// zero; they are sent over the persistent connections and the streams between
+// daemons alike.
+type PayloadChunk struct {
+	Seq  *uint32 `protobuf:"varint,1,req,name=seq" json:"seq,omitempty"`
+	Data []byte  `protobuf:"bytes,2,opt,name=data" json:"data,omitempty"`
+	// the chunk completes the payload
+	Last                 *bool    `protobuf:"varint,3,opt,name=last" json:"last,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *PSResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.PeerIDs) > 0 {
-		for iNdEx := len(m.PeerIDs) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.PeerIDs[iNdEx])
-			copy(dAtA[i:], m.PeerIDs[iNdEx])
-			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.PeerIDs[iNdEx])))
-			i--
-			dAtA[i] = 0x12
-		}
-	}
-	if len(m.Topics) > 0 {
-		for iNdEx := len(m.Topics) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Topics[iNdEx])
-			copy(dAtA[i:], m.Topics[iNdEx])
-			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Topics[iNdEx])))
-			i--
-			dAtA[i] = 0xa
+func (m *PayloadChunk) Reset()         { *m = PayloadChunk{} }
+func (m *PayloadChunk) String() string { return proto.CompactTextString(m) }
+func (*PayloadChunk) ProtoMessage()    {}
+func (*PayloadChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{42}
+}
+func (m *PayloadChunk) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PayloadChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PayloadChunk.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *PayloadChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PayloadChunk.Merge(m, src)
+}
+func (m *PayloadChunk) XXX_Size() int {
+	return m.Size()
+}
+func (m *PayloadChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_PayloadChunk.DiscardUnknown(m)
 }
 
-func (m *CallUnaryRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_PayloadChunk proto.InternalMessageInfo
+
+func (m *PayloadChunk) GetSeq() uint32 {
+	if m != nil && m.Seq != nil {
+		return *m.Seq
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *CallUnaryRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *PayloadChunk) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
 }
 
-func (m *CallUnaryRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+func (m *PayloadChunk) GetLast() bool {
+	if m != nil && m.Last != nil {
+		return *m.Last
 	}
-	if m.Data == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("data")
-	} else {
-		i -= len(m.Data)
-		copy(dAtA[i:], m.Data)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Data)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if m.Proto == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("proto")
+	return false
+}
+
+type StreamHandlerRequest struct {
+	Addr                 []byte   `protobuf:"bytes,1,req,name=addr" json:"addr,omitempty"`
+	Proto                []string `protobuf:"bytes,2,rep,name=proto" json:"proto,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StreamHandlerRequest) Reset()         { *m = StreamHandlerRequest{} }
+func (m *StreamHandlerRequest) String() string { return proto.CompactTextString(m) }
+func (*StreamHandlerRequest) ProtoMessage()    {}
+func (*StreamHandlerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{43}
+}
+func (m *StreamHandlerRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StreamHandlerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StreamHandlerRequest.Marshal(b, m, deterministic)
 	} else {
-		i -= len(*m.Proto)
-		copy(dAtA[i:], *m.Proto)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Proto)))
-		i--
-		dAtA[i] = 0x12
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if m.Peer == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
-	} else {
-		i -= len(m.Peer)
-		copy(dAtA[i:], m.Peer)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
-		i--
-		dAtA[i] = 0xa
+}
+func (m *StreamHandlerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamHandlerRequest.Merge(m, src)
+}
+func (m *StreamHandlerRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *StreamHandlerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamHandlerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamHandlerRequest proto.InternalMessageInfo
+
+func (m *StreamHandlerRequest) GetAddr() []byte {
+	if m != nil {
+		return m.Addr
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *CallUnaryResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *StreamHandlerRequest) GetProto() []string {
+	if m != nil {
+		return m.Proto
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *CallUnaryResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type ErrorResponse struct {
+	Msg *string `protobuf:"bytes,1,req,name=msg" json:"msg,omitempty"`
+	// the error of every address dialed, when CONNECT fails to dial the peer
+	DialAttempts         []*DialAttempt `protobuf:"bytes,2,rep,name=dialAttempts" json:"dialAttempts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
-func (m *CallUnaryResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Result != nil {
-		{
-			size := m.Result.Size()
-			i -= size
-			if _, err := m.Result.MarshalTo(dAtA[i:]); err != nil {
-				return 0, err
-			}
+func (m *ErrorResponse) Reset()         { *m = ErrorResponse{} }
+func (m *ErrorResponse) String() string { return proto.CompactTextString(m) }
+func (*ErrorResponse) ProtoMessage()    {}
+func (*ErrorResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{44}
+}
+func (m *ErrorResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ErrorResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ErrorResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-
-func (m *CallUnaryResponse_Response) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *ErrorResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ErrorResponse.Merge(m, src)
 }
-
-func (m *CallUnaryResponse_Response) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.Response != nil {
-		i -= len(m.Response)
-		copy(dAtA[i:], m.Response)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Response)))
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
+func (m *ErrorResponse) XXX_Size() int {
+	return m.Size()
 }
-func (m *CallUnaryResponse_Error) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *ErrorResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ErrorResponse.DiscardUnknown(m)
 }
 
-func (m *CallUnaryResponse_Error) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.Error != nil {
-		i -= len(m.Error)
-		copy(dAtA[i:], m.Error)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Error)))
-		i--
-		dAtA[i] = 0x12
+var xxx_messageInfo_ErrorResponse proto.InternalMessageInfo
+
+func (m *ErrorResponse) GetMsg() string {
+	if m != nil && m.Msg != nil {
+		return *m.Msg
 	}
-	return len(dAtA) - i, nil
+	return ""
 }
-func (m *AddUnaryHandlerRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+
+func (m *ErrorResponse) GetDialAttempts() []*DialAttempt {
+	if m != nil {
+		return m.DialAttempts
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *AddUnaryHandlerRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type DialAttempt struct {
+	Addr                 []byte              `protobuf:"bytes,1,req,name=addr" json:"addr,omitempty"`
+	Error                *string             `protobuf:"bytes,2,req,name=error" json:"error,omitempty"`
+	Reason               *DialAttempt_Reason `protobuf:"varint,3,opt,name=reason,enum=p2pd.pb.DialAttempt_Reason" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
 }
 
-func (m *AddUnaryHandlerRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Proto == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("proto")
+func (m *DialAttempt) Reset()         { *m = DialAttempt{} }
+func (m *DialAttempt) String() string { return proto.CompactTextString(m) }
+func (*DialAttempt) ProtoMessage()    {}
+func (*DialAttempt) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{45}
+}
+func (m *DialAttempt) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DialAttempt) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DialAttempt.Marshal(b, m, deterministic)
 	} else {
-		i -= len(*m.Proto)
-		copy(dAtA[i:], *m.Proto)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Proto)))
-		i--
-		dAtA[i] = 0xa
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-
-func (m *DaemonError) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *DialAttempt) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DialAttempt.Merge(m, src)
 }
-
-func (m *DaemonError) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *DialAttempt) XXX_Size() int {
+	return m.Size()
+}
+func (m *DialAttempt) XXX_DiscardUnknown() {
+	xxx_messageInfo_DialAttempt.DiscardUnknown(m)
 }
 
-func (m *DaemonError) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Message != nil {
-		i -= len(*m.Message)
-		copy(dAtA[i:], *m.Message)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Message)))
-		i--
-		dAtA[i] = 0xa
+var xxx_messageInfo_DialAttempt proto.InternalMessageInfo
+
+func (m *DialAttempt) GetAddr() []byte {
+	if m != nil {
+		return m.Addr
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *Cancel) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *DialAttempt) GetError() string {
+	if m != nil && m.Error != nil {
+		return *m.Error
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *Cancel) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *DialAttempt) GetReason() DialAttempt_Reason {
+	if m != nil && m.Reason != nil {
+		return *m.Reason
+	}
+	return DialAttempt_OTHER
 }
 
-func (m *Cancel) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return len(dAtA) - i, nil
+type StreamInfo struct {
+	Peer  []byte  `protobuf:"bytes,1,req,name=peer" json:"peer,omitempty"`
+	Addr  []byte  `protobuf:"bytes,2,req,name=addr" json:"addr,omitempty"`
+	Proto *string `protobuf:"bytes,3,req,name=proto" json:"proto,omitempty"`
+	// set on streams proxied over the persistent connection: the number of
+	// frames the client may send ahead of those the daemon acked
+	Window               *uint32  `protobuf:"varint,4,opt,name=window" json:"window,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func encodeVarintP2Pd(dAtA []byte, offset int, v uint64) int {
-	offset -= sovP2Pd(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
-	}
-	dAtA[offset] = uint8(v)
-	return base
+func (m *StreamInfo) Reset()         { *m = StreamInfo{} }
+func (m *StreamInfo) String() string { return proto.CompactTextString(m) }
+func (*StreamInfo) ProtoMessage()    {}
+func (*StreamInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{46}
 }
-func (m *Request) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Type != nil {
-		n += 1 + sovP2Pd(uint64(*m.Type))
-	}
-	if m.Connect != nil {
-		l = m.Connect.Size()
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	if m.StreamOpen != nil {
-		l = m.StreamOpen.Size()
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	if m.StreamHandler != nil {
-		l = m.StreamHandler.Size()
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	if m.Dht != nil {
-		l = m.Dht.Size()
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	if m.ConnManager != nil {
-		l = m.ConnManager.Size()
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	if m.Disconnect != nil {
-		l = m.Disconnect.Size()
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	if m.Pubsub != nil {
-		l = m.Pubsub.Size()
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *StreamInfo) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StreamInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StreamInfo.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return n
+}
+func (m *StreamInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamInfo.Merge(m, src)
+}
+func (m *StreamInfo) XXX_Size() int {
+	return m.Size()
+}
+func (m *StreamInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamInfo.DiscardUnknown(m)
 }
 
-func (m *Response) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Type != nil {
-		n += 1 + sovP2Pd(uint64(*m.Type))
-	}
-	if m.Error != nil {
-		l = m.Error.Size()
-		n += 1 + l + sovP2Pd(uint64(l))
+var xxx_messageInfo_StreamInfo proto.InternalMessageInfo
+
+func (m *StreamInfo) GetPeer() []byte {
+	if m != nil {
+		return m.Peer
 	}
-	if m.StreamInfo != nil {
-		l = m.StreamInfo.Size()
-		n += 1 + l + sovP2Pd(uint64(l))
+	return nil
+}
+
+func (m *StreamInfo) GetAddr() []byte {
+	if m != nil {
+		return m.Addr
 	}
-	if m.Identify != nil {
-		l = m.Identify.Size()
-		n += 1 + l + sovP2Pd(uint64(l))
+	return nil
+}
+
+func (m *StreamInfo) GetProto() string {
+	if m != nil && m.Proto != nil {
+		return *m.Proto
 	}
-	if m.Dht != nil {
-		l = m.Dht.Size()
-		n += 1 + l + sovP2Pd(uint64(l))
+	return ""
+}
+
+func (m *StreamInfo) GetWindow() uint32 {
+	if m != nil && m.Window != nil {
+		return *m.Window
 	}
-	if len(m.Peers) > 0 {
-		for _, e := range m.Peers {
-			l = e.Size()
-			n += 1 + l + sovP2Pd(uint64(l))
+	return 0
+}
+
+type DHTRequest struct {
+	Type                 *DHTRequest_Type `protobuf:"varint,1,req,name=type,enum=p2pd.pb.DHTRequest_Type" json:"type,omitempty"`
+	Peer                 []byte           `protobuf:"bytes,2,opt,name=peer" json:"peer,omitempty"`
+	Cid                  []byte           `protobuf:"bytes,3,opt,name=cid" json:"cid,omitempty"`
+	Key                  []byte           `protobuf:"bytes,4,opt,name=key" json:"key,omitempty"`
+	Value                []byte           `protobuf:"bytes,5,opt,name=value" json:"value,omitempty"`
+	Count                *int32           `protobuf:"varint,6,opt,name=count" json:"count,omitempty"`
+	Timeout              *int64           `protobuf:"varint,7,opt,name=timeout" json:"timeout,omitempty"`
+	Quorum               *int32           `protobuf:"varint,8,opt,name=quorum" json:"quorum,omitempty"`
+	Announce             *bool            `protobuf:"varint,9,opt,name=announce" json:"announce,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *DHTRequest) Reset()         { *m = DHTRequest{} }
+func (m *DHTRequest) String() string { return proto.CompactTextString(m) }
+func (*DHTRequest) ProtoMessage()    {}
+func (*DHTRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{47}
+}
+func (m *DHTRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DHTRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DHTRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if m.Pubsub != nil {
-		l = m.Pubsub.Size()
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+}
+func (m *DHTRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DHTRequest.Merge(m, src)
+}
+func (m *DHTRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *DHTRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DHTRequest.DiscardUnknown(m)
 }
 
-func (m *PersistentConnectionRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.CallId != nil {
-		l = len(m.CallId)
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	if m.Message != nil {
-		n += m.Message.Size()
+var xxx_messageInfo_DHTRequest proto.InternalMessageInfo
+
+func (m *DHTRequest) GetType() DHTRequest_Type {
+	if m != nil && m.Type != nil {
+		return *m.Type
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return DHTRequest_FIND_PEER
+}
+
+func (m *DHTRequest) GetPeer() []byte {
+	if m != nil {
+		return m.Peer
 	}
-	return n
+	return nil
 }
 
-func (m *PersistentConnectionRequest_AddUnaryHandler) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *DHTRequest) GetCid() []byte {
+	if m != nil {
+		return m.Cid
 	}
-	var l int
-	_ = l
-	if m.AddUnaryHandler != nil {
-		l = m.AddUnaryHandler.Size()
-		n += 1 + l + sovP2Pd(uint64(l))
+	return nil
+}
+
+func (m *DHTRequest) GetKey() []byte {
+	if m != nil {
+		return m.Key
 	}
-	return n
+	return nil
 }
-func (m *PersistentConnectionRequest_CallUnary) Size() (n int) {
-	if m == nil {
-		return 0
+
+func (m *DHTRequest) GetValue() []byte {
+	if m != nil {
+		return m.Value
 	}
-	var l int
-	_ = l
-	if m.CallUnary != nil {
-		l = m.CallUnary.Size()
-		n += 1 + l + sovP2Pd(uint64(l))
+	return nil
+}
+
+func (m *DHTRequest) GetCount() int32 {
+	if m != nil && m.Count != nil {
+		return *m.Count
 	}
-	return n
+	return 0
 }
-func (m *PersistentConnectionRequest_UnaryResponse) Size() (n int) {
-	if m == nil {
-		return 0
+
+func (m *DHTRequest) GetTimeout() int64 {
+	if m != nil && m.Timeout != nil {
+		return *m.Timeout
 	}
-	var l int
-	_ = l
-	if m.UnaryResponse != nil {
-		l = m.UnaryResponse.Size()
-		n += 1 + l + sovP2Pd(uint64(l))
+	return 0
+}
+
+func (m *DHTRequest) GetQuorum() int32 {
+	if m != nil && m.Quorum != nil {
+		return *m.Quorum
 	}
-	return n
+	return 0
 }
-func (m *PersistentConnectionRequest_Cancel) Size() (n int) {
-	if m == nil {
-		return 0
+
+func (m *DHTRequest) GetAnnounce() bool {
+	if m != nil && m.Announce != nil {
+		return *m.Announce
 	}
-	var l int
-	_ = l
-	if m.Cancel != nil {
-		l = m.Cancel.Size()
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	return n
+	return false
 }
-func (m *PersistentConnectionResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.CallId != nil {
-		l = len(m.CallId)
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	if m.Message != nil {
-		n += m.Message.Size()
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+
+type DHTResponse struct {
+	Type                 *DHTResponse_Type `protobuf:"varint,1,req,name=type,enum=p2pd.pb.DHTResponse_Type" json:"type,omitempty"`
+	Peer                 *PeerInfo         `protobuf:"bytes,2,opt,name=peer" json:"peer,omitempty"`
+	Value                []byte            `protobuf:"bytes,3,opt,name=value" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
-func (m *PersistentConnectionResponse_CallUnaryResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.CallUnaryResponse != nil {
-		l = m.CallUnaryResponse.Size()
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	return n
+func (m *DHTResponse) Reset()         { *m = DHTResponse{} }
+func (m *DHTResponse) String() string { return proto.CompactTextString(m) }
+func (*DHTResponse) ProtoMessage()    {}
+func (*DHTResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{48}
 }
-func (m *PersistentConnectionResponse_RequestHandling) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.RequestHandling != nil {
-		l = m.RequestHandling.Size()
-		n += 1 + l + sovP2Pd(uint64(l))
+func (m *DHTResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DHTResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DHTResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return n
 }
-func (m *PersistentConnectionResponse_DaemonError) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *DHTResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DHTResponse.Merge(m, src)
+}
+func (m *DHTResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *DHTResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DHTResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DHTResponse proto.InternalMessageInfo
+
+func (m *DHTResponse) GetType() DHTResponse_Type {
+	if m != nil && m.Type != nil {
+		return *m.Type
 	}
-	var l int
-	_ = l
-	if m.DaemonError != nil {
-		l = m.DaemonError.Size()
-		n += 1 + l + sovP2Pd(uint64(l))
+	return DHTResponse_BEGIN
+}
+
+func (m *DHTResponse) GetPeer() *PeerInfo {
+	if m != nil {
+		return m.Peer
 	}
-	return n
+	return nil
 }
-func (m *PersistentConnectionResponse_Cancel) Size() (n int) {
-	if m == nil {
-		return 0
+
+func (m *DHTResponse) GetValue() []byte {
+	if m != nil {
+		return m.Value
 	}
-	var l int
-	_ = l
-	if m.Cancel != nil {
-		l = m.Cancel.Size()
-		n += 1 + l + sovP2Pd(uint64(l))
+	return nil
+}
+
+type PeerInfo struct {
+	Id                   []byte   `protobuf:"bytes,1,req,name=id" json:"id,omitempty"`
+	Addrs                [][]byte `protobuf:"bytes,2,rep,name=addrs" json:"addrs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerInfo) Reset()         { *m = PeerInfo{} }
+func (m *PeerInfo) String() string { return proto.CompactTextString(m) }
+func (*PeerInfo) ProtoMessage()    {}
+func (*PeerInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{49}
+}
+func (m *PeerInfo) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PeerInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PeerInfo.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return n
 }
-func (m *IdentifyResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *PeerInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerInfo.Merge(m, src)
+}
+func (m *PeerInfo) XXX_Size() int {
+	return m.Size()
+}
+func (m *PeerInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerInfo proto.InternalMessageInfo
+
+func (m *PeerInfo) GetId() []byte {
+	if m != nil {
+		return m.Id
 	}
-	var l int
-	_ = l
-	if m.Id != nil {
-		l = len(m.Id)
-		n += 1 + l + sovP2Pd(uint64(l))
+	return nil
+}
+
+func (m *PeerInfo) GetAddrs() [][]byte {
+	if m != nil {
+		return m.Addrs
 	}
-	if len(m.Addrs) > 0 {
-		for _, b := range m.Addrs {
-			l = len(b)
-			n += 1 + l + sovP2Pd(uint64(l))
+	return nil
+}
+
+type ConnectedPeer struct {
+	Id                   []byte            `protobuf:"bytes,1,req,name=id" json:"id,omitempty"`
+	Conns                []*ConnectionInfo `protobuf:"bytes,2,rep,name=conns" json:"conns,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ConnectedPeer) Reset()         { *m = ConnectedPeer{} }
+func (m *ConnectedPeer) String() string { return proto.CompactTextString(m) }
+func (*ConnectedPeer) ProtoMessage()    {}
+func (*ConnectedPeer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{50}
+}
+func (m *ConnectedPeer) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ConnectedPeer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ConnectedPeer.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+}
+func (m *ConnectedPeer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConnectedPeer.Merge(m, src)
+}
+func (m *ConnectedPeer) XXX_Size() int {
+	return m.Size()
+}
+func (m *ConnectedPeer) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConnectedPeer.DiscardUnknown(m)
 }
 
-func (m *ConnectRequest) Size() (n int) {
-	if m == nil {
-		return 0
+var xxx_messageInfo_ConnectedPeer proto.InternalMessageInfo
+
+func (m *ConnectedPeer) GetId() []byte {
+	if m != nil {
+		return m.Id
 	}
-	var l int
-	_ = l
-	if m.Peer != nil {
-		l = len(m.Peer)
-		n += 1 + l + sovP2Pd(uint64(l))
+	return nil
+}
+
+func (m *ConnectedPeer) GetConns() []*ConnectionInfo {
+	if m != nil {
+		return m.Conns
 	}
-	if len(m.Addrs) > 0 {
-		for _, b := range m.Addrs {
-			l = len(b)
-			n += 1 + l + sovP2Pd(uint64(l))
+	return nil
+}
+
+type ConnectionInfo struct {
+	Addr      []byte                    `protobuf:"bytes,1,req,name=addr" json:"addr,omitempty"`
+	Direction *ConnectionInfo_Direction `protobuf:"varint,2,req,name=direction,enum=p2pd.pb.ConnectionInfo_Direction" json:"direction,omitempty"`
+	Relayed   *bool                     `protobuf:"varint,3,req,name=relayed" json:"relayed,omitempty"`
+	// security is left unset when the negotiated protocol is not known
+	Security             *string  `protobuf:"bytes,4,opt,name=security" json:"security,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConnectionInfo) Reset()         { *m = ConnectionInfo{} }
+func (m *ConnectionInfo) String() string { return proto.CompactTextString(m) }
+func (*ConnectionInfo) ProtoMessage()    {}
+func (*ConnectionInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{51}
+}
+func (m *ConnectionInfo) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ConnectionInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ConnectionInfo.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if m.Timeout != nil {
-		n += 1 + sovP2Pd(uint64(*m.Timeout))
+}
+func (m *ConnectionInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConnectionInfo.Merge(m, src)
+}
+func (m *ConnectionInfo) XXX_Size() int {
+	return m.Size()
+}
+func (m *ConnectionInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConnectionInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConnectionInfo proto.InternalMessageInfo
+
+func (m *ConnectionInfo) GetAddr() []byte {
+	if m != nil {
+		return m.Addr
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return nil
+}
+
+func (m *ConnectionInfo) GetDirection() ConnectionInfo_Direction {
+	if m != nil && m.Direction != nil {
+		return *m.Direction
 	}
-	return n
+	return ConnectionInfo_UNKNOWN
 }
 
-func (m *StreamOpenRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Peer != nil {
-		l = len(m.Peer)
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	if len(m.Proto) > 0 {
-		for _, s := range m.Proto {
-			l = len(s)
-			n += 1 + l + sovP2Pd(uint64(l))
-		}
-	}
-	if m.Timeout != nil {
-		n += 1 + sovP2Pd(uint64(*m.Timeout))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *ConnectionInfo) GetRelayed() bool {
+	if m != nil && m.Relayed != nil {
+		return *m.Relayed
 	}
-	return n
+	return false
 }
 
-func (m *StreamHandlerRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ConnectionInfo) GetSecurity() string {
+	if m != nil && m.Security != nil {
+		return *m.Security
 	}
-	var l int
-	_ = l
-	if m.Addr != nil {
-		l = len(m.Addr)
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	if len(m.Proto) > 0 {
-		for _, s := range m.Proto {
-			l = len(s)
-			n += 1 + l + sovP2Pd(uint64(l))
+	return ""
+}
+
+type ConnManagerRequest struct {
+	Type                 *ConnManagerRequest_Type `protobuf:"varint,1,req,name=type,enum=p2pd.pb.ConnManagerRequest_Type" json:"type,omitempty"`
+	Peer                 []byte                   `protobuf:"bytes,2,opt,name=peer" json:"peer,omitempty"`
+	Tag                  *string                  `protobuf:"bytes,3,opt,name=tag" json:"tag,omitempty"`
+	Weight               *int64                   `protobuf:"varint,4,opt,name=weight" json:"weight,omitempty"`
+	LowWaterMark         *int64                   `protobuf:"varint,5,opt,name=lowWaterMark" json:"lowWaterMark,omitempty"`
+	HighWaterMark        *int64                   `protobuf:"varint,6,opt,name=highWaterMark" json:"highWaterMark,omitempty"`
+	GracePeriod          *int64                   `protobuf:"varint,7,opt,name=gracePeriod" json:"gracePeriod,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *ConnManagerRequest) Reset()         { *m = ConnManagerRequest{} }
+func (m *ConnManagerRequest) String() string { return proto.CompactTextString(m) }
+func (*ConnManagerRequest) ProtoMessage()    {}
+func (*ConnManagerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{52}
+}
+func (m *ConnManagerRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ConnManagerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ConnManagerRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+}
+func (m *ConnManagerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConnManagerRequest.Merge(m, src)
+}
+func (m *ConnManagerRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ConnManagerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConnManagerRequest.DiscardUnknown(m)
 }
 
-func (m *ErrorResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Msg != nil {
-		l = len(*m.Msg)
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+var xxx_messageInfo_ConnManagerRequest proto.InternalMessageInfo
+
+func (m *ConnManagerRequest) GetType() ConnManagerRequest_Type {
+	if m != nil && m.Type != nil {
+		return *m.Type
 	}
-	return n
+	return ConnManagerRequest_TAG_PEER
 }
 
-func (m *StreamInfo) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Peer != nil {
-		l = len(m.Peer)
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	if m.Addr != nil {
-		l = len(m.Addr)
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	if m.Proto != nil {
-		l = len(*m.Proto)
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *ConnManagerRequest) GetPeer() []byte {
+	if m != nil {
+		return m.Peer
 	}
-	return n
+	return nil
 }
 
-func (m *DHTRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Type != nil {
-		n += 1 + sovP2Pd(uint64(*m.Type))
-	}
-	if m.Peer != nil {
-		l = len(m.Peer)
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	if m.Cid != nil {
-		l = len(m.Cid)
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	if m.Key != nil {
-		l = len(m.Key)
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	if m.Value != nil {
-		l = len(m.Value)
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	if m.Count != nil {
-		n += 1 + sovP2Pd(uint64(*m.Count))
-	}
-	if m.Timeout != nil {
-		n += 1 + sovP2Pd(uint64(*m.Timeout))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *ConnManagerRequest) GetTag() string {
+	if m != nil && m.Tag != nil {
+		return *m.Tag
 	}
-	return n
+	return ""
 }
 
-func (m *DHTResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Type != nil {
-		n += 1 + sovP2Pd(uint64(*m.Type))
-	}
-	if m.Peer != nil {
-		l = m.Peer.Size()
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	if m.Value != nil {
-		l = len(m.Value)
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *ConnManagerRequest) GetWeight() int64 {
+	if m != nil && m.Weight != nil {
+		return *m.Weight
 	}
-	return n
+	return 0
 }
 
-func (m *PeerInfo) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Id != nil {
-		l = len(m.Id)
-		n += 1 + l + sovP2Pd(uint64(l))
+func (m *ConnManagerRequest) GetLowWaterMark() int64 {
+	if m != nil && m.LowWaterMark != nil {
+		return *m.LowWaterMark
 	}
-	if len(m.Addrs) > 0 {
-		for _, b := range m.Addrs {
-			l = len(b)
-			n += 1 + l + sovP2Pd(uint64(l))
-		}
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+	return 0
 }
 
-func (m *ConnManagerRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Type != nil {
-		n += 1 + sovP2Pd(uint64(*m.Type))
-	}
-	if m.Peer != nil {
-		l = len(m.Peer)
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	if m.Tag != nil {
-		l = len(*m.Tag)
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	if m.Weight != nil {
-		n += 1 + sovP2Pd(uint64(*m.Weight))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *ConnManagerRequest) GetHighWaterMark() int64 {
+	if m != nil && m.HighWaterMark != nil {
+		return *m.HighWaterMark
 	}
-	return n
+	return 0
 }
 
-func (m *DisconnectRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Peer != nil {
-		l = len(m.Peer)
-		n += 1 + l + sovP2Pd(uint64(l))
+func (m *ConnManagerRequest) GetGracePeriod() int64 {
+	if m != nil && m.GracePeriod != nil {
+		return *m.GracePeriod
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+	return 0
 }
 
-func (m *PSRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Type != nil {
-		n += 1 + sovP2Pd(uint64(*m.Type))
-	}
-	if m.Topic != nil {
-		l = len(*m.Topic)
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	if m.Data != nil {
-		l = len(m.Data)
-		n += 1 + l + sovP2Pd(uint64(l))
+type DisconnectRequest struct {
+	Peer []byte `protobuf:"bytes,1,req,name=peer" json:"peer,omitempty"`
+	// also removes the addresses of the peer from the peerstore, so it isn't
+	// dialed again until new ones are learned
+	ForgetAddrs          *bool    `protobuf:"varint,2,opt,name=forgetAddrs" json:"forgetAddrs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DisconnectRequest) Reset()         { *m = DisconnectRequest{} }
+func (m *DisconnectRequest) String() string { return proto.CompactTextString(m) }
+func (*DisconnectRequest) ProtoMessage()    {}
+func (*DisconnectRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{53}
+}
+func (m *DisconnectRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DisconnectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DisconnectRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+}
+func (m *DisconnectRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DisconnectRequest.Merge(m, src)
+}
+func (m *DisconnectRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *DisconnectRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DisconnectRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DisconnectRequest proto.InternalMessageInfo
+
+func (m *DisconnectRequest) GetPeer() []byte {
+	if m != nil {
+		return m.Peer
 	}
-	return n
+	return nil
 }
 
-func (m *PSMessage) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *DisconnectRequest) GetForgetAddrs() bool {
+	if m != nil && m.ForgetAddrs != nil {
+		return *m.ForgetAddrs
 	}
-	var l int
-	_ = l
-	if m.From != nil {
-		l = len(m.From)
-		n += 1 + l + sovP2Pd(uint64(l))
+	return false
+}
+
+type DisconnectResponse struct {
+	ClosedConns          *int32   `protobuf:"varint,1,req,name=closedConns" json:"closedConns,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DisconnectResponse) Reset()         { *m = DisconnectResponse{} }
+func (m *DisconnectResponse) String() string { return proto.CompactTextString(m) }
+func (*DisconnectResponse) ProtoMessage()    {}
+func (*DisconnectResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{54}
+}
+func (m *DisconnectResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DisconnectResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DisconnectResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if m.Data != nil {
-		l = len(m.Data)
-		n += 1 + l + sovP2Pd(uint64(l))
+}
+func (m *DisconnectResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DisconnectResponse.Merge(m, src)
+}
+func (m *DisconnectResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *DisconnectResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DisconnectResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DisconnectResponse proto.InternalMessageInfo
+
+func (m *DisconnectResponse) GetClosedConns() int32 {
+	if m != nil && m.ClosedConns != nil {
+		return *m.ClosedConns
 	}
-	if m.Seqno != nil {
-		l = len(m.Seqno)
-		n += 1 + l + sovP2Pd(uint64(l))
+	return 0
+}
+
+type PSRequest struct {
+	Type                 *PSRequest_Type `protobuf:"varint,1,req,name=type,enum=p2pd.pb.PSRequest_Type" json:"type,omitempty"`
+	Topic                *string         `protobuf:"bytes,2,opt,name=topic" json:"topic,omitempty"`
+	Data                 []byte          `protobuf:"bytes,3,opt,name=data" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *PSRequest) Reset()         { *m = PSRequest{} }
+func (m *PSRequest) String() string { return proto.CompactTextString(m) }
+func (*PSRequest) ProtoMessage()    {}
+func (*PSRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{55}
+}
+func (m *PSRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PSRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PSRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if len(m.TopicIDs) > 0 {
-		for _, s := range m.TopicIDs {
-			l = len(s)
-			n += 1 + l + sovP2Pd(uint64(l))
+}
+func (m *PSRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PSRequest.Merge(m, src)
+}
+func (m *PSRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *PSRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PSRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PSRequest proto.InternalMessageInfo
+
+func (m *PSRequest) GetType() PSRequest_Type {
+	if m != nil && m.Type != nil {
+		return *m.Type
+	}
+	return PSRequest_GET_TOPICS
+}
+
+func (m *PSRequest) GetTopic() string {
+	if m != nil && m.Topic != nil {
+		return *m.Topic
+	}
+	return ""
+}
+
+func (m *PSRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type PSMessage struct {
+	From                 []byte   `protobuf:"bytes,1,opt,name=from" json:"from,omitempty"`
+	Data                 []byte   `protobuf:"bytes,2,opt,name=data" json:"data,omitempty"`
+	Seqno                []byte   `protobuf:"bytes,3,opt,name=seqno" json:"seqno,omitempty"`
+	TopicIDs             []string `protobuf:"bytes,4,rep,name=topicIDs" json:"topicIDs,omitempty"`
+	Signature            []byte   `protobuf:"bytes,5,opt,name=signature" json:"signature,omitempty"`
+	Key                  []byte   `protobuf:"bytes,6,opt,name=key" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PSMessage) Reset()         { *m = PSMessage{} }
+func (m *PSMessage) String() string { return proto.CompactTextString(m) }
+func (*PSMessage) ProtoMessage()    {}
+func (*PSMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{56}
+}
+func (m *PSMessage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PSMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PSMessage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if m.Signature != nil {
-		l = len(m.Signature)
-		n += 1 + l + sovP2Pd(uint64(l))
+}
+func (m *PSMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PSMessage.Merge(m, src)
+}
+func (m *PSMessage) XXX_Size() int {
+	return m.Size()
+}
+func (m *PSMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_PSMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PSMessage proto.InternalMessageInfo
+
+func (m *PSMessage) GetFrom() []byte {
+	if m != nil {
+		return m.From
 	}
-	if m.Key != nil {
-		l = len(m.Key)
-		n += 1 + l + sovP2Pd(uint64(l))
+	return nil
+}
+
+func (m *PSMessage) GetData() []byte {
+	if m != nil {
+		return m.Data
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return nil
+}
+
+func (m *PSMessage) GetSeqno() []byte {
+	if m != nil {
+		return m.Seqno
 	}
-	return n
+	return nil
 }
 
-func (m *PSResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *PSMessage) GetTopicIDs() []string {
+	if m != nil {
+		return m.TopicIDs
 	}
-	var l int
-	_ = l
-	if len(m.Topics) > 0 {
-		for _, s := range m.Topics {
-			l = len(s)
-			n += 1 + l + sovP2Pd(uint64(l))
-		}
+	return nil
+}
+
+func (m *PSMessage) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
 	}
-	if len(m.PeerIDs) > 0 {
-		for _, b := range m.PeerIDs {
-			l = len(b)
-			n += 1 + l + sovP2Pd(uint64(l))
+	return nil
+}
+
+func (m *PSMessage) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+// AddTopicValidatorRequest has the client validate the messages received on
+// topic before they are delivered and propagated. The daemon sends each of
+// them as a ValidateMessage, and rejects the message if no ValidationResult is
+// received within timeoutMs.
+type AddTopicValidatorRequest struct {
+	Topic                *string  `protobuf:"bytes,1,req,name=topic" json:"topic,omitempty"`
+	TimeoutMs            *int64   `protobuf:"varint,2,opt,name=timeoutMs" json:"timeoutMs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddTopicValidatorRequest) Reset()         { *m = AddTopicValidatorRequest{} }
+func (m *AddTopicValidatorRequest) String() string { return proto.CompactTextString(m) }
+func (*AddTopicValidatorRequest) ProtoMessage()    {}
+func (*AddTopicValidatorRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{57}
+}
+func (m *AddTopicValidatorRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AddTopicValidatorRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AddTopicValidatorRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AddTopicValidatorRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddTopicValidatorRequest.Merge(m, src)
+}
+func (m *AddTopicValidatorRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *AddTopicValidatorRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddTopicValidatorRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddTopicValidatorRequest proto.InternalMessageInfo
+
+func (m *AddTopicValidatorRequest) GetTopic() string {
+	if m != nil && m.Topic != nil {
+		return *m.Topic
+	}
+	return ""
+}
+
+func (m *AddTopicValidatorRequest) GetTimeoutMs() int64 {
+	if m != nil && m.TimeoutMs != nil {
+		return *m.TimeoutMs
+	}
+	return 0
+}
+
+type ValidateMessage struct {
+	Topic                *string    `protobuf:"bytes,1,req,name=topic" json:"topic,omitempty"`
+	Message              *PSMessage `protobuf:"bytes,2,req,name=message" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ValidateMessage) Reset()         { *m = ValidateMessage{} }
+func (m *ValidateMessage) String() string { return proto.CompactTextString(m) }
+func (*ValidateMessage) ProtoMessage()    {}
+func (*ValidateMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{58}
+}
+func (m *ValidateMessage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ValidateMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ValidateMessage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ValidateMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidateMessage.Merge(m, src)
+}
+func (m *ValidateMessage) XXX_Size() int {
+	return m.Size()
+}
+func (m *ValidateMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidateMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidateMessage proto.InternalMessageInfo
+
+func (m *ValidateMessage) GetTopic() string {
+	if m != nil && m.Topic != nil {
+		return *m.Topic
+	}
+	return ""
+}
+
+func (m *ValidateMessage) GetMessage() *PSMessage {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+type ValidationResult struct {
+	Result               *ValidationResult_Result `protobuf:"varint,1,req,name=result,enum=p2pd.pb.ValidationResult_Result" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *ValidationResult) Reset()         { *m = ValidationResult{} }
+func (m *ValidationResult) String() string { return proto.CompactTextString(m) }
+func (*ValidationResult) ProtoMessage()    {}
+func (*ValidationResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{59}
+}
+func (m *ValidationResult) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ValidationResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ValidationResult.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ValidationResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidationResult.Merge(m, src)
+}
+func (m *ValidationResult) XXX_Size() int {
+	return m.Size()
+}
+func (m *ValidationResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidationResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidationResult proto.InternalMessageInfo
+
+func (m *ValidationResult) GetResult() ValidationResult_Result {
+	if m != nil && m.Result != nil {
+		return *m.Result
+	}
+	return ValidationResult_ACCEPT
+}
+
+type PSResponse struct {
+	Topics               []string `protobuf:"bytes,1,rep,name=topics" json:"topics,omitempty"`
+	PeerIDs              [][]byte `protobuf:"bytes,2,rep,name=peerIDs" json:"peerIDs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PSResponse) Reset()         { *m = PSResponse{} }
+func (m *PSResponse) String() string { return proto.CompactTextString(m) }
+func (*PSResponse) ProtoMessage()    {}
+func (*PSResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{60}
+}
+func (m *PSResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PSResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PSResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PSResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PSResponse.Merge(m, src)
+}
+func (m *PSResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *PSResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PSResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PSResponse proto.InternalMessageInfo
+
+func (m *PSResponse) GetTopics() []string {
+	if m != nil {
+		return m.Topics
+	}
+	return nil
+}
+
+func (m *PSResponse) GetPeerIDs() [][]byte {
+	if m != nil {
+		return m.PeerIDs
+	}
+	return nil
+}
+
+type CallUnaryRequest struct {
+	Peer      []byte  `protobuf:"bytes,1,req,name=peer" json:"peer,omitempty"`
+	Proto     *string `protobuf:"bytes,2,req,name=proto" json:"proto,omitempty"`
+	Data      []byte  `protobuf:"bytes,3,req,name=data" json:"data,omitempty"`
+	TimeoutMs *int64  `protobuf:"varint,4,opt,name=timeoutMs" json:"timeoutMs,omitempty"`
+	// W3C trace context of the span the call is made from, passed on from the
+	// client to the daemons on both ends and to the handling client, so that
+	// they continue the trace
+	TraceParent *string `protobuf:"bytes,5,opt,name=traceParent" json:"traceParent,omitempty"`
+	TraceState  *string `protobuf:"bytes,6,opt,name=traceState" json:"traceState,omitempty"`
+	// data follows in PayloadChunk messages, totaling chunkedSize bytes
+	ChunkedSize          *uint64  `protobuf:"varint,7,opt,name=chunkedSize" json:"chunkedSize,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CallUnaryRequest) Reset()         { *m = CallUnaryRequest{} }
+func (m *CallUnaryRequest) String() string { return proto.CompactTextString(m) }
+func (*CallUnaryRequest) ProtoMessage()    {}
+func (*CallUnaryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{61}
+}
+func (m *CallUnaryRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CallUnaryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CallUnaryRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CallUnaryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CallUnaryRequest.Merge(m, src)
+}
+func (m *CallUnaryRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *CallUnaryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CallUnaryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CallUnaryRequest proto.InternalMessageInfo
+
+func (m *CallUnaryRequest) GetPeer() []byte {
+	if m != nil {
+		return m.Peer
+	}
+	return nil
+}
+
+func (m *CallUnaryRequest) GetProto() string {
+	if m != nil && m.Proto != nil {
+		return *m.Proto
+	}
+	return ""
+}
+
+func (m *CallUnaryRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *CallUnaryRequest) GetTimeoutMs() int64 {
+	if m != nil && m.TimeoutMs != nil {
+		return *m.TimeoutMs
+	}
+	return 0
+}
+
+func (m *CallUnaryRequest) GetTraceParent() string {
+	if m != nil && m.TraceParent != nil {
+		return *m.TraceParent
+	}
+	return ""
+}
+
+func (m *CallUnaryRequest) GetTraceState() string {
+	if m != nil && m.TraceState != nil {
+		return *m.TraceState
+	}
+	return ""
+}
+
+func (m *CallUnaryRequest) GetChunkedSize() uint64 {
+	if m != nil && m.ChunkedSize != nil {
+		return *m.ChunkedSize
+	}
+	return 0
+}
+
+type CallUnaryResponse struct {
+	// Types that are valid to be assigned to Result:
+	//	*CallUnaryResponse_Response
+	//	*CallUnaryResponse_Error
+	Result isCallUnaryResponse_Result `protobuf_oneof:"result"`
+	// the response follows in PayloadChunk messages, totaling chunkedSize bytes
+	ChunkedSize          *uint64  `protobuf:"varint,3,opt,name=chunkedSize" json:"chunkedSize,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CallUnaryResponse) Reset()         { *m = CallUnaryResponse{} }
+func (m *CallUnaryResponse) String() string { return proto.CompactTextString(m) }
+func (*CallUnaryResponse) ProtoMessage()    {}
+func (*CallUnaryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{62}
+}
+func (m *CallUnaryResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CallUnaryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CallUnaryResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CallUnaryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CallUnaryResponse.Merge(m, src)
+}
+func (m *CallUnaryResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *CallUnaryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CallUnaryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CallUnaryResponse proto.InternalMessageInfo
+
+type isCallUnaryResponse_Result interface {
+	isCallUnaryResponse_Result()
+	MarshalTo([]byte) (int, error)
+	Size() int
+}
+
+type CallUnaryResponse_Response struct {
+	Response []byte `protobuf:"bytes,1,opt,name=response,oneof" json:"response,omitempty"`
+}
+type CallUnaryResponse_Error struct {
+	Error []byte `protobuf:"bytes,2,opt,name=error,oneof" json:"error,omitempty"`
+}
+
+func (*CallUnaryResponse_Response) isCallUnaryResponse_Result() {}
+func (*CallUnaryResponse_Error) isCallUnaryResponse_Result()    {}
+
+func (m *CallUnaryResponse) GetResult() isCallUnaryResponse_Result {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (m *CallUnaryResponse) GetResponse() []byte {
+	if x, ok := m.GetResult().(*CallUnaryResponse_Response); ok {
+		return x.Response
+	}
+	return nil
+}
+
+func (m *CallUnaryResponse) GetError() []byte {
+	if x, ok := m.GetResult().(*CallUnaryResponse_Error); ok {
+		return x.Error
+	}
+	return nil
+}
+
+func (m *CallUnaryResponse) GetChunkedSize() uint64 {
+	if m != nil && m.ChunkedSize != nil {
+		return *m.ChunkedSize
+	}
+	return 0
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*CallUnaryResponse) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*CallUnaryResponse_Response)(nil),
+		(*CallUnaryResponse_Error)(nil),
+	}
+}
+
+type AddUnaryHandlerRequest struct {
+	Proto *string `protobuf:"bytes,1,req,name=proto" json:"proto,omitempty"`
+	// zero or unset means no limit
+	MaxConcurrentCalls *int32                                 `protobuf:"varint,2,opt,name=maxConcurrentCalls" json:"maxConcurrentCalls,omitempty"`
+	OverflowPolicy     *AddUnaryHandlerRequest_OverflowPolicy `protobuf:"varint,3,opt,name=overflowPolicy,enum=p2pd.pb.AddUnaryHandlerRequest_OverflowPolicy" json:"overflowPolicy,omitempty"`
+	// takes over the handler if another one is registered for proto, e.g. by a
+	// previous connection of a reconnecting client
+	Replace *bool `protobuf:"varint,4,opt,name=replace" json:"replace,omitempty"`
+	// hand incoming streams to the client as StreamFrame messages rather than
+	// unary calls
+	Raw *bool `protobuf:"varint,5,opt,name=raw" json:"raw,omitempty"`
+	// with raw: the number of data frames of each stream the daemon may send
+	// ahead of those the client acked; unbounded if unset
+	Window               *uint32  `protobuf:"varint,6,opt,name=window" json:"window,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddUnaryHandlerRequest) Reset()         { *m = AddUnaryHandlerRequest{} }
+func (m *AddUnaryHandlerRequest) String() string { return proto.CompactTextString(m) }
+func (*AddUnaryHandlerRequest) ProtoMessage()    {}
+func (*AddUnaryHandlerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{63}
+}
+func (m *AddUnaryHandlerRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AddUnaryHandlerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AddUnaryHandlerRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AddUnaryHandlerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddUnaryHandlerRequest.Merge(m, src)
+}
+func (m *AddUnaryHandlerRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *AddUnaryHandlerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddUnaryHandlerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddUnaryHandlerRequest proto.InternalMessageInfo
+
+func (m *AddUnaryHandlerRequest) GetProto() string {
+	if m != nil && m.Proto != nil {
+		return *m.Proto
+	}
+	return ""
+}
+
+func (m *AddUnaryHandlerRequest) GetMaxConcurrentCalls() int32 {
+	if m != nil && m.MaxConcurrentCalls != nil {
+		return *m.MaxConcurrentCalls
+	}
+	return 0
+}
+
+func (m *AddUnaryHandlerRequest) GetOverflowPolicy() AddUnaryHandlerRequest_OverflowPolicy {
+	if m != nil && m.OverflowPolicy != nil {
+		return *m.OverflowPolicy
+	}
+	return AddUnaryHandlerRequest_REJECT
+}
+
+func (m *AddUnaryHandlerRequest) GetReplace() bool {
+	if m != nil && m.Replace != nil {
+		return *m.Replace
+	}
+	return false
+}
+
+func (m *AddUnaryHandlerRequest) GetRaw() bool {
+	if m != nil && m.Raw != nil {
+		return *m.Raw
+	}
+	return false
+}
+
+func (m *AddUnaryHandlerRequest) GetWindow() uint32 {
+	if m != nil && m.Window != nil {
+		return *m.Window
+	}
+	return 0
+}
+
+// RemoveUnaryHandlerRequest removes a handler added on the same persistent
+// connection; calls in progress are still answered
+type RemoveUnaryHandlerRequest struct {
+	Proto                *string  `protobuf:"bytes,1,req,name=proto" json:"proto,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoveUnaryHandlerRequest) Reset()         { *m = RemoveUnaryHandlerRequest{} }
+func (m *RemoveUnaryHandlerRequest) String() string { return proto.CompactTextString(m) }
+func (*RemoveUnaryHandlerRequest) ProtoMessage()    {}
+func (*RemoveUnaryHandlerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{64}
+}
+func (m *RemoveUnaryHandlerRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RemoveUnaryHandlerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RemoveUnaryHandlerRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RemoveUnaryHandlerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveUnaryHandlerRequest.Merge(m, src)
+}
+func (m *RemoveUnaryHandlerRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *RemoveUnaryHandlerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveUnaryHandlerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveUnaryHandlerRequest proto.InternalMessageInfo
+
+func (m *RemoveUnaryHandlerRequest) GetProto() string {
+	if m != nil && m.Proto != nil {
+		return *m.Proto
+	}
+	return ""
+}
+
+type DaemonError struct {
+	Message              *string           `protobuf:"bytes,1,opt,name=message" json:"message,omitempty"`
+	Code                 *DaemonError_Code `protobuf:"varint,2,opt,name=code,enum=p2pd.pb.DaemonError_Code" json:"code,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *DaemonError) Reset()         { *m = DaemonError{} }
+func (m *DaemonError) String() string { return proto.CompactTextString(m) }
+func (*DaemonError) ProtoMessage()    {}
+func (*DaemonError) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{65}
+}
+func (m *DaemonError) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DaemonError) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DaemonError.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DaemonError) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DaemonError.Merge(m, src)
+}
+func (m *DaemonError) XXX_Size() int {
+	return m.Size()
+}
+func (m *DaemonError) XXX_DiscardUnknown() {
+	xxx_messageInfo_DaemonError.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DaemonError proto.InternalMessageInfo
+
+func (m *DaemonError) GetMessage() string {
+	if m != nil && m.Message != nil {
+		return *m.Message
+	}
+	return ""
+}
+
+func (m *DaemonError) GetCode() DaemonError_Code {
+	if m != nil && m.Code != nil {
+		return *m.Code
+	}
+	return DaemonError_UNKNOWN
+}
+
+type Cancel struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Cancel) Reset()         { *m = Cancel{} }
+func (m *Cancel) String() string { return proto.CompactTextString(m) }
+func (*Cancel) ProtoMessage()    {}
+func (*Cancel) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{66}
+}
+func (m *Cancel) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Cancel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Cancel.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Cancel) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Cancel.Merge(m, src)
+}
+func (m *Cancel) XXX_Size() int {
+	return m.Size()
+}
+func (m *Cancel) XXX_DiscardUnknown() {
+	xxx_messageInfo_Cancel.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Cancel proto.InternalMessageInfo
+
+// CancelAll cancels every call issued on the persistent connection it is
+// sent on, as a Cancel for each of them would
+type CancelAll struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelAll) Reset()         { *m = CancelAll{} }
+func (m *CancelAll) String() string { return proto.CompactTextString(m) }
+func (*CancelAll) ProtoMessage()    {}
+func (*CancelAll) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{67}
+}
+func (m *CancelAll) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CancelAll) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CancelAll.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CancelAll) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelAll.Merge(m, src)
+}
+func (m *CancelAll) XXX_Size() int {
+	return m.Size()
+}
+func (m *CancelAll) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelAll.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelAll proto.InternalMessageInfo
+
+type CancelAllResponse struct {
+	Cancelled            *int32   `protobuf:"varint,1,req,name=cancelled" json:"cancelled,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelAllResponse) Reset()         { *m = CancelAllResponse{} }
+func (m *CancelAllResponse) String() string { return proto.CompactTextString(m) }
+func (*CancelAllResponse) ProtoMessage()    {}
+func (*CancelAllResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{68}
+}
+func (m *CancelAllResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CancelAllResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CancelAllResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CancelAllResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelAllResponse.Merge(m, src)
+}
+func (m *CancelAllResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *CancelAllResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelAllResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelAllResponse proto.InternalMessageInfo
+
+func (m *CancelAllResponse) GetCancelled() int32 {
+	if m != nil && m.Cancelled != nil {
+		return *m.Cancelled
+	}
+	return 0
+}
+
+// EndOfStream is sent after the last callUnaryResponse of a callStream,
+// once the remote peer has closed the stream.
+type EndOfStream struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EndOfStream) Reset()         { *m = EndOfStream{} }
+func (m *EndOfStream) String() string { return proto.CompactTextString(m) }
+func (*EndOfStream) ProtoMessage()    {}
+func (*EndOfStream) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{69}
+}
+func (m *EndOfStream) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *EndOfStream) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_EndOfStream.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *EndOfStream) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EndOfStream.Merge(m, src)
+}
+func (m *EndOfStream) XXX_Size() int {
+	return m.Size()
+}
+func (m *EndOfStream) XXX_DiscardUnknown() {
+	xxx_messageInfo_EndOfStream.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EndOfStream proto.InternalMessageInfo
+
+// Ping is sent by the daemon on idle persistent connections when keepalive is
+// enabled; clients reply with a Pong carrying the same callId.
+type Ping struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Ping) Reset()         { *m = Ping{} }
+func (m *Ping) String() string { return proto.CompactTextString(m) }
+func (*Ping) ProtoMessage()    {}
+func (*Ping) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{70}
+}
+func (m *Ping) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Ping) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Ping.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Ping) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Ping.Merge(m, src)
+}
+func (m *Ping) XXX_Size() int {
+	return m.Size()
+}
+func (m *Ping) XXX_DiscardUnknown() {
+	xxx_messageInfo_Ping.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Ping proto.InternalMessageInfo
+
+type Pong struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Pong) Reset()         { *m = Pong{} }
+func (m *Pong) String() string { return proto.CompactTextString(m) }
+func (*Pong) ProtoMessage()    {}
+func (*Pong) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{71}
+}
+func (m *Pong) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Pong) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Pong.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Pong) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Pong.Merge(m, src)
+}
+func (m *Pong) XXX_Size() int {
+	return m.Size()
+}
+func (m *Pong) XXX_DiscardUnknown() {
+	xxx_messageInfo_Pong.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Pong proto.InternalMessageInfo
+
+type GetStatsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStatsRequest) Reset()         { *m = GetStatsRequest{} }
+func (m *GetStatsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetStatsRequest) ProtoMessage()    {}
+func (*GetStatsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{72}
+}
+func (m *GetStatsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetStatsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetStatsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetStatsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStatsRequest.Merge(m, src)
+}
+func (m *GetStatsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetStatsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStatsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStatsRequest proto.InternalMessageInfo
+
+type UnaryHandlerInfo struct {
+	Proto                *string  `protobuf:"bytes,1,req,name=proto" json:"proto,omitempty"`
+	Enabled              *bool    `protobuf:"varint,2,req,name=enabled" json:"enabled,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnaryHandlerInfo) Reset()         { *m = UnaryHandlerInfo{} }
+func (m *UnaryHandlerInfo) String() string { return proto.CompactTextString(m) }
+func (*UnaryHandlerInfo) ProtoMessage()    {}
+func (*UnaryHandlerInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{73}
+}
+func (m *UnaryHandlerInfo) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *UnaryHandlerInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_UnaryHandlerInfo.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *UnaryHandlerInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnaryHandlerInfo.Merge(m, src)
+}
+func (m *UnaryHandlerInfo) XXX_Size() int {
+	return m.Size()
+}
+func (m *UnaryHandlerInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnaryHandlerInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnaryHandlerInfo proto.InternalMessageInfo
+
+func (m *UnaryHandlerInfo) GetProto() string {
+	if m != nil && m.Proto != nil {
+		return *m.Proto
+	}
+	return ""
+}
+
+func (m *UnaryHandlerInfo) GetEnabled() bool {
+	if m != nil && m.Enabled != nil {
+		return *m.Enabled
+	}
+	return false
+}
+
+type StatsResponse struct {
+	UnaryHandlers []*UnaryHandlerInfo `protobuf:"bytes,1,rep,name=unaryHandlers" json:"unaryHandlers,omitempty"`
+	// number of outgoing calls that are still in progress
+	ActiveCalls *int64 `protobuf:"varint,2,req,name=activeCalls" json:"activeCalls,omitempty"`
+	// number of incoming calls awaiting a response from a client
+	PendingResponses     *int64   `protobuf:"varint,3,req,name=pendingResponses" json:"pendingResponses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StatsResponse) Reset()         { *m = StatsResponse{} }
+func (m *StatsResponse) String() string { return proto.CompactTextString(m) }
+func (*StatsResponse) ProtoMessage()    {}
+func (*StatsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{74}
+}
+func (m *StatsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StatsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StatsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StatsResponse.Merge(m, src)
+}
+func (m *StatsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *StatsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StatsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StatsResponse proto.InternalMessageInfo
+
+func (m *StatsResponse) GetUnaryHandlers() []*UnaryHandlerInfo {
+	if m != nil {
+		return m.UnaryHandlers
+	}
+	return nil
+}
+
+func (m *StatsResponse) GetActiveCalls() int64 {
+	if m != nil && m.ActiveCalls != nil {
+		return *m.ActiveCalls
+	}
+	return 0
+}
+
+func (m *StatsResponse) GetPendingResponses() int64 {
+	if m != nil && m.PendingResponses != nil {
+		return *m.PendingResponses
+	}
+	return 0
+}
+
+// SubscribeEventsRequest streams the selected host events as event responses
+// until the call is cancelled or the persistent connection is closed.
+type SubscribeEventsRequest struct {
+	Types                []Event_Type `protobuf:"varint,1,rep,name=types,enum=p2pd.pb.Event_Type" json:"types,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *SubscribeEventsRequest) Reset()         { *m = SubscribeEventsRequest{} }
+func (m *SubscribeEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeEventsRequest) ProtoMessage()    {}
+func (*SubscribeEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{75}
+}
+func (m *SubscribeEventsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SubscribeEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SubscribeEventsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SubscribeEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeEventsRequest.Merge(m, src)
+}
+func (m *SubscribeEventsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SubscribeEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeEventsRequest proto.InternalMessageInfo
+
+func (m *SubscribeEventsRequest) GetTypes() []Event_Type {
+	if m != nil {
+		return m.Types
+	}
+	return nil
+}
+
+type Event struct {
+	Type *Event_Type `protobuf:"varint,1,req,name=type,enum=p2pd.pb.Event_Type" json:"type,omitempty"`
+	// set for LOCAL_REACHABILITY_CHANGED
+	Reachability *Event_Reachability `protobuf:"varint,2,opt,name=reachability,enum=p2pd.pb.Event_Reachability" json:"reachability,omitempty"`
+	// set for PEER_CONNECTEDNESS_CHANGED
+	Peer          []byte               `protobuf:"bytes,3,opt,name=peer" json:"peer,omitempty"`
+	Connectedness *Event_Connectedness `protobuf:"varint,4,opt,name=connectedness,enum=p2pd.pb.Event_Connectedness" json:"connectedness,omitempty"`
+	// set for LOCAL_ADDRESSES_UPDATED; the current listen addresses
+	Addrs [][]byte `protobuf:"bytes,5,rep,name=addrs" json:"addrs,omitempty"`
+	// set for PEER_CONNECTEDNESS_CHANGED; the remote address of the first
+	// connection opened to the peer, or of the last one closed
+	Addr                 []byte   `protobuf:"bytes,6,opt,name=addr" json:"addr,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Event) Reset()         { *m = Event{} }
+func (m *Event) String() string { return proto.CompactTextString(m) }
+func (*Event) ProtoMessage()    {}
+func (*Event) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{76}
+}
+func (m *Event) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Event) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Event.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Event) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Event.Merge(m, src)
+}
+func (m *Event) XXX_Size() int {
+	return m.Size()
+}
+func (m *Event) XXX_DiscardUnknown() {
+	xxx_messageInfo_Event.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Event proto.InternalMessageInfo
+
+func (m *Event) GetType() Event_Type {
+	if m != nil && m.Type != nil {
+		return *m.Type
+	}
+	return Event_LOCAL_REACHABILITY_CHANGED
+}
+
+func (m *Event) GetReachability() Event_Reachability {
+	if m != nil && m.Reachability != nil {
+		return *m.Reachability
+	}
+	return Event_UNKNOWN
+}
+
+func (m *Event) GetPeer() []byte {
+	if m != nil {
+		return m.Peer
+	}
+	return nil
+}
+
+func (m *Event) GetConnectedness() Event_Connectedness {
+	if m != nil && m.Connectedness != nil {
+		return *m.Connectedness
+	}
+	return Event_NOT_CONNECTED
+}
+
+func (m *Event) GetAddrs() [][]byte {
+	if m != nil {
+		return m.Addrs
+	}
+	return nil
+}
+
+func (m *Event) GetAddr() []byte {
+	if m != nil {
+		return m.Addr
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("p2pd.pb.Request_Type", Request_Type_name, Request_Type_value)
+	proto.RegisterEnum("p2pd.pb.Response_Type", Response_Type_name, Response_Type_value)
+	proto.RegisterEnum("p2pd.pb.PeerstoreRequest_Type", PeerstoreRequest_Type_name, PeerstoreRequest_Type_value)
+	proto.RegisterEnum("p2pd.pb.PeerListsRequest_Type", PeerListsRequest_Type_name, PeerListsRequest_Type_value)
+	proto.RegisterEnum("p2pd.pb.DialAttempt_Reason", DialAttempt_Reason_name, DialAttempt_Reason_value)
+	proto.RegisterEnum("p2pd.pb.DHTRequest_Type", DHTRequest_Type_name, DHTRequest_Type_value)
+	proto.RegisterEnum("p2pd.pb.DHTResponse_Type", DHTResponse_Type_name, DHTResponse_Type_value)
+	proto.RegisterEnum("p2pd.pb.ConnectionInfo_Direction", ConnectionInfo_Direction_name, ConnectionInfo_Direction_value)
+	proto.RegisterEnum("p2pd.pb.ConnManagerRequest_Type", ConnManagerRequest_Type_name, ConnManagerRequest_Type_value)
+	proto.RegisterEnum("p2pd.pb.PSRequest_Type", PSRequest_Type_name, PSRequest_Type_value)
+	proto.RegisterEnum("p2pd.pb.ValidationResult_Result", ValidationResult_Result_name, ValidationResult_Result_value)
+	proto.RegisterEnum("p2pd.pb.AddUnaryHandlerRequest_OverflowPolicy", AddUnaryHandlerRequest_OverflowPolicy_name, AddUnaryHandlerRequest_OverflowPolicy_value)
+	proto.RegisterEnum("p2pd.pb.DaemonError_Code", DaemonError_Code_name, DaemonError_Code_value)
+	proto.RegisterEnum("p2pd.pb.Event_Type", Event_Type_name, Event_Type_value)
+	proto.RegisterEnum("p2pd.pb.Event_Reachability", Event_Reachability_name, Event_Reachability_value)
+	proto.RegisterEnum("p2pd.pb.Event_Connectedness", Event_Connectedness_name, Event_Connectedness_value)
+	proto.RegisterType((*Request)(nil), "p2pd.pb.Request")
+	proto.RegisterType((*Response)(nil), "p2pd.pb.Response")
+	proto.RegisterType((*PersistentConnectionRequest)(nil), "p2pd.pb.PersistentConnectionRequest")
+	proto.RegisterType((*PersistentConnectionResponse)(nil), "p2pd.pb.PersistentConnectionResponse")
+	proto.RegisterType((*IdentifyResponse)(nil), "p2pd.pb.IdentifyResponse")
+	proto.RegisterType((*RotateIdentityRequest)(nil), "p2pd.pb.RotateIdentityRequest")
+	proto.RegisterType((*DaemonInfoResponse)(nil), "p2pd.pb.DaemonInfoResponse")
+	proto.RegisterType((*AutoNATRequest)(nil), "p2pd.pb.AutoNATRequest")
+	proto.RegisterType((*AutoNATResponse)(nil), "p2pd.pb.AutoNATResponse")
+	proto.RegisterType((*AutoNATProbe)(nil), "p2pd.pb.AutoNATProbe")
+	proto.RegisterType((*CallRecord)(nil), "p2pd.pb.CallRecord")
+	proto.RegisterType((*ConnectRequest)(nil), "p2pd.pb.ConnectRequest")
+	proto.RegisterType((*ConnectResponse)(nil), "p2pd.pb.ConnectResponse")
+	proto.RegisterType((*PingRequest)(nil), "p2pd.pb.PingRequest")
+	proto.RegisterType((*PeerMetadataRequest)(nil), "p2pd.pb.PeerMetadataRequest")
+	proto.RegisterType((*Metadata)(nil), "p2pd.pb.Metadata")
+	proto.RegisterType((*MetadataEntry)(nil), "p2pd.pb.MetadataEntry")
+	proto.RegisterType((*LogLevelRequest)(nil), "p2pd.pb.LogLevelRequest")
+	proto.RegisterType((*SubsystemLogLevel)(nil), "p2pd.pb.SubsystemLogLevel")
+	proto.RegisterType((*FindPeerRequest)(nil), "p2pd.pb.FindPeerRequest")
+	proto.RegisterType((*RoutingTableRequest)(nil), "p2pd.pb.RoutingTableRequest")
+	proto.RegisterType((*RoutingTableBucket)(nil), "p2pd.pb.RoutingTableBucket")
+	proto.RegisterType((*RoutingTableResponse)(nil), "p2pd.pb.RoutingTableResponse")
+	proto.RegisterType((*SetBootstrapPeersRequest)(nil), "p2pd.pb.SetBootstrapPeersRequest")
+	proto.RegisterType((*PeerstoreRequest)(nil), "p2pd.pb.PeerstoreRequest")
+	proto.RegisterType((*PeerstoreResponse)(nil), "p2pd.pb.PeerstoreResponse")
+	proto.RegisterType((*PeerListsRequest)(nil), "p2pd.pb.PeerListsRequest")
+	proto.RegisterType((*PeerListsResponse)(nil), "p2pd.pb.PeerListsResponse")
+	proto.RegisterType((*ResourceScope)(nil), "p2pd.pb.ResourceScope")
+	proto.RegisterType((*ProtocolResourceScope)(nil), "p2pd.pb.ProtocolResourceScope")
+	proto.RegisterType((*PeerResourceScope)(nil), "p2pd.pb.PeerResourceScope")
+	proto.RegisterType((*ResourceUsageResponse)(nil), "p2pd.pb.ResourceUsageResponse")
+	proto.RegisterType((*BandwidthRequest)(nil), "p2pd.pb.BandwidthRequest")
+	proto.RegisterType((*BandwidthStats)(nil), "p2pd.pb.BandwidthStats")
+	proto.RegisterType((*PeerBandwidth)(nil), "p2pd.pb.PeerBandwidth")
+	proto.RegisterType((*ProtocolBandwidth)(nil), "p2pd.pb.ProtocolBandwidth")
+	proto.RegisterType((*BandwidthResponse)(nil), "p2pd.pb.BandwidthResponse")
+	proto.RegisterType((*AddrsResponse)(nil), "p2pd.pb.AddrsResponse")
+	proto.RegisterType((*RelayInfo)(nil), "p2pd.pb.RelayInfo")
+	proto.RegisterType((*PingResponse)(nil), "p2pd.pb.PingResponse")
+	proto.RegisterType((*StreamOpenRequest)(nil), "p2pd.pb.StreamOpenRequest")
+	proto.RegisterType((*StreamFrame)(nil), "p2pd.pb.StreamFrame")
+	proto.RegisterType((*PayloadChunk)(nil), "p2pd.pb.PayloadChunk")
+	proto.RegisterType((*StreamHandlerRequest)(nil), "p2pd.pb.StreamHandlerRequest")
+	proto.RegisterType((*ErrorResponse)(nil), "p2pd.pb.ErrorResponse")
+	proto.RegisterType((*DialAttempt)(nil), "p2pd.pb.DialAttempt")
+	proto.RegisterType((*StreamInfo)(nil), "p2pd.pb.StreamInfo")
+	proto.RegisterType((*DHTRequest)(nil), "p2pd.pb.DHTRequest")
+	proto.RegisterType((*DHTResponse)(nil), "p2pd.pb.DHTResponse")
+	proto.RegisterType((*PeerInfo)(nil), "p2pd.pb.PeerInfo")
+	proto.RegisterType((*ConnectedPeer)(nil), "p2pd.pb.ConnectedPeer")
+	proto.RegisterType((*ConnectionInfo)(nil), "p2pd.pb.ConnectionInfo")
+	proto.RegisterType((*ConnManagerRequest)(nil), "p2pd.pb.ConnManagerRequest")
+	proto.RegisterType((*DisconnectRequest)(nil), "p2pd.pb.DisconnectRequest")
+	proto.RegisterType((*DisconnectResponse)(nil), "p2pd.pb.DisconnectResponse")
+	proto.RegisterType((*PSRequest)(nil), "p2pd.pb.PSRequest")
+	proto.RegisterType((*PSMessage)(nil), "p2pd.pb.PSMessage")
+	proto.RegisterType((*AddTopicValidatorRequest)(nil), "p2pd.pb.AddTopicValidatorRequest")
+	proto.RegisterType((*ValidateMessage)(nil), "p2pd.pb.ValidateMessage")
+	proto.RegisterType((*ValidationResult)(nil), "p2pd.pb.ValidationResult")
+	proto.RegisterType((*PSResponse)(nil), "p2pd.pb.PSResponse")
+	proto.RegisterType((*CallUnaryRequest)(nil), "p2pd.pb.CallUnaryRequest")
+	proto.RegisterType((*CallUnaryResponse)(nil), "p2pd.pb.CallUnaryResponse")
+	proto.RegisterType((*AddUnaryHandlerRequest)(nil), "p2pd.pb.AddUnaryHandlerRequest")
+	proto.RegisterType((*RemoveUnaryHandlerRequest)(nil), "p2pd.pb.RemoveUnaryHandlerRequest")
+	proto.RegisterType((*DaemonError)(nil), "p2pd.pb.DaemonError")
+	proto.RegisterType((*Cancel)(nil), "p2pd.pb.Cancel")
+	proto.RegisterType((*CancelAll)(nil), "p2pd.pb.CancelAll")
+	proto.RegisterType((*CancelAllResponse)(nil), "p2pd.pb.CancelAllResponse")
+	proto.RegisterType((*EndOfStream)(nil), "p2pd.pb.EndOfStream")
+	proto.RegisterType((*Ping)(nil), "p2pd.pb.Ping")
+	proto.RegisterType((*Pong)(nil), "p2pd.pb.Pong")
+	proto.RegisterType((*GetStatsRequest)(nil), "p2pd.pb.GetStatsRequest")
+	proto.RegisterType((*UnaryHandlerInfo)(nil), "p2pd.pb.UnaryHandlerInfo")
+	proto.RegisterType((*StatsResponse)(nil), "p2pd.pb.StatsResponse")
+	proto.RegisterType((*SubscribeEventsRequest)(nil), "p2pd.pb.SubscribeEventsRequest")
+	proto.RegisterType((*Event)(nil), "p2pd.pb.Event")
+}
+
+func init() { proto.RegisterFile("p2pd.proto", fileDescriptor_7333f0e9b622f7df) }
+
+var fileDescriptor_7333f0e9b622f7df = []byte{
+	// 4824 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x7b, 0xcd, 0x8f, 0x1b, 0x47,
+	0x76, 0xf8, 0xf0, 0x73, 0xc8, 0xc7, 0x8f, 0xe9, 0x29, 0x7d, 0xb5, 0x64, 0xfd, 0xf4, 0x9b, 0xed,
+	0xc4, 0xbb, 0xb2, 0x2d, 0x0f, 0x6c, 0xd9, 0xde, 0xf5, 0x3a, 0xbb, 0xb6, 0x7b, 0xc8, 0xd6, 0x90,
+	0x2b, 0x0e, 0x9b, 0x5b, 0x6c, 0x8e, 0x56, 0x6b, 0x20, 0x44, 0x0f, 0xd9, 0x1a, 0x11, 0xe2, 0x74,
+	0xd3, 0xdd, 0x4d, 0xc9, 0xb3, 0x97, 0x5c, 0x83, 0xdc, 0x73, 0xc9, 0x21, 0x08, 0x10, 0x20, 0x08,
+	0x92, 0x60, 0xaf, 0xf9, 0x17, 0x72, 0x34, 0x02, 0x24, 0x97, 0x1c, 0x92, 0x18, 0x41, 0xce, 0xf9,
+	0x13, 0x82, 0x57, 0x1f, 0xdd, 0xd5, 0x4d, 0x8e, 0x2d, 0x21, 0x27, 0xf6, 0x7b, 0xf5, 0x5e, 0x7d,
+	0xbc, 0x7a, 0x5f, 0xf5, 0xaa, 0x08, 0xb0, 0x7a, 0xb8, 0x9a, 0x1f, 0xae, 0xc2, 0x20, 0x0e, 0xc8,
+	0x2e, 0xff, 0x3e, 0x33, 0x7e, 0xdf, 0x84, 0x5d, 0xea, 0x7d, 0xbd, 0xf6, 0xa2, 0x98, 0xbc, 0x03,
+	0xe5, 0xf8, 0x72, 0xe5, 0xe9, 0x85, 0x83, 0xe2, 0xfd, 0xf6, 0xc3, 0x1b, 0x87, 0x82, 0xe6, 0x50,
+	0xb4, 0x1f, 0x3a, 0x97, 0x2b, 0x8f, 0x32, 0x12, 0xf2, 0x21, 0xec, 0xce, 0x02, 0xdf, 0xf7, 0x66,
+	0xb1, 0x5e, 0x3c, 0x28, 0xdc, 0x6f, 0x3c, 0xbc, 0x95, 0x50, 0x77, 0x38, 0x5e, 0x30, 0x51, 0x49,
+	0x47, 0x3e, 0x03, 0x88, 0xe2, 0xd0, 0x73, 0x2f, 0xec, 0x95, 0xe7, 0xeb, 0x25, 0xc6, 0x75, 0x27,
+	0xe1, 0x1a, 0x27, 0x4d, 0x92, 0x51, 0xa1, 0x26, 0x1d, 0x68, 0x71, 0xa8, 0xe7, 0xfa, 0xf3, 0xa5,
+	0x17, 0xea, 0x65, 0xc6, 0xfe, 0xff, 0x72, 0xec, 0xa2, 0x55, 0xf6, 0x90, 0xe5, 0x21, 0x6f, 0x43,
+	0x69, 0xfe, 0x3c, 0xd6, 0x2b, 0x8c, 0xf5, 0x5a, 0xc2, 0xda, 0xed, 0x39, 0x92, 0x01, 0xdb, 0xc9,
+	0x2f, 0xa1, 0x81, 0x53, 0x3e, 0x71, 0x7d, 0xf7, 0xdc, 0x0b, 0xf5, 0x2a, 0x23, 0x7f, 0x2b, 0xb3,
+	0x3c, 0xd1, 0x26, 0xd9, 0x54, 0x7a, 0x5c, 0xe6, 0x7c, 0x11, 0x49, 0xe1, 0xec, 0xe6, 0x96, 0xd9,
+	0x4d, 0x9a, 0x92, 0x65, 0xa6, 0xd4, 0xe4, 0x5d, 0xa8, 0xae, 0xd6, 0x67, 0xd1, 0xfa, 0x4c, 0xaf,
+	0x31, 0x3e, 0x92, 0xf0, 0x8d, 0xc6, 0x92, 0x5e, 0x50, 0x90, 0xfb, 0x50, 0x5e, 0x2d, 0xfc, 0x73,
+	0xbd, 0xce, 0x28, 0xaf, 0xa7, 0x94, 0x0b, 0xff, 0x5c, 0xd2, 0x32, 0x0a, 0x62, 0xc3, 0x7e, 0xe4,
+	0xc5, 0x47, 0x41, 0x10, 0x47, 0x71, 0xe8, 0xae, 0x46, 0x9e, 0x17, 0x46, 0x3a, 0x30, 0xb6, 0x1f,
+	0xa5, 0x02, 0xcc, 0x53, 0xc8, 0x3e, 0x36, 0x79, 0xc9, 0xcf, 0xa0, 0xbe, 0xf2, 0xbc, 0x70, 0xb0,
+	0x88, 0xe2, 0x48, 0x6f, 0xb0, 0x8e, 0x6e, 0xa7, 0xe3, 0xcb, 0x16, 0xd9, 0x41, 0x4a, 0x8b, 0x8c,
+	0x67, 0xae, 0x3f, 0x7f, 0xb5, 0x98, 0xc7, 0xcf, 0xf5, 0x66, 0x8e, 0xf1, 0x48, 0xb6, 0x24, 0x8c,
+	0x09, 0x2d, 0xf9, 0x18, 0x6a, 0xcf, 0x16, 0xfe, 0x1c, 0xfb, 0xd6, 0x5b, 0x8c, 0x4f, 0x4f, 0xf8,
+	0x1e, 0x89, 0x06, 0xc9, 0x96, 0x50, 0x92, 0x2f, 0xa1, 0x19, 0x06, 0xeb, 0x78, 0xe1, 0x9f, 0x3b,
+	0xee, 0xd9, 0xd2, 0xd3, 0xdb, 0x8c, 0xf3, 0x6e, 0xaa, 0xd7, 0x4a, 0xa3, 0xe4, 0xce, 0x70, 0x90,
+	0x47, 0xd0, 0x0e, 0x83, 0xd8, 0x8d, 0xbd, 0xfe, 0xdc, 0xf3, 0xe3, 0x45, 0x7c, 0xa9, 0xef, 0xb1,
+	0x3e, 0xee, 0x29, 0x7d, 0xa8, 0xcd, 0xb2, 0x97, 0x1c, 0x17, 0x9a, 0x8b, 0xbb, 0x8e, 0x83, 0xa1,
+	0xe9, 0xe8, 0x5a, 0xce, 0x5c, 0x4c, 0x8e, 0x4f, 0xcc, 0x45, 0xd0, 0x49, 0x21, 0x47, 0x71, 0x10,
+	0x7a, 0xfa, 0xfe, 0x16, 0x21, 0xb3, 0x96, 0x8c, 0x90, 0x19, 0x06, 0x57, 0x8d, 0xc0, 0x89, 0x17,
+	0xbb, 0x73, 0x37, 0x76, 0x75, 0x92, 0x5b, 0xf5, 0x48, 0x69, 0x4c, 0x56, 0xad, 0x72, 0xa0, 0xb4,
+	0x97, 0xc1, 0xf9, 0xc0, 0x7b, 0xe9, 0x2d, 0xf5, 0x6b, 0x39, 0x69, 0x0f, 0x44, 0x43, 0x22, 0x6d,
+	0x49, 0x69, 0xfc, 0x57, 0x09, 0xca, 0xe8, 0x21, 0x48, 0x13, 0x6a, 0xfd, 0xae, 0x35, 0x74, 0xfa,
+	0x8f, 0x9e, 0x6a, 0x3b, 0xa4, 0x01, 0xbb, 0x1d, 0x7b, 0x38, 0xb4, 0x3a, 0x8e, 0x56, 0x20, 0x7b,
+	0xd0, 0x18, 0x3b, 0xd4, 0x32, 0x4f, 0xa6, 0xf6, 0xc8, 0x1a, 0x6a, 0x45, 0x42, 0xa0, 0x2d, 0x10,
+	0x3d, 0x73, 0xd8, 0x1d, 0x58, 0x54, 0x2b, 0x91, 0x5d, 0x28, 0x75, 0x7b, 0x8e, 0x56, 0x26, 0x6d,
+	0x80, 0x41, 0x7f, 0xec, 0x4c, 0x47, 0x96, 0x45, 0xc7, 0x5a, 0x05, 0xb9, 0xb1, 0xab, 0x13, 0x73,
+	0x68, 0x1e, 0x5b, 0x54, 0xab, 0x22, 0x41, 0xb7, 0x3f, 0x96, 0xdd, 0xef, 0x12, 0x80, 0xea, 0x68,
+	0x72, 0x34, 0x9e, 0x1c, 0x69, 0x35, 0xf2, 0x16, 0xdc, 0x1a, 0x59, 0x74, 0xdc, 0x1f, 0x3b, 0xd6,
+	0xd0, 0x99, 0x22, 0xcd, 0x74, 0x32, 0x3a, 0xa6, 0x66, 0xd7, 0xd2, 0xea, 0xe4, 0x3a, 0x68, 0xac,
+	0x67, 0xc1, 0xda, 0xb7, 0x87, 0x63, 0x0d, 0x48, 0x0d, 0xca, 0xa3, 0xfe, 0xf0, 0x58, 0x6b, 0x90,
+	0x5b, 0x70, 0x6d, 0x6c, 0x39, 0xd3, 0x23, 0xdb, 0x76, 0xc6, 0x0e, 0x35, 0x47, 0x62, 0x0a, 0x4d,
+	0x1c, 0x11, 0x3f, 0xa7, 0xc8, 0x3d, 0xd6, 0x5a, 0x38, 0x7f, 0x6a, 0x8d, 0xed, 0x09, 0xed, 0x58,
+	0xd3, 0xc9, 0xd8, 0x3c, 0xb6, 0xb4, 0x36, 0x4e, 0x93, 0x75, 0x4e, 0xad, 0x81, 0xf9, 0x74, 0xac,
+	0xed, 0x91, 0x16, 0xd4, 0x8f, 0xcc, 0x61, 0xf7, 0x49, 0xbf, 0xeb, 0xf4, 0x34, 0x0d, 0xc1, 0x47,
+	0xfd, 0x61, 0x97, 0xf5, 0xa9, 0xed, 0x93, 0x7d, 0x68, 0x51, 0x7b, 0xe2, 0xf4, 0x87, 0xc7, 0x53,
+	0xc7, 0x3c, 0x1a, 0x58, 0x1a, 0x21, 0xd7, 0x60, 0x8f, 0xda, 0x8e, 0xe9, 0x58, 0x53, 0x2e, 0x48,
+	0xe7, 0xa9, 0x76, 0x0d, 0xbb, 0xed, 0x9a, 0xd6, 0x89, 0x3d, 0x9c, 0xf6, 0x87, 0x8f, 0x6c, 0xed,
+	0x3a, 0x4a, 0xd6, 0x9c, 0x38, 0xf6, 0xd0, 0x74, 0xb4, 0x1b, 0x44, 0x83, 0x66, 0xc7, 0x1c, 0x0c,
+	0xa6, 0xbd, 0xfe, 0xd8, 0xb1, 0xe9, 0x53, 0xed, 0x66, 0x22, 0x3d, 0xb3, 0xdb, 0xa5, 0x63, 0xed,
+	0x16, 0x0e, 0xcb, 0x56, 0xe1, 0xd8, 0xd4, 0xd2, 0x74, 0x1c, 0x96, 0xad, 0xe4, 0xc4, 0x72, 0xcc,
+	0xae, 0xe9, 0x98, 0xda, 0x6d, 0xa4, 0x18, 0xd8, 0xc7, 0xd3, 0x81, 0x75, 0x6a, 0x0d, 0xb4, 0x3b,
+	0x28, 0x24, 0x6a, 0x0d, 0xbb, 0xd6, 0x6f, 0x4f, 0xed, 0xc9, 0x58, 0x48, 0xe0, 0x2d, 0xe3, 0xf7,
+	0x00, 0x35, 0xea, 0x45, 0xab, 0xc0, 0x8f, 0x3c, 0xf2, 0x6e, 0x26, 0x62, 0xdc, 0x54, 0x22, 0x06,
+	0x27, 0x50, 0x43, 0xc6, 0x03, 0xa8, 0x78, 0x61, 0x18, 0x84, 0x22, 0x60, 0xa4, 0xc4, 0x16, 0x62,
+	0x25, 0x07, 0xe5, 0x44, 0xe4, 0x23, 0x19, 0x2d, 0xfa, 0xfe, 0xb3, 0x40, 0x2f, 0xe5, 0x7c, 0xf6,
+	0x38, 0x69, 0xa2, 0x0a, 0x19, 0xf9, 0x04, 0x6a, 0x0b, 0x66, 0x72, 0xcf, 0x2e, 0xf5, 0x72, 0xce,
+	0x64, 0xfa, 0xa2, 0x21, 0x19, 0x28, 0x21, 0x25, 0x3f, 0x56, 0x03, 0xc3, 0xf5, 0x6c, 0x60, 0x10,
+	0xc4, 0x48, 0x40, 0x7e, 0x02, 0x15, 0x66, 0x66, 0x7a, 0xf5, 0xa0, 0x74, 0xbf, 0xf1, 0x70, 0x3f,
+	0x63, 0x52, 0x6c, 0x32, 0xbc, 0x9d, 0xbc, 0x97, 0xf8, 0xf1, 0xdd, 0xdc, 0xc4, 0x47, 0xe3, 0xa4,
+	0x4b, 0x41, 0x42, 0x3e, 0x87, 0xb6, 0xf0, 0xff, 0xde, 0x9c, 0xfb, 0xe6, 0xda, 0x41, 0x29, 0x23,
+	0xa0, 0x8e, 0xda, 0x4c, 0x73, 0xd4, 0x18, 0xb5, 0x95, 0x40, 0x70, 0x23, 0x17, 0x08, 0xc4, 0x60,
+	0x8c, 0x84, 0x7c, 0xaa, 0x3a, 0x6e, 0xc8, 0x85, 0x26, 0xc5, 0x71, 0x0b, 0xa6, 0x94, 0x98, 0x74,
+	0xa1, 0x15, 0x7a, 0x51, 0xb0, 0x0e, 0x67, 0xde, 0x24, 0x72, 0xcf, 0x3d, 0xbd, 0x91, 0xf7, 0x83,
+	0x6a, 0x6b, 0xd2, 0x43, 0x96, 0x09, 0xe3, 0x5b, 0xe8, 0x2d, 0xdd, 0xcb, 0x48, 0x6f, 0x1e, 0x94,
+	0x32, 0xf1, 0x8d, 0x22, 0x9a, 0x89, 0x50, 0x50, 0x90, 0x87, 0x69, 0x86, 0x91, 0xf7, 0xf8, 0x49,
+	0x86, 0x21, 0x46, 0x91, 0x84, 0xb8, 0xbe, 0x34, 0xbe, 0xb4, 0x73, 0xeb, 0x53, 0xe2, 0x8b, 0x5c,
+	0x5f, 0x42, 0x4c, 0xde, 0x86, 0x32, 0x2e, 0x56, 0xb8, 0xf7, 0x2d, 0x3b, 0xcb, 0x9a, 0xc9, 0x3d,
+	0x80, 0x85, 0x1f, 0xc5, 0xae, 0x3f, 0xf3, 0xfa, 0x73, 0xe6, 0xca, 0x9b, 0x54, 0xc1, 0x10, 0x33,
+	0x17, 0x71, 0xf6, 0x73, 0x69, 0x4a, 0x36, 0xe2, 0x88, 0x69, 0x64, 0x58, 0xc8, 0x1f, 0x65, 0xf2,
+	0x07, 0x92, 0xcb, 0x3e, 0xd4, 0xfc, 0x41, 0xb0, 0x2b, 0xe4, 0x8c, 0xd9, 0xf5, 0x2e, 0x02, 0x9f,
+	0x59, 0xcd, 0xb5, 0x3c, 0x73, 0xd2, 0xa4, 0x30, 0x27, 0x38, 0x94, 0xb8, 0x0c, 0x52, 0xd7, 0x73,
+	0x12, 0x4f, 0x82, 0x94, 0x94, 0xb8, 0x20, 0x24, 0x9f, 0x40, 0x63, 0xe6, 0x2e, 0x97, 0xbd, 0x05,
+	0xc6, 0x9e, 0x4b, 0xfd, 0xc6, 0x41, 0x29, 0xa3, 0xee, 0x1d, 0x77, 0xb9, 0xa4, 0xde, 0x2c, 0x08,
+	0xe7, 0x54, 0xa5, 0x43, 0x5f, 0xe0, 0xce, 0xe7, 0x61, 0xa4, 0xdf, 0xcc, 0xf9, 0x02, 0x13, 0xb1,
+	0xa9, 0x2f, 0x60, 0x44, 0x52, 0x6d, 0x79, 0x28, 0xbc, 0xb5, 0x45, 0x6d, 0x45, 0x28, 0x54, 0xd5,
+	0x96, 0xa1, 0xc8, 0xfb, 0x50, 0xbb, 0x90, 0x71, 0x50, 0xcf, 0x6d, 0x6d, 0x12, 0x03, 0x13, 0x12,
+	0x1c, 0x48, 0x86, 0xb3, 0x48, 0xbf, 0x7d, 0x50, 0xca, 0x0c, 0x34, 0x5e, 0x9f, 0x45, 0x97, 0x51,
+	0xec, 0x5d, 0x24, 0x21, 0x30, 0x25, 0x36, 0x6e, 0x8b, 0xd8, 0x57, 0x85, 0xa2, 0xfd, 0x58, 0xdb,
+	0x21, 0x75, 0xa8, 0x58, 0x94, 0xda, 0x54, 0x2b, 0x18, 0xff, 0xb6, 0x0b, 0x6f, 0x8d, 0xbc, 0x30,
+	0x5a, 0x44, 0xb1, 0xe7, 0xc7, 0x42, 0x77, 0x17, 0x81, 0xcc, 0x73, 0xc9, 0x4d, 0xa8, 0xa2, 0x68,
+	0xfa, 0x73, 0xe6, 0x45, 0x9b, 0x54, 0x40, 0xe4, 0x31, 0xec, 0xb9, 0xf3, 0xf9, 0xc4, 0x77, 0xc3,
+	0x4b, 0x99, 0xf5, 0x72, 0xcf, 0xf9, 0xff, 0x55, 0x69, 0xa9, 0xed, 0xa2, 0xc7, 0xde, 0x0e, 0xcd,
+	0x73, 0x92, 0x9f, 0x43, 0x1d, 0xbb, 0x65, 0x38, 0xbd, 0x94, 0x73, 0x8d, 0x1d, 0xd9, 0x92, 0x76,
+	0x90, 0x52, 0x93, 0x23, 0x68, 0xad, 0x79, 0x23, 0x97, 0xaf, 0xf0, 0xac, 0x77, 0xb6, 0xb1, 0x73,
+	0x8a, 0xde, 0x0e, 0xcd, 0xb2, 0x90, 0x77, 0x70, 0x8d, 0xfe, 0xcc, 0x5b, 0x0a, 0x27, 0xbb, 0xa7,
+	0x30, 0x23, 0xba, 0xb7, 0x43, 0x05, 0x01, 0xaa, 0x30, 0x8e, 0xcd, 0x3d, 0xbc, 0x5e, 0xfd, 0xe1,
+	0xa9, 0x2a, 0xe4, 0xe4, 0xa7, 0x50, 0x3b, 0xf7, 0xe2, 0x71, 0xec, 0xc6, 0x91, 0xbe, 0x9b, 0xd3,
+	0xe1, 0x63, 0xd1, 0x90, 0x72, 0x26, 0xb4, 0x28, 0xeb, 0x68, 0x7d, 0x16, 0xcd, 0xc2, 0xc5, 0x99,
+	0x67, 0xbd, 0xf4, 0xfc, 0x38, 0xd2, 0x6b, 0x39, 0x59, 0x8f, 0xb3, 0xed, 0x8a, 0xac, 0x73, 0x9c,
+	0xe4, 0x0f, 0xa0, 0xbc, 0x0a, 0x12, 0x87, 0xdc, 0x4a, 0x35, 0x35, 0xf0, 0xcf, 0x7b, 0x3b, 0x94,
+	0x35, 0x92, 0x87, 0x50, 0xe7, 0x0b, 0x36, 0x97, 0x4b, 0xe1, 0x8a, 0x49, 0x4e, 0x28, 0xe6, 0x72,
+	0xc9, 0x77, 0x42, 0x00, 0xe4, 0x53, 0x68, 0xf0, 0x60, 0xf7, 0x28, 0x74, 0x2f, 0xa4, 0x0b, 0xbe,
+	0x9e, 0x0b, 0x8a, 0xac, 0xad, 0xb7, 0x43, 0x55, 0x52, 0xf2, 0x20, 0x09, 0x48, 0xcd, 0xab, 0x0e,
+	0x16, 0xb8, 0x05, 0x9c, 0x86, 0xfc, 0x1a, 0xf6, 0xdd, 0xf9, 0xdc, 0x09, 0x56, 0x8b, 0xd9, 0xa9,
+	0xbb, 0x5c, 0xcc, 0xdd, 0x38, 0x90, 0x69, 0xf7, 0x8f, 0x54, 0xdd, 0xcb, 0x52, 0xa4, 0xfd, 0x6c,
+	0x72, 0x93, 0x63, 0xd0, 0x5e, 0x72, 0x80, 0x69, 0x7e, 0xb4, 0x5e, 0xc6, 0x7a, 0x3b, 0xb7, 0xb7,
+	0xa7, 0x39, 0x82, 0xde, 0x0e, 0xdd, 0x60, 0x22, 0x0e, 0x90, 0xd0, 0xbb, 0x08, 0x5e, 0x7a, 0x19,
+	0xc3, 0xe0, 0x6e, 0xdb, 0x50, 0xc2, 0x49, 0x9e, 0x24, 0x9d, 0xdd, 0x16, 0x7e, 0xf2, 0x3e, 0x54,
+	0x66, 0xcf, 0xd7, 0xfe, 0x0b, 0x5d, 0xcb, 0x07, 0x51, 0xf7, 0x72, 0x19, 0xb8, 0xf3, 0x0e, 0x36,
+	0xf6, 0x76, 0x28, 0xa7, 0x3a, 0xaa, 0xc3, 0xee, 0x85, 0x17, 0x61, 0x48, 0x33, 0xfe, 0xb5, 0x0a,
+	0x77, 0xb7, 0x5b, 0xb7, 0x50, 0xfd, 0xab, 0xcc, 0xfb, 0x57, 0xb0, 0x3f, 0xcb, 0x1b, 0x8e, 0x5e,
+	0x7c, 0x0d, 0xd3, 0xda, 0x64, 0x23, 0x16, 0xec, 0x85, 0x62, 0x7d, 0xb8, 0x20, 0xcc, 0x06, 0x5e,
+	0xc3, 0xc6, 0xf3, 0x3c, 0xa8, 0x5f, 0x3c, 0x1c, 0xb0, 0x8c, 0x4c, 0x2f, 0xe7, 0xf4, 0xab, 0x9b,
+	0xb6, 0xa1, 0x7e, 0x29, 0xa4, 0x6f, 0x62, 0xdf, 0x9f, 0x42, 0xc3, 0xf3, 0xe7, 0xf6, 0xb3, 0x8c,
+	0x81, 0xa7, 0x83, 0x58, 0x69, 0x1b, 0x0e, 0xa2, 0x90, 0x92, 0x43, 0xa8, 0x44, 0x8a, 0x65, 0xdf,
+	0x54, 0x14, 0xdf, 0x4d, 0xb3, 0x16, 0xdc, 0x25, 0x46, 0x46, 0x7e, 0x0c, 0x15, 0x0f, 0x2d, 0x52,
+	0x98, 0x72, 0x3b, 0x1d, 0x03, 0xb1, 0x48, 0xc7, 0x9a, 0x99, 0xbd, 0x2e, 0xb6, 0xd9, 0xeb, 0x42,
+	0xd8, 0x2b, 0xca, 0xe6, 0xb3, 0x4d, 0x7b, 0xbd, 0xb3, 0x69, 0xaf, 0xca, 0x24, 0x52, 0x72, 0xf2,
+	0x4b, 0x68, 0x2f, 0xfc, 0x59, 0x70, 0xb1, 0xf0, 0xcf, 0xc5, 0xaa, 0x1b, 0x57, 0xe6, 0xb3, 0xbd,
+	0x1d, 0x9a, 0x23, 0xce, 0x9b, 0x7d, 0xf3, 0xf5, 0xcd, 0xfe, 0x33, 0x68, 0x71, 0x93, 0x3e, 0xe1,
+	0xda, 0xaa, 0xb7, 0x36, 0xac, 0x5f, 0xb4, 0xa0, 0xcb, 0xce, 0x90, 0x92, 0x2e, 0xec, 0x09, 0xe3,
+	0xf3, 0x24, 0x77, 0x3b, 0xe7, 0x51, 0x4f, 0xb3, 0xed, 0xa8, 0x52, 0x39, 0x96, 0xd4, 0xb0, 0xf6,
+	0xde, 0xd4, 0xb0, 0xe6, 0xa0, 0xe5, 0x53, 0x76, 0xd2, 0x86, 0xe2, 0x42, 0xda, 0x51, 0x71, 0x31,
+	0x27, 0xd7, 0x65, 0x1a, 0x51, 0x3c, 0x28, 0xdd, 0x6f, 0xca, 0x74, 0xe1, 0x5d, 0xd0, 0xa2, 0xc5,
+	0xb9, 0x2f, 0xd2, 0x65, 0x96, 0x7d, 0x30, 0x73, 0x68, 0xd2, 0x0d, 0xbc, 0xf1, 0x04, 0x6e, 0x6c,
+	0x3d, 0xc1, 0x13, 0x1d, 0x76, 0x5f, 0x78, 0x97, 0x0e, 0x3f, 0xdc, 0x14, 0xee, 0xd7, 0xa9, 0x04,
+	0xc9, 0x1f, 0x42, 0xeb, 0x3c, 0x74, 0x67, 0xde, 0xc8, 0x0b, 0x17, 0xc1, 0xfc, 0x24, 0x62, 0x46,
+	0x5b, 0xa2, 0x59, 0xa4, 0xf1, 0x67, 0x45, 0x20, 0x9b, 0xf9, 0x16, 0xb9, 0x0b, 0xf5, 0x28, 0x76,
+	0xc3, 0xd8, 0x59, 0x5c, 0xf0, 0x53, 0x53, 0x89, 0xa6, 0x08, 0xf4, 0x15, 0xeb, 0x55, 0x8c, 0x4d,
+	0x45, 0xd6, 0x24, 0x20, 0xc4, 0x5f, 0x04, 0xf3, 0xf5, 0xd2, 0x63, 0xeb, 0xa8, 0x53, 0x01, 0xe1,
+	0x24, 0x5f, 0xa2, 0xef, 0x09, 0x7c, 0x66, 0xac, 0x75, 0x2a, 0x41, 0x1c, 0xe7, 0x3c, 0x38, 0x15,
+	0x6d, 0x95, 0x83, 0xe2, 0xfd, 0x3a, 0x4d, 0x11, 0xc8, 0x37, 0x7f, 0x1e, 0x9f, 0x04, 0x73, 0x8f,
+	0xd9, 0x5f, 0x9d, 0x4a, 0x90, 0x18, 0xd0, 0xe4, 0x6a, 0x80, 0x99, 0xaa, 0x17, 0x32, 0x53, 0xab,
+	0xd3, 0x0c, 0x0e, 0xa5, 0xce, 0x72, 0x74, 0xbd, 0x76, 0x50, 0xbc, 0x5f, 0xa3, 0x1c, 0x20, 0x77,
+	0xa0, 0xc6, 0x3e, 0x7a, 0xc1, 0x4a, 0xaf, 0xb3, 0x86, 0x04, 0x36, 0xbe, 0x84, 0x76, 0xb6, 0xcc,
+	0x81, 0x7d, 0xac, 0xc2, 0xe0, 0x8c, 0x0b, 0xb7, 0x46, 0x39, 0x80, 0xf3, 0xc2, 0xf5, 0x06, 0xeb,
+	0x58, 0x08, 0x55, 0x82, 0xc6, 0x9f, 0xc0, 0x5e, 0x2e, 0x07, 0x25, 0x5f, 0x40, 0x33, 0xf4, 0xdc,
+	0xd9, 0x73, 0xf7, 0x6c, 0xb1, 0xc4, 0xca, 0x0c, 0x3f, 0x83, 0xbe, 0x95, 0xb5, 0xf2, 0x43, 0xaa,
+	0x90, 0xd0, 0x0c, 0x03, 0x79, 0x4f, 0xce, 0xa1, 0x98, 0xd3, 0x4d, 0x31, 0xd2, 0x08, 0x1b, 0xc5,
+	0xd4, 0x8c, 0x57, 0xd0, 0x54, 0xd1, 0xff, 0xf7, 0xd1, 0x89, 0x38, 0x71, 0x14, 0x99, 0x36, 0xb3,
+	0x6f, 0xc4, 0xa1, 0x0a, 0x0b, 0x6d, 0x65, 0xdf, 0xc6, 0x5f, 0x16, 0x00, 0xd2, 0x34, 0x3a, 0x61,
+	0x2b, 0x28, 0x6c, 0x5c, 0x98, 0x71, 0xc0, 0xfa, 0xaa, 0x53, 0x0e, 0x64, 0x55, 0xad, 0x94, 0x57,
+	0xb5, 0x3b, 0x50, 0x9b, 0xaf, 0x43, 0x16, 0x59, 0xf5, 0x32, 0x6b, 0x4c, 0x60, 0x54, 0xb7, 0x90,
+	0x87, 0x68, 0xae, 0x39, 0x02, 0xc2, 0x71, 0xf8, 0x09, 0x9e, 0x2b, 0x0d, 0x07, 0x8c, 0xbf, 0x28,
+	0x40, 0x3b, 0x5b, 0xf3, 0xbd, 0x6a, 0x92, 0x5b, 0x6c, 0x55, 0xd9, 0xf1, 0x52, 0x66, 0xc7, 0xb1,
+	0x05, 0x49, 0x1c, 0x67, 0xc0, 0x74, 0xbb, 0x44, 0x25, 0xb8, 0xd5, 0xbe, 0x2b, 0x57, 0xd8, 0xf7,
+	0xaf, 0x61, 0x2f, 0x77, 0x5a, 0x4c, 0x84, 0x2c, 0x26, 0x87, 0xdf, 0x5b, 0xbb, 0x2c, 0x5e, 0xd9,
+	0x65, 0x43, 0xa9, 0xb1, 0x5e, 0xb5, 0xd6, 0x59, 0xb0, 0xf6, 0xb9, 0x16, 0x57, 0x28, 0x07, 0xae,
+	0x5e, 0xab, 0xd1, 0x81, 0x6b, 0x5b, 0xaa, 0x72, 0x5b, 0xbb, 0xbe, 0xda, 0x44, 0x7e, 0x01, 0x35,
+	0xd9, 0x01, 0xf9, 0x00, 0x76, 0x3d, 0x3f, 0x0e, 0x17, 0x5e, 0xa4, 0x17, 0x72, 0xc5, 0x04, 0x49,
+	0x63, 0xf9, 0x71, 0x78, 0x49, 0x25, 0x99, 0xf1, 0x33, 0x68, 0x65, 0x5a, 0x88, 0x06, 0xa5, 0x17,
+	0x1e, 0xd7, 0xeb, 0x3a, 0xc5, 0x4f, 0x5c, 0xd5, 0x4b, 0x77, 0xb9, 0xf6, 0xa4, 0x9a, 0x31, 0xc0,
+	0xb0, 0x60, 0x2f, 0x57, 0x13, 0x64, 0x9a, 0x27, 0x0f, 0x4b, 0xc2, 0x7b, 0xa6, 0x08, 0xec, 0x66,
+	0x89, 0xd4, 0x6c, 0xfe, 0x75, 0xca, 0x01, 0xe3, 0x18, 0xf6, 0x37, 0x0e, 0x58, 0xf9, 0x8e, 0x8a,
+	0x57, 0x76, 0x54, 0x4c, 0x3b, 0x7a, 0x0a, 0x7b, 0xb9, 0x8a, 0xf0, 0x55, 0x72, 0x8c, 0x5e, 0x2c,
+	0x56, 0xdd, 0x9e, 0xc3, 0xe6, 0x51, 0xa3, 0x12, 0xfc, 0x9e, 0x6d, 0x5a, 0xc3, 0xb5, 0x2d, 0x25,
+	0x63, 0x66, 0x7e, 0xac, 0x6e, 0x23, 0x7d, 0x19, 0x02, 0xd8, 0x4d, 0xe8, 0x3d, 0x0b, 0xbd, 0xe8,
+	0xb9, 0x1c, 0x40, 0x80, 0x48, 0xff, 0x2c, 0x08, 0x67, 0xdc, 0x99, 0xd7, 0x28, 0x07, 0xd4, 0x61,
+	0xcb, 0xf9, 0x8d, 0x25, 0xea, 0xb0, 0x47, 0xeb, 0xd9, 0x0b, 0x2f, 0xc6, 0xfd, 0x99, 0xad, 0x96,
+	0x6c, 0x4d, 0x15, 0x8a, 0x9f, 0xe9, 0x3c, 0x84, 0x85, 0x31, 0xc0, 0x78, 0x01, 0xd7, 0xb7, 0x55,
+	0x1d, 0x50, 0xb6, 0x48, 0xd0, 0x61, 0x7a, 0xca, 0x7b, 0x49, 0x11, 0xe4, 0x13, 0xd8, 0x3d, 0x63,
+	0xe3, 0xf0, 0xde, 0xd4, 0x2a, 0xc2, 0xe6, 0x5c, 0xa8, 0xa4, 0x35, 0x86, 0xa0, 0x5f, 0x75, 0x91,
+	0x90, 0x3a, 0x80, 0x82, 0xea, 0x00, 0xee, 0x42, 0xfd, 0x4c, 0x92, 0x0b, 0x41, 0xa5, 0x08, 0xe3,
+	0x5f, 0x0a, 0xa0, 0xe5, 0x6b, 0xdd, 0xe4, 0x61, 0xa6, 0xe8, 0x78, 0xef, 0xca, 0xa2, 0xb8, 0x5a,
+	0x7c, 0xdc, 0xe6, 0x6d, 0x93, 0x09, 0x95, 0xd4, 0x09, 0x69, 0x50, 0x8a, 0xe3, 0xa5, 0xd8, 0x03,
+	0xfc, 0x44, 0x77, 0xc8, 0x3c, 0x6a, 0xa4, 0x57, 0x0e, 0x4a, 0xe8, 0x0e, 0x39, 0x64, 0xfc, 0x5c,
+	0x9c, 0xf9, 0x5b, 0x50, 0x37, 0xbb, 0x5d, 0x51, 0x67, 0xdd, 0x61, 0x55, 0xea, 0x81, 0x65, 0x52,
+	0x81, 0x28, 0x60, 0xa5, 0xf5, 0xd8, 0x72, 0xa6, 0x23, 0x6a, 0x3b, 0x76, 0xc7, 0x1e, 0x8c, 0xb5,
+	0xa2, 0x61, 0xc3, 0xfe, 0x46, 0xdd, 0x82, 0xed, 0x08, 0xf6, 0x3c, 0x0b, 0x96, 0x5c, 0x48, 0x75,
+	0x9a, 0x22, 0xb8, 0x2d, 0xac, 0x56, 0x41, 0x18, 0x7b, 0x73, 0xb6, 0x27, 0x75, 0x9a, 0x22, 0x8c,
+	0xbf, 0x15, 0x82, 0x52, 0x6f, 0x5e, 0xbe, 0x57, 0x50, 0x2a, 0xa1, 0x2a, 0x28, 0x03, 0x9a, 0xee,
+	0x72, 0x19, 0xbc, 0x92, 0xb5, 0x48, 0xae, 0x4b, 0x19, 0x1c, 0xd2, 0x9c, 0x2d, 0x83, 0xd9, 0x0b,
+	0x49, 0xc3, 0xe5, 0x97, 0xc1, 0x19, 0xba, 0x10, 0xce, 0x2e, 0x94, 0x8e, 0x2d, 0x47, 0xdb, 0xc1,
+	0x8f, 0xb1, 0xe5, 0x68, 0x05, 0xe3, 0x2b, 0xd8, 0x57, 0x26, 0x20, 0xd6, 0x9e, 0x1f, 0xb6, 0xf0,
+	0x1a, 0xc3, 0x16, 0xb7, 0x0c, 0xfb, 0xf7, 0x05, 0x68, 0xc9, 0x52, 0xe4, 0x78, 0x16, 0xf0, 0x05,
+	0x61, 0x75, 0x2c, 0xea, 0xfb, 0x67, 0xc1, 0xda, 0x9f, 0x8b, 0xa4, 0x2b, 0x83, 0xc3, 0x94, 0x8e,
+	0xc1, 0xf6, 0x3a, 0xe6, 0x44, 0x3c, 0xfd, 0xca, 0x22, 0xc9, 0x8f, 0xa1, 0xcd, 0x93, 0xeb, 0xa4,
+	0x2f, 0x1e, 0x55, 0x73, 0x58, 0x72, 0x1f, 0xf6, 0x04, 0x26, 0xe9, 0x8f, 0x47, 0xd8, 0x3c, 0xda,
+	0xf8, 0x0a, 0x6e, 0x8c, 0xc4, 0x06, 0x67, 0x27, 0x9d, 0x44, 0xf4, 0x82, 0x1a, 0xd1, 0x1f, 0x40,
+	0x65, 0xcd, 0x12, 0x71, 0x9c, 0x5e, 0x23, 0x5b, 0x6e, 0x4f, 0x99, 0x29, 0x27, 0x32, 0x26, 0x5c,
+	0xce, 0xd9, 0x8e, 0xb7, 0xb9, 0xc2, 0x37, 0xeb, 0xf6, 0x1f, 0x0b, 0x70, 0x63, 0x6b, 0xb1, 0x97,
+	0x1c, 0x42, 0x55, 0x71, 0xd5, 0x57, 0x77, 0x24, 0xa8, 0xc8, 0x2f, 0x54, 0x7d, 0xe7, 0x5e, 0x46,
+	0xd1, 0xd1, 0x6d, 0x72, 0x51, 0xed, 0xe1, 0x03, 0xe9, 0xed, 0x4a, 0xb9, 0x3a, 0xdd, 0xc6, 0xa2,
+	0xa5, 0x27, 0xfc, 0x63, 0xd0, 0xf2, 0x77, 0x8c, 0x68, 0xdb, 0x67, 0x97, 0x23, 0x2e, 0x11, 0xf4,
+	0x3d, 0x02, 0x52, 0xfc, 0x45, 0x21, 0x91, 0xd3, 0x3d, 0x80, 0xb3, 0x4b, 0x39, 0x2f, 0xe1, 0xbc,
+	0x15, 0x8c, 0xf1, 0x0d, 0xb4, 0x93, 0xfe, 0x79, 0x59, 0x09, 0x7d, 0x7a, 0x10, 0xbb, 0xcb, 0xbe,
+	0x2f, 0xd4, 0x4e, 0x82, 0x98, 0x7e, 0xb1, 0x4f, 0x9b, 0xc5, 0x71, 0x96, 0x7e, 0x49, 0x98, 0xa5,
+	0x5f, 0x78, 0x22, 0xf1, 0x99, 0x7e, 0x15, 0xa8, 0x80, 0x58, 0x44, 0x71, 0x63, 0xcf, 0x66, 0x11,
+	0x02, 0x1b, 0x24, 0x68, 0x50, 0x68, 0xe1, 0xac, 0x93, 0xd1, 0xb7, 0x6e, 0xf3, 0xfb, 0xf2, 0xf8,
+	0xcc, 0xb7, 0xf9, 0xd6, 0x66, 0x61, 0x9c, 0x9f, 0xa3, 0x39, 0x95, 0xf1, 0x1b, 0xd8, 0x97, 0x2b,
+	0x4b, 0xfb, 0xdd, 0xae, 0x97, 0x6f, 0xd8, 0xf3, 0xdf, 0x15, 0x60, 0x7f, 0xa3, 0x18, 0x8f, 0x9d,
+	0x30, 0x09, 0xe8, 0x85, 0x1f, 0xe8, 0x84, 0x51, 0xa1, 0xd2, 0xa6, 0xc1, 0x4e, 0xd5, 0xb5, 0x8c,
+	0x20, 0x64, 0x30, 0xfe, 0x54, 0x55, 0xb5, 0x0d, 0x85, 0xc9, 0x2f, 0x53, 0x51, 0x33, 0xe3, 0x29,
+	0xb4, 0x32, 0x35, 0x69, 0xdc, 0x9d, 0x25, 0x2b, 0xf6, 0x08, 0x1f, 0x25, 0x20, 0xdc, 0xd1, 0xe0,
+	0x2c, 0xf2, 0xc2, 0x97, 0xc2, 0x3d, 0x37, 0x69, 0x02, 0xa7, 0x27, 0x26, 0x11, 0x69, 0x18, 0x60,
+	0x8c, 0xa1, 0x9e, 0x5c, 0x7b, 0xbc, 0x41, 0xca, 0x7c, 0x17, 0xea, 0xc9, 0x0d, 0x10, 0xd3, 0x90,
+	0x1a, 0x4d, 0x11, 0xc6, 0x6f, 0xa0, 0xa9, 0x5e, 0xfc, 0x60, 0xbf, 0x61, 0x1c, 0x73, 0x87, 0x5a,
+	0xa2, 0xec, 0x1b, 0x43, 0xdc, 0xc5, 0xc2, 0x17, 0x7a, 0x87, 0x9f, 0x88, 0x71, 0x5f, 0x9e, 0x0b,
+	0x7f, 0x86, 0x9f, 0x8c, 0xc6, 0xfd, 0x46, 0x38, 0x2e, 0xfc, 0x34, 0x02, 0xd8, 0xdf, 0x78, 0xa4,
+	0xf1, 0x43, 0xc7, 0x91, 0x52, 0xaa, 0x24, 0x57, 0x67, 0xfa, 0x37, 0xa1, 0xfa, 0x0c, 0xcb, 0x15,
+	0x73, 0x16, 0x74, 0x6b, 0x54, 0x40, 0xc6, 0x02, 0x1a, 0x4a, 0x6d, 0x03, 0x87, 0x62, 0x75, 0xfc,
+	0x02, 0x37, 0x49, 0xfc, 0x46, 0x93, 0x9c, 0x2d, 0x83, 0xc8, 0x7b, 0x12, 0x2e, 0x62, 0x4f, 0xa4,
+	0x0f, 0x0a, 0x26, 0x3d, 0xb1, 0x94, 0x94, 0x13, 0x0b, 0x5b, 0xed, 0xec, 0x05, 0x1b, 0xad, 0x45,
+	0xf1, 0xd3, 0xe8, 0x41, 0x53, 0x2d, 0x48, 0x20, 0x45, 0xe4, 0x7d, 0xcd, 0x56, 0xd5, 0xa2, 0xf8,
+	0x99, 0x8c, 0x5e, 0x54, 0x46, 0x27, 0x50, 0x5e, 0xba, 0x51, 0x2c, 0x5c, 0x01, 0xfb, 0x36, 0xbe,
+	0x84, 0xeb, 0xdb, 0xde, 0xa2, 0x6c, 0x3d, 0x75, 0x6c, 0x15, 0x94, 0xf1, 0x15, 0xb4, 0x32, 0x37,
+	0xa2, 0x6c, 0x2b, 0xa2, 0x73, 0x99, 0x89, 0x5f, 0x44, 0x58, 0xa8, 0x6b, 0xce, 0x17, 0xee, 0xd2,
+	0x8c, 0x63, 0xef, 0x62, 0x95, 0xa4, 0x68, 0x4a, 0xa5, 0x2e, 0x6d, 0xa4, 0x19, 0x4a, 0xe3, 0x1f,
+	0x0a, 0xd0, 0x50, 0x5a, 0xaf, 0x9a, 0x96, 0xbc, 0xa8, 0x2d, 0xa6, 0x42, 0xfb, 0x08, 0x0f, 0x85,
+	0x6e, 0x14, 0xf0, 0xa7, 0x3b, 0xed, 0xcc, 0x9d, 0x54, 0xd2, 0x1f, 0x1e, 0x78, 0xa3, 0xc0, 0xa7,
+	0x82, 0xd4, 0xf8, 0x1c, 0xaa, 0x1c, 0x83, 0x17, 0x22, 0xb6, 0xd3, 0xb3, 0x28, 0x7f, 0x11, 0x40,
+	0xad, 0x47, 0x93, 0xb1, 0xd5, 0xd5, 0x0a, 0x08, 0x38, 0xfd, 0x13, 0xcb, 0x9e, 0x38, 0x5a, 0x11,
+	0x53, 0xa7, 0xc9, 0x90, 0x5a, 0x66, 0xa7, 0xc7, 0x2e, 0xc2, 0x4b, 0xc6, 0x19, 0x40, 0x5a, 0x1a,
+	0xdb, 0xaa, 0x6c, 0x72, 0x01, 0xc5, 0x6d, 0x72, 0x2d, 0xa9, 0x5e, 0xea, 0x26, 0x54, 0x5f, 0x2d,
+	0xfc, 0x79, 0xf0, 0x4a, 0x6c, 0xbc, 0x80, 0x8c, 0x7f, 0x2f, 0x01, 0xa4, 0x6f, 0x80, 0xc8, 0x83,
+	0x4c, 0xd2, 0xa4, 0x6f, 0x79, 0x26, 0xb4, 0x3d, 0xaf, 0x4c, 0xe3, 0x04, 0x66, 0xe6, 0x0b, 0x59,
+	0x72, 0xc2, 0x4f, 0x79, 0x96, 0x2a, 0x73, 0x4c, 0xe6, 0x2c, 0xc5, 0x0f, 0xae, 0x1c, 0x48, 0xcf,
+	0x8d, 0xd5, 0x2b, 0xce, 0x8d, 0xbb, 0x1b, 0x96, 0xf3, 0xf5, 0x3a, 0x08, 0xd7, 0x17, 0xac, 0xc4,
+	0x59, 0xa1, 0x02, 0x42, 0x5f, 0xe4, 0xfa, 0x7e, 0xb0, 0xf6, 0x67, 0x1e, 0xab, 0x6a, 0xd6, 0x68,
+	0x02, 0x1b, 0xff, 0x53, 0x48, 0xd3, 0xd6, 0xf4, 0x19, 0xc2, 0x0e, 0x39, 0x80, 0xbb, 0x09, 0x38,
+	0x96, 0x0f, 0x23, 0xac, 0xee, 0xd4, 0xb1, 0x39, 0x45, 0x01, 0xdf, 0x3a, 0x70, 0x0a, 0x6a, 0x9f,
+	0xf6, 0xbb, 0xf8, 0x1a, 0xa0, 0x48, 0x6e, 0xc0, 0x3e, 0xe6, 0xb6, 0x9d, 0x81, 0x3d, 0xb6, 0x92,
+	0x97, 0x1a, 0x25, 0x24, 0x45, 0xf4, 0x68, 0x72, 0x34, 0xe8, 0x77, 0xa6, 0x8f, 0xad, 0xa7, 0x5a,
+	0x19, 0xc7, 0x43, 0xdc, 0xa9, 0x39, 0x98, 0x58, 0x5a, 0x05, 0x1f, 0x2c, 0x8c, 0x2d, 0x93, 0x76,
+	0x7a, 0x02, 0x53, 0x45, 0x82, 0xd1, 0x44, 0x12, 0xec, 0xa2, 0x66, 0x88, 0x91, 0xb4, 0x1a, 0xbe,
+	0x88, 0x18, 0x3b, 0x26, 0x75, 0xc4, 0xe0, 0xf8, 0x4a, 0xa3, 0xce, 0x1f, 0x8f, 0xd8, 0x23, 0x05,
+	0x07, 0x88, 0xe3, 0x6f, 0x46, 0x12, 0x5c, 0xc3, 0xf8, 0x2b, 0x54, 0xfa, 0xf4, 0x32, 0x9f, 0xbc,
+	0x9f, 0xd9, 0xe2, 0xdb, 0xdb, 0x2e, 0xfc, 0xd5, 0x3d, 0x7e, 0x5b, 0xd9, 0xe3, 0xef, 0xb9, 0x1b,
+	0x4e, 0xb6, 0xb4, 0xa4, 0x6c, 0xa9, 0xf1, 0xb6, 0x90, 0x76, 0x1d, 0x2a, 0x47, 0xd6, 0x71, 0x7f,
+	0xc8, 0xef, 0x06, 0xf9, 0x1a, 0x0b, 0x98, 0x14, 0x5b, 0xc3, 0xae, 0x56, 0x34, 0x3e, 0x80, 0x9a,
+	0xec, 0xee, 0xf5, 0xaa, 0x9c, 0xc6, 0x10, 0x5a, 0x99, 0x77, 0x01, 0x1b, 0x6c, 0x58, 0x7a, 0xc5,
+	0xfc, 0x55, 0x78, 0x87, 0x8d, 0x07, 0x7a, 0x0b, 0x51, 0x9a, 0xe4, 0x54, 0xc6, 0xb7, 0x69, 0x19,
+	0x47, 0xb4, 0x6c, 0x75, 0x0e, 0x5f, 0x40, 0x7d, 0xbe, 0x08, 0x39, 0x11, 0x33, 0xba, 0xb6, 0x72,
+	0x27, 0x94, 0xe5, 0x3f, 0xec, 0x4a, 0x42, 0x9a, 0xf2, 0xf0, 0x73, 0xf1, 0xd2, 0xbd, 0x4c, 0x82,
+	0x97, 0x04, 0x51, 0x6b, 0x23, 0x6f, 0xb6, 0x0e, 0x17, 0x31, 0x37, 0x95, 0x3a, 0x4d, 0x60, 0xe3,
+	0x23, 0xa8, 0x27, 0xbd, 0xa1, 0x66, 0x4c, 0x86, 0x8f, 0x87, 0xf6, 0x93, 0x21, 0xf7, 0x26, 0xfd,
+	0xe1, 0x91, 0x3d, 0x19, 0xa2, 0x37, 0x69, 0x42, 0xcd, 0x9e, 0x38, 0x1c, 0x2a, 0x1a, 0xdf, 0x16,
+	0x81, 0x6c, 0x3e, 0xd7, 0x23, 0x1f, 0x67, 0xb6, 0xff, 0xe0, 0x7b, 0x5e, 0xf6, 0xbd, 0x86, 0xa5,
+	0xc7, 0xee, 0xb9, 0x08, 0x2e, 0xf8, 0xc9, 0x9c, 0x8c, 0xb7, 0x38, 0x7f, 0x2e, 0x0f, 0xf1, 0x02,
+	0xc2, 0x53, 0xc8, 0x32, 0x78, 0xf5, 0xc4, 0x8d, 0xbd, 0xf0, 0xc4, 0x0d, 0x5f, 0x30, 0xb3, 0x2f,
+	0xd1, 0x0c, 0x0e, 0x4f, 0x21, 0xcf, 0x17, 0xe7, 0xcf, 0x53, 0xa2, 0x2a, 0x2f, 0x2c, 0x67, 0x90,
+	0xe4, 0x00, 0x1a, 0x4a, 0xa5, 0x59, 0x78, 0x04, 0x15, 0x65, 0xfc, 0x36, 0x7d, 0x87, 0xe5, 0x98,
+	0xc7, 0xd2, 0xbe, 0xdb, 0x00, 0x93, 0x61, 0x02, 0x17, 0xf0, 0xb1, 0x93, 0x43, 0xfb, 0x27, 0x5a,
+	0x11, 0x5b, 0xf0, 0xb1, 0xd3, 0xa0, 0x7f, 0xd2, 0x77, 0xd0, 0x78, 0xb9, 0xe1, 0x39, 0xf8, 0xa4,
+	0x8a, 0x59, 0xed, 0x64, 0x28, 0xc1, 0x8a, 0xd1, 0x87, 0xfd, 0x8d, 0x27, 0x8c, 0x5b, 0xfd, 0xf2,
+	0x01, 0x34, 0x9e, 0x05, 0xe1, 0xb9, 0x17, 0x9b, 0x42, 0x75, 0xd1, 0x0b, 0xa9, 0x28, 0xe3, 0xa7,
+	0x40, 0x36, 0x5f, 0x33, 0x20, 0x1f, 0x8b, 0xdf, 0xf3, 0x0e, 0xd3, 0x5d, 0x5e, 0x98, 0x50, 0x51,
+	0xc6, 0xdf, 0x14, 0xa0, 0x9e, 0xdc, 0x5a, 0x92, 0xf7, 0x32, 0x9b, 0x79, 0x6b, 0xf3, 0x5e, 0x53,
+	0xdd, 0xc3, 0xeb, 0x98, 0x63, 0xae, 0x16, 0x33, 0x59, 0x7a, 0x62, 0x40, 0x12, 0xda, 0x4b, 0x69,
+	0x68, 0x37, 0x8e, 0x84, 0x0c, 0xdb, 0x00, 0xe8, 0xb4, 0x1c, 0x7b, 0xd4, 0xef, 0x8c, 0xb9, 0x14,
+	0x95, 0x27, 0x69, 0x2c, 0x7c, 0x31, 0x27, 0x37, 0xee, 0x69, 0x45, 0x94, 0xd5, 0x78, 0x72, 0x34,
+	0xee, 0xd0, 0xfe, 0x11, 0x06, 0xaf, 0x3f, 0x67, 0x13, 0x95, 0x37, 0x21, 0x04, 0xca, 0xcf, 0xc2,
+	0xe0, 0x42, 0xa6, 0x2f, 0xf8, 0xbd, 0x35, 0xa9, 0xb8, 0x0e, 0x95, 0xc8, 0xfb, 0xda, 0x0f, 0xa4,
+	0x1b, 0x61, 0x00, 0x3f, 0x2f, 0xac, 0x16, 0xb3, 0x7e, 0x37, 0xd2, 0xcb, 0x2c, 0x5b, 0x48, 0x60,
+	0x56, 0x19, 0x58, 0x9c, 0xfb, 0x6e, 0xbc, 0x0e, 0x65, 0x3c, 0x49, 0x11, 0x32, 0xf6, 0x54, 0x93,
+	0xd8, 0x83, 0x45, 0x9a, 0xab, 0x2e, 0x6f, 0x53, 0x09, 0x89, 0x04, 0x9f, 0x01, 0x38, 0x82, 0x08,
+	0x39, 0xc9, 0x75, 0x47, 0x8a, 0x30, 0x26, 0xb0, 0x97, 0xbb, 0x09, 0xba, 0xa2, 0x9b, 0x07, 0xc9,
+	0xed, 0x8e, 0x38, 0x29, 0x6c, 0xb9, 0x88, 0xa2, 0x92, 0xc4, 0xf8, 0x1d, 0x68, 0xf9, 0x1b, 0x61,
+	0xf2, 0x69, 0x52, 0x99, 0xce, 0x1b, 0x6f, 0x9e, 0xf4, 0x90, 0xff, 0xc8, 0xda, 0xb5, 0xf1, 0x00,
+	0x33, 0x11, 0xd6, 0x07, 0x40, 0xd5, 0xec, 0x74, 0xac, 0x11, 0x16, 0x25, 0x00, 0xaa, 0xd4, 0xfa,
+	0x15, 0x7f, 0x9b, 0x08, 0x50, 0xed, 0x1f, 0x0f, 0xf1, 0x71, 0x5c, 0xd1, 0xf8, 0x1c, 0x20, 0x7d,
+	0xa9, 0x85, 0x46, 0xcd, 0x16, 0x20, 0xab, 0x32, 0x02, 0x42, 0x57, 0x86, 0xba, 0xde, 0xef, 0x72,
+	0x1f, 0xdb, 0xa4, 0x12, 0x34, 0xfe, 0xb9, 0x00, 0x5a, 0xfe, 0xc6, 0xf5, 0x0d, 0x4a, 0xf7, 0xa9,
+	0x46, 0x16, 0x13, 0xbd, 0xc8, 0xec, 0x41, 0x39, 0xb7, 0x07, 0x68, 0x36, 0x31, 0x73, 0x01, 0x6e,
+	0x88, 0x37, 0x9e, 0x15, 0xa6, 0xdf, 0x2a, 0x0a, 0x53, 0x65, 0x06, 0xe2, 0x29, 0x4a, 0x5e, 0xfb,
+	0x28, 0x18, 0x66, 0x78, 0x98, 0xfb, 0x7a, 0xf3, 0xf1, 0xe2, 0x77, 0x1e, 0xf3, 0x2b, 0x65, 0xaa,
+	0xa2, 0x8c, 0x35, 0xec, 0x6f, 0xdc, 0x47, 0x93, 0xbb, 0x78, 0xed, 0xc3, 0xbf, 0xb9, 0x6a, 0xe3,
+	0xbb, 0x8a, 0x30, 0x95, 0x9c, 0xf2, 0xe6, 0xaf, 0xc9, 0xae, 0x5c, 0x11, 0xcc, 0x0f, 0x56, 0xda,
+	0x18, 0xec, 0xa8, 0x26, 0x77, 0xda, 0xf8, 0xeb, 0x22, 0xdc, 0xdc, 0xfe, 0xd0, 0xe5, 0x8a, 0xe3,
+	0xe8, 0x21, 0x90, 0x0b, 0xf7, 0x9b, 0x4e, 0xe0, 0xcf, 0xd6, 0x21, 0x2e, 0x1d, 0x27, 0x1d, 0x89,
+	0x52, 0xfc, 0x96, 0x16, 0x72, 0x0a, 0xed, 0xe0, 0xa5, 0x17, 0x3e, 0x5b, 0x06, 0xaf, 0x46, 0xc1,
+	0x72, 0x31, 0xbb, 0x14, 0x19, 0xee, 0xe1, 0x0f, 0xbc, 0xb3, 0x39, 0xb4, 0x33, 0x5c, 0x34, 0xd7,
+	0x0b, 0x8f, 0x74, 0xab, 0xa5, 0x3b, 0xf3, 0xc4, 0xc1, 0x46, 0x82, 0x68, 0x93, 0xa1, 0xfb, 0x8a,
+	0xed, 0x52, 0x8d, 0xe2, 0xa7, 0x92, 0x9c, 0x56, 0x33, 0xc9, 0xe9, 0x4f, 0xa0, 0x9d, 0x1d, 0x45,
+	0x51, 0x59, 0x96, 0x49, 0x1c, 0x0d, 0xec, 0xce, 0x63, 0xad, 0x60, 0x7c, 0x08, 0xb7, 0xaf, 0x7c,
+	0xf4, 0xb0, 0x5d, 0x4e, 0xc6, 0x7f, 0x16, 0xa1, 0xa1, 0xdc, 0xe9, 0xe3, 0x7c, 0xa5, 0x79, 0x8a,
+	0x2b, 0xcf, 0x8b, 0xe4, 0x16, 0xb7, 0x3c, 0xc3, 0xcb, 0xc2, 0xe2, 0x41, 0x21, 0x9b, 0x30, 0xa5,
+	0xdc, 0x87, 0x9d, 0x60, 0xee, 0x51, 0x46, 0x66, 0xfc, 0x69, 0x11, 0xca, 0x08, 0x66, 0x03, 0xb5,
+	0x06, 0xcd, 0xa1, 0xcd, 0x8a, 0xa2, 0xd6, 0x78, 0x6c, 0xa1, 0xf3, 0xd4, 0xa0, 0xd9, 0xed, 0x9b,
+	0x83, 0xe9, 0x91, 0xd9, 0x79, 0x6c, 0x3f, 0x7a, 0xc4, 0x0f, 0x00, 0x0c, 0xf3, 0xc8, 0xec, 0x0f,
+	0xac, 0xae, 0x56, 0xc2, 0xfc, 0x32, 0x7d, 0xa3, 0x3b, 0xed, 0x5a, 0xc3, 0xbe, 0xd5, 0xd5, 0xca,
+	0xe4, 0x0e, 0xdc, 0x94, 0xe5, 0xd4, 0xe9, 0xd0, 0x76, 0xa6, 0xe3, 0xc9, 0x68, 0x64, 0x53, 0xc7,
+	0xea, 0x6a, 0x15, 0xf5, 0x44, 0xc1, 0x72, 0xca, 0x8e, 0x39, 0xec, 0x58, 0x03, 0xec, 0x6e, 0x17,
+	0xbb, 0x3b, 0xb1, 0xc6, 0xf8, 0x4e, 0x77, 0xea, 0xd8, 0xf6, 0x74, 0x60, 0xd2, 0x63, 0xcc, 0x2e,
+	0x6f, 0xc0, 0x7e, 0x77, 0x32, 0x1a, 0xf4, 0x3b, 0xf8, 0xe4, 0x96, 0x3d, 0xa3, 0xed, 0x77, 0xb5,
+	0x3a, 0xbe, 0x02, 0x1e, 0x5a, 0xc7, 0xb6, 0xd3, 0x37, 0xd9, 0xe8, 0xb2, 0x57, 0xc0, 0x97, 0xb1,
+	0x8c, 0x0a, 0x87, 0x36, 0x9f, 0x98, 0x7d, 0x1c, 0xb8, 0x81, 0xa9, 0x67, 0x82, 0xb5, 0x9f, 0x0c,
+	0xad, 0xae, 0xd6, 0x34, 0x6a, 0x50, 0xe5, 0x8f, 0x03, 0x8c, 0x06, 0xd4, 0x93, 0x67, 0x02, 0xc6,
+	0x87, 0xb0, 0x9f, 0x00, 0x6a, 0xfd, 0x97, 0xbf, 0x19, 0x58, 0x7a, 0x73, 0x59, 0x91, 0x4f, 0x10,
+	0x46, 0x0b, 0x1a, 0xca, 0xdb, 0x08, 0xa3, 0x0a, 0x65, 0x3c, 0xe7, 0xb3, 0xdf, 0xc0, 0x3f, 0x37,
+	0xf6, 0x61, 0x2f, 0xf7, 0xc0, 0xc9, 0x38, 0x02, 0x4d, 0x55, 0x06, 0x96, 0xd2, 0x6d, 0xb7, 0x18,
+	0x1d, 0xaf, 0x8b, 0xb0, 0x9e, 0xcf, 0x2b, 0x9f, 0x35, 0x2a, 0x41, 0xbc, 0x7d, 0x6c, 0x65, 0x9e,
+	0x57, 0x90, 0x2f, 0xc4, 0x73, 0x30, 0xd1, 0xab, 0xbc, 0x60, 0x4a, 0x95, 0x22, 0x3f, 0x26, 0xcd,
+	0xd2, 0xa3, 0xed, 0xbb, 0xb3, 0x78, 0xf1, 0xd2, 0x93, 0x76, 0x89, 0x15, 0x06, 0x15, 0x85, 0xb7,
+	0x71, 0x2b, 0xcf, 0x9f, 0x2b, 0x65, 0x8c, 0x48, 0x94, 0x26, 0x36, 0xf0, 0x46, 0x07, 0x6e, 0x6e,
+	0x7f, 0x99, 0x45, 0xde, 0x81, 0x0a, 0x06, 0x7d, 0x3e, 0xc1, 0xb6, 0xf2, 0xd8, 0x82, 0x91, 0xf1,
+	0xb4, 0x80, 0x53, 0x18, 0xff, 0x5d, 0x82, 0x0a, 0xc3, 0x92, 0x9f, 0x64, 0xd2, 0x89, 0xad, 0x3c,
+	0x8c, 0x60, 0xe3, 0xfe, 0xb7, 0x98, 0x3b, 0x14, 0xbf, 0xf6, 0xfd, 0x6f, 0x49, 0xc9, 0x27, 0x8f,
+	0x78, 0x1d, 0x9a, 0xe5, 0xf4, 0xbe, 0x17, 0x71, 0x3f, 0xdf, 0x7e, 0x78, 0x37, 0xd7, 0x6b, 0x47,
+	0xa5, 0xa1, 0x59, 0x96, 0xf4, 0xb4, 0x50, 0x51, 0x8b, 0x46, 0x32, 0x95, 0xaf, 0x2a, 0x37, 0xcb,
+	0x33, 0x91, 0xe3, 0xdc, 0x83, 0x3b, 0x03, 0xbb, 0x63, 0x0e, 0xa6, 0xe2, 0xe4, 0xdd, 0x1f, 0xf4,
+	0x9d, 0xa7, 0xd3, 0x4e, 0xcf, 0x1c, 0x1e, 0x5b, 0x5d, 0x6d, 0x07, 0xdb, 0xd9, 0x4b, 0xf1, 0xe4,
+	0x4c, 0x38, 0xb4, 0xc6, 0xe3, 0xa4, 0xbd, 0x80, 0x2f, 0xed, 0x39, 0x7f, 0x62, 0xdb, 0xd3, 0xc9,
+	0xa8, 0x6b, 0xa2, 0x51, 0x14, 0x8d, 0x8f, 0xa1, 0xa9, 0x0a, 0x21, 0xeb, 0x12, 0xf8, 0x7b, 0xfd,
+	0x41, 0xbf, 0x23, 0x32, 0x29, 0xda, 0x3f, 0x35, 0x1d, 0x8c, 0xbf, 0xa7, 0xca, 0xe1, 0x86, 0xad,
+	0x6a, 0x1f, 0x5a, 0x68, 0x56, 0xc9, 0x14, 0xb4, 0x1d, 0x66, 0xda, 0x09, 0xc8, 0xfe, 0x5a, 0xd0,
+	0x31, 0x87, 0x92, 0x82, 0xff, 0xb5, 0xa0, 0x63, 0x0e, 0x15, 0x2e, 0xad, 0x74, 0xd4, 0xfc, 0xa7,
+	0xef, 0xee, 0x15, 0xbe, 0xfd, 0xee, 0x5e, 0xe1, 0x3f, 0xbe, 0xbb, 0x57, 0xf8, 0xdf, 0x01, 0x00,
+	0x65, 0xab, 0x1f, 0xa3, 0x11, 0x35, 0x00, 0x00,
+}
+
+func (m *Request) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Request) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Request) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.LogLevel != nil {
+		{
+			size, err := m.LogLevel.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x9a
+	}
+	if m.PeerMetadata != nil {
+		{
+			size, err := m.PeerMetadata.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x92
+	}
+	if m.Peerstore != nil {
+		{
+			size, err := m.Peerstore.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x8a
+	}
+	if m.AutoNAT != nil {
+		{
+			size, err := m.AutoNAT.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x82
+	}
+	if m.RotateIdentity != nil {
+		{
+			size, err := m.RotateIdentity.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x7a
+	}
+	if m.RoutingTable != nil {
+		{
+			size, err := m.RoutingTable.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x72
+	}
+	if m.FindPeer != nil {
+		{
+			size, err := m.FindPeer.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x6a
+	}
+	if m.Bandwidth != nil {
+		{
+			size, err := m.Bandwidth.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x62
+	}
+	if m.PeerLists != nil {
+		{
+			size, err := m.PeerLists.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x5a
+	}
+	if m.SetBootstrapPeers != nil {
+		{
+			size, err := m.SetBootstrapPeers.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x52
+	}
+	if m.Ping != nil {
+		{
+			size, err := m.Ping.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x4a
+	}
+	if m.Pubsub != nil {
+		{
+			size, err := m.Pubsub.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.Disconnect != nil {
+		{
+			size, err := m.Disconnect.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.ConnManager != nil {
+		{
+			size, err := m.ConnManager.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.Dht != nil {
+		{
+			size, err := m.Dht.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.StreamHandler != nil {
+		{
+			size, err := m.StreamHandler.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.StreamOpen != nil {
+		{
+			size, err := m.StreamOpen.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Connect != nil {
+		{
+			size, err := m.Connect.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Type == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Type))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Response) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Response) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Response) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.LogLevels) > 0 {
+		for iNdEx := len(m.LogLevels) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.LogLevels[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintP2Pd(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0xca
+		}
+	}
+	if m.Metadata != nil {
+		{
+			size, err := m.Metadata.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xc2
+	}
+	if m.Peerstore != nil {
+		{
+			size, err := m.Peerstore.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xba
+	}
+	if m.Addrs != nil {
+		{
+			size, err := m.Addrs.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xb2
+	}
+	if len(m.CallHistory) > 0 {
+		for iNdEx := len(m.CallHistory) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.CallHistory[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintP2Pd(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0xaa
+		}
+	}
+	if m.AutoNAT != nil {
+		{
+			size, err := m.AutoNAT.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa2
+	}
+	if m.DaemonInfo != nil {
+		{
+			size, err := m.DaemonInfo.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x9a
+	}
+	if m.Disconnect != nil {
+		{
+			size, err := m.Disconnect.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x92
+	}
+	if m.RoutingTable != nil {
+		{
+			size, err := m.RoutingTable.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x8a
+	}
+	if m.InstanceId != nil {
+		i -= len(m.InstanceId)
+		copy(dAtA[i:], m.InstanceId)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.InstanceId)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x82
+	}
+	if m.Peer != nil {
+		{
+			size, err := m.Peer.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x7a
+	}
+	if m.Bandwidth != nil {
+		{
+			size, err := m.Bandwidth.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x72
+	}
+	if m.Connect != nil {
+		{
+			size, err := m.Connect.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x6a
+	}
+	if len(m.Relays) > 0 {
+		for iNdEx := len(m.Relays) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Relays[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintP2Pd(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x62
+		}
+	}
+	if m.ResourceUsage != nil {
+		{
+			size, err := m.ResourceUsage.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x5a
+	}
+	if m.PeerLists != nil {
+		{
+			size, err := m.PeerLists.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x52
+	}
+	if m.Ping != nil {
+		{
+			size, err := m.Ping.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x4a
+	}
+	if len(m.ConnectedPeers) > 0 {
+		for iNdEx := len(m.ConnectedPeers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.ConnectedPeers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintP2Pd(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x42
+		}
+	}
+	if m.Pubsub != nil {
+		{
+			size, err := m.Pubsub.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.Peers) > 0 {
+		for iNdEx := len(m.Peers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Peers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintP2Pd(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if m.Dht != nil {
+		{
+			size, err := m.Dht.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Identify != nil {
+		{
+			size, err := m.Identify.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.StreamInfo != nil {
+		{
+			size, err := m.StreamInfo.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Error != nil {
+		{
+			size, err := m.Error.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Type == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Type))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PersistentConnectionRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PersistentConnectionRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PersistentConnectionRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Message != nil {
+		{
+			size := m.Message.Size()
+			i -= size
+			if _, err := m.Message.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if m.CallId == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("callId")
+	} else {
+		i -= len(m.CallId)
+		copy(dAtA[i:], m.CallId)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.CallId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PersistentConnectionRequest_AddUnaryHandler) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PersistentConnectionRequest_AddUnaryHandler) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.AddUnaryHandler != nil {
+		{
+			size, err := m.AddUnaryHandler.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	return len(dAtA) - i, nil
+}
+func (m *PersistentConnectionRequest_CallUnary) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PersistentConnectionRequest_CallUnary) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.CallUnary != nil {
+		{
+			size, err := m.CallUnary.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *PersistentConnectionRequest_UnaryResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PersistentConnectionRequest_UnaryResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.UnaryResponse != nil {
+		{
+			size, err := m.UnaryResponse.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	return len(dAtA) - i, nil
+}
+func (m *PersistentConnectionRequest_Cancel) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PersistentConnectionRequest_Cancel) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Cancel != nil {
+		{
+			size, err := m.Cancel.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *PersistentConnectionRequest_CallStream) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PersistentConnectionRequest_CallStream) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.CallStream != nil {
+		{
+			size, err := m.CallStream.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	return len(dAtA) - i, nil
+}
+func (m *PersistentConnectionRequest_GetStats) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PersistentConnectionRequest_GetStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.GetStats != nil {
+		{
+			size, err := m.GetStats.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *PersistentConnectionRequest_SubscribeEvents) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PersistentConnectionRequest_SubscribeEvents) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.SubscribeEvents != nil {
+		{
+			size, err := m.SubscribeEvents.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x42
+	}
+	return len(dAtA) - i, nil
+}
+func (m *PersistentConnectionRequest_Pong) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PersistentConnectionRequest_Pong) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Pong != nil {
+		{
+			size, err := m.Pong.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x4a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *PersistentConnectionRequest_CancelAll) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PersistentConnectionRequest_CancelAll) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.CancelAll != nil {
+		{
+			size, err := m.CancelAll.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x52
+	}
+	return len(dAtA) - i, nil
+}
+func (m *PersistentConnectionRequest_StreamFrame) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PersistentConnectionRequest_StreamFrame) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.StreamFrame != nil {
+		{
+			size, err := m.StreamFrame.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x5a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *PersistentConnectionRequest_Pubsub) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PersistentConnectionRequest_Pubsub) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Pubsub != nil {
+		{
+			size, err := m.Pubsub.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x62
+	}
+	return len(dAtA) - i, nil
+}
+func (m *PersistentConnectionRequest_AddTopicValidator) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PersistentConnectionRequest_AddTopicValidator) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.AddTopicValidator != nil {
+		{
+			size, err := m.AddTopicValidator.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x6a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *PersistentConnectionRequest_ValidationResult) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PersistentConnectionRequest_ValidationResult) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.ValidationResult != nil {
+		{
+			size, err := m.ValidationResult.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x72
+	}
+	return len(dAtA) - i, nil
+}
+func (m *PersistentConnectionRequest_RemoveUnaryHandler) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PersistentConnectionRequest_RemoveUnaryHandler) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.RemoveUnaryHandler != nil {
+		{
+			size, err := m.RemoveUnaryHandler.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x7a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *PersistentConnectionRequest_Chunk) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PersistentConnectionRequest_Chunk) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Chunk != nil {
+		{
+			size, err := m.Chunk.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x82
+	}
+	return len(dAtA) - i, nil
+}
+func (m *PersistentConnectionResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PersistentConnectionResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PersistentConnectionResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Message != nil {
+		{
+			size := m.Message.Size()
+			i -= size
+			if _, err := m.Message.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if m.CallId == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("callId")
+	} else {
+		i -= len(m.CallId)
+		copy(dAtA[i:], m.CallId)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.CallId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PersistentConnectionResponse_CallUnaryResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PersistentConnectionResponse_CallUnaryResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.CallUnaryResponse != nil {
+		{
+			size, err := m.CallUnaryResponse.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	return len(dAtA) - i, nil
+}
+func (m *PersistentConnectionResponse_RequestHandling) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PersistentConnectionResponse_RequestHandling) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.RequestHandling != nil {
+		{
+			size, err := m.RequestHandling.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *PersistentConnectionResponse_DaemonError) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PersistentConnectionResponse_DaemonError) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.DaemonError != nil {
+		{
+			size, err := m.DaemonError.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	return len(dAtA) - i, nil
+}
+func (m *PersistentConnectionResponse_Cancel) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PersistentConnectionResponse_Cancel) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Cancel != nil {
+		{
+			size, err := m.Cancel.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *PersistentConnectionResponse_EndOfStream) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PersistentConnectionResponse_EndOfStream) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.EndOfStream != nil {
+		{
+			size, err := m.EndOfStream.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	return len(dAtA) - i, nil
+}
+func (m *PersistentConnectionResponse_Stats) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PersistentConnectionResponse_Stats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Stats != nil {
+		{
+			size, err := m.Stats.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *PersistentConnectionResponse_Event) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PersistentConnectionResponse_Event) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Event != nil {
+		{
+			size, err := m.Event.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x42
+	}
+	return len(dAtA) - i, nil
+}
+func (m *PersistentConnectionResponse_Ping) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PersistentConnectionResponse_Ping) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Ping != nil {
+		{
+			size, err := m.Ping.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x4a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *PersistentConnectionResponse_CancelAll) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PersistentConnectionResponse_CancelAll) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.CancelAll != nil {
+		{
+			size, err := m.CancelAll.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x52
+	}
+	return len(dAtA) - i, nil
+}
+func (m *PersistentConnectionResponse_IncomingStream) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PersistentConnectionResponse_IncomingStream) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.IncomingStream != nil {
+		{
+			size, err := m.IncomingStream.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x5a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *PersistentConnectionResponse_StreamFrame) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PersistentConnectionResponse_StreamFrame) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.StreamFrame != nil {
+		{
+			size, err := m.StreamFrame.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x62
+	}
+	return len(dAtA) - i, nil
+}
+func (m *PersistentConnectionResponse_PubsubMessage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PersistentConnectionResponse_PubsubMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.PubsubMessage != nil {
+		{
+			size, err := m.PubsubMessage.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x6a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *PersistentConnectionResponse_ValidateMessage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PersistentConnectionResponse_ValidateMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.ValidateMessage != nil {
+		{
+			size, err := m.ValidateMessage.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x72
+	}
+	return len(dAtA) - i, nil
+}
+func (m *PersistentConnectionResponse_Chunk) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PersistentConnectionResponse_Chunk) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Chunk != nil {
+		{
+			size, err := m.Chunk.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x7a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *IdentifyResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *IdentifyResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *IdentifyResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.SignedPeerRecord != nil {
+		i -= len(m.SignedPeerRecord)
+		copy(dAtA[i:], m.SignedPeerRecord)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.SignedPeerRecord)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Addrs) > 0 {
+		for iNdEx := len(m.Addrs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Addrs[iNdEx])
+			copy(dAtA[i:], m.Addrs[iNdEx])
+			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Addrs[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Id == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("id")
+	} else {
+		i -= len(m.Id)
+		copy(dAtA[i:], m.Id)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Id)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RotateIdentityRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RotateIdentityRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RotateIdentityRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.GracePeriodMs != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.GracePeriodMs))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.KeyType != nil {
+		i -= len(*m.KeyType)
+		copy(dAtA[i:], *m.KeyType)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.KeyType)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DaemonInfoResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DaemonInfoResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DaemonInfoResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.RelayHop == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("relayHop")
+	} else {
+		i--
+		if *m.RelayHop {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.Relay == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("relay")
+	} else {
+		i--
+		if *m.Relay {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.PubsubRouter != nil {
+		i -= len(*m.PubsubRouter)
+		copy(dAtA[i:], *m.PubsubRouter)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.PubsubRouter)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.DhtMode != nil {
+		i -= len(*m.DhtMode)
+		copy(dAtA[i:], *m.DhtMode)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.DhtMode)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.GoVersion == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("goVersion")
+	} else {
+		i -= len(*m.GoVersion)
+		copy(dAtA[i:], *m.GoVersion)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.GoVersion)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Version != nil {
+		i -= len(*m.Version)
+		copy(dAtA[i:], *m.Version)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Version)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Module != nil {
+		i -= len(*m.Module)
+		copy(dAtA[i:], *m.Module)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Module)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Uptime == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("uptime")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Uptime))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.StartTime == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("startTime")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.StartTime))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AutoNATRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AutoNATRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AutoNATRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Timeout != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Timeout))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Probe != nil {
+		i--
+		if *m.Probe {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AutoNATResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AutoNATResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AutoNATResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Probe != nil {
+		{
+			size, err := m.Probe.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Reachability == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("reachability")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Reachability))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AutoNATProbe) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AutoNATProbe) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AutoNATProbe) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Addr != nil {
+		i -= len(m.Addr)
+		copy(dAtA[i:], m.Addr)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Addr)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Peer == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	} else {
+		i -= len(m.Peer)
+		copy(dAtA[i:], m.Peer)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Reachability == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("reachability")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Reachability))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CallRecord) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CallRecord) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CallRecord) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Error != nil {
+		i -= len(*m.Error)
+		copy(dAtA[i:], *m.Error)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Error)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.Result == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("result")
+	} else {
+		i -= len(*m.Result)
+		copy(dAtA[i:], *m.Result)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Result)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Duration == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("duration")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Duration))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.StartTime == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("startTime")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.StartTime))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Proto == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("proto")
+	} else {
+		i -= len(*m.Proto)
+		copy(dAtA[i:], *m.Proto)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Proto)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Peer == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	} else {
+		i -= len(m.Peer)
+		copy(dAtA[i:], m.Peer)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ConnectRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConnectRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ConnectRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.SignedPeerRecord != nil {
+		i -= len(m.SignedPeerRecord)
+		copy(dAtA[i:], m.SignedPeerRecord)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.SignedPeerRecord)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.AddrTTL != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.AddrTTL))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Timeout != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Timeout))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Addrs) > 0 {
+		for iNdEx := len(m.Addrs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Addrs[iNdEx])
+			copy(dAtA[i:], m.Addrs[iNdEx])
+			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Addrs[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Peer == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	} else {
+		i -= len(m.Peer)
+		copy(dAtA[i:], m.Peer)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ConnectResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConnectResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ConnectResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.SignedPeerRecord != nil {
+		i -= len(m.SignedPeerRecord)
+		copy(dAtA[i:], m.SignedPeerRecord)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.SignedPeerRecord)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Addr == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("addr")
+	} else {
+		i -= len(m.Addr)
+		copy(dAtA[i:], m.Addr)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Addr)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PingRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PingRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PingRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Timeout != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Timeout))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Count != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Count))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Peer == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	} else {
+		i -= len(m.Peer)
+		copy(dAtA[i:], m.Peer)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PeerMetadataRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PeerMetadataRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PeerMetadataRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Timeout != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Timeout))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Peer == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	} else {
+		i -= len(m.Peer)
+		copy(dAtA[i:], m.Peer)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Metadata) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Metadata) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Metadata) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Entries) > 0 {
+		for iNdEx := len(m.Entries) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Entries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintP2Pd(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MetadataEntry) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MetadataEntry) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MetadataEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Value == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("value")
+	} else {
+		i -= len(*m.Value)
+		copy(dAtA[i:], *m.Value)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Value)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Key == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("key")
+	} else {
+		i -= len(*m.Key)
+		copy(dAtA[i:], *m.Key)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Key)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LogLevelRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LogLevelRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LogLevelRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Level != nil {
+		i -= len(*m.Level)
+		copy(dAtA[i:], *m.Level)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Level)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Subsystem != nil {
+		i -= len(*m.Subsystem)
+		copy(dAtA[i:], *m.Subsystem)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Subsystem)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SubsystemLogLevel) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SubsystemLogLevel) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SubsystemLogLevel) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Level == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("level")
+	} else {
+		i -= len(*m.Level)
+		copy(dAtA[i:], *m.Level)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Level)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Subsystem == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("subsystem")
+	} else {
+		i -= len(*m.Subsystem)
+		copy(dAtA[i:], *m.Subsystem)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Subsystem)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *FindPeerRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FindPeerRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FindPeerRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Timeout != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Timeout))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.SkipDHT != nil {
+		i--
+		if *m.SkipDHT {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Peer == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	} else {
+		i -= len(m.Peer)
+		copy(dAtA[i:], m.Peer)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RoutingTableRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RoutingTableRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RoutingTableRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Timeout != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Timeout))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Force != nil {
+		i--
+		if *m.Force {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Refresh != nil {
+		i--
+		if *m.Refresh {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Peers != nil {
+		i--
+		if *m.Peers {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RoutingTableBucket) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RoutingTableBucket) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RoutingTableBucket) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Peers) > 0 {
+		for iNdEx := len(m.Peers) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Peers[iNdEx])
+			copy(dAtA[i:], m.Peers[iNdEx])
+			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peers[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Cpl == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("cpl")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Cpl))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RoutingTableResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RoutingTableResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RoutingTableResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Buckets) > 0 {
+		for iNdEx := len(m.Buckets) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Buckets[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintP2Pd(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.PeerCount == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("peerCount")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.PeerCount))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SetBootstrapPeersRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetBootstrapPeersRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SetBootstrapPeersRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Bootstrap != nil {
+		i--
+		if *m.Bootstrap {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Addrs) > 0 {
+		for iNdEx := len(m.Addrs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Addrs[iNdEx])
+			copy(dAtA[i:], m.Addrs[iNdEx])
+			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Addrs[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PeerstoreRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PeerstoreRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PeerstoreRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Protos) > 0 {
+		for iNdEx := len(m.Protos) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Protos[iNdEx])
+			copy(dAtA[i:], m.Protos[iNdEx])
+			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Protos[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if m.Ttl != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Ttl))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Addrs) > 0 {
+		for iNdEx := len(m.Addrs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Addrs[iNdEx])
+			copy(dAtA[i:], m.Addrs[iNdEx])
+			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Addrs[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.Peer == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	} else {
+		i -= len(m.Peer)
+		copy(dAtA[i:], m.Peer)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Type == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Type))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PeerstoreResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PeerstoreResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PeerstoreResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Supported) > 0 {
+		for iNdEx := len(m.Supported) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Supported[iNdEx])
+			copy(dAtA[i:], m.Supported[iNdEx])
+			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Supported[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Protocols) > 0 {
+		for iNdEx := len(m.Protocols) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Protocols[iNdEx])
+			copy(dAtA[i:], m.Protocols[iNdEx])
+			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Protocols[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PeerListsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PeerListsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PeerListsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.BlockedPeers) > 0 {
+		for iNdEx := len(m.BlockedPeers) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.BlockedPeers[iNdEx])
+			copy(dAtA[i:], m.BlockedPeers[iNdEx])
+			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.BlockedPeers[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.AllowedPeers) > 0 {
+		for iNdEx := len(m.AllowedPeers) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.AllowedPeers[iNdEx])
+			copy(dAtA[i:], m.AllowedPeers[iNdEx])
+			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.AllowedPeers[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Type == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Type))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PeerListsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PeerListsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PeerListsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.BlockedPeers) > 0 {
+		for iNdEx := len(m.BlockedPeers) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.BlockedPeers[iNdEx])
+			copy(dAtA[i:], m.BlockedPeers[iNdEx])
+			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.BlockedPeers[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.AllowedPeers) > 0 {
+		for iNdEx := len(m.AllowedPeers) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.AllowedPeers[iNdEx])
+			copy(dAtA[i:], m.AllowedPeers[iNdEx])
+			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.AllowedPeers[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ResourceScope) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResourceScope) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResourceScope) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.StreamsOutbound == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("streamsOutbound")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.StreamsOutbound))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.StreamsInbound == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("streamsInbound")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.StreamsInbound))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.ConnsOutbound == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("connsOutbound")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.ConnsOutbound))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.ConnsInbound == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("connsInbound")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.ConnsInbound))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ProtocolResourceScope) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ProtocolResourceScope) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ProtocolResourceScope) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Usage == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("usage")
+	} else {
+		{
+			size, err := m.Usage.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Proto == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("proto")
+	} else {
+		i -= len(*m.Proto)
+		copy(dAtA[i:], *m.Proto)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Proto)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PeerResourceScope) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PeerResourceScope) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PeerResourceScope) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Usage == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("usage")
+	} else {
+		{
+			size, err := m.Usage.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Peer == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	} else {
+		i -= len(m.Peer)
+		copy(dAtA[i:], m.Peer)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ResourceUsageResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResourceUsageResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResourceUsageResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Peers) > 0 {
+		for iNdEx := len(m.Peers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Peers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintP2Pd(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Protocols) > 0 {
+		for iNdEx := len(m.Protocols) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Protocols[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintP2Pd(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.System == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("system")
+	} else {
+		{
+			size, err := m.System.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BandwidthRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BandwidthRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BandwidthRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.ByProtocol != nil {
+		i--
+		if *m.ByProtocol {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Peer != nil {
+		i -= len(m.Peer)
+		copy(dAtA[i:], m.Peer)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ByPeer != nil {
+		i--
+		if *m.ByPeer {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BandwidthStats) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BandwidthStats) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BandwidthStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.RateOut == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("rateOut")
+	} else {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(*m.RateOut))))
+		i--
+		dAtA[i] = 0x21
+	}
+	if m.RateIn == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("rateIn")
+	} else {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(*m.RateIn))))
+		i--
+		dAtA[i] = 0x19
+	}
+	if m.TotalOut == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("totalOut")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.TotalOut))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.TotalIn == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("totalIn")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.TotalIn))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PeerBandwidth) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PeerBandwidth) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PeerBandwidth) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Stats == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("stats")
+	} else {
+		{
+			size, err := m.Stats.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Peer == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	} else {
+		i -= len(m.Peer)
+		copy(dAtA[i:], m.Peer)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ProtocolBandwidth) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ProtocolBandwidth) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ProtocolBandwidth) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Stats == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("stats")
+	} else {
+		{
+			size, err := m.Stats.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Proto == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("proto")
+	} else {
+		i -= len(*m.Proto)
+		copy(dAtA[i:], *m.Proto)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Proto)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BandwidthResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BandwidthResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BandwidthResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Protocols) > 0 {
+		for iNdEx := len(m.Protocols) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Protocols[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintP2Pd(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Peers) > 0 {
+		for iNdEx := len(m.Peers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Peers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintP2Pd(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Total == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("total")
+	} else {
+		{
+			size, err := m.Total.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AddrsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AddrsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AddrsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Relay) > 0 {
+		for iNdEx := len(m.Relay) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Relay[iNdEx])
+			copy(dAtA[i:], m.Relay[iNdEx])
+			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Relay[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Observed) > 0 {
+		for iNdEx := len(m.Observed) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Observed[iNdEx])
+			copy(dAtA[i:], m.Observed[iNdEx])
+			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Observed[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Listen) > 0 {
+		for iNdEx := len(m.Listen) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Listen[iNdEx])
+			copy(dAtA[i:], m.Listen[iNdEx])
+			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Listen[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RelayInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RelayInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RelayInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Connected == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("connected")
+	} else {
+		i--
+		if *m.Connected {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Addrs) > 0 {
+		for iNdEx := len(m.Addrs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Addrs[iNdEx])
+			copy(dAtA[i:], m.Addrs[iNdEx])
+			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Addrs[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Peer == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	} else {
+		i -= len(m.Peer)
+		copy(dAtA[i:], m.Peer)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PingResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PingResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PingResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Max == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("max")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Max))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Avg == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("avg")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Avg))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Min == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("min")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Min))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Rtts) > 0 {
+		for iNdEx := len(m.Rtts) - 1; iNdEx >= 0; iNdEx-- {
+			i = encodeVarintP2Pd(dAtA, i, uint64(m.Rtts[iNdEx]))
+			i--
+			dAtA[i] = 0x8
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StreamOpenRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StreamOpenRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StreamOpenRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Framed != nil {
+		i--
+		if *m.Framed {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Timeout != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Timeout))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Proto) > 0 {
+		for iNdEx := len(m.Proto) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Proto[iNdEx])
+			copy(dAtA[i:], m.Proto[iNdEx])
+			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Proto[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Peer == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	} else {
+		i -= len(m.Peer)
+		copy(dAtA[i:], m.Peer)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StreamFrame) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StreamFrame) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StreamFrame) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Ack != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Ack))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Error != nil {
+		i -= len(*m.Error)
+		copy(dAtA[i:], *m.Error)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Error)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.CloseWrite != nil {
+		i--
+		if *m.CloseWrite {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Data != nil {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PayloadChunk) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PayloadChunk) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PayloadChunk) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Last != nil {
+		i--
+		if *m.Last {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Data != nil {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Seq == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("seq")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Seq))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StreamHandlerRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StreamHandlerRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StreamHandlerRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Proto) > 0 {
+		for iNdEx := len(m.Proto) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Proto[iNdEx])
+			copy(dAtA[i:], m.Proto[iNdEx])
+			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Proto[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Addr == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("addr")
+	} else {
+		i -= len(m.Addr)
+		copy(dAtA[i:], m.Addr)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Addr)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ErrorResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ErrorResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ErrorResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.DialAttempts) > 0 {
+		for iNdEx := len(m.DialAttempts) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.DialAttempts[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintP2Pd(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Msg == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("msg")
+	} else {
+		i -= len(*m.Msg)
+		copy(dAtA[i:], *m.Msg)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Msg)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DialAttempt) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DialAttempt) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DialAttempt) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Reason != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Reason))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Error == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("error")
+	} else {
+		i -= len(*m.Error)
+		copy(dAtA[i:], *m.Error)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Error)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Addr == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("addr")
+	} else {
+		i -= len(m.Addr)
+		copy(dAtA[i:], m.Addr)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Addr)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StreamInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StreamInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StreamInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Window != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Window))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Proto == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("proto")
+	} else {
+		i -= len(*m.Proto)
+		copy(dAtA[i:], *m.Proto)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Proto)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Addr == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("addr")
+	} else {
+		i -= len(m.Addr)
+		copy(dAtA[i:], m.Addr)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Addr)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Peer == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	} else {
+		i -= len(m.Peer)
+		copy(dAtA[i:], m.Peer)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DHTRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DHTRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DHTRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Announce != nil {
+		i--
+		if *m.Announce {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.Quorum != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Quorum))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.Timeout != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Timeout))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.Count != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Count))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.Value != nil {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Key != nil {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Cid != nil {
+		i -= len(m.Cid)
+		copy(dAtA[i:], m.Cid)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Cid)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Peer != nil {
+		i -= len(m.Peer)
+		copy(dAtA[i:], m.Peer)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Type == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Type))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DHTResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DHTResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DHTResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Value != nil {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Peer != nil {
+		{
+			size, err := m.Peer.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Type == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Type))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PeerInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PeerInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PeerInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Addrs) > 0 {
+		for iNdEx := len(m.Addrs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Addrs[iNdEx])
+			copy(dAtA[i:], m.Addrs[iNdEx])
+			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Addrs[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Id == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("id")
+	} else {
+		i -= len(m.Id)
+		copy(dAtA[i:], m.Id)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Id)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ConnectedPeer) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConnectedPeer) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ConnectedPeer) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Conns) > 0 {
+		for iNdEx := len(m.Conns) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Conns[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintP2Pd(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Id == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("id")
+	} else {
+		i -= len(m.Id)
+		copy(dAtA[i:], m.Id)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Id)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ConnectionInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConnectionInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ConnectionInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Security != nil {
+		i -= len(*m.Security)
+		copy(dAtA[i:], *m.Security)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Security)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Relayed == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("relayed")
+	} else {
+		i--
+		if *m.Relayed {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Direction == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("direction")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Direction))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Addr == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("addr")
+	} else {
+		i -= len(m.Addr)
+		copy(dAtA[i:], m.Addr)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Addr)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ConnManagerRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConnManagerRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ConnManagerRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.GracePeriod != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.GracePeriod))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.HighWaterMark != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.HighWaterMark))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.LowWaterMark != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.LowWaterMark))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Weight != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Weight))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Tag != nil {
+		i -= len(*m.Tag)
+		copy(dAtA[i:], *m.Tag)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Tag)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Peer != nil {
+		i -= len(m.Peer)
+		copy(dAtA[i:], m.Peer)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Type == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Type))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DisconnectRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DisconnectRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DisconnectRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.ForgetAddrs != nil {
+		i--
+		if *m.ForgetAddrs {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Peer == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	} else {
+		i -= len(m.Peer)
+		copy(dAtA[i:], m.Peer)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DisconnectResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DisconnectResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DisconnectResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.ClosedConns == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("closedConns")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.ClosedConns))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PSRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PSRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PSRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Data != nil {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Topic != nil {
+		i -= len(*m.Topic)
+		copy(dAtA[i:], *m.Topic)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Topic)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Type == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Type))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PSMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PSMessage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PSMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Key != nil {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.Signature != nil {
+		i -= len(m.Signature)
+		copy(dAtA[i:], m.Signature)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Signature)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.TopicIDs) > 0 {
+		for iNdEx := len(m.TopicIDs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.TopicIDs[iNdEx])
+			copy(dAtA[i:], m.TopicIDs[iNdEx])
+			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.TopicIDs[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if m.Seqno != nil {
+		i -= len(m.Seqno)
+		copy(dAtA[i:], m.Seqno)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Seqno)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Data != nil {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.From != nil {
+		i -= len(m.From)
+		copy(dAtA[i:], m.From)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.From)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AddTopicValidatorRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AddTopicValidatorRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AddTopicValidatorRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.TimeoutMs != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.TimeoutMs))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Topic == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("topic")
+	} else {
+		i -= len(*m.Topic)
+		copy(dAtA[i:], *m.Topic)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Topic)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ValidateMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ValidateMessage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ValidateMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Message == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("message")
+	} else {
+		{
+			size, err := m.Message.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Topic == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("topic")
+	} else {
+		i -= len(*m.Topic)
+		copy(dAtA[i:], *m.Topic)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Topic)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ValidationResult) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ValidationResult) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ValidationResult) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Result == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("result")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Result))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PSResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PSResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PSResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.PeerIDs) > 0 {
+		for iNdEx := len(m.PeerIDs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.PeerIDs[iNdEx])
+			copy(dAtA[i:], m.PeerIDs[iNdEx])
+			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.PeerIDs[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Topics) > 0 {
+		for iNdEx := len(m.Topics) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Topics[iNdEx])
+			copy(dAtA[i:], m.Topics[iNdEx])
+			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Topics[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CallUnaryRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CallUnaryRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CallUnaryRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.ChunkedSize != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.ChunkedSize))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.TraceState != nil {
+		i -= len(*m.TraceState)
+		copy(dAtA[i:], *m.TraceState)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.TraceState)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.TraceParent != nil {
+		i -= len(*m.TraceParent)
+		copy(dAtA[i:], *m.TraceParent)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.TraceParent)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.TimeoutMs != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.TimeoutMs))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Data == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("data")
+	} else {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Proto == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("proto")
+	} else {
+		i -= len(*m.Proto)
+		copy(dAtA[i:], *m.Proto)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Proto)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Peer == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	} else {
+		i -= len(m.Peer)
+		copy(dAtA[i:], m.Peer)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CallUnaryResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CallUnaryResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CallUnaryResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.ChunkedSize != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.ChunkedSize))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Result != nil {
+		{
+			size := m.Result.Size()
+			i -= size
+			if _, err := m.Result.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CallUnaryResponse_Response) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CallUnaryResponse_Response) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Response != nil {
+		i -= len(m.Response)
+		copy(dAtA[i:], m.Response)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Response)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+func (m *CallUnaryResponse_Error) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CallUnaryResponse_Error) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Error != nil {
+		i -= len(m.Error)
+		copy(dAtA[i:], m.Error)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Error)))
+		i--
+		dAtA[i] = 0x12
+	}
+	return len(dAtA) - i, nil
+}
+func (m *AddUnaryHandlerRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AddUnaryHandlerRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AddUnaryHandlerRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Window != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Window))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.Raw != nil {
+		i--
+		if *m.Raw {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Replace != nil {
+		i--
+		if *m.Replace {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.OverflowPolicy != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.OverflowPolicy))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.MaxConcurrentCalls != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.MaxConcurrentCalls))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Proto == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("proto")
+	} else {
+		i -= len(*m.Proto)
+		copy(dAtA[i:], *m.Proto)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Proto)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RemoveUnaryHandlerRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RemoveUnaryHandlerRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RemoveUnaryHandlerRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Proto == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("proto")
+	} else {
+		i -= len(*m.Proto)
+		copy(dAtA[i:], *m.Proto)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Proto)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DaemonError) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DaemonError) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DaemonError) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Code != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Code))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Message != nil {
+		i -= len(*m.Message)
+		copy(dAtA[i:], *m.Message)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Message)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Cancel) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Cancel) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Cancel) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CancelAll) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CancelAll) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CancelAll) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CancelAllResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CancelAllResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CancelAllResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Cancelled == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("cancelled")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Cancelled))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *EndOfStream) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EndOfStream) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *EndOfStream) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Ping) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Ping) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Ping) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Pong) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Pong) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Pong) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetStatsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetStatsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetStatsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *UnaryHandlerInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UnaryHandlerInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UnaryHandlerInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Enabled == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("enabled")
+	} else {
+		i--
+		if *m.Enabled {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Proto == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("proto")
+	} else {
+		i -= len(*m.Proto)
+		copy(dAtA[i:], *m.Proto)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Proto)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StatsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StatsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StatsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.PendingResponses == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("pendingResponses")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.PendingResponses))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.ActiveCalls == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("activeCalls")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.ActiveCalls))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.UnaryHandlers) > 0 {
+		for iNdEx := len(m.UnaryHandlers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.UnaryHandlers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintP2Pd(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SubscribeEventsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SubscribeEventsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SubscribeEventsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Types) > 0 {
+		for iNdEx := len(m.Types) - 1; iNdEx >= 0; iNdEx-- {
+			i = encodeVarintP2Pd(dAtA, i, uint64(m.Types[iNdEx]))
+			i--
+			dAtA[i] = 0x8
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Event) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Event) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Event) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Addr != nil {
+		i -= len(m.Addr)
+		copy(dAtA[i:], m.Addr)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Addr)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.Addrs) > 0 {
+		for iNdEx := len(m.Addrs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Addrs[iNdEx])
+			copy(dAtA[i:], m.Addrs[iNdEx])
+			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Addrs[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if m.Connectedness != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Connectedness))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Peer != nil {
+		i -= len(m.Peer)
+		copy(dAtA[i:], m.Peer)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Reachability != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Reachability))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Type == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Type))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintP2Pd(dAtA []byte, offset int, v uint64) int {
+	offset -= sovP2Pd(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Request) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != nil {
+		n += 1 + sovP2Pd(uint64(*m.Type))
+	}
+	if m.Connect != nil {
+		l = m.Connect.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.StreamOpen != nil {
+		l = m.StreamOpen.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.StreamHandler != nil {
+		l = m.StreamHandler.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Dht != nil {
+		l = m.Dht.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.ConnManager != nil {
+		l = m.ConnManager.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Disconnect != nil {
+		l = m.Disconnect.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Pubsub != nil {
+		l = m.Pubsub.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Ping != nil {
+		l = m.Ping.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.SetBootstrapPeers != nil {
+		l = m.SetBootstrapPeers.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.PeerLists != nil {
+		l = m.PeerLists.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Bandwidth != nil {
+		l = m.Bandwidth.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.FindPeer != nil {
+		l = m.FindPeer.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.RoutingTable != nil {
+		l = m.RoutingTable.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.RotateIdentity != nil {
+		l = m.RotateIdentity.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.AutoNAT != nil {
+		l = m.AutoNAT.Size()
+		n += 2 + l + sovP2Pd(uint64(l))
+	}
+	if m.Peerstore != nil {
+		l = m.Peerstore.Size()
+		n += 2 + l + sovP2Pd(uint64(l))
+	}
+	if m.PeerMetadata != nil {
+		l = m.PeerMetadata.Size()
+		n += 2 + l + sovP2Pd(uint64(l))
+	}
+	if m.LogLevel != nil {
+		l = m.LogLevel.Size()
+		n += 2 + l + sovP2Pd(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Response) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != nil {
+		n += 1 + sovP2Pd(uint64(*m.Type))
+	}
+	if m.Error != nil {
+		l = m.Error.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.StreamInfo != nil {
+		l = m.StreamInfo.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Identify != nil {
+		l = m.Identify.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Dht != nil {
+		l = m.Dht.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if len(m.Peers) > 0 {
+		for _, e := range m.Peers {
+			l = e.Size()
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.Pubsub != nil {
+		l = m.Pubsub.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if len(m.ConnectedPeers) > 0 {
+		for _, e := range m.ConnectedPeers {
+			l = e.Size()
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.Ping != nil {
+		l = m.Ping.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.PeerLists != nil {
+		l = m.PeerLists.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.ResourceUsage != nil {
+		l = m.ResourceUsage.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if len(m.Relays) > 0 {
+		for _, e := range m.Relays {
+			l = e.Size()
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.Connect != nil {
+		l = m.Connect.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Bandwidth != nil {
+		l = m.Bandwidth.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Peer != nil {
+		l = m.Peer.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.InstanceId != nil {
+		l = len(m.InstanceId)
+		n += 2 + l + sovP2Pd(uint64(l))
+	}
+	if m.RoutingTable != nil {
+		l = m.RoutingTable.Size()
+		n += 2 + l + sovP2Pd(uint64(l))
+	}
+	if m.Disconnect != nil {
+		l = m.Disconnect.Size()
+		n += 2 + l + sovP2Pd(uint64(l))
+	}
+	if m.DaemonInfo != nil {
+		l = m.DaemonInfo.Size()
+		n += 2 + l + sovP2Pd(uint64(l))
+	}
+	if m.AutoNAT != nil {
+		l = m.AutoNAT.Size()
+		n += 2 + l + sovP2Pd(uint64(l))
+	}
+	if len(m.CallHistory) > 0 {
+		for _, e := range m.CallHistory {
+			l = e.Size()
+			n += 2 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.Addrs != nil {
+		l = m.Addrs.Size()
+		n += 2 + l + sovP2Pd(uint64(l))
+	}
+	if m.Peerstore != nil {
+		l = m.Peerstore.Size()
+		n += 2 + l + sovP2Pd(uint64(l))
+	}
+	if m.Metadata != nil {
+		l = m.Metadata.Size()
+		n += 2 + l + sovP2Pd(uint64(l))
+	}
+	if len(m.LogLevels) > 0 {
+		for _, e := range m.LogLevels {
+			l = e.Size()
+			n += 2 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PersistentConnectionRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CallId != nil {
+		l = len(m.CallId)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Message != nil {
+		n += m.Message.Size()
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PersistentConnectionRequest_AddUnaryHandler) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.AddUnaryHandler != nil {
+		l = m.AddUnaryHandler.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	return n
+}
+func (m *PersistentConnectionRequest_CallUnary) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CallUnary != nil {
+		l = m.CallUnary.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	return n
+}
+func (m *PersistentConnectionRequest_UnaryResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.UnaryResponse != nil {
+		l = m.UnaryResponse.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	return n
+}
+func (m *PersistentConnectionRequest_Cancel) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Cancel != nil {
+		l = m.Cancel.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	return n
+}
+func (m *PersistentConnectionRequest_CallStream) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CallStream != nil {
+		l = m.CallStream.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	return n
+}
+func (m *PersistentConnectionRequest_GetStats) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.GetStats != nil {
+		l = m.GetStats.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	return n
+}
+func (m *PersistentConnectionRequest_SubscribeEvents) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SubscribeEvents != nil {
+		l = m.SubscribeEvents.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	return n
+}
+func (m *PersistentConnectionRequest_Pong) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Pong != nil {
+		l = m.Pong.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	return n
+}
+func (m *PersistentConnectionRequest_CancelAll) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CancelAll != nil {
+		l = m.CancelAll.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	return n
+}
+func (m *PersistentConnectionRequest_StreamFrame) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.StreamFrame != nil {
+		l = m.StreamFrame.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	return n
+}
+func (m *PersistentConnectionRequest_Pubsub) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Pubsub != nil {
+		l = m.Pubsub.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	return n
+}
+func (m *PersistentConnectionRequest_AddTopicValidator) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.AddTopicValidator != nil {
+		l = m.AddTopicValidator.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	return n
+}
+func (m *PersistentConnectionRequest_ValidationResult) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ValidationResult != nil {
+		l = m.ValidationResult.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	return n
+}
+func (m *PersistentConnectionRequest_RemoveUnaryHandler) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.RemoveUnaryHandler != nil {
+		l = m.RemoveUnaryHandler.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	return n
+}
+func (m *PersistentConnectionRequest_Chunk) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Chunk != nil {
+		l = m.Chunk.Size()
+		n += 2 + l + sovP2Pd(uint64(l))
+	}
+	return n
+}
+func (m *PersistentConnectionResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CallId != nil {
+		l = len(m.CallId)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Message != nil {
+		n += m.Message.Size()
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PersistentConnectionResponse_CallUnaryResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CallUnaryResponse != nil {
+		l = m.CallUnaryResponse.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	return n
+}
+func (m *PersistentConnectionResponse_RequestHandling) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.RequestHandling != nil {
+		l = m.RequestHandling.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	return n
+}
+func (m *PersistentConnectionResponse_DaemonError) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.DaemonError != nil {
+		l = m.DaemonError.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	return n
+}
+func (m *PersistentConnectionResponse_Cancel) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Cancel != nil {
+		l = m.Cancel.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	return n
+}
+func (m *PersistentConnectionResponse_EndOfStream) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.EndOfStream != nil {
+		l = m.EndOfStream.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	return n
+}
+func (m *PersistentConnectionResponse_Stats) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Stats != nil {
+		l = m.Stats.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	return n
+}
+func (m *PersistentConnectionResponse_Event) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Event != nil {
+		l = m.Event.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	return n
+}
+func (m *PersistentConnectionResponse_Ping) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Ping != nil {
+		l = m.Ping.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	return n
+}
+func (m *PersistentConnectionResponse_CancelAll) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CancelAll != nil {
+		l = m.CancelAll.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	return n
+}
+func (m *PersistentConnectionResponse_IncomingStream) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.IncomingStream != nil {
+		l = m.IncomingStream.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	return n
+}
+func (m *PersistentConnectionResponse_StreamFrame) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.StreamFrame != nil {
+		l = m.StreamFrame.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	return n
+}
+func (m *PersistentConnectionResponse_PubsubMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PubsubMessage != nil {
+		l = m.PubsubMessage.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	return n
+}
+func (m *PersistentConnectionResponse_ValidateMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ValidateMessage != nil {
+		l = m.ValidateMessage.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	return n
+}
+func (m *PersistentConnectionResponse_Chunk) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Chunk != nil {
+		l = m.Chunk.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	return n
+}
+func (m *IdentifyResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Id != nil {
+		l = len(m.Id)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if len(m.Addrs) > 0 {
+		for _, b := range m.Addrs {
+			l = len(b)
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.SignedPeerRecord != nil {
+		l = len(m.SignedPeerRecord)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RotateIdentityRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.KeyType != nil {
+		l = len(*m.KeyType)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.GracePeriodMs != nil {
+		n += 1 + sovP2Pd(uint64(*m.GracePeriodMs))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DaemonInfoResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.StartTime != nil {
+		n += 1 + sovP2Pd(uint64(*m.StartTime))
+	}
+	if m.Uptime != nil {
+		n += 1 + sovP2Pd(uint64(*m.Uptime))
+	}
+	if m.Module != nil {
+		l = len(*m.Module)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Version != nil {
+		l = len(*m.Version)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.GoVersion != nil {
+		l = len(*m.GoVersion)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.DhtMode != nil {
+		l = len(*m.DhtMode)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.PubsubRouter != nil {
+		l = len(*m.PubsubRouter)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Relay != nil {
+		n += 2
+	}
+	if m.RelayHop != nil {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *AutoNATRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Probe != nil {
+		n += 2
+	}
+	if m.Timeout != nil {
+		n += 1 + sovP2Pd(uint64(*m.Timeout))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *AutoNATResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Reachability != nil {
+		n += 1 + sovP2Pd(uint64(*m.Reachability))
+	}
+	if m.Probe != nil {
+		l = m.Probe.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *AutoNATProbe) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Reachability != nil {
+		n += 1 + sovP2Pd(uint64(*m.Reachability))
+	}
+	if m.Peer != nil {
+		l = len(m.Peer)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Addr != nil {
+		l = len(m.Addr)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CallRecord) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Peer != nil {
+		l = len(m.Peer)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Proto != nil {
+		l = len(*m.Proto)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.StartTime != nil {
+		n += 1 + sovP2Pd(uint64(*m.StartTime))
+	}
+	if m.Duration != nil {
+		n += 1 + sovP2Pd(uint64(*m.Duration))
+	}
+	if m.Result != nil {
+		l = len(*m.Result)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Error != nil {
+		l = len(*m.Error)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ConnectRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Peer != nil {
+		l = len(m.Peer)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if len(m.Addrs) > 0 {
+		for _, b := range m.Addrs {
+			l = len(b)
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.Timeout != nil {
+		n += 1 + sovP2Pd(uint64(*m.Timeout))
+	}
+	if m.AddrTTL != nil {
+		n += 1 + sovP2Pd(uint64(*m.AddrTTL))
+	}
+	if m.SignedPeerRecord != nil {
+		l = len(m.SignedPeerRecord)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ConnectResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Addr != nil {
+		l = len(m.Addr)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.SignedPeerRecord != nil {
+		l = len(m.SignedPeerRecord)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PingRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Peer != nil {
+		l = len(m.Peer)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Count != nil {
+		n += 1 + sovP2Pd(uint64(*m.Count))
+	}
+	if m.Timeout != nil {
+		n += 1 + sovP2Pd(uint64(*m.Timeout))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PeerMetadataRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Peer != nil {
+		l = len(m.Peer)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Timeout != nil {
+		n += 1 + sovP2Pd(uint64(*m.Timeout))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Metadata) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Entries) > 0 {
+		for _, e := range m.Entries {
+			l = e.Size()
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *MetadataEntry) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Key != nil {
+		l = len(*m.Key)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Value != nil {
+		l = len(*m.Value)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *LogLevelRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Subsystem != nil {
+		l = len(*m.Subsystem)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Level != nil {
+		l = len(*m.Level)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SubsystemLogLevel) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Subsystem != nil {
+		l = len(*m.Subsystem)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Level != nil {
+		l = len(*m.Level)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *FindPeerRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Peer != nil {
+		l = len(m.Peer)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.SkipDHT != nil {
+		n += 2
+	}
+	if m.Timeout != nil {
+		n += 1 + sovP2Pd(uint64(*m.Timeout))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RoutingTableRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Peers != nil {
+		n += 2
+	}
+	if m.Refresh != nil {
+		n += 2
+	}
+	if m.Force != nil {
+		n += 2
+	}
+	if m.Timeout != nil {
+		n += 1 + sovP2Pd(uint64(*m.Timeout))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RoutingTableBucket) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Cpl != nil {
+		n += 1 + sovP2Pd(uint64(*m.Cpl))
+	}
+	if len(m.Peers) > 0 {
+		for _, b := range m.Peers {
+			l = len(b)
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RoutingTableResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PeerCount != nil {
+		n += 1 + sovP2Pd(uint64(*m.PeerCount))
+	}
+	if len(m.Buckets) > 0 {
+		for _, e := range m.Buckets {
+			l = e.Size()
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SetBootstrapPeersRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Addrs) > 0 {
+		for _, b := range m.Addrs {
+			l = len(b)
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.Bootstrap != nil {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PeerstoreRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != nil {
+		n += 1 + sovP2Pd(uint64(*m.Type))
+	}
+	if m.Peer != nil {
+		l = len(m.Peer)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if len(m.Addrs) > 0 {
+		for _, b := range m.Addrs {
+			l = len(b)
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.Ttl != nil {
+		n += 1 + sovP2Pd(uint64(*m.Ttl))
+	}
+	if len(m.Protos) > 0 {
+		for _, s := range m.Protos {
+			l = len(s)
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PeerstoreResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Protocols) > 0 {
+		for _, s := range m.Protocols {
+			l = len(s)
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if len(m.Supported) > 0 {
+		for _, s := range m.Supported {
+			l = len(s)
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PeerListsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != nil {
+		n += 1 + sovP2Pd(uint64(*m.Type))
+	}
+	if len(m.AllowedPeers) > 0 {
+		for _, b := range m.AllowedPeers {
+			l = len(b)
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if len(m.BlockedPeers) > 0 {
+		for _, b := range m.BlockedPeers {
+			l = len(b)
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PeerListsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.AllowedPeers) > 0 {
+		for _, b := range m.AllowedPeers {
+			l = len(b)
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if len(m.BlockedPeers) > 0 {
+		for _, b := range m.BlockedPeers {
+			l = len(b)
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ResourceScope) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ConnsInbound != nil {
+		n += 1 + sovP2Pd(uint64(*m.ConnsInbound))
+	}
+	if m.ConnsOutbound != nil {
+		n += 1 + sovP2Pd(uint64(*m.ConnsOutbound))
+	}
+	if m.StreamsInbound != nil {
+		n += 1 + sovP2Pd(uint64(*m.StreamsInbound))
+	}
+	if m.StreamsOutbound != nil {
+		n += 1 + sovP2Pd(uint64(*m.StreamsOutbound))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ProtocolResourceScope) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Proto != nil {
+		l = len(*m.Proto)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Usage != nil {
+		l = m.Usage.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PeerResourceScope) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Peer != nil {
+		l = len(m.Peer)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Usage != nil {
+		l = m.Usage.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ResourceUsageResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.System != nil {
+		l = m.System.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if len(m.Protocols) > 0 {
+		for _, e := range m.Protocols {
+			l = e.Size()
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if len(m.Peers) > 0 {
+		for _, e := range m.Peers {
+			l = e.Size()
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *BandwidthRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ByPeer != nil {
+		n += 2
+	}
+	if m.Peer != nil {
+		l = len(m.Peer)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.ByProtocol != nil {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *BandwidthStats) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.TotalIn != nil {
+		n += 1 + sovP2Pd(uint64(*m.TotalIn))
+	}
+	if m.TotalOut != nil {
+		n += 1 + sovP2Pd(uint64(*m.TotalOut))
+	}
+	if m.RateIn != nil {
+		n += 9
+	}
+	if m.RateOut != nil {
+		n += 9
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PeerBandwidth) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Peer != nil {
+		l = len(m.Peer)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Stats != nil {
+		l = m.Stats.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ProtocolBandwidth) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Proto != nil {
+		l = len(*m.Proto)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Stats != nil {
+		l = m.Stats.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *BandwidthResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Total != nil {
+		l = m.Total.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if len(m.Peers) > 0 {
+		for _, e := range m.Peers {
+			l = e.Size()
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if len(m.Protocols) > 0 {
+		for _, e := range m.Protocols {
+			l = e.Size()
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *AddrsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Listen) > 0 {
+		for _, b := range m.Listen {
+			l = len(b)
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if len(m.Observed) > 0 {
+		for _, b := range m.Observed {
+			l = len(b)
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if len(m.Relay) > 0 {
+		for _, b := range m.Relay {
+			l = len(b)
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RelayInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Peer != nil {
+		l = len(m.Peer)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if len(m.Addrs) > 0 {
+		for _, b := range m.Addrs {
+			l = len(b)
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.Connected != nil {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PingResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Rtts) > 0 {
+		for _, e := range m.Rtts {
+			n += 1 + sovP2Pd(uint64(e))
+		}
+	}
+	if m.Min != nil {
+		n += 1 + sovP2Pd(uint64(*m.Min))
+	}
+	if m.Avg != nil {
+		n += 1 + sovP2Pd(uint64(*m.Avg))
+	}
+	if m.Max != nil {
+		n += 1 + sovP2Pd(uint64(*m.Max))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *StreamOpenRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Peer != nil {
+		l = len(m.Peer)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if len(m.Proto) > 0 {
+		for _, s := range m.Proto {
+			l = len(s)
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.Timeout != nil {
+		n += 1 + sovP2Pd(uint64(*m.Timeout))
+	}
+	if m.Framed != nil {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *StreamFrame) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Data != nil {
+		l = len(m.Data)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.CloseWrite != nil {
+		n += 2
+	}
+	if m.Error != nil {
+		l = len(*m.Error)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Ack != nil {
+		n += 1 + sovP2Pd(uint64(*m.Ack))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PayloadChunk) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Seq != nil {
+		n += 1 + sovP2Pd(uint64(*m.Seq))
+	}
+	if m.Data != nil {
+		l = len(m.Data)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Last != nil {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *StreamHandlerRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Addr != nil {
+		l = len(m.Addr)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if len(m.Proto) > 0 {
+		for _, s := range m.Proto {
+			l = len(s)
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ErrorResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Msg != nil {
+		l = len(*m.Msg)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if len(m.DialAttempts) > 0 {
+		for _, e := range m.DialAttempts {
+			l = e.Size()
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DialAttempt) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Addr != nil {
+		l = len(m.Addr)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Error != nil {
+		l = len(*m.Error)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Reason != nil {
+		n += 1 + sovP2Pd(uint64(*m.Reason))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *StreamInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Peer != nil {
+		l = len(m.Peer)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Addr != nil {
+		l = len(m.Addr)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Proto != nil {
+		l = len(*m.Proto)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Window != nil {
+		n += 1 + sovP2Pd(uint64(*m.Window))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DHTRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != nil {
+		n += 1 + sovP2Pd(uint64(*m.Type))
+	}
+	if m.Peer != nil {
+		l = len(m.Peer)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Cid != nil {
+		l = len(m.Cid)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Key != nil {
+		l = len(m.Key)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Value != nil {
+		l = len(m.Value)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Count != nil {
+		n += 1 + sovP2Pd(uint64(*m.Count))
+	}
+	if m.Timeout != nil {
+		n += 1 + sovP2Pd(uint64(*m.Timeout))
+	}
+	if m.Quorum != nil {
+		n += 1 + sovP2Pd(uint64(*m.Quorum))
+	}
+	if m.Announce != nil {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DHTResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != nil {
+		n += 1 + sovP2Pd(uint64(*m.Type))
+	}
+	if m.Peer != nil {
+		l = m.Peer.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Value != nil {
+		l = len(m.Value)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PeerInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Id != nil {
+		l = len(m.Id)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if len(m.Addrs) > 0 {
+		for _, b := range m.Addrs {
+			l = len(b)
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ConnectedPeer) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Id != nil {
+		l = len(m.Id)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if len(m.Conns) > 0 {
+		for _, e := range m.Conns {
+			l = e.Size()
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ConnectionInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Addr != nil {
+		l = len(m.Addr)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Direction != nil {
+		n += 1 + sovP2Pd(uint64(*m.Direction))
+	}
+	if m.Relayed != nil {
+		n += 2
+	}
+	if m.Security != nil {
+		l = len(*m.Security)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ConnManagerRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != nil {
+		n += 1 + sovP2Pd(uint64(*m.Type))
+	}
+	if m.Peer != nil {
+		l = len(m.Peer)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Tag != nil {
+		l = len(*m.Tag)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Weight != nil {
+		n += 1 + sovP2Pd(uint64(*m.Weight))
+	}
+	if m.LowWaterMark != nil {
+		n += 1 + sovP2Pd(uint64(*m.LowWaterMark))
+	}
+	if m.HighWaterMark != nil {
+		n += 1 + sovP2Pd(uint64(*m.HighWaterMark))
+	}
+	if m.GracePeriod != nil {
+		n += 1 + sovP2Pd(uint64(*m.GracePeriod))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DisconnectRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Peer != nil {
+		l = len(m.Peer)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.ForgetAddrs != nil {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DisconnectResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ClosedConns != nil {
+		n += 1 + sovP2Pd(uint64(*m.ClosedConns))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PSRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != nil {
+		n += 1 + sovP2Pd(uint64(*m.Type))
+	}
+	if m.Topic != nil {
+		l = len(*m.Topic)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Data != nil {
+		l = len(m.Data)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PSMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.From != nil {
+		l = len(m.From)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Data != nil {
+		l = len(m.Data)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Seqno != nil {
+		l = len(m.Seqno)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if len(m.TopicIDs) > 0 {
+		for _, s := range m.TopicIDs {
+			l = len(s)
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.Signature != nil {
+		l = len(m.Signature)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Key != nil {
+		l = len(m.Key)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *AddTopicValidatorRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Topic != nil {
+		l = len(*m.Topic)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.TimeoutMs != nil {
+		n += 1 + sovP2Pd(uint64(*m.TimeoutMs))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ValidateMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Topic != nil {
+		l = len(*m.Topic)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Message != nil {
+		l = m.Message.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ValidationResult) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Result != nil {
+		n += 1 + sovP2Pd(uint64(*m.Result))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PSResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Topics) > 0 {
+		for _, s := range m.Topics {
+			l = len(s)
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if len(m.PeerIDs) > 0 {
+		for _, b := range m.PeerIDs {
+			l = len(b)
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CallUnaryRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Peer != nil {
+		l = len(m.Peer)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Proto != nil {
+		l = len(*m.Proto)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Data != nil {
+		l = len(m.Data)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.TimeoutMs != nil {
+		n += 1 + sovP2Pd(uint64(*m.TimeoutMs))
+	}
+	if m.TraceParent != nil {
+		l = len(*m.TraceParent)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.TraceState != nil {
+		l = len(*m.TraceState)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.ChunkedSize != nil {
+		n += 1 + sovP2Pd(uint64(*m.ChunkedSize))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CallUnaryResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Result != nil {
+		n += m.Result.Size()
+	}
+	if m.ChunkedSize != nil {
+		n += 1 + sovP2Pd(uint64(*m.ChunkedSize))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CallUnaryResponse_Response) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Response != nil {
+		l = len(m.Response)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	return n
+}
+func (m *CallUnaryResponse_Error) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Error != nil {
+		l = len(m.Error)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	return n
+}
+func (m *AddUnaryHandlerRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Proto != nil {
+		l = len(*m.Proto)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.MaxConcurrentCalls != nil {
+		n += 1 + sovP2Pd(uint64(*m.MaxConcurrentCalls))
+	}
+	if m.OverflowPolicy != nil {
+		n += 1 + sovP2Pd(uint64(*m.OverflowPolicy))
+	}
+	if m.Replace != nil {
+		n += 2
+	}
+	if m.Raw != nil {
+		n += 2
+	}
+	if m.Window != nil {
+		n += 1 + sovP2Pd(uint64(*m.Window))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RemoveUnaryHandlerRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Proto != nil {
+		l = len(*m.Proto)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DaemonError) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Message != nil {
+		l = len(*m.Message)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Code != nil {
+		n += 1 + sovP2Pd(uint64(*m.Code))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Cancel) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CancelAll) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CancelAllResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Cancelled != nil {
+		n += 1 + sovP2Pd(uint64(*m.Cancelled))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *EndOfStream) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Ping) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Pong) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetStatsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *UnaryHandlerInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Proto != nil {
+		l = len(*m.Proto)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Enabled != nil {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *StatsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.UnaryHandlers) > 0 {
+		for _, e := range m.UnaryHandlers {
+			l = e.Size()
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.ActiveCalls != nil {
+		n += 1 + sovP2Pd(uint64(*m.ActiveCalls))
+	}
+	if m.PendingResponses != nil {
+		n += 1 + sovP2Pd(uint64(*m.PendingResponses))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SubscribeEventsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Types) > 0 {
+		for _, e := range m.Types {
+			n += 1 + sovP2Pd(uint64(e))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Event) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != nil {
+		n += 1 + sovP2Pd(uint64(*m.Type))
+	}
+	if m.Reachability != nil {
+		n += 1 + sovP2Pd(uint64(*m.Reachability))
+	}
+	if m.Peer != nil {
+		l = len(m.Peer)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Connectedness != nil {
+		n += 1 + sovP2Pd(uint64(*m.Connectedness))
+	}
+	if len(m.Addrs) > 0 {
+		for _, b := range m.Addrs {
+			l = len(b)
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.Addr != nil {
+		l = len(m.Addr)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovP2Pd(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozP2Pd(x uint64) (n int) {
+	return sovP2Pd(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Request) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Request: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Request: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var v Request_Type
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= Request_Type(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Type = &v
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Connect", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Connect == nil {
+				m.Connect = &ConnectRequest{}
+			}
+			if err := m.Connect.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StreamOpen", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.StreamOpen == nil {
+				m.StreamOpen = &StreamOpenRequest{}
+			}
+			if err := m.StreamOpen.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StreamHandler", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.StreamHandler == nil {
+				m.StreamHandler = &StreamHandlerRequest{}
+			}
+			if err := m.StreamHandler.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Dht", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Dht == nil {
+				m.Dht = &DHTRequest{}
+			}
+			if err := m.Dht.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConnManager", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ConnManager == nil {
+				m.ConnManager = &ConnManagerRequest{}
+			}
+			if err := m.ConnManager.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Disconnect", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Disconnect == nil {
+				m.Disconnect = &DisconnectRequest{}
+			}
+			if err := m.Disconnect.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pubsub", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Pubsub == nil {
+				m.Pubsub = &PSRequest{}
+			}
+			if err := m.Pubsub.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ping", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Ping == nil {
+				m.Ping = &PingRequest{}
+			}
+			if err := m.Ping.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SetBootstrapPeers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SetBootstrapPeers == nil {
+				m.SetBootstrapPeers = &SetBootstrapPeersRequest{}
+			}
+			if err := m.SetBootstrapPeers.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PeerLists", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.PeerLists == nil {
+				m.PeerLists = &PeerListsRequest{}
+			}
+			if err := m.PeerLists.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bandwidth", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Bandwidth == nil {
+				m.Bandwidth = &BandwidthRequest{}
+			}
+			if err := m.Bandwidth.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FindPeer", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.FindPeer == nil {
+				m.FindPeer = &FindPeerRequest{}
+			}
+			if err := m.FindPeer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RoutingTable", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.RoutingTable == nil {
+				m.RoutingTable = &RoutingTableRequest{}
+			}
+			if err := m.RoutingTable.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RotateIdentity", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.RotateIdentity == nil {
+				m.RotateIdentity = &RotateIdentityRequest{}
+			}
+			if err := m.RotateIdentity.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AutoNAT", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.AutoNAT == nil {
+				m.AutoNAT = &AutoNATRequest{}
+			}
+			if err := m.AutoNAT.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peerstore", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Peerstore == nil {
+				m.Peerstore = &PeerstoreRequest{}
+			}
+			if err := m.Peerstore.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PeerMetadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.PeerMetadata == nil {
+				m.PeerMetadata = &PeerMetadataRequest{}
+			}
+			if err := m.PeerMetadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 19:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LogLevel", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LogLevel == nil {
+				m.LogLevel = &LogLevelRequest{}
+			}
+			if err := m.LogLevel.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Response) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Response: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Response: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var v Response_Type
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= Response_Type(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Type = &v
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Error == nil {
+				m.Error = &ErrorResponse{}
+			}
+			if err := m.Error.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StreamInfo", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.StreamInfo == nil {
+				m.StreamInfo = &StreamInfo{}
+			}
+			if err := m.StreamInfo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Identify", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Identify == nil {
+				m.Identify = &IdentifyResponse{}
+			}
+			if err := m.Identify.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Dht", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Dht == nil {
+				m.Dht = &DHTResponse{}
+			}
+			if err := m.Dht.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peers = append(m.Peers, &PeerInfo{})
+			if err := m.Peers[len(m.Peers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pubsub", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Pubsub == nil {
+				m.Pubsub = &PSResponse{}
+			}
+			if err := m.Pubsub.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConnectedPeers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ConnectedPeers = append(m.ConnectedPeers, &ConnectedPeer{})
+			if err := m.ConnectedPeers[len(m.ConnectedPeers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ping", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Ping == nil {
+				m.Ping = &PingResponse{}
+			}
+			if err := m.Ping.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PeerLists", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.PeerLists == nil {
+				m.PeerLists = &PeerListsResponse{}
+			}
+			if err := m.PeerLists.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResourceUsage", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ResourceUsage == nil {
+				m.ResourceUsage = &ResourceUsageResponse{}
+			}
+			if err := m.ResourceUsage.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Relays", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Relays = append(m.Relays, &RelayInfo{})
+			if err := m.Relays[len(m.Relays)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Connect", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Connect == nil {
+				m.Connect = &ConnectResponse{}
+			}
+			if err := m.Connect.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bandwidth", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Bandwidth == nil {
+				m.Bandwidth = &BandwidthResponse{}
+			}
+			if err := m.Bandwidth.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Peer == nil {
+				m.Peer = &PeerInfo{}
+			}
+			if err := m.Peer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InstanceId", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.InstanceId = append(m.InstanceId[:0], dAtA[iNdEx:postIndex]...)
+			if m.InstanceId == nil {
+				m.InstanceId = []byte{}
+			}
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RoutingTable", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.RoutingTable == nil {
+				m.RoutingTable = &RoutingTableResponse{}
+			}
+			if err := m.RoutingTable.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Disconnect", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Disconnect == nil {
+				m.Disconnect = &DisconnectResponse{}
+			}
+			if err := m.Disconnect.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 19:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DaemonInfo", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.DaemonInfo == nil {
+				m.DaemonInfo = &DaemonInfoResponse{}
+			}
+			if err := m.DaemonInfo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 20:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AutoNAT", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.AutoNAT == nil {
+				m.AutoNAT = &AutoNATResponse{}
+			}
+			if err := m.AutoNAT.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 21:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CallHistory", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CallHistory = append(m.CallHistory, &CallRecord{})
+			if err := m.CallHistory[len(m.CallHistory)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 22:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addrs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Addrs == nil {
+				m.Addrs = &AddrsResponse{}
+			}
+			if err := m.Addrs.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 23:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peerstore", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Peerstore == nil {
+				m.Peerstore = &PeerstoreResponse{}
+			}
+			if err := m.Peerstore.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 24:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Metadata == nil {
+				m.Metadata = &Metadata{}
+			}
+			if err := m.Metadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 25:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LogLevels", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LogLevels = append(m.LogLevels, &SubsystemLogLevel{})
+			if err := m.LogLevels[len(m.LogLevels)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PersistentConnectionRequest) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PersistentConnectionRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PersistentConnectionRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CallId", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CallId = append(m.CallId[:0], dAtA[iNdEx:postIndex]...)
+			if m.CallId == nil {
+				m.CallId = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AddUnaryHandler", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &AddUnaryHandlerRequest{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Message = &PersistentConnectionRequest_AddUnaryHandler{v}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CallUnary", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &CallUnaryRequest{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Message = &PersistentConnectionRequest_CallUnary{v}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UnaryResponse", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &CallUnaryResponse{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Message = &PersistentConnectionRequest_UnaryResponse{v}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cancel", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &Cancel{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Message = &PersistentConnectionRequest_Cancel{v}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CallStream", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &CallUnaryRequest{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Message = &PersistentConnectionRequest_CallStream{v}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GetStats", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &GetStatsRequest{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Message = &PersistentConnectionRequest_GetStats{v}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SubscribeEvents", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &SubscribeEventsRequest{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Message = &PersistentConnectionRequest_SubscribeEvents{v}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pong", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &Pong{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Message = &PersistentConnectionRequest_Pong{v}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CancelAll", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &CancelAll{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Message = &PersistentConnectionRequest_CancelAll{v}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StreamFrame", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &StreamFrame{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Message = &PersistentConnectionRequest_StreamFrame{v}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pubsub", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &PSRequest{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Message = &PersistentConnectionRequest_Pubsub{v}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AddTopicValidator", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &AddTopicValidatorRequest{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Message = &PersistentConnectionRequest_AddTopicValidator{v}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidationResult", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ValidationResult{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Message = &PersistentConnectionRequest_ValidationResult{v}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RemoveUnaryHandler", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &RemoveUnaryHandlerRequest{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Message = &PersistentConnectionRequest_RemoveUnaryHandler{v}
+			iNdEx = postIndex
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Chunk", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &PayloadChunk{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Message = &PersistentConnectionRequest_Chunk{v}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("callId")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PersistentConnectionResponse) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PersistentConnectionResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PersistentConnectionResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CallId", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CallId = append(m.CallId[:0], dAtA[iNdEx:postIndex]...)
+			if m.CallId == nil {
+				m.CallId = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CallUnaryResponse", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &CallUnaryResponse{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Message = &PersistentConnectionResponse_CallUnaryResponse{v}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestHandling", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &CallUnaryRequest{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Message = &PersistentConnectionResponse_RequestHandling{v}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DaemonError", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &DaemonError{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Message = &PersistentConnectionResponse_DaemonError{v}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cancel", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &Cancel{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Message = &PersistentConnectionResponse_Cancel{v}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndOfStream", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &EndOfStream{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Message = &PersistentConnectionResponse_EndOfStream{v}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stats", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &StatsResponse{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Message = &PersistentConnectionResponse_Stats{v}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Event", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &Event{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Message = &PersistentConnectionResponse_Event{v}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ping", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &Ping{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Message = &PersistentConnectionResponse_Ping{v}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CancelAll", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &CancelAllResponse{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Message = &PersistentConnectionResponse_CancelAll{v}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IncomingStream", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &StreamInfo{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Message = &PersistentConnectionResponse_IncomingStream{v}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StreamFrame", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &StreamFrame{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Message = &PersistentConnectionResponse_StreamFrame{v}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PubsubMessage", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &PSMessage{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Message = &PersistentConnectionResponse_PubsubMessage{v}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidateMessage", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ValidateMessage{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Message = &PersistentConnectionResponse_ValidateMessage{v}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Chunk", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &PayloadChunk{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Message = &PersistentConnectionResponse_Chunk{v}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("callId")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *IdentifyResponse) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: IdentifyResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: IdentifyResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = append(m.Id[:0], dAtA[iNdEx:postIndex]...)
+			if m.Id == nil {
+				m.Id = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addrs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addrs = append(m.Addrs, make([]byte, postIndex-iNdEx))
+			copy(m.Addrs[len(m.Addrs)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignedPeerRecord", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SignedPeerRecord = append(m.SignedPeerRecord[:0], dAtA[iNdEx:postIndex]...)
+			if m.SignedPeerRecord == nil {
+				m.SignedPeerRecord = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("id")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RotateIdentityRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RotateIdentityRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RotateIdentityRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyType", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.KeyType = &s
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GracePeriodMs", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.GracePeriodMs = &v
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DaemonInfoResponse) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DaemonInfoResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DaemonInfoResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTime", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.StartTime = &v
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Uptime", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Uptime = &v
+			hasFields[0] |= uint64(0x00000002)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Module", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Module = &s
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Version = &s
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GoVersion", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.GoVersion = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000004)
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DhtMode", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.DhtMode = &s
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PubsubRouter", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.PubsubRouter = &s
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Relay", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			b := bool(v != 0)
+			m.Relay = &b
+			hasFields[0] |= uint64(0x00000008)
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RelayHop", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			b := bool(v != 0)
+			m.RelayHop = &b
+			hasFields[0] |= uint64(0x00000010)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("startTime")
+	}
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("uptime")
+	}
+	if hasFields[0]&uint64(0x00000004) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("goVersion")
+	}
+	if hasFields[0]&uint64(0x00000008) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("relay")
+	}
+	if hasFields[0]&uint64(0x00000010) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("relayHop")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AutoNATRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AutoNATRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AutoNATRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Probe", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			b := bool(v != 0)
+			m.Probe = &b
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Timeout = &v
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AutoNATResponse) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AutoNATResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AutoNATResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reachability", wireType)
+			}
+			var v Event_Reachability
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= Event_Reachability(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Reachability = &v
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Probe", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Probe == nil {
+				m.Probe = &AutoNATProbe{}
+			}
+			if err := m.Probe.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("reachability")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AutoNATProbe) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AutoNATProbe: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AutoNATProbe: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reachability", wireType)
+			}
+			var v Event_Reachability
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= Event_Reachability(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Reachability = &v
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peer = append(m.Peer[:0], dAtA[iNdEx:postIndex]...)
+			if m.Peer == nil {
+				m.Peer = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000002)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addr", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addr = append(m.Addr[:0], dAtA[iNdEx:postIndex]...)
+			if m.Addr == nil {
+				m.Addr = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("reachability")
+	}
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CallRecord) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CallRecord: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CallRecord: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peer = append(m.Peer[:0], dAtA[iNdEx:postIndex]...)
+			if m.Peer == nil {
+				m.Peer = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proto", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Proto = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000002)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTime", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.StartTime = &v
+			hasFields[0] |= uint64(0x00000004)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Duration", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Duration = &v
+			hasFields[0] |= uint64(0x00000008)
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Result = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000010)
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Error = &s
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	}
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("proto")
+	}
+	if hasFields[0]&uint64(0x00000004) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("startTime")
+	}
+	if hasFields[0]&uint64(0x00000008) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("duration")
+	}
+	if hasFields[0]&uint64(0x00000010) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("result")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConnectRequest) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConnectRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConnectRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peer = append(m.Peer[:0], dAtA[iNdEx:postIndex]...)
+			if m.Peer == nil {
+				m.Peer = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addrs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addrs = append(m.Addrs, make([]byte, postIndex-iNdEx))
+			copy(m.Addrs[len(m.Addrs)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Timeout = &v
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AddrTTL", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.AddrTTL = &v
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignedPeerRecord", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SignedPeerRecord = append(m.SignedPeerRecord[:0], dAtA[iNdEx:postIndex]...)
+			if m.SignedPeerRecord == nil {
+				m.SignedPeerRecord = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConnectResponse) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConnectResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConnectResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addr", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addr = append(m.Addr[:0], dAtA[iNdEx:postIndex]...)
+			if m.Addr == nil {
+				m.Addr = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignedPeerRecord", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SignedPeerRecord = append(m.SignedPeerRecord[:0], dAtA[iNdEx:postIndex]...)
+			if m.SignedPeerRecord == nil {
+				m.SignedPeerRecord = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("addr")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PingRequest) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PingRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PingRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peer = append(m.Peer[:0], dAtA[iNdEx:postIndex]...)
+			if m.Peer == nil {
+				m.Peer = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+			}
+			var v int32
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Count = &v
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Timeout = &v
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PeerMetadataRequest) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PeerMetadataRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PeerMetadataRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peer = append(m.Peer[:0], dAtA[iNdEx:postIndex]...)
+			if m.Peer == nil {
+				m.Peer = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Timeout = &v
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Metadata) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Metadata: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Metadata: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Entries", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Entries = append(m.Entries, &MetadataEntry{})
+			if err := m.Entries[len(m.Entries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MetadataEntry) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MetadataEntry: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MetadataEntry: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Key = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Value = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000002)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("key")
+	}
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("value")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LogLevelRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LogLevelRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LogLevelRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Subsystem", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Subsystem = &s
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Level", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Level = &s
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SubsystemLogLevel) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SubsystemLogLevel: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SubsystemLogLevel: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Subsystem", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Subsystem = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Level", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Level = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000002)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("subsystem")
+	}
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("level")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FindPeerRequest) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FindPeerRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FindPeerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peer = append(m.Peer[:0], dAtA[iNdEx:postIndex]...)
+			if m.Peer == nil {
+				m.Peer = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SkipDHT", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			b := bool(v != 0)
+			m.SkipDHT = &b
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Timeout = &v
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RoutingTableRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RoutingTableRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RoutingTableRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peers", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			b := bool(v != 0)
+			m.Peers = &b
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Refresh", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			b := bool(v != 0)
+			m.Refresh = &b
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Force", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			b := bool(v != 0)
+			m.Force = &b
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Timeout = &v
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RoutingTableBucket) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RoutingTableBucket: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RoutingTableBucket: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cpl", wireType)
+			}
+			var v int32
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Cpl = &v
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peers", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peers = append(m.Peers, make([]byte, postIndex-iNdEx))
+			copy(m.Peers[len(m.Peers)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("cpl")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RoutingTableResponse) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RoutingTableResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RoutingTableResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PeerCount", wireType)
+			}
+			var v int32
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.PeerCount = &v
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Buckets", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Buckets = append(m.Buckets, &RoutingTableBucket{})
+			if err := m.Buckets[len(m.Buckets)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("peerCount")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SetBootstrapPeersRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SetBootstrapPeersRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SetBootstrapPeersRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addrs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addrs = append(m.Addrs, make([]byte, postIndex-iNdEx))
+			copy(m.Addrs[len(m.Addrs)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bootstrap", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			b := bool(v != 0)
+			m.Bootstrap = &b
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PeerstoreRequest) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PeerstoreRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PeerstoreRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var v PeerstoreRequest_Type
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= PeerstoreRequest_Type(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Type = &v
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peer = append(m.Peer[:0], dAtA[iNdEx:postIndex]...)
+			if m.Peer == nil {
+				m.Peer = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000002)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addrs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addrs = append(m.Addrs, make([]byte, postIndex-iNdEx))
+			copy(m.Addrs[len(m.Addrs)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ttl", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Ttl = &v
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Protos", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Protos = append(m.Protos, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+	}
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PeerstoreResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PeerstoreResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PeerstoreResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Protocols", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Protocols = append(m.Protocols, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Supported", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Supported = append(m.Supported, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PeerListsRequest) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PeerListsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PeerListsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var v PeerListsRequest_Type
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= PeerListsRequest_Type(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Type = &v
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AllowedPeers", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AllowedPeers = append(m.AllowedPeers, make([]byte, postIndex-iNdEx))
+			copy(m.AllowedPeers[len(m.AllowedPeers)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockedPeers", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BlockedPeers = append(m.BlockedPeers, make([]byte, postIndex-iNdEx))
+			copy(m.BlockedPeers[len(m.BlockedPeers)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PeerListsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PeerListsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PeerListsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AllowedPeers", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AllowedPeers = append(m.AllowedPeers, make([]byte, postIndex-iNdEx))
+			copy(m.AllowedPeers[len(m.AllowedPeers)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockedPeers", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BlockedPeers = append(m.BlockedPeers, make([]byte, postIndex-iNdEx))
+			copy(m.BlockedPeers[len(m.BlockedPeers)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResourceScope) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResourceScope: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResourceScope: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConnsInbound", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ConnsInbound = &v
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConnsOutbound", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ConnsOutbound = &v
+			hasFields[0] |= uint64(0x00000002)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StreamsInbound", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.StreamsInbound = &v
+			hasFields[0] |= uint64(0x00000004)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StreamsOutbound", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.StreamsOutbound = &v
+			hasFields[0] |= uint64(0x00000008)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("connsInbound")
+	}
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("connsOutbound")
+	}
+	if hasFields[0]&uint64(0x00000004) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("streamsInbound")
+	}
+	if hasFields[0]&uint64(0x00000008) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("streamsOutbound")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ProtocolResourceScope) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ProtocolResourceScope: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ProtocolResourceScope: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proto", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Proto = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Usage", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Usage == nil {
+				m.Usage = &ResourceScope{}
+			}
+			if err := m.Usage.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000002)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("proto")
+	}
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("usage")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PeerResourceScope) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PeerResourceScope: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PeerResourceScope: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peer = append(m.Peer[:0], dAtA[iNdEx:postIndex]...)
+			if m.Peer == nil {
+				m.Peer = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Usage", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Usage == nil {
+				m.Usage = &ResourceScope{}
+			}
+			if err := m.Usage.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000002)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	}
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("usage")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResourceUsageResponse) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResourceUsageResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResourceUsageResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field System", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.System == nil {
+				m.System = &ResourceScope{}
+			}
+			if err := m.System.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Protocols", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Protocols = append(m.Protocols, &ProtocolResourceScope{})
+			if err := m.Protocols[len(m.Protocols)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peers = append(m.Peers, &PeerResourceScope{})
+			if err := m.Peers[len(m.Peers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("system")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BandwidthRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BandwidthRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BandwidthRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ByPeer", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			b := bool(v != 0)
+			m.ByPeer = &b
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peer = append(m.Peer[:0], dAtA[iNdEx:postIndex]...)
+			if m.Peer == nil {
+				m.Peer = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ByProtocol", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			b := bool(v != 0)
+			m.ByProtocol = &b
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BandwidthStats) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BandwidthStats: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BandwidthStats: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalIn", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.TotalIn = &v
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalOut", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.TotalOut = &v
+			hasFields[0] |= uint64(0x00000002)
+		case 3:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RateIn", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			v2 := float64(math.Float64frombits(v))
+			m.RateIn = &v2
+			hasFields[0] |= uint64(0x00000004)
+		case 4:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RateOut", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			v2 := float64(math.Float64frombits(v))
+			m.RateOut = &v2
+			hasFields[0] |= uint64(0x00000008)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("totalIn")
+	}
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("totalOut")
+	}
+	if hasFields[0]&uint64(0x00000004) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("rateIn")
+	}
+	if hasFields[0]&uint64(0x00000008) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("rateOut")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PeerBandwidth) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PeerBandwidth: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PeerBandwidth: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peer = append(m.Peer[:0], dAtA[iNdEx:postIndex]...)
+			if m.Peer == nil {
+				m.Peer = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stats", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Stats == nil {
+				m.Stats = &BandwidthStats{}
+			}
+			if err := m.Stats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000002)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	}
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("stats")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ProtocolBandwidth) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ProtocolBandwidth: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ProtocolBandwidth: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proto", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Proto = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stats", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Stats == nil {
+				m.Stats = &BandwidthStats{}
+			}
+			if err := m.Stats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000002)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("proto")
+	}
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("stats")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BandwidthResponse) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BandwidthResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BandwidthResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Total", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Total == nil {
+				m.Total = &BandwidthStats{}
+			}
+			if err := m.Total.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peers = append(m.Peers, &PeerBandwidth{})
+			if err := m.Peers[len(m.Peers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Protocols", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Protocols = append(m.Protocols, &ProtocolBandwidth{})
+			if err := m.Protocols[len(m.Protocols)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("total")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AddrsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AddrsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AddrsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Listen", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Listen = append(m.Listen, make([]byte, postIndex-iNdEx))
+			copy(m.Listen[len(m.Listen)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Observed", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Observed = append(m.Observed, make([]byte, postIndex-iNdEx))
+			copy(m.Observed[len(m.Observed)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Relay", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Relay = append(m.Relay, make([]byte, postIndex-iNdEx))
+			copy(m.Relay[len(m.Relay)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RelayInfo) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RelayInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RelayInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peer = append(m.Peer[:0], dAtA[iNdEx:postIndex]...)
+			if m.Peer == nil {
+				m.Peer = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addrs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addrs = append(m.Addrs, make([]byte, postIndex-iNdEx))
+			copy(m.Addrs[len(m.Addrs)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Connected", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			b := bool(v != 0)
+			m.Connected = &b
+			hasFields[0] |= uint64(0x00000002)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	}
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("connected")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PingResponse) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PingResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PingResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType == 0 {
+				var v int64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowP2Pd
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= int64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Rtts = append(m.Rtts, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowP2Pd
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthP2Pd
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthP2Pd
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.Rtts) == 0 {
+					m.Rtts = make([]int64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v int64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowP2Pd
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= int64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Rtts = append(m.Rtts, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Rtts", wireType)
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Min", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Min = &v
+			hasFields[0] |= uint64(0x00000001)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Avg", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Avg = &v
+			hasFields[0] |= uint64(0x00000002)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Max", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Max = &v
+			hasFields[0] |= uint64(0x00000004)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("min")
+	}
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("avg")
+	}
+	if hasFields[0]&uint64(0x00000004) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("max")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StreamOpenRequest) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StreamOpenRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StreamOpenRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peer = append(m.Peer[:0], dAtA[iNdEx:postIndex]...)
+			if m.Peer == nil {
+				m.Peer = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proto", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Proto = append(m.Proto, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Timeout = &v
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Framed", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			b := bool(v != 0)
+			m.Framed = &b
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StreamFrame) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StreamFrame: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StreamFrame: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CloseWrite", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			b := bool(v != 0)
+			m.CloseWrite = &b
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Error = &s
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ack", wireType)
+			}
+			var v uint32
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Ack = &v
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PayloadChunk) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PayloadChunk: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PayloadChunk: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Seq", wireType)
+			}
+			var v uint32
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Seq = &v
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Last", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			b := bool(v != 0)
+			m.Last = &b
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("seq")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StreamHandlerRequest) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StreamHandlerRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StreamHandlerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addr", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addr = append(m.Addr[:0], dAtA[iNdEx:postIndex]...)
+			if m.Addr == nil {
+				m.Addr = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proto", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Proto = append(m.Proto, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("addr")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ErrorResponse) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ErrorResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ErrorResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Msg = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DialAttempts", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DialAttempts = append(m.DialAttempts, &DialAttempt{})
+			if err := m.DialAttempts[len(m.DialAttempts)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("msg")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DialAttempt) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DialAttempt: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DialAttempt: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addr", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addr = append(m.Addr[:0], dAtA[iNdEx:postIndex]...)
+			if m.Addr == nil {
+				m.Addr = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Error = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000002)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
+			}
+			var v DialAttempt_Reason
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= DialAttempt_Reason(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Reason = &v
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("addr")
+	}
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("error")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StreamInfo) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StreamInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StreamInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peer = append(m.Peer[:0], dAtA[iNdEx:postIndex]...)
+			if m.Peer == nil {
+				m.Peer = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addr", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addr = append(m.Addr[:0], dAtA[iNdEx:postIndex]...)
+			if m.Addr == nil {
+				m.Addr = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000002)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proto", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Proto = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000004)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Window", wireType)
+			}
+			var v uint32
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Window = &v
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
 		}
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
-}
-
-func (m *CallUnaryRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Peer != nil {
-		l = len(m.Peer)
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	if m.Proto != nil {
-		l = len(*m.Proto)
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	if m.Data != nil {
-		l = len(m.Data)
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
-}
-
-func (m *CallUnaryResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Result != nil {
-		n += m.Result.Size()
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
-}
-
-func (m *CallUnaryResponse_Response) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Response != nil {
-		l = len(m.Response)
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	return n
-}
-func (m *CallUnaryResponse_Error) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Error != nil {
-		l = len(m.Error)
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	return n
-}
-func (m *AddUnaryHandlerRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Proto != nil {
-		l = len(*m.Proto)
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
-}
-
-func (m *DaemonError) Size() (n int) {
-	if m == nil {
-		return 0
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
 	}
-	var l int
-	_ = l
-	if m.Message != nil {
-		l = len(*m.Message)
-		n += 1 + l + sovP2Pd(uint64(l))
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("addr")
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if hasFields[0]&uint64(0x00000004) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("proto")
 	}
-	return n
-}
 
-func (m *Cancel) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
-}
-
-func sovP2Pd(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozP2Pd(x uint64) (n int) {
-	return sovP2Pd(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	return nil
 }
-func (m *Request) Unmarshal(dAtA []byte) error {
+func (m *DHTRequest) Unmarshal(dAtA []byte) error {
 	var hasFields [1]uint64
 	l := len(dAtA)
 	iNdEx := 0
@@ -4169,17 +23178,106 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Request: wiretype end group for non-group")
+			return fmt.Errorf("proto: DHTRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Request: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DHTRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
 			}
-			var v Request_Type
+			var v DHTRequest_Type
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= DHTRequest_Type(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Type = &v
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peer = append(m.Peer[:0], dAtA[iNdEx:postIndex]...)
+			if m.Peer == nil {
+				m.Peer = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cid", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Cid = append(m.Cid[:0], dAtA[iNdEx:postIndex]...)
+			if m.Cid == nil {
+				m.Cid = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -4189,18 +23287,31 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= Request_Type(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Type = &v
-			hasFields[0] |= uint64(0x00000001)
-		case 2:
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Connect", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -4210,33 +23321,31 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthP2Pd
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Connect == nil {
-				m.Connect = &ConnectRequest{}
-			}
-			if err := m.Connect.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
 			}
 			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StreamOpen", wireType)
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
 			}
-			var msglen int
+			var v int32
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -4246,33 +23355,57 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthP2Pd
+			m.Count = &v
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if m.StreamOpen == nil {
-				m.StreamOpen = &StreamOpenRequest{}
+			m.Timeout = &v
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Quorum", wireType)
 			}
-			if err := m.StreamOpen.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			var v int32
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StreamHandler", wireType)
+			m.Quorum = &v
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Announce", wireType)
 			}
-			var msglen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -4282,31 +23415,92 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthP2Pd
+			b := bool(v != 0)
+			m.Announce = &b
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.StreamHandler == nil {
-				m.StreamHandler = &StreamHandlerRequest{}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DHTResponse) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
 			}
-			if err := m.StreamHandler.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			iNdEx = postIndex
-		case 5:
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DHTResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DHTResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var v DHTResponse_Type
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= DHTResponse_Type(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Type = &v
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Dht", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4333,18 +23527,18 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Dht == nil {
-				m.Dht = &DHTRequest{}
+			if m.Peer == nil {
+				m.Peer = &PeerInfo{}
 			}
-			if err := m.Dht.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Peer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 6:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ConnManager", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -4354,33 +23548,86 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			if postIndex > l {
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PeerInfo) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ConnManager == nil {
-				m.ConnManager = &ConnManagerRequest{}
-			}
-			if err := m.ConnManager.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
-		case 7:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PeerInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PeerInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Disconnect", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -4390,33 +23637,32 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthP2Pd
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Disconnect == nil {
-				m.Disconnect = &DisconnectRequest{}
-			}
-			if err := m.Disconnect.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Id = append(m.Id[:0], dAtA[iNdEx:postIndex]...)
+			if m.Id == nil {
+				m.Id = []byte{}
 			}
 			iNdEx = postIndex
-		case 8:
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Pubsub", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Addrs", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -4426,27 +23672,23 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthP2Pd
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Pubsub == nil {
-				m.Pubsub = &PSRequest{}
-			}
-			if err := m.Pubsub.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Addrs = append(m.Addrs, make([]byte, postIndex-iNdEx))
+			copy(m.Addrs[len(m.Addrs)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -4465,7 +23707,7 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 		}
 	}
 	if hasFields[0]&uint64(0x00000001) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("id")
 	}
 
 	if iNdEx > l {
@@ -4473,7 +23715,7 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Response) Unmarshal(dAtA []byte) error {
+func (m *ConnectedPeer) Unmarshal(dAtA []byte) error {
 	var hasFields [1]uint64
 	l := len(dAtA)
 	iNdEx := 0
@@ -4497,38 +23739,17 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Response: wiretype end group for non-group")
+			return fmt.Errorf("proto: ConnectedPeer: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Response: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ConnectedPeer: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
-			}
-			var v Response_Type
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowP2Pd
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= Response_Type(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.Type = &v
-			hasFields[0] |= uint64(0x00000001)
-		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -4538,31 +23759,30 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthP2Pd
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Error == nil {
-				m.Error = &ErrorResponse{}
-			}
-			if err := m.Error.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Id = append(m.Id[:0], dAtA[iNdEx:postIndex]...)
+			if m.Id == nil {
+				m.Id = []byte{}
 			}
 			iNdEx = postIndex
-		case 3:
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StreamInfo", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Conns", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4589,18 +23809,71 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.StreamInfo == nil {
-				m.StreamInfo = &StreamInfo{}
-			}
-			if err := m.StreamInfo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Conns = append(m.Conns, &ConnectionInfo{})
+			if err := m.Conns[len(m.Conns)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 4:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("id")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConnectionInfo) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConnectionInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConnectionInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Identify", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Addr", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -4610,33 +23883,32 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthP2Pd
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Identify == nil {
-				m.Identify = &IdentifyResponse{}
-			}
-			if err := m.Identify.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Addr = append(m.Addr[:0], dAtA[iNdEx:postIndex]...)
+			if m.Addr == nil {
+				m.Addr = []byte{}
 			}
 			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Dht", wireType)
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Direction", wireType)
 			}
-			var msglen int
+			var v ConnectionInfo_Direction
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -4646,33 +23918,18 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= ConnectionInfo_Direction(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Dht == nil {
-				m.Dht = &DHTResponse{}
-			}
-			if err := m.Dht.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Peers", wireType)
+			m.Direction = &v
+			hasFields[0] |= uint64(0x00000002)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Relayed", wireType)
 			}
-			var msglen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -4682,31 +23939,19 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Peers = append(m.Peers, &PeerInfo{})
-			if err := m.Peers[len(m.Peers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 7:
+			b := bool(v != 0)
+			m.Relayed = &b
+			hasFields[0] |= uint64(0x00000004)
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Pubsub", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Security", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -4716,27 +23961,24 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Pubsub == nil {
-				m.Pubsub = &PSResponse{}
+				return ErrInvalidLengthP2Pd
 			}
-			if err := m.Pubsub.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Security = &s
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -4755,7 +23997,13 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 		}
 	}
 	if hasFields[0]&uint64(0x00000001) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("addr")
+	}
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("direction")
+	}
+	if hasFields[0]&uint64(0x00000004) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("relayed")
 	}
 
 	if iNdEx > l {
@@ -4763,7 +24011,7 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PersistentConnectionRequest) Unmarshal(dAtA []byte) error {
+func (m *ConnManagerRequest) Unmarshal(dAtA []byte) error {
 	var hasFields [1]uint64
 	l := len(dAtA)
 	iNdEx := 0
@@ -4787,17 +24035,17 @@ func (m *PersistentConnectionRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PersistentConnectionRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ConnManagerRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PersistentConnectionRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ConnManagerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CallId", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
 			}
-			var byteLen int
+			var v ConnManagerRequest_Type
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -4807,32 +24055,18 @@ func (m *PersistentConnectionRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				v |= ConnManagerRequest_Type(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.CallId = append(m.CallId[:0], dAtA[iNdEx:postIndex]...)
-			if m.CallId == nil {
-				m.CallId = []byte{}
-			}
-			iNdEx = postIndex
+			m.Type = &v
 			hasFields[0] |= uint64(0x00000001)
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AddUnaryHandler", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -4842,32 +24076,31 @@ func (m *PersistentConnectionRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthP2Pd
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &AddUnaryHandlerRequest{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Peer = append(m.Peer[:0], dAtA[iNdEx:postIndex]...)
+			if m.Peer == nil {
+				m.Peer = []byte{}
 			}
-			m.Message = &PersistentConnectionRequest_AddUnaryHandler{v}
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CallUnary", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Tag", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -4877,32 +24110,30 @@ func (m *PersistentConnectionRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthP2Pd
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &CallUnaryRequest{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Message = &PersistentConnectionRequest_CallUnary{v}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Tag = &s
 			iNdEx = postIndex
 		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UnaryResponse", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Weight", wireType)
 			}
-			var msglen int
+			var v int64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -4912,32 +24143,17 @@ func (m *PersistentConnectionRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			v := &CallUnaryResponse{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Message = &PersistentConnectionRequest_UnaryResponse{v}
-			iNdEx = postIndex
+			m.Weight = &v
 		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Cancel", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LowWaterMark", wireType)
 			}
-			var msglen int
+			var v int64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -4947,27 +24163,52 @@ func (m *PersistentConnectionRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthP2Pd
+			m.LowWaterMark = &v
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HighWaterMark", wireType)
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthP2Pd
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			m.HighWaterMark = &v
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GracePeriod", wireType)
 			}
-			v := &Cancel{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			m.Message = &PersistentConnectionRequest_Cancel{v}
-			iNdEx = postIndex
+			m.GracePeriod = &v
 		default:
 			iNdEx = preIndex
 			skippy, err := skipP2Pd(dAtA[iNdEx:])
@@ -4985,7 +24226,7 @@ func (m *PersistentConnectionRequest) Unmarshal(dAtA []byte) error {
 		}
 	}
 	if hasFields[0]&uint64(0x00000001) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("callId")
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
 	}
 
 	if iNdEx > l {
@@ -4993,7 +24234,7 @@ func (m *PersistentConnectionRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PersistentConnectionResponse) Unmarshal(dAtA []byte) error {
+func (m *DisconnectRequest) Unmarshal(dAtA []byte) error {
 	var hasFields [1]uint64
 	l := len(dAtA)
 	iNdEx := 0
@@ -5017,15 +24258,15 @@ func (m *PersistentConnectionResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PersistentConnectionResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: DisconnectRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PersistentConnectionResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DisconnectRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CallId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -5052,17 +24293,17 @@ func (m *PersistentConnectionResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.CallId = append(m.CallId[:0], dAtA[iNdEx:postIndex]...)
-			if m.CallId == nil {
-				m.CallId = []byte{}
+			m.Peer = append(m.Peer[:0], dAtA[iNdEx:postIndex]...)
+			if m.Peer == nil {
+				m.Peer = []byte{}
 			}
 			iNdEx = postIndex
 			hasFields[0] |= uint64(0x00000001)
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CallUnaryResponse", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ForgetAddrs", wireType)
 			}
-			var msglen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -5072,32 +24313,149 @@ func (m *PersistentConnectionResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			b := bool(v != 0)
+			m.ForgetAddrs = &b
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DisconnectResponse) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DisconnectResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DisconnectResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClosedConns", wireType)
+			}
+			var v int32
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ClosedConns = &v
+			hasFields[0] |= uint64(0x00000001)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("closedConns")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PSRequest) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &CallUnaryResponse{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			m.Message = &PersistentConnectionResponse_CallUnaryResponse{v}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RequestHandling", wireType)
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PSRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PSRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
 			}
-			var msglen int
+			var v PSRequest_Type
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -5107,32 +24465,18 @@ func (m *PersistentConnectionResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= PSRequest_Type(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			v := &CallUnaryRequest{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Message = &PersistentConnectionResponse_RequestHandling{v}
-			iNdEx = postIndex
-		case 4:
+			m.Type = &v
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DaemonError", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Topic", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -5142,32 +24486,30 @@ func (m *PersistentConnectionResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthP2Pd
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &DaemonError{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Message = &PersistentConnectionResponse_DaemonError{v}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Topic = &s
 			iNdEx = postIndex
-		case 5:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Cancel", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -5177,26 +24519,25 @@ func (m *PersistentConnectionResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthP2Pd
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &Cancel{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
 			}
-			m.Message = &PersistentConnectionResponse_Cancel{v}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -5215,7 +24556,7 @@ func (m *PersistentConnectionResponse) Unmarshal(dAtA []byte) error {
 		}
 	}
 	if hasFields[0]&uint64(0x00000001) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("callId")
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
 	}
 
 	if iNdEx > l {
@@ -5223,8 +24564,7 @@ func (m *PersistentConnectionResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *IdentifyResponse) Unmarshal(dAtA []byte) error {
-	var hasFields [1]uint64
+func (m *PSMessage) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5247,15 +24587,15 @@ func (m *IdentifyResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: IdentifyResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: PSMessage: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: IdentifyResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PSMessage: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field From", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -5282,15 +24622,14 @@ func (m *IdentifyResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Id = append(m.Id[:0], dAtA[iNdEx:postIndex]...)
-			if m.Id == nil {
-				m.Id = []byte{}
+			m.From = append(m.From[:0], dAtA[iNdEx:postIndex]...)
+			if m.From == nil {
+				m.From = []byte{}
 			}
 			iNdEx = postIndex
-			hasFields[0] |= uint64(0x00000001)
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Addrs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -5317,67 +24656,80 @@ func (m *IdentifyResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Addrs = append(m.Addrs, make([]byte, postIndex-iNdEx))
-			copy(m.Addrs[len(m.Addrs)-1], dAtA[iNdEx:postIndex])
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipP2Pd(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Seqno", wireType)
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			if (iNdEx + skippy) > l {
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-	if hasFields[0]&uint64(0x00000001) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("id")
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ConnectRequest) Unmarshal(dAtA []byte) error {
-	var hasFields [1]uint64
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowP2Pd
+			m.Seqno = append(m.Seqno[:0], dAtA[iNdEx:postIndex]...)
+			if m.Seqno == nil {
+				m.Seqno = []byte{}
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TopicIDs", wireType)
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ConnectRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ConnectRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TopicIDs = append(m.TopicIDs, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -5404,15 +24756,14 @@ func (m *ConnectRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Peer = append(m.Peer[:0], dAtA[iNdEx:postIndex]...)
-			if m.Peer == nil {
-				m.Peer = []byte{}
+			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signature == nil {
+				m.Signature = []byte{}
 			}
-			iNdEx = postIndex
-			hasFields[0] |= uint64(0x00000001)
-		case 2:
+			iNdEx = postIndex
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Addrs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -5439,29 +24790,11 @@ func (m *ConnectRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Addrs = append(m.Addrs, make([]byte, postIndex-iNdEx))
-			copy(m.Addrs[len(m.Addrs)-1], dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
-			}
-			var v int64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowP2Pd
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
 			}
-			m.Timeout = &v
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipP2Pd(dAtA[iNdEx:])
@@ -5478,16 +24811,13 @@ func (m *ConnectRequest) Unmarshal(dAtA []byte) error {
 			iNdEx += skippy
 		}
 	}
-	if hasFields[0]&uint64(0x00000001) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
-	}
 
 	if iNdEx > l {
 		return io.ErrUnexpectedEOF
 	}
 	return nil
 }
-func (m *StreamOpenRequest) Unmarshal(dAtA []byte) error {
+func (m *AddTopicValidatorRequest) Unmarshal(dAtA []byte) error {
 	var hasFields [1]uint64
 	l := len(dAtA)
 	iNdEx := 0
@@ -5511,50 +24841,15 @@ func (m *StreamOpenRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: StreamOpenRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: AddTopicValidatorRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: StreamOpenRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AddTopicValidatorRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowP2Pd
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Peer = append(m.Peer[:0], dAtA[iNdEx:postIndex]...)
-			if m.Peer == nil {
-				m.Peer = []byte{}
-			}
-			iNdEx = postIndex
-			hasFields[0] |= uint64(0x00000001)
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Proto", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Topic", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -5582,11 +24877,13 @@ func (m *StreamOpenRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Proto = append(m.Proto, string(dAtA[iNdEx:postIndex]))
+			s := string(dAtA[iNdEx:postIndex])
+			m.Topic = &s
 			iNdEx = postIndex
-		case 3:
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TimeoutMs", wireType)
 			}
 			var v int64
 			for shift := uint(0); ; shift += 7 {
@@ -5603,7 +24900,7 @@ func (m *StreamOpenRequest) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-			m.Timeout = &v
+			m.TimeoutMs = &v
 		default:
 			iNdEx = preIndex
 			skippy, err := skipP2Pd(dAtA[iNdEx:])
@@ -5621,7 +24918,7 @@ func (m *StreamOpenRequest) Unmarshal(dAtA []byte) error {
 		}
 	}
 	if hasFields[0]&uint64(0x00000001) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("topic")
 	}
 
 	if iNdEx > l {
@@ -5629,7 +24926,7 @@ func (m *StreamOpenRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *StreamHandlerRequest) Unmarshal(dAtA []byte) error {
+func (m *ValidateMessage) Unmarshal(dAtA []byte) error {
 	var hasFields [1]uint64
 	l := len(dAtA)
 	iNdEx := 0
@@ -5653,17 +24950,17 @@ func (m *StreamHandlerRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: StreamHandlerRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ValidateMessage: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: StreamHandlerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ValidateMessage: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Addr", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Topic", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -5673,32 +24970,31 @@ func (m *StreamHandlerRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthP2Pd
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Addr = append(m.Addr[:0], dAtA[iNdEx:postIndex]...)
-			if m.Addr == nil {
-				m.Addr = []byte{}
-			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Topic = &s
 			iNdEx = postIndex
 			hasFields[0] |= uint64(0x00000001)
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Proto", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -5708,24 +25004,29 @@ func (m *StreamHandlerRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthP2Pd
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Proto = append(m.Proto, string(dAtA[iNdEx:postIndex]))
+			if m.Message == nil {
+				m.Message = &PSMessage{}
+			}
+			if err := m.Message.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000002)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipP2Pd(dAtA[iNdEx:])
@@ -5743,7 +25044,10 @@ func (m *StreamHandlerRequest) Unmarshal(dAtA []byte) error {
 		}
 	}
 	if hasFields[0]&uint64(0x00000001) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("addr")
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("topic")
+	}
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("message")
 	}
 
 	if iNdEx > l {
@@ -5751,7 +25055,7 @@ func (m *StreamHandlerRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ErrorResponse) Unmarshal(dAtA []byte) error {
+func (m *ValidationResult) Unmarshal(dAtA []byte) error {
 	var hasFields [1]uint64
 	l := len(dAtA)
 	iNdEx := 0
@@ -5775,17 +25079,17 @@ func (m *ErrorResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ErrorResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: ValidationResult: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ErrorResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ValidationResult: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
 			}
-			var stringLen uint64
+			var v ValidationResult_Result
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -5795,25 +25099,12 @@ func (m *ErrorResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				v |= ValidationResult_Result(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			s := string(dAtA[iNdEx:postIndex])
-			m.Msg = &s
-			iNdEx = postIndex
+			m.Result = &v
 			hasFields[0] |= uint64(0x00000001)
 		default:
 			iNdEx = preIndex
@@ -5832,7 +25123,7 @@ func (m *ErrorResponse) Unmarshal(dAtA []byte) error {
 		}
 	}
 	if hasFields[0]&uint64(0x00000001) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("msg")
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("result")
 	}
 
 	if iNdEx > l {
@@ -5840,8 +25131,7 @@ func (m *ErrorResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *StreamInfo) Unmarshal(dAtA []byte) error {
-	var hasFields [1]uint64
+func (m *PSResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5864,17 +25154,17 @@ func (m *StreamInfo) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: StreamInfo: wiretype end group for non-group")
+			return fmt.Errorf("proto: PSResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: StreamInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PSResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Topics", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -5884,30 +25174,27 @@ func (m *StreamInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthP2Pd
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Peer = append(m.Peer[:0], dAtA[iNdEx:postIndex]...)
-			if m.Peer == nil {
-				m.Peer = []byte{}
-			}
+			m.Topics = append(m.Topics, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-			hasFields[0] |= uint64(0x00000001)
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Addr", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PeerIDs", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -5934,46 +25221,9 @@ func (m *StreamInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Addr = append(m.Addr[:0], dAtA[iNdEx:postIndex]...)
-			if m.Addr == nil {
-				m.Addr = []byte{}
-			}
-			iNdEx = postIndex
-			hasFields[0] |= uint64(0x00000002)
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Proto", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowP2Pd
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			s := string(dAtA[iNdEx:postIndex])
-			m.Proto = &s
+			m.PeerIDs = append(m.PeerIDs, make([]byte, postIndex-iNdEx))
+			copy(m.PeerIDs[len(m.PeerIDs)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-			hasFields[0] |= uint64(0x00000004)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipP2Pd(dAtA[iNdEx:])
@@ -5990,22 +25240,13 @@ func (m *StreamInfo) Unmarshal(dAtA []byte) error {
 			iNdEx += skippy
 		}
 	}
-	if hasFields[0]&uint64(0x00000001) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
-	}
-	if hasFields[0]&uint64(0x00000002) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("addr")
-	}
-	if hasFields[0]&uint64(0x00000004) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("proto")
-	}
 
 	if iNdEx > l {
 		return io.ErrUnexpectedEOF
 	}
 	return nil
 }
-func (m *DHTRequest) Unmarshal(dAtA []byte) error {
+func (m *CallUnaryRequest) Unmarshal(dAtA []byte) error {
 	var hasFields [1]uint64
 	l := len(dAtA)
 	iNdEx := 0
@@ -6023,40 +25264,19 @@ func (m *DHTRequest) Unmarshal(dAtA []byte) error {
 			iNdEx++
 			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: DHTRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DHTRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
-			}
-			var v DHTRequest_Type
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowP2Pd
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= DHTRequest_Type(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.Type = &v
-			hasFields[0] |= uint64(0x00000001)
-		case 2:
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CallUnaryRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CallUnaryRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
 			}
@@ -6090,11 +25310,12 @@ func (m *DHTRequest) Unmarshal(dAtA []byte) error {
 				m.Peer = []byte{}
 			}
 			iNdEx = postIndex
-		case 3:
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Cid", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Proto", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -6104,29 +25325,29 @@ func (m *DHTRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthP2Pd
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Cid = append(m.Cid[:0], dAtA[iNdEx:postIndex]...)
-			if m.Cid == nil {
-				m.Cid = []byte{}
-			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Proto = &s
 			iNdEx = postIndex
-		case 4:
+			hasFields[0] |= uint64(0x00000002)
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -6153,16 +25374,37 @@ func (m *DHTRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
-			if m.Key == nil {
-				m.Key = []byte{}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
 			}
 			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000004)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimeoutMs", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.TimeoutMs = &v
 		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TraceParent", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -6172,31 +25414,30 @@ func (m *DHTRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthP2Pd
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
-			if m.Value == nil {
-				m.Value = []byte{}
-			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.TraceParent = &s
 			iNdEx = postIndex
 		case 6:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TraceState", wireType)
 			}
-			var v int32
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -6206,17 +25447,30 @@ func (m *DHTRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int32(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Count = &v
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.TraceState = &s
+			iNdEx = postIndex
 		case 7:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ChunkedSize", wireType)
 			}
-			var v int64
+			var v uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -6226,12 +25480,12 @@ func (m *DHTRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int64(b&0x7F) << shift
+				v |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Timeout = &v
+			m.ChunkedSize = &v
 		default:
 			iNdEx = preIndex
 			skippy, err := skipP2Pd(dAtA[iNdEx:])
@@ -6249,7 +25503,13 @@ func (m *DHTRequest) Unmarshal(dAtA []byte) error {
 		}
 	}
 	if hasFields[0]&uint64(0x00000001) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	}
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("proto")
+	}
+	if hasFields[0]&uint64(0x00000004) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("data")
 	}
 
 	if iNdEx > l {
@@ -6257,8 +25517,7 @@ func (m *DHTRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DHTResponse) Unmarshal(dAtA []byte) error {
-	var hasFields [1]uint64
+func (m *CallUnaryResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6281,72 +25540,15 @@ func (m *DHTResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DHTResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: CallUnaryResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DHTResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CallUnaryResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
-			}
-			var v DHTResponse_Type
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowP2Pd
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= DHTResponse_Type(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.Type = &v
-			hasFields[0] |= uint64(0x00000001)
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowP2Pd
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Peer == nil {
-				m.Peer = &PeerInfo{}
-			}
-			if err := m.Peer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Response", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -6373,69 +25575,13 @@ func (m *DHTResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
-			if m.Value == nil {
-				m.Value = []byte{}
-			}
+			v := make([]byte, postIndex-iNdEx)
+			copy(v, dAtA[iNdEx:postIndex])
+			m.Result = &CallUnaryResponse_Response{v}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipP2Pd(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-	if hasFields[0]&uint64(0x00000001) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *PeerInfo) Unmarshal(dAtA []byte) error {
-	var hasFields [1]uint64
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowP2Pd
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: PeerInfo: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PeerInfo: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -6462,17 +25608,15 @@ func (m *PeerInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Id = append(m.Id[:0], dAtA[iNdEx:postIndex]...)
-			if m.Id == nil {
-				m.Id = []byte{}
-			}
+			v := make([]byte, postIndex-iNdEx)
+			copy(v, dAtA[iNdEx:postIndex])
+			m.Result = &CallUnaryResponse_Error{v}
 			iNdEx = postIndex
-			hasFields[0] |= uint64(0x00000001)
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Addrs", wireType)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChunkedSize", wireType)
 			}
-			var byteLen int
+			var v uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -6482,24 +25626,12 @@ func (m *PeerInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				v |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Addrs = append(m.Addrs, make([]byte, postIndex-iNdEx))
-			copy(m.Addrs[len(m.Addrs)-1], dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
+			m.ChunkedSize = &v
 		default:
 			iNdEx = preIndex
 			skippy, err := skipP2Pd(dAtA[iNdEx:])
@@ -6516,16 +25648,13 @@ func (m *PeerInfo) Unmarshal(dAtA []byte) error {
 			iNdEx += skippy
 		}
 	}
-	if hasFields[0]&uint64(0x00000001) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("id")
-	}
 
 	if iNdEx > l {
 		return io.ErrUnexpectedEOF
 	}
 	return nil
 }
-func (m *ConnManagerRequest) Unmarshal(dAtA []byte) error {
+func (m *AddUnaryHandlerRequest) Unmarshal(dAtA []byte) error {
 	var hasFields [1]uint64
 	l := len(dAtA)
 	iNdEx := 0
@@ -6549,17 +25678,17 @@ func (m *ConnManagerRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ConnManagerRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: AddUnaryHandlerRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ConnManagerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AddUnaryHandlerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proto", wireType)
 			}
-			var v ConnManagerRequest_Type
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -6569,18 +25698,31 @@ func (m *ConnManagerRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= ConnManagerRequest_Type(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Type = &v
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Proto = &s
+			iNdEx = postIndex
 			hasFields[0] |= uint64(0x00000001)
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxConcurrentCalls", wireType)
 			}
-			var byteLen int
+			var v int32
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -6590,31 +25732,17 @@ func (m *ConnManagerRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				v |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Peer = append(m.Peer[:0], dAtA[iNdEx:postIndex]...)
-			if m.Peer == nil {
-				m.Peer = []byte{}
-			}
-			iNdEx = postIndex
+			m.MaxConcurrentCalls = &v
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Tag", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OverflowPolicy", wireType)
 			}
-			var stringLen uint64
+			var v AddUnaryHandlerRequest_OverflowPolicy
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -6624,30 +25752,59 @@ func (m *ConnManagerRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				v |= AddUnaryHandlerRequest_OverflowPolicy(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthP2Pd
+			m.OverflowPolicy = &v
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Replace", wireType)
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthP2Pd
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			b := bool(v != 0)
+			m.Replace = &b
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Raw", wireType)
 			}
-			s := string(dAtA[iNdEx:postIndex])
-			m.Tag = &s
-			iNdEx = postIndex
-		case 4:
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			b := bool(v != 0)
+			m.Raw = &b
+		case 6:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Weight", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Window", wireType)
 			}
-			var v int64
+			var v uint32
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -6657,12 +25814,12 @@ func (m *ConnManagerRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int64(b&0x7F) << shift
+				v |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Weight = &v
+			m.Window = &v
 		default:
 			iNdEx = preIndex
 			skippy, err := skipP2Pd(dAtA[iNdEx:])
@@ -6680,7 +25837,7 @@ func (m *ConnManagerRequest) Unmarshal(dAtA []byte) error {
 		}
 	}
 	if hasFields[0]&uint64(0x00000001) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("proto")
 	}
 
 	if iNdEx > l {
@@ -6688,7 +25845,7 @@ func (m *ConnManagerRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DisconnectRequest) Unmarshal(dAtA []byte) error {
+func (m *RemoveUnaryHandlerRequest) Unmarshal(dAtA []byte) error {
 	var hasFields [1]uint64
 	l := len(dAtA)
 	iNdEx := 0
@@ -6712,17 +25869,17 @@ func (m *DisconnectRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DisconnectRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: RemoveUnaryHandlerRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DisconnectRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RemoveUnaryHandlerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Proto", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -6732,25 +25889,24 @@ func (m *DisconnectRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthP2Pd
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Peer = append(m.Peer[:0], dAtA[iNdEx:postIndex]...)
-			if m.Peer == nil {
-				m.Peer = []byte{}
-			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Proto = &s
 			iNdEx = postIndex
 			hasFields[0] |= uint64(0x00000001)
 		default:
@@ -6770,7 +25926,7 @@ func (m *DisconnectRequest) Unmarshal(dAtA []byte) error {
 		}
 	}
 	if hasFields[0]&uint64(0x00000001) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("proto")
 	}
 
 	if iNdEx > l {
@@ -6778,8 +25934,7 @@ func (m *DisconnectRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PSRequest) Unmarshal(dAtA []byte) error {
-	var hasFields [1]uint64
+func (m *DaemonError) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6802,36 +25957,15 @@ func (m *PSRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PSRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: DaemonError: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PSRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DaemonError: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
-			}
-			var v PSRequest_Type
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowP2Pd
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= PSRequest_Type(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.Type = &v
-			hasFields[0] |= uint64(0x00000001)
-		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Topic", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -6860,13 +25994,13 @@ func (m *PSRequest) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			s := string(dAtA[iNdEx:postIndex])
-			m.Topic = &s
+			m.Message = &s
 			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
 			}
-			var byteLen int
+			var v DaemonError_Code
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -6876,26 +26010,114 @@ func (m *PSRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				v |= DaemonError_Code(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			m.Code = &v
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Cancel) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Cancel: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Cancel: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CancelAll) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CancelAll: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CancelAll: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
 		default:
 			iNdEx = preIndex
 			skippy, err := skipP2Pd(dAtA[iNdEx:])
@@ -6912,16 +26134,14 @@ func (m *PSRequest) Unmarshal(dAtA []byte) error {
 			iNdEx += skippy
 		}
 	}
-	if hasFields[0]&uint64(0x00000001) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
-	}
 
 	if iNdEx > l {
 		return io.ErrUnexpectedEOF
 	}
 	return nil
 }
-func (m *PSMessage) Unmarshal(dAtA []byte) error {
+func (m *CancelAllResponse) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6944,151 +26164,17 @@ func (m *PSMessage) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PSMessage: wiretype end group for non-group")
+			return fmt.Errorf("proto: CancelAllResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PSMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CancelAllResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field From", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowP2Pd
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.From = append(m.From[:0], dAtA[iNdEx:postIndex]...)
-			if m.From == nil {
-				m.From = []byte{}
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowP2Pd
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
-			}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Seqno", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowP2Pd
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Seqno = append(m.Seqno[:0], dAtA[iNdEx:postIndex]...)
-			if m.Seqno == nil {
-				m.Seqno = []byte{}
-			}
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TopicIDs", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowP2Pd
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.TopicIDs = append(m.TopicIDs, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cancelled", wireType)
 			}
-			var byteLen int
+			var v int32
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -7098,60 +26184,67 @@ func (m *PSMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				v |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthP2Pd
+			m.Cancelled = &v
+			hasFields[0] |= uint64(0x00000001)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
-			if m.Signature == nil {
-				m.Signature = []byte{}
-			}
-			iNdEx = postIndex
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowP2Pd
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthP2Pd
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("cancelled")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *EndOfStream) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
 			}
-			if postIndex > l {
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
-			if m.Key == nil {
-				m.Key = []byte{}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EndOfStream: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EndOfStream: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
 		default:
 			iNdEx = preIndex
 			skippy, err := skipP2Pd(dAtA[iNdEx:])
@@ -7174,7 +26267,7 @@ func (m *PSMessage) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PSResponse) Unmarshal(dAtA []byte) error {
+func (m *Ping) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7197,76 +26290,12 @@ func (m *PSResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PSResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: Ping: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PSResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Ping: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Topics", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowP2Pd
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Topics = append(m.Topics, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PeerIDs", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowP2Pd
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.PeerIDs = append(m.PeerIDs, make([]byte, postIndex-iNdEx))
-			copy(m.PeerIDs[len(m.PeerIDs)-1], dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipP2Pd(dAtA[iNdEx:])
@@ -7289,8 +26318,7 @@ func (m *PSResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CallUnaryRequest) Unmarshal(dAtA []byte) error {
-	var hasFields [1]uint64
+func (m *Pong) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7313,116 +26341,63 @@ func (m *CallUnaryRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CallUnaryRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: Pong: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CallUnaryRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Pong: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowP2Pd
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Peer = append(m.Peer[:0], dAtA[iNdEx:postIndex]...)
-			if m.Peer == nil {
-				m.Peer = []byte{}
-			}
-			iNdEx = postIndex
-			hasFields[0] |= uint64(0x00000001)
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Proto", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowP2Pd
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthP2Pd
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			s := string(dAtA[iNdEx:postIndex])
-			m.Proto = &s
-			iNdEx = postIndex
-			hasFields[0] |= uint64(0x00000002)
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowP2Pd
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthP2Pd
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetStatsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
 			}
-			if postIndex > l {
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
-			hasFields[0] |= uint64(0x00000004)
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetStatsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetStatsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
 		default:
 			iNdEx = preIndex
 			skippy, err := skipP2Pd(dAtA[iNdEx:])
@@ -7439,22 +26414,14 @@ func (m *CallUnaryRequest) Unmarshal(dAtA []byte) error {
 			iNdEx += skippy
 		}
 	}
-	if hasFields[0]&uint64(0x00000001) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
-	}
-	if hasFields[0]&uint64(0x00000002) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("proto")
-	}
-	if hasFields[0]&uint64(0x00000004) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("data")
-	}
 
 	if iNdEx > l {
 		return io.ErrUnexpectedEOF
 	}
 	return nil
 }
-func (m *CallUnaryResponse) Unmarshal(dAtA []byte) error {
+func (m *UnaryHandlerInfo) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7477,17 +26444,17 @@ func (m *CallUnaryResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CallUnaryResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: UnaryHandlerInfo: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CallUnaryResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: UnaryHandlerInfo: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Response", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Proto", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -7497,30 +26464,31 @@ func (m *CallUnaryResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthP2Pd
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := make([]byte, postIndex-iNdEx)
-			copy(v, dAtA[iNdEx:postIndex])
-			m.Result = &CallUnaryResponse_Response{v}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Proto = &s
 			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Enabled", wireType)
 			}
-			var byteLen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -7530,25 +26498,14 @@ func (m *CallUnaryResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			v := make([]byte, postIndex-iNdEx)
-			copy(v, dAtA[iNdEx:postIndex])
-			m.Result = &CallUnaryResponse_Error{v}
-			iNdEx = postIndex
+			}
+			b := bool(v != 0)
+			m.Enabled = &b
+			hasFields[0] |= uint64(0x00000002)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipP2Pd(dAtA[iNdEx:])
@@ -7565,13 +26522,19 @@ func (m *CallUnaryResponse) Unmarshal(dAtA []byte) error {
 			iNdEx += skippy
 		}
 	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("proto")
+	}
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("enabled")
+	}
 
 	if iNdEx > l {
 		return io.ErrUnexpectedEOF
 	}
 	return nil
 }
-func (m *AddUnaryHandlerRequest) Unmarshal(dAtA []byte) error {
+func (m *StatsResponse) Unmarshal(dAtA []byte) error {
 	var hasFields [1]uint64
 	l := len(dAtA)
 	iNdEx := 0
@@ -7595,17 +26558,17 @@ func (m *AddUnaryHandlerRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AddUnaryHandlerRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: StatsResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AddUnaryHandlerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: StatsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Proto", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field UnaryHandlers", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -7615,26 +26578,68 @@ func (m *AddUnaryHandlerRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthP2Pd
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			s := string(dAtA[iNdEx:postIndex])
-			m.Proto = &s
+			m.UnaryHandlers = append(m.UnaryHandlers, &UnaryHandlerInfo{})
+			if err := m.UnaryHandlers[len(m.UnaryHandlers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActiveCalls", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ActiveCalls = &v
 			hasFields[0] |= uint64(0x00000001)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PendingResponses", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.PendingResponses = &v
+			hasFields[0] |= uint64(0x00000002)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipP2Pd(dAtA[iNdEx:])
@@ -7652,7 +26657,10 @@ func (m *AddUnaryHandlerRequest) Unmarshal(dAtA []byte) error {
 		}
 	}
 	if hasFields[0]&uint64(0x00000001) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("proto")
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("activeCalls")
+	}
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("pendingResponses")
 	}
 
 	if iNdEx > l {
@@ -7660,7 +26668,7 @@ func (m *AddUnaryHandlerRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DaemonError) Unmarshal(dAtA []byte) error {
+func (m *SubscribeEventsRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7683,45 +26691,81 @@ func (m *DaemonError) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DaemonError: wiretype end group for non-group")
+			return fmt.Errorf("proto: SubscribeEventsRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DaemonError: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SubscribeEventsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowP2Pd
+			if wireType == 0 {
+				var v Event_Type
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowP2Pd
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= Event_Type(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
 				}
-				if iNdEx >= l {
+				m.Types = append(m.Types, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowP2Pd
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthP2Pd
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthP2Pd
+				}
+				if postIndex > l {
 					return io.ErrUnexpectedEOF
 				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
+				var elementCount int
+				if elementCount != 0 && len(m.Types) == 0 {
+					m.Types = make([]Event_Type, 0, elementCount)
 				}
+				for iNdEx < postIndex {
+					var v Event_Type
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowP2Pd
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= Event_Type(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Types = append(m.Types, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Types", wireType)
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			s := string(dAtA[iNdEx:postIndex])
-			m.Message = &s
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipP2Pd(dAtA[iNdEx:])
@@ -7744,7 +26788,8 @@ func (m *DaemonError) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Cancel) Unmarshal(dAtA []byte) error {
+func (m *Event) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7767,12 +26812,173 @@ func (m *Cancel) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Cancel: wiretype end group for non-group")
+			return fmt.Errorf("proto: Event: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Cancel: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Event: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var v Event_Type
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= Event_Type(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Type = &v
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reachability", wireType)
+			}
+			var v Event_Reachability
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= Event_Reachability(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Reachability = &v
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peer = append(m.Peer[:0], dAtA[iNdEx:postIndex]...)
+			if m.Peer == nil {
+				m.Peer = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Connectedness", wireType)
+			}
+			var v Event_Connectedness
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= Event_Connectedness(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Connectedness = &v
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addrs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addrs = append(m.Addrs, make([]byte, postIndex-iNdEx))
+			copy(m.Addrs[len(m.Addrs)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addr", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addr = append(m.Addr[:0], dAtA[iNdEx:postIndex]...)
+			if m.Addr == nil {
+				m.Addr = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipP2Pd(dAtA[iNdEx:])
@@ -7789,6 +26995,9 @@ func (m *Cancel) Unmarshal(dAtA []byte) error {
 			iNdEx += skippy
 		}
 	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+	}
 
 	if iNdEx > l {
 		return io.ErrUnexpectedEOF
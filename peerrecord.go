@@ -0,0 +1,67 @@
+package p2pd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	"github.com/libp2p/go-libp2p-core/record"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+// errNoCertifiedAddrBook is returned when consuming a signed peer record
+// with a peerstore that can't keep it.
+var errNoCertifiedAddrBook = errors.New("peerstore doesn't support signed peer records")
+
+// signedPeerRecord returns the envelope of the signed peer record the
+// peerstore keeps for p, or nil if there is none: the peer doesn't sign its
+// records, or the peerstore can't keep them. The host keeps its own record.
+func (d *Daemon) signedPeerRecord(p peer.ID) []byte {
+	cab, ok := peerstore.GetCertifiedAddrBook(d.host.Peerstore())
+	if !ok {
+		return nil
+	}
+	envelope := cab.GetPeerRecord(p)
+	if envelope == nil {
+		return nil
+	}
+
+	data, err := envelope.Marshal()
+	if err != nil {
+		log.Debugw("error marshalling signed peer record", "peer", p, "error", err)
+		return nil
+	}
+	return data
+}
+
+// consumeSignedPeerRecord checks that data is the envelope of a peer record
+// signed by p and adds its addresses to the peerstore for ttl, in place of
+// the unsigned ones. It returns the addresses of the record.
+func (d *Daemon) consumeSignedPeerRecord(p peer.ID, data []byte, ttl time.Duration) ([]ma.Multiaddr, error) {
+	cab, ok := peerstore.GetCertifiedAddrBook(d.host.Peerstore())
+	if !ok {
+		return nil, errNoCertifiedAddrBook
+	}
+
+	// the envelope is only consumed if its signature is valid
+	envelope, rec, err := record.ConsumeEnvelope(data, peer.PeerRecordEnvelopeDomain)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signed peer record: %w", err)
+	}
+	peerRec, ok := rec.(*peer.PeerRecord)
+	if !ok {
+		return nil, errors.New("signed record is not a peer record")
+	}
+	if peerRec.PeerID != p {
+		return nil, fmt.Errorf("signed peer record is for peer %s", peerRec.PeerID.Pretty())
+	}
+
+	// a record older than the one kept is ignored, but its addresses are
+	// still the peer's
+	if _, err := cab.ConsumePeerRecord(envelope, ttl); err != nil {
+		return nil, err
+	}
+	return peerRec.Addrs, nil
+}
@@ -0,0 +1,138 @@
+package p2pd
+
+import (
+	"context"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+
+	ds "github.com/ipfs/go-datastore"
+	leveldb "github.com/ipfs/go-ds-leveldb"
+	"github.com/libp2p/go-libp2p-peerstore/pstoreds"
+)
+
+// NewPersistentPeerstore opens a peerstore backed by a leveldb datastore at
+// path, so that peer addresses, keys and protocols survive restarts. The
+// datastore is synced to disk every flushInterval until ctx is done. The
+// returned function stops the flushing and closes the datastore.
+func NewPersistentPeerstore(ctx context.Context, path string, flushInterval time.Duration) (peerstore.Peerstore, func() error, error) {
+	store, err := leveldb.NewDatastore(path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ps, err := pstoreds.NewPeerstore(ctx, store, pstoreds.DefaultOpts())
+	if err != nil {
+		store.Close()
+		return nil, nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		if flushInterval <= 0 {
+			return
+		}
+
+		ticker := time.NewTicker(flushInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := store.Sync(ds.NewKey("/")); err != nil {
+					log.Warnw("error flushing peerstore", "error", err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	closer := func() error {
+		cancel()
+		<-done
+
+		if err := store.Sync(ds.NewKey("/")); err != nil {
+			log.Warnw("error flushing peerstore", "error", err)
+		}
+		return store.Close()
+	}
+
+	return ps, closer, nil
+}
+
+// doPeerstore adds addresses of a peer learned out of band to the peerstore,
+// e.g. from a registry in a private network without a DHT, or forgets them.
+// It also reports the protocols of a peer, so that clients can tell whether a
+// call would go through without opening a stream.
+func (d *Daemon) doPeerstore(req *pb.Request) *pb.Response {
+	if req.Peerstore == nil {
+		return errorResponseString("Malformed request; missing parameters")
+	}
+
+	p, err := peer.IDFromBytes(req.Peerstore.GetPeer())
+	if err != nil {
+		return errorResponse(err)
+	}
+
+	switch req.Peerstore.GetType() {
+	case pb.PeerstoreRequest_ADD_ADDRS:
+		addrs := make([]ma.Multiaddr, len(req.Peerstore.GetAddrs()))
+		for x, bs := range req.Peerstore.GetAddrs() {
+			addrs[x], err = ma.NewMultiaddrBytes(bs)
+			if err != nil {
+				return errorResponse(err)
+			}
+		}
+
+		ttl := peerstore.AddressTTL
+		if req.Peerstore.Ttl != nil {
+			if req.Peerstore.GetTtl() <= 0 {
+				return errorResponseString("address TTL must be positive")
+			}
+			ttl = time.Duration(req.Peerstore.GetTtl()) * time.Second
+		}
+
+		// addresses of a peer with a signed peer record are ignored
+		d.host.Peerstore().AddAddrs(p, addrs, ttl)
+		log.Debugw("added addresses", "peer", p, "addrs", addrs, "ttl", ttl)
+
+	case pb.PeerstoreRequest_CLEAR_ADDRS:
+		d.host.Peerstore().ClearAddrs(p)
+		log.Debugw("cleared addresses", "peer", p)
+
+	case pb.PeerstoreRequest_GET_PROTOCOLS:
+		// protocols are learned from identify, so they are unknown for peers
+		// the host hasn't connected to yet
+		protos, err := d.host.Peerstore().GetProtocols(p)
+		if err != nil {
+			return errorResponse(err)
+		}
+		var supported []string
+		if len(req.Peerstore.GetProtos()) > 0 {
+			supported, err = d.host.Peerstore().SupportsProtocols(p, req.Peerstore.GetProtos()...)
+			if err != nil {
+				return errorResponse(err)
+			}
+		}
+
+		res := okResponse()
+		res.Peerstore = &pb.PeerstoreResponse{
+			Protocols: protos,
+			Supported: supported,
+		}
+		return res
+
+	default:
+		return errorResponseString("Unexpected request")
+	}
+
+	return okResponse()
+}
@@ -2,20 +2,162 @@ package p2pd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
+	swarm "github.com/libp2p/go-libp2p-swarm"
+	"github.com/multiformats/go-multistream"
 
 	ggio "github.com/gogo/protobuf/io"
+	"github.com/gogo/protobuf/proto"
 	"github.com/libp2p/go-libp2p-core/protocol"
 	"github.com/libp2p/go-libp2p-daemon/internal/utils"
 	pb "github.com/libp2p/go-libp2p-daemon/pb"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// SetMaxPersistentConns caps the number of persistent connections clients may
+// have open at once; upgrade requests beyond it are rejected. Zero removes the
+// cap, which is the default. Connections already open are kept.
+func (d *Daemon) SetMaxPersistentConns(max int) {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	d.maxPersistentConns = max
+}
+
+// SetPersistentConnQueueSize bounds the number of messages waiting to be
+// written to each persistent connection; messages beyond it are dropped,
+// except the responses clients wait for and payload chunks, which wait for
+// room. It is 1024 by default and applies to persistent connections opened
+// afterwards.
+func (d *Daemon) SetPersistentConnQueueSize(size int) {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	d.persistentConnQueueSize = size
+}
+
+// SetMaxUnaryMessageSize bounds the size of messages the daemon accepts from
+// remote peers on unary streams. It is network.MessageSizeMax by default.
+func (d *Daemon) SetMaxUnaryMessageSize(size int) {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	d.unary.maxMessageSize = size
+}
+
+// SetUnaryChunking sets the size of the chunks the payloads of unary calls
+// and their responses are split into when larger, on persistent connections
+// and on the streams to other daemons alike, so that each message stays small
+// whatever the payload, and bounds the size of a payload sent in chunks, by a
+// client or a remote peer, to maxPayloadSize.
+//
+// Payloads are never split if chunkSize is zero, the default: the daemon
+// always reassembles chunks it receives, but daemons predating chunking and
+// clients that don't implement it can't, so chunkSize should only be set when
+// every peer and client the daemon talks to supports it. The chunks written to
+// a client wait for room in the persistent connection's queue, see
+// SetPersistentConnQueueSize, so payloads may have more chunks than the queue
+// holds. maxPayloadSize is DefaultMaxChunkedPayloadSize by default.
+func (d *Daemon) SetUnaryChunking(chunkSize, maxPayloadSize int) {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	d.unary.chunkSize = chunkSize
+	d.unary.maxChunkedPayloadSize = maxPayloadSize
+}
+
+// SetRetryUnaryDials has unary and stream calls to a peer in dial backoff,
+// after a failed dial, open their stream again once with a direct dial. Calls
+// then go through as soon as the peer is reachable again, at the cost of a
+// dial per call while it isn't, which the backoff is there to avoid. It is
+// disabled by default.
+//
+// Streams are not pooled across calls: remote handlers close the stream once
+// they have answered a single request, so reusing them would need a new
+// protocol. Calls to a connected peer only pay for opening a stream on the
+// existing connection and negotiating the protocol.
+func (d *Daemon) SetRetryUnaryDials(retry bool) {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	d.unary.retryDials = retry
+}
+
+// SetNegotiationTimeout bounds how long negotiating the protocol of a unary or
+// stream call with a connected peer may take, so that calls to peers stalling
+// there fail fast while the calls themselves can still run for long. Dialing
+// the peer is bounded by the call's timeout and the dial timeout only. The
+// negotiation is only bounded by the call's timeout if zero, the default.
+//
+// A peer already known to support the protocol, e.g. after an earlier call,
+// is negotiated with lazily along with the request, which only the call's
+// timeout bounds.
+func (d *Daemon) SetNegotiationTimeout(timeout time.Duration) {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	d.unary.negotiationTimeout = timeout
+}
+
+// unaryConfig returns the settings of unary and stream calls.
+func (d *Daemon) unaryConfig() unaryConfig {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	return d.unary
+}
+
+// acquirePersistentConn counts a new persistent connection, unless the
+// maximum, which it returns, is reached.
+func (d *Daemon) acquirePersistentConn() (int, bool) {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	if d.maxPersistentConns > 0 && d.persistentConnCount >= d.maxPersistentConns {
+		return d.maxPersistentConns, false
+	}
+	d.persistentConnCount++
+	return d.maxPersistentConns, true
+}
+
+func (d *Daemon) releasePersistentConn() {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	d.persistentConnCount--
+}
+
 func (d *Daemon) handlePersistentConn(r ggio.Reader, unsafeW ggio.WriteCloser) {
+	if max, ok := d.acquirePersistentConn(); !ok {
+		rejectedPersistentConns.Inc()
+		log.Warnw("rejecting persistent connection", "max", max)
+		if err := unsafeW.WriteMsg(errorResponseString(
+			fmt.Sprintf("too many persistent connections; the maximum is %d", max),
+		)); err != nil {
+			log.Debugw("error writing message", "error", err)
+		}
+		return
+	}
+	defer d.releasePersistentConn()
+
+	// responses are queued rather than written by each call's goroutine, so
+	// that a client which stops reading can't pile them up
+	d.mx.Lock()
+	queueSize := d.persistentConnQueueSize
+	d.mx.Unlock()
+	w := utils.NewQueuedWriter(unsafeW, queueSize, persistentConnQueued)
+	defer w.Close()
+	defer d.releaseCalls(w)
+
 	var streamHandlers []string
 	defer func() {
 		d.mx.Lock()
@@ -23,8 +165,23 @@ func (d *Daemon) handlePersistentConn(r ggio.Reader, unsafeW ggio.WriteCloser) {
 
 		for _, proto := range streamHandlers {
 			p := protocol.ID(proto)
+			// handlers replaced by another connection are left alone
+			if d.unaryHandlerOwners[p] != ggio.Writer(w) {
+				continue
+			}
 			d.host.RemoveStreamHandler(p)
 			delete(d.registeredUnaryProtocols, p)
+			delete(d.unaryHandlerOwners, p)
+		}
+
+		for topic, owner := range d.topicValidatorOwners {
+			if owner != ggio.Writer(w) {
+				continue
+			}
+			if err := d.pubsub.UnregisterTopicValidator(topic); err != nil {
+				log.Debugw("error unregistering topic validator", "topic", topic, "error", err)
+			}
+			delete(d.topicValidatorOwners, topic)
 		}
 	}()
 
@@ -37,25 +194,173 @@ func (d *Daemon) handlePersistentConn(r ggio.Reader, unsafeW ggio.WriteCloser) {
 
 	d.terminateOnce.Do(func() { go d.awaitTermination() })
 
-	w := utils.NewSafeWriter(unsafeW)
+	persistentConns.Inc()
+	defer persistentConns.Dec()
+
+	// connCtx ends calls that must not outlive the connection, such as event
+	// and pubsub subscriptions
+	connCtx, cancelConn := context.WithCancel(d.ctx)
+	defer cancelConn()
+
+	// the client can't get the responses of its calls once writing to it
+	// fails, so they are cancelled rather than left running until their
+	// timeouts, and closing the connection ends the read loop below
+	go func() {
+		select {
+		case <-w.Failed():
+			log.Debugw("error writing to persistent connection", "error", w.Err())
+			cancelConn()
+			d.releaseCalls(w)
+			w.Close()
+		case <-connCtx.Done():
+		}
+	}()
 
-	if err := w.WriteMsg(&pb.Response{Type: pb.Response_OK.Enum()}); err != nil {
+	if err := w.WriteMsg(&pb.Response{
+		Type:       pb.Response_OK.Enum(),
+		InstanceId: d.instanceID[:],
+	}); err != nil {
 		log.Debugw("error writing message", "error", err)
 		return
 	}
 
+	d.mx.Lock()
+	interval, maxMissed := d.keepAliveInterval, d.keepAliveMaxMissed
+	d.mx.Unlock()
+
+	received := make(chan struct{}, 1)
+	if interval > 0 {
+		go d.keepAlive(connCtx, w, w, received, interval, maxMissed)
+	}
+
+	// callID -> the message whose payload is still being received in chunks
+	chunked := make(map[uuid.UUID]pendingPayload)
+
 	for {
 		var req pb.PersistentConnectionRequest
-		if err := r.ReadMsg(&req); err != nil {
+		if err := r.ReadMsg(&req); err == io.ErrShortBuffer {
+			// the rest of the message can't be skipped, nor its call
+			// answered, so the connection is closed with an explanation
+			oversizedClientMsgs.Inc()
+			log.Warnw("closing persistent connection: message too large", "max", controlMsgSizeMax)
+			w.WriteMsg(errorUnaryCallCode(uuid.Nil, pb.DaemonError_MESSAGE_TOO_LARGE,
+				fmt.Sprintf("client message exceeds the maximum size of %d bytes; closing the connection", controlMsgSizeMax)))
+			if err := w.Flush(time.Second); err != nil {
+				log.Debugw("error flushing persistent connection", "error", err)
+			}
+			return
+		} else if err != nil {
 			log.Debugw("error reading message", "error", err)
 			return
 		}
 
-		go d.handlePersistentConnRequest(req, w, &streamHandlers)
+		select {
+		case received <- struct{}{}:
+		default:
+		}
+
+		if req.GetStreamFrame() != nil {
+			// stream data must be handled in order
+			d.deliverStreamFrame(&req, w)
+			continue
+		}
+
+		// so are payload chunks, the message they belong to being handled
+		// once they are all received
+		msg := d.reassemblePayload(chunked, &req, w)
+		if msg == nil {
+			continue
+		}
+
+		go d.handlePersistentConnRequest(connCtx, *msg, w, &streamHandlers)
+	}
+}
+
+// pendingPayload is a message from a client whose payload follows in chunks.
+type pendingPayload struct {
+	req      *pb.PersistentConnectionRequest
+	assembly *utils.ChunkAssembly
+}
+
+// reassemblePayload keeps track of the messages of a client whose payload
+// follows in chunks in chunked, by call id. It returns req as is unless it is
+// such a message or a chunk, and the message with its full payload once its
+// last chunk is received; it returns nil otherwise. A payload that can't be
+// reassembled fails its call.
+func (d *Daemon) reassemblePayload(chunked map[uuid.UUID]pendingPayload, req *pb.PersistentConnectionRequest, w ggio.Writer) *pb.PersistentConnectionRequest {
+	callID, err := uuid.FromBytes(req.CallId)
+	if err != nil {
+		return req
+	}
+
+	chunk := req.GetChunk()
+	if chunk == nil {
+		if req.GetCancel() != nil {
+			delete(chunked, callID)
+			return req
+		}
+
+		assembly, err := utils.NewChunkAssembly(req, d.unaryConfig().maxChunkedPayloadSize)
+		if err != nil {
+			d.failPayload(callID, req, err, w)
+			return nil
+		}
+		if assembly == nil {
+			return req
+		}
+		chunked[callID] = pendingPayload{req, assembly}
+		return nil
+	}
+
+	pending, found := chunked[callID]
+	if !found {
+		log.Debugw("dropping payload chunk of unknown call", "callID", callID)
+		return nil
+	}
+	msg, err := pending.assembly.Add(chunk)
+	if err != nil {
+		delete(chunked, callID)
+		d.failPayload(callID, pending.req, err, w)
+		return nil
+	}
+	if msg == nil {
+		return nil
+	}
+	delete(chunked, callID)
+	return msg.(*pb.PersistentConnectionRequest)
+}
+
+// failPayload reports that the payload of req couldn't be reassembled, to the
+// client for a call, and to the caller for the response to an incoming call.
+func (d *Daemon) failPayload(callID uuid.UUID, req *pb.PersistentConnectionRequest, err error, w ggio.Writer) {
+	log.Debugw("error reassembling chunked payload", "callID", callID, "error", err)
+
+	if req.GetUnaryResponse() == nil {
+		if err := w.WriteMsg(errorUnaryCall(callID, err)); err != nil {
+			log.Debugw("error writing message", "error", err)
+		}
+		return
+	}
+
+	failed := &pb.PersistentConnectionRequest{
+		CallId: req.CallId,
+		Message: &pb.PersistentConnectionRequest_UnaryResponse{
+			UnaryResponse: &pb.CallUnaryResponse{
+				Result: &pb.CallUnaryResponse_Error{Error: []byte(err.Error())},
+			},
+		},
 	}
+	// handing the response over may wait for the call
+	go func() {
+		if resp := d.sendReponseToRemote(failed, w); resp != nil {
+			if err := w.WriteMsg(resp); err != nil {
+				log.Debugw("error writing message", "error", err)
+			}
+		}
+	}()
 }
 
-func (d *Daemon) handlePersistentConnRequest(req pb.PersistentConnectionRequest, w ggio.WriteCloser, streamHandlers *[]string) {
+func (d *Daemon) handlePersistentConnRequest(connCtx context.Context, req pb.PersistentConnectionRequest, w *utils.QueuedWriter, streamHandlers *[]string) {
 	callID, err := uuid.FromBytes(req.CallId)
 	if err != nil {
 		log.Debugw("bad call id: ", "error", err)
@@ -75,27 +380,79 @@ func (d *Daemon) handlePersistentConnRequest(req pb.PersistentConnectionRequest,
 		}
 		d.mx.Unlock()
 
-		if err := w.WriteMsg(resp); err != nil {
-			log.Debugw("error reading message", "error", err)
-			return
+		d.writeResponse(w, resp)
+
+	case *pb.PersistentConnectionRequest_RemoveUnaryHandler:
+		resp := d.doRemoveUnaryHandler(w, callID, req.GetRemoveUnaryHandler())
+
+		d.mx.Lock()
+		if _, ok := resp.Message.(*pb.PersistentConnectionResponse_DaemonError); !ok {
+			proto := req.GetRemoveUnaryHandler().GetProto()
+			for i, p := range *streamHandlers {
+				if p == proto {
+					*streamHandlers = append((*streamHandlers)[:i], (*streamHandlers)[i+1:]...)
+					break
+				}
+			}
 		}
+		d.mx.Unlock()
+
+		d.writeResponse(w, resp)
 
 	case *pb.PersistentConnectionRequest_CallUnary:
-		ctx, cancel := context.WithCancel(context.Background())
-		d.cancelUnary.Store(callID, cancel)
+		ctx, cancel := context.WithCancel(connCtx)
 		defer cancel()
 
+		if !d.trackCall(callID, inflightCall{cancel, w}) {
+			d.writeResponse(w, errorDuplicateCallID(callID))
+			return
+		}
 		defer d.cancelUnary.Delete(callID)
 
-		resp := d.doUnaryCall(ctx, callID, &req)
+		d.writeResponse(w, d.doUnaryCall(ctx, callID, &req))
 
-		if err := w.WriteMsg(resp); err != nil {
-			log.Debugw("error reading message", "error", err)
+	case *pb.PersistentConnectionRequest_CallStream:
+		ctx, cancel := context.WithCancel(connCtx)
+		defer cancel()
+
+		if !d.trackCall(callID, inflightCall{cancel, w}) {
+			d.writeResponse(w, errorDuplicateCallID(callID))
 			return
 		}
+		defer d.cancelUnary.Delete(callID)
+
+		d.doStreamCall(ctx, callID, &req, w)
 
 	case *pb.PersistentConnectionRequest_UnaryResponse:
-		d.sendReponseToRemote(&req)
+		if resp := d.sendReponseToRemote(&req, w); resp != nil {
+			if err := w.WriteMsg(resp); err != nil {
+				log.Debugw("error writing message", "error", err)
+				return
+			}
+		}
+
+	case *pb.PersistentConnectionRequest_GetStats:
+		d.writeResponse(w, d.doGetStats(callID))
+
+	case *pb.PersistentConnectionRequest_SubscribeEvents:
+		d.doSubscribeEvents(connCtx, callID, req.GetSubscribeEvents(), w)
+
+	case *pb.PersistentConnectionRequest_Pubsub:
+		d.doPersistentPubsub(connCtx, callID, req.GetPubsub(), w)
+
+	case *pb.PersistentConnectionRequest_AddTopicValidator:
+		d.writeResponse(w, d.doAddTopicValidator(w, callID, req.GetAddTopicValidator()))
+
+	case *pb.PersistentConnectionRequest_ValidationResult:
+		if resp := d.sendReponseToRemote(&req, w); resp != nil {
+			if err := w.WriteMsg(resp); err != nil {
+				log.Debugw("error writing message", "error", err)
+				return
+			}
+		}
+
+	case *pb.PersistentConnectionRequest_Pong:
+		// the keepalive only cares that something was received
 
 	case *pb.PersistentConnectionRequest_Cancel:
 		cf, found := d.cancelUnary.Load(callID)
@@ -103,63 +460,345 @@ func (d *Daemon) handlePersistentConnRequest(req pb.PersistentConnectionRequest,
 			return
 		}
 
-		cf.(context.CancelFunc)()
+		// call ids are chosen by clients, so they may know those of others
+		call := cf.(inflightCall)
+		if call.owner != ggio.Writer(w) {
+			if err := w.WriteMsg(errorCallNotOwned(callID)); err != nil {
+				log.Debugw("error writing message", "error", err)
+			}
+			return
+		}
+		call.cancel()
+
+	case *pb.PersistentConnectionRequest_CancelAll:
+		d.writeResponse(w, d.doCancelAll(callID, w))
+	}
+}
+
+// inflightCall is a cancellable call in cancelUnary, along with the writer
+// of the persistent connection that issued it.
+type inflightCall struct {
+	cancel context.CancelFunc
+	owner  ggio.Writer
+}
+
+// persistentConnWriteTimeout bounds how long a response a client waits for
+// may wait for room in the queue of its persistent connection.
+const persistentConnWriteTimeout = 30 * time.Second
+
+// writeResponse writes resp, which the client waits for, to w, waiting for
+// room in its queue rather than dropping it. The connection is closed if resp
+// still can't be written, as the client would otherwise wait for it forever.
+func (d *Daemon) writeResponse(w *utils.QueuedWriter, resp *pb.PersistentConnectionResponse) {
+	err := utils.WriteChunkedResponse(w.Waiting(persistentConnWriteTimeout), resp, d.unaryConfig().chunkSize)
+	if err == nil || err == io.ErrClosedPipe {
+		return
+	}
+	log.Warnw("closing persistent connection: error writing a response", "error", err)
+	w.Close()
+}
+
+// trackCall registers call in cancelUnary, unless a call with the same id is
+// already in flight: ids are chosen by clients, and reusing one would mix up
+// the two calls.
+func (d *Daemon) trackCall(callID uuid.UUID, call inflightCall) bool {
+	_, loaded := d.cancelUnary.LoadOrStore(callID, call)
+	return !loaded
+}
+
+// responseWaiter is an incoming call in responseWaiters, waiting for the
+// client owning the handler to respond.
+type responseWaiter struct {
+	rc     chan *pb.PersistentConnectionRequest
+	done   <-chan struct{}
+	cancel context.CancelFunc
+	owner  ggio.Writer
+}
+
+// releaseCalls cancels and forgets the calls issued on the persistent
+// connection of w and the incoming calls awaiting its response, so that
+// they don't leak once the connection is gone.
+func (d *Daemon) releaseCalls(w ggio.Writer) {
+	d.cancelUnary.Range(func(k, v interface{}) bool {
+		if call := v.(inflightCall); call.owner == w {
+			call.cancel()
+			d.cancelUnary.Delete(k)
+		}
+		return true
+	})
+	d.responseWaiters.Range(func(k, v interface{}) bool {
+		if waiter := v.(responseWaiter); waiter.owner == w {
+			waiter.cancel()
+			d.responseWaiters.Delete(k)
+		}
+		return true
+	})
+	d.inboundStreams.Range(func(k, v interface{}) bool {
+		if stream := v.(inboundStream); stream.owner == w {
+			stream.cancel()
+			d.inboundStreams.Delete(k)
+		}
+		return true
+	})
+}
+
+// doCancelAll cancels the calls issued on the persistent connection of w.
+func (d *Daemon) doCancelAll(callID uuid.UUID, w ggio.Writer) *pb.PersistentConnectionResponse {
+	var cancelled int32
+	d.cancelUnary.Range(func(_, v interface{}) bool {
+		if call := v.(inflightCall); call.owner == w {
+			call.cancel()
+			cancelled++
+		}
+		return true
+	})
+
+	resp := okUnaryCallResponse(callID)
+	resp.Message = &pb.PersistentConnectionResponse_CancelAll{
+		CancelAll: &pb.CancelAllResponse{Cancelled: &cancelled},
 	}
+	return resp
 }
 
 func (d *Daemon) doAddUnaryHandler(w ggio.Writer, callID uuid.UUID, req *pb.AddUnaryHandlerRequest) *pb.PersistentConnectionResponse {
 	d.mx.Lock()
 	defer d.mx.Unlock()
 
+	if d.draining {
+		return errorUnaryCallString(callID, "daemon is draining; no new handlers are accepted")
+	}
+
 	p := protocol.ID(*req.Proto)
-	if registered, found := d.registeredUnaryProtocols[p]; found && registered {
+	registered, found := d.registeredUnaryProtocols[p]
+	if found && registered && !req.GetReplace() {
 		return errorUnaryCallString(
 			callID,
 			fmt.Sprintf("handler for protocol %s already set", *req.Proto),
 		)
 	}
 
-	d.host.SetStreamHandler(p, d.getPersistentStreamHandler(w))
+	if req.GetMaxConcurrentCalls() < 0 {
+		return errorUnaryCallString(callID, "max concurrent calls can't be negative")
+	}
+
+	if found {
+		// calls in progress are still answered by the previous owner
+		d.host.RemoveStreamHandler(p)
+		log.Infow("replacing unary stream handler", "protocol", p)
+	}
+
+	if req.GetRaw() {
+		d.host.SetStreamHandler(p, d.getPersistentRawStreamHandler(w, req.GetWindow()))
+	} else {
+		d.host.SetStreamHandler(p, d.getPersistentStreamHandler(w, req.GetMaxConcurrentCalls(), req.GetOverflowPolicy()))
+	}
 	d.registeredUnaryProtocols[p] = true
+	d.unaryHandlerOwners[p] = w
 
-	log.Debugw("set unary stream handler", "protocol", p)
+	log.Infow("set unary stream handler", "protocol", p, "raw", req.GetRaw())
 
 	return okUnaryCallResponse(callID)
 }
 
-func (d *Daemon) doUnaryCall(ctx context.Context, callID uuid.UUID, req *pb.PersistentConnectionRequest) *pb.PersistentConnectionResponse {
+// doRemoveUnaryHandler removes the handler of a protocol, provided it was
+// added on the persistent connection of w. Incoming calls already handed to
+// the client are still answered.
+func (d *Daemon) doRemoveUnaryHandler(w ggio.Writer, callID uuid.UUID, req *pb.RemoveUnaryHandlerRequest) *pb.PersistentConnectionResponse {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	p := protocol.ID(req.GetProto())
+	if d.unaryHandlerOwners[p] != w {
+		return errorUnaryCallString(
+			callID,
+			fmt.Sprintf("no handler for protocol %s on this connection", p),
+		)
+	}
+
+	d.host.RemoveStreamHandler(p)
+	delete(d.registeredUnaryProtocols, p)
+	delete(d.unaryHandlerOwners, p)
+
+	log.Infow("removed unary stream handler", "protocol", p)
+
+	return okUnaryCallResponse(callID)
+}
+
+func (d *Daemon) doGetStats(callID uuid.UUID) *pb.PersistentConnectionResponse {
+	d.mx.Lock()
+	handlers := make([]*pb.UnaryHandlerInfo, 0, len(d.registeredUnaryProtocols))
+	for p, enabled := range d.registeredUnaryProtocols {
+		proto, enabled := string(p), enabled
+		handlers = append(handlers, &pb.UnaryHandlerInfo{Proto: &proto, Enabled: &enabled})
+	}
+	d.mx.Unlock()
+
+	activeCalls, pendingResponses := syncMapLen(&d.cancelUnary), syncMapLen(&d.responseWaiters)
+
+	resp := okUnaryCallResponse(callID)
+	resp.Message = &pb.PersistentConnectionResponse_Stats{
+		Stats: &pb.StatsResponse{
+			UnaryHandlers:    handlers,
+			ActiveCalls:      &activeCalls,
+			PendingResponses: &pendingResponses,
+		},
+	}
+	return resp
+}
+
+func syncMapLen(m *sync.Map) int64 {
+	var n int64
+	m.Range(func(_, _ interface{}) bool {
+		n++
+		return true
+	})
+	return n
+}
+
+func (d *Daemon) doUnaryCall(ctx context.Context, callID uuid.UUID, req *pb.PersistentConnectionRequest) (resp *pb.PersistentConnectionResponse) {
+	unaryCalls.Inc()
+
+	result := unaryCallFailed
+	defer func(start time.Time) {
+		unaryCallDuration.WithLabelValues(result).Observe(time.Since(start).Seconds())
+		d.recordCall(req.GetCallUnary(), start, result, resp)
+	}(time.Now())
+
 	pid, err := peer.IDFromBytes(req.GetCallUnary().Peer)
 	if err != nil {
 		return errorUnaryCall(callID, err)
 	}
 
-	remoteStream, err := d.host.NewStream(
-		ctx,
-		pid,
-		protocol.ID(*req.GetCallUnary().Proto),
-	)
+	ctx, span := startCallSpan(ctx, "unary call", trace.SpanKindInternal, req.GetCallUnary(),
+		attribute.String("p2pd.peer", pid.Pretty()))
+	defer func() { endCallSpan(span, result) }()
+
+	if timeout := req.GetCallUnary().GetTimeoutMs(); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(timeout)*time.Millisecond)
+		defer cancel()
+	}
+
+	remoteStream, err := d.newCallStream(ctx, pid, protocol.ID(*req.GetCallUnary().Proto))
 	if err != nil {
+		switch ctx.Err() {
+		case context.DeadlineExceeded:
+			result = unaryCallTimedOut
+		case context.Canceled:
+			result = unaryCallCancelled
+		}
 		return errorUnaryCall(callID, err)
 	}
 	defer remoteStream.Close()
 
 	select {
-	case response := <-exchangeMessages(ctx, remoteStream, req):
+	case response := <-exchangeMessages(ctx, remoteStream, req, d.unaryConfig()):
+		if res := response.GetCallUnaryResponse(); res != nil && res.GetError() == nil {
+			result = unaryCallSucceeded
+		}
 		return response
 
 	case <-ctx.Done():
+		// let the remote handler know the call was abandoned
+		remoteStream.Reset()
+
+		if ctx.Err() == context.DeadlineExceeded {
+			result = unaryCallTimedOut
+			return errorUnaryCallCode(callID, pb.DaemonError_TIMEOUT, "unary call deadline exceeded")
+		}
+		result = unaryCallCancelled
 		return okUnaryCallCancelled(callID)
 	}
 }
 
-func exchangeMessages(ctx context.Context, s network.Stream, req *pb.PersistentConnectionRequest) <-chan *pb.PersistentConnectionResponse {
+// errNegotiationTimeout is returned when a peer doesn't negotiate the
+// protocol of a call within the negotiation timeout.
+var errNegotiationTimeout = errors.New("protocol negotiation timed out")
+
+// newCallStream opens the stream of a unary or stream call. With
+// SetRetryUnaryDials, it is opened again once with a direct dial if the peer
+// is in dial backoff. With SetLoopbackCalls, calls to the host itself get an
+// in-memory stream.
+func (d *Daemon) newCallStream(ctx context.Context, p peer.ID, proto protocol.ID) (network.Stream, error) {
+	cfg := d.unaryConfig()
+	if cfg.loopback && p == d.host.ID() {
+		return d.newLoopbackStream(ctx, proto)
+	}
+
+	s, err := d.openCallStream(ctx, p, proto, cfg)
+	if err == nil || !cfg.retryDials || !isDialBackoff(err) || ctx.Err() != nil {
+		return s, err
+	}
+
+	log.Debugw("peer in dial backoff; retrying with a direct dial", "peer", p, "protocol", proto)
+	return d.openCallStream(network.WithForceDirectDial(ctx, "unary call retry"), p, proto, cfg)
+}
+
+// openCallStream opens a stream to p, dialing it as the dial preference of cfg
+// says and bounding the protocol negotiation by its negotiation timeout once
+// connected.
+func (d *Daemon) openCallStream(ctx context.Context, p peer.ID, proto protocol.ID, cfg unaryConfig) (network.Stream, error) {
+	// the peer is dialed first, so that the negotiation timeout doesn't
+	// apply to the dial and the dial follows the preference; identify, which
+	// the stream waits for, does count
+	if cfg.negotiationTimeout > 0 || len(cfg.dialPreference) > 0 {
+		err := d.dialPreferred(ctx, p, nil, func(ctx context.Context) error {
+			_, err := d.host.Network().DialPeer(ctx, p)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if cfg.negotiationTimeout <= 0 {
+		return d.host.NewStream(ctx, p, proto)
+	}
+
+	nctx, cancel := context.WithTimeout(ctx, cfg.negotiationTimeout)
+	defer cancel()
+
+	s, err := d.host.NewStream(nctx, p, proto)
+	if err != nil && ctx.Err() == nil && nctx.Err() == context.DeadlineExceeded {
+		return nil, fmt.Errorf("%w: no answer from %s for %s", errNegotiationTimeout, p.Pretty(), cfg.negotiationTimeout)
+	}
+	return s, err
+}
+
+// isDialBackoff tells whether a dial failed only because the peer, or each of
+// its addresses, is in dial backoff.
+func isDialBackoff(err error) bool {
+	if errors.Is(err, swarm.ErrDialBackoff) {
+		return true
+	}
+
+	var dialErr *swarm.DialError
+	if !errors.As(err, &dialErr) || dialErr.Cause != nil || len(dialErr.DialErrors) == 0 {
+		return false
+	}
+	for _, te := range dialErr.DialErrors {
+		if !errors.Is(te.Cause, swarm.ErrDialBackoff) {
+			return false
+		}
+	}
+	return true
+}
+
+func exchangeMessages(ctx context.Context, s network.Stream, req *pb.PersistentConnectionRequest, cfg unaryConfig) <-chan *pb.PersistentConnectionResponse {
 	callID, _ := uuid.FromBytes(req.CallId)
 	rc := make(chan *pb.PersistentConnectionResponse)
 
+	// the remote daemon continues the trace from this span
+	ctx, span := startCallSpan(ctx, "exchange messages", trace.SpanKindClient, req.GetCallUnary(),
+		attribute.String("p2pd.peer", s.Conn().RemotePeer().Pretty()))
+
 	go func() {
 		defer close(rc)
 
-		if err := ggio.NewDelimitedWriter(s).WriteMsg(req); ctx.Err() != nil {
+		result := unaryCallFailed
+		defer func() { endCallSpan(span, result) }()
+
+		if err := utils.WriteChunkedRequest(ggio.NewDelimitedWriter(s), req, cfg.chunkSize); ctx.Err() != nil {
+			result = abandonedCallResult(ctx)
 			return
 		} else if err != nil {
 			rc <- errorUnaryCall(callID, err)
@@ -167,12 +806,16 @@ func exchangeMessages(ctx context.Context, s network.Stream, req *pb.PersistentC
 		}
 
 		remoteResp := &pb.PersistentConnectionRequest{}
-		if err := ggio.NewDelimitedReader(s, network.MessageSizeMax).ReadMsg(remoteResp); ctx.Err() != nil {
+		if err := utils.ReadChunkedRequest(newUnaryReader(s, cfg.maxMessageSize), remoteResp, cfg.maxChunkedPayloadSize); ctx.Err() != nil {
+			result = abandonedCallResult(ctx)
 			return
 		} else if err != nil {
 			rc <- errorUnaryCall(callID, err)
 			return
 		}
+		if remoteResp.GetUnaryResponse().GetError() == nil {
+			result = unaryCallSucceeded
+		}
 
 		resp := okUnaryCallResponse(callID)
 		resp.Message = &pb.PersistentConnectionResponse_CallUnaryResponse{
@@ -191,35 +834,187 @@ func exchangeMessages(ctx context.Context, s network.Stream, req *pb.PersistentC
 	return rc
 }
 
-// notifyWhenClosed writers to a semaphor channel if the given io.Reader fails to
-// read before the context was cancelled
-func notifyWhenClosed(ctx context.Context, r io.Reader) <-chan struct{} {
-	event := make(chan struct{})
+// abandonedCallResult tells why a call was abandoned once ctx is done.
+func abandonedCallResult(ctx context.Context) string {
+	if ctx.Err() == context.DeadlineExceeded {
+		return unaryCallTimedOut
+	}
+	return unaryCallCancelled
+}
+
+// doStreamCall sends a request to a remote handler and relays every response
+// it writes back to the client, followed by an EndOfStream frame once the
+// remote closes the stream. Responses are relayed one at a time: the next
+// message is not read from the remote until the previous one has been written
+// to the client, so a slow client slows down the remote instead of making the
+// daemon buffer its responses.
+func (d *Daemon) doStreamCall(ctx context.Context, callID uuid.UUID, req *pb.PersistentConnectionRequest, w ggio.Writer) {
+	cfg := d.unaryConfig()
+	writeResponse := func(resp *pb.PersistentConnectionResponse) bool {
+		if err := utils.WriteChunkedResponse(w, resp, cfg.chunkSize); err != nil {
+			log.Debugw("error writing message", "error", err)
+			return false
+		}
+		return true
+	}
+
+	pid, err := peer.IDFromBytes(req.GetCallStream().Peer)
+	if err != nil {
+		writeResponse(errorUnaryCall(callID, err))
+		return
+	}
+
+	remoteStream, err := d.newCallStream(ctx, pid, protocol.ID(*req.GetCallStream().Proto))
+	if err != nil {
+		writeResponse(errorUnaryCall(callID, err))
+		return
+	}
+	defer remoteStream.Close()
+
+	// remote handlers expect a unary request, which makes every unary handler
+	// usable as a stream that yields a single response
+	remoteReq := &pb.PersistentConnectionRequest{
+		CallId: req.CallId,
+		Message: &pb.PersistentConnectionRequest_CallUnary{
+			CallUnary: req.GetCallStream(),
+		},
+	}
+
+	responses := streamMessages(ctx, remoteStream, remoteReq, cfg)
+	for {
+		select {
+		case response, ok := <-responses:
+			if !ok {
+				if ctx.Err() == nil {
+					writeResponse(okEndOfStream(callID))
+					return
+				}
+				// the call was cancelled, let the other case report it
+				responses = nil
+				continue
+			}
+
+			if !writeResponse(response) || response.GetDaemonError() != nil {
+				remoteStream.Reset()
+				return
+			}
+
+		case <-ctx.Done():
+			// resetting the stream unblocks the goroutine reading from it
+			remoteStream.Reset()
+			writeResponse(okUnaryCallCancelled(callID))
+			return
+		}
+	}
+}
+
+// streamMessages writes a request to the remote stream and returns a channel
+// yielding every response read from it. The channel is closed once the remote
+// closes the stream, an error occurs (in which case the error is the last
+// message sent) or the context is cancelled.
+func streamMessages(ctx context.Context, s network.Stream, req *pb.PersistentConnectionRequest, cfg unaryConfig) <-chan *pb.PersistentConnectionResponse {
+	callID, _ := uuid.FromBytes(req.CallId)
+	rc := make(chan *pb.PersistentConnectionResponse)
 
 	go func() {
-		defer close(event)
+		defer close(rc)
 
-		buff := make([]byte, 1)
-		if _, err := r.Read(buff); err != nil {
+		send := func(resp *pb.PersistentConnectionResponse) bool {
 			select {
-			case event <- struct{}{}:
+			case rc <- resp:
+				return true
 			case <-ctx.Done():
+				return false
+			}
+		}
+
+		if err := utils.WriteChunkedRequest(ggio.NewDelimitedWriter(s), req, cfg.chunkSize); ctx.Err() != nil {
+			return
+		} else if err != nil {
+			send(errorUnaryCall(callID, err))
+			return
+		}
+
+		r := newUnaryReader(s, cfg.maxMessageSize)
+		for {
+			remoteResp := &pb.PersistentConnectionRequest{}
+			if err := utils.ReadChunkedRequest(r, remoteResp, cfg.maxChunkedPayloadSize); ctx.Err() != nil {
+				return
+			} else if err == io.EOF {
+				return
+			} else if err != nil {
+				send(errorUnaryCall(callID, err))
+				return
+			}
+
+			resp := okUnaryCallResponse(callID)
+			resp.Message = &pb.PersistentConnectionResponse_CallUnaryResponse{
+				CallUnaryResponse: remoteResp.GetUnaryResponse(),
+			}
+
+			if !send(resp) {
+				return
 			}
 		}
 	}()
 
-	return event
+	return rc
+}
+
+// unaryReader reads delimited messages from a remote unary stream, reporting
+// messages larger than maxSize with a descriptive error.
+type unaryReader struct {
+	ggio.Reader
+	maxSize int
+}
+
+func newUnaryReader(r io.Reader, maxSize int) ggio.Reader {
+	return unaryReader{ggio.NewDelimitedReader(r, maxSize), maxSize}
+}
+
+func (r unaryReader) ReadMsg(msg proto.Message) error {
+	err := r.Reader.ReadMsg(msg)
+	if err == io.ErrShortBuffer {
+		return messageTooLargeError{r.maxSize}
+	}
+	return err
+}
+
+type messageTooLargeError struct {
+	maxSize int
+}
+
+func (e messageTooLargeError) Error() string {
+	return fmt.Sprintf("remote message exceeds the maximum size of %d bytes", e.maxSize)
 }
 
 // getPersistentStreamHandler returns a libp2p stream handler tied to a
-// given persistent client stream
-func (d *Daemon) getPersistentStreamHandler(cw ggio.Writer) network.StreamHandler {
+// given persistent client stream. When maxCalls is positive, calls past the
+// limit are rejected or queued according to overflow.
+func (d *Daemon) getPersistentStreamHandler(
+	cw ggio.Writer,
+	maxCalls int32,
+	overflow pb.AddUnaryHandlerRequest_OverflowPolicy,
+) network.StreamHandler {
+	var sem chan struct{}
+	if maxCalls > 0 {
+		sem = make(chan struct{}, maxCalls)
+	}
+
 	return func(s network.Stream) {
 		defer s.Close()
+		cfg := d.unaryConfig()
+
+		// the caller closing or resetting the stream cancels the call; the
+		// notifier reads ahead to notice it without dropping what the caller
+		// sends meanwhile
+		notifier := utils.NewCloseNotifier(s, cfg.maxMessageSize)
+		defer notifier.Close()
 
 		req := &pb.PersistentConnectionRequest{}
-		if err := ggio.NewDelimitedReader(s, network.MessageSizeMax).ReadMsg(req); err != nil {
+		if err := utils.ReadChunkedRequest(newUnaryReader(notifier, cfg.maxMessageSize), req, cfg.maxChunkedPayloadSize); err != nil {
 			log.Debugw("failed to read proto from incoming p2p stream", "error", err)
+			s.Reset()
 			return
 		}
 
@@ -237,26 +1032,62 @@ func (d *Daemon) getPersistentStreamHandler(cw ggio.Writer) network.StreamHandle
 			return
 		}
 
-		rc := make(chan *pb.PersistentConnectionRequest)
-		d.responseWaiters.Store(callID, rc)
-		defer d.responseWaiters.Delete(callID)
-
 		ctx, cancel := context.WithCancel(d.ctx)
 		defer cancel()
 
+		// the client handling the call continues the trace from this span
+		_, span := startCallSpan(ctx, "handle unary call", trace.SpanKindServer, req.GetCallUnary(),
+			attribute.String("p2pd.peer", s.Conn().RemotePeer().Pretty()))
+		result := unaryCallFailed
+		defer func() { endCallSpan(span, result) }()
+
+		closed := notifier.Failed()
+
+		if sem != nil {
+			if overflow == pb.AddUnaryHandlerRequest_BLOCK {
+				select {
+				case sem <- struct{}{}:
+				case <-closed:
+					return
+				case <-ctx.Done():
+					s.Reset()
+					return
+				}
+			} else {
+				select {
+				case sem <- struct{}{}:
+				default:
+					log.Debugw("too many concurrent calls; rejecting", "protocol", s.Protocol())
+					s.Reset()
+					return
+				}
+			}
+			defer func() { <-sem }()
+		}
+
+		rc := make(chan *pb.PersistentConnectionRequest)
+		waiter := responseWaiter{rc, ctx.Done(), cancel, cw}
+		if _, loaded := d.responseWaiters.LoadOrStore(callID, waiter); loaded {
+			log.Debugw("call id already awaiting a response; rejecting", "callID", callID)
+			s.Reset()
+			return
+		}
+		defer d.responseWaiters.Delete(callID)
+
 		resp := &pb.PersistentConnectionResponse{
 			CallId: req.CallId,
 			Message: &pb.PersistentConnectionResponse_RequestHandling{
 				RequestHandling: req.GetCallUnary(),
 			},
 		}
-		if err := cw.WriteMsg(resp); err != nil {
+		if err := utils.WriteChunkedResponse(cw, resp, cfg.chunkSize); err != nil {
 			log.Debugw("failed to write message to client", "error", err)
 			return
 		}
 
 		select {
-		case <-notifyWhenClosed(ctx, s):
+		case <-closed:
+			result = unaryCallCancelled
 			if err := cw.WriteMsg(
 				&pb.PersistentConnectionResponse{
 					CallId: callID[:],
@@ -267,44 +1098,251 @@ func (d *Daemon) getPersistentStreamHandler(cw ggio.Writer) network.StreamHandle
 			); err != nil {
 				log.Debugw("failed to write to client", "error", err)
 			}
+		case <-ctx.Done():
+			// the connection owning the handler is gone
+			s.Reset()
 		case response := <-rc:
 			w := ggio.NewDelimitedWriter(s)
-			if err := w.WriteMsg(response); err != nil {
+			if err := utils.WriteChunkedRequest(w, response, cfg.chunkSize); err != nil {
 				log.Debugw("failed to write message to remote", "error", err)
+			} else if response.GetUnaryResponse().GetError() == nil {
+				result = unaryCallSucceeded
 			}
 		}
 	}
 }
 
-func (d *Daemon) sendReponseToRemote(req *pb.PersistentConnectionRequest) {
+// inboundStreamFrames bounds the number of frames a client can send ahead of
+// what was written to an inbound stream; it is the window announced to the
+// client in the stream's StreamInfo.
+const inboundStreamFrames = 64
+
+// inboundStreamAck is the number of frames of the client written to an
+// inbound stream the daemon acks at once.
+const inboundStreamAck = inboundStreamFrames / 4
+
+// inboundStream is a stream accepted by a raw handler in inboundStreams,
+// along with the writer of the persistent connection it is proxied over.
+type inboundStream struct {
+	frames chan *pb.StreamFrame
+	// credit holds a token per data frame sent to the client and not acked
+	// yet, up to the window the client asked for; nil if it didn't
+	credit chan struct{}
+	cancel context.CancelFunc
+	owner  ggio.Writer
+}
+
+// getPersistentRawStreamHandler hands incoming streams to the client of cw,
+// which is notified of each of them with its StreamInfo, then proxies them
+// over the persistent connection as StreamFrame messages with the same call
+// id. At most window data frames of each stream are sent ahead of the
+// client's acks, unless it is zero.
+func (d *Daemon) getPersistentRawStreamHandler(cw ggio.Writer, window uint32) network.StreamHandler {
+	return func(s network.Stream) {
+		streamID := uuid.New()
+
+		ctx, cancel := context.WithCancel(d.ctx)
+		defer cancel()
+
+		stream := inboundStream{
+			frames: make(chan *pb.StreamFrame, inboundStreamFrames),
+			cancel: cancel,
+			owner:  cw,
+		}
+		if window > 0 {
+			stream.credit = make(chan struct{}, window)
+		}
+		frames := stream.frames
+		d.inboundStreams.Store(streamID, stream)
+		defer d.inboundStreams.Delete(streamID)
+
+		info := makeStreamInfo(s)
+		inboundWindow := uint32(inboundStreamFrames)
+		info.Window = &inboundWindow
+		if err := cw.WriteMsg(&pb.PersistentConnectionResponse{
+			CallId: streamID[:],
+			Message: &pb.PersistentConnectionResponse_IncomingStream{
+				IncomingStream: info,
+			},
+		}); err != nil {
+			log.Debugw("failed to write message to client", "error", err)
+			s.Reset()
+			return
+		}
+
+		send := func(frame *pb.StreamFrame) error {
+			// the data waits for the client to read what it was sent before,
+			// rather than filling the queue of the connection
+			if stream.credit != nil && len(frame.Data) > 0 {
+				select {
+				case stream.credit <- struct{}{}:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+			return cw.WriteMsg(&pb.PersistentConnectionResponse{
+				CallId:  streamID[:],
+				Message: &pb.PersistentConnectionResponse_StreamFrame{StreamFrame: frame},
+			})
+		}
+		// recv is called again once the frame it returned before is written
+		// to the stream, so the frames returned so far can be acked then
+		var written uint32
+		recv := func() (*pb.StreamFrame, error) {
+			if written >= inboundStreamAck {
+				ack := written
+				written = 0
+				if err := send(&pb.StreamFrame{Ack: &ack}); err != nil {
+					return nil, err
+				}
+			}
+
+			select {
+			case frame := <-frames:
+				written++
+				return frame, nil
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		pipeFrames(s, recv, send)
+	}
+}
+
+// deliverStreamFrame passes a frame sent by the client of w to the inbound
+// stream it is for, without waiting for it to be written. A client sending
+// more frames than the stream's window ahead of the acks resets it. Acks of
+// the client release the window of the frames sent to it instead.
+func (d *Daemon) deliverStreamFrame(req *pb.PersistentConnectionRequest, w ggio.Writer) {
+	streamID, err := uuid.FromBytes(req.CallId)
+	if err != nil {
+		log.Debugw("bad call id: ", "error", err)
+		return
+	}
+
+	v, found := d.inboundStreams.Load(streamID)
+	if !found || v.(inboundStream).owner != w {
+		log.Debugw("frame for an unknown stream", "stream", streamID)
+		return
+	}
+
+	stream := v.(inboundStream)
+	if ack := req.GetStreamFrame().Ack; ack != nil {
+		for i := uint32(0); i < *ack; i++ {
+			select {
+			case <-stream.credit:
+			default:
+			}
+		}
+		return
+	}
+
+	select {
+	case stream.frames <- req.GetStreamFrame():
+	default:
+		log.Debugw("too many frames queued; resetting stream", "stream", streamID)
+		stream.cancel()
+	}
+}
+
+// sendReponseToRemote hands the response the client of w sent to the incoming
+// call awaiting it. It returns an error to write back to the client if no call
+// awaits it anymore, e.g. as the caller went away while the client was
+// handling the call, or if the call awaits the response of another client,
+// and nil otherwise.
+func (d *Daemon) sendReponseToRemote(req *pb.PersistentConnectionRequest, w ggio.Writer) *pb.PersistentConnectionResponse {
 	callID, err := uuid.FromBytes(req.CallId)
 	if err != nil {
 		log.Debugf("failed to unmarshal call id from bytes: %v", err)
-		return
+		return nil
 	}
 
 	rc, found := d.responseWaiters.Load(callID)
 	if !found {
 		log.Debugf("could not find request awaiting response for following call id: %s", callID.String())
-		return
+		return errorCallNotAwaited(callID)
 	}
 
-	rc.(chan *pb.PersistentConnectionRequest) <- req
+	waiter := rc.(responseWaiter)
+	if waiter.owner != w {
+		log.Debugw("response to a call awaiting another client", "callID", callID)
+		return errorResponseNotOwned(callID)
+	}
+	select {
+	case waiter.rc <- req:
+		return nil
+	case <-waiter.done:
+	case <-d.ctx.Done():
+	}
+	log.Debugw("call stopped awaiting its response", "callID", callID)
+	return errorCallNotAwaited(callID)
 }
 
 func errorUnaryCall(callID uuid.UUID, err error) *pb.PersistentConnectionResponse {
-	return errorUnaryCallString(callID, err.Error())
+	return errorUnaryCallCode(callID, daemonErrorCode(err), err.Error())
 }
 
 func errorUnaryCallString(callID uuid.UUID, errMsg string) *pb.PersistentConnectionResponse {
+	return errorUnaryCallCode(callID, pb.DaemonError_UNKNOWN, errMsg)
+}
+
+func errorUnaryCallCode(callID uuid.UUID, code pb.DaemonError_Code, errMsg string) *pb.PersistentConnectionResponse {
 	return &pb.PersistentConnectionResponse{
 		CallId: callID[:],
 		Message: &pb.PersistentConnectionResponse_DaemonError{
-			DaemonError: &pb.DaemonError{Message: &errMsg},
+			DaemonError: &pb.DaemonError{Message: &errMsg, Code: &code},
 		},
 	}
 }
 
+// daemonErrorCode maps the libp2p errors clients may want to handle to a
+// stable code.
+func daemonErrorCode(err error) pb.DaemonError_Code {
+	var dialErr *swarm.DialError
+	var tooLarge messageTooLargeError
+	var payloadTooLarge utils.PayloadTooLargeError
+
+	switch {
+	case errors.Is(err, errNegotiationTimeout):
+		return pb.DaemonError_NEGOTIATION_TIMEOUT
+	case errors.Is(err, context.DeadlineExceeded):
+		return pb.DaemonError_TIMEOUT
+	case errors.Is(err, context.Canceled):
+		return pb.DaemonError_CANCELLED
+	case errors.Is(err, swarm.ErrNoAddresses), errors.Is(err, swarm.ErrNoGoodAddresses):
+		return pb.DaemonError_NO_ADDRESSES
+	case isDialBackoff(err):
+		return pb.DaemonError_DIAL_BACKOFF
+	case errors.Is(err, swarm.ErrGaterDisallowedConnection):
+		return pb.DaemonError_CONNECTION_DENIED
+	case errors.As(err, &dialErr):
+		return pb.DaemonError_DIAL_FAILED
+	case errors.Is(err, multistream.ErrNotSupported):
+		return pb.DaemonError_PROTOCOL_NOT_SUPPORTED
+	case errors.As(err, &tooLarge), errors.As(err, &payloadTooLarge):
+		return pb.DaemonError_MESSAGE_TOO_LARGE
+	default:
+		return pb.DaemonError_UNKNOWN
+	}
+}
+
+func errorDuplicateCallID(callID uuid.UUID) *pb.PersistentConnectionResponse {
+	return errorUnaryCallCode(callID, pb.DaemonError_DUPLICATE_CALL_ID, "call id already in use")
+}
+
+func errorCallNotAwaited(callID uuid.UUID) *pb.PersistentConnectionResponse {
+	return errorUnaryCallCode(callID, pb.DaemonError_CALL_NOT_AWAITED, "call no longer awaits a response")
+}
+
+func errorResponseNotOwned(callID uuid.UUID) *pb.PersistentConnectionResponse {
+	return errorUnaryCallCode(callID, pb.DaemonError_CALL_NOT_OWNED, "call awaits a response from another connection")
+}
+
+func errorCallNotOwned(callID uuid.UUID) *pb.PersistentConnectionResponse {
+	return errorUnaryCallCode(callID, pb.DaemonError_CALL_NOT_OWNED, "call was issued by another connection")
+}
+
 func okUnaryCallResponse(callID uuid.UUID) *pb.PersistentConnectionResponse {
 	return &pb.PersistentConnectionResponse{CallId: callID[:]}
 }
@@ -317,3 +1355,12 @@ func okUnaryCallCancelled(callID uuid.UUID) *pb.PersistentConnectionResponse {
 		},
 	}
 }
+
+func okEndOfStream(callID uuid.UUID) *pb.PersistentConnectionResponse {
+	return &pb.PersistentConnectionResponse{
+		CallId: callID[:],
+		Message: &pb.PersistentConnectionResponse_EndOfStream{
+			EndOfStream: &pb.EndOfStream{},
+		},
+	}
+}
@@ -0,0 +1,72 @@
+package p2pd
+
+import (
+	"fmt"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p/p2p/protocol/ping"
+
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// DefaultPingCount is the number of ping rounds when a request doesn't set it.
+const DefaultPingCount = 1
+
+func (d *Daemon) doPing(req *pb.Request) *pb.Response {
+	if req.Ping == nil {
+		return errorResponseString("Malformed request; missing parameters")
+	}
+
+	p, err := peer.IDFromBytes(req.Ping.GetPeer())
+	if err != nil {
+		return errorResponse(err)
+	}
+
+	count := int(req.Ping.GetCount())
+	if count < 0 {
+		return errorResponseString("ping count can't be negative")
+	}
+	if count == 0 {
+		count = DefaultPingCount
+	}
+
+	ctx, cancel := d.requestContext(req.Ping.GetTimeout())
+	defer cancel()
+
+	// all rounds go over the single stream opened by ping.Ping, which is
+	// reset once ctx is cancelled
+	results := ping.Ping(ctx, d.host, p)
+
+	rtts := make([]int64, 0, count)
+	for len(rtts) < count {
+		res, ok := <-results
+		if !ok {
+			return errorResponseString(fmt.Sprintf("ping to %s timed out after %d of %d rounds", p.Pretty(), len(rtts), count))
+		}
+		if res.Error != nil {
+			log.Debugw("error pinging peer", "peer", p, "error", res.Error)
+			return errorResponse(res.Error)
+		}
+		rtts = append(rtts, int64(res.RTT))
+	}
+
+	res := okResponse()
+	res.Ping = pingResponse(rtts)
+	return res
+}
+
+func pingResponse(rtts []int64) *pb.PingResponse {
+	min, max, sum := rtts[0], rtts[0], int64(0)
+	for _, rtt := range rtts {
+		if rtt < min {
+			min = rtt
+		}
+		if rtt > max {
+			max = rtt
+		}
+		sum += rtt
+	}
+	avg := sum / int64(len(rtts))
+
+	return &pb.PingResponse{Rtts: rtts, Min: &min, Avg: &avg, Max: &max}
+}
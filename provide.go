@@ -0,0 +1,187 @@
+package p2pd
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+
+	"github.com/ipfs/go-cid"
+)
+
+// defaultReprovideInterval is how often the content the daemon keeps
+// providing is announced to the DHT again, unless SetReprovideInterval says
+// otherwise.
+const defaultReprovideInterval = 12 * time.Hour
+
+// provideRetryInterval is how long announcing content that failed to be
+// announced, e.g. before the DHT had any peers, waits to be retried.
+const provideRetryInterval = time.Minute
+
+// SetReprovideInterval sets how often the content the daemon keeps providing
+// is announced to the DHT again, so that its provider records don't expire,
+// which is every 12 hours by default. It must be called before StartProviding
+// to apply.
+func (d *Daemon) SetReprovideInterval(interval time.Duration) {
+	d.provideMx.Lock()
+	defer d.provideMx.Unlock()
+
+	d.reprovideInterval = interval
+}
+
+// StartProviding adds cids to the content the daemon keeps providing: they
+// are announced to the DHT right away, and again at the reprovide interval
+// until StopProviding. Failed announcements are retried every minute. It fails if
+// the DHT isn't enabled.
+func (d *Daemon) StartProviding(cids ...cid.Cid) error {
+	if d.dht == nil {
+		return errors.New("DHT not enabled")
+	}
+
+	var added []cid.Cid
+	d.provideMx.Lock()
+	for _, c := range cids {
+		if _, ok := d.providing[c]; !ok {
+			d.providing[c] = false
+			added = append(added, c)
+		}
+	}
+	d.provideMx.Unlock()
+
+	d.reprovideOnce.Do(func() { go d.reprovide() })
+	go d.provide(added)
+	return nil
+}
+
+// StopProviding stops announcing cids. Provider records already announced
+// aren't withdrawn, as the DHT doesn't support it, and expire on their own.
+func (d *Daemon) StopProviding(cids ...cid.Cid) {
+	d.provideMx.Lock()
+	defer d.provideMx.Unlock()
+
+	for _, c := range cids {
+		delete(d.providing, c)
+	}
+}
+
+// Providing returns the content the daemon keeps providing.
+func (d *Daemon) Providing() []cid.Cid {
+	return d.providingCIDs(false)
+}
+
+// providingCIDs returns the content the daemon keeps providing, or only the
+// content that failed to be announced the last time if failedOnly is set.
+func (d *Daemon) providingCIDs(failedOnly bool) []cid.Cid {
+	d.provideMx.Lock()
+	defer d.provideMx.Unlock()
+
+	cids := make([]cid.Cid, 0, len(d.providing))
+	for c, announced := range d.providing {
+		if !failedOnly || !announced {
+			cids = append(cids, c)
+		}
+	}
+	return cids
+}
+
+func (d *Daemon) reprovide() {
+	d.provideMx.Lock()
+	interval := d.reprovideInterval
+	d.provideMx.Unlock()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	retry := time.NewTicker(provideRetryInterval)
+	defer retry.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			d.provide(d.providingCIDs(false))
+		case <-retry.C:
+			d.provide(d.providingCIDs(true))
+		case <-d.ctx.Done():
+			return
+		}
+	}
+}
+
+// provide announces cids one at a time, skipping those no longer provided,
+// and records whether each announcement succeeded.
+func (d *Daemon) provide(cids []cid.Cid) {
+	for _, c := range cids {
+		d.provideMx.Lock()
+		_, ok := d.providing[c]
+		d.provideMx.Unlock()
+		if !ok {
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(d.ctx, DefaultTimeout)
+		err := d.dht.Provide(ctx, c, true)
+		cancel()
+		if d.ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			log.Warnw("error providing content", "cid", c, "error", err)
+		}
+
+		d.provideMx.Lock()
+		if _, ok := d.providing[c]; ok {
+			d.providing[c] = err == nil
+		}
+		d.provideMx.Unlock()
+	}
+}
+
+func (d *Daemon) doDHTStartProviding(req *pb.DHTRequest) (*pb.Response, <-chan *pb.DHTResponse, func()) {
+	if req.Cid == nil {
+		return errorResponseString("Malformed request; missing cid parameter"), nil, nil
+	}
+
+	c, err := cid.Cast(req.Cid)
+	if err != nil {
+		return errorResponse(err), nil, nil
+	}
+
+	if err := d.StartProviding(c); err != nil {
+		return errorResponse(err), nil, nil
+	}
+	return okResponse(), nil, nil
+}
+
+func (d *Daemon) doDHTStopProviding(req *pb.DHTRequest) (*pb.Response, <-chan *pb.DHTResponse, func()) {
+	if req.Cid == nil {
+		return errorResponseString("Malformed request; missing cid parameter"), nil, nil
+	}
+
+	c, err := cid.Cast(req.Cid)
+	if err != nil {
+		return errorResponse(err), nil, nil
+	}
+
+	d.StopProviding(c)
+	return okResponse(), nil, nil
+}
+
+func (d *Daemon) doDHTListProviding(req *pb.DHTRequest) (*pb.Response, <-chan *pb.DHTResponse, func()) {
+	ctx, cancel := context.WithCancel(d.ctx)
+	cids := d.Providing()
+
+	rch := make(chan *pb.DHTResponse)
+	go func() {
+		defer cancel()
+		defer close(rch)
+		for _, c := range cids {
+			select {
+			case rch <- dhtResponseValue(c.Bytes()):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return dhtOkResponse(dhtResponseBegin()), rch, cancel
+}
@@ -1,14 +1,81 @@
 package p2pd
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"time"
+
 	pb "github.com/libp2p/go-libp2p-daemon/pb"
 
 	"github.com/libp2p/go-libp2p-core/peer"
 
 	ggio "github.com/gogo/protobuf/io"
+	"github.com/google/uuid"
 	ps "github.com/libp2p/go-libp2p-pubsub"
+	pspb "github.com/libp2p/go-libp2p-pubsub/pb"
+)
+
+// Pubsub message id schemes, which tell messages apart to deliver and forward
+// each of them once. Every peer of a topic must use the same scheme.
+const (
+	// MessageIDAuthorSeqno identifies messages by their author and sequence
+	// number, which is the pubsub default.
+	MessageIDAuthorSeqno = "author-seqno"
+	// MessageIDContent identifies messages by the SHA-256 hash of their topic
+	// and data, so that identical messages are delivered once whoever
+	// publishes them.
+	MessageIDContent = "content"
+	// MessageIDPrefix identifies messages by their topic and the leading bytes
+	// of their data, where applications embed their own ids; messages shorter
+	// than that are identified by all of their data.
+	MessageIDPrefix = "prefix"
 )
 
+// NewMessageIDFn returns the message id function of scheme, to pass to
+// EnablePubsub with ps.WithMessageIdFn; prefixLen is the length of the ids
+// of MessageIDPrefix. The empty scheme is MessageIDAuthorSeqno.
+func NewMessageIDFn(scheme string, prefixLen int) (ps.MsgIdFunction, error) {
+	switch scheme {
+	case "", MessageIDAuthorSeqno:
+		return ps.DefaultMsgIdFn, nil
+
+	case MessageIDContent:
+		return func(m *pspb.Message) string {
+			h := sha256.New()
+			h.Write(topicPrefix(m.GetTopic()))
+			h.Write(m.GetData())
+			return string(h.Sum(nil))
+		}, nil
+
+	case MessageIDPrefix:
+		if prefixLen <= 0 {
+			return nil, fmt.Errorf("prefix message ids need a positive length, got %d", prefixLen)
+		}
+		return func(m *pspb.Message) string {
+			id := m.GetData()
+			if len(id) > prefixLen {
+				id = id[:prefixLen]
+			}
+			return string(append(topicPrefix(m.GetTopic()), id...))
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown pubsub message id scheme: %s", scheme)
+	}
+}
+
+// topicPrefix scopes message ids to a topic, as pubsub remembers the ids of
+// every topic together: the same message published to two topics must get
+// two ids. The topic is length-prefixed so that no topic and data can be
+// mistaken for another.
+func topicPrefix(topic string) []byte {
+	buf := make([]byte, binary.MaxVarintLen64, binary.MaxVarintLen64+len(topic))
+	n := binary.PutUvarint(buf, uint64(len(topic)))
+	return append(buf[:n], topic...)
+}
+
 func (d *Daemon) doPubsub(req *pb.Request) (*pb.Response, *ps.Subscription) {
 	if d.pubsub == nil {
 		return errorResponseString("PubSub not enabled"), nil
@@ -47,10 +114,23 @@ func (d *Daemon) doPubsubListPeers(req *pb.PSRequest) (*pb.Response, *ps.Subscri
 		return errorResponseString("Malformed request; missing topic parameter"), nil
 	}
 
+	if !d.subscribedTo(*req.Topic) {
+		return errorResponseString(fmt.Sprintf("Not subscribed to topic %s", *req.Topic)), nil
+	}
+
 	peers := d.pubsub.ListPeers(*req.Topic)
 	return psOkResponse(psResponsePeers(peers)), nil
 }
 
+func (d *Daemon) subscribedTo(topic string) bool {
+	for _, t := range d.pubsub.GetTopics() {
+		if t == topic {
+			return true
+		}
+	}
+	return false
+}
+
 func (d *Daemon) doPubsubPublish(req *pb.PSRequest) (*pb.Response, *ps.Subscription) {
 	if req.Topic == nil {
 		return errorResponseString("Malformed request; missing topic parameter"), nil
@@ -113,6 +193,154 @@ func (d *Daemon) doPubsubPipe(sub *ps.Subscription, r ggio.ReadCloser, w ggio.Wr
 	}
 }
 
+// doPersistentPubsub serves a pubsub request made on a persistent connection.
+// A subscription lasts until the call is cancelled or the connection closes.
+func (d *Daemon) doPersistentPubsub(connCtx context.Context, callID uuid.UUID, req *pb.PSRequest, w ggio.Writer) {
+	writeResponse := func(resp *pb.PersistentConnectionResponse) bool {
+		if err := w.WriteMsg(resp); err != nil {
+			log.Debugw("error writing message", "error", err)
+			return false
+		}
+		return true
+	}
+
+	if d.pubsub == nil {
+		writeResponse(errorUnaryCallString(callID, "PubSub not enabled"))
+		return
+	}
+
+	if req.Topic == nil {
+		writeResponse(errorUnaryCallString(callID, "Malformed request; missing topic parameter"))
+		return
+	}
+
+	switch req.GetType() {
+	case pb.PSRequest_PUBLISH:
+		//lint:ignore SA1019 requires API changes
+		if err := d.pubsub.Publish(*req.Topic, req.Data); err != nil {
+			writeResponse(errorUnaryCall(callID, err))
+			return
+		}
+		writeResponse(okUnaryCallResponse(callID))
+
+	case pb.PSRequest_SUBSCRIBE:
+		//lint:ignore SA1019 requires API changes
+		sub, err := d.pubsub.Subscribe(*req.Topic)
+		if err != nil {
+			writeResponse(errorUnaryCall(callID, err))
+			return
+		}
+		defer sub.Cancel()
+
+		ctx, cancel := context.WithCancel(connCtx)
+		defer cancel()
+
+		if !d.trackCall(callID, inflightCall{cancel, w}) {
+			writeResponse(errorDuplicateCallID(callID))
+			return
+		}
+		defer d.cancelUnary.Delete(callID)
+
+		for {
+			msg, err := sub.Next(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					writeResponse(okUnaryCallCancelled(callID))
+				} else {
+					writeResponse(errorUnaryCall(callID, err))
+				}
+				return
+			}
+
+			resp := okUnaryCallResponse(callID)
+			resp.Message = &pb.PersistentConnectionResponse_PubsubMessage{PubsubMessage: psMessage(msg)}
+			if !writeResponse(resp) {
+				return
+			}
+		}
+
+	default:
+		log.Debugw("unexpected persistent pubsub request type", "type", req.GetType())
+		writeResponse(errorUnaryCallString(callID, "Unexpected request"))
+	}
+}
+
+// doAddTopicValidator registers a validator for a topic that has the client
+// of w validate each message received on it.
+func (d *Daemon) doAddTopicValidator(w ggio.Writer, callID uuid.UUID, req *pb.AddTopicValidatorRequest) *pb.PersistentConnectionResponse {
+	if d.pubsub == nil {
+		return errorUnaryCallString(callID, "PubSub not enabled")
+	}
+
+	if req.GetTimeoutMs() < 0 {
+		return errorUnaryCallString(callID, "validator timeout can't be negative")
+	}
+	timeout := defaultValidatorTimeout
+	if req.GetTimeoutMs() > 0 {
+		timeout = time.Duration(req.GetTimeoutMs()) * time.Millisecond
+	}
+
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	topic := req.GetTopic()
+	if err := d.pubsub.RegisterTopicValidator(topic, d.clientValidator(w, topic, timeout)); err != nil {
+		return errorUnaryCall(callID, err)
+	}
+	d.topicValidatorOwners[topic] = w
+
+	log.Infow("set topic validator", "topic", topic)
+
+	return okUnaryCallResponse(callID)
+}
+
+// clientValidator forwards the messages received on topic to the client of
+// w, and waits for its verdict for up to timeout.
+func (d *Daemon) clientValidator(w ggio.Writer, topic string, timeout time.Duration) ps.ValidatorEx {
+	return func(ctx context.Context, _ peer.ID, msg *ps.Message) ps.ValidationResult {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		callID := uuid.New()
+		rc := make(chan *pb.PersistentConnectionRequest)
+		d.responseWaiters.Store(callID, responseWaiter{rc, ctx.Done(), cancel, w})
+		defer d.responseWaiters.Delete(callID)
+
+		if err := w.WriteMsg(&pb.PersistentConnectionResponse{
+			CallId: callID[:],
+			Message: &pb.PersistentConnectionResponse_ValidateMessage{
+				ValidateMessage: &pb.ValidateMessage{
+					Topic:   &topic,
+					Message: psMessage(msg),
+				},
+			},
+		}); err != nil {
+			log.Debugw("failed to write message to client", "error", err)
+			return ps.ValidationIgnore
+		}
+
+		select {
+		case resp := <-rc:
+			switch resp.GetValidationResult().GetResult() {
+			case pb.ValidationResult_ACCEPT:
+				return ps.ValidationAccept
+			case pb.ValidationResult_IGNORE:
+				return ps.ValidationIgnore
+			default:
+				return ps.ValidationReject
+			}
+
+		case <-ctx.Done():
+			if ctx.Err() == context.DeadlineExceeded {
+				log.Debugw("topic validator timed out; rejecting message", "topic", topic)
+				return ps.ValidationReject
+			}
+			// the connection owning the validator is gone
+			return ps.ValidationIgnore
+		}
+	}
+}
+
 func psResponseTopics(topics []string) *pb.PSResponse {
 	return &pb.PSResponse{Topics: topics}
 }
@@ -0,0 +1,86 @@
+package p2pd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/libp2p/go-libp2p-daemon/config"
+)
+
+// Ready returns nil if the daemon meets the readiness criterion, or an error
+// describing why it doesn't. The control socket is bound once the daemon is
+// created, which meets config.ReadyListening; config.ReadyBootstrapped needs a
+// successful bootstrap and config.ReadyDHT also a non-empty routing table.
+func (d *Daemon) Ready(criterion string) error {
+	switch criterion {
+	case config.ReadyListening:
+		return nil
+
+	case config.ReadyBootstrapped, config.ReadyDHT:
+		d.bootstrapMx.Lock()
+		bootstrapped := d.bootstrapped
+		d.bootstrapMx.Unlock()
+
+		if !bootstrapped {
+			return fmt.Errorf("bootstrap has not completed")
+		}
+		if criterion == config.ReadyBootstrapped {
+			return nil
+		}
+
+		if d.dht == nil {
+			return fmt.Errorf("dht is not enabled")
+		}
+		if d.dht.RoutingTable().Size() == 0 {
+			return fmt.Errorf("dht routing table is empty")
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unknown readiness criterion %s", criterion)
+	}
+}
+
+// ReadinessHandler replies 200 when the daemon meets the readiness criterion
+// and 503 with the reason otherwise, for use as a readiness probe.
+func (d *Daemon) ReadinessHandler(criterion string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := d.Ready(criterion); err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintln(w, "ready")
+	})
+}
+
+// StartupInfo is written as a single JSON line once the daemon has started,
+// giving clients a startup signal that is easier to parse than the
+// human-readable output.
+type StartupInfo struct {
+	PeerID       string   `json:"peerID"`
+	ListenAddrs  []string `json:"listenAddrs"`
+	ControlAddrs []string `json:"controlAddrs"`
+}
+
+// StartupInfo describes the daemon's identity and the addresses it listens on.
+func (d *Daemon) StartupInfo() StartupInfo {
+	info := StartupInfo{
+		PeerID:       d.ID().Pretty(),
+		ListenAddrs:  make([]string, 0),
+		ControlAddrs: make([]string, 0, len(d.listeners)),
+	}
+	for _, addr := range d.Addrs() {
+		info.ListenAddrs = append(info.ListenAddrs, addr.String())
+	}
+	for _, l := range d.listeners {
+		info.ControlAddrs = append(info.ControlAddrs, l.Multiaddr().String())
+	}
+	return info
+}
+
+// WriteStartupInfo writes StartupInfo to w as a single JSON line.
+func (d *Daemon) WriteStartupInfo(w io.Writer) error {
+	return json.NewEncoder(w).Encode(d.StartupInfo())
+}
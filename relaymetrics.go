@@ -0,0 +1,162 @@
+package p2pd
+
+import (
+	"time"
+
+	relay "github.com/libp2p/go-libp2p-circuit"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// defaultRelayMetricsInterval is how often the relay metrics are sampled,
+// unless SetRelayMetricsInterval says otherwise.
+const defaultRelayMetricsInterval = 10 * time.Second
+
+var relayCircuits = promauto.NewGauge(prometheus.GaugeOpts{
+	Namespace: metricsNamespace,
+	Name:      "relay_circuits",
+	Help:      "Number of circuits relayed between other peers",
+})
+
+var relayCircuitLimit = promauto.NewGauge(prometheus.GaugeOpts{
+	Namespace: metricsNamespace,
+	Name:      "relay_circuit_limit",
+	Help:      "Maximum number of circuits relayed and hop requests handled at once",
+})
+
+var relayCircuitPeers = promauto.NewGauge(prometheus.GaugeOpts{
+	Namespace: metricsNamespace,
+	Name:      "relay_circuit_peers",
+	Help:      "Number of peers with at least one relayed circuit, as source or destination",
+})
+
+var relayPeerCircuitsMax = promauto.NewGauge(prometheus.GaugeOpts{
+	Namespace: metricsNamespace,
+	Name:      "relay_peer_circuits_max",
+	Help:      "Largest number of relayed circuits a single peer is the source or destination of",
+})
+
+var relayBytes = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: metricsNamespace,
+	Name:      "relay_bytes_total",
+	Help:      "Bytes received (in) and sent (out) on relay streams; requires the bandwidth reporter",
+}, []string{"direction"})
+
+var relaysConnected = promauto.NewGauge(prometheus.GaugeOpts{
+	Namespace: metricsNamespace,
+	Name:      "relays_connected",
+	Help:      "Number of relays the host advertises circuit addresses through and is connected to",
+})
+
+var relayReconnectFailures = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: metricsNamespace,
+	Name:      "static_relay_connect_failures_total",
+	Help:      "Number of failed attempts to connect to a static relay",
+})
+
+// countRelayCircuits counts the circuits the host relays, in total and by
+// peer, from the relay streams open on its connections. A relayed circuit has
+// a stream from its source and one to its destination, while each of the
+// host's own relayed connections has a single stream to its relay. Streams
+// still in their handshake are counted too.
+func countRelayCircuits(n network.Network) (int, map[peer.ID]int) {
+	streams := make(map[peer.ID]int)
+	own := make(map[peer.ID]int)
+	for _, c := range n.Conns() {
+		if p, ok := relayPeer(c.RemoteMultiaddr()); ok {
+			own[p]++
+			continue
+		}
+		for _, s := range c.GetStreams() {
+			if s.Protocol() == relay.ProtoID {
+				streams[c.RemotePeer()]++
+			}
+		}
+	}
+
+	total := 0
+	byPeer := make(map[peer.ID]int)
+	for p, count := range streams {
+		if count -= own[p]; count > 0 {
+			byPeer[p] = count
+			total += count
+		}
+	}
+	return total / 2, byPeer
+}
+
+// SetRelayMetricsInterval sets how often the relay metrics are sampled while
+// the host runs a relay service or keeps static relays, which is every 10
+// seconds by default. It must be called before SetRelayService and
+// KeepStaticRelays to apply.
+func (d *Daemon) SetRelayMetricsInterval(interval time.Duration) {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	d.relayMetricsInterval = interval
+}
+
+// newRelayMetricsTicker returns a ticker firing whenever the relay metrics
+// are to be sampled.
+func (d *Daemon) newRelayMetricsTicker() *time.Ticker {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	return time.NewTicker(d.relayMetricsInterval)
+}
+
+// trackRelayCircuits updates the relay metrics while the host runs a relay
+// service, until the daemon is closed.
+func (d *Daemon) trackRelayCircuits() {
+	ticker := d.newRelayMetricsTicker()
+	defer ticker.Stop()
+
+	// the reporter may be shared with a previous host, whose bytes were
+	// already counted
+	var lastIn, lastOut int64
+	d.mx.Lock()
+	if d.bwc != nil {
+		s := d.bwc.GetBandwidthForProtocol(relay.ProtoID)
+		lastIn, lastOut = s.TotalIn, s.TotalOut
+	}
+	d.mx.Unlock()
+
+	for {
+		d.mx.Lock()
+		hop, bwc := d.relayHop, d.bwc
+		d.mx.Unlock()
+
+		if hop {
+			circuits, byPeer := countRelayCircuits(d.host.Network())
+			max := 0
+			for _, count := range byPeer {
+				if count > max {
+					max = count
+				}
+			}
+			relayCircuits.Set(float64(circuits))
+			relayCircuitPeers.Set(float64(len(byPeer)))
+			relayPeerCircuitsMax.Set(float64(max))
+			relayCircuitLimit.Set(float64(relay.HopStreamLimit))
+
+			if bwc != nil {
+				s := bwc.GetBandwidthForProtocol(relay.ProtoID)
+				if s.TotalIn > lastIn {
+					relayBytes.WithLabelValues("in").Add(float64(s.TotalIn - lastIn))
+				}
+				if s.TotalOut > lastOut {
+					relayBytes.WithLabelValues("out").Add(float64(s.TotalOut - lastOut))
+				}
+				lastIn, lastOut = s.TotalIn, s.TotalOut
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-d.ctx.Done():
+			return
+		}
+	}
+}
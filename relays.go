@@ -0,0 +1,254 @@
+package p2pd
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/event"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// doListRelays reports the relays the host advertises circuit addresses
+// through, as picked by autorelay or configured by the host's address
+// factory. Circuit relay v1 has no reservations: a relay is only usable while
+// the host stays connected to it, which the response tells.
+func (d *Daemon) doListRelays(req *pb.Request) *pb.Response {
+	relays := make([]*pb.RelayInfo, 0)
+	byPeer := make(map[peer.ID]*pb.RelayInfo)
+
+	for _, addr := range d.host.Addrs() {
+		p, ok := relayPeer(addr)
+		if !ok {
+			continue
+		}
+
+		info, ok := byPeer[p]
+		if !ok {
+			connected := d.host.Network().Connectedness(p) == network.Connected
+			info = &pb.RelayInfo{Peer: []byte(p), Connected: &connected}
+			byPeer[p] = info
+			relays = append(relays, info)
+		}
+		info.Addrs = append(info.Addrs, addr.Bytes())
+	}
+
+	res := okResponse()
+	res.Relays = relays
+	return res
+}
+
+// relayPeer returns the relay a circuit address of the host goes through.
+func relayPeer(addr ma.Multiaddr) (peer.ID, bool) {
+	if !isRelayAddr(addr) {
+		return "", false
+	}
+	relayAddr, _ := ma.SplitFunc(addr, func(c ma.Component) bool {
+		return c.Protocol().Code == ma.P_CIRCUIT
+	})
+	if relayAddr == nil {
+		return "", false
+	}
+
+	id, err := relayAddr.ValueForProtocol(ma.P_P2P)
+	if err != nil {
+		log.Debugw("circuit address without a relay peer id", "addr", addr)
+		return "", false
+	}
+	p, err := peer.Decode(id)
+	if err != nil {
+		log.Debugw("bad relay peer id", "addr", addr, "error", err)
+		return "", false
+	}
+	return p, true
+}
+
+// connectedRelays counts the relays the host advertises circuit addresses
+// through and is still connected to.
+func (d *Daemon) connectedRelays() int {
+	relays := make(map[peer.ID]struct{})
+	for _, addr := range d.host.Addrs() {
+		if p, ok := relayPeer(addr); ok && d.host.Network().Connectedness(p) == network.Connected {
+			relays[p] = struct{}{}
+		}
+	}
+	return len(relays)
+}
+
+// RefreshRelays makes autorelay look for relays again every interval while
+// the host is private and connected to fewer than desired of the relays it
+// uses, e.g. after losing one. Autorelay only looks for relays when the
+// host's reachability changes, and the host doesn't expose it, so the private
+// reachability is emitted again; the events forwarded to clients leave such
+// repeats out.
+func (d *Daemon) RefreshRelays(interval time.Duration, desired int) error {
+	sub, err := d.host.EventBus().Subscribe(new(event.EvtLocalReachabilityChanged))
+	if err != nil {
+		return err
+	}
+	em, err := d.host.EventBus().Emitter(new(event.EvtLocalReachabilityChanged))
+	if err != nil {
+		sub.Close()
+		return err
+	}
+
+	go func() {
+		defer sub.Close()
+		defer em.Close()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		reachability := network.ReachabilityUnknown
+		for {
+			select {
+			case evt, ok := <-sub.Out():
+				if !ok {
+					return
+				}
+				reachability = evt.(event.EvtLocalReachabilityChanged).Reachability
+
+			case <-ticker.C:
+				if reachability != network.ReachabilityPrivate {
+					continue
+				}
+				if n := d.connectedRelays(); n < desired {
+					log.Debugw("looking for relays", "connected", n, "desired", desired)
+					em.Emit(event.EvtLocalReachabilityChanged{Reachability: network.ReachabilityPrivate})
+				}
+
+			case <-d.ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// relayConnectTimeout bounds every attempt of KeepStaticRelays to connect to
+// a relay.
+const relayConnectTimeout = time.Minute
+
+// RelayReconnectPolicy controls how KeepStaticRelays retries connecting to a
+// relay.
+type RelayReconnectPolicy struct {
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between attempts.
+	MaxBackoff time.Duration
+	// Multiplier scales the delay after every failed attempt.
+	Multiplier float64
+	// Jitter randomizes every delay by up to this fraction of it, either way,
+	// so that nodes losing a relay at once don't retry in lockstep.
+	Jitter float64
+}
+
+// delay returns backoff randomized by the jitter of the policy.
+func (p RelayReconnectPolicy) delay(backoff time.Duration) time.Duration {
+	return time.Duration(float64(backoff) * (1 + p.Jitter*(2*rand.Float64()-1)))
+}
+
+// KeepStaticRelays keeps the host connected to relays until the daemon is
+// closed, retrying with exponential backoff according to policy whenever it
+// can't reach one. Autorelay gives up on its relays after a few attempts, so
+// it's made to look for them again, as with RefreshRelays, every time one is
+// connected while the host is private.
+func (d *Daemon) KeepStaticRelays(relays []peer.AddrInfo, policy RelayReconnectPolicy) error {
+	sub, err := d.host.EventBus().Subscribe(new(event.EvtPeerConnectednessChanged))
+	if err != nil {
+		return err
+	}
+	em, err := d.host.EventBus().Emitter(new(event.EvtLocalReachabilityChanged))
+	if err != nil {
+		sub.Close()
+		return err
+	}
+
+	disconnected := make(map[peer.ID]chan struct{}, len(relays))
+	for _, pi := range relays {
+		ch := make(chan struct{}, 1)
+		disconnected[pi.ID] = ch
+		go d.keepStaticRelay(pi, policy, ch, em)
+	}
+
+	go func() {
+		defer sub.Close()
+		defer em.Close()
+
+		ticker := d.newRelayMetricsTicker()
+		defer ticker.Stop()
+
+		for {
+			select {
+			case evt, ok := <-sub.Out():
+				if !ok {
+					return
+				}
+				e := evt.(event.EvtPeerConnectednessChanged)
+				if ch, ok := disconnected[e.Peer]; ok && e.Connectedness != network.Connected {
+					select {
+					case ch <- struct{}{}:
+					default:
+					}
+				}
+
+			case <-ticker.C:
+				relaysConnected.Set(float64(d.connectedRelays()))
+
+			case <-d.ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// keepStaticRelay connects to the relay pi whenever the host is disconnected
+// from it, which disconnected tells.
+func (d *Daemon) keepStaticRelay(pi peer.AddrInfo, policy RelayReconnectPolicy, disconnected <-chan struct{}, em event.Emitter) {
+	backoff := policy.InitialBackoff
+	for {
+		if d.host.Network().Connectedness(pi.ID) != network.Connected {
+			// the swarm's dial backoff would hold off the attempts, which
+			// are already spaced out
+			ctx, cancel := context.WithTimeout(network.WithForceDirectDial(d.ctx, "static relay"), relayConnectTimeout)
+			err := d.host.Connect(ctx, pi)
+			cancel()
+			if err != nil {
+				relayReconnectFailures.Inc()
+				log.Debugw("error connecting to static relay", "peer", pi.ID, "backoff", backoff, "error", err)
+
+				select {
+				case <-time.After(policy.delay(backoff)):
+				case <-d.ctx.Done():
+					return
+				}
+				backoff = time.Duration(float64(backoff) * policy.Multiplier)
+				if backoff > policy.MaxBackoff {
+					backoff = policy.MaxBackoff
+				}
+				continue
+			}
+
+			// as autorelay does
+			d.host.ConnManager().TagPeer(pi.ID, "relay", 42)
+			log.Infow("connected to static relay", "peer", pi.ID)
+			backoff = policy.InitialBackoff
+			if d.Reachability() == network.ReachabilityPrivate {
+				em.Emit(event.EvtLocalReachabilityChanged{Reachability: network.ReachabilityPrivate})
+			}
+		}
+
+		select {
+		case <-disconnected:
+		case <-d.ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package p2pd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	discovery "github.com/libp2p/go-libp2p-discovery"
+
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// defaultRendezvousInterval is how often the daemon advertises itself under
+// its rendezvous namespace, unless SetRendezvousInterval says otherwise.
+const defaultRendezvousInterval = 10 * time.Minute
+
+// SetRendezvousInterval sets how often the daemon advertises itself under its
+// rendezvous namespace and looks for the other peers advertising under it,
+// which is every 10 minutes by default. It applies to rendezvous started
+// afterwards.
+func (d *Daemon) SetRendezvousInterval(interval time.Duration) {
+	d.rendezvousMx.Lock()
+	defer d.rendezvousMx.Unlock()
+
+	d.rendezvousInterval = interval
+}
+
+// StartRendezvous has the daemon advertise itself to the DHT under ns right
+// away and then at the rendezvous interval, each time looking for the other
+// peers advertising under ns and connecting to those it isn't connected to.
+// Failed advertisements are retried every minute. It fails if the DHT isn't
+// enabled or the daemon already has a rendezvous namespace.
+func (d *Daemon) StartRendezvous(ns string) error {
+	if d.dht == nil {
+		return errors.New("DHT not enabled")
+	}
+	if ns == "" {
+		return errors.New("rendezvous namespace can't be empty")
+	}
+
+	d.rendezvousMx.Lock()
+	defer d.rendezvousMx.Unlock()
+
+	if d.rendezvousNS != "" {
+		return errors.New("rendezvous already started")
+	}
+	d.rendezvousNS = ns
+	d.rendezvousPeers = make(map[peer.ID]peer.AddrInfo)
+
+	go d.rendezvous(ns, d.rendezvousInterval)
+	return nil
+}
+
+// RendezvousPeers returns the peers found under the rendezvous namespace the
+// last time the daemon looked, or so far the first time.
+func (d *Daemon) RendezvousPeers() []peer.AddrInfo {
+	d.rendezvousMx.Lock()
+	defer d.rendezvousMx.Unlock()
+
+	peers := make([]peer.AddrInfo, 0, len(d.rendezvousPeers))
+	for _, pi := range d.rendezvousPeers {
+		peers = append(peers, pi)
+	}
+	sort.Slice(peers, func(i, j int) bool {
+		return bytes.Compare([]byte(peers[i].ID), []byte(peers[j].ID)) < 0
+	})
+	return peers
+}
+
+func (d *Daemon) rendezvous(ns string, interval time.Duration) {
+	disc := discovery.NewRoutingDiscovery(d.dht)
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+		case <-d.ctx.Done():
+			return
+		}
+
+		if d.advertise(disc, ns) {
+			timer.Reset(interval)
+		} else {
+			timer.Reset(provideRetryInterval)
+		}
+		d.discoverPeers(disc, ns)
+	}
+}
+
+// advertise advertises the daemon under ns and reports whether it succeeded.
+func (d *Daemon) advertise(disc *discovery.RoutingDiscovery, ns string) bool {
+	ctx, cancel := context.WithTimeout(d.ctx, DefaultTimeout)
+	defer cancel()
+
+	if _, err := disc.Advertise(ctx, ns); err != nil {
+		if d.ctx.Err() == nil {
+			log.Warnw("error advertising under the rendezvous namespace", "namespace", ns, "error", err)
+		}
+		return false
+	}
+	return true
+}
+
+// discoverPeers looks for the peers advertising under ns, connecting to them,
+// and replaces the peers found the last time with them.
+func (d *Daemon) discoverPeers(disc *discovery.RoutingDiscovery, ns string) {
+	ctx, cancel := context.WithTimeout(d.ctx, DefaultTimeout)
+	defer cancel()
+
+	peers, err := disc.FindPeers(ctx, ns)
+	if err != nil {
+		log.Warnw("error looking for rendezvous peers", "namespace", ns, "error", err)
+		return
+	}
+
+	found := make(map[peer.ID]struct{})
+	var wg sync.WaitGroup
+	for pi := range peers {
+		if pi.ID == d.host.ID() || len(pi.Addrs) == 0 {
+			continue
+		}
+		found[pi.ID] = struct{}{}
+
+		d.rendezvousMx.Lock()
+		d.rendezvousPeers[pi.ID] = pi
+		d.rendezvousMx.Unlock()
+
+		if d.host.Network().Connectedness(pi.ID) == network.Connected {
+			continue
+		}
+		wg.Add(1)
+		go func(pi peer.AddrInfo) {
+			defer wg.Done()
+			if err := d.host.Connect(ctx, pi); err != nil {
+				log.Debugw("error connecting to rendezvous peer", "peer", pi.ID, "error", err)
+			}
+		}(pi)
+	}
+	wg.Wait()
+
+	if d.ctx.Err() != nil {
+		return
+	}
+	log.Debugw("found rendezvous peers", "namespace", ns, "peers", len(found))
+
+	d.rendezvousMx.Lock()
+	defer d.rendezvousMx.Unlock()
+	for p := range d.rendezvousPeers {
+		if _, ok := found[p]; !ok {
+			delete(d.rendezvousPeers, p)
+		}
+	}
+}
+
+func (d *Daemon) doRendezvousPeers(req *pb.Request) *pb.Response {
+	d.rendezvousMx.Lock()
+	ns := d.rendezvousNS
+	d.rendezvousMx.Unlock()
+
+	if ns == "" {
+		return errorResponseString("Rendezvous not enabled")
+	}
+
+	peers := d.RendezvousPeers()
+	res := okResponse()
+	res.Peers = make([]*pb.PeerInfo, len(peers))
+	for i, pi := range peers {
+		res.Peers[i] = peerInfo2pb(pi)
+	}
+	return res
+}
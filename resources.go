@@ -0,0 +1,96 @@
+package p2pd
+
+import (
+	"sort"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/protocol"
+
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+type resourceUsage struct {
+	connsInbound, connsOutbound     int64
+	streamsInbound, streamsOutbound int64
+}
+
+func (u *resourceUsage) addConn(dir network.Direction) {
+	if dir == network.DirInbound {
+		u.connsInbound++
+	} else {
+		u.connsOutbound++
+	}
+}
+
+func (u *resourceUsage) addStream(dir network.Direction) {
+	if dir == network.DirInbound {
+		u.streamsInbound++
+	} else {
+		u.streamsOutbound++
+	}
+}
+
+func (u *resourceUsage) toPb() *pb.ResourceScope {
+	return &pb.ResourceScope{
+		ConnsInbound:    &u.connsInbound,
+		ConnsOutbound:   &u.connsOutbound,
+		StreamsInbound:  &u.streamsInbound,
+		StreamsOutbound: &u.streamsOutbound,
+	}
+}
+
+func (d *Daemon) doResourceUsage(req *pb.Request) *pb.Response {
+	var system resourceUsage
+	protocols := make(map[protocol.ID]*resourceUsage)
+	peers := make(map[peer.ID]*resourceUsage)
+
+	for _, c := range d.host.Network().Conns() {
+		p := c.RemotePeer()
+		if peers[p] == nil {
+			peers[p] = &resourceUsage{}
+		}
+
+		dir := c.Stat().Direction
+		system.addConn(dir)
+		peers[p].addConn(dir)
+
+		for _, s := range c.GetStreams() {
+			dir := s.Stat().Direction
+			system.addStream(dir)
+			peers[p].addStream(dir)
+
+			proto := s.Protocol()
+			if protocols[proto] == nil {
+				protocols[proto] = &resourceUsage{}
+			}
+			protocols[proto].addStream(dir)
+		}
+	}
+
+	usage := &pb.ResourceUsageResponse{
+		System:    system.toPb(),
+		Protocols: make([]*pb.ProtocolResourceScope, 0, len(protocols)),
+		Peers:     make([]*pb.PeerResourceScope, 0, len(peers)),
+	}
+	for proto, u := range protocols {
+		name := string(proto)
+		usage.Protocols = append(usage.Protocols, &pb.ProtocolResourceScope{
+			Proto: &name,
+			Usage: u.toPb(),
+		})
+	}
+	sort.Slice(usage.Protocols, func(i, j int) bool {
+		return usage.Protocols[i].GetProto() < usage.Protocols[j].GetProto()
+	})
+	for p, u := range peers {
+		usage.Peers = append(usage.Peers, &pb.PeerResourceScope{
+			Peer:  []byte(p),
+			Usage: u.toPb(),
+		})
+	}
+
+	res := okResponse()
+	res.ResourceUsage = usage
+	return res
+}
@@ -0,0 +1,154 @@
+package p2pd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+	tcp "github.com/libp2p/go-tcp-transport"
+	ma "github.com/multiformats/go-multiaddr"
+
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// IdentityRotator starts the daemon taking over on identity rotation: an
+// embedded daemon whose host uses key and is otherwise configured like the
+// one it replaces.
+type IdentityRotator func(key crypto.PrivKey) (*Daemon, error)
+
+// SetIdentityRotator enables identity rotation, with rotator starting the
+// daemons taking over. Daemons it starts inherit it unless they set their own.
+func (d *Daemon) SetIdentityRotator(rotator IdentityRotator) {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+	d.rotator = rotator
+}
+
+// Current returns the daemon serving the control listeners: this one, or the
+// last one taking over from it on identity rotation.
+func (d *Daemon) Current() *Daemon {
+	for {
+		d.mx.Lock()
+		next := d.successor
+		d.mx.Unlock()
+
+		if next == nil {
+			return d
+		}
+		d = next
+	}
+}
+
+// RotateIdentity starts a daemon with a host using key and hands the control
+// listeners over to it: control connections accepted from then on are served
+// by the new daemon. This daemon stops accepting unary handlers, waits up to
+// gracePeriod for persistent connections and in-flight calls to finish, and
+// closes along with its host.
+//
+// The new host has another peer ID, so it starts without any of the
+// connections, DHT routing table, pubsub subscriptions or handlers of the old
+// one.
+func (d *Daemon) RotateIdentity(key crypto.PrivKey, gracePeriod time.Duration) (*Daemon, error) {
+	d.mx.Lock()
+	rotator := d.rotator
+	switch {
+	case rotator == nil:
+		d.mx.Unlock()
+		return nil, errors.New("identity rotation is not enabled")
+	case d.closed || d.draining:
+		d.mx.Unlock()
+		return nil, errors.New("daemon is shutting down")
+	}
+	// refuse concurrent rotations and new handlers while the successor starts
+	d.draining = true
+	d.mx.Unlock()
+
+	successor, err := rotator(key)
+	if err != nil {
+		d.mx.Lock()
+		d.draining = false
+		d.mx.Unlock()
+		return nil, err
+	}
+
+	successor.mx.Lock()
+	if successor.rotator == nil {
+		successor.rotator = rotator
+	}
+	successor.mx.Unlock()
+
+	d.mx.Lock()
+	successor.listeners = d.listeners
+	d.listeners = nil
+	d.successor = successor
+	d.mx.Unlock()
+
+	if len(successor.listeners) > 0 {
+		go successor.trapSignals()
+	}
+
+	log.Infow("rotated identity", "old", d.ID(), "new", successor.ID(), "grace", gracePeriod)
+
+	go func() {
+		d.drain(gracePeriod)
+		d.Close()
+	}()
+
+	return successor, nil
+}
+
+// CheckRotatableAddrs fails if a host listening on addrs can't have its
+// identity rotated: the new host listens on the same addresses while the old
+// one drains, so it can't bind the fixed ports the old one holds. Only TCP
+// ports are shared, through SO_REUSEPORT, and only when the TCP transport
+// uses it; UDP ports, such as QUIC's, and websocket ports never are.
+func CheckRotatableAddrs(addrs []ma.Multiaddr) error {
+	for _, addr := range addrs {
+		if port, err := addr.ValueForProtocol(ma.P_UDP); err == nil && port != "0" {
+			return fmt.Errorf("can't rotate the identity while listening on the fixed UDP port of %s", addr)
+		}
+		port, err := addr.ValueForProtocol(ma.P_TCP)
+		if err != nil || port == "0" {
+			continue
+		}
+		if _, err := addr.ValueForProtocol(ma.P_WS); err == nil {
+			return fmt.Errorf("can't rotate the identity while listening on the fixed websocket port of %s", addr)
+		}
+		if _, err := addr.ValueForProtocol(ma.P_WSS); err == nil {
+			return fmt.Errorf("can't rotate the identity while listening on the fixed websocket port of %s", addr)
+		}
+		if !tcp.ReuseportIsAvailable() {
+			return fmt.Errorf("can't rotate the identity while listening on the fixed TCP port of %s without SO_REUSEPORT", addr)
+		}
+	}
+	return nil
+}
+
+func (d *Daemon) doRotateIdentity(req *pb.Request) *pb.Response {
+	keyType := req.GetRotateIdentity().GetKeyType()
+	if keyType == "" {
+		typ, ok := keyTypeName(int(d.host.Peerstore().PrivKey(d.ID()).Type()))
+		if !ok {
+			return errorResponseString("unknown type of the current key; set the key type")
+		}
+		keyType = typ
+	}
+
+	key, err := NewIdentity(keyType, DefaultRSAKeyBits)
+	if err != nil {
+		return errorResponse(err)
+	}
+
+	gracePeriod := d.defaultGracePeriod()
+	if req.GetRotateIdentity().GetGracePeriodMs() > 0 {
+		gracePeriod = time.Duration(req.GetRotateIdentity().GetGracePeriodMs()) * time.Millisecond
+	}
+
+	successor, err := d.RotateIdentity(key, gracePeriod)
+	if err != nil {
+		return errorResponse(err)
+	}
+
+	return successor.doIdentify(req)
+}
@@ -0,0 +1,142 @@
+package p2pd
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/sec"
+	manet "github.com/multiformats/go-multiaddr/net"
+)
+
+// securityUpgradeTimeout bounds how long a connection may take to be
+// upgraded once secured; the connections the swarm doesn't report as opened
+// by then are forgotten, e.g. those the connection gater rejected.
+const securityUpgradeTimeout = time.Minute
+
+// securityTracker records the security protocol negotiated for each
+// connection, which the upgrader doesn't expose. Connections are told apart by
+// their peer and addresses, as the swarm reports them.
+type securityTracker struct {
+	mx    sync.Mutex
+	conns map[securedConn]*securedConnInfo
+}
+
+type securedConn struct {
+	peer          peer.ID
+	local, remote string
+}
+
+type securedConnInfo struct {
+	id      string
+	secured time.Time
+	opened  bool
+}
+
+func newSecurityTracker() *securityTracker {
+	return &securityTracker{conns: make(map[securedConn]*securedConnInfo)}
+}
+
+func securedConnOf(c network.Conn) securedConn {
+	return securedConn{c.RemotePeer(), c.LocalMultiaddr().String(), c.RemoteMultiaddr().String()}
+}
+
+// option wraps the security transports configured by the options before it,
+// so that the connections they secure are recorded. The transports libp2p
+// falls back to when none is configured aren't wrapped.
+func (st *securityTracker) option() libp2p.Option {
+	return func(cfg *libp2p.Config) error {
+		for i, tpt := range cfg.SecurityTransports {
+			secC, id := tpt.SecC, tpt.ID
+			cfg.SecurityTransports[i].SecC = func(h host.Host) (sec.SecureTransport, error) {
+				t, err := secC(h)
+				if err != nil {
+					return nil, err
+				}
+				return &trackedSecureTransport{SecureTransport: t, id: id, tracker: st}, nil
+			}
+		}
+		return nil
+	}
+}
+
+// add records the security protocol id negotiated over insecure. Connections
+// wrapped by a private network protector aren't recorded, as their addresses
+// are lost.
+func (st *securityTracker) add(p peer.ID, insecure net.Conn, id string) {
+	c, ok := insecure.(manet.Conn)
+	if !ok {
+		return
+	}
+
+	st.mx.Lock()
+	defer st.mx.Unlock()
+
+	now := time.Now()
+	for key, info := range st.conns {
+		if !info.opened && now.Sub(info.secured) > securityUpgradeTimeout {
+			delete(st.conns, key)
+		}
+	}
+	key := securedConn{p, c.LocalMultiaddr().String(), c.RemoteMultiaddr().String()}
+	st.conns[key] = &securedConnInfo{id: id, secured: now}
+}
+
+// opened keeps the security protocol of c until it is closed.
+func (st *securityTracker) opened(c network.Conn) {
+	st.mx.Lock()
+	defer st.mx.Unlock()
+
+	if info, ok := st.conns[securedConnOf(c)]; ok {
+		info.opened = true
+	}
+}
+
+// closed forgets the security protocol of c.
+func (st *securityTracker) closed(c network.Conn) {
+	st.mx.Lock()
+	defer st.mx.Unlock()
+
+	delete(st.conns, securedConnOf(c))
+}
+
+// get returns the security protocol negotiated for c, or "" if it wasn't
+// recorded.
+func (st *securityTracker) get(c network.Conn) string {
+	st.mx.Lock()
+	defer st.mx.Unlock()
+
+	if info, ok := st.conns[securedConnOf(c)]; ok {
+		return info.id
+	}
+	return ""
+}
+
+// trackedSecureTransport records the connections secured by SecureTransport
+// with the protocol id it is registered with.
+type trackedSecureTransport struct {
+	sec.SecureTransport
+	id      string
+	tracker *securityTracker
+}
+
+func (t *trackedSecureTransport) SecureInbound(ctx context.Context, insecure net.Conn) (sec.SecureConn, error) {
+	conn, err := t.SecureTransport.SecureInbound(ctx, insecure)
+	if err == nil {
+		t.tracker.add(conn.RemotePeer(), insecure, t.id)
+	}
+	return conn, err
+}
+
+func (t *trackedSecureTransport) SecureOutbound(ctx context.Context, insecure net.Conn, p peer.ID) (sec.SecureConn, error) {
+	conn, err := t.SecureTransport.SecureOutbound(ctx, insecure, p)
+	if err == nil {
+		t.tracker.add(conn.RemotePeer(), insecure, t.id)
+	}
+	return conn, err
+}
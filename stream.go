@@ -8,6 +8,7 @@ import (
 	"github.com/libp2p/go-libp2p-core/network"
 
 	ggio "github.com/gogo/protobuf/io"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
 	manet "github.com/multiformats/go-multiaddr/net"
 )
 
@@ -31,6 +32,108 @@ func (d *Daemon) doStreamPipe(c net.Conn, s network.Stream) {
 	wg.Wait()
 }
 
+// streamFrameSize bounds the data carried by the frames of framed streams.
+const streamFrameSize = 1 << 16
+
+// doFramedStreamPipe pipes s to the client as StreamFrame messages, which,
+// unlike doStreamPipe, lets each side close its end of the stream on its own
+// and tells the client whether the stream was closed or reset.
+func (d *Daemon) doFramedStreamPipe(r ggio.Reader, w ggio.Writer, s network.Stream) {
+	recv := func() (*pb.StreamFrame, error) {
+		var frame pb.StreamFrame
+		if err := r.ReadMsg(&frame); err != nil {
+			return nil, err
+		}
+		return &frame, nil
+	}
+	send := func(frame *pb.StreamFrame) error {
+		return w.WriteMsg(frame)
+	}
+
+	pipeFrames(s, recv, send)
+}
+
+// pipeFrames sends the data read from s as frames with send, and writes to s
+// the frames received with recv. It returns once the stream is done both
+// ways, or was reset; recv must then fail, and failing earlier means that
+// the client is gone.
+func pipeFrames(s network.Stream, recv func() (*pb.StreamFrame, error), send func(*pb.StreamFrame) error) {
+	toClient := make(chan bool, 1)
+	go func() {
+		buf := make([]byte, streamFrameSize)
+		for {
+			n, err := s.Read(buf)
+			if n > 0 {
+				// send may queue the frame, so it can't share buf
+				data := append([]byte(nil), buf[:n]...)
+				if werr := send(&pb.StreamFrame{Data: data}); werr != nil {
+					log.Debugw("error writing frame", "error", werr)
+					s.Reset()
+					toClient <- false
+					return
+				}
+			}
+
+			if err == io.EOF {
+				closeWrite := true
+				toClient <- send(&pb.StreamFrame{CloseWrite: &closeWrite}) == nil
+				return
+			}
+			if err != nil {
+				log.Debugw("stream error", "error", err)
+				msg := err.Error()
+				send(&pb.StreamFrame{Error: &msg})
+				toClient <- false
+				return
+			}
+		}
+	}()
+
+	fromClient, finished := make(chan struct{}), make(chan struct{})
+	go func() {
+		closedWrite := false
+		for {
+			frame, err := recv()
+			if err != nil {
+				select {
+				case <-finished:
+				default:
+					// the client is gone before the stream was done
+					s.Reset()
+				}
+				break
+			}
+			if frame.Error != nil {
+				s.Reset()
+				break
+			}
+			if closedWrite {
+				continue
+			}
+
+			if _, err := s.Write(frame.GetData()); err != nil {
+				log.Debugw("stream error", "error", err)
+				s.Reset()
+				break
+			}
+			if frame.GetCloseWrite() {
+				s.CloseWrite()
+				closedWrite = true
+				close(fromClient)
+			}
+		}
+		if !closedWrite {
+			close(fromClient)
+		}
+	}()
+
+	if ok := <-toClient; ok {
+		<-fromClient
+	}
+	close(finished)
+	s.Close()
+}
+
 func (d *Daemon) handleStream(s network.Stream) {
 	p := s.Protocol()
 
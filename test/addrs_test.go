@@ -0,0 +1,55 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/libp2p/go-libp2p"
+	ma "github.com/multiformats/go-multiaddr"
+
+	p2pd "github.com/libp2p/go-libp2p-daemon"
+)
+
+func TestListAddrs(t *testing.T) {
+	dmaddr, cmaddr, dirCloser := getEndpointsMaker(t)(t)
+	defer dirCloser()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// advertise an external and a circuit address, as identify and
+	// autorelay would
+	observed := ma.StringCast("/ip4/1.2.3.4/tcp/4001")
+	circuit := ma.StringCast("/ip4/5.6.7.8/tcp/4001/p2p/QmcgpsyWgH8Y8ajJz1Cu72KnS5uo2Aa2LpzU7kinSoooo4/p2p-circuit")
+	d, err := p2pd.NewDaemon(ctx, []ma.Multiaddr{dmaddr}, "",
+		libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"),
+		libp2p.AddrsFactory(func(addrs []ma.Multiaddr) []ma.Multiaddr {
+			return append(addrs, observed, circuit)
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	go d.Serve()
+
+	c, clientCloser := createClient(t, d.Listener().Multiaddr(), cmaddr)
+	defer clientCloser()
+
+	addrs, err := c.ListAddrs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(addrs.Listen) != 1 {
+		t.Fatalf("expected the listen address, got %v", addrs.Listen)
+	}
+	if len(addrs.Observed) != 1 || !addrs.Observed[0].Equal(observed) {
+		t.Fatalf("expected the external address to be observed, got %v", addrs.Observed)
+	}
+	if len(addrs.Relay) != 1 || !addrs.Relay[0].Equal(circuit) {
+		t.Fatalf("expected the circuit address to be a relay address, got %v", addrs.Relay)
+	}
+	if len(addrs.All()) != len(d.Addrs()) {
+		t.Fatalf("expected all of %v, got %v", d.Addrs(), addrs.All())
+	}
+}
@@ -0,0 +1,65 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p-core/network"
+	ma "github.com/multiformats/go-multiaddr"
+
+	p2pd "github.com/libp2p/go-libp2p-daemon"
+)
+
+func TestAutoNATReachability(t *testing.T) {
+	_, c, closer := createDaemonClientPair(t)
+	defer closer()
+
+	reachability, err := c.Reachability()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reachability != network.ReachabilityUnknown {
+		t.Fatalf("expected unknown reachability, got %s", reachability)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := c.ProbeReachability(ctx); err == nil {
+		t.Fatal("expected the probe to fail without AutoNAT servers")
+	}
+}
+
+func TestAutoNATForcedReachability(t *testing.T) {
+	dmaddr, cmaddr, dirCloser := getEndpointsMaker(t)(t)
+	defer dirCloser()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	d, err := p2pd.NewDaemon(ctx, []ma.Multiaddr{dmaddr}, "", libp2p.ForceReachabilityPublic())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	go d.Serve()
+
+	c, closeClient := createClient(t, d.Listener().Multiaddr(), cmaddr)
+	defer closeClient()
+
+	// the reachability is tracked in the background
+	deadline := time.Now().Add(time.Second)
+	for {
+		reachability, err := c.Reachability()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if reachability == network.ReachabilityPublic {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected public reachability, got %s", reachability)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,89 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p-core/metrics"
+	p2pd "github.com/libp2p/go-libp2p-daemon"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+func TestBandwidth(t *testing.T) {
+	dmaddr, cmaddr, dirCloser := getEndpointsMaker(t)(t)
+	defer dirCloser()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bwc := metrics.NewBandwidthCounter()
+	d, err := p2pd.NewDaemon(ctx, []ma.Multiaddr{dmaddr}, "", libp2p.BandwidthReporter(bwc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	d.SetBandwidthReporter(bwc)
+	go d.Serve()
+
+	c, clientCloser := createClient(t, d.Listener().Multiaddr(), cmaddr)
+	defer clientCloser()
+
+	other, err := libp2p.New(ctx, libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer other.Close()
+
+	if err := c.Connect(other.ID(), other.Addrs()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Ping(ctx, other.ID(), 3); err != nil {
+		t.Fatal(err)
+	}
+
+	// the counters are updated periodically
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		forPeer, err := c.BandwidthForPeer(other.ID())
+		if err != nil {
+			t.Fatal(err)
+		}
+		// queried after the peer's stats, so it includes them
+		total, err := c.BandwidthTotals()
+		if err != nil {
+			t.Fatal(err)
+		}
+		byPeer, err := c.BandwidthByPeer()
+		if err != nil {
+			t.Fatal(err)
+		}
+		byProtocol, err := c.BandwidthByProtocol()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ping := byProtocol["/ipfs/ping/1.0.0"]
+		if total.TotalOut > 0 && forPeer.TotalOut > 0 && byPeer[other.ID()].TotalOut > 0 && ping.TotalOut > 0 {
+			if total.TotalOut < forPeer.TotalOut || total.TotalIn < forPeer.TotalIn {
+				t.Fatalf("expected the totals %+v to include the peer's stats %+v", total, forPeer)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("bandwidth wasn't counted: total %+v, peer %+v, by peer %v, by protocol %v",
+				total, forPeer, byPeer, byProtocol)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+func TestBandwidthDisabled(t *testing.T) {
+	_, c, closer := createDaemonClientPair(t)
+	defer closer()
+
+	if _, err := c.BandwidthTotals(); err == nil {
+		t.Fatal("expected an error without a bandwidth reporter")
+	}
+}
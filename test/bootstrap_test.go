@@ -0,0 +1,40 @@
+package test
+
+import (
+	"testing"
+
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+func TestSetBootstrapPeers(t *testing.T) {
+	_, c1, closer1 := createDaemonClientPair(t)
+	defer closer1()
+	d2, _, closer2 := createDaemonClientPair(t)
+	defer closer2()
+
+	// addresses without a peer id are rejected
+	if err := c1.SetBootstrapPeers(d2.Addrs(), true); err == nil {
+		t.Fatal("expected bootstrap peers without a peer id to be rejected")
+	}
+	if err := c1.SetBootstrapPeers(nil, false); err == nil {
+		t.Fatal("expected an empty bootstrap peer set to be rejected")
+	}
+
+	p2pAddr, err := ma.NewComponent("p2p", d2.ID().Pretty())
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := d2.Addrs()[0].Encapsulate(p2pAddr)
+
+	if err := c1.SetBootstrapPeers([]ma.Multiaddr{addr}, true); err != nil {
+		t.Fatal(err)
+	}
+
+	peers, err := c1.ListConnections()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(peers) != 1 || peers[0].ID != d2.ID() {
+		t.Fatalf("expected the daemon to connect to the new bootstrap peer, got %v", peers)
+	}
+}
@@ -0,0 +1,57 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/protocol"
+)
+
+func TestCallHistory(t *testing.T) {
+	_, p1, cancel1 := createDaemonClientPair(t)
+	defer cancel1()
+	d2, p2, cancel2 := createDaemonClientPair(t)
+	defer cancel2()
+
+	if _, err := p2.CallHistory(); err == nil {
+		t.Fatal("expected the call history to be disabled by default")
+	}
+	d2.SetCallHistorySize(2)
+
+	peer1ID, peer1Addrs, err := p1.Identify()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p2.Connect(peer1ID, peer1Addrs); err != nil {
+		t.Fatal(err)
+	}
+
+	var proto protocol.ID = "sqrt"
+	if err := p1.AddUnaryHandler(proto, sqrtHandler); err != nil {
+		t.Fatal(err)
+	}
+
+	// the first call is pushed out of the history by the last two
+	for _, x := range []float64{4, 16, -16} {
+		p2.CallUnaryHandler(context.Background(), peer1ID, proto, float64Bytes(x))
+	}
+
+	records, err := p2.CallHistory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected 2 calls in the history, got %d", len(records))
+	}
+
+	ok, failed := records[0], records[1]
+	if ok.Peer != peer1ID || ok.Proto != proto || ok.Result != "success" || ok.Error != "" {
+		t.Fatalf("expected a successful call to %s over %s, got %+v", peer1ID, proto, ok)
+	}
+	if failed.Result != "error" || failed.Error == "" {
+		t.Fatalf("expected a failed call with its error, got %+v", failed)
+	}
+	if failed.StartTime.Before(ok.StartTime) || ok.Duration <= 0 {
+		t.Fatalf("expected the calls in order with their duration, got %+v", records)
+	}
+}
@@ -0,0 +1,153 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p-core/peer"
+
+	p2pd "github.com/libp2p/go-libp2p-daemon"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+func TestConnManagerSetLimits(t *testing.T) {
+	dmaddr, cmaddr, dirCloser := getEndpointsMaker(t)(t)
+	defer dirCloser()
+
+	cm := p2pd.NewConnManager(10, 20, time.Minute)
+	d, err := p2pd.NewDaemon(context.Background(), []ma.Multiaddr{dmaddr}, "", libp2p.ConnectionManager(cm))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	go d.Serve()
+
+	c, clientCloser := createClient(t, d.Listener().Multiaddr(), cmaddr)
+	defer clientCloser()
+
+	peers := make([]peer.ID, 3)
+	for i := range peers {
+		other, _, closer := createDaemonClientPair(t)
+		defer closer()
+
+		if err := c.Connect(other.ID(), other.Addrs()); err != nil {
+			t.Fatal(err)
+		}
+		peers[i] = other.ID()
+	}
+	kept, protected, trimmed := peers[0], peers[1], peers[2]
+
+	cm.TagPeer(kept, "keep", 100)
+	cm.Protect(protected, "protect")
+
+	if err := c.SetConnManagerLimits(2, 1, 0); err == nil {
+		t.Fatal("expected a low water mark above the high water mark to be rejected")
+	}
+	// water marks count connections rather than peers, and there may be
+	// several connections to a peer, so keep just enough for the tagged one
+	low := len(cm.GetTagInfo(kept).Conns)
+	if err := c.SetConnManagerLimits(low, low, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	info := cm.GetInfo()
+	if info.LowWater != low || info.HighWater != low || info.GracePeriod != 0 {
+		t.Fatalf("limits were not updated: %+v", info)
+	}
+	if info.ConnCount < len(peers) {
+		t.Fatalf("expected at least %d connections to be carried over, got %d", len(peers), info.ConnCount)
+	}
+	if tags := cm.GetTagInfo(kept); tags == nil || tags.Tags["keep"] != 100 {
+		t.Fatal("expected tags to be carried over")
+	}
+	if !cm.IsProtected(protected, "protect") {
+		t.Fatal("expected protections to be carried over")
+	}
+
+	cm.TrimOpenConns(context.Background())
+
+	connected := make(map[peer.ID]bool)
+	conns, err := c.ListConnections()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, cp := range conns {
+		connected[cp.ID] = true
+	}
+	if !connected[kept] || !connected[protected] || connected[trimmed] {
+		t.Fatalf("expected only the untagged peer to be trimmed, connected to %v", connected)
+	}
+}
+
+func TestConnManagerProtect(t *testing.T) {
+	dmaddr, cmaddr, dirCloser := getEndpointsMaker(t)(t)
+	defer dirCloser()
+
+	cm := p2pd.NewConnManager(10, 20, time.Minute)
+	d, err := p2pd.NewDaemon(context.Background(), []ma.Multiaddr{dmaddr}, "", libp2p.ConnectionManager(cm))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	go d.Serve()
+
+	c, clientCloser := createClient(t, d.Listener().Multiaddr(), cmaddr)
+	defer clientCloser()
+
+	peers := make([]peer.ID, 3)
+	for i := range peers {
+		other, _, closer := createDaemonClientPair(t)
+		defer closer()
+
+		if err := c.Connect(other.ID(), other.Addrs()); err != nil {
+			t.Fatal(err)
+		}
+		peers[i] = other.ID()
+	}
+	kept, protected, trimmed := peers[0], peers[1], peers[2]
+
+	cm.TagPeer(kept, "keep", 100)
+
+	if err := c.Protect(protected, ""); err == nil {
+		t.Fatal("expected protecting a peer without a tag to fail")
+	}
+	if err := c.Protect(protected, "server"); err != nil {
+		t.Fatal(err)
+	}
+	if !cm.IsProtected(protected, "server") {
+		t.Fatal("expected the peer to be protected")
+	}
+
+	// protected peers aren't counted against the low water mark, so keep
+	// just enough connections for the tagged one
+	low := len(cm.GetTagInfo(kept).Conns)
+	if err := c.SetConnManagerLimits(low, low, 0); err != nil {
+		t.Fatal(err)
+	}
+	cm.TrimOpenConns(context.Background())
+
+	if len(d.Host().Network().ConnsToPeer(trimmed)) != 0 {
+		t.Fatal("expected the unprotected peer to be trimmed")
+	}
+	if len(d.Host().Network().ConnsToPeer(protected)) == 0 {
+		t.Fatal("expected the protected peer to stay connected")
+	}
+
+	if err := c.Unprotect(protected, "server"); err != nil {
+		t.Fatal(err)
+	}
+	if cm.IsProtected(protected, "") {
+		t.Fatal("expected the protection to be removed")
+	}
+}
+
+func TestConnManagerProtectDisabled(t *testing.T) {
+	_, c, closer := createDaemonClientPair(t)
+	defer closer()
+
+	if err := c.Protect(randPeerID(t), "server"); err == nil {
+		t.Fatal("expected protecting a peer without a connection manager to fail")
+	}
+}
@@ -0,0 +1,159 @@
+package test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	ggio "github.com/gogo/protobuf/io"
+	"github.com/libp2p/go-libp2p-core/network"
+	p2pd "github.com/libp2p/go-libp2p-daemon"
+	"github.com/libp2p/go-libp2p-daemon/p2pclient"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+	ma "github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+)
+
+// issueCert returns a certificate for 127.0.0.1 signed by parent with
+// parentKey, or a self-signed CA if parent is nil.
+func issueCert(t *testing.T, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: "p2pd test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func writePEMKey(t *testing.T, path string, key *ecdsa.PrivateKey) {
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestControlTLS(t *testing.T) {
+	dir, err := ioutil.TempDir("", "p2pd-tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ca, caKey, caPEM := issueCert(t, nil, nil)
+	_, serverKey, serverPEM := issueCert(t, ca, caKey)
+	clientCert, clientKey, _ := issueCert(t, ca, caKey)
+
+	caFile := filepath.Join(dir, "ca.pem")
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	if err := ioutil.WriteFile(caFile, caPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(certFile, serverPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	writePEMKey(t, keyFile, serverKey)
+
+	serverConfig, err := p2pd.NewControlTLSConfig(caFile, certFile, keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	daemonAddr, clientAddr, cleanup := makeTcpLocalhostEndpoints(t)
+	defer cleanup()
+	d, closeDaemon := createDaemon(t, daemonAddr)
+	defer closeDaemon()
+	d.SetControlTLS(serverConfig)
+	controlAddr := d.Listener().Multiaddr()
+
+	// a plaintext request is never processed
+	conn, err := manet.Dial(controlAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+	if err := ggio.NewDelimitedWriter(conn).WriteMsg(&pb.Request{Type: pb.Request_IDENTIFY.Enum()}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ggio.NewDelimitedReader(conn, network.MessageSizeMax).ReadMsg(&pb.Response{}); err == nil {
+		t.Fatal("expected a plaintext connection to be rejected")
+	}
+	conn.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+
+	// neither is one without a client certificate
+	anonymous, err := p2pclient.NewClientWithTLS(controlAddr, clientAddr, &tls.Config{RootCAs: roots})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer anonymous.Close()
+	if _, _, err := anonymous.Identify(); err == nil {
+		t.Fatal("expected a connection without a client certificate to be rejected")
+	}
+
+	clientAddr, err = ma.NewMultiaddr("/ip4/127.0.0.1/tcp/0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := p2pclient.NewClientWithTLS(controlAddr, clientAddr, &tls.Config{
+		RootCAs: roots,
+		Certificates: []tls.Certificate{{
+			Certificate: [][]byte{clientCert.Raw},
+			PrivateKey:  clientKey,
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	id, _, err := client.Identify()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != d.ID() {
+		t.Fatalf("expected to identify %s, got %s", d.ID(), id)
+	}
+}
@@ -4,12 +4,15 @@ import (
 	"bytes"
 	"context"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/libp2p/go-libp2p-core/crypto"
 	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
 
+	p2pd "github.com/libp2p/go-libp2p-daemon"
 	"github.com/libp2p/go-libp2p-daemon/p2pclient"
 	pb "github.com/libp2p/go-libp2p-daemon/pb"
 )
@@ -128,6 +131,43 @@ func TestDHTGetValue(t *testing.T) {
 	}
 }
 
+func TestDHTGetValueContext(t *testing.T) {
+	daemon, client, closer := createMockDaemonClientPair(t)
+	defer closer()
+	key := randBytes(t)
+	value := randBytes(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	valuec := make(chan []byte, 1)
+	go func() {
+		val, err := client.GetValueContext(ctx, key, 3)
+		if err != nil {
+			t.Error(err)
+		}
+		valuec <- val
+	}()
+
+	conn := daemon.ExpectConn(t)
+	req := conn.ExpectDHTRequestType(t, pb.DHTRequest_GET_VALUE)
+	if req.GetQuorum() != 3 {
+		t.Fatalf("expected quorum 3, got %d", req.GetQuorum())
+	}
+	if timeout := req.GetTimeout(); timeout <= 0 || timeout > 10 {
+		t.Fatalf("expected the context deadline to be sent as timeout, got %d", timeout)
+	}
+	conn.SendMessage(t, wrapDhtResponse(valueResponse(value)))
+
+	select {
+	case resvalue := <-valuec:
+		if !bytes.Equal(resvalue, value) {
+			t.Fatal("value did not match")
+		}
+	case <-time.After(testTimeout):
+		t.Fatal("timed out waiting for value")
+	}
+}
+
 func TestDHTPutValue(t *testing.T) {
 	daemon, client, closer := createMockDaemonClientPair(t)
 	defer closer()
@@ -154,6 +194,25 @@ func TestDHTPutValue(t *testing.T) {
 	}
 }
 
+func TestDHTPutValueClientMode(t *testing.T) {
+	dmaddr, cmaddr, dirCloser := getEndpointsMaker(t)(t)
+	defer dirCloser()
+	d, err := p2pd.NewDaemon(context.Background(), []ma.Multiaddr{dmaddr}, "client")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	go d.Serve()
+
+	c, clientCloser := createClient(t, d.Listener().Multiaddr(), cmaddr)
+	defer clientCloser()
+
+	err = c.PutValue([]byte("/v/key"), []byte("value"))
+	if err == nil || !strings.Contains(err.Error(), "client mode") {
+		t.Fatalf("expected a put without DHT servers to fail in client mode, got %v", err)
+	}
+}
+
 func TestDHTProvide(t *testing.T) {
 	daemon, client, closer := createMockDaemonClientPair(t)
 	defer closer()
@@ -207,6 +266,74 @@ func TestDHTFindProviders(t *testing.T) {
 	}
 }
 
+func TestDHTFindProvidersWithCount(t *testing.T) {
+	daemon, client, closer := createMockDaemonClientPair(t)
+	defer closer()
+	ids := randPeerIDs(t, 2)
+	contentID := randCid(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	infocc := make(chan (<-chan p2pclient.PeerInfo), 1)
+	go func() {
+		infoc, err := client.FindProvidersWithCount(ctx, contentID, 2)
+		if err != nil {
+			t.Errorf("request failed: %s", err)
+		}
+		infocc <- infoc
+	}()
+
+	conn := daemon.ExpectConn(t)
+	req := conn.ExpectDHTRequestType(t, pb.DHTRequest_FIND_PROVIDERS)
+	if req.GetCount() != 2 {
+		t.Fatalf("expected count 2, got %d", req.GetCount())
+	}
+	if timeout := req.GetTimeout(); timeout <= 0 || timeout > 10 {
+		t.Fatalf("expected the context deadline to be sent as timeout, got %d", timeout)
+	}
+
+	resps := make([]*pb.DHTResponse, 2)
+	for i, id := range ids {
+		resps[i] = peerInfoResponse(t, id)
+	}
+	conn.SendStreamAsync(t, resps)
+
+	i := 0
+	for range <-infocc {
+		i++
+	}
+	if i != 2 {
+		t.Fatalf("expected 2 responses, got %d", i)
+	}
+}
+
+func TestDHTProvideContext(t *testing.T) {
+	daemon, client, closer := createMockDaemonClientPair(t)
+	defer closer()
+	cid := randCid(t)
+
+	donec := make(chan struct{})
+	go func() {
+		if err := client.ProvideContext(context.Background(), cid, false); err != nil {
+			t.Error(err)
+		}
+		close(donec)
+	}()
+
+	conn := daemon.ExpectConn(t)
+	req := conn.ExpectDHTRequestType(t, pb.DHTRequest_PROVIDE)
+	if req.Announce == nil || req.GetAnnounce() {
+		t.Fatal("expected the request not to be announced")
+	}
+	conn.SendMessage(t, wrapDhtResponse(nil))
+
+	select {
+	case <-donec:
+	case <-time.After(testTimeout):
+		t.Fatal("timed out waiting for response")
+	}
+}
+
 func TestDHTGetClosestPeers(t *testing.T) {
 	daemon, client, closer := createMockDaemonClientPair(t)
 	defer closer()
@@ -236,6 +363,53 @@ func TestDHTGetClosestPeers(t *testing.T) {
 	}
 }
 
+func TestDHTGetClosestPeersInfo(t *testing.T) {
+	daemon, client, closer := createMockDaemonClientPair(t)
+	defer closer()
+	ids := randPeerIDs(t, 2)
+	key := randBytes(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	infocc := make(chan (<-chan p2pclient.PeerInfo), 1)
+	go func() {
+		infoc, err := client.GetClosestPeersInfo(ctx, key)
+		if err != nil {
+			t.Errorf("request failed: %s", err)
+		}
+		infocc <- infoc
+	}()
+
+	conn := daemon.ExpectConn(t)
+	req := conn.ExpectDHTRequestType(t, pb.DHTRequest_GET_CLOSEST_PEERS)
+	if !bytes.Equal(req.GetKey(), key) {
+		t.Fatal("request key didn't match expected key")
+	}
+	if timeout := req.GetTimeout(); timeout <= 0 || timeout > 10 {
+		t.Fatalf("expected the context deadline to be sent as timeout, got %d", timeout)
+	}
+
+	resps := make([]*pb.DHTResponse, 2)
+	for i, id := range ids {
+		resps[i] = peerInfoResponse(t, id)
+	}
+	conn.SendStreamAsync(t, resps)
+
+	i := 0
+	for info := range <-infocc {
+		if info.ID != ids[i] {
+			t.Fatalf("expected peer %s, got %s", ids[i], info.ID)
+		}
+		if len(info.Addrs) != 1 {
+			t.Fatalf("expected peer addresses, got %v", info.Addrs)
+		}
+		i++
+	}
+	if i != 2 {
+		t.Fatalf("expected 2 responses, got %d", i)
+	}
+}
+
 func TestDHTSearchValue(t *testing.T) {
 	daemon, client, closer := createMockDaemonClientPair(t)
 	defer closer()
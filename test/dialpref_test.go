@@ -0,0 +1,89 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/libp2p/go-libp2p"
+	p2pd "github.com/libp2p/go-libp2p-daemon"
+	"github.com/libp2p/go-libp2p-daemon/p2pclient"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+func TestAddrPreferenceRank(t *testing.T) {
+	pref, err := p2pd.ParseDialPreference([]string{"ws", "10.0.0.0/8", "p2p-circuit"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for addr, rank := range map[string]int{
+		"/ip4/127.0.0.1/tcp/4001/ws":  0,
+		"/ip4/10.1.2.3/udp/4001/quic": 1,
+		"/ip4/10.1.2.3/tcp/4001/ws/p2p/QmcgpsyWgH8Y8ajJz1Cu72KnS5uo2Aa2LpzU7kinSupNKC/p2p-circuit": 2,
+		"/ip4/127.0.0.1/tcp/4001": -1,
+	} {
+		if got := pref.Rank(ma.StringCast(addr)); got != rank {
+			t.Errorf("expected %s to rank %d, got %d", addr, rank, got)
+		}
+	}
+
+	if _, err := p2pd.ParseDialPreference([]string{"carrier-pigeon"}); err == nil {
+		t.Fatal("expected an unknown protocol to be rejected")
+	}
+}
+
+func TestDialPreference(t *testing.T) {
+	dmaddr, cmaddr, dirCloser := getEndpointsMaker(t)(t)
+	defer dirCloser()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	gater := p2pd.NewConnectionGater()
+	d, err := p2pd.NewDaemon(ctx, []ma.Multiaddr{dmaddr}, "", libp2p.ConnectionGater(gater))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.SetConnectionGater(gater)
+	go d.Serve()
+
+	c, closeClient := createClient(t, d.Listener().Multiaddr(), cmaddr)
+	defer closeClient()
+
+	pref, err := p2pd.ParseDialPreference([]string{"ws"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.SetDialPreference(pref, 0)
+
+	// the swarm ranks TCP before websockets on its own
+	both, err := libp2p.New(ctx, libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0", "/ip4/127.0.0.1/tcp/0/ws"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer both.Close()
+
+	addr, err := c.ConnectWithOptions(both.ID(), both.Addrs(), p2pclient.ConnectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pref.Rank(addr) != 0 {
+		t.Fatalf("expected the connection to go over websockets, got %s", addr)
+	}
+
+	// nothing listens on port 1, so the preferred dial fails
+	tcpOnly, err := libp2p.New(ctx, libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tcpOnly.Close()
+
+	addrs := append([]ma.Multiaddr{ma.StringCast("/ip4/127.0.0.1/tcp/1/ws")}, tcpOnly.Addrs()...)
+	addr, err = c.ConnectWithOptions(tcpOnly.ID(), addrs, p2pclient.ConnectOptions{})
+	if err != nil {
+		t.Fatalf("expected the dial to fall back to the other addresses, got %s", err)
+	}
+	if pref.Rank(addr) != -1 {
+		t.Fatalf("expected the connection to go over TCP, got %s", addr)
+	}
+}
@@ -0,0 +1,45 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p-core/peer"
+	p2pd "github.com/libp2p/go-libp2p-daemon"
+)
+
+func TestEmbeddedDaemon(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d, err := p2pd.NewEmbeddedDaemon(ctx, "", libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+
+	if len(d.Listeners()) != 0 || d.Listener() != nil {
+		t.Fatalf("expected no control listeners, got %v", d.Listeners())
+	}
+	if err := d.Serve(); err == nil {
+		t.Fatal("expected serving without control listeners to fail")
+	}
+
+	if err := d.EnablePubsub("gossipsub", false, false); err != nil {
+		t.Fatal(err)
+	}
+	if d.PubSub() == nil {
+		t.Fatal("expected pubsub to be enabled")
+	}
+
+	d2, _, closer := createDaemonClientPair(t)
+	defer closer()
+
+	if err := d.Host().Connect(ctx, peer.AddrInfo{ID: d2.ID(), Addrs: d2.Addrs()}); err != nil {
+		t.Fatal(err)
+	}
+	if len(d.Host().Network().ConnsToPeer(d2.ID())) == 0 {
+		t.Fatalf("expected the embedded daemon to be connected to %s", d2.ID())
+	}
+}
@@ -0,0 +1,148 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/event"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-daemon/p2pclient"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+func TestSubscribeEvents(t *testing.T) {
+	_, c1, closer1 := createDaemonClientPair(t)
+	defer closer1()
+	d2, _, closer2 := createDaemonClientPair(t)
+	defer closer2()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := c1.SubscribeEvents(ctx, pb.Event_PEER_CONNECTEDNESS_CHANGED)
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitActiveCalls(t, c1, 1)
+
+	if err := c1.Connect(d2.ID(), d2.Addrs()); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case evt := <-events:
+		if evt.Err != nil {
+			t.Fatal(evt.Err)
+		}
+		if evt.Type != pb.Event_PEER_CONNECTEDNESS_CHANGED {
+			t.Fatalf("expected a connectedness event, got %s", evt.Type)
+		}
+		if evt.Peer != d2.ID() || evt.Connectedness != network.Connected {
+			t.Fatalf("expected %s to be connected, got %s %s", d2.ID(), evt.Peer, evt.Connectedness)
+		}
+		if evt.Addr == nil {
+			t.Fatal("expected the remote address of the connection")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the connectedness event")
+	}
+
+	if _, err := c1.Disconnect(d2.ID(), false); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case evt := <-events:
+		if evt.Err != nil {
+			t.Fatal(evt.Err)
+		}
+		if evt.Peer != d2.ID() || evt.Connectedness != network.NotConnected {
+			t.Fatalf("expected %s to be disconnected, got %s %s", d2.ID(), evt.Peer, evt.Connectedness)
+		}
+		if evt.Addr == nil {
+			t.Fatal("expected the remote address of the closed connection")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the disconnection event")
+	}
+
+	cancel()
+	for range events {
+	}
+	waitActiveCalls(t, c1, 0)
+}
+
+func TestReachabilityRepeatsNotForwarded(t *testing.T) {
+	d, c, closer := createDaemonClientPair(t)
+	defer closer()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := c.SubscribeEvents(ctx, pb.Event_LOCAL_REACHABILITY_CHANGED)
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitActiveCalls(t, c, 1)
+
+	// as RefreshRelays and KeepStaticRelays do to make autorelay look for
+	// relays
+	em, err := d.Host().EventBus().Emitter(new(event.EvtLocalReachabilityChanged))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer em.Close()
+	for _, r := range []network.Reachability{network.ReachabilityPrivate, network.ReachabilityPrivate, network.ReachabilityPublic} {
+		if err := em.Emit(event.EvtLocalReachabilityChanged{Reachability: r}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	next := func() network.Reachability {
+		select {
+		case evt := <-events:
+			if evt.Err != nil {
+				t.Fatal(evt.Err)
+			}
+			return evt.Reachability
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for a reachability event")
+			return 0
+		}
+	}
+	// AutoNAT may have reported a reachability before
+	for next() != network.ReachabilityPrivate {
+	}
+	if r := next(); r != network.ReachabilityPublic {
+		t.Fatalf("expected the repeated private reachability to be left out, got %s", r)
+	}
+}
+
+func TestSubscribeEventsNoTypes(t *testing.T) {
+	_, c, closer := createDaemonClientPair(t)
+	defer closer()
+
+	if _, err := c.SubscribeEvents(context.Background()); err == nil {
+		t.Fatal("expected subscribing to no event types to fail")
+	}
+}
+
+func waitActiveCalls(t *testing.T, c *p2pclient.Client, n int) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		stats, err := c.GetPersistentConnStats()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stats.ActiveCalls == n {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d active calls, got %d", n, stats.ActiveCalls)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
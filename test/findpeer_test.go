@@ -0,0 +1,219 @@
+package test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p-core/peer"
+	p2pd "github.com/libp2p/go-libp2p-daemon"
+	"github.com/libp2p/go-libp2p-daemon/p2pclient"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+func TestLookupPeer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the daemon learns of target through hub's DHT
+	hub := createDHTHost(t, ctx)
+	defer hub.Close()
+	target := createDHTHost(t, ctx)
+	defer target.Close()
+	if err := hub.Connect(ctx, peer.AddrInfo{ID: target.ID(), Addrs: target.Addrs()}); err != nil {
+		t.Fatal(err)
+	}
+
+	dmaddr, cmaddr, dirCloser := getEndpointsMaker(t)(t)
+	defer dirCloser()
+	d, err := p2pd.NewDaemon(ctx, []ma.Multiaddr{dmaddr}, "server",
+		libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	go d.Serve()
+
+	c, clientCloser := createClient(t, d.Listener().Multiaddr(), cmaddr)
+	defer clientCloser()
+
+	if _, err := c.LookupPeer(ctx, target.ID(), true); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected the peer not to be found in the peerstore, got %v", err)
+	}
+
+	if err := c.Connect(hub.ID(), hub.Addrs()); err != nil {
+		t.Fatal(err)
+	}
+
+	// the DHTs add each other to their routing tables asynchronously
+	lookupCtx, cancelLookup := context.WithTimeout(ctx, 10*time.Second)
+	defer cancelLookup()
+	var info p2pclient.PeerInfo
+	for {
+		info, err = c.LookupPeer(lookupCtx, target.ID(), false)
+		if err == nil || lookupCtx.Err() != nil {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatalf("expected the peer to be found through the DHT: %s", err)
+	}
+	if info.ID != target.ID() || len(info.Addrs) == 0 {
+		t.Fatalf("expected the peer's addresses, got %v", info)
+	}
+
+	// the addresses found are now in the peerstore
+	info, err = c.LookupPeer(ctx, target.ID(), true)
+	if err != nil {
+		t.Fatalf("expected the peer to be found in the peerstore: %s", err)
+	}
+	if info.ID != target.ID() || len(info.Addrs) == 0 {
+		t.Fatalf("expected the peer's addresses, got %v", info)
+	}
+}
+
+func TestRoutingTable(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, c, closer := createDaemonClientPair(t)
+	defer closer()
+	if _, err := c.RoutingTable(false); err == nil {
+		t.Fatal("expected an error without the DHT")
+	}
+
+	dmaddr, cmaddr, dirCloser := getEndpointsMaker(t)(t)
+	defer dirCloser()
+	d, err := p2pd.NewDaemon(ctx, []ma.Multiaddr{dmaddr}, "server",
+		libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	go d.Serve()
+
+	c, clientCloser := createClient(t, d.Listener().Multiaddr(), cmaddr)
+	defer clientCloser()
+
+	table, err := c.RoutingTable(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if table.PeerCount != 0 || len(table.Buckets) != 0 {
+		t.Fatalf("expected an empty routing table, got %+v", table)
+	}
+
+	peers := make(map[peer.ID]bool)
+	for i := 0; i < 2; i++ {
+		h := createDHTHost(t, ctx)
+		defer h.Close()
+		if err := c.Connect(h.ID(), h.Addrs()); err != nil {
+			t.Fatal(err)
+		}
+		peers[h.ID()] = true
+	}
+
+	// peers are added to the routing table asynchronously
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		table, err = c.RoutingTable(true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if table.PeerCount == len(peers) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d peers in the routing table, got %+v", len(peers), table)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	listed := 0
+	for _, bucket := range table.Buckets {
+		for _, p := range bucket {
+			if !peers[p] {
+				t.Fatalf("unexpected peer %s in the routing table", p)
+			}
+			listed++
+		}
+	}
+	if listed != len(peers) {
+		t.Fatalf("expected the peers to be listed, got %+v", table.Buckets)
+	}
+
+	// the peers are only listed on request
+	table, err = c.RoutingTable(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if table.PeerCount != len(peers) || len(table.Buckets) != 0 {
+		t.Fatalf("expected the peer count only, got %+v", table)
+	}
+}
+
+func TestRefreshRoutingTable(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, c, closer := createDaemonClientPair(t)
+	defer closer()
+	if _, err := c.RefreshRoutingTable(ctx, false); err == nil || !strings.Contains(err.Error(), "DHT not enabled") {
+		t.Fatalf("expected an error without the DHT, got %v", err)
+	}
+
+	// the daemon only learns of target by querying hub
+	hub := createDHTHost(t, ctx)
+	defer hub.Close()
+	target := createDHTHost(t, ctx)
+	defer target.Close()
+	if err := hub.Connect(ctx, peer.AddrInfo{ID: target.ID(), Addrs: target.Addrs()}); err != nil {
+		t.Fatal(err)
+	}
+
+	dmaddr, cmaddr, dirCloser := getEndpointsMaker(t)(t)
+	defer dirCloser()
+	d, err := p2pd.NewDaemon(ctx, []ma.Multiaddr{dmaddr}, "server",
+		libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	go d.Serve()
+
+	c, clientCloser := createClient(t, d.Listener().Multiaddr(), cmaddr)
+	defer clientCloser()
+
+	if err := c.Connect(hub.ID(), hub.Addrs()); err != nil {
+		t.Fatal(err)
+	}
+
+	// the DHTs add each other to their routing tables asynchronously
+	deadline := time.Now().Add(10 * time.Second)
+	for d.DHT().RoutingTable().Find(hub.ID()) == "" {
+		if time.Now().After(deadline) {
+			t.Fatal("expected hub to be added to the routing table")
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	refreshCtx, cancelRefresh := context.WithTimeout(ctx, 30*time.Second)
+	defer cancelRefresh()
+	table, err := c.RefreshRoutingTable(refreshCtx, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := false
+	for _, bucket := range table.Buckets {
+		for _, p := range bucket {
+			found = found || p == target.ID()
+		}
+	}
+	if !found {
+		t.Fatalf("expected the refresh to add target to the routing table, got %+v", table)
+	}
+}
@@ -0,0 +1,133 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p-core/peer"
+	p2pd "github.com/libp2p/go-libp2p-daemon"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+func TestInboundRateLimit(t *testing.T) {
+	dmaddr, _, dirCloser := getEndpointsMaker(t)(t)
+	defer dirCloser()
+
+	gater := p2pd.NewConnectionGater()
+	gater.LimitInboundRate(1, time.Hour, 2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d, err := p2pd.NewDaemon(ctx, []ma.Multiaddr{dmaddr}, "",
+		libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"),
+		libp2p.ConnectionGater(gater),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h, err := libp2p.New(ctx, libp2p.NoListenAddrs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	rejected := metricValue(t, "p2pd_rejected_connections_total", map[string]string{"reason": "rate_limit"})
+
+	pi := peer.AddrInfo{ID: d.ID(), Addrs: d.Addrs()}
+	for i := 0; i < 2; i++ {
+		if err := h.Connect(ctx, pi); err != nil {
+			t.Fatalf("connection %d within the burst failed: %s", i+1, err)
+		}
+		if err := h.Network().ClosePeer(d.ID()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := h.Connect(ctx, pi); err == nil {
+		t.Fatal("expected the connection over the rate limit to be rejected")
+	}
+	if got := metricValue(t, "p2pd_rejected_connections_total", map[string]string{"reason": "rate_limit"}); got <= rejected {
+		t.Fatal("expected the rejected connection to be counted")
+	}
+}
+
+func TestPeerLists(t *testing.T) {
+	dmaddr, cmaddr, dirCloser := getEndpointsMaker(t)(t)
+	defer dirCloser()
+
+	gater := p2pd.NewConnectionGater()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d1, err := p2pd.NewDaemon(ctx, []ma.Multiaddr{dmaddr}, "", libp2p.ConnectionGater(gater))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d1.SetConnectionGater(gater)
+	go d1.Serve()
+
+	c1, closeClient := createClient(t, d1.Listener().Multiaddr(), cmaddr)
+	defer closeClient()
+
+	d2, c2, closer2 := createDaemonClientPair(t)
+	defer closer2()
+
+	if err := c1.SetPeerLists(nil, []peer.ID{d2.ID()}); err != nil {
+		t.Fatal(err)
+	}
+	allowed, blocked, err := c1.GetPeerLists()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(allowed) != 0 || len(blocked) != 1 || blocked[0] != d2.ID() {
+		t.Fatalf("unexpected peer lists: allowed %v, blocked %v", allowed, blocked)
+	}
+
+	rejected := metricValue(t, "p2pd_rejected_connections_total", map[string]string{"reason": "peer_list"})
+	if err := c1.Connect(d2.ID(), d2.Addrs()); err == nil {
+		t.Fatal("expected the dial to a blocked peer to be denied")
+	}
+	if got := metricValue(t, "p2pd_rejected_connections_total", map[string]string{"reason": "peer_list"}); got <= rejected {
+		t.Fatal("expected the denied dial to be counted")
+	}
+	rejected = metricValue(t, "p2pd_rejected_connections_total", map[string]string{"reason": "peer_list"})
+	if err := c2.Connect(d1.ID(), d1.Addrs()); err == nil {
+		t.Fatal("expected the connection from a blocked peer to be denied")
+	}
+	if got := metricValue(t, "p2pd_rejected_connections_total", map[string]string{"reason": "peer_list"}); got <= rejected {
+		t.Fatal("expected the denied connection to be counted")
+	}
+
+	if err := c1.SetPeerLists([]peer.ID{d2.ID()}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := c1.Connect(d2.ID(), d2.Addrs()); err != nil {
+		t.Fatal(err)
+	}
+
+	// peers that are no longer allowed are disconnected
+	if err := c1.SetPeerLists([]peer.ID{randPeerID(t)}, nil); err != nil {
+		t.Fatal(err)
+	}
+	peers, err := c1.ListConnections()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(peers) != 0 {
+		t.Fatalf("expected the denied peer to be disconnected, got %v", peers)
+	}
+}
+
+func TestPeerListsWithoutGater(t *testing.T) {
+	_, c, closer := createDaemonClientPair(t)
+	defer closer()
+
+	if _, _, err := c.GetPeerLists(); err == nil {
+		t.Fatal("expected peer lists to be unavailable without a connection gater")
+	}
+}
@@ -0,0 +1,107 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func httpControlRequest(t *testing.T, method, url, token string, body interface{}, out interface{}) int {
+	var payload bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&payload).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	req, err := http.NewRequest(method, url, &payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	if out != nil {
+		if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return res.StatusCode
+}
+
+func TestHTTPControl(t *testing.T) {
+	d1, _, closer1 := createDaemonClientPair(t)
+	defer closer1()
+	d2, _, closer2 := createDaemonClientPair(t)
+	defer closer2()
+
+	const token = "secret"
+	srv := httptest.NewServer(d1.HTTPControlHandler(token))
+	defer srv.Close()
+
+	if status := httpControlRequest(t, http.MethodGet, srv.URL+"/identify", "", nil, nil); status != http.StatusUnauthorized {
+		t.Fatalf("expected request without token to be rejected, got status %d", status)
+	}
+
+	type peerInfo struct {
+		ID    string   `json:"id"`
+		Addrs []string `json:"addrs"`
+	}
+	type connectedPeer struct {
+		ID    string `json:"id"`
+		Conns []struct {
+			Addr      string `json:"addr"`
+			Direction string `json:"direction"`
+		} `json:"conns"`
+	}
+
+	var identify peerInfo
+	if status := httpControlRequest(t, http.MethodGet, srv.URL+"/identify", token, nil, &identify); status != http.StatusOK {
+		t.Fatalf("identify failed with status %d", status)
+	}
+	if identify.ID != d1.ID().Pretty() {
+		t.Fatalf("expected peer id %s, got %s", d1.ID().Pretty(), identify.ID)
+	}
+
+	addrs := make([]string, len(d2.Addrs()))
+	for i, addr := range d2.Addrs() {
+		addrs[i] = addr.String()
+	}
+	connectReq := map[string]interface{}{"peer": d2.ID().Pretty(), "addrs": addrs}
+
+	var connected connectedPeer
+	if status := httpControlRequest(t, http.MethodPost, srv.URL+"/connect", token, connectReq, &connected); status != http.StatusOK {
+		t.Fatalf("connect failed with status %d", status)
+	}
+	if connected.ID != d2.ID().Pretty() || len(connected.Conns) == 0 {
+		t.Fatalf("expected a connection to %s, got %+v", d2.ID().Pretty(), connected)
+	}
+	if connected.Conns[0].Direction != "outbound" {
+		t.Fatalf("expected outbound connection, got %s", connected.Conns[0].Direction)
+	}
+
+	var peers []connectedPeer
+	if status := httpControlRequest(t, http.MethodGet, srv.URL+"/peers", token, nil, &peers); status != http.StatusOK {
+		t.Fatalf("listing peers failed with status %d", status)
+	}
+	if len(peers) != 1 || peers[0].ID != d2.ID().Pretty() {
+		t.Fatalf("expected to be connected to %s only, got %+v", d2.ID().Pretty(), peers)
+	}
+
+	var dhtErr struct {
+		Error string `json:"error"`
+	}
+	url := srv.URL + "/dht/findpeer?peer=" + d2.ID().Pretty()
+	if status := httpControlRequest(t, http.MethodGet, url, token, nil, &dhtErr); status == http.StatusOK || dhtErr.Error == "" {
+		t.Fatal("expected find peer to fail with the DHT disabled")
+	}
+}
@@ -0,0 +1,70 @@
+package test
+
+import (
+	"crypto/rand"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+
+	p2pd "github.com/libp2p/go-libp2p-daemon"
+)
+
+func TestDecodeIdentity(t *testing.T) {
+	priv, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bytes, err := crypto.MarshalPrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := p2pd.DecodeIdentity(crypto.ConfigEncodeKey(bytes) + "\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !key.Equals(priv) {
+		t.Fatal("decoded key differs from the encoded one")
+	}
+
+	if _, err := p2pd.DecodeIdentity("not a key"); err == nil {
+		t.Fatal("expected decoding garbage to fail")
+	}
+}
+
+func TestGenerateIdentity(t *testing.T) {
+	dir, err := ioutil.TempDir("", "p2pd-identity")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, keyType := range []string{"ed25519", "secp256k1", "rsa"} {
+		path := filepath.Join(dir, keyType)
+
+		id, err := p2pd.GenerateIdentity(keyType, p2pd.DefaultRSAKeyBits, path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		key, err := p2pd.ReadIdentity(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !id.MatchesPrivateKey(key) {
+			t.Fatalf("peer id of the %s key doesn't match the written key", keyType)
+		}
+
+		if _, err := p2pd.GenerateIdentity(keyType, p2pd.DefaultRSAKeyBits, path); err == nil {
+			t.Fatalf("expected the existing %s key not to be overwritten", keyType)
+		}
+	}
+
+	if _, err := p2pd.GenerateIdentity("dsa", 0, filepath.Join(dir, "dsa")); err == nil {
+		t.Fatal("expected an unknown key type to be rejected")
+	}
+}
@@ -0,0 +1,43 @@
+package test
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestDaemonInfo(t *testing.T) {
+	d, c, closer := createDaemonClientPair(t)
+	defer closer()
+
+	info, err := c.DaemonInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info.StartTime.After(time.Now()) || info.Uptime <= 0 {
+		t.Fatalf("expected the daemon to have started, got start time %s and uptime %s", info.StartTime, info.Uptime)
+	}
+	if info.GoVersion != runtime.Version() {
+		t.Fatalf("expected go version %s, got %s", runtime.Version(), info.GoVersion)
+	}
+	if info.DHTMode != "" {
+		t.Fatalf("expected no DHT, got mode %s", info.DHTMode)
+	}
+	if info.PubSubRouter != "gossipsub" {
+		t.Fatalf("expected gossipsub, got %q", info.PubSubRouter)
+	}
+	// libp2p enables the relay transport by default, but not the service
+	if !info.Relay || info.RelayHop {
+		t.Fatalf("expected the relay transport only, got relay %v and hop %v", info.Relay, info.RelayHop)
+	}
+
+	d.SetRelayService(true)
+	info, err = c.DaemonInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !info.RelayHop {
+		t.Fatal("expected the relay service to be reported")
+	}
+}
@@ -1,14 +1,24 @@
 package test
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
 	"io"
+	"io/ioutil"
+	"net"
 	"testing"
 	"time"
 
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
+	noise "github.com/libp2p/go-libp2p-noise"
 
 	p2pd "github.com/libp2p/go-libp2p-daemon"
 	"github.com/libp2p/go-libp2p-daemon/p2pclient"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
 	ma "github.com/multiformats/go-multiaddr"
 )
 
@@ -57,6 +67,149 @@ func TestConnect(t *testing.T) {
 	}
 }
 
+func TestConnectWithInfo(t *testing.T) {
+	_, c1, closer1 := createDaemonClientPair(t)
+	defer closer1()
+	d2, _, closer2 := createDaemonClientPair(t)
+	defer closer2()
+
+	conns, err := c1.ConnectWithInfo(d2.ID(), d2.Addrs())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conns) == 0 {
+		t.Fatal("expected the connection to be reported")
+	}
+
+	addrset := make(map[string]struct{})
+	for _, addr := range d2.Addrs() {
+		addrset[addr.String()] = struct{}{}
+	}
+	for _, conn := range conns {
+		if _, ok := addrset[conn.Addr.String()]; !ok {
+			t.Fatalf("connection address %s is not one of the peer's addresses", conn.Addr)
+		}
+		if conn.Relayed {
+			t.Fatal("expected direct connection")
+		}
+	}
+}
+
+func TestConnectWithOptions(t *testing.T) {
+	_, c1, closer1 := createDaemonClientPair(t)
+	defer closer1()
+	d2, _, closer2 := createDaemonClientPair(t)
+	defer closer2()
+
+	var tcpAddr ma.Multiaddr
+	for _, addr := range d2.Addrs() {
+		if _, err := addr.ValueForProtocol(ma.P_WS); err == nil {
+			continue
+		}
+		if _, err := addr.ValueForProtocol(ma.P_TCP); err == nil {
+			tcpAddr = addr
+			break
+		}
+	}
+	if tcpAddr == nil {
+		t.Fatalf("expected a tcp address, got %v", d2.Addrs())
+	}
+
+	opts := p2pclient.ConnectOptions{AddrTTL: time.Hour, Timeout: 5 * time.Second}
+	addr, err := c1.ConnectWithOptions(d2.ID(), []ma.Multiaddr{tcpAddr}, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !addr.Equal(tcpAddr) {
+		t.Fatalf("expected to connect on %s, got %s", tcpAddr, addr)
+	}
+
+	// already connected, the open connection is reported
+	addr, err = c1.ConnectWithOptions(d2.ID(), d2.Addrs(), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !addr.Equal(tcpAddr) {
+		t.Fatalf("expected the open connection on %s, got %s", tcpAddr, addr)
+	}
+}
+
+func TestDisconnect(t *testing.T) {
+	d1, c1, closer1 := createDaemonClientPair(t)
+	defer closer1()
+	d2, _, closer2 := createDaemonClientPair(t)
+	defer closer2()
+
+	opts := p2pclient.ConnectOptions{AddrTTL: time.Hour}
+	if _, err := c1.ConnectWithOptions(d2.ID(), d2.Addrs(), opts); err != nil {
+		t.Fatal(err)
+	}
+
+	closed, err := c1.Disconnect(d2.ID(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if closed == 0 {
+		t.Fatal("expected the connection to be closed")
+	}
+	if len(d1.Host().Network().ConnsToPeer(d2.ID())) != 0 {
+		t.Fatalf("expected no connection to %s", d2.ID())
+	}
+	if len(d1.Host().Peerstore().Addrs(d2.ID())) == 0 {
+		t.Fatal("expected the addresses to be kept")
+	}
+
+	if _, err := c1.ConnectWithOptions(d2.ID(), d2.Addrs(), opts); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c1.Disconnect(d2.ID(), true); err != nil {
+		t.Fatal(err)
+	}
+	if addrs := d1.Host().Peerstore().Addrs(d2.ID()); len(addrs) != 0 {
+		t.Fatalf("expected the addresses to be forgotten, got %v", addrs)
+	}
+
+	closed, err = c1.Disconnect(d2.ID(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if closed != 0 {
+		t.Fatalf("expected no connection to close, got %d", closed)
+	}
+}
+
+func TestPing(t *testing.T) {
+	_, c1, closer1 := createDaemonClientPair(t)
+	defer closer1()
+	d2, _, closer2 := createDaemonClientPair(t)
+	defer closer2()
+
+	if err := c1.Connect(d2.ID(), d2.Addrs()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := c1.Ping(ctx, d2.ID(), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.RTTs) != 3 {
+		t.Fatalf("expected 3 rounds, got %d", len(res.RTTs))
+	}
+	if res.Min > res.Avg || res.Avg > res.Max {
+		t.Fatalf("inconsistent ping stats: %+v", res)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if _, err := c1.Ping(ctx, randPeerID(t), 1); err == nil {
+		t.Fatal("expected ping to an unreachable peer to fail")
+	}
+}
+
 func TestConnectFailsOnBadAddress(t *testing.T) {
 	_, c1, closer1 := createDaemonClientPair(t)
 	defer closer1()
@@ -69,6 +222,175 @@ func TestConnectFailsOnBadAddress(t *testing.T) {
 	}
 }
 
+func TestConnectReportsDialErrors(t *testing.T) {
+	_, c, closer := createDaemonClientPair(t)
+	defer closer()
+
+	// nothing listens on a port once its listener is closed
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr, err := ma.NewMultiaddr(fmt.Sprintf("/ip4/127.0.0.1/tcp/%d", l.Addr().(*net.TCPAddr).Port))
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.Close()
+
+	err = c.Connect(randPeerID(t), []ma.Multiaddr{addr})
+	var connErr *p2pclient.ConnectError
+	if !errors.As(err, &connErr) {
+		t.Fatalf("expected a connect error, got %v", err)
+	}
+	if len(connErr.Attempts) != 1 {
+		t.Fatalf("expected 1 dial attempt, got %d", len(connErr.Attempts))
+	}
+	attempt := connErr.Attempts[0]
+	if !attempt.Addr.Equal(addr) {
+		t.Fatalf("expected a dial to %s, got %s", addr, attempt.Addr)
+	}
+	if attempt.Reason != pb.DialAttempt_REFUSED {
+		t.Fatalf("expected the dial to be refused, got %s: %s", attempt.Reason, attempt.Err)
+	}
+}
+
+func TestStaleUnixSocket(t *testing.T) {
+	daemonPath, _, dirCloser := createTempDir(t)
+	defer dirCloser()
+
+	// leave a socket file behind, as a daemon that was killed would
+	l, err := net.ListenUnix("unix", &net.UnixAddr{Name: daemonPath, Net: "unix"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.SetUnlinkOnClose(false)
+	l.Close()
+
+	addr, err := ma.NewComponent("unix", daemonPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := p2pd.NewDaemon(context.Background(), []ma.Multiaddr{addr}, "")
+	if err != nil {
+		t.Fatalf("expected stale socket to be replaced: %s", err)
+	}
+	defer d.Close()
+
+	if _, err := p2pd.NewDaemon(context.Background(), []ma.Multiaddr{addr}, ""); err == nil {
+		t.Fatal("expected listening on a live daemon's socket to fail")
+	}
+}
+
+func TestMultipleListeners(t *testing.T) {
+	tcpDaemon, tcpClient, tcpCleanup := makeTcpLocalhostEndpoints(t)
+	defer tcpCleanup()
+	unixDaemon, unixClient, unixCleanup := getEndpointsMaker(t)(t)
+	defer unixCleanup()
+
+	d, err := p2pd.NewDaemon(context.Background(), []ma.Multiaddr{tcpDaemon, unixDaemon}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	go d.Serve()
+
+	listeners := d.Listeners()
+	if len(listeners) != 2 {
+		t.Fatalf("expected 2 listeners, got %d", len(listeners))
+	}
+
+	clientAddrs := []ma.Multiaddr{tcpClient, unixClient}
+	for i, l := range listeners {
+		c, closeClient := createClient(t, l.Multiaddr(), clientAddrs[i])
+		id, _, err := c.Identify()
+		closeClient()
+		if err != nil {
+			t.Fatalf("identify over %s: %s", l.Multiaddr(), err)
+		}
+		if id != d.ID() {
+			t.Fatalf("identify over %s returned the wrong peer id", l.Multiaddr())
+		}
+	}
+}
+
+func TestListConnections(t *testing.T) {
+	_, c1, closer1 := createDaemonClientPair(t)
+	defer closer1()
+	d2, c2, closer2 := createDaemonClientPair(t)
+	defer closer2()
+	if err := connect(c1, d2); err != nil {
+		t.Fatal(err)
+	}
+
+	peers, err := c1.ListConnections()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(peers) != 1 || peers[0].ID != d2.ID() {
+		t.Fatalf("expected to be connected to %s only, got %v", d2.ID(), peers)
+	}
+	if len(peers[0].Conns) == 0 {
+		t.Fatal("expected at least one open connection")
+	}
+	for _, conn := range peers[0].Conns {
+		if conn.Direction != network.DirOutbound {
+			t.Fatalf("expected outbound connection, got %s", conn.Direction)
+		}
+		if conn.Relayed {
+			t.Fatal("expected direct connection")
+		}
+	}
+
+	peers, err = c2.ListConnections()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(peers) != 1 || len(peers[0].Conns) == 0 {
+		t.Fatalf("expected a connection to the dialing peer, got %v", peers)
+	}
+	if dir := peers[0].Conns[0].Direction; dir != network.DirInbound {
+		t.Fatalf("expected inbound connection, got %s", dir)
+	}
+}
+
+func TestConnectionSecurity(t *testing.T) {
+	newPair := func() (*p2pd.Daemon, *p2pclient.Client) {
+		dmaddr, cmaddr, dirCloser := getEndpointsMaker(t)(t)
+		t.Cleanup(dirCloser)
+		d, err := p2pd.NewDaemon(context.Background(), []ma.Multiaddr{dmaddr}, "",
+			libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"),
+			libp2p.Security(noise.ID, noise.New))
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { d.Close() })
+		go d.Serve()
+
+		c, clientCloser := createClient(t, d.Listener().Multiaddr(), cmaddr)
+		t.Cleanup(clientCloser)
+		return d, c
+	}
+	_, c1 := newPair()
+	d2, c2 := newPair()
+	if err := connect(c1, d2); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []*p2pclient.Client{c1, c2} {
+		peers, err := c.ListConnections()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(peers) != 1 || len(peers[0].Conns) == 0 {
+			t.Fatalf("expected a connection to the other daemon, got %v", peers)
+		}
+		if security := peers[0].Conns[0].Security; security != noise.ID {
+			t.Fatalf("expected the connection to be secured with %s, got %q", noise.ID, security)
+		}
+	}
+}
+
 func TestStreams(t *testing.T) {
 	d1, c1, closer1 := createDaemonClientPair(t)
 	defer closer1()
@@ -118,3 +440,232 @@ func TestStreams(t *testing.T) {
 	}
 	conn.Close()
 }
+
+func TestFramedStreams(t *testing.T) {
+	_, c, closer := createDaemonClientPair(t)
+	defer closer()
+
+	h, err := libp2p.New(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+	if err := c.Connect(h.ID(), h.Addrs()); err != nil {
+		t.Fatal(err)
+	}
+
+	// the remote replies once the client closed its end of the stream
+	h.SetStreamHandler("/echo", func(s network.Stream) {
+		data, err := ioutil.ReadAll(s)
+		if err != nil {
+			s.Reset()
+			return
+		}
+		s.Write(data)
+		s.Close()
+	})
+	h.SetStreamHandler("/reset", func(s network.Stream) {
+		s.Reset()
+	})
+
+	_, stream, err := c.NewFramedStream(h.ID(), []string{"/echo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Close()
+
+	if _, err := stream.Write([]byte("test")); err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.CloseWrite(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadAll(stream)
+	if err != nil {
+		t.Fatalf("expected the stream to be closed cleanly, got %v", err)
+	}
+	if string(data) != "test" {
+		t.Fatalf(`expected "test", got %q`, data)
+	}
+
+	_, stream, err = c.NewFramedStream(h.ID(), []string{"/reset"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Close()
+
+	var resetErr *p2pclient.StreamResetError
+	if _, err := ioutil.ReadAll(stream); !errors.As(err, &resetErr) {
+		t.Fatalf("expected the stream to be reset, got %v", err)
+	}
+}
+
+func TestFramedStreamHandler(t *testing.T) {
+	d1, c1, closer1 := createDaemonClientPair(t)
+	defer closer1()
+	d2, c2, closer2 := createDaemonClientPair(t)
+	defer closer2()
+	if err := connect(c1, d2); err != nil {
+		t.Fatal(err)
+	}
+
+	callers := make(chan peer.ID, 1)
+	err := c1.AddFramedStreamHandler("/echo", func(info *p2pclient.StreamInfo, s *p2pclient.FramedStream) {
+		defer s.Close()
+		callers <- info.Peer
+
+		data, err := ioutil.ReadAll(s)
+		if err != nil {
+			s.Reset()
+			return
+		}
+		s.Write(data)
+		s.CloseWrite()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = c1.AddFramedStreamHandler("/reset", func(info *p2pclient.StreamInfo, s *p2pclient.FramedStream) {
+		s.Reset()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, stream, err := c2.NewFramedStream(d1.ID(), []string{"/echo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Close()
+
+	// large enough to take several frames
+	payload := bytes.Repeat([]byte("test"), 1<<16)
+	if _, err := stream.Write(payload); err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.CloseWrite(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadAll(stream)
+	if err != nil {
+		t.Fatalf("expected the stream to be closed cleanly, got %v", err)
+	}
+	if !bytes.Equal(data, payload) {
+		t.Fatalf("expected the payload to be echoed, got %d bytes", len(data))
+	}
+	if caller := <-callers; caller != d2.ID() {
+		t.Fatalf("expected the handler to be told the stream is from %s, got %s", d2.ID(), caller)
+	}
+
+	_, stream, err = c2.NewFramedStream(d1.ID(), []string{"/reset"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Close()
+
+	var resetErr *p2pclient.StreamResetError
+	if _, err := ioutil.ReadAll(stream); !errors.As(err, &resetErr) {
+		t.Fatalf("expected the stream to be reset, got %v", err)
+	}
+}
+
+func TestFramedStreamHandlerFlowControl(t *testing.T) {
+	d1, c1, closer1 := createDaemonClientPair(t)
+	defer closer1()
+	d2, c2, closer2 := createDaemonClientPair(t)
+	defer closer2()
+	if err := connect(c1, d2); err != nil {
+		t.Fatal(err)
+	}
+
+	// many more frames than the daemon queues for the stream
+	payload := bytes.Repeat([]byte("flood"), 1<<21)
+	written := make(chan error, 1)
+	err := c1.AddFramedStreamHandler("/flood", func(info *p2pclient.StreamInfo, s *p2pclient.FramedStream) {
+		defer s.Close()
+		_, err := s.Write(payload)
+		if err == nil {
+			err = s.CloseWrite()
+		}
+		written <- err
+		ioutil.ReadAll(s)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, stream, err := c2.NewFramedStream(d1.ID(), []string{"/flood"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Close()
+	if err := stream.CloseWrite(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the handler writes faster than the stream is read
+	time.Sleep(500 * time.Millisecond)
+	data, err := ioutil.ReadAll(stream)
+	if err != nil {
+		t.Fatalf("expected the stream to be closed cleanly, got %v", err)
+	}
+	if !bytes.Equal(data, payload) {
+		t.Fatalf("expected the payload to be received whole, got %d bytes of %d", len(data), len(payload))
+	}
+	if err := <-written; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSlowFramedStreamHandler(t *testing.T) {
+	d1, c1, closer1 := createDaemonClientPair(t)
+	defer closer1()
+	d2, c2, closer2 := createDaemonClientPair(t)
+	defer closer2()
+	if err := connect(c1, d2); err != nil {
+		t.Fatal(err)
+	}
+
+	payload := bytes.Repeat([]byte("flood"), 1<<21)
+	unblock := make(chan struct{})
+	received := make(chan []byte, 1)
+	err := c1.AddFramedStreamHandler("/slow", func(info *p2pclient.StreamInfo, s *p2pclient.FramedStream) {
+		defer s.Close()
+		<-unblock
+		data, _ := ioutil.ReadAll(s)
+		received <- data
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, stream, err := c2.NewFramedStream(d1.ID(), []string{"/slow"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Close()
+	go func() {
+		if _, err := stream.Write(payload); err == nil {
+			stream.CloseWrite()
+		}
+	}()
+
+	// the daemon stops sending past the window of the handler, so the other
+	// calls of the connection go on
+	time.Sleep(500 * time.Millisecond)
+	for i := 0; i < 10; i++ {
+		if _, err := c1.GetPersistentConnStats(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	close(unblock)
+	select {
+	case data := <-received:
+		if !bytes.Equal(data, payload) {
+			t.Fatalf("expected the payload to be received whole, got %d bytes of %d", len(data), len(payload))
+		}
+	case <-time.After(20 * time.Second):
+		t.Fatal("expected the handler to read the whole stream")
+	}
+}
@@ -0,0 +1,66 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	ggio "github.com/gogo/protobuf/io"
+	"github.com/libp2p/go-libp2p-core/network"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+	manet "github.com/multiformats/go-multiaddr/net"
+)
+
+func TestKeepAlive(t *testing.T) {
+	d, c, cancel := createDaemonClientPair(t)
+	defer cancel()
+	d.SetKeepAlive(50*time.Millisecond, 2)
+
+	if _, err := c.GetPersistentConnStats(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the client answers pings, so the idle connection is kept open
+	time.Sleep(500 * time.Millisecond)
+
+	if _, err := c.GetPersistentConnStats(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestKeepAliveClosesUnresponsiveConn(t *testing.T) {
+	d, _, cancel := createDaemonClientPair(t)
+	defer cancel()
+	d.SetKeepAlive(50*time.Millisecond, 2)
+
+	conn, err := manet.Dial(d.Listener().Multiaddr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	w := ggio.NewDelimitedWriter(conn)
+	r := ggio.NewDelimitedReader(conn, network.MessageSizeMax)
+	if err := w.WriteMsg(&pb.Request{Type: pb.Request_PERSISTENT_CONN_UPGRADE.Enum()}); err != nil {
+		t.Fatal(err)
+	}
+	var res pb.Response
+	if err := r.ReadMsg(&res); err != nil {
+		t.Fatal(err)
+	}
+
+	pings := 0
+	for {
+		var msg pb.PersistentConnectionResponse
+		if err := r.ReadMsg(&msg); err != nil {
+			break
+		}
+		if msg.GetPing() == nil {
+			t.Fatalf("expected a ping, got %v", msg.Message)
+		}
+		pings++
+	}
+	if pings != 2 {
+		t.Fatalf("expected the connection to be closed after 2 unanswered pings, got %d", pings)
+	}
+}
@@ -0,0 +1,38 @@
+package test
+
+import "testing"
+
+func TestLogLevel(t *testing.T) {
+	_, c, cancel := createDaemonClientPair(t)
+	defer cancel()
+
+	levels, err := c.LogLevels()
+	if err != nil {
+		t.Fatal(err)
+	}
+	original, ok := levels["p2pd"]
+	if !ok {
+		t.Fatalf("expected the p2pd subsystem to be listed, got %v", levels)
+	}
+	defer c.SetLogLevel("p2pd", original)
+
+	levels, err = c.SetLogLevel("p2pd", "debug")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if levels["p2pd"] != "debug" {
+		t.Fatalf("expected p2pd to log at debug, got %s", levels["p2pd"])
+	}
+
+	if _, err := c.SetLogLevel("no-such-subsystem", "debug"); err == nil {
+		t.Fatal("expected an unknown subsystem to be rejected")
+	}
+	if _, err := c.SetLogLevel("p2pd", "verbose"); err == nil {
+		t.Fatal("expected an unknown level to be rejected")
+	}
+	if levels, err := c.LogLevels(); err != nil {
+		t.Fatal(err)
+	} else if levels["p2pd"] != "debug" {
+		t.Fatalf("expected failed requests to leave the level alone, got %s", levels["p2pd"])
+	}
+}
@@ -0,0 +1,57 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	p2pd "github.com/libp2p/go-libp2p-daemon"
+)
+
+func TestPeerMetadata(t *testing.T) {
+	d1, p1, cancel1 := createDaemonClientPair(t)
+	defer cancel1()
+	d2, p2, cancel2 := createDaemonClientPair(t)
+	defer cancel2()
+
+	if err := d1.SetMetadata(map[string]string{"role": "trainer", "zone": "eu-west"}); err != nil {
+		t.Fatal(err)
+	}
+
+	peer1ID, peer1Addrs, err := p1.Identify()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p2.Connect(peer1ID, peer1Addrs); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// identify tells peers the metadata is there
+	supported, err := p2.SupportsProtocols(peer1ID, p2pd.MetadataProtocol)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(supported) != 1 {
+		t.Fatalf("expected %s to be announced, got %v", p2pd.MetadataProtocol, supported)
+	}
+
+	md, err := p2.PeerMetadata(ctx, peer1ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(md) != 2 || md["role"] != "trainer" || md["zone"] != "eu-west" {
+		t.Fatalf("expected the metadata of the peer, got %v", md)
+	}
+
+	// d2 announces none
+	if _, err := p1.PeerMetadata(ctx, d2.ID()); err == nil {
+		t.Fatal("expected reading the metadata of a peer without any to fail")
+	}
+
+	if err := d1.SetMetadata(map[string]string{"": "trainer"}); err == nil {
+		t.Fatal("expected an empty key to be rejected")
+	}
+}
@@ -0,0 +1,63 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-daemon/p2pclient"
+)
+
+func TestSignedPeerRecords(t *testing.T) {
+	_, p1, cancel1 := createDaemonClientPair(t)
+	defer cancel1()
+	_, p2, cancel2 := createDaemonClientPair(t)
+	defer cancel2()
+
+	peer1ID, peer1Addrs, err := p1.Identify()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the host signs its record once its addresses are known
+	var record []byte
+	deadline := time.Now().Add(2 * time.Second)
+	for record == nil {
+		if record, err = p1.SignedPeerRecord(); err != nil {
+			t.Fatal(err)
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected the daemon to have a signed peer record")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// the record of another peer is rejected
+	peer2ID, _, err := p2.Identify()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := p1.ConnectWithRecord(peer2ID, nil, p2pclient.ConnectOptions{SignedPeerRecord: record}); err == nil {
+		t.Fatal("expected the record of another peer to be rejected")
+	}
+
+	tampered := append([]byte{}, record...)
+	tampered[len(tampered)-1] ^= 0xff
+	if _, _, err := p2.ConnectWithRecord(peer1ID, nil, p2pclient.ConnectOptions{SignedPeerRecord: tampered}); err == nil {
+		t.Fatal("expected a tampered record to be rejected")
+	}
+
+	// the addresses of the record are dialed, and the peer's record is
+	// returned once connected
+	_, remoteRecord, err := p2.ConnectWithRecord(peer1ID, nil, p2pclient.ConnectOptions{SignedPeerRecord: record})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if remoteRecord == nil {
+		t.Fatal("expected the peer's record to be returned")
+	}
+
+	// peers without records are dialed with their unsigned addresses
+	if _, _, err := p2.ConnectWithRecord(peer1ID, peer1Addrs, p2pclient.ConnectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,149 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	"github.com/libp2p/go-libp2p-core/protocol"
+
+	p2pd "github.com/libp2p/go-libp2p-daemon"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+func TestPersistentPeerstore(t *testing.T) {
+	daemonPath, _, dirCloser := createTempDir(t)
+	defer dirCloser()
+	path := daemonPath + ".peerstore"
+
+	id := randPeerID(t)
+	addr, err := ma.NewMultiaddr("/ip4/1.2.3.4/tcp/4001")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ps, closePeerstore, err := p2pd.NewPersistentPeerstore(context.Background(), path, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ps.AddAddr(id, addr, peerstore.PermanentAddrTTL)
+	if err := ps.AddProtocols(id, "/test/1.0.0"); err != nil {
+		t.Fatal(err)
+	}
+	ps.Close()
+	if err := closePeerstore(); err != nil {
+		t.Fatal(err)
+	}
+
+	ps, closePeerstore, err = p2pd.NewPersistentPeerstore(context.Background(), path, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		ps.Close()
+		closePeerstore()
+	}()
+
+	addrs := ps.Addrs(id)
+	if len(addrs) != 1 || !addrs[0].Equal(addr) {
+		t.Fatalf("expected %s to be restored, got %v", addr, addrs)
+	}
+	protos, err := ps.GetProtocols(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(protos) != 1 || protos[0] != "/test/1.0.0" {
+		t.Fatalf("expected protocols to be restored, got %v", protos)
+	}
+}
+
+func TestPeerstoreAddrs(t *testing.T) {
+	_, p1, cancel1 := createDaemonClientPair(t)
+	defer cancel1()
+	d2, p2, cancel2 := createDaemonClientPair(t)
+	defer cancel2()
+
+	peer1ID, peer1Addrs, err := p1.Identify()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := p2.AddAddrs(peer1ID, peer1Addrs, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if addrs := d2.Host().Peerstore().Addrs(peer1ID); len(addrs) != len(peer1Addrs) {
+		t.Fatalf("expected the addresses to be added, got %v", addrs)
+	}
+
+	if err := p2.ClearAddrs(peer1ID); err != nil {
+		t.Fatal(err)
+	}
+	if addrs := d2.Host().Peerstore().Addrs(peer1ID); len(addrs) != 0 {
+		t.Fatalf("expected the addresses to be cleared, got %v", addrs)
+	}
+
+	if err := p2.AddAddrs(peer1ID, peer1Addrs, time.Millisecond); err == nil {
+		t.Fatal("expected a TTL under a second to be rejected")
+	}
+
+	// the added addresses are dialed without looking the peer up
+	if err := p2.AddAddrs(peer1ID, peer1Addrs, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := p2.Connect(peer1ID, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPeerProtocols(t *testing.T) {
+	_, p1, cancel1 := createDaemonClientPair(t)
+	defer cancel1()
+	_, p2, cancel2 := createDaemonClientPair(t)
+	defer cancel2()
+
+	peer1ID, peer1Addrs, err := p1.Identify()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// nothing is known before identify
+	protos, err := p2.PeerProtocols(peer1ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(protos) != 0 {
+		t.Fatalf("expected no protocols for an unknown peer, got %v", protos)
+	}
+
+	var proto protocol.ID = "echo"
+	echo := func(ctx context.Context, data []byte) ([]byte, error) {
+		return data, nil
+	}
+	if err := p1.AddUnaryHandler(proto, echo); err != nil {
+		t.Fatal(err)
+	}
+	if err := p2.Connect(peer1ID, peer1Addrs); err != nil {
+		t.Fatal(err)
+	}
+
+	protos, err = p2.PeerProtocols(peer1ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, p := range protos {
+		found = found || p == proto
+	}
+	if !found {
+		t.Fatalf("expected %s among the protocols of the peer, got %v", proto, protos)
+	}
+
+	supported, err := p2.SupportsProtocols(peer1ID, proto, "missing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(supported) != 1 || supported[0] != proto {
+		t.Fatalf("expected only %s to be supported, got %v", proto, supported)
+	}
+}
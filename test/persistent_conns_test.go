@@ -0,0 +1,101 @@
+package test
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	ggio "github.com/gogo/protobuf/io"
+	"github.com/google/uuid"
+	"github.com/libp2p/go-libp2p-core/network"
+	p2pd "github.com/libp2p/go-libp2p-daemon"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+	manet "github.com/multiformats/go-multiaddr/net"
+)
+
+// upgradeConn opens a persistent connection to d, returning the connection
+// and the daemon's response.
+func upgradeConn(t *testing.T, d *p2pd.Daemon) (manet.Conn, *pb.Response) {
+	conn, err := manet.Dial(d.Listener().Multiaddr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	w := ggio.NewDelimitedWriter(conn)
+	r := ggio.NewDelimitedReader(conn, network.MessageSizeMax)
+	if err := w.WriteMsg(&pb.Request{Type: pb.Request_PERSISTENT_CONN_UPGRADE.Enum()}); err != nil {
+		t.Fatal(err)
+	}
+	res := &pb.Response{}
+	if err := r.ReadMsg(res); err != nil {
+		t.Fatal(err)
+	}
+	return conn, res
+}
+
+func TestMaxPersistentConns(t *testing.T) {
+	d, _, cancel := createDaemonClientPair(t)
+	defer cancel()
+	d.SetMaxPersistentConns(1)
+
+	first, res := upgradeConn(t, d)
+	if res.GetType() != pb.Response_OK {
+		t.Fatalf("expected the first connection to be accepted, got %v", res.GetError())
+	}
+
+	second, res := upgradeConn(t, d)
+	second.Close()
+	if res.GetType() != pb.Response_ERROR {
+		t.Fatal("expected a connection beyond the maximum to be rejected")
+	}
+
+	// the slot is freed once the first connection is closed
+	first.Close()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		conn, res := upgradeConn(t, d)
+		conn.Close()
+		if res.GetType() == pb.Response_OK {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected a connection to be accepted once the first one is closed, got %v", res.GetError())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestOversizedClientMessage(t *testing.T) {
+	d, _, cancel := createDaemonClientPair(t)
+	defer cancel()
+
+	conn, res := upgradeConn(t, d)
+	defer conn.Close()
+	if res.GetType() != pb.Response_OK {
+		t.Fatalf("expected the connection to be accepted, got %v", res.GetError())
+	}
+
+	// only the length prefix is needed for the message to be rejected
+	prefix := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(prefix, network.MessageSizeMax+1)
+	if _, err := conn.Write(prefix[:n]); err != nil {
+		t.Fatal(err)
+	}
+
+	r := ggio.NewDelimitedReader(conn, network.MessageSizeMax)
+	var msg pb.PersistentConnectionResponse
+	if err := r.ReadMsg(&msg); err != nil {
+		t.Fatal(err)
+	}
+	if msg.GetDaemonError().GetCode() != pb.DaemonError_MESSAGE_TOO_LARGE {
+		t.Fatalf("expected a MESSAGE_TOO_LARGE error, got %v", msg.Message)
+	}
+	if callID, err := uuid.FromBytes(msg.GetCallId()); err != nil || callID != uuid.Nil {
+		t.Fatalf("expected the error not to be about a call, got call id %v", msg.GetCallId())
+	}
+
+	if err := r.ReadMsg(&msg); err == nil {
+		t.Fatal("expected the connection to be closed")
+	}
+}
@@ -0,0 +1,60 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p-core/pnet"
+	p2pd "github.com/libp2p/go-libp2p-daemon"
+	"github.com/libp2p/go-libp2p-daemon/p2pclient"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+func createPrivateDaemonClientPair(t *testing.T, psk pnet.PSK) (*p2pd.Daemon, *p2pclient.Client, func()) {
+	dmaddr, cmaddr, dirCloser := getEndpointsMaker(t)(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	d, err := p2pd.NewDaemon(ctx, []ma.Multiaddr{dmaddr}, "",
+		libp2p.PrivateNetwork(psk),
+		libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go d.Serve()
+
+	c, closeClient := createClient(t, d.Listener().Multiaddr(), cmaddr)
+	return d, c, func() {
+		cancel()
+		closeClient()
+		dirCloser()
+	}
+}
+
+func TestPrivateNetwork(t *testing.T) {
+	psk := pnet.PSK(bytes.Repeat([]byte{1}, 32))
+	otherPSK := pnet.PSK(bytes.Repeat([]byte{2}, 32))
+
+	_, c1, closer1 := createPrivateDaemonClientPair(t, psk)
+	defer closer1()
+	d2, _, closer2 := createPrivateDaemonClientPair(t, psk)
+	defer closer2()
+	d3, _, closer3 := createPrivateDaemonClientPair(t, otherPSK)
+	defer closer3()
+	d4, _, closer4 := createDaemonClientPair(t)
+	defer closer4()
+
+	if err := connect(c1, d2); err != nil {
+		t.Fatal(err)
+	}
+	// the handshake stalls rather than fails with mismatching keys
+	opts := p2pclient.ConnectOptions{Timeout: time.Second}
+	if _, err := c1.ConnectWithOptions(d3.ID(), d3.Addrs(), opts); err == nil {
+		t.Fatal("expected peers with another pre-shared key to be unreachable")
+	}
+	if _, err := c1.ConnectWithOptions(d4.ID(), d4.Addrs(), opts); err == nil {
+		t.Fatal("expected peers outside of the private network to be unreachable")
+	}
+}
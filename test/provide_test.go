@@ -0,0 +1,103 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p-core/peer"
+	p2pd "github.com/libp2p/go-libp2p-daemon"
+	dht "github.com/libp2p/go-libp2p-kad-dht"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+func TestProviding(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	h, err := libp2p.New(ctx, libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+	hub, err := dht.New(ctx, h, dht.Mode(dht.ModeServer))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dmaddr, cmaddr, dirCloser := getEndpointsMaker(t)(t)
+	defer dirCloser()
+	d, err := p2pd.NewDaemon(ctx, []ma.Multiaddr{dmaddr}, "server",
+		libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	go d.Serve()
+
+	c, clientCloser := createClient(t, d.Listener().Multiaddr(), cmaddr)
+	defer clientCloser()
+
+	if err := c.Connect(h.ID(), h.Addrs()); err != nil {
+		t.Fatal(err)
+	}
+	// the DHTs add each other to their routing tables asynchronously
+	for d.DHT().RoutingTable().Size() == 0 {
+		select {
+		case <-ctx.Done():
+			t.Fatal(ctx.Err())
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+
+	id := randCid(t)
+	if err := c.StartProviding(id); err != nil {
+		t.Fatal(err)
+	}
+
+	cids, err := c.ListProviding(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cids) != 1 || !cids[0].Equals(id) {
+		t.Fatalf("expected to be providing %s, got %v", id, cids)
+	}
+
+	findCtx, cancelFind := context.WithTimeout(ctx, 10*time.Second)
+	defer cancelFind()
+	var provider peer.AddrInfo
+	for provider.ID != d.ID() {
+		providers, err := hub.FindProviders(findCtx, id)
+		if findCtx.Err() != nil {
+			t.Fatalf("expected the daemon to announce %s, got %v", id, providers)
+		}
+		if err == nil && len(providers) > 0 {
+			provider = providers[0]
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	if err := c.StopProviding(id); err != nil {
+		t.Fatal(err)
+	}
+	cids, err = c.ListProviding(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cids) != 0 {
+		t.Fatalf("expected to provide nothing, got %v", cids)
+	}
+}
+
+func TestProvidingWithoutDHT(t *testing.T) {
+	d, c, closer := createDaemonClientPair(t)
+	defer closer()
+
+	if err := d.StartProviding(randCid(t)); err == nil {
+		t.Fatal("expected providing without the DHT to fail")
+	}
+	if err := c.StartProviding(randCid(t)); err == nil {
+		t.Fatal("expected providing without the DHT to fail")
+	}
+}
@@ -4,6 +4,12 @@ import (
 	"context"
 	"testing"
 	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	p2pd "github.com/libp2p/go-libp2p-daemon"
+	"github.com/libp2p/go-libp2p-daemon/p2pclient"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+	pspb "github.com/libp2p/go-libp2p-pubsub/pb"
 )
 
 func TestPubsubGetTopicsAndSubscribe(t *testing.T) {
@@ -73,3 +79,328 @@ func TestPubsubMessages(t *testing.T) {
 		t.Fatal("timed out waiting for message")
 	}
 }
+
+func TestPubsubListPeers(t *testing.T) {
+	_, c1, closer1 := createDaemonClientPair(t)
+	defer closer1()
+	d2, c2, closer2 := createDaemonClientPair(t)
+	defer closer2()
+
+	if _, err := c1.ListPeers("test"); err == nil {
+		t.Fatal("expected listing peers of a topic that was never joined to fail")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := c1.Subscribe(ctx, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c2.Subscribe(ctx, "test"); err != nil {
+		t.Fatal(err)
+	}
+	// dial a single address: pubsub forgets the subscriptions of a peer when
+	// its stream over a connection losing the parallel dial dies before the
+	// swarm reports the winning one, and the peer doesn't announce them again
+	if err := c1.Connect(d2.ID(), d2.Addrs()[:1]); err != nil {
+		t.Fatal(err)
+	}
+
+	for {
+		peers, err := c1.ListPeers("test")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(peers) == 1 && peers[0] == d2.ID() {
+			return
+		}
+
+		select {
+		case <-time.After(100 * time.Millisecond):
+		case <-ctx.Done():
+			t.Fatalf("timed out waiting for %s to join the topic, got %v", d2.ID(), peers)
+		}
+	}
+}
+
+func TestPubsubPersistentSubscribe(t *testing.T) {
+	d1, c1, closer1 := createDaemonClientPair(t)
+	defer closer1()
+	d2, c2, closer2 := createDaemonClientPair(t)
+	defer closer2()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	msgs := joinTopic(ctx, t, d1, c1, d2, c2, "test")
+
+	// publish until received: the topic peers are known a little before
+	// messages are forwarded to them
+	timeout := time.After(10 * time.Second)
+	var msg *p2pclient.PubSubMessage
+	for msg == nil {
+		if err := c2.PublishTopic("test", []byte("foobar")); err != nil {
+			t.Fatal(err)
+		}
+
+		select {
+		case msg = <-msgs:
+		case <-time.After(500 * time.Millisecond):
+		case <-timeout:
+			t.Fatal("timed out waiting for message")
+		}
+	}
+
+	if msg.Err != nil {
+		t.Fatal(msg.Err)
+	}
+	if msg.From != d2.ID() {
+		t.Fatalf("expected a message from %s, got %s", d2.ID(), msg.From)
+	}
+	if string(msg.Data) != "foobar" {
+		t.Fatalf("expected \"foobar\", got %s", msg.Data)
+	}
+	if len(msg.Seqno) == 0 {
+		t.Fatal("expected the message to have a seqno")
+	}
+
+	cancel()
+	for range msgs {
+	}
+	waitActiveCalls(t, c1, 0)
+
+	topics, err := c1.GetTopics()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(topics) != 0 {
+		t.Fatalf("expected the subscription to be cancelled, still subscribed to %v", topics)
+	}
+}
+
+func TestPubsubSlowSubscriber(t *testing.T) {
+	defer func(size int) { p2pclient.StreamBufferSize = size }(p2pclient.StreamBufferSize)
+	p2pclient.StreamBufferSize = 4
+
+	_, c, closer := createDaemonClientPair(t)
+	defer closer()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the subscriber receives its own messages, but doesn't read them yet
+	msgs, err := c.SubscribeTopic(ctx, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitActiveCalls(t, c, 1)
+
+	for i := 0; i < 4*p2pclient.StreamBufferSize; i++ {
+		if err := c.PublishTopic("test", []byte("spam")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the other calls of the persistent connection go on, while the
+	// subscription is cancelled
+	waitActiveCalls(t, c, 0)
+
+	var last *p2pclient.PubSubMessage
+	for msg := range msgs {
+		last = msg
+	}
+	if last == nil || last.Err != p2pclient.ErrStreamFellBehind {
+		t.Fatalf("expected the subscription to end with ErrStreamFellBehind, got %v", last)
+	}
+}
+
+func waitTopicPeer(t *testing.T, c *p2pclient.Client, topic string, p peer.ID) {
+	t.Helper()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		peers, err := c.ListPeers(topic)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(peers) == 1 && peers[0] == p {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s to join the topic, got %v", p, peers)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+func TestPubsubTopicValidator(t *testing.T) {
+	d1, c1, closer1 := createDaemonClientPair(t)
+	defer closer1()
+	d2, c2, closer2 := createDaemonClientPair(t)
+	defer closer2()
+
+	validator := func(topic string, msg *p2pclient.PubSubMessage) pb.ValidationResult_Result {
+		if string(msg.Data) == "spam" {
+			return pb.ValidationResult_REJECT
+		}
+		return pb.ValidationResult_ACCEPT
+	}
+	if err := c1.AddTopicValidator("test", validator, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := c1.AddTopicValidator("test", validator, 0); err == nil {
+		t.Fatal("expected registering a second validator for the topic to fail")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	msgs := joinTopic(ctx, t, d1, c1, d2, c2, "test")
+
+	timeout := time.After(10 * time.Second)
+	for {
+		if err := c2.PublishTopic("test", []byte("spam")); err != nil {
+			t.Fatal(err)
+		}
+		if err := c2.PublishTopic("test", []byte("ham")); err != nil {
+			t.Fatal(err)
+		}
+
+		select {
+		case msg := <-msgs:
+			if msg.Err != nil {
+				t.Fatal(msg.Err)
+			}
+			if string(msg.Data) != "ham" {
+				t.Fatalf("expected only \"ham\" to be delivered, got %s", msg.Data)
+			}
+			return
+		case <-time.After(500 * time.Millisecond):
+		case <-timeout:
+			t.Fatal("timed out waiting for message")
+		}
+	}
+}
+
+func TestPubsubTopicValidatorTimeout(t *testing.T) {
+	d1, c1, closer1 := createDaemonClientPair(t)
+	defer closer1()
+	d2, c2, closer2 := createDaemonClientPair(t)
+	defer closer2()
+
+	validated := make(chan struct{}, 16)
+	block := make(chan struct{})
+	defer close(block)
+	validator := func(topic string, msg *p2pclient.PubSubMessage) pb.ValidationResult_Result {
+		validated <- struct{}{}
+		<-block
+		return pb.ValidationResult_ACCEPT
+	}
+	if err := c1.AddTopicValidator("test", validator, 100*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	msgs := joinTopic(ctx, t, d1, c1, d2, c2, "test")
+
+	timeout := time.After(10 * time.Second)
+	for received := false; !received; {
+		if err := c2.PublishTopic("test", []byte("foobar")); err != nil {
+			t.Fatal(err)
+		}
+
+		select {
+		case <-validated:
+			received = true
+		case <-time.After(500 * time.Millisecond):
+		case <-timeout:
+			t.Fatal("timed out waiting for the message to be validated")
+		}
+	}
+
+	select {
+	case msg := <-msgs:
+		t.Fatalf("expected the unvalidated message to be rejected, got %s", msg.Data)
+	case <-time.After(time.Second):
+	}
+}
+
+// joinTopic subscribes both clients to topic over their persistent
+// connections, and connects their daemons. The messages received by c1 are
+// returned.
+func joinTopic(
+	ctx context.Context,
+	t *testing.T,
+	d1 *p2pd.Daemon, c1 *p2pclient.Client,
+	d2 *p2pd.Daemon, c2 *p2pclient.Client,
+	topic string,
+) <-chan *p2pclient.PubSubMessage {
+	t.Helper()
+
+	msgs, err := c1.SubscribeTopic(ctx, topic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msgs2, err := c2.SubscribeTopic(ctx, topic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		// the publisher receives its own messages
+		for range msgs2 {
+		}
+	}()
+	waitActiveCalls(t, c1, 1)
+	waitActiveCalls(t, c2, 1)
+
+	if err := c1.Connect(d2.ID(), d2.Addrs()[:1]); err != nil {
+		t.Fatal(err)
+	}
+
+	waitTopicPeer(t, c1, topic, d2.ID())
+	waitTopicPeer(t, c2, topic, d1.ID())
+
+	return msgs
+}
+
+func TestMessageIDFn(t *testing.T) {
+	msg := func(topic, data string, seqno byte) *pspb.Message {
+		return &pspb.Message{
+			From:  []byte(randPeerID(t)),
+			Seqno: []byte{seqno},
+			Topic: &topic,
+			Data:  []byte(data),
+		}
+	}
+
+	content, err := p2pd.NewMessageIDFn(p2pd.MessageIDContent, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if content(msg("a", "hello", 1)) != content(msg("a", "hello", 2)) {
+		t.Fatal("expected identical messages to get the same content id")
+	}
+	if content(msg("a", "hello", 1)) == content(msg("b", "hello", 1)) {
+		t.Fatal("expected content ids to depend on the topic")
+	}
+
+	prefix, err := p2pd.NewMessageIDFn(p2pd.MessageIDPrefix, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prefix(msg("a", "id-1 hello", 1)) != prefix(msg("a", "id-1 world", 2)) {
+		t.Fatal("expected messages with the same prefix to get the same id")
+	}
+	if prefix(msg("a", "id-1 hello", 1)) == prefix(msg("a", "id-2 hello", 1)) {
+		t.Fatal("expected messages with different prefixes to get different ids")
+	}
+
+	if _, err := p2pd.NewMessageIDFn(p2pd.MessageIDPrefix, 0); err == nil {
+		t.Fatal("expected prefix ids without a length to be rejected")
+	}
+	if _, err := p2pd.NewMessageIDFn("random", 0); err == nil {
+		t.Fatal("expected an unknown scheme to be rejected")
+	}
+}
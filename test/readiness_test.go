@@ -0,0 +1,76 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/libp2p/go-libp2p-daemon/config"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+func TestReadinessHandler(t *testing.T) {
+	d1, c1, closer1 := createDaemonClientPair(t)
+	defer closer1()
+	d2, _, closer2 := createDaemonClientPair(t)
+	defer closer2()
+
+	probe := func(criterion string) int {
+		rec := httptest.NewRecorder()
+		d1.ReadinessHandler(criterion).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ready", nil))
+		return rec.Code
+	}
+
+	if code := probe(config.ReadyListening); code != http.StatusOK {
+		t.Fatalf("expected a listening daemon to be ready, got %d", code)
+	}
+	if code := probe(config.ReadyBootstrapped); code != http.StatusServiceUnavailable {
+		t.Fatalf("expected a daemon that hasn't bootstrapped not to be ready, got %d", code)
+	}
+
+	p2pAddr, err := ma.NewComponent("p2p", d2.ID().Pretty())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c1.SetBootstrapPeers([]ma.Multiaddr{d2.Addrs()[0].Encapsulate(p2pAddr)}, true); err != nil {
+		t.Fatal(err)
+	}
+
+	if code := probe(config.ReadyBootstrapped); code != http.StatusOK {
+		t.Fatalf("expected a bootstrapped daemon to be ready, got %d", code)
+	}
+	// the test daemons run without a DHT
+	if code := probe(config.ReadyDHT); code != http.StatusServiceUnavailable {
+		t.Fatalf("expected a daemon without a DHT not to be ready, got %d", code)
+	}
+}
+
+func TestStartupInfo(t *testing.T) {
+	d, _, closer := createDaemonClientPair(t)
+	defer closer()
+
+	var buf bytes.Buffer
+	if err := d.WriteStartupInfo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Count(buf.Bytes(), []byte("\n")) != 1 {
+		t.Fatalf("expected a single line, got %q", buf.String())
+	}
+
+	var info map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &info); err != nil {
+		t.Fatal(err)
+	}
+	if info["peerID"] != d.ID().Pretty() {
+		t.Fatalf("expected peer ID %s, got %v", d.ID().Pretty(), info["peerID"])
+	}
+	if addrs, ok := info["listenAddrs"].([]interface{}); !ok || len(addrs) != len(d.Addrs()) {
+		t.Fatalf("expected the listen addrs %v, got %v", d.Addrs(), info["listenAddrs"])
+	}
+	controlAddrs, ok := info["controlAddrs"].([]interface{})
+	if !ok || len(controlAddrs) != 1 || controlAddrs[0] != d.Listener().Multiaddr().String() {
+		t.Fatalf("expected the control addr %s, got %v", d.Listener().Multiaddr(), info["controlAddrs"])
+	}
+}
@@ -0,0 +1,327 @@
+package test
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	relay "github.com/libp2p/go-libp2p-circuit"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/metrics"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	p2pd "github.com/libp2p/go-libp2p-daemon"
+	"github.com/libp2p/go-libp2p-daemon/p2pclient"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+func TestListRelays(t *testing.T) {
+	dmaddr, cmaddr, dirCloser := getEndpointsMaker(t)(t)
+	defer dirCloser()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	relay, err := libp2p.New(ctx, libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer relay.Close()
+
+	// advertise a circuit address through the relay, as autorelay would
+	circuit := ma.StringCast("/ip4/127.0.0.1/tcp/4001/p2p/" + relay.ID().Pretty() + "/p2p-circuit")
+	d, err := p2pd.NewDaemon(ctx, []ma.Multiaddr{dmaddr}, "",
+		libp2p.AddrsFactory(func(addrs []ma.Multiaddr) []ma.Multiaddr {
+			return append(addrs, circuit)
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	go d.Serve()
+
+	c, clientCloser := createClient(t, d.Listener().Multiaddr(), cmaddr)
+	defer clientCloser()
+
+	relays, err := c.ListRelays()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(relays) != 1 || relays[0].ID != relay.ID() {
+		t.Fatalf("expected the relay to be listed, got %v", relays)
+	}
+	if len(relays[0].Addrs) != 1 || !relays[0].Addrs[0].Equal(circuit) {
+		t.Fatalf("expected the circuit address to be listed, got %v", relays[0].Addrs)
+	}
+	if relays[0].Connected {
+		t.Fatal("expected the relay to be disconnected")
+	}
+
+	if err := c.Connect(relay.ID(), relay.Addrs()); err != nil {
+		t.Fatal(err)
+	}
+
+	relays, err = c.ListRelays()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(relays) != 1 || !relays[0].Connected {
+		t.Fatalf("expected the relay to be connected, got %v", relays)
+	}
+}
+
+func TestRefreshRelays(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	relay, err := libp2p.New(ctx, libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer relay.Close()
+
+	// privateDaemon starts a private daemon refreshing relays every 20ms,
+	// and counts the private reachability events it emits for a while
+	privateDaemon := func(setup func(*p2pclient.Client), opts ...libp2p.Option) int {
+		dmaddr, cmaddr, dirCloser := getEndpointsMaker(t)(t)
+		defer dirCloser()
+
+		opts = append(opts, libp2p.ForceReachabilityPrivate())
+		d, err := p2pd.NewDaemon(ctx, []ma.Multiaddr{dmaddr}, "", opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer d.Close()
+		go d.Serve()
+
+		c, clientCloser := createClient(t, d.Listener().Multiaddr(), cmaddr)
+		defer clientCloser()
+		setup(c)
+
+		if err := d.RefreshRelays(20*time.Millisecond, 1); err != nil {
+			t.Fatal(err)
+		}
+
+		subCtx, cancelSub := context.WithCancel(ctx)
+		defer cancelSub()
+		events, err := c.SubscribeEvents(subCtx, pb.Event_LOCAL_REACHABILITY_CHANGED)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		n := 0
+		timeout := time.After(300 * time.Millisecond)
+		for {
+			select {
+			case evt := <-events:
+				if evt.Reachability == network.ReachabilityPrivate {
+					n++
+				}
+			case <-timeout:
+				return n
+			}
+		}
+	}
+
+	// without relays, the private reachability keeps being emitted again
+	if n := privateDaemon(func(*p2pclient.Client) {}); n < 3 {
+		t.Fatalf("expected relays to be looked for again, got %d private reachability events", n)
+	}
+
+	// with a connected relay, only the initial event is emitted
+	circuit := ma.StringCast("/ip4/127.0.0.1/tcp/4001/p2p/" + relay.ID().Pretty() + "/p2p-circuit")
+	n := privateDaemon(
+		func(c *p2pclient.Client) {
+			if err := c.Connect(relay.ID(), relay.Addrs()); err != nil {
+				t.Fatal(err)
+			}
+		},
+		libp2p.AddrsFactory(func(addrs []ma.Multiaddr) []ma.Multiaddr {
+			return append(addrs, circuit)
+		}),
+	)
+	if n != 1 {
+		t.Fatalf("expected relays not to be looked for again, got %d private reachability events", n)
+	}
+}
+
+func TestRelayClientOnly(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	probe, err := libp2p.New(ctx, libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer probe.Close()
+
+	// canHop tells whether a daemon started with opts offers the relay
+	// service, and whether it speaks the relay protocol at all
+	canHop := func(opts ...libp2p.Option) (bool, error) {
+		dmaddr, _, dirCloser := getEndpointsMaker(t)(t)
+		defer dirCloser()
+
+		opts = append(opts, libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+		d, err := p2pd.NewDaemon(ctx, []ma.Multiaddr{dmaddr}, "", opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer d.Close()
+
+		if err := probe.Connect(ctx, peer.AddrInfo{ID: d.ID(), Addrs: d.Addrs()}); err != nil {
+			t.Fatal(err)
+		}
+		return relay.CanHop(ctx, probe, d.ID())
+	}
+
+	// what p2pd uses for -relay=1 -relayHop=0
+	if hop, err := canHop(libp2p.EnableRelay()); err != nil || hop {
+		t.Fatalf("expected a relay client not to offer the relay service, got %t, %v", hop, err)
+	}
+	// -relay=1 -relayHop=1
+	if hop, err := canHop(libp2p.EnableRelay(relay.OptHop)); err != nil || !hop {
+		t.Fatalf("expected the relay service to be offered, got %t, %v", hop, err)
+	}
+	// -relay=0
+	if _, err := canHop(libp2p.DisableRelay()); err == nil {
+		t.Fatal("expected the relay protocol not to be spoken with relay disabled")
+	}
+}
+
+func TestRelayMetrics(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dmaddr, _, dirCloser := getEndpointsMaker(t)(t)
+	defer dirCloser()
+	bwc := metrics.NewBandwidthCounter()
+	d, err := p2pd.NewDaemon(ctx, []ma.Multiaddr{dmaddr}, "",
+		libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"),
+		libp2p.EnableRelay(relay.OptHop),
+		libp2p.BandwidthReporter(bwc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	d.SetRelayMetricsInterval(50 * time.Millisecond)
+	d.SetBandwidthReporter(bwc)
+	d.SetRelayService(true)
+
+	relayInfo := peer.AddrInfo{ID: d.ID(), Addrs: d.Addrs()}
+	newPeer := func() host.Host {
+		h, err := libp2p.New(ctx, libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"), libp2p.EnableRelay())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := h.Connect(ctx, relayInfo); err != nil {
+			t.Fatal(err)
+		}
+		return h
+	}
+	src, dst := newPeer(), newPeer()
+	defer src.Close()
+	defer dst.Close()
+
+	bytesBefore := metricValue(t, "p2pd_relay_bytes_total", nil)
+
+	// the destination is only reachable through the daemon
+	circuit := ma.StringCast("/p2p/" + d.ID().Pretty() + "/p2p-circuit")
+	if err := src.Connect(ctx, peer.AddrInfo{ID: dst.ID(), Addrs: []ma.Multiaddr{circuit}}); err != nil {
+		t.Fatal(err)
+	}
+
+	waitForMetric := func(name string, expected float64) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for metricValue(t, name, nil) != expected {
+			if time.Now().After(deadline) {
+				t.Fatalf("expected %s to be %v, got %v", name, expected, metricValue(t, name, nil))
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+	}
+	waitForMetric("p2pd_relay_circuits", 1)
+	waitForMetric("p2pd_relay_circuit_peers", 2)
+	waitForMetric("p2pd_relay_peer_circuits_max", 1)
+	waitForMetric("p2pd_relay_circuit_limit", float64(relay.HopStreamLimit))
+	if metricValue(t, "p2pd_relay_bytes_total", nil) <= bytesBefore {
+		t.Fatal("expected the bytes of the identify exchange to be counted as relayed")
+	}
+
+	if err := src.Network().ClosePeer(dst.ID()); err != nil {
+		t.Fatal(err)
+	}
+	waitForMetric("p2pd_relay_circuits", 0)
+	waitForMetric("p2pd_relay_circuit_peers", 0)
+}
+
+func TestKeepStaticRelays(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the relay is down at first, and comes back on the same address
+	key, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	startRelay := func(addrs ...ma.Multiaddr) host.Host {
+		h, err := libp2p.New(ctx, libp2p.Identity(key), libp2p.ListenAddrs(addrs...), libp2p.EnableRelay(relay.OptHop))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return h
+	}
+	r := startRelay(ma.StringCast("/ip4/127.0.0.1/tcp/0"))
+	relayInfo := peer.AddrInfo{ID: r.ID(), Addrs: r.Addrs()}
+	r.Close()
+
+	d, closeDaemon := createDaemon(t, ma.StringCast("/ip4/127.0.0.1/tcp/0"))
+	defer closeDaemon()
+
+	failuresBefore := metricValue(t, "p2pd_static_relay_connect_failures_total", nil)
+	err = d.KeepStaticRelays([]peer.AddrInfo{relayInfo}, p2pd.RelayReconnectPolicy{
+		InitialBackoff: 10 * time.Millisecond,
+		MaxBackoff:     50 * time.Millisecond,
+		Multiplier:     2,
+		Jitter:         0.2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	waitFor := func(what string, cond func() bool) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for !cond() {
+			if time.Now().After(deadline) {
+				t.Fatalf("timed out waiting for %s", what)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	waitFor("failed attempts", func() bool {
+		return metricValue(t, "p2pd_static_relay_connect_failures_total", nil) >= failuresBefore+2
+	})
+
+	r = startRelay(relayInfo.Addrs...)
+	defer r.Close()
+	connected := func() bool {
+		return d.Host().Network().Connectedness(r.ID()) == network.Connected
+	}
+	waitFor("the relay to be connected", connected)
+
+	// and again once the relay drops the connection
+	old := d.Host().Network().ConnsToPeer(r.ID())
+	if err := r.Network().ClosePeer(d.ID()); err != nil {
+		t.Fatal(err)
+	}
+	waitFor("the relay to be connected again", func() bool {
+		conns := d.Host().Network().ConnsToPeer(r.ID())
+		return len(conns) > 0 && conns[0] != old[0]
+	})
+}
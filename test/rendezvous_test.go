@@ -0,0 +1,96 @@
+package test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	p2pd "github.com/libp2p/go-libp2p-daemon"
+	"github.com/libp2p/go-libp2p-daemon/p2pclient"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+func TestRendezvous(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the daemons only know hub, which holds the provider records
+	hub := createDHTHost(t, ctx)
+	defer hub.Close()
+
+	daemons := make([]*p2pd.Daemon, 2)
+	clients := make([]*p2pclient.Client, 2)
+	for i := range daemons {
+		dmaddr, cmaddr, dirCloser := getEndpointsMaker(t)(t)
+		defer dirCloser()
+		d, err := p2pd.NewDaemon(ctx, []ma.Multiaddr{dmaddr}, "server",
+			libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer d.Close()
+		d.SetRendezvousInterval(200 * time.Millisecond)
+		go d.Serve()
+
+		c, clientCloser := createClient(t, d.Listener().Multiaddr(), cmaddr)
+		defer clientCloser()
+
+		if _, err := c.RendezvousPeers(); err == nil || !strings.Contains(err.Error(), "Rendezvous not enabled") {
+			t.Fatalf("expected an error without a rendezvous namespace, got %v", err)
+		}
+		if err := d.Host().Connect(ctx, peer.AddrInfo{ID: hub.ID(), Addrs: hub.Addrs()}); err != nil {
+			t.Fatal(err)
+		}
+
+		daemons[i], clients[i] = d, c
+	}
+
+	// advertising fails until hub is in the routing table
+	deadline := time.Now().Add(10 * time.Second)
+	for _, d := range daemons {
+		for d.DHT().RoutingTable().Find(hub.ID()) == "" {
+			if time.Now().After(deadline) {
+				t.Fatal("expected hub to be added to the routing table")
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+	}
+
+	for _, d := range daemons {
+		if err := d.StartRendezvous("p2pd-test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := daemons[0].StartRendezvous("other"); err == nil {
+		t.Fatal("expected a second namespace to be rejected")
+	}
+
+	deadline = time.Now().Add(20 * time.Second)
+	for i, c := range clients {
+		other := daemons[1-i].ID()
+		for {
+			peers, err := c.RendezvousPeers()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(peers) == 1 && peers[0].ID == other {
+				break
+			}
+			if len(peers) > 1 {
+				t.Fatalf("expected only the other daemon to be found, got %v", peers)
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("expected daemon %d to find the other one, got %v", i, peers)
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+
+		if daemons[i].Host().Network().Connectedness(other) != network.Connected {
+			t.Fatalf("expected daemon %d to connect to the other one", i)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package test
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/protocol"
+	"github.com/libp2p/go-libp2p-daemon/p2pclient"
+)
+
+func TestResourceUsage(t *testing.T) {
+	d1, c1, closer1 := createDaemonClientPair(t)
+	defer closer1()
+	d2, c2, closer2 := createDaemonClientPair(t)
+	defer closer2()
+	if err := connect(c1, d2); err != nil {
+		t.Fatal(err)
+	}
+	testprotos := []string{"/test"}
+
+	opened := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	err := c1.NewStreamHandler(testprotos, func(info *p2pclient.StreamInfo, conn io.ReadWriteCloser) {
+		defer conn.Close()
+		opened <- struct{}{}
+		<-release
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, conn, err := c2.NewStream(d1.ID(), testprotos)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	select {
+	case <-opened:
+	case <-time.After(1 * time.Second):
+		t.Fatal("timed out waiting for the stream to be handled")
+	}
+
+	usage, err := c1.ResourceUsage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// connect may dial more than one of the peer's addresses
+	if usage.System.ConnsOutbound < 1 || usage.System.ConnsInbound != 0 {
+		t.Fatalf("expected outbound connections only, got %+v", usage.System)
+	}
+	if got := usage.Protocols[protocol.ID("/test")]; got.StreamsInbound != 1 || got.StreamsOutbound != 0 {
+		t.Fatalf("expected a single inbound /test stream, got %+v", got)
+	}
+	if got := usage.Peers[d2.ID()]; got.ConnsOutbound < 1 || got.StreamsInbound < 1 {
+		t.Fatalf("expected outbound connections carrying the inbound stream, got %+v", got)
+	}
+
+	usage, err = c2.ResourceUsage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := usage.Protocols[protocol.ID("/test")]; got.StreamsOutbound != 1 || got.StreamsInbound != 0 {
+		t.Fatalf("expected a single outbound /test stream, got %+v", got)
+	}
+	if got := usage.Peers[d1.ID()]; got.ConnsInbound < 1 || got.ConnsOutbound != 0 {
+		t.Fatalf("expected inbound connections only, got %+v", got)
+	}
+}
@@ -0,0 +1,101 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	p2pd "github.com/libp2p/go-libp2p-daemon"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+func TestRotateIdentity(t *testing.T) {
+	d, c, closer := createDaemonClientPair(t)
+	defer closer()
+
+	if _, _, err := c.RotateIdentity("", time.Second); err == nil {
+		t.Fatal("expected rotating without a rotator to fail")
+	}
+
+	d.SetIdentityRotator(func(key crypto.PrivKey) (*p2pd.Daemon, error) {
+		return p2pd.NewEmbeddedDaemon(context.Background(), "",
+			libp2p.Identity(key), libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	})
+
+	if _, _, err := c.RotateIdentity("dsa", time.Second); err == nil {
+		t.Fatal("expected rotating to an unknown key type to fail")
+	}
+	if d.Current() != d {
+		t.Fatal("expected a failed rotation to keep the daemon")
+	}
+
+	oldID := d.ID()
+	id, addrs, err := c.RotateIdentity("ed25519", 100*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	successor := d.Current()
+	defer successor.Close()
+
+	if id == oldID || successor.ID() != id {
+		t.Fatalf("expected a new peer id, got %s; old %s, current %s", id, oldID, successor.ID())
+	}
+	if len(addrs) == 0 {
+		t.Fatal("expected the addresses of the new host")
+	}
+	pub, err := id.ExtractPublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pub.Type() != crypto.Ed25519 {
+		t.Fatalf("expected an ed25519 key, got %s", pub.Type())
+	}
+
+	// the control socket now reaches the new host
+	current, _, err := c.Identify()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if current != id {
+		t.Fatalf("expected the control socket to serve %s, got %s", id, current)
+	}
+
+	// the old daemon closes once drained; the rotator is inherited
+	deadline := time.Now().Add(5 * time.Second)
+	for len(d.Host().Network().ListenAddresses()) > 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the old host to close")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	next, _, err := c.RotateIdentity("", 100*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Current().Close()
+	if next == id || d.Current().ID() != next {
+		t.Fatalf("expected a second rotation to %s, current %s", next, d.Current().ID())
+	}
+}
+
+func TestCheckRotatableAddrs(t *testing.T) {
+	for addr, ok := range map[string]bool{
+		"/ip4/0.0.0.0/udp/0/quic":       true,
+		"/ip4/0.0.0.0/tcp/0/ws":         true,
+		"/ip4/0.0.0.0/tcp/4001":         true,
+		"/ip4/0.0.0.0/udp/4001/quic":    false,
+		"/ip6/::/tcp/4002/ws":           false,
+		"/dns4/example.com/tcp/443/wss": false,
+	} {
+		err := p2pd.CheckRotatableAddrs([]ma.Multiaddr{ma.StringCast(addr)})
+		if ok && err != nil {
+			t.Errorf("expected %s to allow rotation, got %s", addr, err)
+		}
+		if !ok && err == nil {
+			t.Errorf("expected %s to prevent rotation", addr)
+		}
+	}
+}
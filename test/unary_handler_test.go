@@ -1,17 +1,33 @@
 package test
 
 import (
+	"bytes"
 	"context"
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"math"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/libp2p/go-libp2p-core/protocol"
+	p2pd "github.com/libp2p/go-libp2p-daemon"
+	"github.com/libp2p/go-libp2p-daemon/internal/utils"
 	"github.com/libp2p/go-libp2p-daemon/p2pclient"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+
+	ggio "github.com/gogo/protobuf/io"
+	"github.com/gogo/protobuf/proto"
+	"github.com/google/uuid"
+	ma "github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 func TestConcurrentCalls(t *testing.T) {
@@ -158,77 +174,1799 @@ func TestCancellation(t *testing.T) {
 	}
 }
 
-func TestAddUnaryHandler(t *testing.T) {
-	// create a single daemon and connect two clients to it
-	dmaddr, c1maddr, dir1Closer := getEndpointsMaker(t)(t)
-	_, c2maddr, dir2Closer := getEndpointsMaker(t)(t)
+func TestUnaryHandlerConcurrencyLimit(t *testing.T) {
+	_, p1, cancel1 := createDaemonClientPair(t)
+	_, p2, cancel2 := createDaemonClientPair(t)
 
-	daemon, closeDaemon := createDaemon(t, dmaddr)
+	t.Cleanup(func() {
+		cancel1()
+		cancel2()
+	})
 
-	c1, closeClient1 := createClient(t, daemon.Listener().Multiaddr(), c1maddr)
-	c2, closeClient2 := createClient(t, daemon.Listener().Multiaddr(), c2maddr)
+	peer1ID, peer1Addrs, err := p1.Identify()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p2.Connect(peer1ID, peer1Addrs); err != nil {
+		t.Fatal(err)
+	}
 
-	defer func() {
-		closeClient1()
-		closeClient2()
+	const limit, calls = 2, 6
 
-		closeDaemon()
+	for _, block := range []bool{false, true} {
+		var mx sync.Mutex
+		var active, maxActive int
+		handler := func(ctx context.Context, data []byte) ([]byte, error) {
+			mx.Lock()
+			active++
+			if active > maxActive {
+				maxActive = active
+			}
+			mx.Unlock()
 
-		dir1Closer()
-		dir2Closer()
-	}()
+			time.Sleep(200 * time.Millisecond)
 
-	var proto protocol.ID = "sqrt"
+			mx.Lock()
+			active--
+			mx.Unlock()
+			return data, nil
+		}
 
-	if err := c1.AddUnaryHandler(proto, sqrtHandler); err != nil {
+		proto := protocol.ID(fmt.Sprintf("limited/%v", block))
+		opts := p2pclient.UnaryHandlerOptions{MaxConcurrentCalls: limit, BlockOnLimit: block}
+		if err := p1.AddUnaryHandlerWithOptions(proto, handler, opts); err != nil {
+			t.Fatal(err)
+		}
+
+		var wg sync.WaitGroup
+		var failed int
+		wg.Add(calls)
+		for i := 0; i < calls; i++ {
+			go func() {
+				defer wg.Done()
+				if _, err := p2.CallUnaryHandler(context.Background(), peer1ID, proto, []byte("hi")); err != nil {
+					mx.Lock()
+					failed++
+					mx.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+
+		if maxActive > limit {
+			t.Fatalf("block=%v: %d calls handled at once, limit is %d", block, maxActive, limit)
+		}
+		if block && failed != 0 {
+			t.Fatalf("expected all calls to be queued, %d failed", failed)
+		}
+		if !block && failed == 0 {
+			t.Fatal("expected calls past the limit to be rejected")
+		}
+	}
+}
+
+func TestUnaryMessageSizeLimit(t *testing.T) {
+	d1, p1, cancel1 := createDaemonClientPair(t)
+	defer cancel1()
+	d2, p2, cancel2 := createDaemonClientPair(t)
+	defer cancel2()
+	d1.SetMaxUnaryMessageSize(1024)
+	d2.SetMaxUnaryMessageSize(1024)
+
+	peer1ID, peer1Addrs, err := p1.Identify()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p2.Connect(peer1ID, peer1Addrs); err != nil {
 		t.Fatal(err)
 	}
 
-	if err := c2.AddUnaryHandler(proto, sqrtHandler); err == nil {
-		t.Fatal("adding second unary handler with same name should have returned error")
+	var proto protocol.ID = "echo"
+	echo := func(ctx context.Context, data []byte) ([]byte, error) { return data, nil }
+	if err := p1.AddUnaryHandler(proto, echo); err != nil {
+		t.Fatal(err)
 	}
 
-	if err := c1.Close(); err != nil {
-		t.Fatal("closing client 1 should not have returned an error", err)
+	if _, err := p2.CallUnaryHandler(context.Background(), peer1ID, proto, make([]byte, 16)); err != nil {
+		t.Fatal(err)
 	}
 
-	time.Sleep(time.Second)
+	var daemonError *p2pclient.DaemonError
+	_, err = p2.CallUnaryHandler(context.Background(), peer1ID, proto, make([]byte, 4096))
+	if !errors.As(err, &daemonError) {
+		t.Fatalf("expected an oversized message to fail with a daemon error, got %v", err)
+	}
+}
 
-	if err := c2.AddUnaryHandler(proto, sqrtHandler); err != nil {
-		t.Fatal("closing client 1 should have cleaned up the proto list", err)
+func TestChunkedUnaryPayloads(t *testing.T) {
+	defer func(size int) { p2pclient.UnaryChunkSize = size }(p2pclient.UnaryChunkSize)
+	p2pclient.UnaryChunkSize = 1 << 20
+
+	d1, p1, cancel1 := createDaemonClientPair(t)
+	defer cancel1()
+	d2, p2, cancel2 := createDaemonClientPair(t)
+	defer cancel2()
+	d1.SetUnaryChunking(1<<20, p2pd.DefaultMaxChunkedPayloadSize)
+	d2.SetUnaryChunking(1<<20, p2pd.DefaultMaxChunkedPayloadSize)
+
+	peer1ID, peer1Addrs, err := p1.Identify()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p2.Connect(peer1ID, peer1Addrs); err != nil {
+		t.Fatal(err)
+	}
+
+	var proto protocol.ID = "reverse"
+	reverse := func(ctx context.Context, data []byte) ([]byte, error) {
+		out := make([]byte, len(data))
+		for i, b := range data {
+			out[len(data)-1-i] = b
+		}
+		return out, nil
+	}
+	if err := p1.AddUnaryHandler(proto, reverse); err != nil {
+		t.Fatal(err)
+	}
+
+	// over the size of a single message on every hop
+	payload := make([]byte, 6<<20+123)
+	for i := range payload {
+		payload[i] = byte(i * 7)
+	}
+	reply, err := p2.CallUnaryHandler(context.Background(), peer1ID, proto, payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reply) != len(payload) {
+		t.Fatalf("expected a reply of %d bytes, got %d", len(payload), len(reply))
+	}
+	for i := range reply {
+		if reply[i] != payload[len(payload)-1-i] {
+			t.Fatalf("reply differs from the reversed payload at byte %d", i)
+		}
+	}
+
+	stream, err := p2.CallStreamHandler(context.Background(), peer1ID, proto, payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var responses int
+	for r := range stream {
+		if r.Err != nil {
+			t.Fatal(r.Err)
+		}
+		if len(r.Data) != len(payload) {
+			t.Fatalf("expected a stream response of %d bytes, got %d", len(payload), len(r.Data))
+		}
+		responses++
+	}
+	if responses != 1 {
+		t.Fatalf("expected a single stream response, got %d", responses)
+	}
+
+	d1.SetUnaryChunking(1<<20, 4<<20)
+	d2.SetUnaryChunking(1<<20, 4<<20)
+
+	var daemonError *p2pclient.DaemonError
+	_, err = p2.CallUnaryHandler(context.Background(), peer1ID, proto, payload)
+	if !errors.As(err, &daemonError) || daemonError.Code() != pb.DaemonError_MESSAGE_TOO_LARGE {
+		t.Fatalf("expected a payload over the maximum to fail with MESSAGE_TOO_LARGE, got %v", err)
 	}
 }
 
-func float64FromBytes(bytes []byte) float64 {
-	bits := binary.LittleEndian.Uint64(bytes)
-	float := math.Float64frombits(bits)
-	return float
+func TestChunksBeyondQueueSize(t *testing.T) {
+	defer func(size int) { p2pclient.UnaryChunkSize = size }(p2pclient.UnaryChunkSize)
+	p2pclient.UnaryChunkSize = 1 << 10
+
+	d1, p1, cancel1 := createDaemonClientPair(t)
+	defer cancel1()
+	d2, p2, cancel2 := createDaemonClientPair(t)
+	defer cancel2()
+	for _, d := range []*p2pd.Daemon{d1, d2} {
+		d.SetPersistentConnQueueSize(4)
+		d.SetUnaryChunking(1<<10, p2pd.DefaultMaxChunkedPayloadSize)
+	}
+
+	peer1ID, peer1Addrs, err := p1.Identify()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p2.Connect(peer1ID, peer1Addrs); err != nil {
+		t.Fatal(err)
+	}
+
+	var proto protocol.ID = "echo"
+	if err := p1.AddUnaryHandler(proto, func(ctx context.Context, data []byte) ([]byte, error) {
+		return data, nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// many times more chunks than the queues of both persistent connections
+	// hold, both for the request handed to the handler and for the reply
+	payload := make([]byte, 64<<10+5)
+	for i := range payload {
+		payload[i] = byte(i * 7)
+	}
+	reply, err := p2.CallUnaryHandler(context.Background(), peer1ID, proto, payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(reply, payload) {
+		t.Fatal("expected the payload to be echoed whole")
+	}
 }
 
-func float64Bytes(float float64) []byte {
-	bits := math.Float64bits(float)
-	bytes := make([]byte, 8)
-	binary.LittleEndian.PutUint64(bytes, bits)
-	return bytes
+func TestDaemonErrorCodes(t *testing.T) {
+	_, p1, cancel1 := createDaemonClientPair(t)
+	defer cancel1()
+	_, p2, cancel2 := createDaemonClientPair(t)
+	defer cancel2()
+
+	peer1ID, peer1Addrs, err := p1.Identify()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p2.Connect(peer1ID, peer1Addrs); err != nil {
+		t.Fatal(err)
+	}
+
+	var daemonError *p2pclient.DaemonError
+
+	_, err = p2.CallUnaryHandler(context.Background(), peer1ID, "unknown", make([]byte, 0))
+	if !errors.As(err, &daemonError) || daemonError.Code() != pb.DaemonError_PROTOCOL_NOT_SUPPORTED {
+		t.Fatalf("expected a call to an unknown protocol to fail with PROTOCOL_NOT_SUPPORTED, got %v", err)
+	}
+
+	_, err = p2.CallUnaryHandler(context.Background(), randPeerID(t), "unknown", make([]byte, 0))
+	if !errors.As(err, &daemonError) || daemonError.Code() != pb.DaemonError_NO_ADDRESSES {
+		t.Fatalf("expected a call to a peer without addresses to fail with NO_ADDRESSES, got %v", err)
+	}
 }
 
-func slowHandler(ctx context.Context, data []byte) ([]byte, error) {
-	time.Sleep(time.Second * 3)
-	return nil, nil
+func TestDuplicateCallID(t *testing.T) {
+	_, p1, cancel1 := createDaemonClientPair(t)
+	defer cancel1()
+	d2, p2, cancel2 := createDaemonClientPair(t)
+	defer cancel2()
+
+	peer1ID, peer1Addrs, err := p1.Identify()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p2.Connect(peer1ID, peer1Addrs); err != nil {
+		t.Fatal(err)
+	}
+
+	var proto protocol.ID = "blocking"
+	release := make(chan struct{})
+	defer close(release)
+	err = p1.AddUnaryHandler(proto, func(context.Context, []byte) ([]byte, error) {
+		<-release
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conn, err := manet.Dial(d2.Listener().Multiaddr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	w := ggio.NewDelimitedWriter(conn)
+	r := ggio.NewDelimitedReader(conn, network.MessageSizeMax)
+	if err := w.WriteMsg(&pb.Request{Type: pb.Request_PERSISTENT_CONN_UPGRADE.Enum()}); err != nil {
+		t.Fatal(err)
+	}
+	var res pb.Response
+	if err := r.ReadMsg(&res); err != nil {
+		t.Fatal(err)
+	}
+
+	callID := uuid.New()
+	req := &pb.PersistentConnectionRequest{
+		CallId: callID[:],
+		Message: &pb.PersistentConnectionRequest_CallUnary{
+			CallUnary: &pb.CallUnaryRequest{
+				Peer:  []byte(peer1ID),
+				Proto: (*string)(&proto),
+				Data:  []byte("hi"),
+			},
+		},
+	}
+	if err := w.WriteMsg(req); err != nil {
+		t.Fatal(err)
+	}
+
+	// the first call must be in flight before reusing its id
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		stats, err := p2.GetPersistentConnStats()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stats.ActiveCalls == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected 1 active call, got %d", stats.ActiveCalls)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if err := w.WriteMsg(req); err != nil {
+		t.Fatal(err)
+	}
+
+	var resp pb.PersistentConnectionResponse
+	if err := r.ReadMsg(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetDaemonError().GetCode() != pb.DaemonError_DUPLICATE_CALL_ID {
+		t.Fatalf("expected the second call to fail with DUPLICATE_CALL_ID, got %v", resp.Message)
+	}
+
+	// the first call is still tracked
+	if stats, err := p2.GetPersistentConnStats(); err != nil {
+		t.Fatal(err)
+	} else if stats.ActiveCalls != 1 {
+		t.Fatalf("expected the first call to remain active, got %d active calls", stats.ActiveCalls)
+	}
 }
 
-func sqrtHandler(ctx context.Context, data []byte) ([]byte, error) {
-	f := float64FromBytes(data)
-	if f < 0 {
-		return nil, fmt.Errorf("can't extract square root from negative")
+func TestUnaryCallCancelledAfterFollowUp(t *testing.T) {
+	d1, p1, cancel1 := createDaemonClientPair(t)
+	defer cancel1()
+
+	var proto protocol.ID = "slow"
+	handling := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	err := p1.AddUnaryHandler(proto, func(context.Context, []byte) ([]byte, error) {
+		close(handling)
+		<-release
+		return nil, errors.New("released")
+	})
+	if err != nil {
+		t.Fatal(err)
 	}
 
-	result := math.Sqrt(f)
-	return float64Bytes(result), nil
+	h, err := libp2p.New(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := h.Connect(ctx, peer.AddrInfo{ID: d1.ID(), Addrs: d1.Addrs()}); err != nil {
+		t.Fatal(err)
+	}
+	s, err := h.NewStream(ctx, d1.ID(), proto)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	callID := uuid.New()
+	w := ggio.NewDelimitedWriter(s)
+	req := &pb.PersistentConnectionRequest{
+		CallId: callID[:],
+		Message: &pb.PersistentConnectionRequest_CallUnary{
+			CallUnary: &pb.CallUnaryRequest{
+				Peer:  []byte(d1.ID()),
+				Proto: (*string)(&proto),
+				Data:  []byte("hi"),
+			},
+		},
+	}
+	if err := w.WriteMsg(req); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-handling:
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for the call to be handled")
+	}
+
+	// data sent by the caller must not stop the daemon from noticing that
+	// the stream is reset afterwards
+	if err := w.WriteMsg(req); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if stats, err := p1.GetPersistentConnStats(); err != nil {
+		t.Fatal(err)
+	} else if stats.PendingResponses != 1 {
+		t.Fatalf("expected the call to await its response, got %d pending responses", stats.PendingResponses)
+	}
+	s.Reset()
+
+	// the daemon stops waiting for the response once it notices the reset
+	for {
+		stats, err := p1.GetPersistentConnStats()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stats.PendingResponses == 0 {
+			break
+		}
+		if ctx.Err() != nil {
+			t.Fatal("expected the call to be cancelled once the caller reset the stream")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
 }
 
-// Je reprends mon bien où je le trouve
-// https://stackoverflow.com/questions/47969385/go-float-comparison
-func almostEqual(a, b float64) bool {
-	return math.Abs(a-b) <= 1e-9
+func TestResponseAfterCallerGone(t *testing.T) {
+	d1, _, cancel1 := createDaemonClientPair(t)
+	defer cancel1()
+
+	conn, err := manet.Dial(d1.Listener().Multiaddr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	cw := ggio.NewDelimitedWriter(conn)
+	cr := ggio.NewDelimitedReader(conn, network.MessageSizeMax)
+	if err := cw.WriteMsg(&pb.Request{Type: pb.Request_PERSISTENT_CONN_UPGRADE.Enum()}); err != nil {
+		t.Fatal(err)
+	}
+	var res pb.Response
+	if err := cr.ReadMsg(&res); err != nil {
+		t.Fatal(err)
+	}
+
+	// readUntil skips messages until one about callID matches
+	readUntil := func(callID uuid.UUID, match func(*pb.PersistentConnectionResponse) bool) *pb.PersistentConnectionResponse {
+		for {
+			var resp pb.PersistentConnectionResponse
+			if err := cr.ReadMsg(&resp); err != nil {
+				t.Fatal(err)
+			}
+			if id, err := uuid.FromBytes(resp.CallId); err == nil && id == callID && match(&resp) {
+				return &resp
+			}
+		}
+	}
+
+	var proto protocol.ID = "unanswered"
+	addID := uuid.New()
+	err = cw.WriteMsg(&pb.PersistentConnectionRequest{
+		CallId: addID[:],
+		Message: &pb.PersistentConnectionRequest_AddUnaryHandler{
+			AddUnaryHandler: &pb.AddUnaryHandlerRequest{Proto: (*string)(&proto)},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp := readUntil(addID, func(*pb.PersistentConnectionResponse) bool { return true }); resp.GetDaemonError() != nil {
+		t.Fatalf("failed to add handler: %s", resp.GetDaemonError().GetMessage())
+	}
+
+	h, err := libp2p.New(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := h.Connect(ctx, peer.AddrInfo{ID: d1.ID(), Addrs: d1.Addrs()}); err != nil {
+		t.Fatal(err)
+	}
+	s, err := h.NewStream(ctx, d1.ID(), proto)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	callID := uuid.New()
+	err = ggio.NewDelimitedWriter(s).WriteMsg(&pb.PersistentConnectionRequest{
+		CallId: callID[:],
+		Message: &pb.PersistentConnectionRequest_CallUnary{
+			CallUnary: &pb.CallUnaryRequest{
+				Peer:  []byte(d1.ID()),
+				Proto: (*string)(&proto),
+				Data:  []byte("hi"),
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	readUntil(callID, func(resp *pb.PersistentConnectionResponse) bool { return resp.GetRequestHandling() != nil })
+
+	// the caller goes away before the response arrives
+	s.Reset()
+	readUntil(callID, func(resp *pb.PersistentConnectionResponse) bool { return resp.GetCancel() != nil })
+
+	err = cw.WriteMsg(&pb.PersistentConnectionRequest{
+		CallId: callID[:],
+		Message: &pb.PersistentConnectionRequest_UnaryResponse{
+			UnaryResponse: &pb.CallUnaryResponse{
+				Result: &pb.CallUnaryResponse_Response{Response: []byte("late")},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := readUntil(callID, func(resp *pb.PersistentConnectionResponse) bool { return resp.GetDaemonError() != nil })
+	if code := resp.GetDaemonError().GetCode(); code != pb.DaemonError_CALL_NOT_AWAITED {
+		t.Fatalf("expected the response to fail with CALL_NOT_AWAITED, got %s", code)
+	}
+
+	// the connection still serves requests
+	statsID := uuid.New()
+	err = cw.WriteMsg(&pb.PersistentConnectionRequest{
+		CallId:  statsID[:],
+		Message: &pb.PersistentConnectionRequest_GetStats{GetStats: &pb.GetStatsRequest{}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	readUntil(statsID, func(resp *pb.PersistentConnectionResponse) bool { return resp.GetStats() != nil })
+}
+
+func TestCallsOwnedByAnotherConnection(t *testing.T) {
+	d1, _, cancel1 := createDaemonClientPair(t)
+	defer cancel1()
+
+	// open returns the writer of a new persistent connection to d1, and a
+	// function skipping its messages until one about callID matches
+	open := func() (ggio.Writer, func(uuid.UUID, func(*pb.PersistentConnectionResponse) bool) *pb.PersistentConnectionResponse) {
+		conn, err := manet.Dial(d1.Listener().Multiaddr())
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { conn.Close() })
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+		cw := ggio.NewDelimitedWriter(conn)
+		cr := ggio.NewDelimitedReader(conn, network.MessageSizeMax)
+		if err := cw.WriteMsg(&pb.Request{Type: pb.Request_PERSISTENT_CONN_UPGRADE.Enum()}); err != nil {
+			t.Fatal(err)
+		}
+		var res pb.Response
+		if err := cr.ReadMsg(&res); err != nil {
+			t.Fatal(err)
+		}
+
+		return cw, func(callID uuid.UUID, match func(*pb.PersistentConnectionResponse) bool) *pb.PersistentConnectionResponse {
+			for {
+				var resp pb.PersistentConnectionResponse
+				if err := cr.ReadMsg(&resp); err != nil {
+					t.Fatal(err)
+				}
+				if id, err := uuid.FromBytes(resp.CallId); err == nil && id == callID && match(&resp) {
+					return &resp
+				}
+			}
+		}
+	}
+	isError := func(resp *pb.PersistentConnectionResponse) bool { return resp.GetDaemonError() != nil }
+
+	owner, ownerReadUntil := open()
+	other, otherReadUntil := open()
+
+	var proto protocol.ID = "owned"
+	addID := uuid.New()
+	err := owner.WriteMsg(&pb.PersistentConnectionRequest{
+		CallId: addID[:],
+		Message: &pb.PersistentConnectionRequest_AddUnaryHandler{
+			AddUnaryHandler: &pb.AddUnaryHandlerRequest{Proto: (*string)(&proto)},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp := ownerReadUntil(addID, func(*pb.PersistentConnectionResponse) bool { return true }); resp.GetDaemonError() != nil {
+		t.Fatalf("failed to add handler: %s", resp.GetDaemonError().GetMessage())
+	}
+
+	h, err := libp2p.New(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+	h.SetStreamHandler("silent", func(s network.Stream) {})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := h.Connect(ctx, peer.AddrInfo{ID: d1.ID(), Addrs: d1.Addrs()}); err != nil {
+		t.Fatal(err)
+	}
+	s, err := h.NewStream(ctx, d1.ID(), proto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Reset()
+
+	callID := uuid.New()
+	err = ggio.NewDelimitedWriter(s).WriteMsg(&pb.PersistentConnectionRequest{
+		CallId: callID[:],
+		Message: &pb.PersistentConnectionRequest_CallUnary{
+			CallUnary: &pb.CallUnaryRequest{
+				Peer:  []byte(d1.ID()),
+				Proto: (*string)(&proto),
+				Data:  []byte("hi"),
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ownerReadUntil(callID, func(resp *pb.PersistentConnectionResponse) bool { return resp.GetRequestHandling() != nil })
+
+	respond := func(w ggio.Writer, data string) {
+		err := w.WriteMsg(&pb.PersistentConnectionRequest{
+			CallId: callID[:],
+			Message: &pb.PersistentConnectionRequest_UnaryResponse{
+				UnaryResponse: &pb.CallUnaryResponse{
+					Result: &pb.CallUnaryResponse_Response{Response: []byte(data)},
+				},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	respond(other, "stolen")
+	resp := otherReadUntil(callID, isError)
+	if code := resp.GetDaemonError().GetCode(); code != pb.DaemonError_CALL_NOT_OWNED {
+		t.Fatalf("expected the response of another connection to fail with CALL_NOT_OWNED, got %s", code)
+	}
+
+	respond(owner, "mine")
+	var reply pb.PersistentConnectionRequest
+	if err := ggio.NewDelimitedReader(s, network.MessageSizeMax).ReadMsg(&reply); err != nil {
+		t.Fatal(err)
+	}
+	if data := string(reply.GetUnaryResponse().GetResponse()); data != "mine" {
+		t.Fatalf("expected the caller to get the response of the handler's owner, got %q", data)
+	}
+
+	// a call issued by other can't be cancelled by owner
+	callID = uuid.New()
+	silent := "silent"
+	err = other.WriteMsg(&pb.PersistentConnectionRequest{
+		CallId: callID[:],
+		Message: &pb.PersistentConnectionRequest_CallUnary{
+			CallUnary: &pb.CallUnaryRequest{
+				Peer:  []byte(h.ID()),
+				Proto: &silent,
+				Data:  []byte("hi"),
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cancelCall := func(w ggio.Writer) {
+		err := w.WriteMsg(&pb.PersistentConnectionRequest{
+			CallId:  callID[:],
+			Message: &pb.PersistentConnectionRequest_Cancel{Cancel: &pb.Cancel{}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	activeCalls := func() int64 {
+		statsID := uuid.New()
+		err := owner.WriteMsg(&pb.PersistentConnectionRequest{
+			CallId:  statsID[:],
+			Message: &pb.PersistentConnectionRequest_GetStats{GetStats: &pb.GetStatsRequest{}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp := ownerReadUntil(statsID, func(resp *pb.PersistentConnectionResponse) bool { return resp.GetStats() != nil })
+		return resp.GetStats().GetActiveCalls()
+	}
+
+	// the call must be in flight before cancelling it
+	deadline := time.Now().Add(2 * time.Second)
+	for activeCalls() != 1 {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the call to be in flight")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	cancelCall(owner)
+	resp = ownerReadUntil(callID, isError)
+	if code := resp.GetDaemonError().GetCode(); code != pb.DaemonError_CALL_NOT_OWNED {
+		t.Fatalf("expected cancelling the call of another connection to fail with CALL_NOT_OWNED, got %s", code)
+	}
+	if n := activeCalls(); n != 1 {
+		t.Fatalf("expected the call to go on, got %d active calls", n)
+	}
+
+	cancelCall(other)
+	otherReadUntil(callID, func(resp *pb.PersistentConnectionResponse) bool {
+		return resp.GetCancel() != nil || resp.GetDaemonError().GetCode() == pb.DaemonError_CANCELLED
+	})
+}
+
+func TestCloseNotifierKeepsData(t *testing.T) {
+	r, w := io.Pipe()
+	n := utils.NewCloseNotifier(r, 4)
+	defer n.Close()
+
+	go func() {
+		w.Write([]byte("request"))
+		w.Write([]byte("follow-up"))
+		w.CloseWithError(errors.New("reset"))
+	}()
+
+	// the notifier buffers at most 4 bytes, so it can't fail before most of
+	// the data is read
+	select {
+	case <-n.Failed():
+		t.Fatal("notifier failed before the data was read")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(n, int64(len("requestfollow-up"))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "requestfollow-up" {
+		t.Fatalf("expected all the data to be read, got %q", data)
+	}
+
+	select {
+	case <-n.Failed():
+	case <-time.After(time.Second):
+		t.Fatal("expected the notifier to fail once the pipe is closed")
+	}
+	if _, err := n.Read(make([]byte, 1)); err == nil || err.Error() != "reset" {
+		t.Fatalf("expected the pipe error, got %v", err)
+	}
+}
+
+// blockingWriter hands written messages over on writing, then blocks until
+// release is closed.
+type blockingWriter struct {
+	writing chan proto.Message
+	release chan struct{}
+}
+
+func (bw *blockingWriter) WriteMsg(msg proto.Message) error {
+	bw.writing <- msg
+	<-bw.release
+	return nil
+}
+
+func (bw *blockingWriter) Close() error {
+	return nil
+}
+
+func TestQueuedWriterRejectsWhenFull(t *testing.T) {
+	bw := &blockingWriter{writing: make(chan proto.Message), release: make(chan struct{})}
+	w := utils.NewQueuedWriter(bw, 2, prometheus.NewGauge(prometheus.GaugeOpts{Name: "queued"}))
+	defer w.Close()
+
+	msgs := make([]*pb.PersistentConnectionResponse, 4)
+	for i := range msgs {
+		callID := uuid.New()
+		msgs[i] = &pb.PersistentConnectionResponse{CallId: callID[:]}
+	}
+
+	if err := w.WriteMsg(msgs[0]); err != nil {
+		t.Fatal(err)
+	}
+	// the first message is being written, so two more fit in the queue
+	if msg := <-bw.writing; msg != msgs[0] {
+		t.Fatal("expected the first message to be written first")
+	}
+	for _, msg := range msgs[1:3] {
+		if err := w.WriteMsg(msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.WriteMsg(msgs[3]); err != utils.ErrQueueFull {
+		t.Fatalf("expected a write beyond the queue size to fail with ErrQueueFull, got %v", err)
+	}
+
+	close(bw.release)
+	for _, expected := range msgs[1:3] {
+		select {
+		case msg := <-bw.writing:
+			if msg != expected {
+				t.Fatal("expected messages to be written in order")
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for the queued messages to be written")
+		}
+	}
+}
+
+// failingWriter fails every write with err.
+type failingWriter struct {
+	err error
+}
+
+func (fw failingWriter) WriteMsg(msg proto.Message) error {
+	return fw.err
+}
+
+func (fw failingWriter) Close() error {
+	return nil
+}
+
+func TestQueuedWriterFails(t *testing.T) {
+	writeErr := errors.New("broken pipe")
+	w := utils.NewQueuedWriter(failingWriter{writeErr}, 2, prometheus.NewGauge(prometheus.GaugeOpts{Name: "queued"}))
+	defer w.Close()
+
+	if err := w.WriteMsg(&pb.PersistentConnectionResponse{}); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-w.Failed():
+	case <-time.After(time.Second):
+		t.Fatal("expected the writer to fail once a write failed")
+	}
+	if err := w.WriteMsg(&pb.PersistentConnectionResponse{}); err != writeErr {
+		t.Fatalf("expected further writes to fail with the write error, got %v", err)
+	}
+	if err := w.Err(); err != writeErr {
+		t.Fatalf("expected the write error, got %v", err)
+	}
+}
+
+func TestQueuedWriterFlush(t *testing.T) {
+	bw := &blockingWriter{writing: make(chan proto.Message, 1), release: make(chan struct{})}
+	w := utils.NewQueuedWriter(bw, 2, prometheus.NewGauge(prometheus.GaugeOpts{Name: "queued"}))
+	defer w.Close()
+
+	if err := w.WriteMsg(&pb.PersistentConnectionResponse{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Flush(100 * time.Millisecond); err == nil {
+		t.Fatal("expected flushing to time out while the message can't be written")
+	}
+
+	close(bw.release)
+	if err := w.Flush(time.Second); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReplaceUnaryHandler(t *testing.T) {
+	d1, oldClient, cancel1 := createDaemonClientPair(t)
+	defer cancel1()
+	_, p2, cancel2 := createDaemonClientPair(t)
+	defer cancel2()
+
+	_, cmaddr, dirCloser := getEndpointsMaker(t)(t)
+	defer dirCloser()
+	newClient, closeNewClient := createClient(t, d1.Listener().Multiaddr(), cmaddr)
+	defer closeNewClient()
+
+	if err := p2.Connect(d1.ID(), d1.Addrs()); err != nil {
+		t.Fatal(err)
+	}
+
+	var proto protocol.ID = "whoami"
+	reply := func(name string) p2pclient.UnaryHandlerFunc {
+		return func(context.Context, []byte) ([]byte, error) { return []byte(name), nil }
+	}
+
+	if err := oldClient.AddUnaryHandler(proto, reply("old")); err != nil {
+		t.Fatal(err)
+	}
+	if err := newClient.AddUnaryHandler(proto, reply("new")); err == nil {
+		t.Fatal("expected registering a taken protocol without replacing to fail")
+	}
+	if err := newClient.AddUnaryHandlerWithOptions(proto, reply("new"), p2pclient.UnaryHandlerOptions{Replace: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	call := func() string {
+		res, err := p2.CallUnaryHandler(context.Background(), d1.ID(), proto, make([]byte, 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(res)
+	}
+	if got := call(); got != "new" {
+		t.Fatalf("expected the replacing handler to answer, got %s", got)
+	}
+
+	// the handler must survive the previous owner disconnecting
+	conns := metricValue(t, "p2pd_persistent_connections", nil)
+	oldClient.Close()
+	for metricValue(t, "p2pd_persistent_connections", nil) >= conns {
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	if got := call(); got != "new" {
+		t.Fatalf("expected the replacing handler to answer after the old client left, got %s", got)
+	}
+}
+
+// metricValue returns the value of a metric of the default registry, or the
+// sample count for histograms, summed over the series matching labels.
+func TestRemoveUnaryHandler(t *testing.T) {
+	d1, owner, cancel1 := createDaemonClientPair(t)
+	defer cancel1()
+	_, p2, cancel2 := createDaemonClientPair(t)
+	defer cancel2()
+
+	_, cmaddr, dirCloser := getEndpointsMaker(t)(t)
+	defer dirCloser()
+	other, closeOther := createClient(t, d1.Listener().Multiaddr(), cmaddr)
+	defer closeOther()
+
+	if err := p2.Connect(d1.ID(), d1.Addrs()); err != nil {
+		t.Fatal(err)
+	}
+
+	var removed, kept protocol.ID = "removed", "kept"
+	for _, proto := range []protocol.ID{removed, kept} {
+		if err := owner.AddUnaryHandler(proto, sqrtHandler); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// only the connection that added a handler can remove it
+	if err := other.RemoveUnaryHandler(removed); err == nil {
+		t.Fatal("expected removing the handler of another connection to fail")
+	}
+
+	if err := owner.RemoveUnaryHandler(removed); err != nil {
+		t.Fatal(err)
+	}
+	if err := owner.RemoveUnaryHandler(removed); err == nil {
+		t.Fatal("expected removing a removed handler to fail")
+	}
+
+	var daemonError *p2pclient.DaemonError
+	_, err := p2.CallUnaryHandler(context.Background(), d1.ID(), removed, float64Bytes(64))
+	if !errors.As(err, &daemonError) || daemonError.Code() != pb.DaemonError_PROTOCOL_NOT_SUPPORTED {
+		t.Fatalf("expected a call to the removed handler to fail with PROTOCOL_NOT_SUPPORTED, got %v", err)
+	}
+
+	// the connection and its other handlers are left alone
+	res, err := p2.CallUnaryHandler(context.Background(), d1.ID(), kept, float64Bytes(64))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result := float64FromBytes(res); !almostEqual(result, 8) {
+		t.Fatalf("expected 8, got %v", result)
+	}
+
+	// the protocol is free to be added again, by any connection
+	if err := other.AddUnaryHandler(removed, sqrtHandler); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func metricValue(t *testing.T, name string, labels map[string]string) float64 {
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var value float64
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+	metrics:
+		for _, m := range family.GetMetric() {
+			for _, l := range m.GetLabel() {
+				if v, ok := labels[l.GetName()]; ok && v != l.GetValue() {
+					continue metrics
+				}
+			}
+			switch {
+			case m.Counter != nil:
+				value += m.GetCounter().GetValue()
+			case m.Gauge != nil:
+				value += m.GetGauge().GetValue()
+			case m.Histogram != nil:
+				value += float64(m.GetHistogram().GetSampleCount())
+			}
+		}
+	}
+	return value
+}
+
+func TestUnaryCallMetrics(t *testing.T) {
+	_, p1, cancel1 := createDaemonClientPair(t)
+	defer cancel1()
+	_, p2, cancel2 := createDaemonClientPair(t)
+	defer cancel2()
+
+	peer1ID, peer1Addrs, err := p1.Identify()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p2.Connect(peer1ID, peer1Addrs); err != nil {
+		t.Fatal(err)
+	}
+
+	var proto protocol.ID = "sqrt"
+	if err := p1.AddUnaryHandler(proto, sqrtHandler); err != nil {
+		t.Fatal(err)
+	}
+
+	if metricValue(t, "p2pd_persistent_connections", nil) < 1 {
+		t.Fatal("expected the persistent connection to be counted")
+	}
+
+	calls := metricValue(t, "p2pd_unary_calls_total", nil)
+	succeeded := metricValue(t, "p2pd_unary_call_duration_seconds", map[string]string{"result": "success"})
+	failed := metricValue(t, "p2pd_unary_call_duration_seconds", map[string]string{"result": "error"})
+
+	if _, err := p2.CallUnaryHandler(context.Background(), peer1ID, proto, float64Bytes(64)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p2.CallUnaryHandler(context.Background(), peer1ID, proto, float64Bytes(-64)); err == nil {
+		t.Fatal("expected the handler to fail")
+	}
+
+	if v := metricValue(t, "p2pd_unary_calls_total", nil); v != calls+2 {
+		t.Fatalf("expected 2 more calls, got %v", v-calls)
+	}
+	if v := metricValue(t, "p2pd_unary_call_duration_seconds", map[string]string{"result": "success"}); v != succeeded+1 {
+		t.Fatalf("expected 1 more successful call, got %v", v-succeeded)
+	}
+	if v := metricValue(t, "p2pd_unary_call_duration_seconds", map[string]string{"result": "error"}); v != failed+1 {
+		t.Fatalf("expected 1 more failed call, got %v", v-failed)
+	}
+}
+
+func TestUnaryCallTimeout(t *testing.T) {
+	_, p1, cancel1 := createDaemonClientPair(t)
+
+	t.Cleanup(cancel1)
+
+	h, err := libp2p.New(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	var proto protocol.ID = "hang"
+	streamErrors := make(chan error, 1)
+	received := make(chan struct{})
+	h.SetStreamHandler(proto, func(s network.Stream) {
+		req := &pb.PersistentConnectionRequest{}
+		if err := ggio.NewDelimitedReader(s, network.MessageSizeMax).ReadMsg(req); err != nil {
+			streamErrors <- err
+			return
+		}
+		close(received)
+
+		// never reply, wait for the caller to give up instead
+		_, err := s.Read(make([]byte, 1))
+		streamErrors <- err
+	})
+
+	if err := p1.Connect(h.ID(), h.Addrs()); err != nil {
+		t.Fatal(err)
+	}
+
+	// leave enough time for the request to reach the remote even on a busy
+	// machine, the remote never replies anyway
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if _, err := p1.CallUnaryHandler(ctx, h.ID(), proto, []byte("hi")); err == nil {
+		t.Fatal("call is expected to time out but finished successfully")
+	}
+
+	select {
+	case <-received:
+	default:
+		t.Fatal("the request did not reach the remote before the deadline")
+	}
+
+	select {
+	case err := <-streamErrors:
+		if err == nil || err == io.EOF {
+			t.Fatalf("expected remote stream to be reset, got: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("remote stream was not reset after the call timed out")
+	}
+}
+
+func TestAddUnaryHandler(t *testing.T) {
+	// create a single daemon and connect two clients to it
+	dmaddr, c1maddr, dir1Closer := getEndpointsMaker(t)(t)
+	_, c2maddr, dir2Closer := getEndpointsMaker(t)(t)
+
+	daemon, closeDaemon := createDaemon(t, dmaddr)
+
+	c1, closeClient1 := createClient(t, daemon.Listener().Multiaddr(), c1maddr)
+	c2, closeClient2 := createClient(t, daemon.Listener().Multiaddr(), c2maddr)
+
+	defer func() {
+		closeClient1()
+		closeClient2()
+
+		closeDaemon()
+
+		dir1Closer()
+		dir2Closer()
+	}()
+
+	var proto protocol.ID = "sqrt"
+
+	if err := c1.AddUnaryHandler(proto, sqrtHandler); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c2.AddUnaryHandler(proto, sqrtHandler); err == nil {
+		t.Fatal("adding second unary handler with same name should have returned error")
+	}
+
+	if err := c1.Close(); err != nil {
+		t.Fatal("closing client 1 should not have returned an error", err)
+	}
+
+	time.Sleep(time.Second)
+
+	if err := c2.AddUnaryHandler(proto, sqrtHandler); err != nil {
+		t.Fatal("closing client 1 should have cleaned up the proto list", err)
+	}
+}
+
+func TestStreamCalls(t *testing.T) {
+	_, p1, cancel1 := createDaemonClientPair(t)
+	_, p2, cancel2 := createDaemonClientPair(t)
+
+	t.Cleanup(func() {
+		cancel1()
+		cancel2()
+	})
+
+	peer1ID, peer1Addrs, err := p1.Identify()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p2.Connect(peer1ID, peer1Addrs); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run(
+		"test unary handler yields a single response",
+		func(t *testing.T) {
+			var proto protocol.ID = "sqrt"
+			if err := p1.AddUnaryHandler(proto, sqrtHandler); err != nil {
+				t.Fatal(err)
+			}
+
+			responses, err := p2.CallStreamHandler(context.Background(), peer1ID, proto, float64Bytes(64))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var results []float64
+			for resp := range responses {
+				if resp.Err != nil {
+					t.Fatal(resp.Err)
+				}
+				results = append(results, float64FromBytes(resp.Data))
+			}
+
+			if len(results) != 1 || !almostEqual(results[0], math.Sqrt(64)) {
+				t.Fatalf("unexpected stream results: %v", results)
+			}
+		},
+	)
+
+	t.Run(
+		"test remote with several responses",
+		func(t *testing.T) {
+			var proto protocol.ID = "count"
+			const count = 5
+
+			h, err := libp2p.New(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer h.Close()
+
+			h.SetStreamHandler(proto, func(s network.Stream) {
+				defer s.Close()
+
+				req := &pb.PersistentConnectionRequest{}
+				if err := ggio.NewDelimitedReader(s, network.MessageSizeMax).ReadMsg(req); err != nil {
+					s.Reset()
+					return
+				}
+
+				w := ggio.NewDelimitedWriter(s)
+				for i := 0; i < count; i++ {
+					w.WriteMsg(&pb.PersistentConnectionRequest{
+						CallId: req.CallId,
+						Message: &pb.PersistentConnectionRequest_UnaryResponse{
+							UnaryResponse: &pb.CallUnaryResponse{
+								Result: &pb.CallUnaryResponse_Response{Response: float64Bytes(float64(i))},
+							},
+						},
+					})
+				}
+			})
+
+			if err := p2.Connect(h.ID(), h.Addrs()); err != nil {
+				t.Fatal(err)
+			}
+
+			responses, err := p2.CallStreamHandler(context.Background(), h.ID(), proto, []byte("count"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			i := 0
+			for resp := range responses {
+				if resp.Err != nil {
+					t.Fatal(resp.Err)
+				}
+				if result := float64FromBytes(resp.Data); !almostEqual(result, float64(i)) {
+					t.Fatalf("response %d arrived out of order: %f", i, result)
+				}
+				i++
+			}
+
+			if i != count {
+				t.Fatalf("expected %d responses, got %d", count, i)
+			}
+		},
+	)
+}
+
+func TestPersistentConnStats(t *testing.T) {
+	_, c, closer := createDaemonClientPair(t)
+	defer closer()
+
+	var proto protocol.ID = "sqrt"
+	if err := c.AddUnaryHandler(proto, sqrtHandler); err != nil {
+		t.Fatal(err)
+	}
+
+	stats, err := c.GetPersistentConnStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(stats.UnaryHandlers) != 1 || !stats.UnaryHandlers[proto] {
+		t.Fatalf("expected handler for %s to be registered, got %v", proto, stats.UnaryHandlers)
+	}
+	if stats.ActiveCalls != 0 || stats.PendingResponses != 0 {
+		t.Fatalf("expected no calls in flight, got %d active and %d pending", stats.ActiveCalls, stats.PendingResponses)
+	}
+}
+
+func TestCancelAllCalls(t *testing.T) {
+	_, p1, cancel1 := createDaemonClientPair(t)
+	d2, p2, cancel2 := createDaemonClientPair(t)
+	_, cmaddr, dirCloser := getEndpointsMaker(t)(t)
+	p3, closeP3 := createClient(t, d2.Listener().Multiaddr(), cmaddr)
+
+	t.Cleanup(func() {
+		cancel1()
+		cancel2()
+		closeP3()
+		dirCloser()
+	})
+
+	peer1ID, peer1Addrs, err := p1.Identify()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p2.Connect(peer1ID, peer1Addrs); err != nil {
+		t.Fatal(err)
+	}
+
+	var proto protocol.ID = "slow"
+	if err := p1.AddUnaryHandler(proto, slowHandler); err != nil {
+		t.Fatal(err)
+	}
+
+	call := func(c *p2pclient.Client) <-chan error {
+		done := make(chan error, 1)
+		go func() {
+			_, err := c.CallUnaryHandler(context.Background(), peer1ID, proto, []byte("hi"))
+			done <- err
+		}()
+		return done
+	}
+	p2Done, p3Done := call(p2), call(p3)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		stats, err := p2.GetPersistentConnStats()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stats.ActiveCalls == 2 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected 2 active calls, got %d", stats.ActiveCalls)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	cancelled, err := p2.CancelAllCalls()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cancelled != 1 {
+		t.Fatalf("expected 1 call to be cancelled, got %d", cancelled)
+	}
+
+	select {
+	case err := <-p2Done:
+		if err == nil {
+			t.Fatal("call is expected to be cancelled but finished successfully")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("call wasn't cancelled")
+	}
+
+	select {
+	case err := <-p3Done:
+		if err != nil {
+			t.Fatalf("call of another client is expected to succeed, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("call of another client didn't finish")
+	}
+}
+
+func TestQueuedWriterWriteMsgWait(t *testing.T) {
+	bw := &blockingWriter{writing: make(chan proto.Message, 3), release: make(chan struct{})}
+	w := utils.NewQueuedWriter(bw, 1, prometheus.NewGauge(prometheus.GaugeOpts{Name: "queued"}))
+	defer w.Close()
+
+	msgs := make([]*pb.PersistentConnectionResponse, 3)
+	for i := range msgs {
+		callID := uuid.New()
+		msgs[i] = &pb.PersistentConnectionResponse{CallId: callID[:]}
+	}
+
+	if err := w.WriteMsg(msgs[0]); err != nil {
+		t.Fatal(err)
+	}
+	// the first message is being written, so one more fits in the queue
+	for len(bw.writing) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	if err := w.WriteMsg(msgs[1]); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WriteMsgWait(msgs[2], 100*time.Millisecond); err != utils.ErrQueueFull {
+		t.Fatalf("expected waiting for room to time out with ErrQueueFull, got %v", err)
+	}
+
+	written := make(chan error, 1)
+	go func() { written <- w.WriteMsgWait(msgs[2], 5*time.Second) }()
+	time.Sleep(50 * time.Millisecond)
+	close(bw.release)
+
+	if err := <-written; err != nil {
+		t.Fatalf("expected the write to wait for room, got %v", err)
+	}
+	for _, expected := range msgs {
+		select {
+		case msg := <-bw.writing:
+			if msg != expected {
+				t.Fatal("expected messages to be written in order")
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for the messages to be written")
+		}
+	}
+}
+
+func TestCallsReleasedWithConnection(t *testing.T) {
+	d1, p1, cancel1 := createDaemonClientPair(t)
+	d2, p2, cancel2 := createDaemonClientPair(t)
+	_, cmaddr1, dirCloser1 := getEndpointsMaker(t)(t)
+	o1, closeO1 := createClient(t, d1.Listener().Multiaddr(), cmaddr1)
+	_, cmaddr2, dirCloser2 := getEndpointsMaker(t)(t)
+	o2, closeO2 := createClient(t, d2.Listener().Multiaddr(), cmaddr2)
+
+	unblock := make(chan struct{})
+	t.Cleanup(func() {
+		close(unblock)
+		cancel1()
+		cancel2()
+		closeO1()
+		closeO2()
+		dirCloser1()
+		dirCloser2()
+	})
+
+	peer1ID, peer1Addrs, err := p1.Identify()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p2.Connect(peer1ID, peer1Addrs); err != nil {
+		t.Fatal(err)
+	}
+
+	var proto protocol.ID = "blocking"
+	blockingHandler := func(ctx context.Context, data []byte) ([]byte, error) {
+		<-unblock
+		return nil, nil
+	}
+	if err := p1.AddUnaryHandler(proto, blockingHandler); err != nil {
+		t.Fatal(err)
+	}
+
+	// waitForStats polls c until cond holds for its daemon's stats
+	waitForStats := func(c *p2pclient.Client, cond func(*p2pclient.PersistentConnStats) bool) {
+		deadline := time.Now().Add(2 * time.Second)
+		for {
+			stats, err := c.GetPersistentConnStats()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cond(stats) {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("unexpected stats: %d active calls, %d pending responses", stats.ActiveCalls, stats.PendingResponses)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	call := func(c *p2pclient.Client) <-chan error {
+		done := make(chan error, 1)
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			_, err := c.CallUnaryHandler(ctx, peer1ID, proto, []byte("hi"))
+			done <- err
+		}()
+		return done
+	}
+	inFlight := func(s *p2pclient.PersistentConnStats) bool { return s.ActiveCalls == 1 }
+	pending := func(s *p2pclient.PersistentConnStats) bool { return s.PendingResponses == 1 }
+	released := func(s *p2pclient.PersistentConnStats) bool {
+		return s.ActiveCalls == 0 && s.PendingResponses == 0
+	}
+
+	// the caller goes away: its call is cancelled on both daemons
+	callerGone := call(p2)
+	waitForStats(o1, pending)
+	waitForStats(o2, inFlight)
+
+	p2.Close()
+
+	select {
+	case err := <-callerGone:
+		if err != p2pclient.ErrPersistentConnClosed {
+			t.Fatalf("expected the call to fail with ErrPersistentConnClosed, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("call outlived the persistent connection")
+	}
+	waitForStats(o2, released)
+	waitForStats(o1, released)
+
+	done := call(o2)
+	waitForStats(o1, pending)
+	waitForStats(o2, inFlight)
+
+	// the client owning the handler goes away: the call waiting for it fails
+	p1.Close()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatal("call is expected to fail but finished successfully")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("call outlived the connection of the handler")
+	}
+	waitForStats(o1, released)
+	waitForStats(o2, released)
+}
+
+func TestCallGoroutinesExitWithConnection(t *testing.T) {
+	_, p1, cancel1 := createDaemonClientPair(t)
+	defer cancel1()
+	d2, p2, cancel2 := createDaemonClientPair(t)
+	defer cancel2()
+	_, cmaddr, dirCloser := getEndpointsMaker(t)(t)
+	defer dirCloser()
+	caller, closeCaller := createClient(t, d2.Listener().Multiaddr(), cmaddr)
+	defer closeCaller()
+
+	peer1ID, peer1Addrs, err := p1.Identify()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p2.Connect(peer1ID, peer1Addrs); err != nil {
+		t.Fatal(err)
+	}
+
+	var proto protocol.ID = "blocking"
+	unblock := make(chan struct{})
+	if err := p1.AddUnaryHandler(proto, func(ctx context.Context, data []byte) ([]byte, error) {
+		<-unblock
+		return nil, nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// waitForStats polls c until cond holds for its daemon's stats
+	waitForStats := func(c *p2pclient.Client, cond func(*p2pclient.PersistentConnStats) bool) {
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			stats, err := c.GetPersistentConnStats()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cond(stats) {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("unexpected stats: %d active calls, %d pending responses", stats.ActiveCalls, stats.PendingResponses)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		caller.CallUnaryHandler(ctx, peer1ID, proto, []byte("hi"))
+	}()
+
+	waitForStats(p2, func(s *p2pclient.PersistentConnStats) bool { return s.ActiveCalls == 1 })
+	waitForStats(p1, func(s *p2pclient.PersistentConnStats) bool { return s.PendingResponses == 1 })
+
+	// the caller goes away mid-call, then the handler answers a call nobody
+	// waits for anymore
+	caller.Close()
+	close(unblock)
+
+	released := func(s *p2pclient.PersistentConnStats) bool {
+		return s.ActiveCalls == 0 && s.PendingResponses == 0
+	}
+	waitForStats(p2, released)
+	waitForStats(p1, released)
+}
+
+func float64FromBytes(bytes []byte) float64 {
+	bits := binary.LittleEndian.Uint64(bytes)
+	float := math.Float64frombits(bits)
+	return float
+}
+
+func float64Bytes(float float64) []byte {
+	bits := math.Float64bits(float)
+	bytes := make([]byte, 8)
+	binary.LittleEndian.PutUint64(bytes, bits)
+	return bytes
+}
+
+func slowHandler(ctx context.Context, data []byte) ([]byte, error) {
+	time.Sleep(time.Second * 3)
+	return nil, nil
+}
+
+func sqrtHandler(ctx context.Context, data []byte) ([]byte, error) {
+	f := float64FromBytes(data)
+	if f < 0 {
+		return nil, fmt.Errorf("can't extract square root from negative")
+	}
+
+	result := math.Sqrt(f)
+	return float64Bytes(result), nil
+}
+
+// Je reprends mon bien où je le trouve
+// https://stackoverflow.com/questions/47969385/go-float-comparison
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) <= 1e-9
+}
+
+func TestDaemonInstanceID(t *testing.T) {
+	d1, c1, closer1 := createDaemonClientPair(t)
+	defer closer1()
+	d2, c2, closer2 := createDaemonClientPair(t)
+	defer closer2()
+
+	if d1.InstanceID() == d2.InstanceID() {
+		t.Fatal("expected daemon instances to have distinct ids")
+	}
+	if id := c1.DaemonInstanceID(); id != d1.InstanceID() {
+		t.Fatalf("expected the client to get the daemon's instance id %s, got %s", d1.InstanceID(), id)
+	}
+	if id := c2.DaemonInstanceID(); id != d2.InstanceID() {
+		t.Fatalf("expected the client to get the daemon's instance id %s, got %s", d2.InstanceID(), id)
+	}
+}
+
+func TestRetryUnaryDials(t *testing.T) {
+	dmaddr, cmaddr, dirCloser := getEndpointsMaker(t)(t)
+	defer dirCloser()
+
+	gater := p2pd.NewConnectionGater()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d1, err := p2pd.NewDaemon(ctx, []ma.Multiaddr{dmaddr}, "",
+		libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"),
+		libp2p.ConnectionGater(gater),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d1.SetConnectionGater(gater)
+	go d1.Serve()
+
+	p1, closeClient := createClient(t, d1.Listener().Multiaddr(), cmaddr)
+	defer closeClient()
+
+	d2, p2, cancel2 := createDaemonClientPair(t)
+	defer cancel2()
+
+	var proto protocol.ID = "echo"
+	echo := func(ctx context.Context, data []byte) ([]byte, error) {
+		return data, nil
+	}
+	if err := p1.AddUnaryHandler(proto, echo); err != nil {
+		t.Fatal(err)
+	}
+
+	// a failed dial puts the addresses of d1 in backoff for d2
+	if err := p1.SetPeerLists(nil, []peer.ID{d2.ID()}); err != nil {
+		t.Fatal(err)
+	}
+	if err := p2.Connect(d1.ID(), d1.Addrs()); err == nil {
+		t.Fatal("expected the connection from a blocked peer to be denied")
+	}
+	if err := p1.SetPeerLists(nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	var daemonError *p2pclient.DaemonError
+	_, err = p2.CallUnaryHandler(context.Background(), d1.ID(), proto, []byte("hello"))
+	if !errors.As(err, &daemonError) || daemonError.Code() != pb.DaemonError_DIAL_BACKOFF {
+		t.Fatalf("expected a call to a peer in backoff to fail with DIAL_BACKOFF, got %v", err)
+	}
+
+	d2.SetRetryUnaryDials(true)
+
+	res, err := p2.CallUnaryHandler(context.Background(), d1.ID(), proto, []byte("hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(res) != "hello" {
+		t.Fatalf("expected \"hello\", got %s", res)
+	}
+}
+
+func TestNegotiationTimeout(t *testing.T) {
+	d1, p1, cancel1 := createDaemonClientPair(t)
+	defer cancel1()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// a peer that accepts streams but never negotiates them, identify's
+	// included
+	stalled, err := libp2p.New(ctx, libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stalled.Close()
+	stall := make(chan struct{})
+	defer close(stall)
+	stalled.Network().SetStreamHandler(func(s network.Stream) {
+		<-stall
+		s.Reset()
+	})
+
+	// connecting waits for identify, which the peer never answers, so it
+	// times out; the addresses are kept for the call
+	p1.ConnectWithOptions(stalled.ID(), stalled.Addrs(), p2pclient.ConnectOptions{
+		AddrTTL: time.Minute,
+		Timeout: time.Second,
+	})
+
+	d1.SetNegotiationTimeout(200 * time.Millisecond)
+
+	callCtx, callCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer callCancel()
+
+	start := time.Now()
+	_, err = p1.CallUnaryHandler(callCtx, stalled.ID(), "stalled", []byte("hello"))
+	var daemonError *p2pclient.DaemonError
+	if !errors.As(err, &daemonError) || daemonError.Code() != pb.DaemonError_NEGOTIATION_TIMEOUT {
+		t.Fatalf("expected the call to fail with NEGOTIATION_TIMEOUT, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("expected the call to fail fast, took %s", elapsed)
+	}
+}
+
+func TestLoopbackCalls(t *testing.T) {
+	d, c, closer := createDaemonClientPair(t)
+	defer closer()
+
+	var proto protocol.ID = "echo"
+	echo := func(ctx context.Context, data []byte) ([]byte, error) {
+		return data, nil
+	}
+	if err := c.AddUnaryHandler(proto, echo); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.CallUnaryHandler(context.Background(), d.ID(), proto, []byte("hello")); err == nil {
+		t.Fatal("expected a call to the daemon itself to fail")
+	}
+
+	d.SetLoopbackCalls(true)
+
+	res, err := c.CallUnaryHandler(context.Background(), d.ID(), proto, []byte("hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(res) != "hello" {
+		t.Fatalf("expected \"hello\", got %s", res)
+	}
+
+	var daemonError *p2pclient.DaemonError
+	_, err = c.CallUnaryHandler(context.Background(), d.ID(), "missing", []byte("hello"))
+	if !errors.As(err, &daemonError) || daemonError.Code() != pb.DaemonError_PROTOCOL_NOT_SUPPORTED {
+		t.Fatalf("expected a loopback call without a handler to fail with PROTOCOL_NOT_SUPPORTED, got %v", err)
+	}
+}
+
+func BenchmarkLoopbackUnaryCall(b *testing.B) {
+	d, c, closer := createDaemonClientPair(b)
+	defer closer()
+
+	var proto protocol.ID = "echo"
+	echo := func(ctx context.Context, data []byte) ([]byte, error) {
+		return data, nil
+	}
+	if err := c.AddUnaryHandler(proto, echo); err != nil {
+		b.Fatal(err)
+	}
+
+	d.SetLoopbackCalls(true)
+
+	payload := make([]byte, 1<<10)
+	b.SetBytes(int64(len(payload)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := c.CallUnaryHandler(context.Background(), d.ID(), proto, payload); err != nil {
+			b.Fatal(err)
+		}
+	}
 }
@@ -0,0 +1,50 @@
+package test
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"testing"
+
+	p2pd "github.com/libp2p/go-libp2p-daemon"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+func TestUnixSocketPermissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("unix socket permissions are not supported on windows")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// a group the process is in, which it may give the socket to
+	ctx = p2pd.WithUnixSocket(ctx, 0600, -1, os.Getgid(), 4)
+
+	daemonPath, clientPath, dirCloser := createTempDir(t)
+	defer dirCloser()
+	d, err := p2pd.NewDaemon(ctx, []ma.Multiaddr{ma.StringCast("/unix" + daemonPath)}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go d.Serve()
+
+	info, err := os.Stat(daemonPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode()&os.ModeSocket == 0 || info.Mode().Perm() != 0600 {
+		t.Fatalf("expected a socket with mode 0600, got %s", info.Mode())
+	}
+
+	c, closeClient := createClient(t, d.Listener().Multiaddr(), ma.StringCast("/unix"+clientPath))
+	defer closeClient()
+	if _, _, err := c.Identify(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the socket is removed on close, as with the default listener
+	d.Close()
+	if _, err := os.Stat(daemonPath); !os.IsNotExist(err) {
+		t.Fatalf("expected the socket to be removed, got %v", err)
+	}
+}
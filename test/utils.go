@@ -10,7 +10,9 @@ import (
 	"runtime"
 	"testing"
 
+	"github.com/libp2p/go-libp2p"
 	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/host"
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/libp2p/go-libp2p-core/test"
 
@@ -20,11 +22,12 @@ import (
 	p2pd "github.com/libp2p/go-libp2p-daemon"
 	"github.com/libp2p/go-libp2p-daemon/p2pclient"
 	pb "github.com/libp2p/go-libp2p-daemon/pb"
+	dht "github.com/libp2p/go-libp2p-kad-dht"
 	ma "github.com/multiformats/go-multiaddr"
 	mh "github.com/multiformats/go-multihash"
 )
 
-func createTempDir(t *testing.T) (string, string, func()) {
+func createTempDir(t testing.TB) (string, string, func()) {
 	root := os.TempDir()
 	dir, err := ioutil.TempDir(root, "p2pd")
 	if err != nil {
@@ -38,9 +41,9 @@ func createTempDir(t *testing.T) (string, string, func()) {
 	return daemonPath, clientPath, closer
 }
 
-func createDaemon(t *testing.T, daemonAddr ma.Multiaddr) (*p2pd.Daemon, func()) {
+func createDaemon(t testing.TB, daemonAddr ma.Multiaddr) (*p2pd.Daemon, func()) {
 	ctx, cancelCtx := context.WithCancel(context.Background())
-	daemon, err := p2pd.NewDaemon(ctx, daemonAddr, "")
+	daemon, err := p2pd.NewDaemon(ctx, []ma.Multiaddr{daemonAddr}, "")
 	daemon.EnablePubsub("gossipsub", false, false)
 	if err != nil {
 		t.Fatal(err)
@@ -51,7 +54,20 @@ func createDaemon(t *testing.T, daemonAddr ma.Multiaddr) (*p2pd.Daemon, func())
 	return daemon, cancelCtx
 }
 
-func createClient(t *testing.T, daemonAddr ma.Multiaddr, clientAddr ma.Multiaddr) (*p2pclient.Client, func()) {
+// createDHTHost starts a host listening on localhost and running a DHT
+// server.
+func createDHTHost(t *testing.T, ctx context.Context) host.Host {
+	h, err := libp2p.New(ctx, libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dht.New(ctx, h, dht.Mode(dht.ModeServer)); err != nil {
+		t.Fatal(err)
+	}
+	return h
+}
+
+func createClient(t testing.TB, daemonAddr ma.Multiaddr, clientAddr ma.Multiaddr) (*p2pclient.Client, func()) {
 	client, err := p2pclient.NewClient(daemonAddr, clientAddr)
 	if err != nil {
 		t.Fatal(err)
@@ -62,7 +78,7 @@ func createClient(t *testing.T, daemonAddr ma.Multiaddr, clientAddr ma.Multiaddr
 	return client, closer
 }
 
-func createDaemonClientPair(t *testing.T) (*p2pd.Daemon, *p2pclient.Client, func()) {
+func createDaemonClientPair(t testing.TB) (*p2pd.Daemon, *p2pclient.Client, func()) {
 	dmaddr, cmaddr, dirCloser := getEndpointsMaker(t)(t)
 	daemon, closeDaemon := createDaemon(t, dmaddr)
 	client, closeClient := createClient(t, daemon.Listener().Multiaddr(), cmaddr)
@@ -75,9 +91,9 @@ func createDaemonClientPair(t *testing.T) (*p2pd.Daemon, *p2pclient.Client, func
 	return daemon, client, closer
 }
 
-type makeEndpoints func(t *testing.T) (daemon, client ma.Multiaddr, cleanup func())
+type makeEndpoints func(t testing.TB) (daemon, client ma.Multiaddr, cleanup func())
 
-func makeTcpLocalhostEndpoints(t *testing.T) (daemon, client ma.Multiaddr, cleanup func()) {
+func makeTcpLocalhostEndpoints(t testing.TB) (daemon, client ma.Multiaddr, cleanup func()) {
 	daemon, err := ma.NewMultiaddr("/ip4/127.0.0.1/tcp/0")
 	require.NoError(t, err)
 	client, err = ma.NewMultiaddr("/ip4/127.0.0.1/tcp/0")
@@ -86,7 +102,7 @@ func makeTcpLocalhostEndpoints(t *testing.T) (daemon, client ma.Multiaddr, clean
 	return
 }
 
-func makeUnixEndpoints(t *testing.T) (daemon, client ma.Multiaddr, cleanup func()) {
+func makeUnixEndpoints(t testing.TB) (daemon, client ma.Multiaddr, cleanup func()) {
 	daemonPath, clientPath, cleanup := createTempDir(t)
 	daemon, err := ma.NewComponent("unix", daemonPath)
 	require.NoError(t, err)
@@ -95,7 +111,7 @@ func makeUnixEndpoints(t *testing.T) (daemon, client ma.Multiaddr, cleanup func(
 	return
 }
 
-func getEndpointsMaker(t *testing.T) makeEndpoints {
+func getEndpointsMaker(t testing.TB) makeEndpoints {
 	if runtime.GOOS == "windows" {
 		return makeTcpLocalhostEndpoints
 	} else {
@@ -0,0 +1,72 @@
+package p2pd
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// tracer creates the spans of unary calls. It is a no-op unless a tracer
+// provider is set with otel.SetTracerProvider, which p2pd does when tracing is
+// configured; the trace context of calls is passed on either way.
+var tracer = otel.Tracer("github.com/libp2p/go-libp2p-daemon")
+
+var tracePropagator = propagation.TraceContext{}
+
+// callTraceCarrier carries a W3C trace context in the trace fields of a unary
+// call request.
+type callTraceCarrier struct {
+	call *pb.CallUnaryRequest
+}
+
+func (c callTraceCarrier) Get(key string) string {
+	switch key {
+	case "traceparent":
+		return c.call.GetTraceParent()
+	case "tracestate":
+		return c.call.GetTraceState()
+	}
+	return ""
+}
+
+func (c callTraceCarrier) Set(key, value string) {
+	switch key {
+	case "traceparent":
+		c.call.TraceParent = &value
+	case "tracestate":
+		c.call.TraceState = &value
+	}
+}
+
+func (c callTraceCarrier) Keys() []string {
+	return []string{"traceparent", "tracestate"}
+}
+
+// startCallSpan starts a span as a child of the span call was made from, if
+// it carries a trace context, and has call carry the new span's context
+// instead, for whoever it is passed on to.
+func startCallSpan(ctx context.Context, name string, kind trace.SpanKind, call *pb.CallUnaryRequest, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	ctx = tracePropagator.Extract(ctx, callTraceCarrier{call})
+
+	attrs = append(attrs, attribute.String("p2pd.protocol", call.GetProto()))
+	ctx, span := tracer.Start(ctx, name, trace.WithSpanKind(kind), trace.WithAttributes(attrs...))
+
+	tracePropagator.Inject(ctx, callTraceCarrier{call})
+	return ctx, span
+}
+
+// endCallSpan ends the span of a call with its result, as recorded by
+// unaryCallDuration.
+func endCallSpan(span trace.Span, result string) {
+	span.SetAttributes(attribute.String("p2pd.result", result))
+	if result != unaryCallSucceeded {
+		span.SetStatus(codes.Error, result)
+	}
+	span.End()
+}
@@ -19,8 +19,11 @@ func (d *Daemon) trapSignals() {
 			case syscall.SIGUSR1:
 				d.handleSIGUSR1()
 			case syscall.SIGINT, syscall.SIGTERM:
-				d.Close()
-				os.Exit(0x80 + int(s.(syscall.Signal)))
+				log.Infow("shutting down", "signal", s)
+				if err := d.Shutdown(d.defaultGracePeriod()); err != nil {
+					log.Errorw("error shutting down", "error", err)
+				}
+				return
 			default:
 				log.Warnw("uncaught signal", "signal", s)
 			}
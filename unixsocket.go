@@ -0,0 +1,41 @@
+package p2pd
+
+import (
+	"context"
+	"os"
+
+	ma "github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+)
+
+// unixSocketOptions are the mode, owner and backlog of the unix control
+// sockets.
+type unixSocketOptions struct {
+	mode     os.FileMode
+	uid, gid int
+	backlog  int
+}
+
+type unixSocketOptionsKey struct{}
+
+// WithUnixSocket returns a context for NewDaemon that has the unix control
+// sockets get mode, uid and gid, and listen with backlog. The mode is left to
+// the umask if zero, the owner and group to the process if negative, and the
+// backlog is the system default if zero.
+func WithUnixSocket(ctx context.Context, mode os.FileMode, uid, gid, backlog int) context.Context {
+	return context.WithValue(ctx, unixSocketOptionsKey{}, unixSocketOptions{mode, uid, gid, backlog})
+}
+
+// listenControl listens on a control address. Unix sockets get the mode,
+// owner and backlog set on ctx with WithUnixSocket, if any.
+func listenControl(ctx context.Context, maddr ma.Multiaddr) (manet.Listener, error) {
+	c, _ := ma.SplitFirst(maddr)
+	if c == nil || c.Protocol().Code != ma.P_UNIX {
+		return manet.Listen(maddr)
+	}
+	opts, ok := ctx.Value(unixSocketOptionsKey{}).(unixSocketOptions)
+	if !ok || opts.mode == 0 && opts.uid < 0 && opts.gid < 0 && opts.backlog == 0 {
+		return manet.Listen(maddr)
+	}
+	return listenUnix(c.Value(), opts)
+}
@@ -0,0 +1,13 @@
+// +build windows plan9 nacl js
+
+package p2pd
+
+import (
+	"errors"
+
+	manet "github.com/multiformats/go-multiaddr/net"
+)
+
+func listenUnix(path string, opts unixSocketOptions) (manet.Listener, error) {
+	return nil, errors.New("unix socket mode, owner and backlog are not supported on this platform")
+}
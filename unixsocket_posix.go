@@ -0,0 +1,60 @@
+// +build !windows,!plan9,!nacl,!js
+
+package p2pd
+
+import (
+	"net"
+	"os"
+	"syscall"
+
+	manet "github.com/multiformats/go-multiaddr/net"
+)
+
+// listenUnix creates the unix socket at path by hand, so that the mode and
+// owner of opts are set before it listens: no client can connect in the meantime.
+func listenUnix(path string, opts unixSocketOptions) (manet.Listener, error) {
+	fd, err := syscall.Socket(syscall.AF_UNIX, syscall.SOCK_STREAM, 0)
+	if err != nil {
+		return nil, os.NewSyscallError("socket", err)
+	}
+	syscall.CloseOnExec(fd)
+
+	if err := syscall.Bind(fd, &syscall.SockaddrUnix{Name: path}); err != nil {
+		syscall.Close(fd)
+		return nil, &net.OpError{Op: "listen", Net: "unix", Addr: &net.UnixAddr{Name: path, Net: "unix"}, Err: os.NewSyscallError("bind", err)}
+	}
+
+	fail := func(err error) (manet.Listener, error) {
+		syscall.Close(fd)
+		os.Remove(path)
+		return nil, err
+	}
+	if opts.mode != 0 {
+		if err := os.Chmod(path, opts.mode); err != nil {
+			return fail(err)
+		}
+	}
+	if opts.uid >= 0 || opts.gid >= 0 {
+		if err := os.Chown(path, opts.uid, opts.gid); err != nil {
+			return fail(err)
+		}
+	}
+
+	backlog := opts.backlog
+	if backlog <= 0 {
+		backlog = syscall.SOMAXCONN
+	}
+	if err := syscall.Listen(fd, backlog); err != nil {
+		return fail(os.NewSyscallError("listen", err))
+	}
+
+	// the listener works on a duplicate of the descriptor
+	f := os.NewFile(uintptr(fd), path)
+	defer f.Close()
+	l, err := net.FileListener(f)
+	if err != nil {
+		os.Remove(path)
+		return nil, err
+	}
+	return manet.WrapNetListener(l)
+}